@@ -0,0 +1,140 @@
+package assets
+
+// ASCII characters for map tiles in ASCII mode.
+const (
+	ASCIIWall       = '#'
+	ASCIIFloor      = '.'
+	ASCIIDoor       = '+'
+	ASCIIStairsDown = '>'
+	ASCIIStairsUp   = '<'
+	ASCIIChute      = '^'
+	ASCIIGrass      = '"'
+	ASCIIWater      = '~'
+	ASCIISanctuary  = ':' // floor of a sanctuary room
+)
+
+// ASCII characters for entities with no entry in asciiGlyphs.
+const (
+	ASCIIPlayer    = '@' // every player, whatever their class emoji
+	ASCIINPC       = '@'
+	ASCIIFurniture = '&'
+	ASCIIUnknown   = '?'
+)
+
+// asciiGlyphs translates entity and item emoji to a single ASCII character
+// for terminals that cannot draw emoji. Enemies are letters, capitals for the
+// bigger threats; items follow the usual roguelike classes: ! drinks, ? scrolls,
+// / wands, ) weapons, [ armour, = off-hand gear and * other curios.
+// Chronoliths glyphs that reuse a main-dungeon emoji share its character.
+var asciiGlyphs = map[string]rune{
+	// Enemies, floors 1-5.
+	GlyphCrystalCrawl: 'c',
+	GlyphNeonSpecter:  's',
+	GlyphPrismDrake:   'D',
+	GlyphVoidTendril:  'w',
+	GlyphThoughtLeech: 'l',
+	GlyphFractalGolem: 'G',
+	GlyphEntropyBloom: 'b',
+	GlyphApexWarden:   'W',
+	GlyphBroodMatron:  'M',
+	GlyphGlimmerMite:  'm',
+	GlyphLumenOoze:    'o',
+	// Enemies, floors 6-10.
+	GlyphToxinSpore:      'x',
+	GlyphTideWraith:      'k',
+	GlyphMembraneLurker:  'S',
+	GlyphOssifiedScholar: 'z',
+	GlyphArchiveWarden:   'K',
+	GlyphCinderWraith:    'f',
+	GlyphForgeGolem:      'g',
+	GlyphDreamStalker:    'n',
+	GlyphPsychicEcho:     'e',
+	GlyphCrystalRevenant: 'r',
+	GlyphUnmaker:         'U',
+	GlyphResonanceCantor: 'C',
+	GlyphMirrorEcho:      'h',
+	// Floor elites.
+	GlyphShardmind:        'A',
+	GlyphSporeTyrant:      'F',
+	GlyphGearRevenant:     'R',
+	GlyphPrismSpecter:     'P',
+	GlyphTendrilOvermind:  'V',
+	GlyphMembraneHorror:   'Y',
+	GlyphPetrifiedScholar: 'L',
+	GlyphMagmaRevenant:    'E',
+	GlyphSomnivore:        'N',
+	GlyphPrismaticHorror:  'X',
+	// Temporal Ruins enemies.
+	GlyphTimeBeetle:    'a',
+	GlyphTemporalSpark: 'p',
+	GlyphWarFragment:   'u',
+	GlyphRustedWarden:  'd',
+	GlyphTheRecursion:  'Q',
+	GlyphAmberKeeper:   'B',
+	GlyphFrozenCaptain: 'J',
+	GlyphLoopGuardian:  'I',
+	GlyphBreachWatcher: 'i',
+	GlyphClockPriest:   't',
+	GlyphParadoxKnot:   'v',
+	GlyphGeneralSeven:  'Z',
+	GlyphEpochGuardian: 'y',
+	// Consumables.
+	GlyphHyperflask:     '!',
+	GlyphSporeDraught:   '!',
+	GlyphTempoTonic:     '!',
+	GlyphNanoSyringe:    '!',
+	GlyphMemoryScroll:   '?',
+	GlyphNullCloak:      '?',
+	GlyphPrismShard:     '*',
+	GlyphTesseract:      '*',
+	GlyphResonanceCoil:  '*',
+	GlyphPrismaticWard:  '*',
+	GlyphVoidEssence:    '*',
+	GlyphResonanceBurst: '*',
+	GlyphApexCore:       '*',
+	GlyphSnareKit:       '(',
+	GlyphCaltropPouch:   '(',
+	GlyphDecoyDoll:      '(',
+	GlyphPhaseRod:       '/',
+	GlyphBindingWand:    '/',
+	GlyphLightningWand:  '/',
+	// Equipment.
+	GlyphCrystalHelm:       '[',
+	GlyphVoidCrown:         '[',
+	GlyphResonanceCowl:     '[',
+	GlyphFrostWeave:        '[',
+	GlyphPrismaticPlate:    '[',
+	GlyphCalcifiedCarapace: '[',
+	GlyphFluxTreads:        '[',
+	GlyphForgeBoots:        '[',
+	GlyphMembraneWalkers:   '[',
+	GlyphShardBlade:        ')',
+	GlyphTendrilWhip:       ')',
+	GlyphEchoCutter:        ')',
+	GlyphResonanceMaul:     ')',
+	GlyphAbyssalCleaver:    ')',
+	GlyphPhaseMirror:       '=',
+	GlyphPowerCell:         '=',
+	GlyphChronoBand:        '=',
+	// Map features.
+	GlyphGoldPile:       '$',
+	GlyphVendingMachine: '&',
+	GlyphCorpse:         '%',
+	GlyphChest:          '~',
+	GlyphAltar:          '_',
+	GlyphTurret:         'T',
+	GlyphAcidPool:       ';',
+	GlyphSporeCloud:     ';',
+	GlyphEmbers:         ';',
+	GlyphStairsDown:     ASCIIStairsDown,
+	GlyphStairsUp:       ASCIIStairsUp,
+	GlyphDoor:           ASCIIDoor,
+	"📝":                 '|', // wall inscription
+}
+
+// ASCIIGlyph returns the ASCII character for glyph, and false when it has no
+// entry of its own.
+func ASCIIGlyph(glyph string) (rune, bool) {
+	r, ok := asciiGlyphs[glyph]
+	return r, ok
+}
@@ -0,0 +1,41 @@
+package assets
+
+import "emoji-roguelike/internal/generate"
+
+// ChampionChance is the percent chance that an ordinary enemy spawns as a
+// champion with one of ChampionMods. Elites, bosses, minions and split
+// copies never do.
+const ChampionChance = 6
+
+// ChampionMod is a champion modifier: percentage boosts to an enemy's stats,
+// extra chance of a loot pool drop, and the prefix that names it.
+type ChampionMod struct {
+	Prefix    string
+	HPPct     int
+	ATKPct    int
+	DEFPct    int
+	PoolBonus int
+}
+
+// ChampionMods is the table champion modifiers are drawn from.
+var ChampionMods = []ChampionMod{
+	{Prefix: "Vicious", HPPct: 25, ATKPct: 50, PoolBonus: 40},
+	{Prefix: "Hulking", HPPct: 100, PoolBonus: 40},
+	{Prefix: "Armoured", HPPct: 25, DEFPct: 100, PoolBonus: 40},
+	{Prefix: "Ancient", HPPct: 50, ATKPct: 25, DEFPct: 50, PoolBonus: 60},
+}
+
+// Apply returns entry with the modifier's stat boosts. Every boost is at
+// least one point, so even a 0-DEF enemy gains armour.
+func (m ChampionMod) Apply(entry generate.EnemySpawnEntry) generate.EnemySpawnEntry {
+	boost := func(v, pct int) int {
+		if pct == 0 {
+			return v
+		}
+		return v + max(v*pct/100, 1)
+	}
+	entry.MaxHP = boost(entry.MaxHP, m.HPPct)
+	entry.Attack = boost(entry.Attack, m.ATKPct)
+	entry.Defense = boost(entry.Defense, m.DEFPct)
+	return entry
+}
@@ -1,7 +1,7 @@
 package assets
 
 // NPCDef describes a non-player character for city placement.
-// Kind matches the component.NPCKind constants (0=Dialogue, 1=Healer, 2=Shop, 3=Animal).
+// Kind matches the component.NPCKind constants (0=Dialogue, 1=Healer, 2=Shop, 3=Animal, 4=Smith).
 type NPCDef struct {
 	Glyph string
 	Name  string
@@ -19,6 +19,9 @@ type ShopEntry struct {
 	BonusDEF     int
 	BonusMaxHP   int
 	Slot         string // "" for consumables; "head","body","feet","onehand","offhand" for equipment
+	Repair       bool   // a smith's repair job on an equipped item, not an item for sale
+	Limited      bool   // can sell out: Stock is the units left, as in the MUD city shop
+	Stock        int
 }
 
 // CityNPCs lists the named human NPCs of Emberveil.
@@ -130,6 +133,16 @@ var CityNPCs = []NPCDef{
 			"The Flame burns for everyone, even those in the dark.",
 		},
 	},
+	{
+		Glyph: "⚒️",
+		Name:  "Smith Hild",
+		Kind:  4, // NPCKindSmith
+		Lines: []string{
+			"Bring me what the tower chewed up. I'll make it bite back.",
+			"Nothing's beyond mending. Except the fools who wait until it snaps.",
+			"Your gear's sound. Come back when it isn't.",
+		},
+	},
 }
 
 // CityAnimals lists the animals of Emberveil.
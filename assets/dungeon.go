@@ -72,6 +72,98 @@ func BossGlyph(floor int) string {
 	return ""
 }
 
+// infightingFloors lists the floors where constructs and organics share the
+// roster and fight each other when no player is near.
+var infightingFloors = map[int]bool{3: true, 4: true, 5: true}
+
+// Infighting reports whether enemies of rival factions attack each other on
+// a floor. It is off unless the floor opts in.
+func Infighting(floor int) bool { return infightingFloors[floor] }
+
+// Chute tuning.
+const (
+	chuteChance     = 15 // percent of eligible floors that get a chute
+	ChuteFallDamage = 8  // damage taken on landing, never lethal
+	ChuteWarning    = "Scratched beside the shaft: THE DROP SKIPS A FLOOR. SO DID MY LEGS."
+)
+
+// ChuteChance returns the 0–100 chance that a floor gets a chute. Only
+// dungeon floors with another floor two levels below them are eligible.
+func ChuteChance(floor int) int {
+	if df := DungeonFloor(floor); df < 1 || floor+2 > DungeonMaxFloor(floor) {
+		return 0
+	}
+	return chuteChance
+}
+
+// Active runes are inscriptions that fire an effect when read. Every kind
+// shows GlyphRune on the map; only the carving hints at what it does.
+const (
+	GlyphRune      = "🔯"
+	RuneWardText   = "A rune of warding, cut deep and clean: ᛉ. Your skin prickles and hardens."
+	RuneFuryText   = "A jagged rune of fury: ᛏ. Your pulse quickens."
+	RuneHexText    = "A crooked rune, smeared as if in haste: ᚦ. It was not meant for you."
+	RuneRiddleText = "I pay the one who asks for nothing and goes nowhere. Stand upon me, and be still."
+)
+
+// PuzzleDef describes a floor's sealed vault: the glyph tiles that open it,
+// in the order they must be walked, the inscription hinting at that order,
+// and the gold waiting inside.
+type PuzzleDef struct {
+	Glyphs []string
+	Hint   string
+	Gold   int
+}
+
+// puzzles maps a dungeon floor to its vault puzzle.
+var puzzles = map[int]PuzzleDef{
+	4: { // Fractured Observatory
+		Glyphs: []string{"🌑", "🌓", "🌕", "🌗"},
+		Hint:   "Etched on a cracked lens housing: THE VAULT OPENS FOR ONE WHO WALKS THE MOON AS SHE WAXES, THEN AS SHE WANES.",
+		Gold:   40,
+	},
+	9: { // The Dreaming Cortex
+		Glyphs: []string{"😴", "💭", "🌀", "🌅"},
+		Hint:   "Scrawled in a sleeper's hand: FIRST YOU SLEEP. THEN YOU DREAM. THEN YOU FALL. THEN, AT LAST, YOU WAKE.",
+		Gold:   90,
+	},
+}
+
+// PuzzleFor returns the vault puzzle for an absolute floor number. Floors
+// without one, including every Chronoliths floor, get a zero PuzzleDef.
+func PuzzleFor(floor int) PuzzleDef { return puzzles[floor] }
+
+// RuneCount returns how many active runes a dungeon floor gets: one, plus
+// one more from floor 6 on. The city gets none.
+func RuneCount(floor int) int {
+	df := DungeonFloor(floor)
+	switch {
+	case df < 1:
+		return 0
+	case df < 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Sanctuary tuning. A sanctuary is a room enemies will not enter, where
+// players regain 1 HP every SanctuaryRegenEvery turns on top of any class
+// regeneration.
+const (
+	sanctuaryChance     = 20 // percent of dungeon floors that get a sanctuary
+	SanctuaryRegenEvery = 2
+)
+
+// SanctuaryChance returns the 0–100 chance that a floor gets a sanctuary.
+// The city never does; it is safe already.
+func SanctuaryChance(floor int) int {
+	if DungeonFloor(floor) < 1 {
+		return 0
+	}
+	return sanctuaryChance
+}
+
 // EnemyTable returns the enemy spawn table for a floor.
 func EnemyTable(floor int) []generate.EnemySpawnEntry {
 	df := DungeonFloor(floor)
@@ -125,6 +217,20 @@ func FloorLoreSnippets(floor int) []string {
 	return nil
 }
 
+// LingerLine returns the line floor speaks at linger stage (1 for the
+// first): its LingerLore for that stage, or else one of its
+// FloorLoreSnippets in turn. Returns "" for a floor with no lore.
+func LingerLine(floor, stage int) string {
+	if lines := LingerLore[floor]; !IsChronoliths(floor) && stage >= 1 && stage <= len(lines) {
+		return lines[stage-1]
+	}
+	snippets := FloorLoreSnippets(floor)
+	if len(snippets) == 0 || stage < 1 {
+		return ""
+	}
+	return snippets[(stage-1)%len(snippets)]
+}
+
 // WallWritingsFor returns the inscription texts for a floor.
 func WallWritingsFor(floor int) []string {
 	df := DungeonFloor(floor)
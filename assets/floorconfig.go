@@ -0,0 +1,100 @@
+package assets
+
+import (
+	"errors"
+	"fmt"
+
+	"emoji-roguelike/internal/generate"
+)
+
+// MinLeafSize is the smallest BSP leaf the generator will carve a room into.
+// Every floor's MaxLeafSize must be at least this large.
+const MinLeafSize = 8
+
+// Smallest map a floor may be configured with: anything tighter cannot fit
+// a pair of rooms and the corridor between them.
+const (
+	MinFloorWidth  = 30
+	MinFloorHeight = 16
+)
+
+// FloorConfig holds the generation tuning for one dungeon floor. It is plain
+// data so a floor can be rebalanced by editing FloorConfigs alone.
+type FloorConfig struct {
+	Width, Height int                    // map size in tiles
+	MaxLeafSize   int                    // BSP leaves split until this small; lower packs in more rooms
+	Corridors     generate.CorridorStyle // how sibling rooms are joined
+	EnemyBudget   int                    // threat points of enemies, before density and NG+ scaling
+	ItemCount     int                    // consumables scattered on the floor
+	EquipCount    int                    // equipment pieces scattered on the floor
+	GoldPiles     int                    // gold piles scattered on the floor
+	GoldPileMax   int                    // most gold in one pile
+	VendingChance int                    // percent chance of a vending machine
+	AffixChance   int                    // percent chance of a random floor affix
+	Dark          bool                   // always cursed with the Darkness affix
+	Hazards       []string               // hazard glyphs slain enemies may leave here; see DeathHazards
+}
+
+// allHazards is the hazard set of a floor where every death hazard lingers.
+var allHazards = []string{GlyphAcidPool, GlyphSporeCloud, GlyphEmbers}
+
+// FloorConfigs is the generation tuning for dungeon floors 1–10, indexed by
+// DungeonFloor; index 0 is unused. Floors grow from a cramped 40×20 warren
+// with a handful of enemies to a sprawling 90×50 maze packed with them.
+var FloorConfigs = [11]FloorConfig{
+	1:  {Width: 40, Height: 20, MaxLeafSize: 20, EnemyBudget: 5, ItemCount: 3, EquipCount: 1, GoldPiles: 2, GoldPileMax: 5, VendingChance: 20, Hazards: allHazards},
+	2:  {Width: 46, Height: 23, MaxLeafSize: 19, EnemyBudget: 11, ItemCount: 4, EquipCount: 1, GoldPiles: 2, GoldPileMax: 6, VendingChance: 20, AffixChance: 20, Hazards: allHazards},
+	3:  {Width: 51, Height: 27, MaxLeafSize: 18, EnemyBudget: 16, ItemCount: 4, EquipCount: 1, GoldPiles: 3, GoldPileMax: 7, VendingChance: 20, AffixChance: 20, Hazards: allHazards},
+	4:  {Width: 57, Height: 30, MaxLeafSize: 17, EnemyBudget: 22, ItemCount: 5, EquipCount: 2, GoldPiles: 3, GoldPileMax: 8, VendingChance: 20, AffixChance: 20, Hazards: allHazards},
+	5:  {Width: 62, Height: 33, MaxLeafSize: 16, EnemyBudget: 27, ItemCount: 5, EquipCount: 2, GoldPiles: 3, GoldPileMax: 9, VendingChance: 20, AffixChance: 20, Hazards: allHazards},
+	6:  {Width: 68, Height: 37, MaxLeafSize: 14, EnemyBudget: 33, ItemCount: 6, EquipCount: 2, GoldPiles: 4, GoldPileMax: 10, VendingChance: 20, AffixChance: 20, Hazards: allHazards},
+	7:  {Width: 73, Height: 40, MaxLeafSize: 13, EnemyBudget: 38, ItemCount: 6, EquipCount: 2, GoldPiles: 4, GoldPileMax: 11, VendingChance: 20, AffixChance: 20, Hazards: allHazards},
+	8:  {Width: 79, Height: 43, MaxLeafSize: 12, EnemyBudget: 44, ItemCount: 7, EquipCount: 3, GoldPiles: 4, GoldPileMax: 12, VendingChance: 20, AffixChance: 20, Hazards: allHazards},
+	9:  {Width: 84, Height: 47, MaxLeafSize: 11, EnemyBudget: 49, ItemCount: 7, EquipCount: 3, GoldPiles: 5, GoldPileMax: 13, VendingChance: 20, AffixChance: 20, Hazards: allHazards},
+	10: {Width: 90, Height: 50, MaxLeafSize: 10, EnemyBudget: 55, ItemCount: 8, EquipCount: 3, GoldPiles: 5, GoldPileMax: 14, VendingChance: 20, AffixChance: 20, Hazards: allHazards},
+}
+
+// FloorConfigFor returns the tuning for floor. Chronoliths floors share the
+// entry of their DungeonFloor, and anything out of range (the city, the
+// tutorial) gets the nearest dungeon floor's.
+func FloorConfigFor(floor int) FloorConfig {
+	return FloorConfigs[min(max(DungeonFloor(floor), 1), len(FloorConfigs)-1)]
+}
+
+// ValidateFloorConfigs reports the first entry of FloorConfigs the generator
+// cannot build, or nil if every floor is sound. Programs call it at startup so
+// a bad edit fails loudly instead of producing a broken floor mid-run.
+func ValidateFloorConfigs() error {
+	for floor := 1; floor < len(FloorConfigs); floor++ {
+		if err := FloorConfigs[floor].validate(); err != nil {
+			return fmt.Errorf("floor %d config: %w", floor, err)
+		}
+	}
+	return nil
+}
+
+// validate reports what is wrong with c, if anything.
+func (c FloorConfig) validate() error {
+	switch {
+	case c.Width < MinFloorWidth || c.Height < MinFloorHeight:
+		return fmt.Errorf("map %d×%d is smaller than %d×%d", c.Width, c.Height, MinFloorWidth, MinFloorHeight)
+	case c.MaxLeafSize < MinLeafSize:
+		return fmt.Errorf("max leaf size %d is below %d", c.MaxLeafSize, MinLeafSize)
+	case c.Corridors > generate.CorridorStraight:
+		return fmt.Errorf("unknown corridor style %d", c.Corridors)
+	case c.EnemyBudget < 0 || c.ItemCount < 0 || c.EquipCount < 0 || c.GoldPiles < 0:
+		return errors.New("negative spawn count")
+	case c.GoldPiles > 0 && c.GoldPileMax < 1:
+		return fmt.Errorf("gold piles hold at most %d gold", c.GoldPileMax)
+	case c.VendingChance < 0 || c.VendingChance > 100:
+		return fmt.Errorf("vending chance %d%% is outside 0–100", c.VendingChance)
+	case c.AffixChance < 0 || c.AffixChance > 100:
+		return fmt.Errorf("affix chance %d%% is outside 0–100", c.AffixChance)
+	}
+	for _, glyph := range c.Hazards {
+		if !isHazardGlyph(glyph) {
+			return fmt.Errorf("unknown hazard %q", glyph)
+		}
+	}
+	return nil
+}
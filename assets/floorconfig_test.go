@@ -0,0 +1,57 @@
+package assets
+
+import "testing"
+
+func TestFloorConfigsValid(t *testing.T) {
+	if err := ValidateFloorConfigs(); err != nil {
+		t.Fatal(err)
+	}
+	if FloorConfigs[1].AffixChance != 0 {
+		t.Error("floor 1 should never be cursed with an affix")
+	}
+}
+
+func TestValidateFloorConfigsCatchesBadEntries(t *testing.T) {
+	saved := FloorConfigs[4]
+	defer func() { FloorConfigs[4] = saved }()
+	for name, edit := range map[string]func(*FloorConfig){
+		"tiny map":         func(c *FloorConfig) { c.Width = 10 },
+		"small leaves":     func(c *FloorConfig) { c.MaxLeafSize = MinLeafSize - 1 },
+		"bad corridors":    func(c *FloorConfig) { c.Corridors = 9 },
+		"negative budget":  func(c *FloorConfig) { c.EnemyBudget = -1 },
+		"empty piles":      func(c *FloorConfig) { c.GoldPileMax = 0 },
+		"vending over 100": func(c *FloorConfig) { c.VendingChance = 101 },
+		"negative affix":   func(c *FloorConfig) { c.AffixChance = -5 },
+		"unknown hazard":   func(c *FloorConfig) { c.Hazards = []string{GlyphLumenOoze} },
+	} {
+		FloorConfigs[4] = saved
+		edit(&FloorConfigs[4])
+		if ValidateFloorConfigs() == nil {
+			t.Errorf("%s: validation passed", name)
+		}
+	}
+}
+
+func TestFloorConfigForSharesDungeonEntries(t *testing.T) {
+	if got := FloorConfigFor(104); got.Width != FloorConfigs[4].Width || got.EnemyBudget != FloorConfigs[4].EnemyBudget {
+		t.Errorf("Chronoliths floor 104 config = %+v; want floor 4's", got)
+	}
+	if got := FloorConfigFor(0); got.Width != FloorConfigs[1].Width {
+		t.Errorf("floor 0 config width = %d; want floor 1's %d", got.Width, FloorConfigs[1].Width)
+	}
+}
+
+func TestDeathHazardFollowsFloorHazardSet(t *testing.T) {
+	saved := FloorConfigs[3].Hazards
+	defer func() { FloorConfigs[3].Hazards = saved }()
+	if _, ok := DeathHazard(3, GlyphLumenOoze); !ok {
+		t.Fatal("a Lumen Ooze should leave an acid pool on floor 3")
+	}
+	FloorConfigs[3].Hazards = []string{GlyphEmbers}
+	if _, ok := DeathHazard(103, GlyphLumenOoze); ok {
+		t.Error("acid pool left on a floor whose hazard set omits it")
+	}
+	if def, ok := DeathHazard(3, GlyphCinderWraith); !ok || def.Glyph != GlyphEmbers {
+		t.Errorf("DeathHazard(3, Cinder Wraith) = %+v, %v; want embers", def, ok)
+	}
+}
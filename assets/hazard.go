@@ -0,0 +1,40 @@
+package assets
+
+import "slices"
+
+// HazardDef describes a lingering hazard: at the end of each of its Turns
+// turns it deals Damage, ignoring defense, to whatever stands in it.
+type HazardDef struct {
+	Glyph  string
+	Name   string
+	Damage int
+	Turns  int
+}
+
+// DeathHazards maps an enemy glyph to the hazard it leaves on the tile where
+// it dies, so a fight's last blow can still shape where everyone stands.
+var DeathHazards = map[string]HazardDef{
+	GlyphLumenOoze:    {Glyph: GlyphAcidPool, Name: "acid pool", Damage: 1, Turns: 5},
+	GlyphToxinSpore:   {Glyph: GlyphSporeCloud, Name: "spore cloud", Damage: 2, Turns: 4},
+	GlyphCinderWraith: {Glyph: GlyphEmbers, Name: "embers", Damage: 3, Turns: 4},
+}
+
+// DeathHazard returns the hazard the enemy with glyph leaves where it dies on
+// floor, if that floor's FloorConfig lets it linger there.
+func DeathHazard(floor int, glyph string) (HazardDef, bool) {
+	def, ok := DeathHazards[glyph]
+	if !ok || !slices.Contains(FloorConfigFor(floor).Hazards, def.Glyph) {
+		return HazardDef{}, false
+	}
+	return def, true
+}
+
+// isHazardGlyph reports whether glyph is the glyph of some death hazard.
+func isHazardGlyph(glyph string) bool {
+	for _, def := range DeathHazards {
+		if def.Glyph == glyph {
+			return true
+		}
+	}
+	return false
+}
@@ -2,7 +2,7 @@ package assets
 
 import "emoji-roguelike/internal/generate"
 
-// equipTemplates defines all 16 equipment item templates.
+// equipTemplates defines all 17 equipment item templates.
 // Slot values match component.ItemSlot: 1=Head 2=Body 3=Feet 4=OneHand 5=TwoHand 6=OffHand
 var equipTemplates = []generate.EquipSpawnEntry{
 	// Head
@@ -27,6 +27,26 @@ var equipTemplates = []generate.EquipSpawnEntry{
 	// Off-hand
 	{Glyph: GlyphPhaseMirror, Name: "Phase Mirror", Slot: 6, BaseATK: 0, BaseDEF: 3, BaseMaxHP: 0, ATKScale: 0, DEFScale: 5, HPScale: 0, MinFloor: 2},
 	{Glyph: GlyphPowerCell, Name: "Power Cell", Slot: 6, BaseATK: 2, BaseDEF: 2, BaseMaxHP: 0, ATKScale: 3, DEFScale: 3, HPScale: 0, MinFloor: 5},
+	{Glyph: GlyphChronoBand, Name: "Chrono Band", Slot: 6, BaseATK: 0, BaseDEF: 1, BaseMaxHP: 0, ATKScale: 0, DEFScale: 2, HPScale: 0, CDRPercent: 25, MinFloor: 3},
+}
+
+// Equipment durability: a weapon wears a point for each hit it lands, and
+// the wearer's least-worn armor a point for each hit taken.
+const (
+	WeaponDurability = 60
+	ArmorDurability  = 50
+)
+
+// Durability returns the starting durability of equipment in slot, which
+// uses the component.ItemSlot values above; consumables (slot 0) have none.
+func Durability(slot uint8) int {
+	switch slot {
+	case 0:
+		return 0
+	case 4, 5:
+		return WeaponDurability
+	}
+	return ArmorDurability
 }
 
 // EquipTablesForFloor returns all equipment templates available on the given floor.
@@ -40,6 +60,36 @@ func EquipTablesForFloor(floor int) []generate.EquipSpawnEntry {
 	return out
 }
 
+// Treasure chests: every boss and elite leaves one, and its opener keeps one
+// of ChestChoices rewards. The equipment is rolled ChestFloorBonus floors
+// deeper than where the chest fell, and one choice is a ChestConsumables
+// pick.
+const (
+	ChestChoices    = 3
+	ChestFloorBonus = 2
+)
+
+// ChestConsumables are the rare consumables a treasure chest may offer.
+var ChestConsumables = []string{GlyphApexCore, GlyphNanoSyringe, GlyphPrismaticWard, GlyphTempoTonic}
+
+// DropsChest reports whether slaying the enemy with glyph on floor leaves a
+// treasure chest: every floor elite and boss does.
+func DropsChest(glyph string, floor int) bool {
+	return IsEliteGlyph(glyph) || (glyph != "" && glyph == BossGlyph(floor))
+}
+
+// CommonLootPool is the weighted drop pool shared by ordinary enemies. Deeper
+// consumables unlock by floor and rare entries grow more likely with depth.
+var CommonLootPool = []generate.DropEntry{
+	{Glyph: GlyphHyperflask, Weight: 6},
+	{Glyph: GlyphPrismShard, Weight: 3},
+	{Glyph: GlyphMemoryScroll, Weight: 2},
+	{Glyph: GlyphSporeDraught, Weight: 2, MinFloor: 2},
+	{Glyph: GlyphResonanceCoil, Weight: 2, MinFloor: 3},
+	{Glyph: GlyphNanoSyringe, Weight: 1, MinFloor: 5, Rare: true},
+	{Glyph: GlyphPhaseRod, Weight: 1, MinFloor: 6, Rare: true},
+}
+
 // consumableNames maps glyph to human-readable name for all consumable items.
 var consumableNames = map[string]string{
 	GlyphHyperflask:     "Hyperflask",
@@ -55,6 +105,12 @@ var consumableNames = map[string]string{
 	GlyphResonanceBurst: "Resonance Burst",
 	GlyphPhaseRod:       "Phase Rod",
 	GlyphApexCore:       "Apex Core",
+	GlyphTempoTonic:     "Tempo Tonic",
+	GlyphSnareKit:       "Snare Kit",
+	GlyphCaltropPouch:   "Caltrop Pouch",
+	GlyphBindingWand:    "Binding Wand",
+	GlyphLightningWand:  "Lightning Wand",
+	GlyphDecoyDoll:      "Decoy Doll",
 }
 
 // ConsumableName returns the human-readable name for a consumable glyph.
@@ -64,3 +120,30 @@ func ConsumableName(glyph string) string {
 	}
 	return glyph // fallback
 }
+
+// WandDef describes a charged item: how many charges it spawns with and
+// whether it regains one on each new floor.
+type WandDef struct {
+	Charges   int
+	Recharges bool
+}
+
+var wandDefs = map[string]WandDef{
+	GlyphBindingWand:   {Charges: 4},
+	GlyphLightningWand: {Charges: 3, Recharges: true},
+}
+
+// Wand returns the charge definition for glyph, and false if glyph is not a
+// charged item.
+func Wand(glyph string) (WandDef, bool) {
+	def, ok := wandDefs[glyph]
+	return def, ok
+}
+
+// Ritual altar tuning. An altar appears on AltarChance percent of floors with
+// AltarOfferings of the AltarOffering item scattered through other rooms.
+const (
+	AltarChance    = 25
+	AltarOffering  = GlyphPrismShard
+	AltarOfferings = 3
+)
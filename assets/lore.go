@@ -60,6 +60,27 @@ var FloorLore = [11][]string{
 	},
 }
 
+// LingerLore holds the lines some Spire floors speak as a player lingers on
+// them, one per linger stage in order. Floors without an entry, and stages
+// past the end of one, fall back to a FloorLore snippet.
+var LingerLore = map[int][]string{
+	4: { // Fractured Observatory
+		"Somewhere above, a great lens grinds around on its mount. It has found something new to watch.",
+		"Every lens on the floor now points at you. The star charts have started to include your name.",
+		"The Observatory has finished its survey of you. It did not like the results.",
+	},
+	6: { // Membrane of Echoes
+		"Your footsteps begin arriving a moment before you take them.",
+		"The voices have stopped sounding slightly wrong. They now sound exactly like you. That is worse.",
+		"The membrane thins. Things on the other side are pressing their faces to it.",
+	},
+	9: { // The Dreaming Cortex
+		"The Cortex stirs in its sleep. Somewhere, a very large thought rolls over.",
+		"The walls flicker with rapid eye movement. The Cortex is dreaming about you now.",
+		"The Dreaming Cortex wakes. Every thought you have is suddenly being taken very seriously.",
+	},
+}
+
 // WallWritings holds a pool of inscriptions per floor (index 0 unused).
 // 2-5 are chosen at random and placed on floor tiles each run.
 var WallWritings = [11][]string{
@@ -667,10 +688,14 @@ var EnemyLore = map[string]string{
 	GlyphThoughtLeech: "The Thought Leech — feeds on cognition. You feel briefly smarter. Then you feel its absence.",
 	GlyphFractalGolem: "The Fractal Golem — built to last. It outlasted its builders by several geological epochs.",
 	GlyphEntropyBloom: "The Entropy Bloom — chaos given floral form. Its beauty is genuinely impressive, and lethal.",
+	GlyphBroodMatron:  "The Brood Matron — a maintenance hive that never stopped printing workers. The work orders ran out centuries ago.",
+	GlyphGlimmerMite:  "The Glimmer Mite — one of a thousand identical workers. It has never once been thanked.",
+	GlyphLumenOoze:    "The Lumen Ooze — a culture that escaped its dish and kept dividing. Every wound is, to it, an opportunity.",
 	GlyphApexWarden:   "The Apex Warden — a curator turned gatekeeper. Its resignation letter was never filed.",
 	// Floor 6–10 enemies
 	GlyphToxinSpore:       "The Toxin Spore — spores designed to mark dimensional boundaries. It marks you instead.",
 	GlyphTideWraith:       "The Tide Wraith — a current from a sea that doesn't exist yet. Time is flexible here.",
+	GlyphMembraneLurker:   "The Membrane Lurker — it folds itself into the gaps between echoes. By the time you hear it, it has already heard you.",
 	GlyphOssifiedScholar:  "The Ossified Scholar — chose knowledge over mortality, got neither as expected.",
 	GlyphArchiveWarden:    "The Archive Warden — has been protecting these records since before the records existed.",
 	GlyphCinderWraith:     "The Cinder Wraith — the Foundry's quality-control inspector, post-accident.",
@@ -678,6 +703,8 @@ var EnemyLore = map[string]string{
 	GlyphDreamStalker:     "The Dream Stalker — hunts in the space between thoughts. You weren't thinking about it. You were wrong.",
 	GlyphPsychicEcho:      "The Psychic Echo — a memory of something terrible, learning to be terrible again.",
 	GlyphCrystalRevenant:  "The Crystal Revenant — returned from somewhere worse. Doesn't want to go back. Will take yours.",
+	GlyphMirrorEcho:       "The Mirror Echo — the Membrane remembers everyone who passes through. This one remembers you, and has been practising.",
+	GlyphResonanceCantor:  "The Resonance Cantor — it sings the Spire's old maintenance hymns. Everything nearby fights harder to the tune.",
 	GlyphUnmaker:          "The Unmaker — the last question the Spire ever asked. It did not like the answer.",
 	// Floor elites
 	GlyphShardmind:        "The Shardmind — a crystalline intelligence that shattered its own containment to think more freely.",
@@ -691,3 +718,64 @@ var EnemyLore = map[string]string{
 	GlyphSomnivore:        "The Somnivore — it ate the Cortex's dreams and grew vast. It is still hungry. You will do.",
 	GlyphPrismaticHorror:  "The Prismatic Horror — the Heart's immune response to your presence. It has been preparing since you entered Floor 1.",
 }
+
+// Epilogue is a class's closing line on the end screen.
+type Epilogue struct {
+	Victory string
+	Defeat  string
+}
+
+// ClassEpilogues holds each class's end-screen epilogue, keyed by ClassDef.ID.
+var ClassEpilogues = map[string]Epilogue{
+	"arcanist": {
+		Victory: "You fold the Heart into a pocket dimension. It will make a fine lamp.",
+		Defeat:  "Your last rift opens onto nowhere. You step through anyway.",
+	},
+	"revenant": {
+		Victory: "Death came for you at the summit. You sent it back downstairs.",
+		Defeat:  "Death's Bargain comes due. You'll be back. You always are.",
+	},
+	"construct": {
+		Victory: "Mission complete. Timeline Seven logs your retirement as 'final'.",
+		Defeat:  "Systems offline. A spare Construct is already being shipped from Seven.",
+	},
+	"dancer": {
+		Victory: "The Unmaker never saw you coming. Neither did anyone else. Ever.",
+		Defeat:  "You vanish one final time. Entropy keeps the step you missed.",
+	},
+	"oracle": {
+		Victory: "It ended exactly as you foresaw. You still cried a little.",
+		Defeat:  "You saw this coming three floors ago. You came anyway.",
+	},
+	"symbiont": {
+		Victory: "Your parasite purrs, sated on the Heart's light. It has grown fond of you.",
+		Defeat:  "Your parasite slips free, sniffs the air and goes looking for a new host.",
+	},
+	"tinker": {
+		Victory: "The Spire's machines power down one by one, each thanking you politely.",
+		Defeat:  "Your turrets fire a salute to an empty room, then politely shut down.",
+	},
+	"warden": {
+		Victory: "You stood between the Spire and the world. The Spire blinked first.",
+		Defeat:  "You held the line until there was no line left to hold.",
+	},
+}
+
+// defaultEpilogue closes runs of classes with no entry in ClassEpilogues.
+var defaultEpilogue = Epilogue{
+	Victory: "The Unmaker is unmade. The Spire falls silent.",
+	Defeat:  "The Spire keeps what it takes.",
+}
+
+// EpilogueFor returns the end-screen epilogue for a won or lost run as the
+// class classID.
+func EpilogueFor(classID string, won bool) string {
+	e, ok := ClassEpilogues[classID]
+	if !ok {
+		e = defaultEpilogue
+	}
+	if won {
+		return e.Victory
+	}
+	return e.Defeat
+}
@@ -0,0 +1,28 @@
+package assets
+
+import "testing"
+
+func TestEveryClassHasEpilogues(t *testing.T) {
+	for _, c := range Classes {
+		e, ok := ClassEpilogues[c.ID]
+		if !ok || e.Victory == "" || e.Defeat == "" {
+			t.Errorf("class %q has no victory and defeat epilogue", c.ID)
+			continue
+		}
+		// The end screen draws them from column 2, or after a "P1 " label in coop.
+		for _, line := range []string{e.Victory, e.Defeat} {
+			if n := len([]rune(line)); n > 74 {
+				t.Errorf("%s epilogue %q is %d runes; want at most 74 to fit 80 columns", c.ID, line, n)
+			}
+		}
+	}
+}
+
+func TestEpilogueForFallsBackToDefault(t *testing.T) {
+	if got := EpilogueFor("revenant", false); got != ClassEpilogues["revenant"].Defeat {
+		t.Errorf("EpilogueFor(revenant, defeat) = %q", got)
+	}
+	if got := EpilogueFor("no-such-class", true); got != defaultEpilogue.Victory {
+		t.Errorf("EpilogueFor(unknown, victory) = %q; want the default", got)
+	}
+}
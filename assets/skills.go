@@ -55,6 +55,8 @@ var ClassGrowths = map[string]ClassGrowth{
 	"dancer":    {HPPerLevel: 1.5, ATKPer5: 2, DEFPer5: 1},
 	"oracle":    {HPPerLevel: 1.5, ATKPer5: 1, DEFPer5: 1},
 	"symbiont":  {HPPerLevel: 2.5, ATKPer5: 2, DEFPer5: 1},
+	"tinker":    {HPPerLevel: 2.0, ATKPer5: 1, DEFPer5: 2},
+	"warden":    {HPPerLevel: 3.0, ATKPer5: 1, DEFPer5: 2},
 }
 
 // GrowthForLevel returns cumulative (bonusHP, bonusATK, bonusDEF) for reaching
@@ -192,6 +194,18 @@ var ClassSpecs = map[string]map[int][2]SpecDef{
 			{Branch: "B", Name: "Carapace", Description: "Living armor: DEF, HP, thorns"},
 		},
 	},
+	"tinker": {
+		10: {
+			{Branch: "A", Name: "Artificer", Description: "Machine warfare: ATK, faster deploys, kill procs"},
+			{Branch: "B", Name: "Bulwark", Description: "Fortified engineer: DEF, HP, thorns"},
+		},
+	},
+	"warden": {
+		10: {
+			{Branch: "A", Name: "Vanguard", Description: "Frontline punisher: thorns, ATK, kill sustain"},
+			{Branch: "B", Name: "Sentinel", Description: "Immovable guard: DEF, HP, faster challenges"},
+		},
+	},
 }
 
 // ─── Skill Registry ─────────────────────────────────────────────────────────
@@ -335,6 +349,52 @@ var AllSkills = []SkillDef{
 	{ID: "sym_t1b_thorns", Name: "Toxic Spines", Description: "+2 thorns damage", ClassID: "symbiont", Tier: TierAdept, Branch: "B", Kind: SkillPassiveProc, ThornsDamage: 2},
 	{ID: "sym_t1b_regen", Name: "Deep Symbiosis", Description: "-2 regen interval", ClassID: "symbiont", Tier: TierAdept, Branch: "B", Kind: SkillAbilityUpgrade, RegenReduce: 2},
 	{ID: "sym_t1b_def2", Name: "Organic Fortress", Description: "+2 DEF", ClassID: "symbiont", Tier: TierAdept, Branch: "B", Kind: SkillPassiveStat, BonusDEF: 2},
+
+	// ═══ TINKER ═══
+
+	// Tier 0 — Novice
+	{ID: "tin_t0_cd", Name: "Quick Assembly", Description: "-2 ability cooldown", ClassID: "tinker", Tier: TierNovice, Kind: SkillAbilityUpgrade, CooldownReduce: 2},
+	{ID: "tin_t0_def", Name: "Riveted Plating", Description: "+1 DEF", ClassID: "tinker", Tier: TierNovice, Kind: SkillPassiveStat, BonusDEF: 1},
+	{ID: "tin_t0_atk", Name: "Calibrated Strike", Description: "+2 ATK", ClassID: "tinker", Tier: TierNovice, Kind: SkillPassiveStat, BonusATK: 2},
+	{ID: "tin_t0_hp", Name: "Spare Parts", Description: "+3 MaxHP", ClassID: "tinker", Tier: TierNovice, Kind: SkillPassiveStat, BonusMaxHP: 3},
+	{ID: "tin_t0_fov", Name: "Sensor Goggles", Description: "+1 FOV", ClassID: "tinker", Tier: TierNovice, Kind: SkillPassiveStat, BonusFOV: 1},
+
+	// Tier 1 — Adept, Branch A (Artificer)
+	{ID: "tin_t1a_atk", Name: "Overtuned Tools", Description: "+3 ATK", ClassID: "tinker", Tier: TierAdept, Branch: "A", Kind: SkillPassiveStat, BonusATK: 3},
+	{ID: "tin_t1a_cd", Name: "Assembly Line", Description: "-3 ability cooldown", ClassID: "tinker", Tier: TierAdept, Branch: "A", Kind: SkillAbilityUpgrade, CooldownReduce: 3},
+	{ID: "tin_t1a_cd2", Name: "Prefab Chassis", Description: "-4 ability cooldown", ClassID: "tinker", Tier: TierAdept, Branch: "A", Kind: SkillAbilityUpgrade, CooldownReduce: 4},
+	{ID: "tin_t1a_kh", Name: "Salvage", Description: "+2 HP on kill", ClassID: "tinker", Tier: TierAdept, Branch: "A", Kind: SkillPassiveProc, KillHealBonus: 2},
+	{ID: "tin_t1a_hp", Name: "Reinforced Frame", Description: "+4 MaxHP", ClassID: "tinker", Tier: TierAdept, Branch: "A", Kind: SkillPassiveStat, BonusMaxHP: 4},
+
+	// Tier 1 — Adept, Branch B (Bulwark)
+	{ID: "tin_t1b_def", Name: "Blast Shield", Description: "+3 DEF", ClassID: "tinker", Tier: TierAdept, Branch: "B", Kind: SkillPassiveStat, BonusDEF: 3},
+	{ID: "tin_t1b_hp", Name: "Pressure Suit", Description: "+6 MaxHP", ClassID: "tinker", Tier: TierAdept, Branch: "B", Kind: SkillPassiveStat, BonusMaxHP: 6},
+	{ID: "tin_t1b_thorns", Name: "Arc Coils", Description: "+2 thorns damage", ClassID: "tinker", Tier: TierAdept, Branch: "B", Kind: SkillPassiveProc, ThornsDamage: 2},
+	{ID: "tin_t1b_dodge", Name: "Gyro Stabiliser", Description: "8% dodge chance", ClassID: "tinker", Tier: TierAdept, Branch: "B", Kind: SkillPassiveProc, DodgeChance: 8},
+	{ID: "tin_t1b_def2", Name: "Bolted Stance", Description: "+2 DEF", ClassID: "tinker", Tier: TierAdept, Branch: "B", Kind: SkillPassiveStat, BonusDEF: 2},
+
+	// ═══ WARDEN ═══
+
+	// Tier 0 — Novice
+	{ID: "war_t0_def", Name: "Shield Wall", Description: "+1 DEF", ClassID: "warden", Tier: TierNovice, Kind: SkillPassiveStat, BonusDEF: 1},
+	{ID: "war_t0_hp", Name: "Stalwart", Description: "+4 MaxHP", ClassID: "warden", Tier: TierNovice, Kind: SkillPassiveStat, BonusMaxHP: 4},
+	{ID: "war_t0_cd", Name: "War Cry", Description: "-2 ability cooldown", ClassID: "warden", Tier: TierNovice, Kind: SkillAbilityUpgrade, CooldownReduce: 2},
+	{ID: "war_t0_thorns", Name: "Spiked Pauldrons", Description: "+1 thorns damage", ClassID: "warden", Tier: TierNovice, Kind: SkillPassiveProc, ThornsDamage: 1},
+	{ID: "war_t0_atk", Name: "Shield Bash", Description: "+2 ATK", ClassID: "warden", Tier: TierNovice, Kind: SkillPassiveStat, BonusATK: 2},
+
+	// Tier 1 — Adept, Branch A (Vanguard)
+	{ID: "war_t1a_thorns", Name: "Retribution", Description: "+3 thorns damage", ClassID: "warden", Tier: TierAdept, Branch: "A", Kind: SkillPassiveProc, ThornsDamage: 3},
+	{ID: "war_t1a_atk", Name: "Counterstrike", Description: "+3 ATK", ClassID: "warden", Tier: TierAdept, Branch: "A", Kind: SkillPassiveStat, BonusATK: 3},
+	{ID: "war_t1a_kh", Name: "Second Wind", Description: "+2 HP on kill", ClassID: "warden", Tier: TierAdept, Branch: "A", Kind: SkillPassiveProc, KillHealBonus: 2},
+	{ID: "war_t1a_hp", Name: "Battle Hardened", Description: "+5 MaxHP", ClassID: "warden", Tier: TierAdept, Branch: "A", Kind: SkillPassiveStat, BonusMaxHP: 5},
+	{ID: "war_t1a_cd", Name: "Rallying Shout", Description: "-3 ability cooldown", ClassID: "warden", Tier: TierAdept, Branch: "A", Kind: SkillAbilityUpgrade, CooldownReduce: 3},
+
+	// Tier 1 — Adept, Branch B (Sentinel)
+	{ID: "war_t1b_def", Name: "Tower Shield", Description: "+3 DEF", ClassID: "warden", Tier: TierAdept, Branch: "B", Kind: SkillPassiveStat, BonusDEF: 3},
+	{ID: "war_t1b_hp", Name: "Unbreakable", Description: "+8 MaxHP", ClassID: "warden", Tier: TierAdept, Branch: "B", Kind: SkillPassiveStat, BonusMaxHP: 8},
+	{ID: "war_t1b_cd", Name: "Ever Vigilant", Description: "-4 ability cooldown", ClassID: "warden", Tier: TierAdept, Branch: "B", Kind: SkillAbilityUpgrade, CooldownReduce: 4},
+	{ID: "war_t1b_dodge", Name: "Deflection", Description: "8% dodge chance", ClassID: "warden", Tier: TierAdept, Branch: "B", Kind: SkillPassiveProc, DodgeChance: 8},
+	{ID: "war_t1b_def2", Name: "Bulwark Stance", Description: "+2 DEF", ClassID: "warden", Tier: TierAdept, Branch: "B", Kind: SkillPassiveStat, BonusDEF: 2},
 }
 
 // SkillByID returns the SkillDef with the given ID, or nil if not found.
@@ -13,6 +13,9 @@ const (
 	GlyphFractalGolem = "🗿"
 	GlyphEntropyBloom = "🌀"
 	GlyphApexWarden   = "🤖"
+	GlyphBroodMatron  = "🪺" // summoner: calls Glimmer Mites to its side
+	GlyphGlimmerMite  = "🐜" // summoned minion, never spawned by Populate
+	GlyphLumenOoze    = "🫧" // splits into weaker copies when wounded
 	GlyphHyperflask   = "🧪"
 	GlyphPrismShard   = "💎"
 	GlyphNullCloak    = "🫥"
@@ -25,6 +28,7 @@ const (
 	GlyphStairsDown   = "🔽"
 	GlyphStairsUp     = "🔼"
 	GlyphDoor         = "🚪"
+	GlyphTurret       = "🛸" // Tinker-deployed ally turret
 
 	// Equipment — head
 	GlyphCrystalHelm      = "🪖"
@@ -48,16 +52,32 @@ const (
 	// Equipment — off-hand
 	GlyphPhaseMirror      = "🪩"
 	GlyphPowerCell        = "🔋"
+	GlyphChronoBand       = "⌚"
 
 	// New consumables
 	GlyphNanoSyringe    = "💉" // floor 5+ — nano-medicine
 	GlyphResonanceBurst = "🧨" // floor 3+ — overcharge
 	GlyphPhaseRod       = "🪄" // floor 6+ — prismatic defense
 	GlyphApexCore       = "🫀" // floor 8+ — permanent HP upgrade
+	GlyphTempoTonic     = "🧃" // floor 4+ — haste: ability cooldown ticks twice as fast
+	GlyphSnareKit       = "🕸️" // floor 2+ — arms a hidden snare that roots an enemy
+	GlyphCaltropPouch   = "🧷" // floor 3+ — arms a hidden spike trap
+	GlyphBindingWand    = "🧵" // floor 2+ — charged: roots the nearest enemy
+	GlyphLightningWand  = "🌩️" // floor 4+ — charged: bolts the nearest enemy, recharges
+	GlyphDecoyDoll      = "🎎" // floor 2+ — sets down a decoy that draws enemies off you
+	GlyphGoldPile       = "💰" // coins on the floor, collected by walking onto them
+	GlyphVendingMachine = "🏧" // dungeon furniture that sells consumables for gold
+	GlyphCorpse         = "🪦" // a fallen MUD player's dropped backpack and gold
+	GlyphChest          = "🧰" // treasure left by a slain boss or elite; see DropsChest
+	GlyphAltar          = "🛐" // ritual furniture that wants offerings; see AltarOffering
+	GlyphAcidPool       = "🟢" // hazard left by a slain Lumen Ooze; see DeathHazards
+	GlyphSporeCloud     = "🌫️" // hazard left by a slain Toxin Spore
+	GlyphEmbers         = "♨️" // hazard left by a slain Cinder Wraith
 
 	// Floors 6-10 enemies
 	GlyphToxinSpore      = "🦠"
 	GlyphTideWraith      = "🐙"
+	GlyphMembraneLurker  = "🕷️" // ambusher: unseen until adjacent
 	GlyphOssifiedScholar = "🦴"
 	GlyphArchiveWarden   = "🗝️"
 	GlyphCinderWraith    = "🔥"
@@ -66,6 +86,8 @@ const (
 	GlyphPsychicEcho     = "🧿"
 	GlyphCrystalRevenant = "🦂"
 	GlyphUnmaker         = "☄️"
+	GlyphResonanceCantor = "🎼" // support caster: buffs nearby enemies, never attacks
+	GlyphMirrorEcho      = "👥" // copies the player's class ability
 
 	// Floor elites — one unique boss-tier enemy per floor
 	GlyphShardmind       = "💠" // Floor 1 elite
@@ -98,11 +120,17 @@ type ClassDef struct {
 	AbilityDesc        string // one-liner shown on class selection screen
 	AbilityCooldown    int    // turns between uses (0 = no ability)
 	AbilityFreeOnFloor bool   // reset cooldown to 0 on each new floor entry
+	AbilityCharges     int    // charges stored at once (0 or 1 = single charge)
 	// Ongoing passives
 	KillHealChance int // 0-100: % chance to restore 2 HP on each kill
 	PassiveRegen   int // >0: restore 1 HP every N turns
 }
 
+// MaxCharges returns how many ability charges the class can store (at least 1).
+func (c ClassDef) MaxCharges() int {
+	return max(c.AbilityCharges, 1)
+}
+
 // Classes is the ordered list of selectable player classes.
 var Classes = []ClassDef{
 	{
@@ -117,7 +145,7 @@ var Classes = []ClassDef{
 		PassiveDesc:    "Wild Magic: 30% chance per kill to restore 2 HP",
 		KillHealChance: 30,
 		AbilityName:    "Dimensional Rift",
-		AbilityDesc:    "Teleport to a random walkable tile",
+		AbilityDesc:    "Teleport to a chosen visible tile (or a random room)",
 		AbilityCooldown: 12,
 	},
 	{
@@ -176,7 +204,7 @@ var Classes = []ClassDef{
 		FOVRadius:          15,
 		PassiveDesc:        "—",
 		AbilityName:        "Farsight",
-		AbilityDesc:        "Reveal the entire floor",
+		AbilityDesc:        "Reveal the entire floor and anything lurking in it",
 		AbilityCooldown:    20,
 		AbilityFreeOnFloor: true,
 	},
@@ -197,6 +225,35 @@ var Classes = []ClassDef{
 		AbilityCooldown:    12,
 		AbilityFreeOnFloor: true,
 	},
+	{
+		ID:              "tinker",
+		Name:            "Spire Tinker",
+		Emoji:           "🪛",
+		Lore:            "The Spire's machines were never abandoned. They were waiting for someone to ask nicely",
+		MaxHP:           26,
+		Attack:          4,
+		Defense:         4,
+		FOVRadius:       8,
+		PassiveDesc:     "—",
+		AbilityName:     "Deploy Turret",
+		AbilityDesc:     "Place a turret that fires at nearby foes (8 shots, 15 turns)",
+		AbilityCooldown: 16,
+		AbilityCharges:  2,
+	},
+	{
+		ID:              "warden",
+		Name:            "Bastion Warden",
+		Emoji:           "🦏",
+		Lore:            "Sworn to stand between the Spire and whoever is foolish enough to climb it with you",
+		MaxHP:           48,
+		Attack:          4,
+		Defense:         7,
+		FOVRadius:       7,
+		PassiveDesc:     "—",
+		AbilityName:     "Challenge",
+		AbilityDesc:     "Taunt enemies within 6 tiles (5 turns), +3 DEF",
+		AbilityCooldown: 14,
+	},
 }
 
 // FloorNames maps floor number (0-indexed) to its lore name.
@@ -229,6 +286,13 @@ into something neither discipline can fully explain.
 You climb to reach the Prismatic Heart at the summit.
 Press any key to begin...`
 
+// Enemy factions for generate.EnemySpawnEntry.Faction, matching
+// component.FactionID. Rival factions fight each other on infighting floors.
+const (
+	FactionConstruct uint8 = 1
+	FactionOrganic   uint8 = 2
+)
+
 // Enemy tables per floor.
 var EnemyTables = [11][]generate.EnemySpawnEntry{
 	{}, // floor 0 unused
@@ -237,54 +301,72 @@ var EnemyTables = [11][]generate.EnemySpawnEntry{
 		{Glyph: GlyphNeonSpecter, Name: "Neon Specter", ThreatCost: 3, Attack: 4, Defense: 1, MaxHP: 6, SightRange: 7},
 	},
 	{ // Floor 2: Bioluminescent Warrens
-		{Glyph: GlyphThoughtLeech, Name: "Thought Leech", ThreatCost: 3, Attack: 4, Defense: 1, MaxHP: 10, SightRange: 8},
+		{Glyph: GlyphThoughtLeech, Name: "Thought Leech", ThreatCost: 3, Attack: 4, Defense: 1, MaxHP: 10, SightRange: 8, Faction: FactionOrganic},
 		{Glyph: GlyphNeonSpecter, Name: "Neon Specter", ThreatCost: 3, Attack: 4, Defense: 1, MaxHP: 6, SightRange: 7},
 		{Glyph: GlyphPrismDrake, Name: "Prism Drake", ThreatCost: 5, Attack: 6, Defense: 3, MaxHP: 14, SightRange: 6},
+		{Glyph: GlyphLumenOoze, Name: "Lumen Ooze", ThreatCost: 4, Attack: 3, Defense: 0, MaxHP: 16, SightRange: 6, SplitGen: 2, Faction: FactionOrganic},
 	},
 	{ // Floor 3: Resonance Engine
 		{Glyph: GlyphPrismDrake, Name: "Prism Drake", ThreatCost: 5, Attack: 6, Defense: 3, MaxHP: 14, SightRange: 6},
-		{Glyph: GlyphVoidTendril, Name: "Void Tendril", ThreatCost: 4, Attack: 7, Defense: 0, MaxHP: 12, SightRange: 4},
-		{Glyph: GlyphFractalGolem, Name: "Fractal Golem", ThreatCost: 6, Attack: 5, Defense: 5, MaxHP: 20, SightRange: 5},
+		{Glyph: GlyphLumenOoze, Name: "Lumen Ooze", ThreatCost: 4, Attack: 3, Defense: 0, MaxHP: 16, SightRange: 6, SplitGen: 2, Faction: FactionOrganic},
+		{Glyph: GlyphVoidTendril, Name: "Void Tendril", ThreatCost: 4, Attack: 4, Defense: 0, MaxHP: 12, SightRange: 4, AttacksPerTurn: 2, Faction: FactionOrganic},
+		{Glyph: GlyphFractalGolem, Name: "Fractal Golem", ThreatCost: 6, Attack: 5, Defense: 5, MaxHP: 20, SightRange: 5, Fearless: true, AreaAttack: true, Faction: FactionConstruct},
+		{Glyph: GlyphBroodMatron, Name: "Brood Matron", ThreatCost: 6, Attack: 3, Defense: 2, MaxHP: 16, SightRange: 7,
+			SummonGlyph: GlyphGlimmerMite, SummonCap: 3, SummonEvery: 5, Faction: FactionConstruct},
 	},
 	{ // Floor 4: Fractured Observatory
-		{Glyph: GlyphEntropyBloom, Name: "Entropy Bloom", ThreatCost: 7, Attack: 8, Defense: 2, MaxHP: 18, SightRange: 9},
-		{Glyph: GlyphFractalGolem, Name: "Fractal Golem", ThreatCost: 6, Attack: 5, Defense: 5, MaxHP: 20, SightRange: 5},
-		{Glyph: GlyphThoughtLeech, Name: "Thought Leech", ThreatCost: 3, Attack: 4, Defense: 1, MaxHP: 10, SightRange: 8},
+		{Glyph: GlyphEntropyBloom, Name: "Entropy Bloom", ThreatCost: 7, Attack: 8, Defense: 2, MaxHP: 18, SightRange: 9, Faction: FactionOrganic},
+		{Glyph: GlyphFractalGolem, Name: "Fractal Golem", ThreatCost: 6, Attack: 5, Defense: 5, MaxHP: 20, SightRange: 5, Fearless: true, AreaAttack: true, Faction: FactionConstruct},
+		{Glyph: GlyphThoughtLeech, Name: "Thought Leech", ThreatCost: 3, Attack: 4, Defense: 1, MaxHP: 10, SightRange: 8, Faction: FactionOrganic},
+		{Glyph: GlyphBroodMatron, Name: "Brood Matron", ThreatCost: 6, Attack: 3, Defense: 2, MaxHP: 16, SightRange: 7,
+			SummonGlyph: GlyphGlimmerMite, SummonCap: 3, SummonEvery: 5, Faction: FactionConstruct},
 	},
 	{ // Floor 5: Apex Nexus
-		{Glyph: GlyphEntropyBloom, Name: "Entropy Bloom", ThreatCost: 7, Attack: 8, Defense: 2, MaxHP: 18, SightRange: 9},
-		{Glyph: GlyphFractalGolem, Name: "Fractal Golem", ThreatCost: 6, Attack: 5, Defense: 5, MaxHP: 20, SightRange: 5},
-		{Glyph: GlyphThoughtLeech, Name: "Thought Leech", ThreatCost: 3, Attack: 4, Defense: 1, MaxHP: 10, SightRange: 8},
-		{Glyph: GlyphVoidTendril, Name: "Void Tendril", ThreatCost: 4, Attack: 7, Defense: 0, MaxHP: 12, SightRange: 4},
-		{Glyph: GlyphApexWarden, Name: "Apex Warden", ThreatCost: 15, Attack: 12, Defense: 6, MaxHP: 60, SightRange: 10},
+		{Glyph: GlyphEntropyBloom, Name: "Entropy Bloom", ThreatCost: 7, Attack: 8, Defense: 2, MaxHP: 18, SightRange: 9, Faction: FactionOrganic},
+		{Glyph: GlyphFractalGolem, Name: "Fractal Golem", ThreatCost: 6, Attack: 5, Defense: 5, MaxHP: 20, SightRange: 5, Fearless: true, AreaAttack: true, Faction: FactionConstruct},
+		{Glyph: GlyphThoughtLeech, Name: "Thought Leech", ThreatCost: 3, Attack: 4, Defense: 1, MaxHP: 10, SightRange: 8, Faction: FactionOrganic},
+		{Glyph: GlyphVoidTendril, Name: "Void Tendril", ThreatCost: 4, Attack: 4, Defense: 0, MaxHP: 12, SightRange: 4, AttacksPerTurn: 2, Faction: FactionOrganic},
+		{Glyph: GlyphApexWarden, Name: "Apex Warden", ThreatCost: 15, Attack: 12, Defense: 6, MaxHP: 60, SightRange: 10, Fearless: true, AreaAttack: true,
+			Drops: []generate.DropEntry{{Glyph: GlyphPrismaticWard, Guaranteed: true}}, Faction: FactionConstruct},
+		{Glyph: GlyphBroodMatron, Name: "Brood Matron", ThreatCost: 6, Attack: 3, Defense: 2, MaxHP: 16, SightRange: 7,
+			SummonGlyph: GlyphGlimmerMite, SummonCap: 3, SummonEvery: 5, Faction: FactionConstruct},
 	},
 	{ // Floor 6: Membrane of Echoes
 		{Glyph: GlyphToxinSpore, Name: "Toxin Spore", ThreatCost: 4, Attack: 6, Defense: 1, MaxHP: 14, SightRange: 6,
-			SpecialKind: 1, SpecialChance: 40, SpecialMag: 2, SpecialDur: 3},
+			SpecialKind: 1, SpecialChance: 40, SpecialMag: 2, SpecialDur: 3, Faction: FactionOrganic},
 		{Glyph: GlyphTideWraith, Name: "Tide Wraith", ThreatCost: 4, Attack: 8, Defense: 0, MaxHP: 10, SightRange: 8},
+		{Glyph: GlyphMembraneLurker, Name: "Membrane Lurker", ThreatCost: 5, Attack: 9, Defense: 1, MaxHP: 12, SightRange: 6, Ambush: true},
+		{Glyph: GlyphResonanceCantor, Name: "Resonance Cantor", ThreatCost: 5, Attack: 3, Defense: 2, MaxHP: 14, SightRange: 8, Support: true},
+		{Glyph: GlyphMirrorEcho, Name: "Mirror Echo", ThreatCost: 6, Attack: 7, Defense: 2, MaxHP: 16, SightRange: 8, EchoEvery: 8},
 	},
 	{ // Floor 7: The Calcified Archive
 		{Glyph: GlyphOssifiedScholar, Name: "Ossified Scholar", ThreatCost: 5, Attack: 6, Defense: 4, MaxHP: 20, SightRange: 7,
 			SpecialKind: 2, SpecialChance: 35, SpecialMag: 2, SpecialDur: 4},
-		{Glyph: GlyphArchiveWarden, Name: "Archive Warden", ThreatCost: 5, Attack: 10, Defense: 3, MaxHP: 16, SightRange: 8},
+		{Glyph: GlyphArchiveWarden, Name: "Archive Warden", ThreatCost: 5, Attack: 10, Defense: 3, MaxHP: 16, SightRange: 8,
+			SpecialKind: 6, SpecialChance: 15, SpecialDur: 6},
+		{Glyph: GlyphMembraneLurker, Name: "Membrane Lurker", ThreatCost: 5, Attack: 9, Defense: 1, MaxHP: 12, SightRange: 6, Ambush: true},
+		{Glyph: GlyphResonanceCantor, Name: "Resonance Cantor", ThreatCost: 5, Attack: 3, Defense: 2, MaxHP: 14, SightRange: 8, Support: true},
 	},
 	{ // Floor 8: Abyssal Foundry
 		{Glyph: GlyphCinderWraith, Name: "Cinder Wraith", ThreatCost: 6, Attack: 9, Defense: 1, MaxHP: 18, SightRange: 7,
 			SpecialKind: 1, SpecialChance: 45, SpecialMag: 3, SpecialDur: 3},
-		{Glyph: GlyphForgeGolem, Name: "Forge Golem", ThreatCost: 8, Attack: 8, Defense: 7, MaxHP: 34, SightRange: 5,
-			SpecialKind: 3, SpecialChance: 50, SpecialMag: 5, SpecialDur: 0},
+		{Glyph: GlyphForgeGolem, Name: "Forge Golem", ThreatCost: 8, Attack: 8, Defense: 7, MaxHP: 34, SightRange: 5, Fearless: true, AreaAttack: true,
+			SpecialKind: 3, SpecialChance: 50, SpecialMag: 5, SpecialDur: 0, Faction: FactionConstruct},
+		{Glyph: GlyphResonanceCantor, Name: "Resonance Cantor", ThreatCost: 5, Attack: 3, Defense: 2, MaxHP: 14, SightRange: 8, Support: true},
 	},
 	{ // Floor 9: The Dreaming Cortex
-		{Glyph: GlyphDreamStalker, Name: "Dream Stalker", ThreatCost: 7, Attack: 11, Defense: 2, MaxHP: 24, SightRange: 9,
+		{Glyph: GlyphDreamStalker, Name: "Dream Stalker", ThreatCost: 7, Attack: 7, Defense: 2, MaxHP: 24, SightRange: 9, AttacksPerTurn: 2,
 			SpecialKind: 2, SpecialChance: 40, SpecialMag: 3, SpecialDur: 5},
 		{Glyph: GlyphPsychicEcho, Name: "Psychic Echo", ThreatCost: 6, Attack: 9, Defense: 2, MaxHP: 20, SightRange: 8,
 			SpecialKind: 1, SpecialChance: 50, SpecialMag: 2, SpecialDur: 4},
+		{Glyph: GlyphResonanceCantor, Name: "Resonance Cantor", ThreatCost: 5, Attack: 3, Defense: 2, MaxHP: 14, SightRange: 8, Support: true},
 	},
 	{ // Floor 10: The Prismatic Heart
 		{Glyph: GlyphCrystalRevenant, Name: "Crystal Revenant", ThreatCost: 8, Attack: 12, Defense: 5, MaxHP: 28, SightRange: 8,
 			SpecialKind: 3, SpecialChance: 40, SpecialMag: 5, SpecialDur: 0},
-		{Glyph: GlyphUnmaker, Name: "The Unmaker", ThreatCost: 22, Attack: 18, Defense: 8, MaxHP: 90, SightRange: 12,
-			SpecialKind: 3, SpecialChance: 60, SpecialMag: 5, SpecialDur: 0},
+		{Glyph: GlyphUnmaker, Name: "The Unmaker", ThreatCost: 22, Attack: 18, Defense: 8, MaxHP: 90, SightRange: 12, Fearless: true, AreaAttack: true,
+			SpecialKind: 3, SpecialChance: 60, SpecialMag: 5, SpecialDur: 0,
+			Drops: []generate.DropEntry{{Glyph: GlyphApexCore, Guaranteed: true}}},
 	},
 }
 
@@ -293,89 +375,89 @@ var EnemyTables = [11][]generate.EnemySpawnEntry{
 var ItemTables = [11][]generate.ItemSpawnEntry{
 	{},
 	{ // Floor 1 — Crystalline Labs
-		{Glyph: GlyphHyperflask, Name: "Hyperflask"},
+		{Glyph: GlyphHyperflask, Name: "Hyperflask", Heals: true},
 		{Glyph: GlyphMemoryScroll, Name: "Memory Scroll"},
 	},
 	{ // Floor 2 — Bioluminescent Warrens: introduces Spore Draught
-		{Glyph: GlyphHyperflask, Name: "Hyperflask"},
+		{Glyph: GlyphHyperflask, Name: "Hyperflask", Heals: true},
 		{Glyph: GlyphPrismShard, Name: "Prism Shard"},
 		{Glyph: GlyphMemoryScroll, Name: "Memory Scroll"},
-		{Glyph: GlyphSporeDraught, Name: "Spore Draught"},
+		{Glyph: GlyphSporeDraught, Name: "Spore Draught", Heals: true},
 	},
 	{ // Floor 3 — Resonance Engine: introduces Resonance Coil
-		{Glyph: GlyphHyperflask, Name: "Hyperflask"},
+		{Glyph: GlyphHyperflask, Name: "Hyperflask", Heals: true},
 		{Glyph: GlyphPrismShard, Name: "Prism Shard"},
 		{Glyph: GlyphNullCloak, Name: "Null Cloak"},
 		{Glyph: GlyphTesseract, Name: "Tesseract Cube"},
-		{Glyph: GlyphSporeDraught, Name: "Spore Draught"},
+		{Glyph: GlyphSporeDraught, Name: "Spore Draught", Heals: true},
 		{Glyph: GlyphResonanceCoil, Name: "Resonance Coil"},
 	},
 	{ // Floor 4 — Fractured Observatory
-		{Glyph: GlyphHyperflask, Name: "Hyperflask"},
+		{Glyph: GlyphHyperflask, Name: "Hyperflask", Heals: true},
 		{Glyph: GlyphPrismShard, Name: "Prism Shard"},
 		{Glyph: GlyphNullCloak, Name: "Null Cloak"},
 		{Glyph: GlyphTesseract, Name: "Tesseract Cube"},
-		{Glyph: GlyphSporeDraught, Name: "Spore Draught"},
+		{Glyph: GlyphSporeDraught, Name: "Spore Draught", Heals: true},
 		{Glyph: GlyphResonanceCoil, Name: "Resonance Coil"},
 	},
 	{ // Floor 5 — Apex Nexus: introduces Prismatic Ward
-		{Glyph: GlyphHyperflask, Name: "Hyperflask"},
+		{Glyph: GlyphHyperflask, Name: "Hyperflask", Heals: true},
 		{Glyph: GlyphPrismShard, Name: "Prism Shard"},
 		{Glyph: GlyphNullCloak, Name: "Null Cloak"},
 		{Glyph: GlyphTesseract, Name: "Tesseract Cube"},
 		{Glyph: GlyphMemoryScroll, Name: "Memory Scroll"},
-		{Glyph: GlyphSporeDraught, Name: "Spore Draught"},
+		{Glyph: GlyphSporeDraught, Name: "Spore Draught", Heals: true},
 		{Glyph: GlyphResonanceCoil, Name: "Resonance Coil"},
 		{Glyph: GlyphPrismaticWard, Name: "Prismatic Ward"},
 	},
 	{ // Floor 6 — Membrane of Echoes: introduces Void Essence
-		{Glyph: GlyphHyperflask, Name: "Hyperflask"},
+		{Glyph: GlyphHyperflask, Name: "Hyperflask", Heals: true},
 		{Glyph: GlyphPrismShard, Name: "Prism Shard"},
 		{Glyph: GlyphNullCloak, Name: "Null Cloak"},
 		{Glyph: GlyphTesseract, Name: "Tesseract Cube"},
-		{Glyph: GlyphSporeDraught, Name: "Spore Draught"},
+		{Glyph: GlyphSporeDraught, Name: "Spore Draught", Heals: true},
 		{Glyph: GlyphResonanceCoil, Name: "Resonance Coil"},
 		{Glyph: GlyphPrismaticWard, Name: "Prismatic Ward"},
 		{Glyph: GlyphVoidEssence, Name: "Void Essence"},
 	},
 	{ // Floor 7 — The Calcified Archive
-		{Glyph: GlyphHyperflask, Name: "Hyperflask"},
+		{Glyph: GlyphHyperflask, Name: "Hyperflask", Heals: true},
 		{Glyph: GlyphPrismShard, Name: "Prism Shard"},
 		{Glyph: GlyphNullCloak, Name: "Null Cloak"},
 		{Glyph: GlyphTesseract, Name: "Tesseract Cube"},
 		{Glyph: GlyphMemoryScroll, Name: "Memory Scroll"},
-		{Glyph: GlyphSporeDraught, Name: "Spore Draught"},
+		{Glyph: GlyphSporeDraught, Name: "Spore Draught", Heals: true},
 		{Glyph: GlyphResonanceCoil, Name: "Resonance Coil"},
 		{Glyph: GlyphPrismaticWard, Name: "Prismatic Ward"},
 		{Glyph: GlyphVoidEssence, Name: "Void Essence"},
 	},
 	{ // Floor 8 — Abyssal Foundry
-		{Glyph: GlyphHyperflask, Name: "Hyperflask"},
+		{Glyph: GlyphHyperflask, Name: "Hyperflask", Heals: true},
 		{Glyph: GlyphPrismShard, Name: "Prism Shard"},
 		{Glyph: GlyphNullCloak, Name: "Null Cloak"},
 		{Glyph: GlyphTesseract, Name: "Tesseract Cube"},
-		{Glyph: GlyphSporeDraught, Name: "Spore Draught"},
+		{Glyph: GlyphSporeDraught, Name: "Spore Draught", Heals: true},
 		{Glyph: GlyphResonanceCoil, Name: "Resonance Coil"},
 		{Glyph: GlyphPrismaticWard, Name: "Prismatic Ward"},
 		{Glyph: GlyphVoidEssence, Name: "Void Essence"},
 	},
 	{ // Floor 9 — The Dreaming Cortex
-		{Glyph: GlyphHyperflask, Name: "Hyperflask"},
+		{Glyph: GlyphHyperflask, Name: "Hyperflask", Heals: true},
 		{Glyph: GlyphPrismShard, Name: "Prism Shard"},
 		{Glyph: GlyphNullCloak, Name: "Null Cloak"},
 		{Glyph: GlyphTesseract, Name: "Tesseract Cube"},
 		{Glyph: GlyphMemoryScroll, Name: "Memory Scroll"},
-		{Glyph: GlyphSporeDraught, Name: "Spore Draught"},
+		{Glyph: GlyphSporeDraught, Name: "Spore Draught", Heals: true},
 		{Glyph: GlyphResonanceCoil, Name: "Resonance Coil"},
 		{Glyph: GlyphPrismaticWard, Name: "Prismatic Ward"},
 		{Glyph: GlyphVoidEssence, Name: "Void Essence"},
 	},
 	{ // Floor 10 — The Prismatic Heart
-		{Glyph: GlyphHyperflask, Name: "Hyperflask"},
+		{Glyph: GlyphHyperflask, Name: "Hyperflask", Heals: true},
 		{Glyph: GlyphPrismShard, Name: "Prism Shard"},
 		{Glyph: GlyphNullCloak, Name: "Null Cloak"},
 		{Glyph: GlyphTesseract, Name: "Tesseract Cube"},
-		{Glyph: GlyphSporeDraught, Name: "Spore Draught"},
+		{Glyph: GlyphSporeDraught, Name: "Spore Draught", Heals: true},
 		{Glyph: GlyphResonanceCoil, Name: "Resonance Coil"},
 		{Glyph: GlyphPrismaticWard, Name: "Prismatic Ward"},
 		{Glyph: GlyphVoidEssence, Name: "Void Essence"},
@@ -390,64 +472,90 @@ var floorElites = [11]*generate.EnemySpawnEntry{
 		Glyph: GlyphShardmind, Name: "Shardmind",
 		ThreatCost: 0, MaxHP: 20, Attack: 6, Defense: 4, SightRange: 6,
 		SpecialKind: 5, SpecialChance: 30, SpecialMag: 2, SpecialDur: 4,
-		Drops: []generate.DropEntry{{Glyph: GlyphHyperflask, Chance: 60}},
+		Fearless: true,
+		Drops:    []generate.DropEntry{{Glyph: GlyphHyperflask, Chance: 60}, {Glyph: GlyphHyperflask, Guaranteed: true}},
 	},
 	{ // Floor 2 — Bioluminescent Warrens: Spore Tyrant
 		Glyph: GlyphSporeTyrant, Name: "Spore Tyrant",
 		ThreatCost: 0, MaxHP: 22, Attack: 6, Defense: 2, SightRange: 8,
 		SpecialKind: 4, SpecialChance: 25, SpecialMag: 0, SpecialDur: 2,
-		Drops: []generate.DropEntry{{Glyph: GlyphSporeDraught, Chance: 70}},
+		Fearless: true,
+		Drops:    []generate.DropEntry{{Glyph: GlyphSporeDraught, Chance: 70}, {Glyph: GlyphHyperflask, Guaranteed: true}},
 	},
 	{ // Floor 3 — Resonance Engine: Gear Revenant
 		Glyph: GlyphGearRevenant, Name: "Gear Revenant",
 		ThreatCost: 0, MaxHP: 26, Attack: 8, Defense: 4, SightRange: 7,
 		SpecialKind: 2, SpecialChance: 40, SpecialMag: 3, SpecialDur: 4,
-		Drops: []generate.DropEntry{{Glyph: GlyphResonanceCoil, Chance: 60}},
+		Fearless: true,
+		Drops:    []generate.DropEntry{{Glyph: GlyphResonanceCoil, Chance: 60}, {Glyph: GlyphHyperflask, Guaranteed: true}},
 	},
 	{ // Floor 4 — Fractured Observatory: Prism Specter
 		Glyph: GlyphPrismSpecter, Name: "Prism Specter",
 		ThreatCost: 0, MaxHP: 28, Attack: 9, Defense: 3, SightRange: 10,
 		SpecialKind: 3, SpecialChance: 35, SpecialMag: 6, SpecialDur: 0,
-		Drops: []generate.DropEntry{{Glyph: GlyphPrismShard, Chance: 65}},
+		Fearless: true,
+		Drops:    []generate.DropEntry{{Glyph: GlyphPrismShard, Chance: 65}, {Glyph: GlyphHyperflask, Guaranteed: true}},
 	},
 	{ // Floor 5 — Apex Nexus: Tendril Overmind
 		Glyph: GlyphTendrilOvermind, Name: "Tendril Overmind",
 		ThreatCost: 0, MaxHP: 35, Attack: 10, Defense: 3, SightRange: 8,
 		SpecialKind: 1, SpecialChance: 45, SpecialMag: 3, SpecialDur: 4,
-		Drops: []generate.DropEntry{{Glyph: GlyphPrismaticWard, Chance: 65}},
+		Fearless: true,
+		Drops:    []generate.DropEntry{{Glyph: GlyphPrismaticWard, Chance: 65}, {Glyph: GlyphHyperflask, Guaranteed: true}},
 	},
 	{ // Floor 6 — Membrane of Echoes: Membrane Horror
 		Glyph: GlyphMembraneHorror, Name: "Membrane Horror",
 		ThreatCost: 0, MaxHP: 32, Attack: 11, Defense: 2, SightRange: 9,
 		SpecialKind: 5, SpecialChance: 40, SpecialMag: 3, SpecialDur: 3,
-		Drops: []generate.DropEntry{{Glyph: GlyphVoidEssence, Chance: 60}},
+		Fearless: true,
+		Drops:    []generate.DropEntry{{Glyph: GlyphVoidEssence, Chance: 60}, {Glyph: GlyphHyperflask, Guaranteed: true}},
 	},
 	{ // Floor 7 — The Calcified Archive: Petrified Scholar
 		Glyph: GlyphPetrifiedScholar, Name: "Petrified Scholar",
 		ThreatCost: 0, MaxHP: 38, Attack: 10, Defense: 6, SightRange: 7,
 		SpecialKind: 4, SpecialChance: 30, SpecialMag: 0, SpecialDur: 3,
-		Drops: []generate.DropEntry{{Glyph: GlyphNanoSyringe, Chance: 65}},
+		Fearless: true,
+		Drops:    []generate.DropEntry{{Glyph: GlyphNanoSyringe, Chance: 65}, {Glyph: GlyphHyperflask, Guaranteed: true}},
 	},
 	{ // Floor 8 — Abyssal Foundry: Magma Revenant
 		Glyph: GlyphMagmaRevenant, Name: "Magma Revenant",
 		ThreatCost: 0, MaxHP: 42, Attack: 12, Defense: 5, SightRange: 7,
 		SpecialKind: 1, SpecialChance: 50, SpecialMag: 4, SpecialDur: 3,
-		Drops: []generate.DropEntry{{Glyph: GlyphPhaseRod, Chance: 65}},
+		Fearless: true,
+		Drops:    []generate.DropEntry{{Glyph: GlyphPhaseRod, Chance: 65}, {Glyph: GlyphHyperflask, Guaranteed: true}},
 	},
 	{ // Floor 9 — The Dreaming Cortex: Somnivore
 		Glyph: GlyphSomnivore, Name: "Somnivore",
 		ThreatCost: 0, MaxHP: 46, Attack: 13, Defense: 4, SightRange: 10,
 		SpecialKind: 2, SpecialChance: 45, SpecialMag: 4, SpecialDur: 5,
-		Drops: []generate.DropEntry{{Glyph: GlyphResonanceBurst, Chance: 65}},
+		Fearless: true,
+		Drops:    []generate.DropEntry{{Glyph: GlyphResonanceBurst, Chance: 65}, {Glyph: GlyphHyperflask, Guaranteed: true}},
 	},
 	{ // Floor 10 — The Prismatic Heart: Prismatic Horror
 		Glyph: GlyphPrismaticHorror, Name: "Prismatic Horror",
 		ThreatCost: 0, MaxHP: 55, Attack: 15, Defense: 7, SightRange: 10,
 		SpecialKind: 3, SpecialChance: 50, SpecialMag: 6, SpecialDur: 0,
-		Drops: []generate.DropEntry{{Glyph: GlyphApexCore, Chance: 80}},
+		Fearless: true,
+		Drops:    []generate.DropEntry{{Glyph: GlyphApexCore, Chance: 80}, {Glyph: GlyphHyperflask, Guaranteed: true}},
 	},
 }
 
+// minions are enemies that only appear when a summoner calls them. They are
+// absent from EnemyTables, so they are worth no threat-based XP.
+var minions = []generate.EnemySpawnEntry{
+	{Glyph: GlyphGlimmerMite, Name: "Glimmer Mite", Attack: 3, Defense: 0, MaxHP: 5, SightRange: 8, Faction: FactionConstruct},
+}
+
+// MinionForGlyph returns the summoned-minion entry with the given glyph.
+func MinionForGlyph(glyph string) (generate.EnemySpawnEntry, bool) {
+	for _, m := range minions {
+		if m.Glyph == glyph {
+			return m, true
+		}
+	}
+	return generate.EnemySpawnEntry{}, false
+}
+
 // FloorElite returns the elite enemy entry for the given floor, or nil if none.
 func FloorElite(floor int) *generate.EnemySpawnEntry {
 	if floor < 1 || floor > 10 {
@@ -490,3 +598,29 @@ func IsEliteGlyph(glyph string) bool {
 	}
 	return false
 }
+
+// EnemyName returns the display name of the enemy with the given glyph,
+// searching every enemy table, elite and summoned minion. Returns "" if the
+// glyph is not an enemy.
+func EnemyName(glyph string) string {
+	for _, tables := range [][11][]generate.EnemySpawnEntry{EnemyTables, ChronolithsEnemyTables} {
+		for _, table := range tables {
+			for _, entry := range table {
+				if entry.Glyph == glyph {
+					return entry.Name
+				}
+			}
+		}
+	}
+	for _, elites := range [][11]*generate.EnemySpawnEntry{floorElites, chronolithsFloorElites} {
+		for _, e := range elites {
+			if e != nil && e.Glyph == glyph {
+				return e.Name
+			}
+		}
+	}
+	if m, ok := MinionForGlyph(glyph); ok {
+		return m.Name
+	}
+	return ""
+}
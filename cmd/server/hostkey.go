@@ -0,0 +1,162 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	cryptorand "crypto/rand"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"time"
+
+	gossh "github.com/gliderlabs/ssh"
+	xssh "golang.org/x/crypto/ssh"
+)
+
+// retiredKeySuffix is appended to a host key's path when -rotate-key
+// replaces it. The retired key is still served until its grace period ends.
+const retiredKeySuffix = ".old"
+
+// loadHostSigners returns the signers to serve. The first path is the primary
+// key and is generated if absent; the rest are optional extra keys, such as an
+// RSA key for older clients. A key retired by rotateHostKey is added while it
+// is younger than grace.
+//
+// SSH presents one host key per algorithm, so a key whose algorithm an
+// earlier key already covers is skipped. rotateHostKey always picks a new
+// algorithm for the replacement so that the retired key is not one of them.
+func loadHostSigners(paths []string, grace time.Duration, now time.Time, logger *slog.Logger) []gossh.Signer {
+	signers := []gossh.Signer{loadOrCreateHostKey(paths[0], logger)}
+	candidates := paths[1:]
+	retired := paths[0] + retiredKeySuffix
+	if info, err := os.Stat(retired); err == nil {
+		if now.Sub(info.ModTime()) < grace {
+			candidates = append(candidates, retired)
+		} else {
+			logger.Info("retired host key past its grace period, not serving it", "path", retired)
+		}
+	}
+
+	for _, path := range candidates {
+		signer, err := loadHostKey(path)
+		if err != nil {
+			logger.Warn("skipping host key", "path", path, "error", err)
+			continue
+		}
+		if servesAlgorithm(signers, signer.PublicKey().Type()) {
+			logger.Warn("skipping host key: another key already covers its algorithm",
+				"path", path, "type", signer.PublicKey().Type())
+			continue
+		}
+		logger.Info("loaded host key", "path", path, "fingerprint", xssh.FingerprintSHA256(signer.PublicKey()))
+		signers = append(signers, signer)
+	}
+	return signers
+}
+
+// servesAlgorithm reports whether any of signers has a key of type algo.
+func servesAlgorithm(signers []gossh.Signer, algo string) bool {
+	for _, s := range signers {
+		if s.PublicKey().Type() == algo {
+			return true
+		}
+	}
+	return false
+}
+
+// loadHostKey reads and parses a PEM-encoded private key.
+func loadHostKey(path string) (gossh.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return xssh.ParsePrivateKey(data)
+}
+
+func loadOrCreateHostKey(path string, logger *slog.Logger) gossh.Signer {
+	if signer, err := loadHostKey(path); err == nil {
+		logger.Info("loaded host key", "path", path, "fingerprint", xssh.FingerprintSHA256(signer.PublicKey()))
+		return signer
+	}
+
+	logger.Info("generating new host key", "path", path)
+	signer, err := generateHostKey(path, xssh.KeyAlgoED25519)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	return signer
+}
+
+// generateHostKey creates a key of type algo, which must be ed25519 or
+// ECDSA P-256, and writes it to path. The key is still returned if the write
+// fails.
+func generateHostKey(path, algo string) (gossh.Signer, error) {
+	var key crypto.Signer
+	var err error
+	switch algo {
+	case xssh.KeyAlgoED25519:
+		_, key, err = ed25519.GenerateKey(cryptorand.Reader)
+	case xssh.KeyAlgoECDSA256:
+		key, err = ecdsa.GenerateKey(elliptic.P256(), cryptorand.Reader)
+	default:
+		return nil, fmt.Errorf("generate host key: unsupported algorithm %q", algo)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("generate host key: %w", err)
+	}
+	signer, err := xssh.NewSignerFromKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("create signer: %w", err)
+	}
+	if pemBlock, err := xssh.MarshalPrivateKey(key, "emoji-roguelike server"); err == nil {
+		_ = os.WriteFile(path, pem.EncodeToMemory(pemBlock), 0600)
+	}
+	return signer, nil
+}
+
+// rotateHostKey moves the key at path to path+retiredKeySuffix, restarting
+// its grace period, and generates a fresh key in its place.
+//
+// The fresh key never shares the retired key's algorithm: SSH serves one key
+// per algorithm, so a same-type replacement would shadow the retired key and
+// clients that pinned it would be locked out at once. An ed25519 key is
+// replaced by ECDSA P-256 and anything else by ed25519.
+//
+// While an earlier retired key is still within grace, rotation is skipped
+// rather than overwriting it, so leaving -rotate-key on rotates at most once
+// per grace period instead of on every restart.
+func rotateHostKey(path string, grace time.Duration, now time.Time, logger *slog.Logger) error {
+	retired := path + retiredKeySuffix
+	if info, err := os.Stat(retired); err == nil && now.Sub(info.ModTime()) < grace {
+		logger.Info("host key already rotated, still serving the retired key", "path", retired,
+			"until", info.ModTime().Add(grace).Format(time.RFC3339))
+		return nil
+	}
+
+	algo := xssh.KeyAlgoED25519
+	if old, err := loadHostKey(path); err == nil && old.PublicKey().Type() == xssh.KeyAlgoED25519 {
+		algo = xssh.KeyAlgoECDSA256
+	}
+	switch err := os.Rename(path, retired); {
+	case err == nil:
+		if err := os.Chtimes(retired, now, now); err != nil {
+			return fmt.Errorf("retire host key: %w", err)
+		}
+		logger.Info("retired host key", "path", retired)
+	case !os.IsNotExist(err):
+		return fmt.Errorf("retire host key: %w", err)
+	}
+	signer, err := generateHostKey(path, algo)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("write host key: %w", err)
+	}
+	logger.Info("rotated host key", "path", path, "type", algo, "fingerprint", xssh.FingerprintSHA256(signer.PublicKey()))
+	return nil
+}
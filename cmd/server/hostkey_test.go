@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	cryptorand "crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gossh "github.com/gliderlabs/ssh"
+	xssh "golang.org/x/crypto/ssh"
+)
+
+var quietLog = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func writeRSAKey(t *testing.T, path string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(cryptorand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey: %v", err)
+	}
+	block, err := xssh.MarshalPrivateKey(key, "")
+	if err != nil {
+		t.Fatalf("MarshalPrivateKey: %v", err)
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func signerTypes(signers []gossh.Signer) []string {
+	var types []string
+	for _, s := range signers {
+		types = append(types, s.PublicKey().Type())
+	}
+	return types
+}
+
+func TestLoadHostSignersServesEveryAlgorithm(t *testing.T) {
+	dir := t.TempDir()
+	primary, rsaPath := filepath.Join(dir, "host_key"), filepath.Join(dir, "rsa_key")
+	writeRSAKey(t, rsaPath)
+
+	got := signerTypes(loadHostSigners([]string{primary, rsaPath}, time.Hour, time.Now(), quietLog))
+	if len(got) != 2 || got[0] != xssh.KeyAlgoED25519 || got[1] != xssh.KeyAlgoRSA {
+		t.Errorf("signer types = %v; want a generated ed25519 key then the RSA key", got)
+	}
+	if _, err := os.Stat(primary); err != nil {
+		t.Errorf("primary key was not written: %v", err)
+	}
+}
+
+func TestRotatedKeyServedUntilGraceEnds(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "host_key")
+	writeRSAKey(t, path)
+	now := time.Now()
+	if err := rotateHostKey(path, time.Hour, now, quietLog); err != nil {
+		t.Fatalf("rotateHostKey: %v", err)
+	}
+
+	got := signerTypes(loadHostSigners([]string{path}, time.Hour, now.Add(time.Minute), quietLog))
+	if len(got) != 2 || got[0] != xssh.KeyAlgoED25519 || got[1] != xssh.KeyAlgoRSA {
+		t.Errorf("during grace, signer types = %v; want the new ed25519 key and the old RSA key", got)
+	}
+	got = signerTypes(loadHostSigners([]string{path}, time.Hour, now.Add(2*time.Hour), quietLog))
+	if len(got) != 1 || got[0] != xssh.KeyAlgoED25519 {
+		t.Errorf("after grace, signer types = %v; want only the new key", got)
+	}
+}
+
+func TestRotatingED25519KeyKeepsServingIt(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "host_key")
+	old := loadOrCreateHostKey(path, quietLog)
+	if err := rotateHostKey(path, time.Hour, time.Now(), quietLog); err != nil {
+		t.Fatalf("rotateHostKey: %v", err)
+	}
+
+	signers := loadHostSigners([]string{path}, time.Hour, time.Now(), quietLog)
+	if got := signerTypes(signers); len(got) != 2 || got[0] != xssh.KeyAlgoECDSA256 || got[1] != xssh.KeyAlgoED25519 {
+		t.Fatalf("signer types = %v; want a new ECDSA key and the old ed25519 key", got)
+	}
+	if !bytes.Equal(signers[1].PublicKey().Marshal(), old.PublicKey().Marshal()) {
+		t.Error("the retired ed25519 key is not the one that was rotated out")
+	}
+}
+
+func TestRotateHostKeyWaitsOutGrace(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "host_key")
+	loadOrCreateHostKey(path, quietLog)
+	now := time.Now()
+	if err := rotateHostKey(path, time.Hour, now, quietLog); err != nil {
+		t.Fatalf("rotateHostKey: %v", err)
+	}
+	current, _ := os.ReadFile(path)
+	retired, _ := os.ReadFile(path + retiredKeySuffix)
+
+	// A restart with the flag still set must not rotate again.
+	if err := rotateHostKey(path, time.Hour, now.Add(time.Minute), quietLog); err != nil {
+		t.Fatalf("second rotateHostKey: %v", err)
+	}
+	if got, _ := os.ReadFile(path); !bytes.Equal(got, current) {
+		t.Error("the current key changed on a restart within grace")
+	}
+	if got, _ := os.ReadFile(path + retiredKeySuffix); !bytes.Equal(got, retired) {
+		t.Error("the retired key was overwritten within its grace period")
+	}
+
+	if err := rotateHostKey(path, time.Hour, now.Add(2*time.Hour), quietLog); err != nil {
+		t.Fatalf("rotateHostKey after grace: %v", err)
+	}
+	if got, _ := os.ReadFile(path + retiredKeySuffix); !bytes.Equal(got, current) {
+		t.Error("after grace, want the current key retired in turn")
+	}
+}
@@ -5,7 +5,7 @@
 //
 // Usage:
 //
-//	./emoji-roguelike-server [--port 2222] [--key server_host_key]
+//	./emoji-roguelike-server [--port 2222] [--key server_host_key[,rsa_host_key]] [--rotate-key]
 //
 // Connect from any terminal:
 //
@@ -13,9 +13,6 @@
 package main
 
 import (
-	cryptorand "crypto/rand"
-	"crypto/ed25519"
-	"encoding/pem"
 	"flag"
 	"fmt"
 	"log"
@@ -24,17 +21,16 @@ import (
 	"net"
 	"os"
 	"strings"
-	"sync"
 	"time"
 	"unicode"
 
+	"emoji-roguelike/assets"
 	"emoji-roguelike/internal/mud"
 	internalssh "emoji-roguelike/internal/ssh"
 	"emoji-roguelike/internal/telnet"
 
 	"github.com/gdamore/tcell/v2"
 	gossh "github.com/gliderlabs/ssh"
-	xssh "golang.org/x/crypto/ssh"
 )
 
 // allowedTerms is the set of TERM values we accept from SSH clients.
@@ -79,14 +75,31 @@ func sanitizeName(name string) string {
 func main() {
 	port := flag.Int("port", 2222, "SSH server port")
 	telnetPort := flag.Int("telnet-port", 2323, "Telnet server port (0 to disable)")
-	keyFile := flag.String("key", "server_host_key", "Path to the PEM-encoded host key (auto-generated if absent)")
+	keyFiles := flag.String("key", "server_host_key", "Comma-separated paths to PEM-encoded host keys; the first is auto-generated if absent")
+	rotateKey := flag.Bool("rotate-key", false, "Replace the first host key with one of a different algorithm, keeping the old key as a fallback for -key-grace (skipped while an earlier rotation is still in grace)")
+	keyGrace := flag.Duration("key-grace", 7*24*time.Hour, "How long a rotated-out host key is still served")
+	scaleEnemies := flag.Bool("scale-enemies", false, "Toughen newly spawned enemies to match the strongest player on their floor")
+	enemyCap := flag.Int("enemy-cap", mud.DefaultEnemyCap, "Most live enemies a floor may hold per 1000 map tiles")
+	ascii := flag.Bool("ascii", false, "Draw the map with ASCII characters instead of emoji for every player")
 	flag.Parse()
 
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	if err := assets.ValidateFloorConfigs(); err != nil {
+		log.Fatalf("%v", err)
+	}
 
-	signer := loadOrCreateHostKey(*keyFile, logger)
+	keyPaths := strings.Split(*keyFiles, ",")
+	if *rotateKey {
+		if err := rotateHostKey(keyPaths[0], *keyGrace, time.Now(), logger); err != nil {
+			log.Fatalf("%v", err)
+		}
+	}
+	signers := loadHostSigners(keyPaths, *keyGrace, time.Now(), logger)
 	rng := mathrand.New(mathrand.NewSource(time.Now().UnixNano()))
 	srv := mud.NewServer(rng, logger)
+	srv.EnemyScaling = *scaleEnemies
+	srv.EnemyCap = *enemyCap
+	srv.ASCII = *ascii
 
 	// Start the world ticker in a background goroutine.
 	go srv.Run()
@@ -99,7 +112,7 @@ func main() {
 			handleSession(srv, s, logger)
 		},
 		PtyCallback: func(_ gossh.Context, _ gossh.Pty) bool { return true },
-		HostSigners: []gossh.Signer{signer},
+		HostSigners: signers,
 	}
 
 	// Start telnet listener if enabled.
@@ -117,7 +130,7 @@ func main() {
 					logger.Error("telnet accept", "error", err)
 					return
 				}
-				go telnet.HandleConnection(conn, srv, logger, &termMu)
+				go telnet.HandleConnection(conn, srv, logger)
 			}
 		}()
 	}
@@ -127,9 +140,16 @@ func main() {
 	log.Fatal(sshSrv.ListenAndServe())
 }
 
-// termMu serializes os.Setenv("TERM") around tcell screen creation.
-// Multiple goroutines may create screens concurrently.
-var termMu sync.Mutex
+// newScreen creates a tcell screen on tty for the given terminal type. The
+// terminfo entry is looked up by name rather than through $TERM, so
+// concurrent connections never race on the process environment.
+func newScreen(tty tcell.Tty, term string) (tcell.Screen, error) {
+	ti, err := tcell.LookupTerminfo(term)
+	if err != nil {
+		return nil, err
+	}
+	return tcell.NewTerminfoScreenFromTtyTerminfo(tty, ti)
+}
 
 // handleSession is the gliderlabs SSH handler for one connection.
 func handleSession(srv *mud.Server, s gossh.Session, logger *slog.Logger) {
@@ -155,10 +175,7 @@ func handleSession(srv *mud.Server, s gossh.Session, logger *slog.Logger) {
 	}
 
 	tty := internalssh.NewSessionTty(s, pty, winCh)
-	termMu.Lock()
-	_ = os.Setenv("TERM", term)
-	screen, err := tcell.NewTerminfoScreenFromTty(tty)
-	termMu.Unlock()
+	screen, err := newScreen(tty, term)
 	if err != nil {
 		logger.Error("terminal setup failed", "remote", remoteAddr, "error", err)
 		fmt.Fprintf(s, "Terminal setup failed: %v\n", err)
@@ -180,6 +197,11 @@ func handleSession(srv *mud.Server, s gossh.Session, logger *slog.Logger) {
 		name = "Player"
 	}
 
+	if !mud.Title(screen) {
+		logger.Info("disconnected at the title screen", "remote", remoteAddr, "player", name)
+		return
+	}
+
 	// Class selection (blocking, before joining the world).
 	cls, ok := mud.ClassSelect(screen)
 	if !ok {
@@ -193,6 +215,7 @@ func handleSession(srv *mud.Server, s gossh.Session, logger *slog.Logger) {
 	sess.FovRadius = cls.FOVRadius
 	sess.BaseMaxHP = cls.MaxHP
 	sess.RunLog.Class = cls.Name
+	sess.Disconnect = func() { s.Close() }
 
 	if !srv.AddSession(sess) {
 		fmt.Fprintln(s, "Server is full. Please try again later.")
@@ -202,28 +225,3 @@ func handleSession(srv *mud.Server, s gossh.Session, logger *slog.Logger) {
 
 	srv.RunLoop(sess)
 }
-
-// ─── host key ────────────────────────────────────────────────────────────────
-
-func loadOrCreateHostKey(path string, logger *slog.Logger) gossh.Signer {
-	if data, err := os.ReadFile(path); err == nil {
-		if signer, err := xssh.ParsePrivateKey(data); err == nil {
-			logger.Info("loaded host key", "path", path)
-			return signer
-		}
-	}
-
-	logger.Info("generating new host key", "path", path)
-	_, key, err := ed25519.GenerateKey(cryptorand.Reader)
-	if err != nil {
-		log.Fatalf("generate host key: %v", err)
-	}
-	signer, err := xssh.NewSignerFromKey(key)
-	if err != nil {
-		log.Fatalf("create signer: %v", err)
-	}
-	if pemBlock, err := xssh.MarshalPrivateKey(key, "emoji-roguelike server"); err == nil {
-		_ = os.WriteFile(path, pem.EncodeToMemory(pemBlock), 0600)
-	}
-	return signer
-}
@@ -1,6 +1,9 @@
 package main
 
-import "testing"
+import (
+	"os"
+	"testing"
+)
 
 func TestSanitizeName(t *testing.T) {
 	cases := []struct {
@@ -57,3 +60,13 @@ func TestAllowedTerms(t *testing.T) {
 		})
 	}
 }
+
+func TestNewScreenLeavesTermUntouched(t *testing.T) {
+	t.Setenv("TERM", "dumb")
+	if _, err := newScreen(nil, "xterm-256color"); err != nil {
+		t.Fatalf("newScreen(xterm-256color): %v", err)
+	}
+	if got := os.Getenv("TERM"); got != "dumb" {
+		t.Errorf("TERM = %q after newScreen; want it unchanged", got)
+	}
+}
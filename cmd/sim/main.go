@@ -0,0 +1,58 @@
+// emoji-roguelike-sim plays many headless runs with a simple bot across
+// seeds and classes and reports win rates, floors reached and causes of
+// death, for balance tuning. Build:
+//
+//	go build -o emoji-roguelike-sim ./cmd/sim
+//
+// Usage:
+//
+//	./emoji-roguelike-sim [--runs 20] [--classes warden,arcanist] [--format csv|json] [--summary]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/sim"
+)
+
+func main() {
+	runs := flag.Int("runs", 20, "Runs (consecutive seeds) per class")
+	seed := flag.Int64("seed", 1, "First seed")
+	classes := flag.String("classes", "", "Comma-separated class IDs to simulate (default every class)")
+	turns := flag.Int("turns", sim.DefaultMaxTurns, "Turns before a run is cut off as a timeout")
+	workers := flag.Int("workers", runtime.NumCPU(), "Runs played at once")
+	format := flag.String("format", "csv", "Output format: csv or json")
+	summary := flag.Bool("summary", false, "With csv, write one row per class instead of one per run")
+	flag.Parse()
+
+	if err := assets.ValidateFloorConfigs(); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	cfg := sim.Config{Seeds: *runs, FirstSeed: *seed, MaxTurns: *turns, Workers: *workers}
+	if *classes != "" {
+		cfg.Classes = strings.Split(*classes, ",")
+	}
+	results, err := sim.Run(cfg)
+	if err == nil {
+		switch {
+		case *format == "json":
+			err = sim.WriteJSON(os.Stdout, results)
+		case *format != "csv":
+			err = fmt.Errorf("unknown format %q", *format)
+		case *summary:
+			err = sim.WriteSummaryCSV(os.Stdout, sim.Summarize(results))
+		default:
+			err = sim.WriteCSV(os.Stdout, results)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
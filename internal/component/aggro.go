@@ -0,0 +1,15 @@
+package component
+
+import "emoji-roguelike/internal/ecs"
+
+const CAggro ecs.ComponentType = 33
+
+// Aggro is an enemy's threat table: how much threat each player has built
+// up against it by hurting it, healing nearby or taunting it. ProcessAI goes
+// after the visible player with the most threat, and the table decays every
+// turn; see system.AddThreat.
+type Aggro struct {
+	Threat map[ecs.EntityID]int
+}
+
+func (Aggro) Type() ecs.ComponentType { return CAggro }
@@ -11,11 +11,29 @@ const (
 	BehaviorChase    AIBehavior = iota // move toward player, attack if adjacent
 	BehaviorCowardly                   // flee when hurt
 	BehaviorStationary                 // never moves
+	BehaviorAlly                       // player-allied; never targets players
+	BehaviorFlee                       // routed; always moves away, never attacks
+	BehaviorSupport                    // buffs nearby enemies instead of attacking
 )
 
 type AI struct {
 	Behavior   AIBehavior
 	SightRange int
+	Fearless   bool // immune to morale rout (bosses, elites, constructs)
+	Ambush     bool // unseen until adjacent, unless the viewer has true sight
+	// LastKnown is where a player was last seen; the enemy keeps heading
+	// there for Memory more turns after losing line of sight.
+	LastKnown Position
+	Memory    int
+	// Path is a cached A* route toward PathGoal, followed when the direct
+	// step toward the target is blocked.
+	Path     []Position
+	PathGoal Position
+	// Home is where the enemy spawned. On a map with a leash it gives up a
+	// chase that strays too far from Home and heads back, Returning, until
+	// it arrives.
+	Home      Position
+	Returning bool
 }
 
 func (AI) Type() ecs.ComponentType { return CAI }
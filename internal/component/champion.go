@@ -0,0 +1,13 @@
+package component
+
+import "emoji-roguelike/internal/ecs"
+
+const CChampion ecs.ComponentType = 35
+
+// Champion marks an enemy that spawned with a champion modifier: tougher
+// stats and better loot, named with Prefix (e.g. "Vicious").
+type Champion struct {
+	Prefix string
+}
+
+func (Champion) Type() ecs.ComponentType { return CChampion }
@@ -0,0 +1,16 @@
+package component
+
+import "emoji-roguelike/internal/ecs"
+
+const CChest ecs.ComponentType = 34
+
+// Chest is the treasure a slain boss or elite leaves where it fell. Whoever
+// opens it keeps one of Rewards. In the MUD each player in Owners picks
+// their own reward and the chest is gone once they all have; with no Owners
+// the first player to open it empties it.
+type Chest struct {
+	Rewards []Item
+	Owners  []string
+}
+
+func (Chest) Type() ecs.ComponentType { return CChest }
@@ -7,10 +7,15 @@ const CCombat ecs.ComponentType = 4
 type Combat struct {
 	Attack        int
 	Defense       int
-	SpecialKind   uint8 // 0=none 1=poison 2=weaken 3=lifedrain
+	SpecialKind   uint8 // 0=none 1=poison 2=weaken 3=lifedrain 4=stun 5=armorBreak 6=disarm
 	SpecialChance int   // 0-100 percent
 	SpecialMag    int   // poison/weaken magnitude or lifedrain percent*10
 	SpecialDur    int   // turns the player effect lasts
+	// AttacksPerTurn is how many times an enemy swings when it attacks (0
+	// counts as one); with AreaAttack each swing also hits every other
+	// player next to it.
+	AttacksPerTurn int
+	AreaAttack     bool
 }
 
 func (Combat) Type() ecs.ComponentType { return CCombat }
@@ -0,0 +1,16 @@
+package component
+
+import "emoji-roguelike/internal/ecs"
+
+const CCorpse ecs.ComponentType = 30
+
+// Corpse holds what a fallen MUD player was carrying, left where they died
+// for them or anyone else to recover until it crumbles away.
+type Corpse struct {
+	Owner     string // name of the fallen player
+	Items     []Item
+	Gold      int
+	TicksLeft int // server ticks until the corpse crumbles
+}
+
+func (Corpse) Type() ecs.ComponentType { return CCorpse }
@@ -0,0 +1,15 @@
+package component
+
+import "emoji-roguelike/internal/ecs"
+
+const CDecoy ecs.ComponentType = 37
+
+// Decoy marks a player-placed lure. Hostile enemies that can see one go after
+// it instead of any player until it is smashed or TurnsLeft runs out; see
+// system.ProcessAI.
+type Decoy struct {
+	Owner     ecs.EntityID // player entity that placed it
+	TurnsLeft int          // turns until it falls apart
+}
+
+func (Decoy) Type() ecs.ComponentType { return CDecoy }
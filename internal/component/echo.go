@@ -0,0 +1,18 @@
+package component
+
+import "emoji-roguelike/internal/ecs"
+
+const CEcho ecs.ComponentType = 32
+
+// Echo marks an enemy that copies a player's class ability every Every turns.
+// ClassID is the class it mirrors, set by system.AttuneEchoes once the floor's
+// players are known; an echo with no class fights normally. ProcessAI records
+// a cast in Pending; the caller reports it after system.CollectEchoes.
+type Echo struct {
+	ClassID  string
+	Every    int // turns between casts
+	Cooldown int // turns until the next cast
+	Pending  bool
+}
+
+func (Echo) Type() ecs.ComponentType { return CEcho }
@@ -17,6 +17,11 @@ const (
 	EffectSelfBurn  // 6 — player burns themselves (e.g. Resonance Burst side-effect)
 	EffectStun      // 7 — player cannot act for Duration turns
 	EffectArmorBreak // 8 — reduces defender DEF by Magnitude for Duration turns
+	EffectHaste      // 9 — ability cooldown ticks down by 2 per turn instead of 1
+	EffectRoot       // 10 — enemy cannot act for Duration turns (snare traps)
+	EffectTrueSight  // 11 — ambushing enemies are seen at any range
+	EffectDisarm     // 12 — Inventory.Equipped()[Magnitude-1] gives no ATK or DEF
+	EffectParry      // 13 — Magnitude% chance the next blow is parried outright
 )
 
 // ActiveEffect is a timed status applied to an entity.
@@ -0,0 +1,22 @@
+package component
+
+import "emoji-roguelike/internal/ecs"
+
+const CFaction ecs.ComponentType = 28
+
+// FactionID names a side in the bestiary's feuds.
+type FactionID uint8
+
+const (
+	FactionConstruct FactionID = iota + 1 // golems, wardens and hive machinery
+	FactionOrganic                        // leeches, tendrils, blooms and oozes
+)
+
+// Faction marks an enemy as belonging to a faction. On floors with
+// infighting, enemies of different factions attack each other when they are
+// adjacent and no player is in range.
+type Faction struct {
+	ID FactionID
+}
+
+func (Faction) Type() ecs.ComponentType { return CFaction }
@@ -0,0 +1,12 @@
+package component
+
+import "emoji-roguelike/internal/ecs"
+
+const CGoldPile ecs.ComponentType = 23
+
+// GoldPile is a heap of coins on the floor, collected by walking onto it.
+type GoldPile struct {
+	Amount int
+}
+
+func (GoldPile) Type() ecs.ComponentType { return CGoldPile }
@@ -0,0 +1,16 @@
+package component
+
+import "emoji-roguelike/internal/ecs"
+
+const CHazard ecs.ComponentType = 36
+
+// Hazard is a lingering patch of harmful ground, such as an acid pool, left
+// where some enemies die. system.TickHazards hurts whatever stands in it and
+// removes it once TurnsLeft runs out.
+type Hazard struct {
+	Name      string
+	Damage    int
+	TurnsLeft int
+}
+
+func (Hazard) Type() ecs.ComponentType { return CHazard }
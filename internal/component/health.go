@@ -4,8 +4,15 @@ import "emoji-roguelike/internal/ecs"
 
 const CHealth ecs.ComponentType = 2
 
+// LowHPPct is the share of max HP at or below which health counts as low:
+// the HUD pulses red and the terminal bell warns.
+const LowHPPct = 25
+
 type Health struct {
 	Current, Max int
 }
 
 func (Health) Type() ecs.ComponentType { return CHealth }
+
+// Low reports whether h is at or below LowHPPct of its max.
+func (h Health) Low() bool { return h.Current*100 <= h.Max*LowHPPct }
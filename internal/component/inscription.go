@@ -4,9 +4,23 @@ import "emoji-roguelike/internal/ecs"
 
 const CInscription ecs.ComponentType = 12
 
-// Inscription holds text etched onto a wall or floor tile.
+// RuneKind selects what an inscription does when stepped on.
+type RuneKind uint8
+
+const (
+	RuneNone   RuneKind = iota // plain wall-writing: text only
+	RuneWard                   // grants a DEF boost
+	RuneFury                   // grants an ATK boost
+	RuneHex                    // trap: poisons whoever reads it
+	RuneRiddle                 // pays out when the reader waits on it
+)
+
+// Inscription holds text etched onto a wall or floor tile. An active rune
+// (Rune != RuneNone) also fires an effect, once; see system.TriggerRune.
 type Inscription struct {
-	Text string
+	Text  string
+	Rune  RuneKind
+	Spent bool // the rune has fired (or its riddle been answered)
 }
 
 func (Inscription) Type() ecs.ComponentType { return CInscription }
@@ -18,3 +18,40 @@ type Inventory struct {
 }
 
 func (Inventory) Type() ecs.ComponentType { return CInventory }
+
+// CanHold reports whether the backpack has room for n more items. A negative
+// n asks whether there would be room once -n items have left.
+func (inv Inventory) CanHold(n int) bool {
+	return len(inv.Backpack)+n <= inv.Capacity
+}
+
+// Stow adds items to the backpack if they all fit and reports whether they
+// did. It is the only way items should enter a backpack, so Capacity is never
+// exceeded.
+func (inv *Inventory) Stow(items ...Item) bool {
+	if !inv.CanHold(len(items)) {
+		return false
+	}
+	inv.Backpack = append(inv.Backpack, items...)
+	return true
+}
+
+// Equipped returns the equipment slots in display order: Head, Body, Feet,
+// MainHand, OffHand. Empty slots are included as zero Items.
+func (inv Inventory) Equipped() []Item {
+	return []Item{inv.Head, inv.Body, inv.Feet, inv.MainHand, inv.OffHand}
+}
+
+// EquipBonuses sums the ATK, DEF and MaxHP granted by the equipped items.
+// Broken items grant nothing.
+func (inv Inventory) EquipBonuses() (atk, def, maxHP int) {
+	for _, it := range inv.Equipped() {
+		if it.Broken() {
+			continue
+		}
+		atk += it.BonusATK
+		def += it.BonusDEF
+		maxHP += it.BonusMaxHP
+	}
+	return atk, def, maxHP
+}
@@ -24,15 +24,25 @@ type Item struct {
 	BonusATK     int
 	BonusDEF     int
 	BonusMaxHP   int
+	CDRPercent   int // % ability cooldown reduction while equipped
 	IsConsumable bool
 	EffectKind   uint8 // 0 = none; mirrors EffectKind constants
 	EffectMag    int
 	EffectDur    int
+	Charges      int // uses left on a charged item (wand); 0 for everything else
+	// Wear on equipment: Durability drops as the item is used in combat and
+	// the item breaks at 0. MaxDurability 0 means it never wears out.
+	Durability    int
+	MaxDurability int
 }
 
 // IsEmpty returns true when this Item is the zero value (empty slot).
 func (i Item) IsEmpty() bool { return i.Name == "" }
 
+// Broken reports whether worn equipment has run out of durability. A broken
+// item stays equipped but grants nothing until it is repaired.
+func (i Item) Broken() bool { return i.MaxDurability > 0 && i.Durability <= 0 }
+
 // CItem is the ECS component type for floor-item entities.
 // The wrapped Item is copied into Inventory on pickup; the entity is then destroyed.
 const CItem ecs.ComponentType = 13
@@ -4,15 +4,21 @@ import "emoji-roguelike/internal/ecs"
 
 const CLoot ecs.ComponentType = 14
 
-// LootEntry describes one possible item drop with a percentage chance.
+// LootEntry describes one possible item drop. Plain entries roll their own
+// percentage Chance; weighted entries compete in a single pool roll.
 type LootEntry struct {
-	Glyph  string
-	Chance int // 0–100
+	Glyph      string
+	Chance     int  // 0–100
+	Guaranteed bool // always drops
+	Weight     int  // >0: weighted pool entry
+	MinFloor   int  // pool entry only eligible on this floor or deeper
+	Rare       bool // pool weight grows with floor depth
 }
 
 // Loot holds the drop table for an entity.
 type Loot struct {
-	Drops []LootEntry
+	Drops      []LootEntry
+	PoolChance int // 0–100 base chance of one weighted pool drop
 }
 
 func (Loot) Type() ecs.ComponentType { return CLoot }
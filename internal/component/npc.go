@@ -12,6 +12,7 @@ const (
 	NPCKindHealer   NPCKind = 1 // heals player to full, repeatable
 	NPCKindShop     NPCKind = 2 // opens shop modal
 	NPCKindAnimal   NPCKind = 3 // flavor only — no speech marks
+	NPCKindSmith    NPCKind = 4 // opens the repair modal
 )
 
 // NPC is a non-hostile, interactable entity with dialogue.
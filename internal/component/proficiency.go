@@ -0,0 +1,14 @@
+package component
+
+import "emoji-roguelike/internal/ecs"
+
+const CProficiency ecs.ComponentType = 29
+
+// Proficiency counts the hits a player has landed with each weapon this run,
+// keyed by weapon name. Hits is shared with the game's run state, so it
+// outlives the player entity that is rebuilt on every floor.
+type Proficiency struct {
+	Hits map[string]int
+}
+
+func (Proficiency) Type() ecs.ComponentType { return CProficiency }
@@ -0,0 +1,18 @@
+package component
+
+import "emoji-roguelike/internal/ecs"
+
+const CRitual ecs.ComponentType = 31
+
+// Ritual marks furniture that only grants its bonus once Needed offerings of
+// the Offering item have been placed on it, one per interaction.
+type Ritual struct {
+	Offering string // glyph of the item the ritual accepts
+	Needed   int
+	Offered  int
+}
+
+// Complete reports whether every offering has been made.
+func (r Ritual) Complete() bool { return r.Offered >= r.Needed }
+
+func (Ritual) Type() ecs.ComponentType { return CRitual }
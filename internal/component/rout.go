@@ -0,0 +1,14 @@
+package component
+
+import "emoji-roguelike/internal/ecs"
+
+const CRout ecs.ComponentType = 21
+
+// Rout marks an enemy whose morale has broken. While present the entity's AI
+// behaviour is BehaviorFlee; Prior is restored when TurnsRemaining runs out.
+type Rout struct {
+	Prior          AIBehavior
+	TurnsRemaining int
+}
+
+func (Rout) Type() ecs.ComponentType { return CRout }
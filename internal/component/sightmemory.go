@@ -0,0 +1,14 @@
+package component
+
+import "emoji-roguelike/internal/ecs"
+
+const CSightMemory ecs.ComponentType = 26
+
+// SightMemory is a player's fog-of-war memory: the glyph of the enemy or item
+// last seen on each tile that has since left view. system.UpdateFOV keeps it
+// current and the renderer draws it dimmed on tiles out of sight.
+type SightMemory struct {
+	Seen map[Position]string
+}
+
+func (SightMemory) Type() ecs.ComponentType { return CSightMemory }
@@ -0,0 +1,17 @@
+package component
+
+import "emoji-roguelike/internal/ecs"
+
+const CSplitter ecs.ComponentType = 27
+
+// Splitter marks an enemy that divides into two weaker copies when a hit
+// wounds but does not kill it. Each split halves its HP and raises Gen; it
+// stops splitting once Gen reaches MaxGen. system.Attack records a split in
+// Pending; the copy itself is created by the caller after system.CollectSplits.
+type Splitter struct {
+	Gen     int // splits in this enemy's lineage so far
+	MaxGen  int // generation at which it stops splitting
+	Pending bool
+}
+
+func (Splitter) Type() ecs.ComponentType { return CSplitter }
@@ -0,0 +1,20 @@
+package component
+
+import "emoji-roguelike/internal/ecs"
+
+const CSummoner ecs.ComponentType = 25
+
+// Summoner marks an enemy that calls minions to its side every Every turns,
+// keeping at most Cap of them alive. ProcessAI records a summon in Pending;
+// the minion itself is created by the caller after system.CollectSummons.
+type Summoner struct {
+	Minion   string         // glyph of the minion it summons
+	Cap      int            // most minions alive at once
+	Every    int            // turns between summons
+	Cooldown int            // turns until the next summon
+	Minions  []ecs.EntityID // minions it has summoned (some may be dead)
+	Pending  bool
+	At       Position // where the pending minion appears
+}
+
+func (Summoner) Type() ecs.ComponentType { return CSummoner }
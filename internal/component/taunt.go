@@ -0,0 +1,14 @@
+package component
+
+import "emoji-roguelike/internal/ecs"
+
+const CTaunt ecs.ComponentType = 20
+
+// Taunt forces an AI entity to target a specific player, overriding
+// nearest-player targeting, until TurnsRemaining runs out.
+type Taunt struct {
+	Target         ecs.EntityID
+	TurnsRemaining int
+}
+
+func (Taunt) Type() ecs.ComponentType { return CTaunt }
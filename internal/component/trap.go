@@ -0,0 +1,29 @@
+package component
+
+import "emoji-roguelike/internal/ecs"
+
+const CTrap ecs.ComponentType = 22
+
+// TrapKind selects what a player-placed trap does when sprung.
+type TrapKind uint8
+
+const (
+	TrapSpike TrapKind = iota // heavy damage
+	TrapSnare                 // light damage and roots the victim
+)
+
+// Trap is a hidden, player-owned trap. ProcessAI springs it when a hostile
+// enemy steps onto its tile and records the outcome; the trap is then removed
+// by system.CollectSprungTraps.
+type Trap struct {
+	Owner  ecs.EntityID
+	Kind   TrapKind
+	Sprung bool
+	// Outcome, filled in when sprung.
+	VictimGlyph string
+	Damage      int
+	Killed      bool
+	Loot        Loot // victim's loot table, captured before the damage
+}
+
+func (Trap) Type() ecs.ComponentType { return CTrap }
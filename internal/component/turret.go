@@ -0,0 +1,15 @@
+package component
+
+import "emoji-roguelike/internal/ecs"
+
+const CTurret ecs.ComponentType = 19
+
+// Turret marks a player-deployed ally that fires at nearby enemies until its
+// ammo or lifespan runs out.
+type Turret struct {
+	Owner     ecs.EntityID // player entity that deployed it
+	Ammo      int          // shots remaining
+	TurnsLeft int          // turns until it powers down
+}
+
+func (Turret) Type() ecs.ComponentType { return CTurret }
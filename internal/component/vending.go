@@ -0,0 +1,20 @@
+package component
+
+import "emoji-roguelike/internal/ecs"
+
+const CVending ecs.ComponentType = 24
+
+// VendingSlot is one consumable for sale in a vending machine.
+type VendingSlot struct {
+	Glyph string
+	Name  string
+	Price int
+}
+
+// VendingMachine is dungeon furniture that sells a small, finite stock of
+// consumables for gold. Sold slots are removed from Stock.
+type VendingMachine struct {
+	Stock []VendingSlot
+}
+
+func (VendingMachine) Type() ecs.ComponentType { return CVending }
@@ -1,5 +1,7 @@
 package ecs
 
+import "slices"
+
 // World is the central entity registry and component store.
 type World struct {
 	nextID     EntityID
@@ -70,7 +72,10 @@ func (w *World) Has(id EntityID, t ComponentType) bool {
 	return w.Get(id, t) != nil
 }
 
-// Query returns all alive entities that have every listed component type.
+// Query returns all alive entities that have every listed component type,
+// in ascending ID (creation) order. The order is stable so that logic taking
+// the first match, such as picking up one of several items on a tile, behaves
+// the same on every run with the same seed.
 func (w *World) Query(types ...ComponentType) []EntityID {
 	if len(types) == 0 {
 		return nil
@@ -105,5 +110,6 @@ func (w *World) Query(types ...ComponentType) []EntityID {
 			result = append(result, id)
 		}
 	}
+	slices.Sort(result)
 	return result
 }
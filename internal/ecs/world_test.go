@@ -130,3 +130,27 @@ func TestQueryExcludesDeadEntities(t *testing.T) {
 		t.Fatalf("expected only the alive entity; got %v", results)
 	}
 }
+
+func TestQueryReturnsStableOrder(t *testing.T) {
+	w := NewWorld()
+	var want []EntityID
+	for i := range 50 {
+		id := w.CreateEntity()
+		w.Add(id, testComp{val: i})
+		if i%3 != 0 {
+			w.Add(id, otherComp{})
+			want = append(want, id)
+		}
+	}
+	for range 10 {
+		got := w.Query(ComponentType(1), ComponentType(2))
+		if len(got) != len(want) {
+			t.Fatalf("Query returned %d entities; want %d", len(got), len(want))
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("Query order = %v; want ascending IDs %v", got, want)
+			}
+		}
+	}
+}
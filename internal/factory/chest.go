@@ -0,0 +1,48 @@
+package factory
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"math/rand"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// ChestRewards rolls the rewards of a treasure chest left on floor: pieces
+// of equipment for different slots, rolled assets.ChestFloorBonus floors
+// deeper, and one rare consumable, assets.ChestChoices in all.
+func ChestRewards(floor int, rng *rand.Rand) []component.Item {
+	df := assets.DungeonFloor(floor)
+	table := assets.EquipTablesForFloor(df)
+	rng.Shuffle(len(table), func(i, j int) { table[i], table[j] = table[j], table[i] })
+
+	var rewards []component.Item
+	slots := map[uint8]bool{}
+	for _, entry := range table {
+		if len(rewards) == assets.ChestChoices-1 {
+			break
+		}
+		if slots[entry.Slot] {
+			continue
+		}
+		slots[entry.Slot] = true
+		rewards = append(rewards, EquipItem(entry, df+assets.ChestFloorBonus, rng))
+	}
+	glyph := assets.ChestConsumables[rng.Intn(len(assets.ChestConsumables))]
+	return append(rewards, lootItem(glyph))
+}
+
+// NewChest creates a treasure chest at (x, y).
+func NewChest(w *ecs.World, chest component.Chest, x, y int) ecs.EntityID {
+	id := w.CreateEntity()
+	w.Add(id, component.Position{X: x, Y: y})
+	w.Add(id, component.Renderable{
+		Glyph:       assets.GlyphChest,
+		FGColor:     tcell.ColorGold,
+		BGColor:     tcell.ColorDefault,
+		RenderOrder: 2,
+	})
+	w.Add(id, chest)
+	return id
+}
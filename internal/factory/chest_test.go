@@ -0,0 +1,50 @@
+package factory
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestChestRewardsCurated(t *testing.T) {
+	for seed := range int64(20) {
+		rewards := ChestRewards(3, rand.New(rand.NewSource(seed)))
+		if len(rewards) != assets.ChestChoices {
+			t.Fatalf("seed %d: %d rewards; want %d", seed, len(rewards), assets.ChestChoices)
+		}
+		gear, last := rewards[:len(rewards)-1], rewards[len(rewards)-1]
+		if gear[0].IsConsumable || gear[1].IsConsumable || gear[0].Slot == gear[1].Slot {
+			t.Errorf("seed %d: equipment choices %v; want two pieces for different slots", seed, gear)
+		}
+		if !last.IsConsumable || !slices.Contains(assets.ChestConsumables, last.Glyph) {
+			t.Errorf("seed %d: last choice %v; want a chest consumable", seed, last)
+		}
+	}
+}
+
+func TestChestEquipmentRollsDeeper(t *testing.T) {
+	entry := assets.EquipTablesForFloor(1)[0]
+	for seed := range int64(10) {
+		here := EquipItem(entry, 1, rand.New(rand.NewSource(seed)))
+		deeper := EquipItem(entry, 1+assets.ChestFloorBonus, rand.New(rand.NewSource(seed)))
+		if deeper.BonusATK+deeper.BonusDEF+deeper.BonusMaxHP <= here.BonusATK+here.BonusDEF+here.BonusMaxHP {
+			t.Errorf("seed %d: %s rolled %+v deeper vs %+v; want better stats", seed, entry.Name, deeper, here)
+		}
+	}
+}
+
+func TestNewChestHoldsRewards(t *testing.T) {
+	w := ecs.NewWorld()
+	chest := component.Chest{Rewards: []component.Item{{Name: "Helm"}}, Owners: []string{"Ada"}}
+	id := NewChest(w, chest, 2, 3)
+	got := w.Get(id, component.CChest).(component.Chest)
+	if len(got.Rewards) != 1 || got.Owners[0] != "Ada" {
+		t.Errorf("chest = %+v; want the rewards and owners it was made with", got)
+	}
+	if r := w.Get(id, component.CRenderable).(component.Renderable); r.Glyph != assets.GlyphChest {
+		t.Errorf("glyph = %q; want %q", r.Glyph, assets.GlyphChest)
+	}
+}
@@ -30,8 +30,15 @@ func NewPlayer(w *ecs.World, x, y int, class assets.ClassDef) ecs.EntityID {
 	return id
 }
 
-// NewEnemy creates an enemy entity from a spawn entry.
-func NewEnemy(w *ecs.World, entry generate.EnemySpawnEntry, x, y int) ecs.EntityID {
+// NewEnemy creates an enemy entity from a spawn entry. rng rolls whether an
+// ordinary enemy (one with a threat cost) spawns as a champion; pass nil for
+// enemies that never do, such as minions and split copies.
+func NewEnemy(w *ecs.World, entry generate.EnemySpawnEntry, x, y int, rng *rand.Rand) ecs.EntityID {
+	var champ *assets.ChampionMod
+	if rng != nil && entry.ThreatCost > 0 && rng.Intn(100) < assets.ChampionChance {
+		champ = &assets.ChampionMods[rng.Intn(len(assets.ChampionMods))]
+		entry = champ.Apply(entry)
+	}
 	id := w.CreateEntity()
 	w.Add(id, component.Position{X: x, Y: y})
 	w.Add(id, component.Health{Current: entry.MaxHP, Max: entry.MaxHP})
@@ -42,28 +49,106 @@ func NewEnemy(w *ecs.World, entry generate.EnemySpawnEntry, x, y int) ecs.Entity
 		RenderOrder: 5,
 	})
 	w.Add(id, component.Combat{
-		Attack:        entry.Attack,
-		Defense:       entry.Defense,
-		SpecialKind:   entry.SpecialKind,
-		SpecialChance: entry.SpecialChance,
-		SpecialMag:    entry.SpecialMag,
-		SpecialDur:    entry.SpecialDur,
-	})
-	w.Add(id, component.AI{Behavior: component.BehaviorChase, SightRange: entry.SightRange})
+		Attack:         entry.Attack,
+		Defense:        entry.Defense,
+		SpecialKind:    entry.SpecialKind,
+		SpecialChance:  entry.SpecialChance,
+		SpecialMag:     entry.SpecialMag,
+		SpecialDur:     entry.SpecialDur,
+		AttacksPerTurn: entry.AttacksPerTurn,
+		AreaAttack:     entry.AreaAttack,
+	})
+	behavior := component.BehaviorChase
+	if entry.Support {
+		behavior = component.BehaviorSupport
+	}
+	w.Add(id, component.AI{Behavior: behavior, SightRange: entry.SightRange, Fearless: entry.Fearless, Ambush: entry.Ambush,
+		Home: component.Position{X: x, Y: y}})
 	w.Add(id, component.Effects{})
 	w.Add(id, component.TagBlocking{})
-	if len(entry.Drops) > 0 {
-		drops := make([]component.LootEntry, len(entry.Drops))
-		for i, d := range entry.Drops {
-			drops[i] = component.LootEntry{Glyph: d.Glyph, Chance: d.Chance}
-		}
-		w.Add(id, component.Loot{Drops: drops})
+	loot := enemyLoot(entry)
+	if champ != nil {
+		loot.PoolChance += champ.PoolBonus
+		w.Add(id, component.Champion{Prefix: champ.Prefix})
+	}
+	if len(loot.Drops) > 0 {
+		w.Add(id, loot)
+	}
+	if entry.SummonGlyph != "" {
+		w.Add(id, component.Summoner{Minion: entry.SummonGlyph, Cap: entry.SummonCap, Every: entry.SummonEvery})
+	}
+	if entry.SplitGen > 0 {
+		w.Add(id, component.Splitter{MaxGen: entry.SplitGen})
+	}
+	if entry.EchoEvery > 0 {
+		w.Add(id, component.Echo{Every: entry.EchoEvery})
+	}
+	if entry.Faction != 0 {
+		w.Add(id, component.Faction{ID: component.FactionID(entry.Faction)})
+	}
+	return id
+}
+
+// NewSplitCopy creates the copy a splitting enemy sheds at (x, y): an enemy
+// with parent's glyph and combat stats but hp of maxHP health, at generation
+// gen of its lineage. Returns NilEntity if parent is not a splitting enemy.
+func NewSplitCopy(w *ecs.World, parent ecs.EntityID, x, y, hp, maxHP, gen int) ecs.EntityID {
+	rc, cc, ac := w.Get(parent, component.CRenderable), w.Get(parent, component.CCombat), w.Get(parent, component.CAI)
+	sc := w.Get(parent, component.CSplitter)
+	if rc == nil || cc == nil || ac == nil || sc == nil {
+		return ecs.NilEntity
+	}
+	cbt, ai, s := cc.(component.Combat), ac.(component.AI), sc.(component.Splitter)
+	id := NewEnemy(w, generate.EnemySpawnEntry{
+		Glyph:          rc.(component.Renderable).Glyph,
+		Attack:         cbt.Attack,
+		Defense:        cbt.Defense,
+		MaxHP:          maxHP,
+		SightRange:     ai.SightRange,
+		SpecialKind:    cbt.SpecialKind,
+		SpecialChance:  cbt.SpecialChance,
+		SpecialMag:     cbt.SpecialMag,
+		SpecialDur:     cbt.SpecialDur,
+		AttacksPerTurn: cbt.AttacksPerTurn,
+		AreaAttack:     cbt.AreaAttack,
+		Fearless:       ai.Fearless,
+		Ambush:         ai.Ambush,
+		Faction:        parentFaction(w, parent),
+		SplitGen:       s.MaxGen,
+	}, x, y, nil)
+	w.Add(id, component.Health{Current: hp, Max: maxHP})
+	w.Add(id, component.Splitter{Gen: gen, MaxGen: s.MaxGen})
+	return id
+}
+
+// NewMinion creates the minion with the given glyph at (x, y) and records it
+// against summoner's cap. Returns NilEntity if glyph is not a known minion.
+func NewMinion(w *ecs.World, summoner ecs.EntityID, glyph string, x, y int) ecs.EntityID {
+	entry, ok := assets.MinionForGlyph(glyph)
+	if !ok {
+		return ecs.NilEntity
+	}
+	id := NewEnemy(w, entry, x, y, nil)
+	if sc := w.Get(summoner, component.CSummoner); sc != nil {
+		s := sc.(component.Summoner)
+		s.Minions = append(s.Minions, id)
+		w.Add(summoner, s)
 	}
 	return id
 }
 
-// NewItem creates a consumable item entity from a spawn entry.
+// parentFaction returns the raw faction of id, 0 if it has none.
+func parentFaction(w *ecs.World, id ecs.EntityID) uint8 {
+	if fc, ok := w.Get(id, component.CFaction).(component.Faction); ok {
+		return uint8(fc.ID)
+	}
+	return 0
+}
+
+// NewItem creates a consumable item entity from a spawn entry. Charged items
+// (wands) start with their full charges.
 func NewItem(w *ecs.World, entry generate.ItemSpawnEntry, x, y int) ecs.EntityID {
+	wand, _ := assets.Wand(entry.Glyph)
 	id := w.CreateEntity()
 	w.Add(id, component.Position{X: x, Y: y})
 	w.Add(id, component.Renderable{
@@ -78,6 +163,7 @@ func NewItem(w *ecs.World, entry generate.ItemSpawnEntry, x, y int) ecs.EntityID
 		Glyph:        entry.Glyph,
 		Slot:         component.SlotConsumable,
 		IsConsumable: true,
+		Charges:      wand.Charges,
 	}})
 	return id
 }
@@ -90,6 +176,11 @@ func NewItemByGlyph(w *ecs.World, glyph string, x, y int) ecs.EntityID {
 
 // NewEquipItem creates an equipment item entity with floor-scaled stats.
 func NewEquipItem(w *ecs.World, entry generate.EquipSpawnEntry, floor int, rng *rand.Rand, x, y int) ecs.EntityID {
+	return DropItem(w, EquipItem(entry, floor, rng), x, y)
+}
+
+// EquipItem rolls the stats of an equipment item from entry, scaled to floor.
+func EquipItem(entry generate.EquipSpawnEntry, floor int, rng *rand.Rand) component.Item {
 	t := 0.0
 	if floor > 1 {
 		t = float64(floor-1) / 9.0
@@ -109,25 +200,18 @@ func NewEquipItem(w *ecs.World, entry generate.EquipSpawnEntry, floor int, rng *
 		bonusHP += variant * 2
 	}
 
-	id := w.CreateEntity()
-	w.Add(id, component.Position{X: x, Y: y})
-	w.Add(id, component.Renderable{
-		Glyph:       entry.Glyph,
-		FGColor:     tcell.ColorAqua,
-		BGColor:     tcell.ColorDefault,
-		RenderOrder: 2,
-	})
-	w.Add(id, component.TagItem{})
-	w.Add(id, component.CItemComp{Item: component.Item{
-		Name:         entry.Name,
-		Glyph:        entry.Glyph,
-		Slot:         component.ItemSlot(entry.Slot),
-		BonusATK:     bonusATK,
-		BonusDEF:     bonusDEF,
-		BonusMaxHP:   bonusHP,
-		IsConsumable: false,
-	}})
-	return id
+	return component.Item{
+		Name:          entry.Name,
+		Glyph:         entry.Glyph,
+		Slot:          component.ItemSlot(entry.Slot),
+		BonusATK:      bonusATK,
+		BonusDEF:      bonusDEF,
+		BonusMaxHP:    bonusHP,
+		CDRPercent:    entry.CDRPercent,
+		IsConsumable:  false,
+		Durability:    assets.Durability(entry.Slot),
+		MaxDurability: assets.Durability(entry.Slot),
+	}
 }
 
 // DropItem creates a floor-item entity from an Item value (used when dropping from inventory).
@@ -164,6 +248,122 @@ func NewInscription(w *ecs.World, text string, x, y int) ecs.EntityID {
 	return id
 }
 
+// runeTexts is the carving shown for each active rune kind.
+var runeTexts = map[component.RuneKind]string{
+	component.RuneWard:   assets.RuneWardText,
+	component.RuneFury:   assets.RuneFuryText,
+	component.RuneHex:    assets.RuneHexText,
+	component.RuneRiddle: assets.RuneRiddleText,
+}
+
+// NewRune creates an active rune of a random kind at (x, y). Like an
+// inscription it is read by stepping onto it; see system.TriggerRune.
+func NewRune(w *ecs.World, rng *rand.Rand, x, y int) ecs.EntityID {
+	kind := component.RuneKind(rng.Intn(len(runeTexts)) + 1)
+	id := w.CreateEntity()
+	w.Add(id, component.Position{X: x, Y: y})
+	w.Add(id, component.Renderable{
+		Glyph:       assets.GlyphRune,
+		FGColor:     tcell.ColorFuchsia,
+		BGColor:     tcell.ColorDefault,
+		RenderOrder: 1,
+	})
+	w.Add(id, component.Inscription{Text: runeTexts[kind], Rune: kind})
+	return id
+}
+
+// NewPuzzleGlyph creates one glyph tile of a vault puzzle at (x, y). It is
+// only a marker; system.StepPuzzle tracks the sequence on the map.
+func NewPuzzleGlyph(w *ecs.World, glyph string, x, y int) ecs.EntityID {
+	id := w.CreateEntity()
+	w.Add(id, component.Position{X: x, Y: y})
+	w.Add(id, component.Renderable{
+		Glyph:       glyph,
+		FGColor:     tcell.ColorWhite,
+		BGColor:     tcell.ColorDefault,
+		RenderOrder: 1,
+	})
+	return id
+}
+
+// NewGoldPile creates a pile of gold at (x, y) that is collected by walking onto it.
+func NewGoldPile(w *ecs.World, amount, x, y int) ecs.EntityID {
+	id := w.CreateEntity()
+	w.Add(id, component.Position{X: x, Y: y})
+	w.Add(id, component.Renderable{
+		Glyph:       assets.GlyphGoldPile,
+		FGColor:     tcell.ColorGold,
+		BGColor:     tcell.ColorDefault,
+		RenderOrder: 2,
+	})
+	w.Add(id, component.GoldPile{Amount: amount})
+	return id
+}
+
+// NewCorpse creates the corpse of a fallen player at (x, y), holding what
+// they carried.
+func NewCorpse(w *ecs.World, corpse component.Corpse, x, y int) ecs.EntityID {
+	id := w.CreateEntity()
+	w.Add(id, component.Position{X: x, Y: y})
+	w.Add(id, component.Renderable{
+		Glyph:       assets.GlyphCorpse,
+		FGColor:     tcell.ColorSilver,
+		BGColor:     tcell.ColorDefault,
+		RenderOrder: 2,
+	})
+	w.Add(id, corpse)
+	return id
+}
+
+// GoldDropChance is the percent chance a slain enemy leaves a gold pile.
+const GoldDropChance = 35
+
+// DropGold may leave a gold pile worth 1..threat+3 at (x, y) where an enemy
+// died. A pile already on the tile absorbs the new gold. Returns the amount
+// dropped, or 0 if the roll failed.
+func DropGold(w *ecs.World, rng *rand.Rand, threat, x, y int) int {
+	if rng.Intn(100) >= GoldDropChance {
+		return 0
+	}
+	amount := rng.Intn(threat+3) + 1
+	for _, id := range w.Query(component.CGoldPile, component.CPosition) {
+		if p := w.Get(id, component.CPosition).(component.Position); p.X == x && p.Y == y {
+			pile := w.Get(id, component.CGoldPile).(component.GoldPile)
+			pile.Amount += amount
+			w.Add(id, pile)
+			return amount
+		}
+	}
+	NewGoldPile(w, amount, x, y)
+	return amount
+}
+
+// LeaveHazard leaves the hazard the enemy with glyph drops on death, if any
+// may linger on floor, at (x, y). A hazard already on the tile is refreshed
+// instead of stacked. Reports whether a hazard was left.
+func LeaveHazard(w *ecs.World, floor int, glyph string, x, y int) bool {
+	def, ok := assets.DeathHazard(floor, glyph)
+	if !ok {
+		return false
+	}
+	hazard := component.Hazard{Name: def.Name, Damage: def.Damage, TurnsLeft: def.Turns}
+	for _, id := range w.Query(component.CHazard, component.CPosition) {
+		if p := w.Get(id, component.CPosition).(component.Position); p.X == x && p.Y == y {
+			w.DestroyEntity(id)
+		}
+	}
+	id := w.CreateEntity()
+	w.Add(id, component.Position{X: x, Y: y})
+	w.Add(id, component.Renderable{
+		Glyph:       def.Glyph,
+		FGColor:     tcell.ColorOrangeRed,
+		BGColor:     tcell.ColorDefault,
+		RenderOrder: 1,
+	})
+	w.Add(id, hazard)
+	return true
+}
+
 // NewFurniture creates a decorative furniture entity that may grant a one-time bonus.
 func NewFurniture(w *ecs.World, entry generate.FurnitureSpawnEntry, x, y int) ecs.EntityID {
 	id := w.CreateEntity()
@@ -187,6 +387,57 @@ func NewFurniture(w *ecs.World, entry generate.FurnitureSpawnEntry, x, y int) ec
 	return id
 }
 
+// Turret tuning for the Tinker's Deploy Turret ability.
+const (
+	TurretAttack   = 5
+	TurretRange    = 6
+	TurretAmmo     = 8
+	TurretLifespan = 15
+)
+
+// NewTurret creates an allied turret owned by the given player at (x, y).
+// Turrets carry no health and no blocking tag, so enemies cannot target them
+// and teammates can walk through them.
+func NewTurret(w *ecs.World, owner ecs.EntityID, x, y int) ecs.EntityID {
+	id := w.CreateEntity()
+	w.Add(id, component.Position{X: x, Y: y})
+	w.Add(id, component.Renderable{
+		Glyph:       assets.GlyphTurret,
+		FGColor:     tcell.ColorAqua,
+		BGColor:     tcell.ColorDefault,
+		RenderOrder: 5,
+	})
+	w.Add(id, component.Combat{Attack: TurretAttack})
+	w.Add(id, component.AI{Behavior: component.BehaviorAlly, SightRange: TurretRange})
+	w.Add(id, component.Turret{Owner: owner, Ammo: TurretAmmo, TurnsLeft: TurretLifespan})
+	return id
+}
+
+// Decoy tuning for the Decoy Doll consumable.
+const (
+	DecoyHP       = 12
+	DecoyLifespan = 8
+)
+
+// NewDecoy sets down a decoy owned by the given player at (x, y). It blocks
+// and has health so enemies can bump and smash it, but it has no AI: the
+// owner and teammates trade places with it instead of attacking it.
+func NewDecoy(w *ecs.World, owner ecs.EntityID, x, y int) ecs.EntityID {
+	id := w.CreateEntity()
+	w.Add(id, component.Position{X: x, Y: y})
+	w.Add(id, component.Renderable{
+		Glyph:       assets.GlyphDecoyDoll,
+		FGColor:     tcell.ColorFuchsia,
+		BGColor:     tcell.ColorDefault,
+		RenderOrder: 5,
+	})
+	w.Add(id, component.Health{Current: DecoyHP, Max: DecoyHP})
+	w.Add(id, component.Combat{})
+	w.Add(id, component.TagBlocking{})
+	w.Add(id, component.Decoy{Owner: owner, TurnsLeft: DecoyLifespan})
+	return id
+}
+
 // NewNPC creates a non-hostile, interactable NPC entity at (x, y).
 func NewNPC(w *ecs.World, name, glyph string, kind component.NPCKind, lines []string, x, y int) ecs.EntityID {
 	id := w.CreateEntity()
@@ -84,7 +84,7 @@ func TestNewEnemyComponents(t *testing.T) {
 		SightRange: 5,
 	}
 	w := ecs.NewWorld()
-	id := NewEnemy(w, entry, 7, 9)
+	id := NewEnemy(w, entry, 7, 9, nil)
 
 	if !w.Alive(id) {
 		t.Fatal("enemy entity must be alive")
@@ -119,6 +119,60 @@ func TestNewEnemyComponents(t *testing.T) {
 	}
 }
 
+// spawnChampion spawns entry with successive seeds until one rolls a
+// champion, returning it.
+func spawnChampion(t *testing.T, w *ecs.World, entry generate.EnemySpawnEntry) ecs.EntityID {
+	t.Helper()
+	for seed := range int64(1000) {
+		id := NewEnemy(w, entry, 0, 0, rand.New(rand.NewSource(seed)))
+		if w.Has(id, component.CChampion) {
+			return id
+		}
+	}
+	t.Fatal("no champion in 1000 spawns")
+	return ecs.NilEntity
+}
+
+func TestNewEnemyChampionIsTougherWithBetterLoot(t *testing.T) {
+	entry := assets.EnemyTable(1)[0]
+	w := ecs.NewWorld()
+	plain := NewEnemy(w, entry, 0, 0, nil)
+	id := spawnChampion(t, w, entry)
+
+	prefix := w.Get(id, component.CChampion).(component.Champion).Prefix
+	var mod assets.ChampionMod
+	for _, m := range assets.ChampionMods {
+		if m.Prefix == prefix {
+			mod = m
+		}
+	}
+	want := mod.Apply(entry)
+	if h := w.Get(id, component.CHealth).(component.Health); h.Max != want.MaxHP || h.Max <= entry.MaxHP {
+		t.Errorf("%s champion max HP = %d; want %d, above the base %d", prefix, h.Max, want.MaxHP, entry.MaxHP)
+	}
+	if c := w.Get(id, component.CCombat).(component.Combat); c.Attack != want.Attack || c.Defense != want.Defense {
+		t.Errorf("%s champion ATK/DEF = %d/%d; want %d/%d", prefix, c.Attack, c.Defense, want.Attack, want.Defense)
+	}
+	base := w.Get(plain, component.CLoot).(component.Loot).PoolChance
+	if got := w.Get(id, component.CLoot).(component.Loot).PoolChance; got != base+mod.PoolBonus {
+		t.Errorf("champion pool chance = %d; want %d", got, base+mod.PoolBonus)
+	}
+}
+
+func TestNewEnemyChampionsOnlyFromOrdinarySpawns(t *testing.T) {
+	w := ecs.NewWorld()
+	elite := *assets.EliteEnemy(1)
+	for seed := range int64(200) {
+		rng := rand.New(rand.NewSource(seed))
+		if id := NewEnemy(w, elite, 0, 0, rng); w.Has(id, component.CChampion) {
+			t.Fatal("an elite should never roll a champion modifier")
+		}
+		if id := NewEnemy(w, assets.EnemyTable(1)[0], 0, 0, nil); w.Has(id, component.CChampion) {
+			t.Fatal("a spawn without an rng should never be a champion")
+		}
+	}
+}
+
 func TestNewItemComponents(t *testing.T) {
 	entry := generate.ItemSpawnEntry{Glyph: "🧪", Name: "Hyperflask"}
 	w := ecs.NewWorld()
@@ -223,6 +277,18 @@ func TestNewInscriptionComponents(t *testing.T) {
 	}
 }
 
+func TestNewRuneIsActiveInscription(t *testing.T) {
+	w := ecs.NewWorld()
+	rng := rand.New(rand.NewSource(1))
+	for range 20 {
+		id := NewRune(w, rng, 2, 3)
+		ins := w.Get(id, component.CInscription).(component.Inscription)
+		if ins.Rune == component.RuneNone || ins.Text != runeTexts[ins.Rune] {
+			t.Fatalf("rune kind %v with text %q", ins.Rune, ins.Text)
+		}
+	}
+}
+
 func TestNewStairsDownComponents(t *testing.T) {
 	w := ecs.NewWorld()
 	id := NewStairsDown(w, 8, 12)
@@ -308,3 +374,101 @@ func TestNewFurnitureNotBlocking(t *testing.T) {
 		t.Error("furniture must not have CTagBlocking")
 	}
 }
+
+func TestNewItemWandStartsCharged(t *testing.T) {
+	w := ecs.NewWorld()
+	id := NewItemByGlyph(w, assets.GlyphLightningWand, 1, 1)
+	item := w.Get(id, component.CItem).(component.CItemComp).Item
+	def, _ := assets.Wand(assets.GlyphLightningWand)
+	if item.Charges != def.Charges || item.Charges == 0 {
+		t.Errorf("Charges = %d; want %d", item.Charges, def.Charges)
+	}
+	if flask := w.Get(NewItemByGlyph(w, assets.GlyphHyperflask, 1, 1), component.CItem).(component.CItemComp).Item; flask.Charges != 0 {
+		t.Errorf("single-use consumables should have no charges; got %d", flask.Charges)
+	}
+}
+
+func TestNewSplitCopyInheritsParentStats(t *testing.T) {
+	w := ecs.NewWorld()
+	var ooze generate.EnemySpawnEntry
+	for _, e := range assets.EnemyTables[2] {
+		if e.Glyph == assets.GlyphLumenOoze {
+			ooze = e
+		}
+	}
+	parent := NewEnemy(w, ooze, 5, 5, nil)
+	s, ok := w.Get(parent, component.CSplitter).(component.Splitter)
+	if !ok || s.MaxGen != ooze.SplitGen || s.MaxGen == 0 {
+		t.Fatalf("Lumen Ooze splitter = %+v; want MaxGen %d", s, ooze.SplitGen)
+	}
+
+	child := NewSplitCopy(w, parent, 6, 5, 7, 8, 1)
+	if child == ecs.NilEntity {
+		t.Fatal("NewSplitCopy returned NilEntity")
+	}
+	if hp := w.Get(child, component.CHealth).(component.Health); hp.Current != 7 || hp.Max != 8 {
+		t.Errorf("copy HP = %d/%d; want 7/8", hp.Current, hp.Max)
+	}
+	if c := w.Get(child, component.CCombat).(component.Combat); c.Attack != ooze.Attack || c.Defense != ooze.Defense {
+		t.Errorf("copy ATK/DEF = %d/%d; want %d/%d", c.Attack, c.Defense, ooze.Attack, ooze.Defense)
+	}
+	if r := w.Get(child, component.CRenderable).(component.Renderable); r.Glyph != assets.GlyphLumenOoze {
+		t.Errorf("copy glyph = %q; want %q", r.Glyph, assets.GlyphLumenOoze)
+	}
+	if cs := w.Get(child, component.CSplitter).(component.Splitter); cs.Gen != 1 || cs.MaxGen != ooze.SplitGen {
+		t.Errorf("copy splitter = %+v; want gen 1 of %d", cs, ooze.SplitGen)
+	}
+	if NewSplitCopy(w, NewEnemy(w, assets.EnemyTables[1][0], 1, 1, nil), 2, 1, 3, 3, 1) != ecs.NilEntity {
+		t.Error("a non-splitting enemy should not shed copies")
+	}
+}
+
+func TestNewEnemyFaction(t *testing.T) {
+	w := ecs.NewWorld()
+	golem := NewEnemy(w, generate.EnemySpawnEntry{Glyph: assets.GlyphFractalGolem, MaxHP: 20, Faction: assets.FactionConstruct}, 1, 1, nil)
+	if fc, ok := w.Get(golem, component.CFaction).(component.Faction); !ok || fc.ID != component.FactionConstruct {
+		t.Errorf("golem faction = %+v, %v; want construct", fc, ok)
+	}
+	specter := NewEnemy(w, generate.EnemySpawnEntry{Glyph: assets.GlyphNeonSpecter, MaxHP: 6}, 2, 1, nil)
+	if w.Has(specter, component.CFaction) {
+		t.Error("an enemy without a faction should get no Faction component")
+	}
+}
+
+func TestNewEnemyMirrorEchoIsUnattuned(t *testing.T) {
+	w := ecs.NewWorld()
+	var mirror generate.EnemySpawnEntry
+	for _, e := range assets.EnemyTables[6] {
+		if e.Glyph == assets.GlyphMirrorEcho {
+			mirror = e
+		}
+	}
+	id := NewEnemy(w, mirror, 1, 1, nil)
+	e, ok := w.Get(id, component.CEcho).(component.Echo)
+	if !ok || e.Every != mirror.EchoEvery || e.Every == 0 {
+		t.Fatalf("Mirror Echo = %+v, %v; want Every %d", e, ok, mirror.EchoEvery)
+	}
+	if e.ClassID != "" {
+		t.Errorf("ClassID = %q; want none until the floor's players attune it", e.ClassID)
+	}
+}
+
+func TestLeaveHazardRefreshesInsteadOfStacking(t *testing.T) {
+	w := ecs.NewWorld()
+	if LeaveHazard(w, 1, assets.GlyphCrystalCrawl, 3, 3) {
+		t.Error("a Crystal Crawl should leave no hazard")
+	}
+	for range 2 {
+		if !LeaveHazard(w, 1, assets.GlyphLumenOoze, 3, 3) {
+			t.Fatal("a Lumen Ooze should leave an acid pool")
+		}
+	}
+	ids := w.Query(component.CHazard)
+	if len(ids) != 1 {
+		t.Fatalf("got %d hazards on one tile; want 1", len(ids))
+	}
+	def := assets.DeathHazards[assets.GlyphLumenOoze]
+	if h := w.Get(ids[0], component.CHazard).(component.Hazard); h.Damage != def.Damage || h.TurnsLeft != def.Turns {
+		t.Errorf("hazard = %+v; want %+v", h, def)
+	}
+}
@@ -0,0 +1,151 @@
+package factory
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/generate"
+	"math/rand"
+)
+
+const (
+	// lootPoolPerThreat is the pool drop chance granted per point of threat
+	// cost when a spawn entry does not set its own PoolChance.
+	lootPoolPerThreat = 2
+	// LootPoolFloorBonus is the extra pool drop chance per floor of depth.
+	LootPoolFloorBonus = 1
+	// lootCommonWeightScale multiplies common pool weights; rare weights are
+	// multiplied by the floor instead, so rares overtake commons past this floor.
+	lootCommonWeightScale = 5
+)
+
+// enemyLoot builds the loot component for a spawn entry. Enemies that carry a
+// threat cost also draw from assets.CommonLootPool; elites (threat 0) only
+// drop their own table.
+func enemyLoot(entry generate.EnemySpawnEntry) component.Loot {
+	var loot component.Loot
+	for _, d := range entry.Drops {
+		loot.Drops = append(loot.Drops, lootEntry(d))
+	}
+	poolChance := entry.PoolChance
+	if poolChance == 0 {
+		poolChance = entry.ThreatCost * lootPoolPerThreat
+	}
+	if poolChance > 0 {
+		for _, d := range assets.CommonLootPool {
+			loot.Drops = append(loot.Drops, lootEntry(d))
+		}
+		loot.PoolChance = poolChance
+	}
+	return loot
+}
+
+func lootEntry(d generate.DropEntry) component.LootEntry {
+	return component.LootEntry{
+		Glyph:      d.Glyph,
+		Chance:     d.Chance,
+		Guaranteed: d.Guaranteed,
+		Weight:     d.Weight,
+		MinFloor:   d.MinFloor,
+		Rare:       d.Rare,
+	}
+}
+
+// RollLoot rolls a loot table for an enemy killed on floor. Guaranteed entries
+// always drop, plain entries roll their own Chance, and at most one weighted
+// entry is picked from the pool, whose chance rises with depth.
+func RollLoot(loot component.Loot, floor int, rng *rand.Rand) []component.Item {
+	var items []component.Item
+	var pool []component.LootEntry
+	for _, d := range loot.Drops {
+		switch {
+		case d.Guaranteed:
+			items = append(items, lootItem(d.Glyph))
+		case d.Weight > 0:
+			if floor >= d.MinFloor {
+				pool = append(pool, d)
+			}
+		case rng.Intn(100) < d.Chance:
+			items = append(items, lootItem(d.Glyph))
+		}
+	}
+	if len(pool) == 0 || loot.PoolChance <= 0 {
+		return items
+	}
+	if rng.Intn(100) >= loot.PoolChance+floor*LootPoolFloorBonus {
+		return items
+	}
+	return append(items, lootItem(pickPool(pool, floor, rng)))
+}
+
+// pickPool picks one glyph from the non-empty pool, weighted for floor.
+func pickPool(pool []component.LootEntry, floor int, rng *rand.Rand) string {
+	total := 0
+	for _, d := range pool {
+		total += poolWeight(d, floor)
+	}
+	roll := rng.Intn(total)
+	for _, d := range pool {
+		roll -= poolWeight(d, floor)
+		if roll < 0 {
+			return d.Glyph
+		}
+	}
+	return pool[len(pool)-1].Glyph
+}
+
+// CommonLootItem rolls one consumable from assets.CommonLootPool as if an
+// ordinary enemy on floor had dropped it.
+func CommonLootItem(floor int, rng *rand.Rand) component.Item {
+	var pool []component.LootEntry
+	for _, d := range assets.CommonLootPool {
+		if floor >= d.MinFloor {
+			pool = append(pool, lootEntry(d))
+		}
+	}
+	return lootItem(pickPool(pool, floor, rng))
+}
+
+// LootOdds is one drop in a loot preview. Chance is 100 for guaranteed drops.
+type LootOdds struct {
+	Glyph  string
+	Chance int
+}
+
+// PreviewLoot lists the drops RollLoot can produce for loot on floor: the
+// guaranteed and plain entries with their chances, in table order, and the
+// chance of one extra pick from the weighted pool (0 when no pool entry is
+// eligible).
+func PreviewLoot(loot component.Loot, floor int) (drops []LootOdds, poolChance int) {
+	pooled := false
+	for _, d := range loot.Drops {
+		switch {
+		case d.Guaranteed:
+			drops = append(drops, LootOdds{Glyph: d.Glyph, Chance: 100})
+		case d.Weight > 0:
+			pooled = pooled || floor >= d.MinFloor
+		case d.Chance > 0:
+			drops = append(drops, LootOdds{Glyph: d.Glyph, Chance: min(d.Chance, 100)})
+		}
+	}
+	if pooled && loot.PoolChance > 0 {
+		poolChance = min(loot.PoolChance+floor*LootPoolFloorBonus, 100)
+	}
+	return drops, poolChance
+}
+
+// poolWeight returns the effective weight of a pool entry on floor.
+func poolWeight(d component.LootEntry, floor int) int {
+	if d.Rare {
+		return d.Weight * max(floor, 1)
+	}
+	return d.Weight * lootCommonWeightScale
+}
+
+func lootItem(glyph string) component.Item {
+	return component.Item{
+		Name:         assets.ConsumableName(glyph),
+		Glyph:        glyph,
+		Slot:         component.SlotConsumable,
+		IsConsumable: true,
+	}
+}
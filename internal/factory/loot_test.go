@@ -0,0 +1,156 @@
+package factory
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/generate"
+	"math/rand"
+	"testing"
+)
+
+func countGlyph(items []component.Item, glyph string) int {
+	n := 0
+	for _, it := range items {
+		if it.Glyph == glyph {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRollLootEliteSignatureDropRate(t *testing.T) {
+	const trials = 4000
+	for floor := 1; floor <= 10; floor++ {
+		elite := assets.FloorElite(floor)
+		sig := elite.Drops[0]
+		w := ecs.NewWorld()
+		id := NewEnemy(w, *elite, 0, 0, nil)
+		loot := w.Get(id, component.CLoot).(component.Loot)
+		// A guaranteed entry may share the signature glyph; only count extras.
+		base := 0
+		for _, d := range loot.Drops {
+			if d.Guaranteed && d.Glyph == sig.Glyph {
+				base++
+			}
+		}
+
+		rng := rand.New(rand.NewSource(int64(floor)))
+		hits := 0
+		for range trials {
+			items := RollLoot(loot, floor, rng)
+			if countGlyph(items, sig.Glyph) > base {
+				hits++
+			}
+		}
+		got := hits * 100 / trials
+		if got < sig.Chance-4 || got > sig.Chance+4 {
+			t.Errorf("floor %d %s: signature %s dropped %d%% of the time, want ~%d%%",
+				floor, elite.Name, sig.Glyph, got, sig.Chance)
+		}
+	}
+}
+
+func TestRollLootGuaranteedAlwaysDrops(t *testing.T) {
+	elite := assets.FloorElite(1)
+	w := ecs.NewWorld()
+	id := NewEnemy(w, *elite, 0, 0, nil)
+	loot := w.Get(id, component.CLoot).(component.Loot)
+	rng := rand.New(rand.NewSource(7))
+	for range 200 {
+		items := RollLoot(loot, 1, rng)
+		if countGlyph(items, assets.GlyphHyperflask) == 0 {
+			t.Fatal("elite guaranteed Hyperflask must drop on every kill")
+		}
+	}
+}
+
+func TestRollLootPoolRespectsMinFloor(t *testing.T) {
+	loot := component.Loot{
+		Drops: []component.LootEntry{
+			{Glyph: "a", Weight: 1},
+			{Glyph: "b", Weight: 1, MinFloor: 5},
+		},
+		PoolChance: 100,
+	}
+	rng := rand.New(rand.NewSource(3))
+	for range 200 {
+		items := RollLoot(loot, 4, rng)
+		if len(items) != 1 || items[0].Glyph != "a" {
+			t.Fatalf("floor 4 pool roll = %v, want exactly one 'a'", items)
+		}
+	}
+}
+
+func TestCommonLootItemRespectsMinFloor(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	for range 200 {
+		it := CommonLootItem(1, rng)
+		if !it.IsConsumable {
+			t.Fatalf("CommonLootItem = %+v, want a consumable", it)
+		}
+		for _, d := range assets.CommonLootPool {
+			if d.Glyph == it.Glyph && d.MinFloor > 1 {
+				t.Fatalf("floor 1 rolled %s, which unlocks on floor %d", it.Glyph, d.MinFloor)
+			}
+		}
+	}
+}
+
+func TestRollLootRareWeightGrowsWithFloor(t *testing.T) {
+	loot := component.Loot{
+		Drops: []component.LootEntry{
+			{Glyph: "common", Weight: 1},
+			{Glyph: "rare", Weight: 1, Rare: true},
+		},
+		PoolChance: 100,
+	}
+	rate := func(floor int) int {
+		rng := rand.New(rand.NewSource(11))
+		hits := 0
+		for range 2000 {
+			hits += countGlyph(RollLoot(loot, floor, rng), "rare")
+		}
+		return hits
+	}
+	if shallow, deep := rate(1), rate(10); deep <= shallow {
+		t.Errorf("rare drops on floor 10 (%d) should exceed floor 1 (%d)", deep, shallow)
+	}
+}
+
+func TestNewEnemyJoinsCommonPoolByThreat(t *testing.T) {
+	w := ecs.NewWorld()
+	id := NewEnemy(w, generate.EnemySpawnEntry{Glyph: "👾", ThreatCost: 3, MaxHP: 5}, 0, 0, nil)
+	lc := w.Get(id, component.CLoot)
+	if lc == nil {
+		t.Fatal("enemy with threat cost must carry the common loot pool")
+	}
+	loot := lc.(component.Loot)
+	if loot.PoolChance != 3*lootPoolPerThreat {
+		t.Errorf("PoolChance = %d, want %d", loot.PoolChance, 3*lootPoolPerThreat)
+	}
+	if len(loot.Drops) != len(assets.CommonLootPool) {
+		t.Errorf("Drops = %d entries, want %d", len(loot.Drops), len(assets.CommonLootPool))
+	}
+}
+
+func TestPreviewLootListsOddsAndPool(t *testing.T) {
+	loot := component.Loot{
+		Drops: []component.LootEntry{
+			{Glyph: "💎", Guaranteed: true},
+			{Glyph: "🧪", Chance: 25},
+			{Glyph: "🗡️", Weight: 1, MinFloor: 5},
+		},
+		PoolChance: 10,
+	}
+	drops, pool := PreviewLoot(loot, 2)
+	if len(drops) != 2 || drops[0] != (LootOdds{"💎", 100}) || drops[1] != (LootOdds{"🧪", 25}) {
+		t.Errorf("drops = %v; want 💎 at 100 and 🧪 at 25", drops)
+	}
+	if pool != 0 {
+		t.Errorf("pool chance on floor 2 = %d; want 0 before the entry's MinFloor", pool)
+	}
+	if _, pool = PreviewLoot(loot, 5); pool != 10+5*LootPoolFloorBonus {
+		t.Errorf("pool chance on floor 5 = %d; want %d", pool, 10+5*LootPoolFloorBonus)
+	}
+}
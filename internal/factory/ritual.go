@@ -0,0 +1,33 @@
+package factory
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// NewAltar creates a ritual altar at (x, y). It asks for
+// assets.AltarOfferings of assets.AltarOffering and, once they are all
+// placed, grants its furniture bonus like any other furniture.
+func NewAltar(w *ecs.World, x, y int) ecs.EntityID {
+	id := w.CreateEntity()
+	w.Add(id, component.Position{X: x, Y: y})
+	w.Add(id, component.Renderable{
+		Glyph:       assets.GlyphAltar,
+		FGColor:     tcell.ColorFuchsia,
+		BGColor:     tcell.ColorDefault,
+		RenderOrder: 1,
+	})
+	w.Add(id, component.Furniture{
+		Glyph:       assets.GlyphAltar,
+		Name:        "Shard Altar",
+		Description: "A basalt altar with three hollows cut to the shape of a prism shard.",
+		BonusATK:    1,
+		BonusDEF:    1,
+		BonusMaxHP:  5,
+	})
+	w.Add(id, component.Ritual{Offering: assets.AltarOffering, Needed: assets.AltarOfferings})
+	return id
+}
@@ -0,0 +1,99 @@
+package factory
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"math/rand"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	// VendingStockSize is how many consumables a fresh vending machine holds.
+	VendingStockSize = 2
+	// VendingMarkupPercent is added to catalogue prices for the convenience
+	// of buying mid-dive.
+	VendingMarkupPercent = 25
+)
+
+// NewVendingMachine creates a vending machine at (x, y) stocked with
+// VendingStockSize distinct consumables drawn from assets.ShopCatalogue.
+func NewVendingMachine(w *ecs.World, rng *rand.Rand, x, y int) ecs.EntityID {
+	var pool []assets.ShopEntry
+	for _, e := range assets.ShopCatalogue {
+		if e.IsConsumable {
+			pool = append(pool, e)
+		}
+	}
+	rng.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+	var stock []component.VendingSlot
+	for _, e := range pool[:min(VendingStockSize, len(pool))] {
+		stock = append(stock, component.VendingSlot{
+			Glyph: e.Glyph,
+			Name:  e.Name,
+			Price: e.Price * (100 + VendingMarkupPercent) / 100,
+		})
+	}
+
+	id := w.CreateEntity()
+	w.Add(id, component.Position{X: x, Y: y})
+	w.Add(id, component.Renderable{
+		Glyph:       assets.GlyphVendingMachine,
+		FGColor:     tcell.ColorAqua,
+		BGColor:     tcell.ColorDefault,
+		RenderOrder: 1,
+	})
+	w.Add(id, component.Furniture{
+		Glyph:       assets.GlyphVendingMachine,
+		Name:        "Vending Machine",
+		Description: "A humming cabinet of salvaged supplies. It still takes gold.",
+	})
+	w.Add(id, component.VendingMachine{Stock: stock})
+	return id
+}
+
+// ShopItem converts a shop catalogue entry into the item placed in the
+// buyer's backpack.
+func ShopItem(e assets.ShopEntry) component.Item {
+	if e.IsConsumable {
+		return component.Item{
+			Name:         e.Name,
+			Glyph:        e.Glyph,
+			Slot:         component.SlotConsumable,
+			IsConsumable: true,
+		}
+	}
+	slot := shopSlot(e.Slot)
+	return component.Item{
+		Name:          e.Name,
+		Glyph:         e.Glyph,
+		Slot:          slot,
+		BonusATK:      e.BonusATK,
+		BonusDEF:      e.BonusDEF,
+		BonusMaxHP:    e.BonusMaxHP,
+		IsConsumable:  false,
+		Durability:    assets.Durability(uint8(slot)),
+		MaxDurability: assets.Durability(uint8(slot)),
+	}
+}
+
+// shopSlot converts the string slot name used in ShopEntry to the
+// component.ItemSlot constant.
+func shopSlot(s string) component.ItemSlot {
+	switch s {
+	case "head":
+		return component.SlotHead
+	case "body":
+		return component.SlotBody
+	case "feet":
+		return component.SlotFeet
+	case "onehand":
+		return component.SlotOneHand
+	case "twohand":
+		return component.SlotTwoHand
+	case "offhand":
+		return component.SlotOffHand
+	}
+	return component.SlotConsumable
+}
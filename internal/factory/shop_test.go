@@ -0,0 +1,52 @@
+package factory
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"math/rand"
+	"testing"
+)
+
+func TestNewVendingMachineStock(t *testing.T) {
+	prices := make(map[string]int)
+	for _, e := range assets.ShopCatalogue {
+		if e.IsConsumable {
+			prices[e.Glyph] = e.Price
+		}
+	}
+	w := ecs.NewWorld()
+	id := NewVendingMachine(w, rand.New(rand.NewSource(5)), 3, 4)
+
+	if !w.Has(id, component.CFurniture) {
+		t.Error("vending machine must be furniture so players can bump it")
+	}
+	vm := w.Get(id, component.CVending).(component.VendingMachine)
+	if len(vm.Stock) != VendingStockSize {
+		t.Fatalf("stock size = %d, want %d", len(vm.Stock), VendingStockSize)
+	}
+	seen := make(map[string]bool)
+	for _, slot := range vm.Stock {
+		base, ok := prices[slot.Glyph]
+		if !ok {
+			t.Errorf("%s is not a catalogue consumable", slot.Name)
+			continue
+		}
+		if seen[slot.Glyph] {
+			t.Errorf("%s stocked twice", slot.Name)
+		}
+		seen[slot.Glyph] = true
+		if want := base * (100 + VendingMarkupPercent) / 100; slot.Price != want {
+			t.Errorf("%s price = %d, want %d", slot.Name, slot.Price, want)
+		}
+	}
+}
+
+func TestShopItemEquipmentSlot(t *testing.T) {
+	for _, e := range assets.ShopCatalogue {
+		it := ShopItem(e)
+		if e.IsConsumable != (it.Slot == component.SlotConsumable) {
+			t.Errorf("%s: consumable=%v but slot=%v", e.Name, e.IsConsumable, it.Slot)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package game
+
+import (
+	"time"
+
+	"emoji-roguelike/internal/component"
+)
+
+// BellInterval is the least time between two rings of the terminal bell, so
+// a burst of events makes one sound.
+const BellInterval = 2 * time.Second
+
+// Bell decides when to ring the terminal bell for one player, for those who
+// turn audio cues on. Death always rings; see Cue for the rest.
+type Bell struct {
+	last  time.Time // when the bell last rang
+	lowHP bool      // HP already reported low; cleared once it recovers
+	spent int       // ability charges spent as of the last Cue
+}
+
+// Cue reports whether the player's turn should ring the bell: their HP
+// (hp) has just fallen low, or an ability charge came back, spent having
+// dropped since the last call. Rings are rate-limited to one per
+// BellInterval.
+func (b *Bell) Cue(hp component.Health, spent int, now time.Time) bool {
+	low := hp.Low()
+	ready := spent < b.spent
+	warn := (low && !b.lowHP) || ready
+	b.lowHP, b.spent = low, spent
+	if !warn || (!b.last.IsZero() && now.Sub(b.last) < BellInterval) {
+		return false
+	}
+	b.last = now
+	return true
+}
+
+// soundCues rings the terminal bell for this turn's notable events when the
+// player has the bell on: HP falling low, an ability charge returning, or
+// death.
+func (g *Game) soundCues() {
+	if !g.profile.Bell {
+		return
+	}
+	hc := g.world.Get(g.playerID, component.CHealth)
+	if g.state == StateDead || (hc != nil && g.bell.Cue(hc.(component.Health), g.specialSpent, time.Now())) {
+		_ = g.screen.Beep()
+	}
+}
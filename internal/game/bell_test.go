@@ -0,0 +1,57 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"emoji-roguelike/internal/component"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestBellWarnsOnceWhenHPFallsLow(t *testing.T) {
+	var b Bell
+	now := time.Now()
+	if b.Cue(component.Health{Current: 20, Max: 20}, 0, now) {
+		t.Error("full HP should not ring")
+	}
+	if !b.Cue(component.Health{Current: 5, Max: 20}, 0, now) {
+		t.Error("HP falling to a quarter should ring")
+	}
+	if b.Cue(component.Health{Current: 4, Max: 20}, 0, now.Add(time.Minute)) {
+		t.Error("HP staying low should not ring again")
+	}
+	b.Cue(component.Health{Current: 15, Max: 20}, 0, now.Add(2*time.Minute))
+	if !b.Cue(component.Health{Current: 3, Max: 20}, 0, now.Add(3*time.Minute)) {
+		t.Error("HP falling low again after recovering should ring")
+	}
+}
+
+func TestBellRingsForRechargedAbilityRateLimited(t *testing.T) {
+	var b Bell
+	now := time.Now()
+	hp := component.Health{Current: 20, Max: 20}
+	b.Cue(hp, 2, now)
+	if !b.Cue(hp, 1, now) {
+		t.Error("a charge coming back should ring")
+	}
+	if b.Cue(hp, 0, now.Add(BellInterval/2)) {
+		t.Errorf("a second ring within %v should be suppressed", BellInterval)
+	}
+	b.Cue(hp, 1, now.Add(BellInterval))
+	if !b.Cue(hp, 0, now.Add(2*BellInterval)) {
+		t.Error("a charge coming back after the interval should ring")
+	}
+}
+
+func TestSettingsTogglesBell(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	g := newAbilityTestGame(t, "warden")
+	ss := g.screen.(tcell.SimulationScreen)
+	ss.InjectKey(tcell.KeyRune, '9', tcell.ModNone) // Terminal bell: Off → On
+	ss.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	g.runSettingsMenu()
+	if !loadProfile().Bell {
+		t.Error("terminal bell setting should be saved to the profile")
+	}
+}
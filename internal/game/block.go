@@ -0,0 +1,35 @@
+package game
+
+import (
+	"fmt"
+
+	"emoji-roguelike/internal/system"
+)
+
+// BlockMessage tells a player what their block grants, given the parry
+// chance from their shield (0 for none).
+func BlockMessage(parry int) string {
+	if parry > 0 {
+		return "You raise your shield. " + blockTag(parry)
+	}
+	return "You brace yourself. " + blockTag(parry)
+}
+
+// blockTag sums up a block's DEF and parry chance.
+func blockTag(parry int) string {
+	if parry > 0 {
+		return fmt.Sprintf("(+%d DEF, %d%% parry)", system.BlockDEF, parry)
+	}
+	return fmt.Sprintf("(+%d DEF)", system.BlockDEF)
+}
+
+// block spends the player's turn bracing for the enemies' next blows.
+func (g *Game) block() {
+	g.addMessage(BlockMessage(system.Block(g.world, g.playerID)))
+}
+
+// coopBlock is block for co-op player p.
+func (g *CoopGame) coopBlock(p *coopPlayer) {
+	parry := system.Block(g.world, p.id)
+	g.addMessage(fmt.Sprintf("%s braces for the blow. %s", p.class.Name, blockTag(parry)))
+}
@@ -0,0 +1,43 @@
+package game
+
+import (
+	"testing"
+
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/system"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestBlockSpendsTurnBracing(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	killAllEnemies(g)
+	if got := keyToAction(tcell.NewEventKey(tcell.KeyRune, 'p', tcell.ModNone)); got != ActionBlock {
+		t.Fatalf("keyToAction('p') = %v; want ActionBlock", got)
+	}
+	turns := g.runLog.TurnsPlayed
+
+	g.processAction(ActionBlock)
+	if g.runLog.TurnsPlayed != turns+1 {
+		t.Errorf("turns played %d; want blocking to take a turn", g.runLog.TurnsPlayed-turns)
+	}
+	if got := system.GetDefenseBonus(g.world, g.playerID); got != system.BlockDEF {
+		t.Errorf("DEF bonus through the enemy turn = %d; want %d", got, system.BlockDEF)
+	}
+}
+
+func TestBlockWithShieldRaisesParry(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	killAllEnemies(g)
+	inv := g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	inv.OffHand = component.Item{Name: "Phase Mirror", Slot: component.SlotOffHand, BonusDEF: 3}
+	g.world.Add(g.playerID, inv)
+
+	g.processAction(ActionBlock)
+	if !system.HasEffect(g.world, g.playerID, component.EffectParry) {
+		t.Error("blocking with a shield should leave a parry up through the enemy turn")
+	}
+	if want := BlockMessage(system.ParryChance(g.world, g.playerID)); g.messages[len(g.messages)-1] != want {
+		t.Errorf("last message %q; want %q", g.messages[len(g.messages)-1], want)
+	}
+}
@@ -0,0 +1,48 @@
+package game
+
+import (
+	"emoji-roguelike/internal/render"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// cameraMode returns the renderer camera mode for the current settings.
+func (g *Game) cameraMode() render.CameraMode {
+	switch {
+	case g.freeLook:
+		return render.CameraFreeLook
+	case g.profile.CameraEdgeScroll:
+		return render.CameraLocked
+	}
+	return render.CameraFollow
+}
+
+// runFreeLook detaches the camera so the player can pan over the revealed map
+// with the movement keys. It takes no turn; Esc or any other key returns the
+// view to the player under the usual camera mode.
+func (g *Game) runFreeLook() {
+	g.freeLook = true
+	defer func() { g.freeLook = false }()
+	pos := g.playerPosition()
+	fx, fy := pos.X, pos.Y
+	for {
+		g.drawPlay()
+		g.putText(0, 0, "Free look — [direction] pan  [Esc] back", tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true))
+		g.screen.Show()
+
+		switch ev := g.screen.PollEvent().(type) {
+		case *tcell.EventResize:
+			g.screen.Sync()
+		case *tcell.EventKey:
+			dx, dy := actionToDelta(keyToAction(ev))
+			if dx == 0 && dy == 0 {
+				return
+			}
+			// Keep the view's focus on the map so it cannot drift into the void.
+			nx := max(0, min(fx+dx, g.gmap.Width-1))
+			ny := max(0, min(fy+dy, g.gmap.Height-1))
+			g.renderer.Pan(nx-fx, ny-fy)
+			fx, fy = nx, ny
+		}
+	}
+}
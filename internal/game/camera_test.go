@@ -0,0 +1,83 @@
+package game
+
+import (
+	"testing"
+
+	"emoji-roguelike/internal/component"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestFreeLookPansWithoutSpendingTurn(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	pos := g.playerPosition()
+	g.drawPlay()
+	cx, cy := g.renderer.ViewCenter()
+	turns := g.runLog.TurnsPlayed
+
+	ss := g.screen.(tcell.SimulationScreen)
+	ss.InjectKey(tcell.KeyRune, 'j', tcell.ModNone)
+	ss.InjectKey(tcell.KeyRune, 'j', tcell.ModNone)
+	ss.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	g.runFreeLook()
+
+	if x, y := g.renderer.ViewCenter(); x != cx || y != cy+min(2, g.gmap.Height-1-pos.Y) {
+		t.Errorf("view centre = (%d,%d); want panned south from (%d,%d)", x, y, cx, cy)
+	}
+	if g.runLog.TurnsPlayed != turns || g.playerPosition() != pos {
+		t.Error("free look should not move the player or spend a turn")
+	}
+	g.drawPlay()
+	if x, y := g.renderer.ViewCenter(); x != cx || y != cy {
+		t.Errorf("after free look the view centre = (%d,%d); want it back on (%d,%d)", x, y, cx, cy)
+	}
+}
+
+func TestEdgeScrollHoldsViewUntilNearEdge(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	g.profile.CameraEdgeScroll = true
+	g.drawPlay()
+	cx, cy := g.renderer.ViewCenter()
+
+	pos := g.playerPosition()
+	g.world.Add(g.playerID, component.Position{X: pos.X + 1, Y: pos.Y})
+	g.drawPlay()
+	if x, y := g.renderer.ViewCenter(); x != cx || y != cy {
+		t.Errorf("edge scroll moved the view to (%d,%d) for a one-tile step; want (%d,%d)", x, y, cx, cy)
+	}
+
+	g.profile.CameraEdgeScroll = false
+	g.drawPlay()
+	if x, _ := g.renderer.ViewCenter(); x != cx+1 {
+		t.Errorf("follow mode view centre x = %d; want %d", x, cx+1)
+	}
+}
+
+// TestResizeMidFightRefitsView grows the terminal during a fight, checks the
+// view refits around the player, then shrinks it to a sliver to make sure
+// drawing a turn there neither panics nor hangs.
+func TestResizeMidFightRefitsView(t *testing.T) {
+	g, crab := newStrikeGame(t)
+	ss := g.screen.(tcell.SimulationScreen)
+
+	ss.SetSize(120, 40)
+	g.processAction(ActionWait)
+	g.drawPlay()
+	pos := g.playerPosition()
+	if sx, sy, ok := g.renderer.WorldToScreen(pos.X, pos.Y); !ok || sx != 60 || sy != 17 {
+		t.Errorf("player drawn at (%d,%d) visible=%v; want the centre (60,17) of a 120x35 view", sx, sy, ok)
+	}
+	if !strikeShown(g, crab) {
+		t.Error("the strike highlight should survive a resize")
+	}
+
+	ss.SetSize(1, 2)
+	g.processAction(ActionWait)
+	g.drawPlay()
+
+	ss.SetSize(80, 24)
+	g.drawPlay()
+	if _, _, ok := g.renderer.WorldToScreen(pos.X, pos.Y); !ok {
+		t.Error("the player should be back in view after restoring the size")
+	}
+}
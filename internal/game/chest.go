@@ -0,0 +1,217 @@
+package game
+
+import (
+	"fmt"
+
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/factory"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// chestTitle heads the treasure chest modal.
+const chestTitle = "🧰 TREASURE CHEST"
+
+// ChestMessage announces the chest a slain boss or elite, shown as glyph,
+// left behind.
+func ChestMessage(glyph string) string {
+	return fmt.Sprintf("The %s leaves a %s treasure chest behind! Press [,] on it to choose your reward.", glyph, assets.GlyphChest)
+}
+
+// ChestAt returns the treasure chest at pos, if there is one.
+func ChestAt(w *ecs.World, pos component.Position) (ecs.EntityID, bool) {
+	for _, id := range w.Query(component.CChest, component.CPosition) {
+		if w.Get(id, component.CPosition).(component.Position) == pos {
+			return id, true
+		}
+	}
+	return ecs.NilEntity, false
+}
+
+// RunChestModal lets the player pick one of rewards to keep, blocking until
+// they take one or close the chest (Esc/q, or a nil event from nextEvent).
+// The chosen reward is stowed in inv. Returns its index, or -1 if the player
+// took nothing.
+func RunChestModal(screen tcell.Screen, nextEvent func() tcell.Event, rewards []component.Item, inv *component.Inventory) int {
+	cursor := 0
+	statusMsg := ""
+	take := func(idx int) bool {
+		if !inv.Stow(rewards[idx]) {
+			statusMsg = "Backpack full! Drop something first."
+			return false
+		}
+		return true
+	}
+	for {
+		DrawChestScreen(screen, rewards, cursor, *inv, statusMsg)
+
+		ev := nextEvent()
+		if ev == nil {
+			return -1
+		}
+		statusMsg = ""
+		switch ev := ev.(type) {
+		case *tcell.EventResize:
+			screen.Sync()
+		case *tcell.EventKey:
+			switch ev.Key() {
+			case tcell.KeyEscape:
+				return -1
+			case tcell.KeyUp:
+				cursor = max(cursor-1, 0)
+			case tcell.KeyDown:
+				cursor = min(cursor+1, len(rewards)-1)
+			case tcell.KeyEnter:
+				if take(cursor) {
+					return cursor
+				}
+			default:
+				r := ev.Rune()
+				switch {
+				case r == 'q' || r == 'Q':
+					return -1
+				case r == 'k' || r == 'K':
+					cursor = max(cursor-1, 0)
+				case r == 'j' || r == 'J':
+					cursor = min(cursor+1, len(rewards)-1)
+				case r >= 'a' && int(r-'a') < len(rewards):
+					cursor = int(r - 'a')
+					if take(cursor) {
+						return cursor
+					}
+				}
+			}
+		}
+	}
+}
+
+// takeKeys returns the hint for the letter keys that take one of n rewards:
+// "[a-c]" for three, "[a]" for one.
+func takeKeys(n int) string {
+	if n <= 1 {
+		return "[a]"
+	}
+	return fmt.Sprintf("[a-%c]", 'a'+rune(n-1))
+}
+
+// DrawChestScreen renders the treasure chest modal, weighing the reward
+// under the cursor against the gear inv has on.
+func DrawChestScreen(screen tcell.Screen, rewards []component.Item, cursor int, inv component.Inventory, statusMsg string) {
+	screen.Clear()
+	sw, _ := screen.Size()
+
+	white := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	gray := tcell.StyleDefault.Foreground(tcell.ColorGray)
+	gold := tcell.StyleDefault.Foreground(tcell.ColorGold)
+	green := tcell.StyleDefault.Foreground(tcell.ColorGreen)
+	highlight := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorGold)
+
+	put := func(x, y int, s string, style tcell.Style) { drawScreenText(screen, x, y, s, style) }
+
+	put(0, 0, chestTitle+"  Choose one reward to keep", gold)
+	hints := "[j/k] Move  " + takeKeys(len(rewards)) + " Take  [Enter] Take selected  [Esc] Later"
+	if len([]rune(hints)) < sw {
+		put(sw-len([]rune(hints)), 0, hints, gray)
+	}
+	for x := range sw {
+		screen.SetContent(x, 1, '─', nil, gray)
+	}
+
+	for i, item := range rewards {
+		style, pfx := white, "  "
+		if i == cursor {
+			style, pfx = highlight, "► "
+		}
+		tag := "consumable"
+		if !item.IsConsumable {
+			tag = "equip"
+			if item.BonusATK != 0 {
+				tag += fmt.Sprintf(" ATK%+d", item.BonusATK)
+			}
+			if item.BonusDEF != 0 {
+				tag += fmt.Sprintf(" DEF%+d", item.BonusDEF)
+			}
+			if item.BonusMaxHP != 0 {
+				tag += fmt.Sprintf(" HP%+d", item.BonusMaxHP)
+			}
+		}
+		put(0, 2+i, fmt.Sprintf("%s[%c] %s %-20s  [%s]", pfx, 'a'+rune(i), item.Glyph, item.Name, tag), style)
+	}
+
+	row := 2 + len(rewards)
+	for x := range sw {
+		screen.SetContent(x, row, '─', nil, gray)
+	}
+	if cursor >= 0 && cursor < len(rewards) {
+		DrawEquipComparison(screen, row+1, inv, rewards[cursor])
+	}
+	if statusMsg != "" {
+		put(0, row+3, statusMsg, green)
+	}
+	screen.Show()
+}
+
+// dropChest leaves a treasure chest at pos when the enemy with glyph was a
+// boss or elite.
+func (g *Game) dropChest(glyph string, pos component.Position) {
+	if !assets.DropsChest(glyph, g.floor) {
+		return
+	}
+	factory.NewChest(g.world, component.Chest{Rewards: factory.ChestRewards(g.floor, g.combatRng)}, pos.X, pos.Y)
+	g.addMessage(ChestMessage(glyph))
+}
+
+// openChest opens the treasure chest under the player, if any, and reports
+// whether there was one. The chest is gone once the player takes a reward.
+func (g *Game) openChest() bool {
+	id, ok := ChestAt(g.world, g.playerPosition())
+	if !ok {
+		return false
+	}
+	invComp := g.world.Get(g.playerID, component.CInventory)
+	if invComp == nil {
+		return true
+	}
+	inv := invComp.(component.Inventory)
+	rewards := g.world.Get(id, component.CChest).(component.Chest).Rewards
+	if idx := RunChestModal(g.screen, g.screen.PollEvent, rewards, &inv); idx >= 0 {
+		g.world.Add(g.playerID, inv)
+		g.world.DestroyEntity(id)
+		g.addMessage(fmt.Sprintf("You take %s %s from the chest.", rewards[idx].Glyph, rewards[idx].Name))
+	}
+	return true
+}
+
+// coopDropChest leaves a treasure chest at pos when the enemy with glyph was
+// a boss or elite. Coop chests are shared: whichever player opens it first
+// chooses.
+func (g *CoopGame) coopDropChest(glyph string, pos component.Position) {
+	if !assets.DropsChest(glyph, g.floor) {
+		return
+	}
+	factory.NewChest(g.world, component.Chest{Rewards: factory.ChestRewards(g.floor, g.combatRng)}, pos.X, pos.Y)
+	g.addMessage(ChestMessage(glyph))
+}
+
+// coopOpenChest opens the treasure chest under p on p's screen, if any, and
+// reports whether there was one.
+func (g *CoopGame) coopOpenChest(p *coopPlayer) bool {
+	id, ok := ChestAt(g.world, g.coopPlayerPosition(p))
+	if !ok {
+		return false
+	}
+	invComp := g.world.Get(p.id, component.CInventory)
+	if invComp == nil {
+		return true
+	}
+	inv := invComp.(component.Inventory)
+	rewards := g.world.Get(id, component.CChest).(component.Chest).Rewards
+	if idx := RunChestModal(p.screen, func() tcell.Event { return <-p.events }, rewards, &inv); idx >= 0 {
+		g.world.Add(p.id, inv)
+		g.world.DestroyEntity(id)
+		g.addMessage(fmt.Sprintf("%s takes %s %s from the chest.", p.class.Name, rewards[idx].Glyph, rewards[idx].Name))
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// dropTestChest has a floor-1 elite leave its chest under the player and
+// returns the chest's rewards.
+func dropTestChest(t *testing.T, g *Game) []component.Item {
+	t.Helper()
+	elite := assets.EliteEnemy(g.floor)
+	if elite == nil {
+		t.Fatalf("floor %d has no elite", g.floor)
+	}
+	g.dropChest(elite.Glyph, g.playerPosition())
+	id, ok := ChestAt(g.world, g.playerPosition())
+	if !ok {
+		t.Fatal("slain elite left no chest")
+	}
+	return g.world.Get(id, component.CChest).(component.Chest).Rewards
+}
+
+func TestEliteLeavesChestOrdinaryEnemyDoesNot(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	g.dropChest(assets.EnemyTable(1)[0].Glyph, g.playerPosition())
+	if _, ok := ChestAt(g.world, g.playerPosition()); ok {
+		t.Fatal("an ordinary enemy should not leave a chest")
+	}
+	if rewards := dropTestChest(t, g); len(rewards) != assets.ChestChoices {
+		t.Errorf("chest offers %d rewards; want %d", len(rewards), assets.ChestChoices)
+	}
+}
+
+func TestOpeningChestKeepsChosenReward(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	rewards := dropTestChest(t, g)
+
+	g.screen.(tcell.SimulationScreen).InjectKey(tcell.KeyRune, 'b', tcell.ModNone)
+	g.tryPickup()
+
+	inv := g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	if len(inv.Backpack) == 0 || inv.Backpack[len(inv.Backpack)-1].Name != rewards[1].Name {
+		t.Errorf("backpack %v; want the second reward, %s", inv.Backpack, rewards[1].Name)
+	}
+	if _, ok := ChestAt(g.world, g.playerPosition()); ok {
+		t.Error("chest should be gone once a reward is taken")
+	}
+}
+
+func TestClosingChestLeavesItForLater(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	dropTestChest(t, g)
+	before := len(g.world.Get(g.playerID, component.CInventory).(component.Inventory).Backpack)
+
+	g.screen.(tcell.SimulationScreen).InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	g.tryPickup()
+
+	if after := len(g.world.Get(g.playerID, component.CInventory).(component.Inventory).Backpack); after != before {
+		t.Errorf("backpack went from %d to %d items; closing the chest should take nothing", before, after)
+	}
+	if _, ok := ChestAt(g.world, g.playerPosition()); !ok {
+		t.Error("a closed chest should stay for later")
+	}
+}
+
+func TestChestWithFullBackpackStaysOpen(t *testing.T) {
+	rewards := []component.Item{{Name: "Helm", Slot: component.SlotHead}}
+	inv := component.Inventory{Capacity: 0}
+	ss := newSimScreen()
+	events := []tcell.Event{
+		tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone),
+	}
+	next := func() tcell.Event {
+		ev := events[0]
+		events = events[1:]
+		return ev
+	}
+	if idx := RunChestModal(ss, next, rewards, &inv); idx != -1 || len(inv.Backpack) != 0 {
+		t.Errorf("RunChestModal = %d with %d stowed; want nothing taken into a full backpack", idx, len(inv.Backpack))
+	}
+	if len(events) != 0 {
+		t.Error("the modal should stay open after a pick that does not fit")
+	}
+}
+
+func TestChestHintMatchesRewardCount(t *testing.T) {
+	for _, tc := range []struct {
+		rewards int
+		want    string
+	}{{1, "[a] Take"}, {2, "[a-b] Take"}, {4, "[a-d] Take"}} {
+		ss := newSimScreen()
+		DrawChestScreen(ss, make([]component.Item, tc.rewards), 0, component.Inventory{}, "")
+		if header := screenRows(ss)[0]; !strings.Contains(header, tc.want) {
+			t.Errorf("%d rewards: header %q; want it to offer %q", tc.rewards, header, tc.want)
+		}
+	}
+}
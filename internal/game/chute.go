@@ -0,0 +1,53 @@
+package game
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+	"fmt"
+)
+
+// onChute reports whether pos is a chute tile on gmap.
+func onChute(gmap *gamemap.GameMap, pos component.Position) bool {
+	return gmap.InBounds(pos.X, pos.Y) && gmap.At(pos.X, pos.Y).Kind == gamemap.TileChute
+}
+
+// landFall deals id the chute's fall damage, never dropping it below 1 HP, and
+// returns the damage dealt.
+func landFall(w *ecs.World, id ecs.EntityID) int {
+	hc := w.Get(id, component.CHealth)
+	if hc == nil {
+		return 0
+	}
+	hp := hc.(component.Health)
+	dmg := min(assets.ChuteFallDamage, hp.Current-1)
+	hp.Current -= dmg
+	w.Add(id, hp)
+	return dmg
+}
+
+// fallDownChute drops the player two floors down, skipping the merchant and
+// the floor between, and deals fall damage on landing.
+func (g *Game) fallDownChute() {
+	from := g.floor
+	g.addMessage("The ground gives way — you plunge down a chute!")
+	g.loadFloor(from + 2)
+	if dmg := landFall(g.world, g.playerID); dmg > 0 {
+		g.runLog.DamageTaken += dmg
+		g.runLog.noteDamage(g.runLog.TurnsPlayed, "fall", dmg, g.playerHP())
+		g.addMessage(fmt.Sprintf("You land hard, two floors down. (%d damage)", dmg))
+	}
+	g.offerSubclass(from)
+}
+
+// coopFallDownChute drops the whole party two floors down after p steps into
+// a chute. Only p takes the fall damage.
+func (g *CoopGame) coopFallDownChute(p *coopPlayer) {
+	g.addMessage(fmt.Sprintf("%s plunges down a chute, and the party tumbles after!", p.class.Name))
+	g.loadFloor(g.floor + 2)
+	if dmg := landFall(g.world, p.id); dmg > 0 {
+		p.runLog.DamageTaken += dmg
+		g.addMessage(fmt.Sprintf("%s lands hard, two floors down. (%d damage)", p.class.Name, dmg))
+	}
+}
@@ -0,0 +1,60 @@
+package game
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/gamemap"
+	"testing"
+)
+
+// TestSteppingIntoChuteDropsTwoFloors checks that walking onto a chute lands
+// the player two floors down with fall damage.
+func TestSteppingIntoChuteDropsTwoFloors(t *testing.T) {
+	g := newAbilityTestGame(t, "construct")
+	for _, id := range g.world.Query(component.CAI) {
+		g.world.DestroyEntity(id)
+	}
+	pos := g.playerPosition()
+	var action Action
+	for _, a := range []Action{ActionMoveE, ActionMoveW, ActionMoveS, ActionMoveN} {
+		if dx, dy := actionToDelta(a); g.gmap.IsWalkable(pos.X+dx, pos.Y+dy) {
+			action = a
+			break
+		}
+	}
+	dx, dy := actionToDelta(action)
+	for _, id := range g.world.Query(component.CPosition) {
+		if p := g.world.Get(id, component.CPosition).(component.Position); p.X == pos.X+dx && p.Y == pos.Y+dy {
+			g.world.DestroyEntity(id) // clear the tile of anything that would block or interact
+		}
+	}
+	g.gmap.Set(pos.X+dx, pos.Y+dy, gamemap.MakeChute())
+	g.floorsVisited[3] = true // no floor-entry XP, so no level-up heal
+	before := g.playerHP()
+
+	g.processAction(action)
+
+	if g.floor != 3 {
+		t.Fatalf("floor = %d after the chute; want 3", g.floor)
+	}
+	if got := before - g.playerHP(); got != assets.ChuteFallDamage {
+		t.Errorf("fall cost %d HP; want %d", got, assets.ChuteFallDamage)
+	}
+	if !hasMessage(g, "two floors down") {
+		t.Error("expected a landing message")
+	}
+}
+
+func TestChuteFallIsNeverLethal(t *testing.T) {
+	g := newAbilityTestGame(t, "revenant")
+	hp := g.world.Get(g.playerID, component.CHealth).(component.Health)
+	hp.Current = 3
+	g.world.Add(g.playerID, hp)
+	g.floorsVisited[3] = true
+
+	g.fallDownChute()
+
+	if got := g.playerHP(); got != 1 {
+		t.Errorf("HP after a fall at 3 HP = %d; want 1", got)
+	}
+}
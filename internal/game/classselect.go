@@ -37,11 +37,16 @@ func (g *Game) runClassSelect() bool {
 				selected = (selected - 1 + len(assets.Classes)) % len(assets.Classes)
 			case 'j', 'J':
 				selected = (selected + 1) % len(assets.Classes)
+			case 't', 'T':
+				g.selectedClass = assets.Classes[selected]
+				g.fovRadius = g.selectedClass.FOVRadius
+				g.wantTutorial = true
+				return true
 			case 'q', 'Q':
 				if g.confirmQuit(func() { g.drawClassSelect(selected) }) {
 					return false
 				}
-			case '1', '2', '3', '4', '5', '6':
+			case '1', '2', '3', '4', '5', '6', '7', '8':
 				idx := int(ev.Rune()-'1')
 				if idx >= 0 && idx < len(assets.Classes) {
 					g.selectedClass = assets.Classes[idx]
@@ -56,6 +61,14 @@ func (g *Game) runClassSelect() bool {
 // drawClassSelect renders the full class selection UI to the screen.
 func (g *Game) drawClassSelect(selected int) {
 	DrawClassSelectScreen(g.screen, selected)
+	hint := "[t] Play the tutorial with the highlighted class"
+	if g.wantTutorial {
+		hint = "First time? The tutorial plays after you pick a class."
+	}
+	w, _ := g.screen.Size()
+	y := 4 + len(assets.Classes)*5 + 2
+	drawScreenText(g.screen, max((w-len([]rune(hint)))/2, 0), y, hint, tcell.StyleDefault.Foreground(tcell.ColorGray))
+	g.screen.Show()
 }
 
 // drawClassSelectScreen renders the class selection UI onto any tcell screen.
@@ -118,6 +131,9 @@ func DrawClassSelectScreen(screen tcell.Screen, selected int) {
 			if class.AbilityFreeOnFloor {
 				freeTag = " (free per floor)"
 			}
+			if class.MaxCharges() > 1 {
+				freeTag += fmt.Sprintf(" (%d charges)", class.MaxCharges())
+			}
 			abilityLine = fmt.Sprintf("      [z] %s: %s%s", class.AbilityName, class.AbilityDesc, freeTag)
 		} else {
 			abilityLine = "      [z] —"
@@ -126,7 +142,7 @@ func DrawClassSelectScreen(screen tcell.Screen, selected int) {
 	}
 
 	hintsY := startY + len(assets.Classes)*5 + 1
-	centerText(hintsY, "[j/k or ↑/↓] Navigate   [1-6] Quick-select   [Enter] Confirm   [z] Use ability   [q] Quit", dimStyle)
+	centerText(hintsY, fmt.Sprintf("[j/k or ↑/↓] Navigate   [1-%d] Quick-select   [Enter] Confirm   [z] Use ability   [q] Quit", len(assets.Classes)), dimStyle)
 
 	screen.Show()
 }
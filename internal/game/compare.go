@@ -0,0 +1,95 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"emoji-roguelike/internal/component"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// EquipComparison is how equipping a backpack item would change a loadout:
+// the items it would take off, and the change to the equipment ATK, DEF and
+// MaxHP bonuses.
+type EquipComparison struct {
+	Replaces        []component.Item
+	ATK, DEF, MaxHP int
+}
+
+// CompareEquip compares wearing item against what inv has on in its slot.
+// A two-handed item is weighed against the whole main- and off-hand
+// loadout. Broken gear counts for nothing, on either side. Returns false
+// for items that are not equipment or that the loadout cannot take, such as
+// an off-hand item beside a two-handed weapon.
+func CompareEquip(inv component.Inventory, item component.Item) (EquipComparison, bool) {
+	after := inv
+	var replaced []component.Item
+	switch item.Slot {
+	case component.SlotHead:
+		replaced, after.Head = []component.Item{inv.Head}, item
+	case component.SlotBody:
+		replaced, after.Body = []component.Item{inv.Body}, item
+	case component.SlotFeet:
+		replaced, after.Feet = []component.Item{inv.Feet}, item
+	case component.SlotOneHand:
+		replaced, after.MainHand = []component.Item{inv.MainHand}, item
+	case component.SlotTwoHand:
+		replaced = []component.Item{inv.MainHand, inv.OffHand}
+		after.MainHand, after.OffHand = item, component.Item{}
+	case component.SlotOffHand:
+		if inv.MainHand.Slot == component.SlotTwoHand {
+			return EquipComparison{}, false
+		}
+		replaced, after.OffHand = []component.Item{inv.OffHand}, item
+	default:
+		return EquipComparison{}, false
+	}
+
+	atk0, def0, hp0 := inv.EquipBonuses()
+	atk1, def1, hp1 := after.EquipBonuses()
+	cmp := EquipComparison{ATK: atk1 - atk0, DEF: def1 - def0, MaxHP: hp1 - hp0}
+	for _, it := range replaced {
+		if !it.IsEmpty() {
+			cmp.Replaces = append(cmp.Replaces, it)
+		}
+	}
+	return cmp, true
+}
+
+// DrawEquipComparison draws, on row y, how equipping the backpack item would
+// change inv's bonuses, gains in green and losses in red. Draws nothing for
+// items CompareEquip cannot compare.
+func DrawEquipComparison(screen tcell.Screen, y int, inv component.Inventory, item component.Item) {
+	cmp, ok := CompareEquip(inv, item)
+	if !ok {
+		return
+	}
+	white := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	against := "empty slot"
+	if len(cmp.Replaces) > 0 {
+		names := make([]string, len(cmp.Replaces))
+		for i, it := range cmp.Replaces {
+			names[i] = it.Glyph + " " + it.Name
+		}
+		against = strings.Join(names, " + ")
+	}
+	label := "  vs " + against + ":"
+	drawScreenText(screen, 0, y, label, white)
+	x := len([]rune(label)) + 2
+	for _, d := range []struct {
+		stat  string
+		delta int
+	}{{"ATK", cmp.ATK}, {"DEF", cmp.DEF}, {"MaxHP", cmp.MaxHP}} {
+		style := tcell.StyleDefault.Foreground(tcell.ColorGray)
+		switch {
+		case d.delta > 0:
+			style = tcell.StyleDefault.Foreground(tcell.ColorGreen)
+		case d.delta < 0:
+			style = tcell.StyleDefault.Foreground(tcell.ColorRed)
+		}
+		text := fmt.Sprintf("%s %+d", d.stat, d.delta)
+		drawScreenText(screen, x, y, text, style)
+		x += len(text) + 2
+	}
+}
@@ -0,0 +1,85 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"emoji-roguelike/internal/component"
+)
+
+var (
+	testBlade   = component.Item{Name: "Blade", Glyph: "⚔️", Slot: component.SlotOneHand, BonusATK: 3}
+	testBuckler = component.Item{Name: "Buckler", Glyph: "🛡️", Slot: component.SlotOffHand, BonusDEF: 2, BonusMaxHP: 4}
+	testMaul    = component.Item{Name: "Maul", Glyph: "🔨", Slot: component.SlotTwoHand, BonusATK: 6}
+)
+
+func TestCompareEquipAgainstSameSlot(t *testing.T) {
+	inv := component.Inventory{Head: component.Item{Name: "Cap", Slot: component.SlotHead, BonusDEF: 1, BonusMaxHP: 5}}
+	helm := component.Item{Name: "Helm", Slot: component.SlotHead, BonusATK: 1, BonusDEF: 3}
+
+	cmp, ok := CompareEquip(inv, helm)
+	if !ok {
+		t.Fatal("a helm should be comparable")
+	}
+	if cmp.ATK != 1 || cmp.DEF != 2 || cmp.MaxHP != -5 {
+		t.Errorf("deltas ATK%+d DEF%+d MaxHP%+d; want +1 +2 -5", cmp.ATK, cmp.DEF, cmp.MaxHP)
+	}
+	if len(cmp.Replaces) != 1 || cmp.Replaces[0].Name != "Cap" {
+		t.Errorf("replaces %v; want the cap", cmp.Replaces)
+	}
+}
+
+func TestCompareTwoHanderAgainstBothHands(t *testing.T) {
+	inv := component.Inventory{MainHand: testBlade, OffHand: testBuckler}
+	cmp, ok := CompareEquip(inv, testMaul)
+	if !ok {
+		t.Fatal("a two-hander should be comparable")
+	}
+	if cmp.ATK != 3 || cmp.DEF != -2 || cmp.MaxHP != -4 {
+		t.Errorf("deltas ATK%+d DEF%+d MaxHP%+d; want +3 -2 -4 against blade and buckler", cmp.ATK, cmp.DEF, cmp.MaxHP)
+	}
+	if len(cmp.Replaces) != 2 {
+		t.Errorf("replaces %d items; want both hands", len(cmp.Replaces))
+	}
+}
+
+func TestCompareOneHanderAgainstTwoHander(t *testing.T) {
+	cmp, ok := CompareEquip(component.Inventory{MainHand: testMaul}, testBlade)
+	if !ok || cmp.ATK != -3 || len(cmp.Replaces) != 1 {
+		t.Errorf("blade vs maul: %+v; want ATK -3 replacing the maul", cmp)
+	}
+	if _, ok := CompareEquip(component.Inventory{MainHand: testMaul}, testBuckler); ok {
+		t.Error("an off-hand item cannot go beside a two-hander")
+	}
+}
+
+func TestCompareCountsBrokenGearAsNothing(t *testing.T) {
+	broken := testBlade
+	broken.MaxDurability = 10
+	cmp, _ := CompareEquip(component.Inventory{MainHand: broken}, component.Item{Name: "Knife", Slot: component.SlotOneHand, BonusATK: 1})
+	if cmp.ATK != 1 {
+		t.Errorf("ATK delta %+d; a broken blade grants nothing, so want +1", cmp.ATK)
+	}
+}
+
+func TestCompareSkipsConsumables(t *testing.T) {
+	if _, ok := CompareEquip(component.Inventory{}, component.Item{Name: "Hyperflask", IsConsumable: true}); ok {
+		t.Error("consumables have nothing to compare")
+	}
+}
+
+func TestInventoryShowsComparisonForBackpackGear(t *testing.T) {
+	g := newAbilityTestGame(t, "arcanist")
+	inv := component.Inventory{MainHand: testBlade, Backpack: []component.Item{testMaul}, Capacity: 8}
+
+	g.drawInventoryScreen(inv, 0, 0, "")
+	row := screenRows(g.screen)[14]
+	if !strings.Contains(row, "vs ⚔") || !strings.Contains(row, "ATK +3") {
+		t.Errorf("comparison row = %q; want the maul weighed against the blade", row)
+	}
+
+	g.drawInventoryScreen(inv, 1, 3, "")
+	if row := screenRows(g.screen)[14]; strings.TrimSpace(row) != "" {
+		t.Errorf("comparison row = %q; equipped items need no comparison", row)
+	}
+}
@@ -12,6 +12,8 @@ import (
 	"fmt"
 	"math/rand"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
@@ -21,7 +23,7 @@ import (
 type coopPlayer struct {
 	id                   ecs.EntityID
 	screen               tcell.Screen
-	renderer             *render.Renderer
+	renderer             render.Renderer
 	class                assets.ClassDef
 	fovRadius            int
 	baseMaxHP            int
@@ -29,36 +31,57 @@ type coopPlayer struct {
 	furnitureDEF         int
 	furnitureThorns      int
 	furnitureKillRestore bool
-	specialCooldown      int // turns until z-ability can be used again
+	specialCooldown      int // turns until the next z-ability charge is restored
+	specialSpent         int // z-ability charges used and not yet restored
+	gold                 int // purse spent at the between-floor merchant
+	hotbar               Hotbar // consumables on the quick-use keys; see coopUseHotbar
+	hitFlashOff          bool // player disabled the heavy-hit screen flash
+	color                tcell.Color // glyph colour telling the players apart; see coopApplyColor
 	// events receives all tcell events from the polling goroutine.
 	events            chan tcell.Event
 	alive             bool
+	disconnected      bool // connection dropped; see coopDisconnect
 	runLog            RunLog
 	discoveredEnemies map[string]bool
 }
 
 // CoopGame is the shared game session for two players over SSH.
-// A single ECS world, game map, and RNG are shared; each player has an
+// A single ECS world, game map, and pair of RNGs are shared; each player has an
 // independent tcell.Screen and per-player bonus state.
 type CoopGame struct {
-	world    *ecs.World
-	gmap     *gamemap.GameMap
-	floor    int
-	rng      *rand.Rand
-	state    GameState
-	messages []string
-	players  [2]*coopPlayer
+	world     *ecs.World
+	gmap      *gamemap.GameMap
+	floor     int
+	genRng    *rand.Rand // builds floors; see SplitSeed
+	combatRng *rand.Rand // rolls everything that happens on them
+	state     GameState
+	messages  []string
+	players   [2]*coopPlayer
+	// recentKills is the shared morale counter; see system.RecordKill.
+	recentKills int
+	// sharedScreen optionally shows both players on one display (e.g. for
+	// streaming); see SetSharedScreen.
+	sharedScreen   tcell.Screen
+	sharedRenderer render.Renderer
+	// waitForParty turns descending into a vote: the party goes down only
+	// once every living player has pressed > on the stairs this floor; see
+	// SetWaitForParty.
+	waitForParty bool
+	descendVotes [2]bool
 }
 
+// coopPlayerColors are the player glyph colours: P1 yellow, P2 magenta so
+// players can distinguish each other.
+var coopPlayerColors = [2]tcell.Color{tcell.ColorYellow, tcell.ColorFuchsia}
+
 // NewCoopGame creates a CoopGame backed by two already-initialized tcell screens.
 func NewCoopGame(screens [2]tcell.Screen) *CoopGame {
-	g := &CoopGame{
-		rng:   rand.New(rand.NewSource(time.Now().UnixNano())),
-		state: StatePlaying,
-	}
+	g := &CoopGame{state: StatePlaying}
+	g.genRng, g.combatRng = SplitSeed(time.Now().UnixNano())
 	for i, screen := range screens {
 		g.players[i] = &coopPlayer{
 			screen:            screen,
+			color:             coopPlayerColors[i],
 			events:            make(chan tcell.Event, 32),
 			alive:             true,
 			discoveredEnemies: make(map[string]bool),
@@ -71,12 +94,36 @@ func NewCoopGame(screens [2]tcell.Screen) *CoopGame {
 	return g
 }
 
+// SetSharedScreen adds an already-initialized screen that shows the union of
+// both players' sight, framing them together or splitting the view when they
+// are far apart. It takes no input. Call before Run.
+func (g *CoopGame) SetSharedScreen(screen tcell.Screen) {
+	g.sharedScreen = screen
+}
+
+// SetWaitForParty makes the stairs wait for everyone: a player who descends
+// casts a vote instead of dragging the party down, and the floor changes once
+// every living player has voted. Off by default. Call before Run.
+func (g *CoopGame) SetWaitForParty(on bool) {
+	g.waitForParty = on
+}
+
+// screens returns every screen the game draws to: one per player, plus the
+// shared screen if there is one.
+func (g *CoopGame) screens() []tcell.Screen {
+	screens := []tcell.Screen{g.players[0].screen, g.players[1].screen}
+	if g.sharedScreen != nil {
+		screens = append(screens, g.sharedScreen)
+	}
+	return screens
+}
+
 // Run drives the cooperative game loop. Blocks until the game ends.
-// Calls screen.Fini() on both screens before returning.
+// Calls screen.Fini() on both screens (and the shared screen) before returning.
 func (g *CoopGame) Run() {
 	defer func() {
-		for _, p := range g.players {
-			p.screen.Fini()
+		for _, s := range g.screens() {
+			s.Fini()
 		}
 	}()
 
@@ -90,6 +137,7 @@ func (g *CoopGame) Run() {
 	for i := range 2 {
 		i, p := i, g.players[i]
 		go func() {
+			defer restoreOnPanic(g.screens()...)
 			cls := coopClassSelect(p)
 			results <- classResult{i, cls}
 		}()
@@ -106,6 +154,7 @@ func (g *CoopGame) Run() {
 	for _, p := range g.players {
 		p := p
 		go func() {
+			defer restoreOnPanic(g.screens()...)
 			for {
 				ev := p.screen.PollEvent()
 				if ev == nil {
@@ -115,6 +164,21 @@ func (g *CoopGame) Run() {
 			}
 		}()
 	}
+	if g.sharedScreen != nil {
+		// The shared screen takes no input; just keep it sized.
+		go func() {
+			defer restoreOnPanic(g.screens()...)
+			for {
+				ev := g.sharedScreen.PollEvent()
+				if ev == nil {
+					return
+				}
+				if _, ok := ev.(*tcell.EventResize); ok {
+					g.sharedScreen.Sync()
+				}
+			}
+		}()
+	}
 
 	g.loadFloor(1)
 	g.addMessage("Cooperative mode! Use hjklyubn or arrow keys to move. > to descend.")
@@ -133,15 +197,19 @@ func (g *CoopGame) Run() {
 				g.state = StateDead
 				break
 			}
-			turnUsed := g.processCoopAction(g.players[0], action)
-			if g.state != StatePlaying {
-				break
-			}
-			if g.floor != prevFloor {
-				continue // floor changed — skip P2 and AI this round
-			}
-			if turnUsed {
-				g.players[0].runLog.TurnsPlayed++
+			if action == ActionDisconnect {
+				g.coopDisconnect(g.players[0])
+			} else {
+				turnUsed := g.processCoopAction(g.players[0], action)
+				if g.state != StatePlaying {
+					break
+				}
+				if g.floor != prevFloor {
+					continue // floor changed — skip P2 and AI this round
+				}
+				if turnUsed {
+					g.players[0].runLog.TurnsPlayed++
+				}
 			}
 		}
 
@@ -157,15 +225,19 @@ func (g *CoopGame) Run() {
 				g.state = StateDead
 				break
 			}
-			turnUsed := g.processCoopAction(g.players[1], action)
-			if g.state != StatePlaying {
-				break
-			}
-			if g.floor != prevFloor {
-				continue
-			}
-			if turnUsed {
-				g.players[1].runLog.TurnsPlayed++
+			if action == ActionDisconnect {
+				g.coopDisconnect(g.players[1])
+			} else {
+				turnUsed := g.processCoopAction(g.players[1], action)
+				if g.state != StatePlaying {
+					break
+				}
+				if g.floor != prevFloor {
+					continue
+				}
+				if turnUsed {
+					g.players[1].runLog.TurnsPlayed++
+				}
 			}
 		}
 
@@ -184,6 +256,7 @@ func (g *CoopGame) Run() {
 		p.runLog.FloorsReached = g.floor
 		p.runLog.Victory = g.state == StateVictory
 		p.runLog.Timestamp = time.Now()
+		p.runLog.Score = p.runLog.score()
 		if p.runLog.Victory {
 			p.runLog.CauseOfDeath = ""
 		}
@@ -217,7 +290,7 @@ func coopClassSelect(p *coopPlayer) assets.ClassDef {
 				selected = (selected - 1 + len(assets.Classes)) % len(assets.Classes)
 			case 'j', 'J':
 				selected = (selected + 1) % len(assets.Classes)
-			case '1', '2', '3', '4', '5', '6':
+			case '1', '2', '3', '4', '5', '6', '7', '8':
 				idx := int(ev.Rune() - '1')
 				if idx >= 0 && idx < len(assets.Classes) {
 					return assets.Classes[idx]
@@ -230,6 +303,8 @@ func coopClassSelect(p *coopPlayer) assets.ClassDef {
 // loadFloor generates and populates the given floor, creating player entities
 // for all connected players. HP and inventory are preserved across transitions.
 func (g *CoopGame) loadFloor(floor int) {
+	g.descendVotes = [2]bool{}
+
 	// Save HP and inventory for live players before discarding the old world.
 	type savedState struct {
 		hp  int
@@ -253,30 +328,47 @@ func (g *CoopGame) loadFloor(floor int) {
 
 	g.floor = floor
 	g.world = ecs.NewWorld()
+	g.recentKills = 0
 
-	cfg := levelConfig(floor, g.rng)
+	cfg := levelConfig(floor, g.genRng)
 	gmap, px, py := generate.Generate(cfg)
 	g.gmap = gmap
 
 	pop := generate.Populate(gmap, cfg)
 	for _, es := range pop.Enemies {
-		factory.NewEnemy(g.world, es.Entry, es.X, es.Y)
+		factory.NewEnemy(g.world, es.Entry, es.X, es.Y, g.genRng)
 	}
 	for _, is := range pop.Items {
 		factory.NewItem(g.world, is.Entry, is.X, is.Y)
 	}
 	for _, eq := range pop.Equipment {
-		factory.NewEquipItem(g.world, eq.Entry, floor, g.rng, eq.X, eq.Y)
+		factory.NewEquipItem(g.world, eq.Entry, floor, g.genRng, eq.X, eq.Y)
 	}
 	for _, ins := range pop.Inscriptions {
 		factory.NewInscription(g.world, ins.Text, ins.X, ins.Y)
 	}
+	for _, r := range pop.Runes {
+		factory.NewRune(g.world, g.genRng, r.X, r.Y)
+	}
+	for _, pg := range pop.PuzzleGlyphs {
+		factory.NewPuzzleGlyph(g.world, pg.Glyph, pg.X, pg.Y)
+	}
+	system.AttuneEchoes(g.world, []string{g.players[0].class.ID, g.players[1].class.ID})
 	for _, fs := range pop.Furniture {
 		factory.NewFurniture(g.world, fs.Entry, fs.X, fs.Y)
 	}
-
-	// Player spawn colors: P1 yellow, P2 magenta so players can distinguish each other.
-	playerColors := [2]tcell.Color{tcell.ColorYellow, tcell.ColorFuchsia}
+	for _, gp := range pop.GoldPiles {
+		factory.NewGoldPile(g.world, gp.Amount, gp.X, gp.Y)
+	}
+	for _, vm := range pop.Vending {
+		factory.NewVendingMachine(g.world, g.genRng, vm.X, vm.Y)
+	}
+	for _, a := range pop.Altars {
+		factory.NewAltar(g.world, a.X, a.Y)
+	}
+	for _, o := range pop.Offerings {
+		factory.NewItemByGlyph(g.world, assets.AltarOffering, o.X, o.Y)
+	}
 
 	// Spawn positions: P1 at the map start, P2 one tile right (or at the same spot).
 	spawnX := [2]int{px, px + 1}
@@ -291,13 +383,7 @@ func (g *CoopGame) loadFloor(floor int) {
 			continue
 		}
 		p.id = factory.NewPlayer(g.world, spawnX[i], spawnY[i], p.class)
-
-		// Override the default yellow with this player's colour.
-		if rend := g.world.Get(p.id, component.CRenderable); rend != nil {
-			r := rend.(component.Renderable)
-			r.FGColor = playerColors[i]
-			g.world.Add(p.id, r)
-		}
+		g.coopApplyColor(p)
 
 		// Reapply persistent furniture combat bonuses.
 		if p.furnitureATK != 0 || p.furnitureDEF != 0 {
@@ -320,6 +406,9 @@ func (g *CoopGame) loadFloor(floor int) {
 
 		// Restore inventory from previous floor.
 		if saved[i].inv != nil && floor > 1 {
+			if rechargeWands(saved[i].inv) {
+				g.addMessage(fmt.Sprintf("P%d's wands hum with a fresh charge.", i+1))
+			}
 			g.world.Add(p.id, *saved[i].inv)
 			g.coopRecalcPlayerMaxHP(p)
 		}
@@ -340,20 +429,27 @@ func (g *CoopGame) loadFloor(floor int) {
 		// Reset ability cooldown on each floor entry for classes with AbilityFreeOnFloor.
 		if p.class.AbilityFreeOnFloor {
 			p.specialCooldown = 0
+			p.specialSpent = 0
 		}
 
 		system.UpdateFOV(g.world, g.gmap, p.id, p.fovRadius)
 		p.renderer = render.NewRenderer(p.screen, floor)
 		p.renderer.CenterOn(spawnX[i], spawnY[i])
 	}
+	if g.sharedScreen != nil {
+		g.sharedRenderer = render.NewRenderer(g.sharedScreen, floor)
+	}
 
 	if floor == 1 {
 		g.addMessage(fmt.Sprintf("You enter %s.", assets.FloorName(floor)))
 	} else {
 		g.addMessage(fmt.Sprintf("You descend into %s (Floor %d).", assets.FloorName(floor), floor))
 	}
+	if g.gmap.Affix != gamemap.AffixNone {
+		g.addMessage(fmt.Sprintf("This floor is cursed with %s. %s", g.gmap.Affix.Name(), g.gmap.Affix.Desc()))
+	}
 	if lore := assets.FloorLoreSnippets(floor); len(lore) > 0 {
-		g.addMessage(lore[g.rng.Intn(len(lore))])
+		g.addMessage(lore[g.genRng.Intn(len(lore))])
 	}
 }
 
@@ -361,17 +457,47 @@ func (g *CoopGame) loadFloor(floor int) {
 // each centered on their own character.
 func (g *CoopGame) renderAll() {
 	for _, p := range g.players {
-		if p.renderer == nil {
+		if p.renderer == nil || p.disconnected {
 			continue
 		}
 		pos := g.coopPlayerPosition(p)
+		system.UpdateFOV(g.world, g.gmap, p.id, p.fovRadius) // the shared map holds one player's sight at a time
 		p.renderer.CenterOn(pos.X, pos.Y)
+		p.renderer.SetTurn(p.runLog.TurnsPlayed)
+		if ic := g.world.Get(p.id, component.CInventory); ic != nil {
+			p.renderer.SetHotbar(p.hotbar.HUD(ic.(component.Inventory)))
+		}
 		p.renderer.DrawFrame(g.world, g.gmap, p.id)
 		equipATK, equipDEF := g.coopEquipBonuses(p)
 		bonusATK := system.GetAttackBonus(g.world, p.id) + equipATK
 		bonusDEF := system.GetDefenseBonus(g.world, p.id) + equipDEF
-		p.renderer.DrawHUD(g.world, p.id, g.floor, p.class.Name, g.messages, bonusATK, bonusDEF, p.class.AbilityName, p.specialCooldown, 1, 0)
+		p.renderer.DrawHUD(g.world, g.gmap, p.id, g.floor, fmt.Sprintf("%s 💰%d", p.class.Name, p.gold), g.messages, bonusATK, bonusDEF, p.class.AbilityName, p.specialCooldown, g.coopEffectiveCooldown(p), p.class.MaxCharges()-p.specialSpent, p.class.MaxCharges(), 1, 0)
 	}
+	g.renderShared()
+}
+
+// renderShared draws the shared screen, if any: a combined FOV pass over both
+// living players followed by a frame and HUD that show them together.
+func (g *CoopGame) renderShared() {
+	if g.sharedRenderer == nil {
+		return
+	}
+	var ids []ecs.EntityID
+	var radii []int
+	var positions []component.Position
+	var hud []render.SharedHUDPlayer
+	for _, p := range g.players {
+		hud = append(hud, render.SharedHUDPlayer{ID: p.id, Name: p.class.Name, Gold: p.gold})
+		if !p.alive {
+			continue
+		}
+		ids = append(ids, p.id)
+		radii = append(radii, p.fovRadius)
+		positions = append(positions, g.coopPlayerPosition(p))
+	}
+	system.UpdateSharedFOV(g.world, g.gmap, ids, radii)
+	g.sharedRenderer.DrawSharedFrame(g.world, g.gmap, positions)
+	g.sharedRenderer.DrawSharedHUD(g.world, g.floor, g.gmap.Affix, hud, g.messages)
 }
 
 // waitPlayerAction blocks until a meaningful action arrives on p.events.
@@ -380,18 +506,37 @@ func (g *CoopGame) waitPlayerAction(p *coopPlayer) Action {
 	for {
 		ev, ok := <-p.events
 		if !ok || ev == nil {
-			return ActionQuit // disconnect
+			return ActionDisconnect
 		}
 		switch ev := ev.(type) {
 		case *tcell.EventResize:
 			p.screen.Sync()
 			g.renderAll()
 		case *tcell.EventKey:
-			return keyToAction(ev)
+			action := keyToAction(ev)
+			if action == ActionMenu {
+				action = ActionQuit // coop has no pause menu; Esc still quits
+			}
+			return action
 		}
 	}
 }
 
+// coopDisconnect takes p out of the game after their connection drops: their
+// character leaves the map and the other player carries on alone. The game
+// ends only if nobody is left standing.
+func (g *CoopGame) coopDisconnect(p *coopPlayer) {
+	p.disconnected = true
+	p.alive = false
+	g.world.DestroyEntity(p.id)
+	p.id = ecs.NilEntity
+	if !g.players[0].alive && !g.players[1].alive {
+		g.state = StateDead
+		return
+	}
+	g.addMessage(fmt.Sprintf("%s lost their connection. The expedition continues without them.", p.class.Name))
+}
+
 // processCoopAction handles one player's action. It does NOT run enemy AI
 // (that is deferred to tickWorld after both players have acted).
 // Returns true if a game turn was consumed.
@@ -405,16 +550,27 @@ func (g *CoopGame) processCoopAction(p *coopPlayer, action Action) bool {
 	switch action {
 	case ActionWait:
 		g.addMessage(fmt.Sprintf("%s waits.", p.class.Name))
+		if system.AnswerRiddle(g.world, p.id) {
+			g.coopEarnGold(p, system.RiddleGold)
+			g.addMessage(RiddleAnswered)
+		}
+		return true
+
+	case ActionBlock:
+		g.coopBlock(p)
 		return true
 
+	case ActionHotbar1, ActionHotbar2, ActionHotbar3, ActionHotbar4:
+		return g.coopUseHotbar(p, int(action-ActionHotbar1))
+
 	case ActionDescend:
 		pos := g.coopPlayerPosition(p)
 		tile := g.gmap.At(pos.X, pos.Y)
 		if tile.Kind == gamemap.TileStairsDown {
 			if g.floor >= MaxFloors {
 				g.addMessage("There is nowhere further to descend.")
-			} else {
-				g.loadFloor(g.floor + 1)
+			} else if g.voteToDescend(p) {
+				g.coopDescend()
 			}
 		} else {
 			g.addMessage("There are no stairs down here.")
@@ -438,14 +594,18 @@ func (g *CoopGame) processCoopAction(p *coopPlayer, action Action) bool {
 	case ActionInventory:
 		return g.coopRunInventory(p)
 
+	case ActionToggleFlash:
+		p.hitFlashOff = !p.hitFlashOff
+		g.addMessage(fmt.Sprintf("%s: %s", p.class.Name, hitFlashMessage(p.hitFlashOff)))
+		return false
+
 	case ActionSpecialAbility:
 		if p.class.AbilityCooldown == 0 {
 			g.addMessage(fmt.Sprintf("%s has no special ability.", p.class.Name))
-		} else if p.specialCooldown > 0 {
+		} else if p.specialSpent >= p.class.MaxCharges() {
 			g.addMessage(fmt.Sprintf("%s: %s recharging (%d turns).", p.class.Name, p.class.AbilityName, p.specialCooldown))
-		} else {
-			g.useCoopSpecialAbility(p)
-			p.specialCooldown = p.class.AbilityCooldown
+		} else if g.useCoopSpecialAbility(p) {
+			p.specialSpent, p.specialCooldown = system.SpendCharge(p.specialSpent, p.specialCooldown, g.coopEffectiveCooldown(p))
 			return true
 		}
 		return false
@@ -458,8 +618,17 @@ func (g *CoopGame) processCoopAction(p *coopPlayer, action Action) bool {
 		result, target := system.TryMove(g.world, g.gmap, p.id, dx, dy)
 		switch result {
 		case system.MoveOK:
+			if onChute(g.gmap, g.coopPlayerPosition(p)) {
+				g.coopFallDownChute(p)
+				return false
+			}
 			system.UpdateFOV(g.world, g.gmap, p.id, p.fovRadius)
 			g.coopCheckInscription(p)
+			pos := g.coopPlayerPosition(p)
+			if msg := PuzzleMessage(system.StepPuzzle(g.gmap, pos.X, pos.Y)); msg != "" {
+				g.addMessage(msg)
+			}
+			g.coopCollectGold(p)
 			return true
 
 		case system.MoveInteract:
@@ -474,31 +643,37 @@ func (g *CoopGame) processCoopAction(p *coopPlayer, action Action) bool {
 				}
 			}
 			name := g.entityName(target)
+			title := ChampionName(g.world, target, name)
 			enemyPos := g.world.Get(target, component.CPosition).(component.Position)
-			var lootDrops []component.LootEntry
+			var loot component.Loot
 			if lc := g.world.Get(target, component.CLoot); lc != nil {
-				lootDrops = lc.(component.Loot).Drops
+				loot = lc.(component.Loot)
 			}
-			res := system.Attack(g.world, g.rng, p.id, target)
+			res := system.Attack(g.world, g.combatRng, p.id, target)
 			p.runLog.DamageDealt += res.Damage
+			g.coopNoteWornOut(p, res.WornOut)
 			if res.Killed {
 				p.runLog.EnemiesKilled[name]++
-				g.addMessage(fmt.Sprintf("%s kills the %s!", p.class.Name, name))
+				gold := g.combatRng.Intn(4) + 1
+				g.coopEarnGold(p, gold)
+				g.addMessage(fmt.Sprintf("%s kills the %s! (+%d💰)", p.class.Name, title, gold))
+				g.coopNoteKill(enemyPos)
+				factory.DropGold(g.world, g.combatRng, assets.ThreatForGlyph(name), enemyPos.X, enemyPos.Y)
+				factory.LeaveHazard(g.world, g.floor, name, enemyPos.X, enemyPos.Y)
 				if !p.discoveredEnemies[name] {
 					p.discoveredEnemies[name] = true
 					if lore, ok := assets.EnemyLore[name]; ok {
 						g.addMessage(lore)
 					}
 				}
-				for _, d := range lootDrops {
-					if g.rng.Intn(100) < d.Chance {
-						factory.NewItemByGlyph(g.world, d.Glyph, enemyPos.X, enemyPos.Y)
-					}
+				for _, it := range factory.RollLoot(loot, g.floor, g.combatRng) {
+					factory.NewItemByGlyph(g.world, it.Glyph, enemyPos.X, enemyPos.Y)
 				}
+				g.coopDropChest(name, enemyPos)
 				if p.class.KillRestoreHP > 0 {
 					g.coopRestorePlayerHP(p, p.class.KillRestoreHP)
 				}
-				if p.class.KillHealChance > 0 && g.rng.Intn(100) < p.class.KillHealChance {
+				if p.class.KillHealChance > 0 && g.combatRng.Intn(100) < p.class.KillHealChance {
 					g.coopRestorePlayerHP(p, 2)
 					g.addMessage(fmt.Sprintf("%s: Wild magic sparks! (+2 HP)", p.class.Name))
 				}
@@ -507,14 +682,16 @@ func (g *CoopGame) processCoopAction(p *coopPlayer, action Action) bool {
 				}
 				g.checkCoopVictory()
 			} else {
-				g.addMessage(fmt.Sprintf("%s hits the %s for %d damage.", p.class.Name, name, res.Damage))
+				g.addMessage(fmt.Sprintf("%s hits the %s for %d damage.", p.class.Name, title, res.Damage))
 			}
 			return true
 
 		case system.MoveBlocked:
 			pos := g.coopPlayerPosition(p)
 			tx, ty := pos.X+dx, pos.Y+dy
-			if g.gmap.InBounds(tx, ty) && g.gmap.At(tx, ty).Kind == gamemap.TileDoor {
+			if g.gmap.InBounds(tx, ty) && g.gmap.At(tx, ty).Locked {
+				g.addMessage(SealedDoorMessage)
+			} else if g.gmap.InBounds(tx, ty) && g.gmap.At(tx, ty).Kind == gamemap.TileDoor {
 				g.gmap.Set(tx, ty, gamemap.MakeFloor())
 				system.UpdateFOV(g.world, g.gmap, p.id, p.fovRadius)
 				g.addMessage(fmt.Sprintf("%s opens a door.", p.class.Name))
@@ -525,6 +702,47 @@ func (g *CoopGame) processCoopAction(p *coopPlayer, action Action) bool {
 	return false
 }
 
+// voteToDescend records p's vote to take the stairs down and reports whether
+// the party descends now. Without waitForParty any player's descent takes the
+// whole party; with it, every living player must have voted.
+func (g *CoopGame) voteToDescend(p *coopPlayer) bool {
+	if !g.waitForParty {
+		return true
+	}
+	var waiting []string
+	for i, q := range g.players {
+		if q == p {
+			g.descendVotes[i] = true
+		}
+		if q.alive && !g.descendVotes[i] {
+			waiting = append(waiting, q.class.Name)
+		}
+	}
+	if len(waiting) > 0 {
+		g.addMessage(fmt.Sprintf("%s is ready to descend. Waiting for %s to press > on the stairs.",
+			p.class.Name, strings.Join(waiting, " and ")))
+		return false
+	}
+	return true
+}
+
+// coopNoteStrike highlights the attacker of h on the next frame for the
+// player it struck, unless they turned the heavy-hit flash off. The shared
+// screen shows it too.
+func (g *CoopGame) coopNoteStrike(h system.EnemyHitResult) {
+	for _, p := range g.players {
+		if p.id != h.VictimID || p.hitFlashOff {
+			continue
+		}
+		if p.renderer != nil {
+			p.renderer.NoteStrike(h.AttackerID)
+		}
+		if g.sharedRenderer != nil {
+			g.sharedRenderer.NoteStrike(h.AttackerID)
+		}
+	}
+}
+
 // tickWorld applies poison/burn to all players, ticks effects, runs AI, and
 // checks for player deaths and victory. Called once per round after both players act.
 func (g *CoopGame) tickWorld() {
@@ -534,19 +752,23 @@ func (g *CoopGame) tickWorld() {
 			g.coopApplyPoisonDamage(p)
 		}
 	}
+	g.coopApplyHazards()
 	system.TickEffects(g.world)
+	g.recentKills = system.DecayMorale(g.recentKills)
 
 	// Per-player passive ticks: ability cooldown and regeneration.
 	for _, p := range g.players {
 		if !p.alive {
 			continue
 		}
-		if p.specialCooldown > 0 {
-			p.specialCooldown--
-		}
+		p.specialSpent, p.specialCooldown = system.TickCharges(p.specialSpent, p.specialCooldown,
+			system.CooldownTick(g.world, p.id), g.coopEffectiveCooldown(p))
 		if p.class.PassiveRegen > 0 && p.runLog.TurnsPlayed > 0 && p.runLog.TurnsPlayed%p.class.PassiveRegen == 0 {
 			g.coopRestorePlayerHP(p, 1)
 		}
+		if system.InSanctuary(g.world, g.gmap, p.id) && p.runLog.TurnsPlayed%assets.SanctuaryRegenEvery == 0 {
+			g.coopRestorePlayerHP(p, 1)
+		}
 	}
 
 	// Collect alive player IDs for multi-target AI.
@@ -560,18 +782,34 @@ func (g *CoopGame) tickWorld() {
 		return
 	}
 
-	hits := system.ProcessAI(g.world, g.gmap, pids, g.rng)
+	g.resolveCoopTurretShots(system.ProcessTurrets(g.world, g.gmap, g.combatRng))
+
+	hits := system.ProcessAI(g.world, g.gmap, pids, g.combatRng)
+	g.resolveCoopTrapTriggers(system.CollectSprungTraps(g.world))
+	g.resolveCoopSummons(system.CollectSummons(g.world))
+	for _, c := range system.CollectEchoes(g.world) {
+		g.addMessage(EchoMessage(c.Glyph, c.ClassID))
+	}
+	g.resolveCoopSplits(system.CollectSplits(g.world, g.gmap))
 
 	// Attribute damage and apply thorns.
 	// Use the combined thorns of both players (cooperative benefit).
 	maxThorns := max(g.players[0].furnitureThorns, g.players[1].furnitureThorns)
 	for _, h := range hits {
+		g.coopNoteStrike(h)
 		if h.Damage > 0 {
 			// Attribute damage directly via VictimID.
 			for _, p := range g.players {
 				if p.alive && p.id == h.VictimID {
 					p.runLog.DamageTaken += h.Damage
 					p.runLog.CauseOfDeath = h.EnemyGlyph
+					if hp := g.world.Get(p.id, component.CHealth); hp != nil {
+						health := hp.(component.Health)
+						p.runLog.noteDamage(p.runLog.TurnsPlayed, h.EnemyGlyph, h.Damage, health.Current)
+						if p.renderer != nil && !p.hitFlashOff {
+							p.renderer.NoteDamage(h.Damage, health.Max)
+						}
+					}
 					break
 				}
 			}
@@ -584,6 +822,11 @@ func (g *CoopGame) tickWorld() {
 				}
 			}
 		}
+		for _, p := range g.players {
+			if p.id == h.VictimID {
+				g.coopNoteWornOut(p, h.WornOut)
+			}
+		}
 		g.handleCoopHitMessage(h)
 	}
 
@@ -607,7 +850,103 @@ func (g *CoopGame) tickWorld() {
 	g.checkCoopVictory()
 }
 
+// resolveCoopTurretShots reports turret fire and credits kills to the
+// turret's owner.
+func (g *CoopGame) resolveCoopTurretShots(shots []system.TurretShot) {
+	for _, sh := range shots {
+		var owner *coopPlayer
+		for _, p := range g.players {
+			if p.id == sh.Owner {
+				owner = p
+				break
+			}
+		}
+		if owner != nil {
+			owner.runLog.DamageDealt += sh.Damage
+		}
+		if sh.Killed {
+			g.addMessage(fmt.Sprintf("A turret destroys the %s!", sh.TargetGlyph))
+			g.coopCreditKill(owner, sh.TargetGlyph, sh.TargetPos, sh.Loot)
+		}
+	}
+}
+
+// coopCreditKill drops the spoils of an enemy with glyph slain at pos without
+// a player's blow, by a trap, turret or hazard, and credits the kill to owner
+// if there is one.
+func (g *CoopGame) coopCreditKill(owner *coopPlayer, glyph string, pos component.Position, loot component.Loot) {
+	g.coopNoteKill(pos)
+	factory.DropGold(g.world, g.combatRng, assets.ThreatForGlyph(glyph), pos.X, pos.Y)
+	factory.LeaveHazard(g.world, g.floor, glyph, pos.X, pos.Y)
+	if owner != nil {
+		owner.runLog.EnemiesKilled[glyph]++
+		g.coopEarnGold(owner, g.combatRng.Intn(4)+1)
+	}
+	for _, it := range factory.RollLoot(loot, g.floor, g.combatRng) {
+		factory.NewItemByGlyph(g.world, it.Glyph, pos.X, pos.Y)
+	}
+	g.coopDropChest(glyph, pos)
+}
+
+// resolveCoopSummons creates the minions called by summoners this turn.
+func (g *CoopGame) resolveCoopSummons(summons []system.Summon) {
+	for _, sm := range summons {
+		if factory.NewMinion(g.world, sm.Summoner, sm.Minion, sm.Pos.X, sm.Pos.Y) != ecs.NilEntity {
+			g.addMessage(fmt.Sprintf("The %s calls forth a %s!", sm.SummonerGlyph, sm.Minion))
+		}
+	}
+}
+
+// resolveCoopSplits creates the copies shed by splitting enemies this turn.
+func (g *CoopGame) resolveCoopSplits(splits []system.Split) {
+	for _, sp := range splits {
+		if factory.NewSplitCopy(g.world, sp.Parent, sp.Pos.X, sp.Pos.Y, sp.HP, sp.MaxHP, sp.Gen) != ecs.NilEntity {
+			g.addMessage(fmt.Sprintf("The %s splits in two!", sp.Glyph))
+		}
+	}
+}
+
+// resolveCoopTrapTriggers reports sprung traps and credits trap kills to the
+// player who placed the trap.
+func (g *CoopGame) resolveCoopTrapTriggers(triggers []system.TrapTrigger) {
+	for _, tr := range triggers {
+		var owner *coopPlayer
+		for _, p := range g.players {
+			if p.id == tr.Owner {
+				owner = p
+				break
+			}
+		}
+		if owner != nil {
+			owner.runLog.DamageDealt += tr.Damage
+		}
+		if tr.Kind == component.TrapSnare {
+			g.addMessage(fmt.Sprintf("The %s is caught in a snare!", tr.VictimGlyph))
+		} else {
+			g.addMessage(fmt.Sprintf("The %s steps on spikes! (%d damage)", tr.VictimGlyph, tr.Damage))
+		}
+		if tr.Killed {
+			g.addMessage(fmt.Sprintf("A trap kills the %s!", tr.VictimGlyph))
+			g.coopCreditKill(owner, tr.VictimGlyph, tr.Pos, tr.Loot)
+		}
+	}
+}
+
+// coopNoteWornOut reports p's item that broke from wear, if any, and drops
+// any MaxHP it was granting.
+func (g *CoopGame) coopNoteWornOut(p *coopPlayer, name string) {
+	if name == "" {
+		return
+	}
+	g.addMessage(fmt.Sprintf("%s's %s breaks! It's useless until a smith repairs it.", p.class.Name, name))
+	g.coopRecalcPlayerMaxHP(p)
+}
+
 func (g *CoopGame) handleCoopHitMessage(h system.EnemyHitResult) {
+	if h.Parried {
+		g.addMessage(fmt.Sprintf("A player parries the %s's blow!", h.EnemyGlyph))
+		return
+	}
 	switch h.SpecialApplied {
 	case 1:
 		g.addMessage(fmt.Sprintf("The %s poisons a player!", h.EnemyGlyph))
@@ -619,6 +958,8 @@ func (g *CoopGame) handleCoopHitMessage(h system.EnemyHitResult) {
 		g.addMessage(fmt.Sprintf("The %s stuns a player!", h.EnemyGlyph))
 	case 5:
 		g.addMessage(fmt.Sprintf("The %s shatters defenses!", h.EnemyGlyph))
+	case 6:
+		g.addMessage(fmt.Sprintf("The %s knocks a player's %s loose!", h.EnemyGlyph, h.DisarmedItem))
 	}
 }
 
@@ -639,11 +980,13 @@ func (g *CoopGame) coopRestorePlayerHP(p *coopPlayer, n int) {
 		return
 	}
 	hp := hpComp.(component.Health)
+	before := hp.Current
 	hp.Current += n
 	if hp.Current > hp.Max {
 		hp.Current = hp.Max
 	}
 	g.world.Add(p.id, hp)
+	system.HealThreat(g.world, p.id, hp.Current-before)
 }
 
 func (g *CoopGame) coopApplyPoisonDamage(p *coopPlayer) {
@@ -661,7 +1004,11 @@ func (g *CoopGame) coopApplyPoisonDamage(p *coopPlayer) {
 	h.Current -= total
 	g.world.Add(p.id, h)
 	p.runLog.DamageTaken += total
+	if burnDmg > 0 {
+		p.runLog.noteDamage(p.runLog.TurnsPlayed, "self-burn", burnDmg, h.Current+poisonDmg)
+	}
 	if poisonDmg > 0 {
+		p.runLog.noteDamage(p.runLog.TurnsPlayed, "poison", poisonDmg, h.Current)
 		p.runLog.CauseOfDeath = "poison"
 		g.addMessage(fmt.Sprintf("Poison burns through %s! (%d damage)", p.class.Name, poisonDmg))
 	}
@@ -678,30 +1025,39 @@ func (g *CoopGame) coopEquipBonuses(p *coopPlayer) (atk, def int) {
 	if c == nil {
 		return 0, 0
 	}
-	inv := c.(component.Inventory)
-	atk = inv.MainHand.BonusATK + inv.OffHand.BonusATK + inv.Head.BonusATK + inv.Body.BonusATK + inv.Feet.BonusATK
-	def = inv.MainHand.BonusDEF + inv.OffHand.BonusDEF + inv.Head.BonusDEF + inv.Body.BonusDEF + inv.Feet.BonusDEF
-	return atk, def
+	atk, def, _ = c.(component.Inventory).EquipBonuses()
+	lostATK, lostDEF := system.DisarmPenalty(g.world, p.id)
+	return atk - lostATK, def - lostDEF
 }
 
-func (g *CoopGame) coopRecalcPlayerMaxHP(p *coopPlayer) {
+// coopRecalcPlayerMaxHP recalculates p's MaxHP from baseMaxHP + equipment
+// bonuses. Current HP is clamped to the new MaxHP; returns the HP lost to the
+// clamp.
+func (g *CoopGame) coopRecalcPlayerMaxHP(p *coopPlayer) int {
 	invComp := g.world.Get(p.id, component.CInventory)
 	if invComp == nil {
-		return
+		return 0
 	}
-	inv := invComp.(component.Inventory)
-	bonus := inv.Head.BonusMaxHP + inv.Body.BonusMaxHP + inv.Feet.BonusMaxHP +
-		inv.MainHand.BonusMaxHP + inv.OffHand.BonusMaxHP
+	_, _, bonus := invComp.(component.Inventory).EquipBonuses()
 	hpComp := g.world.Get(p.id, component.CHealth)
 	if hpComp == nil {
-		return
+		return 0
 	}
 	hp := hpComp.(component.Health)
 	hp.Max = p.baseMaxHP + bonus
-	if hp.Current > hp.Max {
-		hp.Current = hp.Max
-	}
+	lost := max(hp.Current-hp.Max, 0)
+	hp.Current -= lost
 	g.world.Add(p.id, hp)
+	return lost
+}
+
+// coopSaveInventory writes inv back to p and refits MaxHP to the gear now
+// worn, telling the party if shedding MaxHP cost p current HP.
+func (g *CoopGame) coopSaveInventory(p *coopPlayer, inv component.Inventory) {
+	g.world.Add(p.id, inv)
+	if lost := g.coopRecalcPlayerMaxHP(p); lost > 0 {
+		g.addMessage(fmt.Sprintf("%s: %s", p.class.Name, maxHPLossMessage(lost)))
+	}
 }
 
 func (g *CoopGame) coopCheckInscription(p *coopPlayer) {
@@ -712,13 +1068,32 @@ func (g *CoopGame) coopCheckInscription(p *coopPlayer) {
 			text := g.world.Get(id, component.CInscription).(component.Inscription).Text
 			p.runLog.InscriptionsRead++
 			g.addMessage("📝 " + text)
+			if msg := RuneMessage(system.TriggerRune(g.world, p.id)); msg != "" {
+				g.addMessage(msg)
+			}
 			return
 		}
 	}
 }
 
-// useCoopSpecialAbility fires the class active ability for a coop player.
-func (g *CoopGame) useCoopSpecialAbility(p *coopPlayer) {
+// coopNoteKill feeds a kill at pos into the shared morale counter and routs
+// the nearby survivors if their morale breaks.
+func (g *CoopGame) coopNoteKill(pos component.Position) {
+	g.recentKills = system.RecordKill(g.recentKills)
+	if system.BreakMorale(g.world, pos, g.recentKills) > 0 {
+		g.addMessage("The survivors panic and flee!")
+	}
+}
+
+// coopEffectiveCooldown returns the player's class cooldown after equipment CDR.
+func (g *CoopGame) coopEffectiveCooldown(p *coopPlayer) int {
+	return system.ApplyCDR(p.class.AbilityCooldown, system.GetCDRPercent(g.world, p.id))
+}
+
+// useCoopSpecialAbility fires the class active ability for a coop player and
+// reports whether it fired.
+func (g *CoopGame) useCoopSpecialAbility(p *coopPlayer) bool {
+	defer g.coopApplyColor(p)
 	switch p.class.ID {
 	case "arcanist":
 		g.coopTeleportPlayer(p)
@@ -727,13 +1102,12 @@ func (g *CoopGame) useCoopSpecialAbility(p *coopPlayer) {
 	case "revenant":
 		hpComp := g.world.Get(p.id, component.CHealth)
 		if hpComp == nil {
-			return
+			return false
 		}
 		hp := hpComp.(component.Health)
 		if hp.Current <= 5 {
 			g.addMessage(fmt.Sprintf("%s: Too wounded to bargain with death!", p.class.Name))
-			p.specialCooldown = 0 // refund cooldown
-			return
+			return false
 		}
 		hp.Current -= 5
 		g.world.Add(p.id, hp)
@@ -765,7 +1139,10 @@ func (g *CoopGame) useCoopSpecialAbility(p *coopPlayer) {
 				}
 			}
 		}
-		g.addMessage(fmt.Sprintf("%s: Farsight — entire floor revealed!", p.class.Name))
+		system.ApplyEffect(g.world, p.id, component.ActiveEffect{
+			Kind: component.EffectTrueSight, Magnitude: 1, TurnsRemaining: 10,
+		})
+		g.addMessage(fmt.Sprintf("%s: Farsight — entire floor revealed, lurkers exposed for 10 turns!", p.class.Name))
 
 	case "symbiont":
 		g.coopRestorePlayerHP(p, 10)
@@ -773,7 +1150,24 @@ func (g *CoopGame) useCoopSpecialAbility(p *coopPlayer) {
 			Kind: component.EffectAttackBoost, Magnitude: 4, TurnsRemaining: 6,
 		})
 		g.addMessage(fmt.Sprintf("%s: Parasite Surge! (+10 HP, +4 ATK for 6 turns)", p.class.Name))
+
+	case "tinker":
+		x, y, ok := system.FindDeploySpot(g.world, g.gmap, g.coopPlayerPosition(p))
+		if !ok {
+			g.addMessage(fmt.Sprintf("%s: No room to deploy a turret!", p.class.Name))
+			return false
+		}
+		factory.NewTurret(g.world, p.id, x, y)
+		g.addMessage(fmt.Sprintf("%s: Turret deployed! (%d shots, %d turns)", p.class.Name, factory.TurretAmmo, factory.TurretLifespan))
+
+	case "warden":
+		n := system.TauntEnemies(g.world, g.gmap, p.id, 6, 5)
+		system.ApplyEffect(g.world, p.id, component.ActiveEffect{
+			Kind: component.EffectDefenseBoost, Magnitude: 3, TurnsRemaining: 5,
+		})
+		g.addMessage(fmt.Sprintf("%s: Challenge! %d enemies turn on them. (+3 DEF for 5 turns)", p.class.Name, n))
 	}
+	return true
 }
 
 func (g *CoopGame) coopTeleportPlayer(p *coopPlayer) {
@@ -781,13 +1175,28 @@ func (g *CoopGame) coopTeleportPlayer(p *coopPlayer) {
 	if len(rooms) == 0 {
 		return
 	}
-	room := rooms[g.rng.Intn(len(rooms))]
+	room := rooms[g.combatRng.Intn(len(rooms))]
 	x, y := room.Center()
 	g.world.Add(p.id, component.Position{X: x, Y: y})
 	system.UpdateFOV(g.world, g.gmap, p.id, p.fovRadius)
+	g.coopApplyColor(p)
+}
+
+// coopApplyColor paints p's glyph in p.color, overriding the default player
+// colour. The colour on coopPlayer is authoritative; call this wherever the
+// player's Renderable may have been rewritten.
+func (g *CoopGame) coopApplyColor(p *coopPlayer) {
+	if rend := g.world.Get(p.id, component.CRenderable); rend != nil {
+		r := rend.(component.Renderable)
+		r.FGColor = p.color
+		g.world.Add(p.id, r)
+	}
 }
 
 func (g *CoopGame) coopTryPickup(p *coopPlayer) {
+	if g.coopOpenChest(p) {
+		return
+	}
 	pos := g.coopPlayerPosition(p)
 	for _, itemID := range g.world.Query(component.CTagItem, component.CPosition) {
 		ipos := g.world.Get(itemID, component.CPosition).(component.Position)
@@ -805,11 +1214,10 @@ func (g *CoopGame) coopTryPickup(p *coopPlayer) {
 			return
 		}
 		inv := invComp.(component.Inventory)
-		if len(inv.Backpack) >= inv.Capacity {
+		if !inv.Stow(item) {
 			g.addMessage("Backpack full! Drop something first.")
 			return
 		}
-		inv.Backpack = append(inv.Backpack, item)
 		g.world.Add(p.id, inv)
 		g.world.DestroyEntity(itemID)
 		g.addMessage(fmt.Sprintf("%s picks up %s.", p.class.Name, item.Name))
@@ -828,6 +1236,13 @@ func (g *CoopGame) coopInteractFurniture(p *coopPlayer, id ecs.EntityID) {
 	if f.Used {
 		return
 	}
+	if g.world.Has(id, component.CVending) {
+		g.coopUseVendingMachine(p, id)
+		return
+	}
+	if g.world.Has(id, component.CRitual) && !g.coopAdvanceRitual(p, id) {
+		return
+	}
 	hasBonus := f.BonusATK != 0 || f.BonusDEF != 0 || f.BonusMaxHP != 0 ||
 		f.HealHP != 0 || f.PassiveKind != 0
 	if !hasBonus {
@@ -948,12 +1363,39 @@ func (g *CoopGame) coopApplyConsumable(p *coopPlayer, item component.Item) {
 			Kind: component.EffectDefenseBoost, Magnitude: 6, TurnsRemaining: 15,
 		})
 		g.addMessage("The Phase Rod envelops you. (+6 DEF, 15 turns)")
+	case assets.GlyphTempoTonic:
+		system.ApplyEffect(g.world, p.id, component.ActiveEffect{
+			Kind: component.EffectHaste, Magnitude: 1, TurnsRemaining: 10,
+		})
+		g.addMessage("Time quickens! (Haste: ability recharges 2x for 10 turns)")
+	case assets.GlyphSnareKit, assets.GlyphCaltropPouch:
+		kind, name := component.TrapSnare, "snare"
+		if glyph == assets.GlyphCaltropPouch {
+			kind, name = component.TrapSpike, "spike trap"
+		}
+		pc := g.world.Get(p.id, component.CPosition)
+		if pc == nil {
+			break
+		}
+		pos := pc.(component.Position)
+		if _, ok := system.PlaceTrap(g.world, g.gmap, p.id, pos.X, pos.Y, kind); ok {
+			g.addMessage(fmt.Sprintf("%s arms a hidden %s.", p.class.Name, name))
+		}
+	case assets.GlyphDecoyDoll:
+		pc := g.world.Get(p.id, component.CPosition)
+		if pc == nil {
+			break
+		}
+		x, y, ok := system.FindDeploySpot(g.world, g.gmap, pc.(component.Position))
+		if !ok {
+			break
+		}
+		factory.NewDecoy(g.world, p.id, x, y)
+		g.addMessage(fmt.Sprintf("%s sets down a decoy. Enemies that see it go after it. (%d turns)", p.class.Name, factory.DecoyLifespan))
 	case assets.GlyphApexCore:
 		p.baseMaxHP += 3
-		hp := g.world.Get(p.id, component.CHealth).(component.Health)
-		hp.Max += 3
-		hp.Current += 3
-		g.world.Add(p.id, hp)
+		g.coopRecalcPlayerMaxHP(p)
+		g.coopRestorePlayerHP(p, 3)
 		g.addMessage("The Apex Core integrates into your biology. (+3 MaxHP permanently)")
 	}
 }
@@ -997,12 +1439,11 @@ func (g *CoopGame) coopRunInventory(p *coopPlayer) bool {
 
 	for {
 		clampCursor()
-		g.coopDrawInventoryScreen(p.screen, inv, panel, cursor, statusMsg)
+		g.coopDrawInventoryScreen(p.screen, inv, p.hotbar, panel, cursor, statusMsg)
 
 		ev, ok := <-p.events
 		if !ok || ev == nil {
-			g.world.Add(p.id, inv)
-			g.coopRecalcPlayerMaxHP(p)
+			g.coopSaveInventory(p, inv)
 			return turnUsed
 		}
 		switch ev := ev.(type) {
@@ -1012,8 +1453,7 @@ func (g *CoopGame) coopRunInventory(p *coopPlayer) bool {
 			statusMsg = ""
 			switch ev.Key() {
 			case tcell.KeyEscape:
-				g.world.Add(p.id, inv)
-				g.coopRecalcPlayerMaxHP(p)
+				g.coopSaveInventory(p, inv)
 				return turnUsed
 			case tcell.KeyTab:
 				panel = 1 - panel
@@ -1040,18 +1480,18 @@ func (g *CoopGame) coopRunInventory(p *coopPlayer) bool {
 					statusMsg = msg
 					if used {
 						turnUsed = true
-						g.world.Add(p.id, inv)
-						g.coopRecalcPlayerMaxHP(p)
+						g.coopSaveInventory(p, inv)
 						return turnUsed
 					}
 				case 'd', 'D':
 					statusMsg = g.coopInvDrop(p, &inv, panel, &cursor)
 				case 'i', 'I', 'q', 'Q':
-					g.world.Add(p.id, inv)
-					g.coopRecalcPlayerMaxHP(p)
+					g.coopSaveInventory(p, inv)
 					return turnUsed
 				default:
-					if ev.Rune() >= '1' && ev.Rune() <= '9' {
+					if slot, ok := HotbarSlot(ev.Rune()); ok {
+						statusMsg = p.hotbar.Bind(inv, panel, cursor, slot)
+					} else if ev.Rune() >= '1' && ev.Rune() <= '9' {
 						idx := int(ev.Rune()-'0') - 1
 						if idx < len(inv.Backpack) {
 							panel = 0
@@ -1086,7 +1526,7 @@ func coopInvEquip(inv *component.Inventory, cursor int) string {
 		inv.Backpack = removeAt(inv.Backpack, cursor)
 		inv.Head = item
 		if !old.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, old)
+			inv.Stow(old)
 		}
 		return fmt.Sprintf("Equipped %s.", item.Name)
 	case component.SlotBody:
@@ -1094,7 +1534,7 @@ func coopInvEquip(inv *component.Inventory, cursor int) string {
 		inv.Backpack = removeAt(inv.Backpack, cursor)
 		inv.Body = item
 		if !old.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, old)
+			inv.Stow(old)
 		}
 		return fmt.Sprintf("Equipped %s.", item.Name)
 	case component.SlotFeet:
@@ -1102,7 +1542,7 @@ func coopInvEquip(inv *component.Inventory, cursor int) string {
 		inv.Backpack = removeAt(inv.Backpack, cursor)
 		inv.Feet = item
 		if !old.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, old)
+			inv.Stow(old)
 		}
 		return fmt.Sprintf("Equipped %s.", item.Name)
 	case component.SlotOneHand:
@@ -1110,29 +1550,23 @@ func coopInvEquip(inv *component.Inventory, cursor int) string {
 		inv.Backpack = removeAt(inv.Backpack, cursor)
 		inv.MainHand = item
 		if !old.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, old)
+			inv.Stow(old)
 		}
 		return fmt.Sprintf("Equipped %s.", item.Name)
 	case component.SlotTwoHand:
-		extra := 0
-		if !inv.MainHand.IsEmpty() {
-			extra++
-		}
-		if !inv.OffHand.IsEmpty() {
-			extra++
+		var displaced []component.Item
+		for _, held := range []component.Item{inv.OffHand, inv.MainHand} {
+			if !held.IsEmpty() {
+				displaced = append(displaced, held)
+			}
 		}
-		if len(inv.Backpack)-1+extra > inv.Capacity {
+		// The cursor item leaves the backpack before the held items enter it.
+		if !inv.CanHold(len(displaced) - 1) {
 			return "Not enough backpack space to swap."
 		}
 		inv.Backpack = removeAt(inv.Backpack, cursor)
-		if !inv.OffHand.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, inv.OffHand)
-			inv.OffHand = component.Item{}
-		}
-		if !inv.MainHand.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, inv.MainHand)
-		}
-		inv.MainHand = item
+		inv.Stow(displaced...)
+		inv.MainHand, inv.OffHand = item, component.Item{}
 		return fmt.Sprintf("Equipped %s (two-handed).", item.Name)
 	case component.SlotOffHand:
 		if inv.MainHand.Slot == component.SlotTwoHand {
@@ -1142,7 +1576,7 @@ func coopInvEquip(inv *component.Inventory, cursor int) string {
 		inv.Backpack = removeAt(inv.Backpack, cursor)
 		inv.OffHand = item
 		if !old.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, old)
+			inv.Stow(old)
 		}
 		return fmt.Sprintf("Equipped %s.", item.Name)
 	}
@@ -1150,7 +1584,7 @@ func coopInvEquip(inv *component.Inventory, cursor int) string {
 }
 
 func coopInvUnequip(inv *component.Inventory, cursor int) string {
-	if len(inv.Backpack) >= inv.Capacity {
+	if !inv.CanHold(1) {
 		return "Backpack full — drop something first."
 	}
 	var item component.Item
@@ -1188,7 +1622,7 @@ func coopInvUnequip(inv *component.Inventory, cursor int) string {
 	default:
 		return "Invalid slot."
 	}
-	inv.Backpack = append(inv.Backpack, item)
+	inv.Stow(item)
 	return fmt.Sprintf("Unequipped %s.", item.Name)
 }
 
@@ -1203,11 +1637,33 @@ func (g *CoopGame) coopInvUseConsumable(p *coopPlayer, inv *component.Inventory,
 	if !item.IsConsumable {
 		return "Equipment must be equipped, not used.", false
 	}
+	if item.Charges > 0 {
+		return useCharge(inv, cursor, func(it component.Item) bool { return g.coopZapWand(p, it) })
+	}
+	if why := g.coopConsumableRefusal(p, item); why != "" {
+		return why, false
+	}
 	inv.Backpack = removeAt(inv.Backpack, cursor)
 	g.coopApplyConsumable(p, item)
 	return fmt.Sprintf("Used %s.", item.Name), true
 }
 
+// coopConsumableRefusal is consumableRefusal for co-op player p.
+func (g *CoopGame) coopConsumableRefusal(p *coopPlayer, item component.Item) string {
+	pos := g.coopPlayerPosition(p)
+	switch item.Glyph {
+	case assets.GlyphSnareKit, assets.GlyphCaltropPouch:
+		if !system.CanPlaceTrap(g.world, g.gmap, pos.X, pos.Y) {
+			return "You can't set a trap here."
+		}
+	case assets.GlyphDecoyDoll:
+		if _, _, ok := system.FindDeploySpot(g.world, g.gmap, pos); !ok {
+			return "No room to set a decoy down here."
+		}
+	}
+	return ""
+}
+
 func (g *CoopGame) coopInvDrop(p *coopPlayer, inv *component.Inventory, panel int, cursor *int) string {
 	pos := g.coopPlayerPosition(p)
 	var item component.Item
@@ -1261,7 +1717,7 @@ func (g *CoopGame) coopInvDrop(p *coopPlayer, inv *component.Inventory, panel in
 }
 
 // coopDrawInventoryScreen renders the inventory UI onto the given screen.
-func (g *CoopGame) coopDrawInventoryScreen(screen tcell.Screen, inv component.Inventory, panel, cursor int, statusMsg string) {
+func (g *CoopGame) coopDrawInventoryScreen(screen tcell.Screen, inv component.Inventory, hotbar Hotbar, panel, cursor int, statusMsg string) {
 	screen.Clear()
 	sw, _ := screen.Size()
 	mid := sw / 2
@@ -1294,7 +1750,7 @@ func (g *CoopGame) coopDrawInventoryScreen(screen tcell.Screen, inv component.In
 		screen.SetContent(x, 1, '─', nil, gray)
 	}
 	put(0, 2, "── EQUIPPED ──────────────────", white)
-	put(mid, 2, "── BACKPACK ─────────────────", white)
+	put(mid, 2, "── BACKPACK ─ [!@#$] Hotbar ─", white)
 	for y := 2; y <= 12; y++ {
 		screen.SetContent(mid-1, y, '│', nil, gray)
 	}
@@ -1322,9 +1778,7 @@ func (g *CoopGame) coopDrawInventoryScreen(screen tcell.Screen, inv component.In
 		put(0, row, fmt.Sprintf("%s%s %s", pfx, slot.label, itemStr), style)
 	}
 
-	atkB := inv.Head.BonusATK + inv.Body.BonusATK + inv.Feet.BonusATK + inv.MainHand.BonusATK + inv.OffHand.BonusATK
-	defB := inv.Head.BonusDEF + inv.Body.BonusDEF + inv.Feet.BonusDEF + inv.MainHand.BonusDEF + inv.OffHand.BonusDEF
-	hpB := inv.Head.BonusMaxHP + inv.Body.BonusMaxHP + inv.Feet.BonusMaxHP + inv.MainHand.BonusMaxHP + inv.OffHand.BonusMaxHP
+	atkB, defB, hpB := inv.EquipBonuses()
 	put(0, 8, fmt.Sprintf("  Equip bonus: ATK%+d DEF%+d HP%+d", atkB, defB, hpB), cyan)
 
 	for i, item := range inv.Backpack {
@@ -1340,9 +1794,12 @@ func (g *CoopGame) coopDrawInventoryScreen(screen tcell.Screen, inv component.In
 			pfx = "► "
 		}
 		tag := ""
-		if item.IsConsumable {
+		if item.Charges > 0 {
+			tag = wandTag(item)
+		} else if item.IsConsumable {
 			tag = " [use]"
 		}
+		tag += hotbar.Tag(item)
 		put(mid, row, fmt.Sprintf("%s[%d] %s %s%s%s", pfx, i+1, item.Glyph, item.Name, formatBonuses(item), tag), style)
 	}
 	if len(inv.Backpack) == 0 {
@@ -1377,6 +1834,9 @@ func (g *CoopGame) coopDrawInventoryScreen(screen tcell.Screen, inv component.In
 		put(0, 12, fmt.Sprintf("%s — %s  ATK%+d DEF%+d MaxHP%+d",
 			selItem.Name, slotLabel(selItem.Slot), selItem.BonusATK, selItem.BonusDEF, selItem.BonusMaxHP), white)
 	}
+	if panel == 0 && !selEmpty {
+		DrawEquipComparison(screen, 14, inv, selItem)
+	}
 	if statusMsg != "" {
 		put(0, 13, statusMsg, green)
 	}
@@ -1513,12 +1973,33 @@ func (g *CoopGame) showCoopEndScreen() {
 		label(y, "Damage Dealt:", fmt.Sprintf("%d", totalDmgDealt))
 		y++
 		label(y, "Damage Taken:", fmt.Sprintf("%d", totalDmgTaken))
+		y++
+		label(y, "Gold Earned:", fmt.Sprintf("%d", g.players[0].runLog.GoldEarned+g.players[1].runLog.GoldEarned))
 		y += 2
 
 		if won {
 			put(2, y, "The Unmaker is unmade. Together, you silenced the Spire.", green)
+		} else {
+			// Death recap: each player's killing blow and final HP trajectory.
+			for i, p := range g.players {
+				if p.runLog.CauseOfDeath == "" {
+					continue
+				}
+				line := p.runLog.killerLabel()
+				if hp := p.runLog.hpTrajectory(); hp != "" {
+					line += "  HP " + hp
+				}
+				label(y-1+i, fmt.Sprintf("P%d Killed By:", i+1), line)
+			}
 		}
-		y += 2
+		for i, p := range g.players {
+			style := gray
+			if won {
+				style = green
+			}
+			put(2, y+1+i, fmt.Sprintf("P%d %s", i+1, assets.EpilogueFor(p.class.ID, won)), style)
+		}
+		y += 3
 
 		sep(y)
 		y += 2
@@ -1527,26 +2008,31 @@ func (g *CoopGame) showCoopEndScreen() {
 		screen.Show()
 	}
 
-	// Draw on all screens and wait for either player to press Q.
+	// Draw on every connected screen and wait for either player to press Q
+	// or drop.
 	done := make(chan struct{})
+	var once sync.Once
+	finish := func() { once.Do(func() { close(done) }) }
+	waiting := false
 	for _, p := range g.players {
+		if p.disconnected {
+			continue
+		}
+		waiting = true
 		p := p
 		draw(p.screen)
 		go func() {
+			defer restoreOnPanic(g.screens()...)
 			for {
 				ev, ok := <-p.events
 				if !ok || ev == nil {
-					close(done)
+					finish()
 					return
 				}
 				if ev, ok := ev.(*tcell.EventKey); ok {
 					r := ev.Rune()
 					if r == 'q' || r == 'Q' || ev.Key() == tcell.KeyEscape {
-						select {
-						case <-done:
-						default:
-							close(done)
-						}
+						finish()
 						return
 					}
 				}
@@ -1557,15 +2043,14 @@ func (g *CoopGame) showCoopEndScreen() {
 			}
 		}()
 	}
-	<-done
+	if waiting {
+		<-done
+	}
 }
 
 // addMessage appends a message to the shared log (capped at 50).
 func (g *CoopGame) addMessage(msg string) {
-	g.messages = append(g.messages, msg)
-	if len(g.messages) > 50 {
-		g.messages = g.messages[len(g.messages)-50:]
-	}
+	g.messages = capMessages(append(g.messages, msg), defaultMessageLimit)
 }
 
 func (g *CoopGame) entityName(id ecs.EntityID) string {
@@ -3,6 +3,8 @@ package game
 import (
 	"emoji-roguelike/assets"
 	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/gamemap"
+	"strings"
 	"testing"
 
 	"github.com/gdamore/tcell/v2"
@@ -228,3 +230,180 @@ func TestCoopGamePlayersDifferentColors(t *testing.T) {
 		t.Errorf("both players have the same FGColor (%v); they should be distinct", rend0.FGColor)
 	}
 }
+
+// TestCoopPlayerColorSurvivesTeleport checks that P2 is repainted fuchsia
+// after teleporting even if its Renderable was rewritten with the default
+// player colour beforehand.
+func TestCoopPlayerColorSurvivesTeleport(t *testing.T) {
+	g := newTestCoopGame()
+	g.loadFloor(1)
+	p2 := g.players[1]
+	rend := g.world.Get(p2.id, component.CRenderable).(component.Renderable)
+	rend.FGColor = tcell.ColorYellow
+	g.world.Add(p2.id, rend)
+
+	g.coopTeleportPlayer(p2)
+
+	if got := g.world.Get(p2.id, component.CRenderable).(component.Renderable).FGColor; got != tcell.ColorFuchsia {
+		t.Errorf("P2 FGColor after teleport = %v; want fuchsia", got)
+	}
+}
+
+// screenHasRune reports whether r appears anywhere on the map area of screen.
+func screenHasRune(screen tcell.Screen, r rune) bool {
+	w, h := screen.Size()
+	for y := range h - 5 {
+		for x := range w {
+			if mainc, _, _, _ := screen.GetContent(x, y); mainc == r {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestCoopSharedScreenShowsBothPlayers(t *testing.T) {
+	g := newTestCoopGame()
+	shared := newSimScreen()
+	g.SetSharedScreen(shared)
+	g.loadFloor(1)
+	g.renderAll()
+
+	for i, p := range g.players {
+		glyph := []rune(g.world.Get(p.id, component.CRenderable).(component.Renderable).Glyph)[0]
+		if !screenHasRune(shared, glyph) {
+			t.Errorf("player %d's glyph missing from the shared screen", i+1)
+		}
+		pos := g.coopPlayerPosition(p)
+		if !g.gmap.At(pos.X, pos.Y).Visible {
+			t.Errorf("player %d's tile should be in the shared FOV", i+1)
+		}
+	}
+	if screenHasRune(shared, '│') {
+		t.Error("players standing together should share one unsplit view")
+	}
+}
+
+func TestCoopSharedScreenSplitsWhenApart(t *testing.T) {
+	g := newTestCoopGame()
+	shared := newSimScreen()
+	shared.SetSize(40, 24) // narrow enough that the two ends of floor 1 cannot share a view
+	g.SetSharedScreen(shared)
+	g.loadFloor(1)
+
+	// Move P2 to the walkable tile farthest from P1.
+	p1 := g.coopPlayerPosition(g.players[0])
+	far, best := p1, 0
+	for y := range g.gmap.Height {
+		for x := range g.gmap.Width {
+			if d := (x-p1.X)*(x-p1.X) + (y-p1.Y)*(y-p1.Y); g.gmap.IsWalkable(x, y) && d > best {
+				far, best = component.Position{X: x, Y: y}, d
+			}
+		}
+	}
+	g.world.Add(g.players[1].id, far)
+	g.renderAll()
+
+	if !screenHasRune(shared, '│') {
+		t.Error("players far apart should get a split view")
+	}
+	for i, p := range g.players {
+		glyph := []rune(g.world.Get(p.id, component.CRenderable).(component.Renderable).Glyph)[0]
+		if !screenHasRune(shared, glyph) {
+			t.Errorf("player %d's glyph missing from the split view", i+1)
+		}
+	}
+}
+
+// TestCoopWaitForPartyNeedsEveryVote verifies that with waitForParty on, one
+// player taking the stairs only casts a vote, and that the votes reset on the
+// next floor.
+func TestCoopWaitForPartyNeedsEveryVote(t *testing.T) {
+	g := newTestCoopGame()
+	g.SetWaitForParty(true)
+	g.loadFloor(1)
+	p1, p2 := g.players[0], g.players[1]
+	for y := range g.gmap.Height {
+		for x := range g.gmap.Width {
+			if g.gmap.At(x, y).Kind == gamemap.TileStairsDown {
+				g.world.Add(p1.id, component.Position{X: x, Y: y})
+			}
+		}
+	}
+
+	g.processCoopAction(p1, ActionDescend)
+	if g.floor != 1 {
+		t.Fatalf("floor = %d after one vote; want the party to stay on 1", g.floor)
+	}
+	if !strings.Contains(g.messages[len(g.messages)-1], "Waiting for "+p2.class.Name) {
+		t.Errorf("last message = %q; want it to name the player being waited on", g.messages[len(g.messages)-1])
+	}
+	if !g.voteToDescend(p2) {
+		t.Error("the second vote should send the party down")
+	}
+
+	g.loadFloor(2)
+	if g.voteToDescend(p1) {
+		t.Error("votes from the previous floor should not carry over")
+	}
+	p2.alive = false
+	if !g.voteToDescend(p1) {
+		t.Error("a fallen player should not hold the party back")
+	}
+}
+
+// TestCoopDropTwoHanderFreesOffHand mirrors TestDropTwoHanderFreesOffHand for
+// the co-op inventory.
+func TestCoopDropTwoHanderFreesOffHand(t *testing.T) {
+	g := newTestCoopGame()
+	g.loadFloor(1)
+	p := g.players[0]
+	inv := g.world.Get(p.id, component.CInventory).(component.Inventory)
+	inv.Backpack = append(inv.Backpack, greatblade, buckler)
+
+	g.coopInvEquipOrUnequip(p, &inv, 0, backpackIndex(inv, greatblade.Name))
+	cursor := 3 // weapon slot
+	g.coopInvDrop(p, &inv, 1, &cursor)
+	g.coopInvEquipOrUnequip(p, &inv, 0, backpackIndex(inv, buckler.Name))
+
+	if !inv.MainHand.IsEmpty() || inv.OffHand.Name != buckler.Name {
+		t.Errorf("MainHand %q, OffHand %q; want empty and the buckler", inv.MainHand.Name, inv.OffHand.Name)
+	}
+	if backpackIndex(inv, buckler.Name) >= 0 || backpackIndex(inv, greatblade.Name) >= 0 {
+		t.Errorf("backpack still holds the buckler or greatblade: %v", inv.Backpack)
+	}
+}
+
+func TestWaitPlayerActionReportsDisconnect(t *testing.T) {
+	g := newTestCoopGame()
+	g.loadFloor(1)
+	close(g.players[1].events)
+	if got := g.waitPlayerAction(g.players[1]); got != ActionDisconnect {
+		t.Errorf("closed connection gave action %d; want ActionDisconnect, not a quit", got)
+	}
+}
+
+func TestCoopDisconnectLeavesPartnerPlaying(t *testing.T) {
+	g := newTestCoopGame()
+	g.loadFloor(1)
+	gone := g.players[1].id
+
+	g.coopDisconnect(g.players[1])
+	if g.state != StatePlaying {
+		t.Fatalf("state = %v after one player dropped; want the game to go on", g.state)
+	}
+	if g.world.Alive(gone) || g.players[1].alive {
+		t.Error("the dropped player's character should leave the map")
+	}
+	if got := len(g.world.Query(component.CTagPlayer)); got != 1 {
+		t.Errorf("%d players on the map; want only the one still connected", got)
+	}
+	if last := g.messages[len(g.messages)-1]; !strings.Contains(last, "lost their connection") {
+		t.Errorf("last message = %q; want the remaining player told their partner dropped", last)
+	}
+
+	g.coopDisconnect(g.players[0])
+	if g.state != StateDead {
+		t.Errorf("state = %v with nobody left; want the game to end", g.state)
+	}
+}
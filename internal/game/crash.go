@@ -0,0 +1,19 @@
+package game
+
+import "github.com/gdamore/tcell/v2"
+
+// restoreOnPanic finalizes screens if the calling goroutine is panicking,
+// then re-raises the panic so the runtime still reports it. Run's deferred
+// Fini covers the main goroutine, but a panic anywhere else ends the process
+// before that runs, so every goroutine that draws or handles input defers
+// this instead; the report then lands on a shell that is out of raw mode.
+func restoreOnPanic(screens ...tcell.Screen) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	for _, s := range screens {
+		s.Fini()
+	}
+	panic(r)
+}
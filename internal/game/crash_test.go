@@ -0,0 +1,82 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// finiSpy is a simulation screen that counts Fini calls and can be made to
+// panic on the next PollEvent.
+type finiSpy struct {
+	tcell.SimulationScreen
+	finis       int
+	panicOnPoll bool
+}
+
+func newFiniSpy(t *testing.T) *finiSpy {
+	t.Helper()
+	ss := tcell.NewSimulationScreen("UTF-8")
+	ss.SetSize(80, 24)
+	if err := ss.Init(); err != nil {
+		t.Fatalf("SimulationScreen.Init: %v", err)
+	}
+	return &finiSpy{SimulationScreen: ss}
+}
+
+func (s *finiSpy) Fini() {
+	s.finis++
+	s.SimulationScreen.Fini()
+}
+
+func (s *finiSpy) PollEvent() tcell.Event {
+	if s.panicOnPoll {
+		panic("boom")
+	}
+	return s.SimulationScreen.PollEvent()
+}
+
+// recoverValue runs f and returns whatever it panicked with.
+func recoverValue(f func()) (r any) {
+	defer func() { r = recover() }()
+	f()
+	return nil
+}
+
+func TestRunFinisScreenOnPanic(t *testing.T) {
+	g := newAbilityTestGame(t, "arcanist")
+	spy := newFiniSpy(t)
+	spy.panicOnPoll = true
+	g.screen = spy
+
+	if r := recoverValue(g.Run); r != "boom" {
+		t.Fatalf("Run panicked with %v; want the injected panic re-raised", r)
+	}
+	if spy.finis != 1 {
+		t.Errorf("Fini called %d times; want 1", spy.finis)
+	}
+}
+
+func TestRestoreOnPanicFinisEveryScreen(t *testing.T) {
+	a, b := newFiniSpy(t), newFiniSpy(t)
+	r := recoverValue(func() {
+		defer restoreOnPanic(a, b)
+		panic("boom")
+	})
+	if r != "boom" {
+		t.Fatalf("recovered %v; want the panic re-raised", r)
+	}
+	if a.finis != 1 || b.finis != 1 {
+		t.Errorf("Fini calls = %d, %d; want 1 on each screen", a.finis, b.finis)
+	}
+}
+
+func TestRestoreOnPanicLeavesScreensOnNormalReturn(t *testing.T) {
+	spy := newFiniSpy(t)
+	func() {
+		defer restoreOnPanic(spy)
+	}()
+	if spy.finis != 0 {
+		t.Errorf("Fini called %d times without a panic; want 0", spy.finis)
+	}
+}
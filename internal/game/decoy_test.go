@@ -0,0 +1,54 @@
+package game
+
+import (
+	"testing"
+
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/factory"
+	"emoji-roguelike/internal/gamemap"
+)
+
+func TestDecoyDollSetsDownDecoyBesidePlayer(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	killAllEnemies(g)
+	g.applyConsumable(component.Item{Name: "Decoy Doll", Glyph: assets.GlyphDecoyDoll, IsConsumable: true})
+
+	decoys := g.world.Query(component.CDecoy, component.CPosition)
+	if len(decoys) != 1 {
+		t.Fatalf("%d decoys on the floor; want 1", len(decoys))
+	}
+	d := g.world.Get(decoys[0], component.CDecoy).(component.Decoy)
+	if d.Owner != g.playerID || d.TurnsLeft != factory.DecoyLifespan {
+		t.Errorf("decoy %+v; want owned by the player with %d turns", d, factory.DecoyLifespan)
+	}
+	pos := g.world.Get(decoys[0], component.CPosition).(component.Position)
+	if p := g.playerPosition(); max(abs(pos.X-p.X), abs(pos.Y-p.Y)) != 1 {
+		t.Errorf("decoy at %v, player at %v; want it beside the player", pos, p)
+	}
+}
+
+func TestDecoyDollKeptWhenBoxedIn(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	killAllEnemies(g)
+	p := g.playerPosition()
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if dx != 0 || dy != 0 {
+				g.gmap.Set(p.X+dx, p.Y+dy, gamemap.MakeWall())
+			}
+		}
+	}
+	inv := g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	inv.Backpack = []component.Item{{Name: "Decoy Doll", Glyph: assets.GlyphDecoyDoll, IsConsumable: true}}
+
+	if msg, used := g.invUseConsumable(&inv, 0, 0); used {
+		t.Errorf("invUseConsumable = %q, used; want the use refused", msg)
+	}
+	if len(inv.Backpack) != 1 {
+		t.Errorf("backpack = %+v; want the Decoy Doll kept", inv.Backpack)
+	}
+	if n := len(g.world.Query(component.CDecoy)); n != 0 {
+		t.Errorf("%d decoys on the floor; want 0", n)
+	}
+}
@@ -0,0 +1,38 @@
+package game
+
+import (
+	"fmt"
+
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/system"
+)
+
+// echoTells describes what an echo's copy of each class ability looks like.
+var echoTells = map[string]string{
+	"arcanist":  "it steps out of a rift!",
+	"revenant":  "it bleeds for power!",
+	"construct": "its limbs blur with stolen speed!",
+	"dancer":    "it fades from sight!",
+	"oracle":    "its gaze sweeps the whole floor!",
+	"symbiont":  "its wounds knit shut!",
+	"warden":    "it braces behind a mirrored guard!",
+}
+
+// EchoMessage describes an echo copying classID's ability.
+func EchoMessage(glyph, classID string) string {
+	name := classID
+	for _, c := range assets.Classes {
+		if c.ID == classID {
+			name = c.AbilityName
+			break
+		}
+	}
+	return fmt.Sprintf("The %s mirrors %s: %s", glyph, name, echoTells[classID])
+}
+
+// resolveEchoes reports the class abilities echoes copied this turn.
+func (g *Game) resolveEchoes(casts []system.EchoCast) {
+	for _, c := range casts {
+		g.addMessage(EchoMessage(c.Glyph, c.ClassID))
+	}
+}
@@ -0,0 +1,39 @@
+package game
+
+import (
+	"testing"
+
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/gamemap"
+	"emoji-roguelike/internal/system"
+)
+
+func TestMirrorEchoCopiesPlayerClass(t *testing.T) {
+	g := newAbilityTestGame(t, "arcanist")
+	for _, id := range g.world.Query(component.CAI) {
+		g.world.DestroyEntity(id)
+	}
+	pos := g.playerPosition()
+	for dx := 1; dx <= 3; dx++ {
+		g.gmap.Set(pos.X+dx, pos.Y, gamemap.MakeFloor())
+	}
+	echo := g.world.CreateEntity()
+	g.world.Add(echo, component.Position{X: pos.X + 3, Y: pos.Y})
+	g.world.Add(echo, component.AI{Behavior: component.BehaviorChase, SightRange: 8})
+	g.world.Add(echo, component.Combat{Attack: 1})
+	g.world.Add(echo, component.Health{Current: 16, Max: 16})
+	g.world.Add(echo, component.TagBlocking{})
+	g.world.Add(echo, component.Renderable{Glyph: assets.GlyphMirrorEcho})
+	g.world.Add(echo, component.Echo{Every: 8})
+	system.AttuneEchoes(g.world, []string{g.selectedClass.ID})
+
+	g.processAction(ActionWait)
+	if !hasMessage(g, "mirrors Dimensional Rift") {
+		t.Errorf("messages = %v; want the echo to copy the arcanist's rift", g.messages)
+	}
+	epos := g.world.Get(echo, component.CPosition).(component.Position)
+	if max(abs(epos.X-pos.X), abs(epos.Y-pos.Y)) != 1 {
+		t.Errorf("echo at %v; want beside the player at %v", epos, pos)
+	}
+}
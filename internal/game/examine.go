@@ -0,0 +1,236 @@
+package game
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/factory"
+	"emoji-roguelike/internal/gamemap"
+	"emoji-roguelike/internal/system"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// threatTints are the backgrounds drawn behind enemies when threat tinting is
+// on; threatText colours the HUD and examine readouts.
+var (
+	threatTints = [...]tcell.Color{
+		system.ThreatTrivial:  tcell.ColorDarkGreen,
+		system.ThreatModerate: tcell.ColorDarkOrange,
+		system.ThreatDeadly:   tcell.ColorMaroon,
+	}
+	threatText = [...]tcell.Color{
+		system.ThreatTrivial:  tcell.ColorGreen,
+		system.ThreatModerate: tcell.ColorOrange,
+		system.ThreatDeadly:   tcell.ColorRed,
+	}
+)
+
+// tileNames describes map tiles in examine mode.
+var tileNames = map[gamemap.TileKind]string{
+	gamemap.TileWall:       "a wall",
+	gamemap.TileFloor:      "the floor",
+	gamemap.TileDoor:       "a closed door",
+	gamemap.TileStairsUp:   "stairs leading up",
+	gamemap.TileStairsDown: "stairs leading down",
+	gamemap.TileGrass:      "grass",
+	gamemap.TileWater:      "water",
+	gamemap.TileChute:      "a chute plunging two floors down",
+}
+
+// hostileEnemy reports whether id is an AI-driven combatant that fights the
+// player.
+func (g *Game) hostileEnemy(id ecs.EntityID) bool {
+	ac := g.world.Get(id, component.CAI)
+	return ac != nil && ac.(component.AI).Behavior != component.BehaviorAlly &&
+		g.world.Get(id, component.CHealth) != nil
+}
+
+// enemyLabel names an enemy by its glyph, falling back to the glyph itself,
+// with its champion prefix if it has one.
+func (g *Game) enemyLabel(id ecs.EntityID) string {
+	glyph := g.entityName(id)
+	if name := assets.EnemyName(glyph); name != "" {
+		return ChampionName(g.world, id, name)
+	}
+	return ChampionName(g.world, id, glyph)
+}
+
+// ChampionName prefixes name, the display name of enemy id, with its
+// champion modifier, e.g. "Vicious 🐀". Other enemies keep name as it is.
+func ChampionName(w *ecs.World, id ecs.EntityID, name string) string {
+	if cc := w.Get(id, component.CChampion); cc != nil {
+		return cc.(component.Champion).Prefix + " " + name
+	}
+	return name
+}
+
+// threatTintMap rates every hostile enemy on the floor for the optional
+// threat tint.
+func (g *Game) threatTintMap() map[ecs.EntityID]tcell.Color {
+	tints := make(map[ecs.EntityID]tcell.Color)
+	for _, id := range g.world.Query(component.CAI, component.CPosition) {
+		if g.hostileEnemy(id) {
+			tints[id] = threatTints[system.AssessThreat(g.world, g.playerID, id)]
+		}
+	}
+	return tints
+}
+
+// adjacentThreat returns a HUD note naming the most dangerous hostile enemy
+// next to the player and its threat level, or "" when none is adjacent.
+func (g *Game) adjacentThreat() (string, tcell.Color) {
+	pos := g.playerPosition()
+	worst, found := system.ThreatTrivial, ecs.NilEntity
+	for _, id := range g.world.Query(component.CAI, component.CPosition) {
+		p := g.world.Get(id, component.CPosition).(component.Position)
+		if dx, dy := p.X-pos.X, p.Y-pos.Y; dx < -1 || dx > 1 || dy < -1 || dy > 1 || !g.hostileEnemy(id) {
+			continue
+		}
+		if lvl := system.AssessThreat(g.world, g.playerID, id); found == ecs.NilEntity || lvl > worst {
+			worst, found = lvl, id
+		}
+	}
+	if found == ecs.NilEntity {
+		return "", tcell.ColorDefault
+	}
+	return fmt.Sprintf("%s: %s", g.enemyLabel(found), worst), threatText[worst]
+}
+
+// runExamine moves a cursor over the map, describing whatever is under it,
+// until the player presses Escape, q or e.
+func (g *Game) runExamine() {
+	pos := g.playerPosition()
+	cx, cy := pos.X, pos.Y
+	for {
+		desc, color := g.describeAt(cx, cy)
+		g.drawTargeting("", cx, cy, true)
+		g.putText(0, 0, "Examine: ", tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true))
+		g.putText(9, 0, desc+"   [Esc] done", tcell.StyleDefault.Foreground(color).Bold(true))
+		if drops := g.lootAt(cx, cy); drops != "" {
+			g.putText(9, 1, drops, tcell.StyleDefault.Foreground(tcell.ColorAqua))
+		}
+		g.screen.Show()
+
+		ev := g.screen.PollEvent()
+		switch ev := ev.(type) {
+		case *tcell.EventResize:
+			g.screen.Sync()
+		case *tcell.EventKey:
+			if ev.Key() == tcell.KeyEscape {
+				return
+			}
+			if ev.Key() == tcell.KeyRune {
+				switch ev.Rune() {
+				case 'q', 'Q', 'e', 'E':
+					return
+				}
+			}
+			dx, dy := actionToDelta(keyToAction(ev))
+			if g.gmap.InBounds(cx+dx, cy+dy) {
+				cx, cy = cx+dx, cy+dy
+			}
+		}
+	}
+}
+
+// describeSelf describes the player, with their proficiency in any weapon
+// they wield.
+func (g *Game) describeSelf() string {
+	ic := g.world.Get(g.playerID, component.CInventory)
+	if ic == nil || ic.(component.Inventory).MainHand.IsEmpty() {
+		return "You."
+	}
+	weapon := ic.(component.Inventory).MainHand
+	return fmt.Sprintf("You, wielding the %s.%s", weapon.Name, proficiencyTag(g.weaponHits[weapon.Name]))
+}
+
+// lootAt previews what the enemy at (x, y) may drop on this floor, or returns
+// "" when the player cannot see an enemy there.
+func (g *Game) lootAt(x, y int) string {
+	if !g.gmap.At(x, y).Visible {
+		return ""
+	}
+	at := component.Position{X: x, Y: y}
+	for _, id := range g.world.Query(component.CPosition, component.CLoot) {
+		if g.world.Get(id, component.CPosition).(component.Position) == at &&
+			g.hostileEnemy(id) && !system.Concealed(g.world, g.playerID, id) {
+			return describeLoot(g.world.Get(id, component.CLoot).(component.Loot), g.floor)
+		}
+	}
+	return ""
+}
+
+// describeLoot formats a loot table as a one-line drop preview, e.g.
+// "Drops: 💎 always, 🧪 25%, +12% for a random item".
+func describeLoot(loot component.Loot, floor int) string {
+	drops, pool := factory.PreviewLoot(loot, floor)
+	var parts []string
+	for _, d := range drops {
+		if d.Chance >= 100 {
+			parts = append(parts, d.Glyph+" always")
+		} else {
+			parts = append(parts, fmt.Sprintf("%s %d%%", d.Glyph, d.Chance))
+		}
+	}
+	if pool > 0 {
+		parts = append(parts, fmt.Sprintf("+%d%% for a random item", pool))
+	}
+	if len(parts) == 0 {
+		return "Drops nothing."
+	}
+	return "Drops: " + strings.Join(parts, ", ")
+}
+
+// describeAt describes the most notable thing at (x, y) as the player knows
+// it, with the colour to show it in. Enemies include their stats and threat;
+// ambushers the player cannot see go undescribed.
+func (g *Game) describeAt(x, y int) (string, tcell.Color) {
+	tile := g.gmap.At(x, y)
+	if !tile.Explored && !tile.Visible {
+		return "You haven't seen that spot.", tcell.ColorGray
+	}
+	if tile.Visible {
+		at := component.Position{X: x, Y: y}
+		var itemName string
+		for _, id := range g.world.Query(component.CPosition) {
+			if g.world.Get(id, component.CPosition).(component.Position) != at {
+				continue
+			}
+			switch {
+			case id == g.playerID:
+				return g.describeSelf(), tcell.ColorWhite
+			case g.hostileEnemy(id) && !system.Concealed(g.world, g.playerID, id):
+				hp := g.world.Get(id, component.CHealth).(component.Health)
+				cb, _ := g.world.Get(id, component.CCombat).(component.Combat)
+				lvl := system.AssessThreat(g.world, g.playerID, id)
+				return fmt.Sprintf("%s — HP %d/%d ATK %d DEF %d — Threat: %s",
+					g.enemyLabel(id), hp.Current, hp.Max, cb.Attack, cb.Defense, lvl), threatText[lvl]
+			case g.world.Get(id, component.CNPC) != nil:
+				return g.world.Get(id, component.CNPC).(component.NPC).Name + ".", tcell.ColorWhite
+			case g.world.Get(id, component.CFurniture) != nil:
+				f := g.world.Get(id, component.CFurniture).(component.Furniture)
+				if r, ok := g.world.Get(id, component.CRitual).(component.Ritual); ok && !r.Complete() {
+					return fmt.Sprintf("%s — offerings %d/%d %s.", f.Name, r.Offered, r.Needed, r.Offering), tcell.ColorYellow
+				}
+				return f.Name + ".", tcell.ColorYellow
+			case g.world.Get(id, component.CItem) != nil:
+				itemName = g.world.Get(id, component.CItem).(component.CItemComp).Item.Name
+			}
+		}
+		if itemName != "" {
+			return itemName + " lies here.", tcell.ColorAqua
+		}
+	}
+	name := tileNames[tile.Kind]
+	if tile.Sanctuary && tile.Kind == gamemap.TileFloor {
+		name = "the floor of a sanctuary, where no enemy will tread"
+	}
+	desc := "You see " + name + "."
+	if !tile.Visible {
+		desc = "You remember " + name + "."
+	}
+	return desc, tcell.ColorSilver
+}
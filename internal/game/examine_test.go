@@ -0,0 +1,124 @@
+package game
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/system"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestDescribeAtRatesEnemyThreat(t *testing.T) {
+	g, brute := newRiskyGame(t)
+	p := g.world.Get(brute, component.CPosition).(component.Position)
+	desc, color := g.describeAt(p.X, p.Y)
+	if !strings.Contains(desc, "Threat: Deadly") || !strings.Contains(desc, "ATK 40") {
+		t.Errorf("describeAt(brute) = %q; want stats and a Deadly rating", desc)
+	}
+	if color != tcell.ColorRed {
+		t.Errorf("deadly readout colour = %v; want red", color)
+	}
+	pos := g.playerPosition()
+	if desc, _ := g.describeAt(pos.X, pos.Y); desc != "You." {
+		t.Errorf("describeAt(player) = %q; want \"You.\"", desc)
+	}
+}
+
+func TestAdjacentThreatNote(t *testing.T) {
+	g, brute := newRiskyGame(t)
+	if note, _ := g.adjacentThreat(); note != "" {
+		t.Errorf("no enemy adjacent, got note %q", note)
+	}
+	pos := g.playerPosition()
+	g.world.Add(brute, component.Position{X: pos.X + 1, Y: pos.Y})
+	if note, _ := g.adjacentThreat(); !strings.HasSuffix(note, ": Deadly") {
+		t.Errorf("adjacent brute note = %q; want a Deadly rating", note)
+	}
+}
+
+func TestChampionNamedInExamine(t *testing.T) {
+	g, brute := newRiskyGame(t)
+	g.world.Add(brute, component.Champion{Prefix: "Vicious"})
+	p := g.world.Get(brute, component.CPosition).(component.Position)
+	if desc, _ := g.describeAt(p.X, p.Y); !strings.HasPrefix(desc, "Vicious ") {
+		t.Errorf("describeAt(champion) = %q; want the Vicious prefix", desc)
+	}
+	if got := ChampionName(g.world, g.playerID, "you"); got != "you" {
+		t.Errorf("ChampionName(non-champion) = %q; want the name unchanged", got)
+	}
+}
+
+func TestExamineModeClosesWithoutSpendingTurn(t *testing.T) {
+	g, _ := newRiskyGame(t)
+	injectKeys(g, tcell.KeyRight, tcell.KeyRight, tcell.KeyEscape)
+	g.processAction(ActionExamine)
+	if g.runLog.TurnsPlayed != 0 {
+		t.Error("examining should not spend a turn")
+	}
+}
+
+func TestThreatTintBehindEnemies(t *testing.T) {
+	g, brute := newRiskyGame(t)
+	p := g.world.Get(brute, component.CPosition).(component.Position)
+	bgAt := func() tcell.Color {
+		g.drawPlay()
+		sx, sy, _ := g.renderer.WorldToScreen(p.X, p.Y)
+		_, _, style, _ := g.screen.GetContent(sx, sy)
+		_, bg, _ := style.Decompose()
+		return bg
+	}
+	if bg := bgAt(); bg == threatTints[system.ThreatDeadly] {
+		t.Fatal("threat tint should be off by default")
+	}
+	g.profile.ThreatTint = true
+	if bg := bgAt(); bg != threatTints[system.ThreatDeadly] {
+		t.Errorf("tinted brute background = %v; want %v", bg, threatTints[system.ThreatDeadly])
+	}
+}
+
+func TestAmbusherHiddenUntilTrueSight(t *testing.T) {
+	g, brute := newRiskyGame(t)
+	g.world.Add(brute, component.AI{Behavior: component.BehaviorStationary, SightRange: 8, Ambush: true})
+	p := g.world.Get(brute, component.CPosition).(component.Position)
+	drawn := func() bool {
+		g.drawPlay()
+		sx, sy, _ := g.renderer.WorldToScreen(p.X, p.Y)
+		mainc, _, _, _ := g.screen.GetContent(sx, sy)
+		return mainc == '🦀'
+	}
+	if drawn() {
+		t.Error("an ambusher two tiles away should not be drawn")
+	}
+	if desc, _ := g.describeAt(p.X, p.Y); strings.Contains(desc, "Threat") {
+		t.Errorf("describeAt(hidden ambusher) = %q; want it undescribed", desc)
+	}
+
+	system.ApplyEffect(g.world, g.playerID, component.ActiveEffect{
+		Kind: component.EffectTrueSight, Magnitude: 1, TurnsRemaining: 5,
+	})
+	if !drawn() {
+		t.Error("true sight should reveal the ambusher")
+	}
+	if desc, _ := g.describeAt(p.X, p.Y); !strings.Contains(desc, "Threat") {
+		t.Errorf("describeAt(revealed ambusher) = %q; want its stats", desc)
+	}
+}
+
+func TestExamineEnemyPreviewsLoot(t *testing.T) {
+	g, brute := newRiskyGame(t)
+	p := g.world.Get(brute, component.CPosition).(component.Position)
+	if got := g.lootAt(p.X, p.Y); got != "" {
+		t.Errorf("lootAt(brute without loot) = %q; want \"\"", got)
+	}
+	g.world.Add(brute, component.Loot{Drops: []component.LootEntry{
+		{Glyph: "💎", Guaranteed: true},
+		{Glyph: "🧪", Chance: 25},
+	}})
+	if got, want := g.lootAt(p.X, p.Y), "Drops: 💎 always, 🧪 25%"; got != want {
+		t.Errorf("lootAt(brute) = %q; want %q", got, want)
+	}
+	if got := describeLoot(component.Loot{}, 1); got != "Drops nothing." {
+		t.Errorf("describeLoot(empty) = %q; want \"Drops nothing.\"", got)
+	}
+}
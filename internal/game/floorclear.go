@@ -0,0 +1,191 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/factory"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Floor clear bonus: wiping out every enemy on a dungeon floor offers a heal
+// of ClearHealPct of max HP, ClearGoldPerFloor gold per floor of depth, or a
+// consumable from the common loot pool.
+const (
+	ClearHealPct      = 50
+	ClearGoldPerFloor = 10
+)
+
+// clearTitle heads the floor clear bonus modal.
+const clearTitle = "🏆 FLOOR CLEARED"
+
+// ClearBonusKind says what a ClearBonus grants.
+type ClearBonusKind uint8
+
+const (
+	ClearHeal ClearBonusKind = iota // restores Amount HP
+	ClearGold                       // grants Amount gold
+	ClearItem                       // stows Item in the backpack
+)
+
+// ClearBonus is one of the rewards offered for clearing a floor.
+type ClearBonus struct {
+	Kind   ClearBonusKind
+	Amount int
+	Item   component.Item
+}
+
+// Label describes the bonus in the clear bonus modal.
+func (b ClearBonus) Label() string {
+	switch b.Kind {
+	case ClearHeal:
+		return fmt.Sprintf("❤️ Catch your breath: restore %d HP", b.Amount)
+	case ClearGold:
+		return fmt.Sprintf("💰 Search the fallen: %d gold", b.Amount)
+	default:
+		return fmt.Sprintf("%s Scavenge a %s", b.Item.Glyph, b.Item.Name)
+	}
+}
+
+// ClearBonuses rolls the bonuses offered to a player with maxHP for clearing
+// floor: a heal, gold and a consumable, in that order.
+func ClearBonuses(floor, maxHP int, rng *rand.Rand) []ClearBonus {
+	df := assets.DungeonFloor(floor)
+	return []ClearBonus{
+		{Kind: ClearHeal, Amount: max(maxHP*ClearHealPct/100, 1)},
+		{Kind: ClearGold, Amount: df * ClearGoldPerFloor},
+		{Kind: ClearItem, Item: factory.CommonLootItem(df, rng)},
+	}
+}
+
+// FloorCleared reports whether w has no enemies left: every AI-driven entity
+// is a player ally.
+func FloorCleared(w *ecs.World) bool {
+	for _, id := range w.Query(component.CAI) {
+		if w.Get(id, component.CAI).(component.AI).Behavior != component.BehaviorAlly {
+			return false
+		}
+	}
+	return true
+}
+
+// RunClearBonusModal lets the player pick one of bonuses, blocking until they
+// choose (or nextEvent returns nil). A ClearItem pick is stowed in inv, and
+// a full backpack keeps the modal open. Returns the chosen index, or -1 if
+// the screen closed first.
+func RunClearBonusModal(screen tcell.Screen, nextEvent func() tcell.Event, bonuses []ClearBonus, inv *component.Inventory) int {
+	cursor := 0
+	statusMsg := ""
+	take := func(idx int) bool {
+		if bonuses[idx].Kind == ClearItem && !inv.Stow(bonuses[idx].Item) {
+			statusMsg = "Backpack full! Choose another reward."
+			return false
+		}
+		return true
+	}
+	for {
+		DrawClearBonusScreen(screen, bonuses, cursor, statusMsg)
+
+		ev := nextEvent()
+		if ev == nil {
+			return -1
+		}
+		statusMsg = ""
+		switch ev := ev.(type) {
+		case *tcell.EventResize:
+			screen.Sync()
+		case *tcell.EventKey:
+			switch ev.Key() {
+			case tcell.KeyUp:
+				cursor = max(cursor-1, 0)
+			case tcell.KeyDown:
+				cursor = min(cursor+1, len(bonuses)-1)
+			case tcell.KeyEnter:
+				if take(cursor) {
+					return cursor
+				}
+			default:
+				r := ev.Rune()
+				switch {
+				case r == 'k' || r == 'K':
+					cursor = max(cursor-1, 0)
+				case r == 'j' || r == 'J':
+					cursor = min(cursor+1, len(bonuses)-1)
+				case r >= 'a' && int(r-'a') < len(bonuses):
+					cursor = int(r - 'a')
+					if take(cursor) {
+						return cursor
+					}
+				}
+			}
+		}
+	}
+}
+
+// DrawClearBonusScreen renders the floor clear bonus modal.
+func DrawClearBonusScreen(screen tcell.Screen, bonuses []ClearBonus, cursor int, statusMsg string) {
+	screen.Clear()
+	sw, _ := screen.Size()
+
+	white := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	gray := tcell.StyleDefault.Foreground(tcell.ColorGray)
+	gold := tcell.StyleDefault.Foreground(tcell.ColorGold)
+	green := tcell.StyleDefault.Foreground(tcell.ColorGreen)
+	highlight := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorGold)
+
+	put := func(x, y int, s string, style tcell.Style) { drawScreenText(screen, x, y, s, style) }
+
+	put(0, 0, clearTitle+"  Choose a reward for your thoroughness", gold)
+	hints := "[j/k] Move  [a-c] Take  [Enter] Take selected"
+	if len([]rune(hints)) < sw {
+		put(sw-len([]rune(hints)), 0, hints, gray)
+	}
+	for x := range sw {
+		screen.SetContent(x, 1, '─', nil, gray)
+	}
+	for i, b := range bonuses {
+		style, pfx := white, "  "
+		if i == cursor {
+			style, pfx = highlight, "► "
+		}
+		put(0, 2+i, fmt.Sprintf("%s[%c] %s", pfx, 'a'+rune(i), b.Label()), style)
+	}
+	if statusMsg != "" {
+		put(0, 3+len(bonuses), statusMsg, green)
+	}
+	screen.Show()
+}
+
+// checkFloorCleared offers the clear bonus the first time the player wipes
+// out every enemy on a dungeon floor. The final floor ends the run instead,
+// and the tutorial has its own script.
+func (g *Game) checkFloorCleared() {
+	if g.floorCleared || g.state != StatePlaying || g.tutorial != nil || g.floor >= MaxFloors || !FloorCleared(g.world) {
+		return
+	}
+	g.floorCleared = true
+	g.runLog.FloorsCleared++
+	hpComp, invComp := g.world.Get(g.playerID, component.CHealth), g.world.Get(g.playerID, component.CInventory)
+	if hpComp == nil || invComp == nil {
+		return
+	}
+	inv := invComp.(component.Inventory)
+	bonuses := ClearBonuses(g.floor, hpComp.(component.Health).Max, g.combatRng)
+	idx := RunClearBonusModal(g.screen, g.screen.PollEvent, bonuses, &inv)
+	if idx < 0 {
+		return
+	}
+	switch b := bonuses[idx]; b.Kind {
+	case ClearHeal:
+		g.restorePlayerHP(b.Amount)
+	case ClearGold:
+		g.earnGold(b.Amount)
+	case ClearItem:
+		g.world.Add(g.playerID, inv)
+	}
+	g.addMessage(fmt.Sprintf("Floor cleared! You take your reward: %s.", bonuses[idx].Label()))
+}
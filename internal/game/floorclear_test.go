@@ -0,0 +1,82 @@
+package game
+
+import (
+	"testing"
+
+	"emoji-roguelike/internal/component"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// killAllEnemies removes every AI-driven entity from g's floor.
+func killAllEnemies(g *Game) {
+	for _, id := range g.world.Query(component.CAI) {
+		g.world.DestroyEntity(id)
+	}
+}
+
+func TestClearingFloorOffersBonusOnce(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	if g.floorCleared {
+		t.Fatal("a fresh floor full of enemies should not count as cleared")
+	}
+	gold := g.gold
+
+	g.checkFloorCleared()
+	if g.runLog.FloorsCleared != 0 {
+		t.Fatal("bonus offered while enemies remain")
+	}
+
+	killAllEnemies(g)
+	g.screen.(tcell.SimulationScreen).InjectKey(tcell.KeyRune, 'b', tcell.ModNone)
+	g.checkFloorCleared()
+	if g.runLog.FloorsCleared != 1 || g.gold != gold+ClearGoldPerFloor {
+		t.Fatalf("cleared %d floors with %d gold; want 1 clear and the %d gold bonus",
+			g.runLog.FloorsCleared, g.gold-gold, ClearGoldPerFloor)
+	}
+
+	g.checkFloorCleared()
+	if g.runLog.FloorsCleared != 1 {
+		t.Error("the bonus should be offered only once per floor")
+	}
+}
+
+func TestClearBonusHealsAndScavenges(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	hp := g.world.Get(g.playerID, component.CHealth).(component.Health)
+	hp.Current = 1
+	g.world.Add(g.playerID, hp)
+	killAllEnemies(g)
+
+	g.screen.(tcell.SimulationScreen).InjectKey(tcell.KeyRune, 'a', tcell.ModNone)
+	g.checkFloorCleared()
+	if got, want := g.playerHP(), 1+hp.Max*ClearHealPct/100; got != want {
+		t.Errorf("HP after heal bonus = %d; want %d", got, want)
+	}
+
+	g.floorCleared = false
+	before := len(g.world.Get(g.playerID, component.CInventory).(component.Inventory).Backpack)
+	g.screen.(tcell.SimulationScreen).InjectKey(tcell.KeyRune, 'c', tcell.ModNone)
+	g.checkFloorCleared()
+	inv := g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	if len(inv.Backpack) != before+1 || !inv.Backpack[before].IsConsumable {
+		t.Errorf("backpack %v; want a scavenged consumable added", inv.Backpack)
+	}
+}
+
+func TestClearBonusItemNeedsRoom(t *testing.T) {
+	bonuses := ClearBonuses(1, 20, newAbilityTestGame(t, "warden").combatRng)
+	inv := component.Inventory{Capacity: 0}
+	events := []tcell.Event{
+		tcell.NewEventKey(tcell.KeyRune, 'c', tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone),
+	}
+	next := func() tcell.Event {
+		ev := events[0]
+		events = events[1:]
+		return ev
+	}
+	if idx := RunClearBonusModal(newSimScreen(), next, bonuses, &inv); idx != 0 {
+		t.Errorf("RunClearBonusModal = %d; want the heal once the item did not fit", idx)
+	}
+}
@@ -30,32 +30,46 @@ const (
 
 // RunLog records statistics gathered during one run.
 type RunLog struct {
-	Timestamp        time.Time      `json:"timestamp"`
-	Victory          bool           `json:"victory"`
-	Class            string         `json:"class"`
-	FloorsReached    int            `json:"floors_reached"`
-	TurnsPlayed      int            `json:"turns_played"`
-	EnemiesKilled    map[string]int `json:"enemies_killed"` // glyph → kill count
-	ItemsUsed        map[string]int `json:"items_used"`     // glyph → use count
-	InscriptionsRead int            `json:"inscriptions_read"`
-	DamageDealt      int            `json:"damage_dealt"`
-	DamageTaken      int            `json:"damage_taken"`
-	CauseOfDeath     string         `json:"cause_of_death"` // last thing that hurt the player ("poison" or enemy glyph)
-	Level            int            `json:"level"`
-	SkillsLearned    []string       `json:"skills_learned,omitempty"`
+	Timestamp        time.Time       `json:"timestamp"`
+	Victory          bool            `json:"victory"`
+	Abandoned        bool            `json:"abandoned,omitempty"` // left via Save & Quit before the run ended
+	Class            string          `json:"class"`
+	FloorsReached    int             `json:"floors_reached"`
+	TurnsPlayed      int             `json:"turns_played"`
+	EnemiesKilled    map[string]int  `json:"enemies_killed"` // glyph → kill count
+	ItemsUsed        map[string]int  `json:"items_used"`     // glyph → use count
+	InscriptionsRead int             `json:"inscriptions_read"`
+	DamageDealt      int             `json:"damage_dealt"`
+	DamageTaken      int             `json:"damage_taken"`
+	CauseOfDeath     string          `json:"cause_of_death"` // last thing that hurt the player ("poison" or enemy glyph)
+	Level            int             `json:"level"`
+	SkillsLearned    []string        `json:"skills_learned,omitempty"`
+	Allocation       *StatAllocation `json:"allocation,omitempty"` // point-buy spent at class select, if any
+	Subclass         string          `json:"subclass,omitempty"`   // class whose perk was taken at subclassFloor
+	GoldEarned       int             `json:"gold_earned"`
+	FloorsCleared    int             `json:"floors_cleared,omitempty"` // floors wiped of enemies; see checkFloorCleared
+	RecentDamage     []DamageEvent   `json:"recent_damage,omitempty"` // last few hits, for the death recap
+	FloorTimes       []int           `json:"floor_times,omitempty"`   // seconds of play per floor (index floor-1), menus excluded
+	FloorTurns       []int           `json:"floor_turns,omitempty"`   // turns taken per floor (index floor-1)
+	Score            int             `json:"score"`                   // see RunLog.score
+	MessageLogPath   string          `json:"message_log,omitempty"`   // full message log on disk, with -log
+	NGPlus           int             `json:"ng_plus,omitempty"`       // New Game+ level: victories in a row before this run
 }
 
 // Game is the top-level orchestrator.
 type Game struct {
 	screen            tcell.Screen
-	renderer          *render.Renderer
+	renderer          render.Renderer
+	headless          bool // draw no map or HUD: every floor gets a render.NopRenderer
 	world             *ecs.World
 	gmap              *gamemap.GameMap
 	playerID          ecs.EntityID
-	rng               *rand.Rand
+	genRng            *rand.Rand // builds floors; see SplitSeed
+	combatRng         *rand.Rand // rolls everything that happens on them
 	floor             int
 	state             GameState
 	messages          []string
+	narrative         []string // every message this run, kept when keepMessageLog is set
 	selectedClass     assets.ClassDef
 	fovRadius         int
 	baseMaxHP         int // base MaxHP from class, used by recalcPlayerMaxHP
@@ -67,7 +81,23 @@ type Game struct {
 	furnitureThorns      int  // damage reflected per hit taken
 	furnitureKillRestore bool // restore 1 HP on each kill
 	// Active ability state.
-	specialCooldown int // turns until z-ability can be used again
+	specialCooldown int // turns until the next z-ability charge is restored
+	specialSpent    int // z-ability charges used and not yet restored
+	recentKills     int // morale counter; see system.RecordKill
+	gold            int // purse spent at the between-floor merchant
+	hotbar          Hotbar // consumables on the quick-use keys; see useHotbar
+	hitFlashOff     bool // player disabled the heavy-hit screen flash
+	enemyDensity    float64 // scales each new floor's enemies; 0 means normal
+	sandbox         bool    // new floors spawn no enemies; see SetSandbox
+	ngPlus          int     // New Game+ level of this run; see ascendConfig
+	freeLook        bool    // camera detached from the player; see runFreeLook
+	sheltered       bool    // player stood in a sanctuary last turn; see tickSanctuary
+	asciiFlag       bool    // -ascii given: ASCII map whatever the profile says
+	messageLimit    int     // messages kept for the HUD, 0 for defaultMessageLimit
+	keepMessageLog  bool    // save the run's narrative to disk; see SetMessageLog
+	weaponHits      map[string]int // hits landed per weapon name; see system.ProficiencyLevel
+	floorCleared    bool           // clear bonus settled for this floor; see checkFloorCleared
+	bell            Bell           // terminal bell cues; see soundCues
 	// Leveling state.
 	playerLevel   int
 	playerXP      int
@@ -80,6 +110,12 @@ type Game struct {
 	skillBonusDEF   int
 	skillBonusMaxHP int
 	skillBonusFOV   int
+	// Tutorial and persistent profile state.
+	tutorial     *tutorial // non-nil while on the tutorial floor
+	wantTutorial bool      // play the tutorial before floor 1
+	profile      Profile
+	// Speed-run clock: map-screen time per floor (index floor-1).
+	floorPlay []time.Duration
 }
 
 // New creates and returns a Game with screen initialized.
@@ -96,17 +132,35 @@ func New() (*Game, error) {
 
 	g := &Game{
 		screen: screen,
-		rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
+	g.genRng, g.combatRng = SplitSeed(time.Now().UnixNano())
 	g.resetForRun()
 	return g, nil
 }
 
+// SetEnemyDensity scales how many enemies each new floor spawns. 1 is
+// normal, and so is 0 or less; use SetSandbox for a floor with no enemies.
+func (g *Game) SetEnemyDensity(d float64) {
+	g.enemyDensity = max(d, 0)
+}
+
+// SetSandbox turns the enemy-free sandbox on or off for new floors.
+func (g *Game) SetSandbox(on bool) {
+	g.sandbox = on
+}
+
+// SetASCII forces the ASCII map for this session, overriding the saved
+// setting. Call before Run.
+func (g *Game) SetASCII(on bool) {
+	g.asciiFlag = on
+}
+
 // resetForRun clears all per-run state in preparation for a fresh start.
 func (g *Game) resetForRun() {
 	g.floor = 1
 	g.state = StatePlaying
 	g.messages = nil
+	g.narrative = nil
 	g.world = nil
 	g.gmap = nil
 	g.baseMaxHP = 0
@@ -114,22 +168,33 @@ func (g *Game) resetForRun() {
 	g.runLog = RunLog{
 		EnemiesKilled: make(map[string]int),
 		ItemsUsed:     make(map[string]int),
+		NGPlus:        g.ngPlus,
+	}
+	// An ascending NG+ run keeps the permanent furniture upgrades it won.
+	if g.ngPlus == 0 {
+		g.furnitureATK = 0
+		g.furnitureDEF = 0
+		g.furnitureThorns = 0
+		g.furnitureKillRestore = false
 	}
-	g.furnitureATK = 0
-	g.furnitureDEF = 0
-	g.furnitureThorns = 0
-	g.furnitureKillRestore = false
 	g.specialCooldown = 0
+	g.specialSpent = 0
+	g.gold = 0
+	g.hotbar = Hotbar{}
 	g.playerLevel = 1
 	g.playerXP = 0
 	g.pendingLevels = 0
 	g.learnedSkills = nil
 	g.branch = ""
 	g.floorsVisited = make(map[int]bool)
+	g.weaponHits = make(map[string]int)
 	g.skillBonusATK = 0
 	g.skillBonusDEF = 0
 	g.skillBonusMaxHP = 0
 	g.skillBonusFOV = 0
+	g.tutorial = nil
+	g.floorPlay = nil
+	g.bell = Bell{}
 }
 
 // loadFloor generates and populates the given floor.
@@ -158,28 +223,51 @@ func (g *Game) loadFloor(floor int) {
 		g.runLog.FloorsReached = floor
 	}
 	g.world = ecs.NewWorld()
+	g.recentKills = 0
 
-	cfg := levelConfig(floor, g.rng)
+	cfg := levelConfig(floor, g.genRng)
+	cfg.EnemyDensity = g.enemyDensity
+	cfg.NoEnemies = g.sandbox
+	ascendConfig(cfg, g.ngPlus)
 	gmap, px, py := generate.Generate(cfg)
 	g.gmap = gmap
 
 	// Populate enemies, items, inscriptions, and equipment.
 	pop := generate.Populate(gmap, cfg)
 	for _, es := range pop.Enemies {
-		factory.NewEnemy(g.world, es.Entry, es.X, es.Y)
+		factory.NewEnemy(g.world, es.Entry, es.X, es.Y, g.genRng)
 	}
 	for _, is := range pop.Items {
 		factory.NewItem(g.world, is.Entry, is.X, is.Y)
 	}
 	for _, eq := range pop.Equipment {
-		factory.NewEquipItem(g.world, eq.Entry, floor, g.rng, eq.X, eq.Y)
+		factory.NewEquipItem(g.world, eq.Entry, floor, g.genRng, eq.X, eq.Y)
 	}
 	for _, ins := range pop.Inscriptions {
 		factory.NewInscription(g.world, ins.Text, ins.X, ins.Y)
 	}
+	for _, r := range pop.Runes {
+		factory.NewRune(g.world, g.genRng, r.X, r.Y)
+	}
+	for _, pg := range pop.PuzzleGlyphs {
+		factory.NewPuzzleGlyph(g.world, pg.Glyph, pg.X, pg.Y)
+	}
+	system.AttuneEchoes(g.world, []string{g.selectedClass.ID})
 	for _, fs := range pop.Furniture {
 		factory.NewFurniture(g.world, fs.Entry, fs.X, fs.Y)
 	}
+	for _, gp := range pop.GoldPiles {
+		factory.NewGoldPile(g.world, gp.Amount, gp.X, gp.Y)
+	}
+	for _, vm := range pop.Vending {
+		factory.NewVendingMachine(g.world, g.genRng, vm.X, vm.Y)
+	}
+	for _, a := range pop.Altars {
+		factory.NewAltar(g.world, a.X, a.Y)
+	}
+	for _, o := range pop.Offerings {
+		factory.NewItemByGlyph(g.world, assets.AltarOffering, o.X, o.Y)
+	}
 
 	// Create player using the selected class definition.
 	g.playerID = factory.NewPlayer(g.world, px, py, g.selectedClass)
@@ -204,7 +292,9 @@ func (g *Game) loadFloor(floor int) {
 	}
 
 	// Restore inventory from previous floor.
+	recharged := false
 	if savedInv != nil && floor > 1 {
+		recharged = rechargeWands(savedInv)
 		g.world.Add(g.playerID, *savedInv)
 		g.recalcPlayerMaxHP()
 	}
@@ -223,13 +313,15 @@ func (g *Game) loadFloor(floor int) {
 		}
 	}
 
-	// Reapply skill bonuses to the new player entity.
+	// Reapply skill bonuses and weapon proficiency to the new player entity.
 	g.applySkillBonuses()
 	g.recalcPlayerMaxHP()
+	g.world.Add(g.playerID, component.Proficiency{Hits: g.weaponHits})
 
 	// Reset ability cooldown on each floor entry for classes with AbilityFreeOnFloor.
 	if g.selectedClass.AbilityFreeOnFloor {
 		g.specialCooldown = 0
+		g.specialSpent = 0
 	}
 
 	// Grant XP for first-time floor entry.
@@ -238,8 +330,11 @@ func (g *Game) loadFloor(floor int) {
 		g.grantXP(assets.XPForFloorEntry(floor))
 	}
 
-	system.UpdateFOV(g.world, g.gmap, g.playerID, g.effectiveFOVRadius())
-	g.renderer = render.NewRenderer(g.screen, floor)
+	// A floor with no enemies to begin with, as in the sandbox, earns no
+	// clear bonus.
+	g.floorCleared = FloorCleared(g.world)
+	g.updateFOV()
+	g.renderer = g.newRenderer(floor)
 	g.renderer.CenterOn(px, py)
 
 	if floor == 1 {
@@ -247,37 +342,57 @@ func (g *Game) loadFloor(floor int) {
 	} else {
 		g.addMessage(fmt.Sprintf("You descend into %s (Floor %d).", assets.FloorName(floor), floor))
 	}
+	if gmap.Affix != gamemap.AffixNone {
+		g.addMessage(fmt.Sprintf("This floor is cursed with %s. %s", gmap.Affix.Name(), gmap.Affix.Desc()))
+	}
 	if lore := assets.FloorLoreSnippets(floor); len(lore) > 0 {
-		g.addMessage(lore[g.rng.Intn(len(lore))])
+		g.addMessage(lore[g.genRng.Intn(len(lore))])
+	}
+	if recharged {
+		g.addMessage("Your wands hum with a fresh charge.")
 	}
 }
 
-// Run is the main game loop. Supports multiple consecutive runs via Try Again.
+// startRun loads floor 1 and greets the player.
+func (g *Game) startRun() {
+	g.loadFloor(1)
+	g.addMessage("Use hjklyubn or arrow keys to move. > to descend.")
+	if g.ngPlus > 0 {
+		g.addMessage(fmt.Sprintf("NG+%d: the Spire remembers you, and its guardians have grown stronger.", g.ngPlus))
+	}
+}
+
+// Run is the main game loop. Supports multiple consecutive runs via Try Again;
+// after a victory the next run ascends to the following NG+ level.
 func (g *Game) Run() {
 	defer g.screen.Fini()
 
+	g.profile = loadProfile()
+	if !g.showTitle() {
+		return
+	}
 	for {
 		g.resetForRun()
+		g.wantTutorial = !g.profile.TutorialDone
 
 		if !g.runClassSelect() {
 			return
 		}
+		g.runStatAllocation()
 		g.runLog.Class = g.selectedClass.Name
 
-		g.loadFloor(1)
-		g.addMessage("Use hjklyubn or arrow keys to move. > to descend.")
+		if g.wantTutorial {
+			g.loadTutorialFloor()
+		} else {
+			g.startRun()
+		}
 
 		for g.state != StateDead && g.state != StateVictory {
-			playerPos := g.playerPosition()
-			g.renderer.CenterOn(playerPos.X, playerPos.Y)
-			g.renderer.DrawFrame(g.world, g.gmap, g.playerID)
-			// Compute equipment + effect bonuses for HUD display.
-			equipATK, equipDEF := g.equipBonuses()
-			bonusATK := system.GetAttackBonus(g.world, g.playerID) + equipATK
-			bonusDEF := system.GetDefenseBonus(g.world, g.playerID) + equipDEF
-			g.renderer.DrawHUD(g.world, g.playerID, g.floor, g.selectedClass.Name, g.messages, bonusATK, bonusDEF, g.selectedClass.AbilityName, g.specialCooldown, g.playerLevel, g.pendingLevels)
+			g.drawPlay()
 
+			waitStart := time.Now()
 			ev := g.screen.PollEvent()
+			g.addPlayTime(time.Since(waitStart))
 			switch ev := ev.(type) {
 			case *tcell.EventResize:
 				g.screen.Sync()
@@ -285,27 +400,23 @@ func (g *Game) Run() {
 			case *tcell.EventKey:
 				action := keyToAction(ev)
 				if action == ActionQuit {
-					if g.confirmQuit(func() {
-						playerPos := g.playerPosition()
-						g.renderer.CenterOn(playerPos.X, playerPos.Y)
-						g.renderer.DrawFrame(g.world, g.gmap, g.playerID)
-						equipATK, equipDEF := g.equipBonuses()
-						bonusATK := system.GetAttackBonus(g.world, g.playerID) + equipATK
-						bonusDEF := system.GetDefenseBonus(g.world, g.playerID) + equipDEF
-						g.renderer.DrawHUD(g.world, g.playerID, g.floor, g.selectedClass.Name, g.messages, bonusATK, bonusDEF, g.selectedClass.AbilityName, g.specialCooldown, g.playerLevel, g.pendingLevels)
-					}) {
+					if g.confirmQuit(g.drawPlay) {
 						return
 					}
 					continue
 				}
-				g.processAction(action)
+				if action == ActionMenu {
+					if g.runPauseMenu() {
+						return
+					}
+					continue
+				}
+				g.playAction(action)
 			}
 		}
 
 		g.runLog.Victory = g.state == StateVictory
-		g.runLog.Timestamp = time.Now()
-		g.runLog.Level = g.playerLevel
-		g.runLog.SkillsLearned = g.learnedSkills
+		g.finalizeRunLog()
 		if g.runLog.Victory {
 			g.runLog.CauseOfDeath = ""
 		}
@@ -314,6 +425,11 @@ func (g *Game) Run() {
 		if !g.showEndScreen() {
 			return
 		}
+		if g.runLog.Victory {
+			g.ngPlus++
+		} else {
+			g.ngPlus = 0
+		}
 	}
 }
 
@@ -324,18 +440,28 @@ func (g *Game) processAction(action Action) {
 		g.addMessage("You are stunned and cannot act!")
 		g.runLog.TurnsPlayed++
 		g.applyPoisonDamage()
+		g.applyHazards()
 		system.TickEffects(g.world)
-		if g.specialCooldown > 0 {
-			g.specialCooldown--
-		}
+		g.specialSpent, g.specialCooldown = system.TickCharges(g.specialSpent, g.specialCooldown,
+			system.CooldownTick(g.world, g.playerID), g.effectiveCooldown())
+		g.recentKills = system.DecayMorale(g.recentKills)
 		if ri := g.effectiveRegenInterval(); ri > 0 && g.runLog.TurnsPlayed%ri == 0 {
 			g.restorePlayerHP(1)
 		}
-		hits := system.ProcessAI(g.world, g.gmap, []ecs.EntityID{g.playerID}, g.rng)
+		g.tickSanctuary()
+		g.resolveTurretShots(system.ProcessTurrets(g.world, g.gmap, g.combatRng))
+		hits := system.ProcessAI(g.world, g.gmap, []ecs.EntityID{g.playerID}, g.combatRng)
+		g.resolveTrapTriggers(system.CollectSprungTraps(g.world))
+		g.resolveSummons(system.CollectSummons(g.world))
+		g.resolveEchoes(system.CollectEchoes(g.world))
+		g.resolveSplits(system.CollectSplits(g.world, g.gmap))
 		for _, h := range hits {
+			g.noteStrike(h.AttackerID)
 			if h.Damage > 0 {
+				g.flashOnHeavyHit(h.Damage)
 				g.runLog.DamageTaken += h.Damage
 				g.runLog.CauseOfDeath = h.EnemyGlyph
+				g.runLog.noteDamage(g.runLog.TurnsPlayed, h.EnemyGlyph, h.Damage, g.playerHP())
 				totalThorns := g.furnitureThorns + g.computeSkillBonuses().ThornsDamage
 				if totalThorns > 0 && h.AttackerID != ecs.NilEntity && g.world.Alive(h.AttackerID) {
 					if hp := g.world.Get(h.AttackerID, component.CHealth); hp != nil {
@@ -345,6 +471,7 @@ func (g *Game) processAction(action Action) {
 					}
 				}
 			}
+			g.noteWornOut(h.WornOut)
 			g.handleSpecialHitMessage(h)
 		}
 		g.checkPlayerDead()
@@ -357,6 +484,14 @@ func (g *Game) processAction(action Action) {
 	case ActionWait:
 		turnUsed = true
 		g.addMessage("You wait.")
+		g.answerRiddle()
+
+	case ActionBlock:
+		turnUsed = true
+		g.block()
+
+	case ActionHotbar1, ActionHotbar2, ActionHotbar3, ActionHotbar4:
+		turnUsed = g.useHotbar(int(action-ActionHotbar1))
 
 	case ActionDescend:
 		pos := g.playerPosition()
@@ -365,7 +500,7 @@ func (g *Game) processAction(action Action) {
 			if g.floor >= MaxFloors {
 				g.addMessage("There is nowhere further to descend.")
 			} else {
-				g.loadFloor(g.floor + 1)
+				g.descend()
 				return
 			}
 		} else {
@@ -390,7 +525,7 @@ func (g *Game) processAction(action Action) {
 			if g.floor >= MaxFloors {
 				g.addMessage("There is nowhere further to descend.")
 			} else {
-				g.loadFloor(g.floor + 1)
+				g.descend()
 				return
 			}
 		case gamemap.TileStairsUp:
@@ -406,6 +541,21 @@ func (g *Game) processAction(action Action) {
 	case ActionHelp:
 		g.runHelpScreen()
 
+	case ActionToggleFlash:
+		g.hitFlashOff = !g.hitFlashOff
+		g.addMessage(hitFlashMessage(g.hitFlashOff))
+
+	case ActionExamine:
+		g.runExamine()
+
+	case ActionTravel:
+		g.runTravel()
+		return
+
+	case ActionFreeLook:
+		g.runFreeLook()
+		return
+
 	case ActionPickup:
 		g.tryPickup()
 		turnUsed = true
@@ -423,45 +573,60 @@ func (g *Game) processAction(action Action) {
 	case ActionSpecialAbility:
 		if g.selectedClass.AbilityCooldown == 0 {
 			g.addMessage("No special ability.")
-		} else if g.specialCooldown > 0 {
+		} else if g.specialSpent >= g.selectedClass.MaxCharges() {
 			g.addMessage(fmt.Sprintf("%s recharging (%d turns).", g.selectedClass.AbilityName, g.specialCooldown))
-		} else {
-			g.useSpecialAbility()
-			g.specialCooldown = g.effectiveCooldown()
+		} else if g.useSpecialAbility() {
+			g.specialSpent, g.specialCooldown = system.SpendCharge(g.specialSpent, g.specialCooldown, g.effectiveCooldown())
 			turnUsed = true
 		}
 
 	default:
 		dx, dy := actionToDelta(action)
 		if dx != 0 || dy != 0 {
+			if !g.confirmRiskyMove(dx, dy) {
+				break
+			}
 			result, target := system.TryMove(g.world, g.gmap, g.playerID, dx, dy)
 			switch result {
 			case system.MoveOK:
+				if onChute(g.gmap, g.playerPosition()) {
+					g.fallDownChute()
+					return
+				}
 				turnUsed = true
-				system.UpdateFOV(g.world, g.gmap, g.playerID, g.effectiveFOVRadius())
+				g.updateFOV()
 				g.checkInscription()
+				g.stepPuzzle()
+				g.autoPickup()
 			case system.MoveInteract:
 				g.interactFurniture(target)
 				turnUsed = true
 			case system.MoveAttack:
 				// Capture name/glyph/position/loot BEFORE Attack() which may destroy the entity.
-				name := g.entityName(target)
-				glyph := name
+				glyph := g.entityName(target)
+				name := ChampionName(g.world, target, glyph)
 				enemyPos := g.world.Get(target, component.CPosition).(component.Position)
-				var lootDrops []component.LootEntry
+				var loot component.Loot
 				if lc := g.world.Get(target, component.CLoot); lc != nil {
-					lootDrops = lc.(component.Loot).Drops
+					loot = lc.(component.Loot)
 				}
-				res := system.Attack(g.world, g.rng, g.playerID, target)
+				res := system.Attack(g.world, g.combatRng, g.playerID, target)
 				if res.Dodged {
 					g.addMessage(fmt.Sprintf("The %s dodges your attack!", name))
 					turnUsed = true
 					break
 				}
 				g.runLog.DamageDealt += res.Damage
+				system.RecordWeaponHit(g.world, g.playerID)
+				g.noteWornOut(res.WornOut)
 				if res.Killed {
 					g.runLog.EnemiesKilled[glyph]++
-					g.addMessage(fmt.Sprintf("You kill the %s!", name))
+					gold := g.combatRng.Intn(4) + 1
+					g.earnGold(gold)
+					g.addMessage(fmt.Sprintf("You kill the %s! (+%d💰)", name, gold))
+					g.noteKill(enemyPos)
+					factory.DropGold(g.world, g.combatRng, assets.ThreatForGlyph(glyph), enemyPos.X, enemyPos.Y)
+					factory.LeaveHazard(g.world, g.floor, glyph, enemyPos.X, enemyPos.Y)
 					// Grant XP for kill.
 					if assets.IsEliteGlyph(glyph) {
 						g.grantXP(assets.XPForEliteKill(g.floor))
@@ -474,19 +639,18 @@ func (g *Game) processAction(action Action) {
 							g.addMessage(lore)
 						}
 					}
-					for _, d := range lootDrops {
-						if g.rng.Intn(100) < d.Chance {
-							factory.NewItemByGlyph(g.world, d.Glyph, enemyPos.X, enemyPos.Y)
-							g.addMessage(fmt.Sprintf("The %s drops something!", name))
-						}
+					for _, it := range factory.RollLoot(loot, g.floor, g.combatRng) {
+						factory.NewItemByGlyph(g.world, it.Glyph, enemyPos.X, enemyPos.Y)
+						g.addMessage(fmt.Sprintf("The %s drops something!", name))
 					}
+					g.dropChest(glyph, enemyPos)
 					if g.selectedClass.KillRestoreHP > 0 {
 						g.restorePlayerHP(g.selectedClass.KillRestoreHP)
 						g.addMessage(fmt.Sprintf("The kill feeds you. (+%d HP)", g.selectedClass.KillRestoreHP))
 					}
 					sb := g.computeSkillBonuses()
 					healChance := g.selectedClass.KillHealChance + sb.KillHealAdd
-					if healChance > 0 && g.rng.Intn(100) < healChance {
+					if healChance > 0 && g.combatRng.Intn(100) < healChance {
 						g.restorePlayerHP(2)
 						g.addMessage("Wild magic sparks! (+2 HP)")
 					}
@@ -504,9 +668,11 @@ func (g *Game) processAction(action Action) {
 			case system.MoveBlocked:
 				pos := g.playerPosition()
 				tx, ty := pos.X+dx, pos.Y+dy
-				if g.gmap.InBounds(tx, ty) && g.gmap.At(tx, ty).Kind == gamemap.TileDoor {
+				if g.gmap.InBounds(tx, ty) && g.gmap.At(tx, ty).Locked {
+					g.addMessage(SealedDoorMessage)
+				} else if g.gmap.InBounds(tx, ty) && g.gmap.At(tx, ty).Kind == gamemap.TileDoor {
 					g.gmap.Set(tx, ty, gamemap.MakeFloor())
-					system.UpdateFOV(g.world, g.gmap, g.playerID, g.effectiveFOVRadius())
+					g.updateFOV()
 					g.addMessage("You open the door.")
 					turnUsed = true
 				}
@@ -517,18 +683,28 @@ func (g *Game) processAction(action Action) {
 	if turnUsed {
 		g.runLog.TurnsPlayed++
 		g.applyPoisonDamage()
+		g.applyHazards()
 		system.TickEffects(g.world)
-		if g.specialCooldown > 0 {
-			g.specialCooldown--
-		}
+		g.specialSpent, g.specialCooldown = system.TickCharges(g.specialSpent, g.specialCooldown,
+			system.CooldownTick(g.world, g.playerID), g.effectiveCooldown())
+		g.recentKills = system.DecayMorale(g.recentKills)
 		if ri := g.effectiveRegenInterval(); ri > 0 && g.runLog.TurnsPlayed%ri == 0 {
 			g.restorePlayerHP(1)
 		}
-		hits := system.ProcessAI(g.world, g.gmap, []ecs.EntityID{g.playerID}, g.rng)
+		g.tickSanctuary()
+		g.resolveTurretShots(system.ProcessTurrets(g.world, g.gmap, g.combatRng))
+		hits := system.ProcessAI(g.world, g.gmap, []ecs.EntityID{g.playerID}, g.combatRng)
+		g.resolveTrapTriggers(system.CollectSprungTraps(g.world))
+		g.resolveSummons(system.CollectSummons(g.world))
+		g.resolveEchoes(system.CollectEchoes(g.world))
+		g.resolveSplits(system.CollectSplits(g.world, g.gmap))
 		for _, h := range hits {
+			g.noteStrike(h.AttackerID)
 			if h.Damage > 0 {
+				g.flashOnHeavyHit(h.Damage)
 				g.runLog.DamageTaken += h.Damage
 				g.runLog.CauseOfDeath = h.EnemyGlyph
+				g.runLog.noteDamage(g.runLog.TurnsPlayed, h.EnemyGlyph, h.Damage, g.playerHP())
 				// Thorns: reflect damage back to the attacker.
 				totalThorns := g.furnitureThorns + g.computeSkillBonuses().ThornsDamage
 				if totalThorns > 0 && h.AttackerID != ecs.NilEntity && g.world.Alive(h.AttackerID) {
@@ -539,13 +715,126 @@ func (g *Game) processAction(action Action) {
 					}
 				}
 			}
+			g.noteWornOut(h.WornOut)
 			g.handleSpecialHitMessage(h)
 		}
 		g.checkPlayerDead()
 	}
 }
 
+// resolveTrapTriggers reports sprung traps and credits trap kills to the
+// player like turret kills.
+func (g *Game) resolveTrapTriggers(triggers []system.TrapTrigger) {
+	for _, tr := range triggers {
+		g.runLog.DamageDealt += tr.Damage
+		if tr.Kind == component.TrapSnare {
+			g.addMessage(fmt.Sprintf("The %s is caught in your snare!", tr.VictimGlyph))
+		} else {
+			g.addMessage(fmt.Sprintf("The %s steps on your spikes! (%d damage)", tr.VictimGlyph, tr.Damage))
+		}
+		if tr.Killed {
+			g.creditKill("Your trap kills", tr.VictimGlyph, tr.Pos, tr.Loot)
+		}
+	}
+}
+
+// creditKill gives the player the spoils of an enemy with glyph slain at pos
+// without their blow, by a trap, turret or hazard: kill count, gold, XP, loot,
+// chest and the victory check. by opens the kill message, e.g. "Your trap
+// kills".
+func (g *Game) creditKill(by, glyph string, pos component.Position, loot component.Loot) {
+	g.runLog.EnemiesKilled[glyph]++
+	gold := g.combatRng.Intn(4) + 1
+	g.earnGold(gold)
+	g.addMessage(fmt.Sprintf("%s the %s! (+%d💰)", by, glyph, gold))
+	g.noteKill(pos)
+	factory.DropGold(g.world, g.combatRng, assets.ThreatForGlyph(glyph), pos.X, pos.Y)
+	factory.LeaveHazard(g.world, g.floor, glyph, pos.X, pos.Y)
+	if assets.IsEliteGlyph(glyph) {
+		g.grantXP(assets.XPForEliteKill(g.floor))
+	} else {
+		g.grantXP(assets.XPForKill(assets.ThreatForGlyph(glyph), g.floor))
+	}
+	for _, it := range factory.RollLoot(loot, g.floor, g.combatRng) {
+		factory.NewItemByGlyph(g.world, it.Glyph, pos.X, pos.Y)
+		g.addMessage(fmt.Sprintf("The %s drops something!", glyph))
+	}
+	g.dropChest(glyph, pos)
+	g.checkVictory()
+}
+
+// flashOnHeavyHit arms the renderer's heavy-hit border flash unless the
+// player has turned it off.
+func (g *Game) flashOnHeavyHit(damage int) {
+	if g.hitFlashOff {
+		return
+	}
+	if hp := g.world.Get(g.playerID, component.CHealth); hp != nil {
+		g.renderer.NoteDamage(damage, hp.(component.Health).Max)
+	}
+}
+
+// noteStrike telegraphs an enemy's attack by highlighting it on the next
+// frame. It shares the heavy-hit flash toggle.
+func (g *Game) noteStrike(attacker ecs.EntityID) {
+	if !g.hitFlashOff {
+		g.renderer.NoteStrike(attacker)
+	}
+}
+
+// hitFlashMessage reports the new state of the heavy-hit flash toggle.
+func hitFlashMessage(off bool) string {
+	if off {
+		return "Heavy-hit flash off."
+	}
+	return "Heavy-hit flash on."
+}
+
+// resolveSummons creates the minions called by summoners this turn.
+func (g *Game) resolveSummons(summons []system.Summon) {
+	for _, sm := range summons {
+		if factory.NewMinion(g.world, sm.Summoner, sm.Minion, sm.Pos.X, sm.Pos.Y) != ecs.NilEntity {
+			g.addMessage(fmt.Sprintf("The %s calls forth a %s!", sm.SummonerGlyph, sm.Minion))
+		}
+	}
+}
+
+// resolveSplits creates the copies shed by splitting enemies this turn.
+func (g *Game) resolveSplits(splits []system.Split) {
+	for _, sp := range splits {
+		if factory.NewSplitCopy(g.world, sp.Parent, sp.Pos.X, sp.Pos.Y, sp.HP, sp.MaxHP, sp.Gen) != ecs.NilEntity {
+			g.addMessage(fmt.Sprintf("The %s splits in two!", sp.Glyph))
+		}
+	}
+}
+
+// noteKill feeds a kill at pos into the morale counter and routs the nearby
+// survivors if their morale breaks.
+func (g *Game) noteKill(pos component.Position) {
+	g.recentKills = system.RecordKill(g.recentKills)
+	if system.BreakMorale(g.world, pos, g.recentKills) > 0 {
+		g.addMessage("The survivors panic and flee!")
+	}
+}
+
+// resolveTurretShots reports turret fire and credits turret kills to the
+// player: kill count, XP and loot drops, as if the player landed the blow.
+func (g *Game) resolveTurretShots(shots []system.TurretShot) {
+	for _, sh := range shots {
+		g.runLog.DamageDealt += sh.Damage
+		if !sh.Killed {
+			g.addMessage(fmt.Sprintf("Your turret hits the %s for %d damage.", sh.TargetGlyph, sh.Damage))
+			continue
+		}
+		g.creditKill("Your turret destroys", sh.TargetGlyph, sh.TargetPos, sh.Loot)
+	}
+}
+
 func (g *Game) handleSpecialHitMessage(h system.EnemyHitResult) {
+	if h.Parried {
+		g.addMessage(fmt.Sprintf("You parry the %s's blow!", h.EnemyGlyph))
+		return
+	}
 	switch h.SpecialApplied {
 	case 1:
 		g.addMessage(fmt.Sprintf("The %s poisons you!", h.EnemyGlyph))
@@ -557,6 +846,8 @@ func (g *Game) handleSpecialHitMessage(h system.EnemyHitResult) {
 		g.addMessage(fmt.Sprintf("The %s stuns you! (skip next turn)", h.EnemyGlyph))
 	case 5:
 		g.addMessage(fmt.Sprintf("The %s shatters your defenses!", h.EnemyGlyph))
+	case 6:
+		g.addMessage(fmt.Sprintf("The %s knocks your %s loose! It's useless for a while.", h.EnemyGlyph, h.DisarmedItem))
 	}
 }
 
@@ -575,7 +866,11 @@ func (g *Game) applyPoisonDamage() {
 	h.Current -= totalDmg
 	g.world.Add(g.playerID, h)
 	g.runLog.DamageTaken += totalDmg
+	if burnDmg > 0 {
+		g.runLog.noteDamage(g.runLog.TurnsPlayed, "self-burn", burnDmg, h.Current+poisonDmg)
+	}
 	if poisonDmg > 0 {
+		g.runLog.noteDamage(g.runLog.TurnsPlayed, "poison", poisonDmg, h.Current)
 		g.runLog.CauseOfDeath = "poison"
 		g.addMessage(fmt.Sprintf("Poison burns through you! (%d damage)", poisonDmg))
 	}
@@ -593,15 +888,21 @@ func (g *Game) restorePlayerHP(n int) {
 		return
 	}
 	hp := hpComp.(component.Health)
+	before := hp.Current
 	hp.Current += n
 	if hp.Current > hp.Max {
 		hp.Current = hp.Max
 	}
 	g.world.Add(g.playerID, hp)
+	system.HealThreat(g.world, g.playerID, hp.Current-before)
 }
 
 func (g *Game) tryPickup() {
 	pos := g.playerPosition()
+	gotGold := g.collectGold()
+	if g.openChest() {
+		return
+	}
 	items := g.world.Query(component.CTagItem, component.CPosition)
 	for _, itemID := range items {
 		ipos := g.world.Get(itemID, component.CPosition).(component.Position)
@@ -620,13 +921,12 @@ func (g *Game) tryPickup() {
 				return
 			}
 			inv := invComp.(component.Inventory)
-			if len(inv.Backpack) >= inv.Capacity {
+			if !inv.Stow(item) {
 				g.addMessage("Backpack full! Drop something first.")
 				return
 			}
 
-			// Add to backpack and destroy floor entity.
-			inv.Backpack = append(inv.Backpack, item)
+			// Save the backpack and destroy floor entity.
 			g.world.Add(g.playerID, inv)
 			g.world.DestroyEntity(itemID)
 			g.grantXP(assets.XPForPickup)
@@ -634,7 +934,65 @@ func (g *Game) tryPickup() {
 			return
 		}
 	}
-	g.addMessage("Nothing to pick up here.")
+	if !gotGold {
+		g.addMessage("Nothing to pick up here.")
+	}
+}
+
+// confirmRiskyMove asks before a move or attack that the enemies around the
+// player's resulting position could answer with a killing blow. Returns false
+// if the player backs off. Skipped when the safety prompt is turned off.
+func (g *Game) confirmRiskyMove(dx, dy int) bool {
+	if g.profile.RiskConfirmOff {
+		return true
+	}
+	pos := g.playerPosition()
+	end := component.Position{X: pos.X + dx, Y: pos.Y + dy}
+	if !g.gmap.InBounds(end.X, end.Y) || !g.gmap.IsWalkable(end.X, end.Y) {
+		end = pos
+	}
+	for _, id := range g.world.Query(component.CTagBlocking, component.CPosition) {
+		if g.world.Get(id, component.CPosition).(component.Position) == end {
+			end = pos // attacking or bumping: the player stays put
+			break
+		}
+	}
+	incoming, hp := system.EstimateIncomingDamage(g.world, g.playerID, end), g.playerHP()
+	if incoming < hp {
+		return true
+	}
+	msg := fmt.Sprintf("Risky! Enemies could deal %d damage (HP %d). Continue? [Y]es / [N]o", incoming, hp)
+	if g.confirm(msg, g.drawPlay) {
+		return true
+	}
+	g.addMessage("You hold back.")
+	return false
+}
+
+// autoPickup collects whatever the autopickup setting allows from the
+// player's tile after a move. Items are left behind when the backpack is full.
+func (g *Game) autoPickup() {
+	mode := g.profile.Autopickup
+	if mode == autopickupOff {
+		return
+	}
+	g.collectGold()
+	if mode != autopickupAll {
+		return
+	}
+	pos := g.playerPosition()
+	for _, id := range g.world.Query(component.CItem, component.CPosition) {
+		if g.world.Get(id, component.CPosition).(component.Position) != pos {
+			continue
+		}
+		inv, ok := g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+		if ok && len(inv.Backpack) >= inv.Capacity {
+			g.addMessage(fmt.Sprintf("Backpack full — you leave the %s.", g.world.Get(id, component.CItem).(component.CItemComp).Item.Name))
+			return
+		}
+		g.tryPickup()
+		return
+	}
 }
 
 // applyConsumable uses a consumable item from inventory (called by inventory screen).
@@ -713,12 +1071,34 @@ func (g *Game) applyConsumable(item component.Item) {
 		})
 		g.addMessage("The Phase Rod envelops you in prismatic shielding. (+6 DEF, 15 turns)")
 
+	case assets.GlyphTempoTonic:
+		system.ApplyEffect(g.world, g.playerID, component.ActiveEffect{
+			Kind: component.EffectHaste, Magnitude: 1, TurnsRemaining: 10,
+		})
+		g.addMessage("Time quickens around you. (Haste: ability recharges 2x for 10 turns)")
+
+	case assets.GlyphSnareKit, assets.GlyphCaltropPouch:
+		kind, name := component.TrapSnare, "snare"
+		if item.Glyph == assets.GlyphCaltropPouch {
+			kind, name = component.TrapSpike, "spike trap"
+		}
+		pos := g.playerPosition()
+		if _, ok := system.PlaceTrap(g.world, g.gmap, g.playerID, pos.X, pos.Y, kind); ok {
+			g.addMessage(fmt.Sprintf("You arm a hidden %s at your feet. Lure something onto it.", name))
+		}
+
+	case assets.GlyphDecoyDoll:
+		x, y, ok := system.FindDeploySpot(g.world, g.gmap, g.playerPosition())
+		if !ok {
+			break
+		}
+		factory.NewDecoy(g.world, g.playerID, x, y)
+		g.addMessage(fmt.Sprintf("You set down a decoy. Enemies that see it go after it instead of you. (%d turns)", factory.DecoyLifespan))
+
 	case assets.GlyphApexCore:
 		g.baseMaxHP += 3
-		hp := g.world.Get(g.playerID, component.CHealth).(component.Health)
-		hp.Max += 3
-		hp.Current += 3
-		g.world.Add(g.playerID, hp)
+		g.recalcPlayerMaxHP()
+		g.restorePlayerHP(3)
 		g.addMessage("The Apex Core integrates into your biology. (+3 MaxHP permanently)")
 	}
 }
@@ -730,33 +1110,36 @@ func (g *Game) equipBonuses() (atk, def int) {
 	if c == nil {
 		return 0, 0
 	}
-	inv := c.(component.Inventory)
-	atk = inv.MainHand.BonusATK + inv.OffHand.BonusATK +
-		inv.Head.BonusATK + inv.Body.BonusATK + inv.Feet.BonusATK
-	def = inv.MainHand.BonusDEF + inv.OffHand.BonusDEF +
-		inv.Head.BonusDEF + inv.Body.BonusDEF + inv.Feet.BonusDEF
-	return atk, def
+	atk, def, _ = c.(component.Inventory).EquipBonuses()
+	lostATK, lostDEF := system.DisarmPenalty(g.world, g.playerID)
+	return atk - lostATK, def - lostDEF
 }
 
 // recalcPlayerMaxHP recalculates the player's MaxHP from baseMaxHP + equipment bonuses.
-func (g *Game) recalcPlayerMaxHP() {
+// Current HP is clamped to the new MaxHP; returns the HP lost to the clamp.
+func (g *Game) recalcPlayerMaxHP() int {
 	invComp := g.world.Get(g.playerID, component.CInventory)
 	if invComp == nil {
-		return
+		return 0
 	}
-	inv := invComp.(component.Inventory)
-	bonus := inv.Head.BonusMaxHP + inv.Body.BonusMaxHP + inv.Feet.BonusMaxHP +
-		inv.MainHand.BonusMaxHP + inv.OffHand.BonusMaxHP + g.skillBonusMaxHP
+	_, _, equipHP := invComp.(component.Inventory).EquipBonuses()
+	bonus := equipHP + g.skillBonusMaxHP
 	hpComp := g.world.Get(g.playerID, component.CHealth)
 	if hpComp == nil {
-		return
+		return 0
 	}
 	hp := hpComp.(component.Health)
 	hp.Max = g.baseMaxHP + bonus
-	if hp.Current > hp.Max {
-		hp.Current = hp.Max
-	}
+	lost := max(hp.Current-hp.Max, 0)
+	hp.Current -= lost
 	g.world.Add(g.playerID, hp)
+	return lost
+}
+
+// maxHPLossMessage tells a player that lowering their MaxHP cost them lost
+// current HP.
+func maxHPLossMessage(lost int) string {
+	return fmt.Sprintf("Your max HP drops, and %d HP goes with it.", lost)
 }
 
 func (g *Game) checkInscription() {
@@ -768,6 +1151,7 @@ func (g *Game) checkInscription() {
 			g.runLog.InscriptionsRead++
 			g.grantXP(assets.XPForInscription)
 			g.addMessage("📝 " + text)
+			g.readRune()
 			return
 		}
 	}
@@ -784,6 +1168,13 @@ func (g *Game) interactFurniture(id ecs.EntityID) {
 	if f.Used {
 		return
 	}
+	if g.world.Has(id, component.CVending) {
+		g.useVendingMachine(id)
+		return
+	}
+	if g.world.Has(id, component.CRitual) && !g.advanceRitual(id) {
+		return
+	}
 
 	hasBonus := f.BonusATK != 0 || f.BonusDEF != 0 || f.BonusMaxHP != 0 ||
 		f.HealHP != 0 || f.PassiveKind != 0
@@ -829,7 +1220,7 @@ func (g *Game) interactFurniture(id ecs.EntityID) {
 	switch f.PassiveKind {
 	case component.PassiveKeenEye:
 		g.fovRadius++
-		system.UpdateFOV(g.world, g.gmap, g.playerID, g.effectiveFOVRadius())
+		g.updateFOV()
 		g.addMessage("Your vision expands permanently.")
 	case component.PassiveKillRestore:
 		g.furnitureKillRestore = true
@@ -849,29 +1240,34 @@ func (g *Game) teleportPlayer() {
 	if len(rooms) == 0 {
 		return
 	}
-	room := rooms[g.rng.Intn(len(rooms))]
+	room := rooms[g.combatRng.Intn(len(rooms))]
 	x, y := room.Center()
 	g.world.Add(g.playerID, component.Position{X: x, Y: y})
-	system.UpdateFOV(g.world, g.gmap, g.playerID, g.effectiveFOVRadius())
+	g.updateFOV()
 }
 
-// useSpecialAbility fires the class active ability (z key).
-func (g *Game) useSpecialAbility() {
+// useSpecialAbility fires the class active ability (z key). It reports whether
+// the ability fired; a refused use costs no charge and no turn.
+func (g *Game) useSpecialAbility() bool {
 	switch g.selectedClass.ID {
 	case "arcanist":
+		if x, y, ok := g.runTargeting("Dimensional Rift: pick a destination [Enter] confirm  [Esc] random", g.validBlinkTarget); ok {
+			g.blinkPlayer(x, y)
+			g.addMessage("Dimensional Rift folds space — you step through!")
+			return true
+		}
 		g.teleportPlayer()
 		g.addMessage("Dimensional Rift tears open — you reappear elsewhere!")
 
 	case "revenant":
 		hpComp := g.world.Get(g.playerID, component.CHealth)
 		if hpComp == nil {
-			return
+			return false
 		}
 		hp := hpComp.(component.Health)
 		if hp.Current <= 5 {
 			g.addMessage("Too wounded to bargain with death!")
-			g.specialCooldown = 0 // refund cooldown — no turn spent on non-use
-			return
+			return false
 		}
 		hp.Current -= 5
 		g.world.Add(g.playerID, hp)
@@ -903,7 +1299,10 @@ func (g *Game) useSpecialAbility() {
 				}
 			}
 		}
-		g.addMessage("Farsight! The entire floor is revealed.")
+		system.ApplyEffect(g.world, g.playerID, component.ActiveEffect{
+			Kind: component.EffectTrueSight, Magnitude: 1, TurnsRemaining: 10,
+		})
+		g.addMessage("Farsight! The entire floor is revealed, and nothing can lurk unseen for 10 turns.")
 
 	case "symbiont":
 		g.restorePlayerHP(10)
@@ -911,7 +1310,24 @@ func (g *Game) useSpecialAbility() {
 			Kind: component.EffectAttackBoost, Magnitude: 4, TurnsRemaining: 6,
 		})
 		g.addMessage("Parasite Surge! (+10 HP, +4 ATK for 6 turns)")
+
+	case "tinker":
+		x, y, ok := system.FindDeploySpot(g.world, g.gmap, g.playerPosition())
+		if !ok {
+			g.addMessage("No room to deploy a turret here!")
+			return false
+		}
+		factory.NewTurret(g.world, g.playerID, x, y)
+		g.addMessage(fmt.Sprintf("Turret deployed! (%d shots, %d turns)", factory.TurretAmmo, factory.TurretLifespan))
+
+	case "warden":
+		n := system.TauntEnemies(g.world, g.gmap, g.playerID, 6, 5)
+		system.ApplyEffect(g.world, g.playerID, component.ActiveEffect{
+			Kind: component.EffectDefenseBoost, Magnitude: 3, TurnsRemaining: 5,
+		})
+		g.addMessage(fmt.Sprintf("Challenge! %d enemies turn on you. (+3 DEF for 5 turns)", n))
 	}
+	return true
 }
 
 func (g *Game) checkPlayerDead() {
@@ -943,8 +1359,13 @@ func (g *Game) checkVictory() {
 // redraw is called first each iteration to paint the background.
 // Returns true if the player confirms (Y), false otherwise (N / Escape).
 func (g *Game) confirmQuit(redraw func()) bool {
+	return g.confirm("Quit? [Y]es / [N]o", redraw)
+}
+
+// confirm draws msg centred over redraw's output and waits for Y (true) or
+// N / Escape (false).
+func (g *Game) confirm(msg string, redraw func()) bool {
 	sw, sh := g.screen.Size()
-	msg := "Quit? [Y]es / [N]o"
 	style := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDefault).Bold(true)
 	for {
 		redraw()
@@ -974,6 +1395,40 @@ func (g *Game) confirmQuit(redraw func()) bool {
 	}
 }
 
+// newRenderer returns the renderer for floor: one drawing to the screen, or a
+// render.NopRenderer when the game runs headless.
+func (g *Game) newRenderer(floor int) render.Renderer {
+	if g.headless {
+		return render.NopRenderer{}
+	}
+	return render.NewRenderer(g.screen, floor)
+}
+
+// drawPlay renders the map centered on the player and the HUD.
+func (g *Game) drawPlay() {
+	playerPos := g.playerPosition()
+	g.renderer.SetMode(g.cameraMode())
+	g.renderer.Follow(playerPos.X, playerPos.Y)
+	g.renderer.SetLineWalls(g.profile.LineWalls)
+	g.renderer.SetASCII(g.asciiFlag || g.profile.ASCII)
+	if g.profile.ThreatTint {
+		g.renderer.SetEnemyTints(g.threatTintMap())
+	} else {
+		g.renderer.SetEnemyTints(nil)
+	}
+	g.renderer.SetThreatNote(g.adjacentThreat())
+	g.renderer.SetTurn(g.runLog.TurnsPlayed)
+	if ic := g.world.Get(g.playerID, component.CInventory); ic != nil {
+		g.renderer.SetHotbar(g.hotbar.HUD(ic.(component.Inventory)))
+	}
+	g.renderer.DrawFrame(g.world, g.gmap, g.playerID)
+	// Compute equipment + effect bonuses for HUD display.
+	equipATK, equipDEF := g.equipBonuses()
+	bonusATK := system.GetAttackBonus(g.world, g.playerID) + equipATK
+	bonusDEF := system.GetDefenseBonus(g.world, g.playerID) + equipDEF
+	g.renderer.DrawHUD(g.world, g.gmap, g.playerID, g.floor, fmt.Sprintf("%s 💰%d", g.selectedClass.Name, g.gold), g.messages, bonusATK, bonusDEF, g.selectedClass.AbilityName, g.specialCooldown, g.effectiveCooldown(), g.selectedClass.MaxCharges()-g.specialSpent, g.selectedClass.MaxCharges(), g.playerLevel, g.pendingLevels)
+}
+
 // runHelpScreen shows a keybinding reference overlay. Any key dismisses it.
 func (g *Game) runHelpScreen() {
 	lines := []string{
@@ -987,13 +1442,19 @@ func (g *Game) runHelpScreen() {
 		"  i                   Inventory",
 		"  Enter               Use stairs",
 		"  z                   Special ability",
+		"  p                   Block (shield: parry)",
+		"  ! @ # $             Use hotbar slot 1-4",
 		"",
 		"── Stairs (alternate) ────────────────",
 		"  >                   Descend",
 		"  <                   Ascend",
 		"",
 		"── Game ──────────────────────────────",
-		"  q / Esc             Quit",
+		"  Esc / q             Pause menu / Quit",
+		"  f                   Toggle hit flash",
+		"  e                   Examine (threat of enemies)",
+		"  t + direction       Travel along a corridor",
+		"  v                   Free-look the map",
 		"  ?                   This help",
 		"",
 		"  [any key to close]",
@@ -1058,6 +1519,15 @@ func (g *Game) playerPosition() component.Position {
 	return c.(component.Position)
 }
 
+// playerHP returns the player's current HP, or 0 if they have no Health.
+func (g *Game) playerHP() int {
+	c := g.world.Get(g.playerID, component.CHealth)
+	if c == nil {
+		return 0
+	}
+	return c.(component.Health).Current
+}
+
 func (g *Game) entityName(id ecs.EntityID) string {
 	rend := g.world.Get(id, component.CRenderable)
 	if rend == nil {
@@ -1067,9 +1537,9 @@ func (g *Game) entityName(id ecs.EntityID) string {
 }
 
 func (g *Game) addMessage(msg string) {
-	g.messages = append(g.messages, msg)
-	if len(g.messages) > 50 {
-		g.messages = g.messages[len(g.messages)-50:]
+	g.messages = capMessages(append(g.messages, msg), g.messageLimit)
+	if g.keepMessageLog {
+		g.narrative = append(g.narrative, msg)
 	}
 }
 
@@ -1117,6 +1587,9 @@ func (g *Game) showEndScreen() bool {
 			g.runLog.FloorsReached, assets.FloorName(g.runLog.FloorsReached))
 	}
 
+	rank, ranked := leaderboardRank(g.runLog.Score)
+	floorTimes, totalSecs, totalTurns := g.runLog.floorTimeEntries()
+
 	white := tcell.StyleDefault.Foreground(tcell.ColorWhite)
 	gold  := tcell.StyleDefault.Foreground(tcell.ColorYellow)
 	gray  := tcell.StyleDefault.Foreground(tcell.ColorGray)
@@ -1152,7 +1625,26 @@ func (g *Game) showEndScreen() bool {
 		}
 		y += 2
 
-		label(y, "Class:", g.runLog.Class); y++
+		// Score and floor times in a right-hand column, three floors per row.
+		g.putText(40, y, "Score:", dim)
+		g.putText(48, y, fmt.Sprintf("%d  (#%d of %d runs)", g.runLog.Score, rank, ranked), gold)
+		if len(floorTimes) > 0 {
+			g.putText(40, y+1, fmt.Sprintf("Floor Times (%s, %d turns):", formatDuration(totalSecs), totalTurns), dim)
+			for i, e := range floorTimes {
+				if r := []rune(e); len(r) > 12 {
+					e = string(r[:12])
+				}
+				g.putText(40+(i%3)*13, y+2+i/3, e, white)
+			}
+		}
+		class := g.runLog.Class
+		if g.runLog.Subclass != "" {
+			class += " / " + g.runLog.Subclass
+		}
+		if g.runLog.NGPlus > 0 {
+			class += fmt.Sprintf("  (NG+%d)", g.runLog.NGPlus)
+		}
+		label(y, "Class:", class); y++
 		label(y, "Floor Reached:", floorName); y++
 		label(y, "Turns Survived:", fmt.Sprintf("%d", g.runLog.TurnsPlayed)); y += 2
 
@@ -1172,25 +1664,43 @@ func (g *Game) showEndScreen() bool {
 		}
 		y++
 
+		if !won && len(g.runLog.RecentDamage) > 0 {
+			// Death recap in a right-hand column beside the short stat rows.
+			g.putText(40, y, "Final Blows:", dim)
+			for i, line := range g.runLog.recapLines() {
+				g.putText(42, y+1+i, line, white)
+			}
+			g.putText(40, y+5, "HP: "+g.runLog.hpTrajectory(), red)
+		}
 		label(y, "Items Used:", fmt.Sprintf("%d", totalItems)); y++
-		label(y, "Inscriptions Read:", fmt.Sprintf("%d", g.runLog.InscriptionsRead)); y += 2
+		label(y, "Inscriptions Read:", fmt.Sprintf("%d", g.runLog.InscriptionsRead)); y++
+		label(y, "Gold Earned:", fmt.Sprintf("%d", g.runLog.GoldEarned)); y += 2
 
 		label(y, "Damage Dealt:", fmt.Sprintf("%d", g.runLog.DamageDealt)); y++
 		label(y, "Damage Taken:", fmt.Sprintf("%d", g.runLog.DamageTaken)); y += 2
 
+		epilogue := assets.EpilogueFor(g.selectedClass.ID, won)
 		if won {
-			g.putText(2, y, "The Unmaker is unmade. The Spire falls silent.", green)
-		} else if g.runLog.CauseOfDeath == "poison" {
-			label(y, "Killed By:", "poison")
-		} else if g.runLog.CauseOfDeath != "" {
-			label(y, "Killed By:", g.runLog.CauseOfDeath)
+			g.putText(2, y, epilogue, green)
+		} else {
+			if g.runLog.CauseOfDeath != "" {
+				label(y, "Killed By:", g.runLog.killerLabel()); y++
+			}
+			g.putText(2, y, epilogue, gray)
 		}
 		y += 2
 
 		sep(y); y += 2
 
-		g.putText(2, y, "[R] Try Again", green)
-		g.putText(18, y, "[Q] Quit", red)
+		again := "[R] Try Again"
+		if won {
+			again = fmt.Sprintf("[R] Ascend Again (NG+%d)", g.ngPlus+1)
+		}
+		g.putText(2, y, again, green)
+		g.putText(len(again)+5, y, "[Q] Quit", red)
+		if g.runLog.MessageLogPath != "" {
+			g.putText(2, y+2, "Full log: "+g.runLog.MessageLogPath, dim)
+		}
 	}
 
 	for {
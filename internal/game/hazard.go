@@ -0,0 +1,59 @@
+package game
+
+import (
+	"fmt"
+
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/system"
+)
+
+// HazardHurtMessage tells the player named who ("you" for the player reading
+// it) that a hazard hurt them.
+func HazardHurtMessage(who, hazard string, damage int) string {
+	return fmt.Sprintf("The %s sears %s! (%d damage)", hazard, who, damage)
+}
+
+// HazardKillMessage announces an enemy with glyph dying in a hazard.
+func HazardKillMessage(glyph, hazard string) string {
+	return fmt.Sprintf("The %s perishes in the %s.", glyph, hazard)
+}
+
+// applyHazards lets the floor's hazards hurt whoever stands in them this
+// turn. Damage to the player counts toward their death like poison does; an
+// enemy the hazard kills is credited to the player like a trap kill.
+func (g *Game) applyHazards() {
+	for _, hit := range system.TickHazards(g.world) {
+		switch {
+		case hit.Target == g.playerID:
+			g.runLog.DamageTaken += hit.Damage
+			g.runLog.CauseOfDeath = hit.Hazard
+			g.runLog.noteDamage(g.runLog.TurnsPlayed, hit.Hazard, hit.Damage, g.playerHP())
+			g.addMessage(HazardHurtMessage("you", hit.Hazard, hit.Damage))
+		case hit.Killed:
+			g.creditKill("The "+hit.Hazard+" claims", hit.Glyph, hit.Pos, hit.Loot)
+		}
+	}
+}
+
+// coopApplyHazards is applyHazards for both co-op players. Hazard kills
+// belong to no one: they drop their spoils but earn neither player gold.
+func (g *CoopGame) coopApplyHazards() {
+	for _, hit := range system.TickHazards(g.world) {
+		if hit.Killed {
+			g.addMessage(HazardKillMessage(hit.Glyph, hit.Hazard))
+			g.coopCreditKill(nil, hit.Glyph, hit.Pos, hit.Loot)
+			continue
+		}
+		for _, p := range g.players {
+			if p.id != hit.Target {
+				continue
+			}
+			p.runLog.DamageTaken += hit.Damage
+			p.runLog.CauseOfDeath = hit.Hazard
+			if hc := g.world.Get(p.id, component.CHealth); hc != nil {
+				p.runLog.noteDamage(p.runLog.TurnsPlayed, hit.Hazard, hit.Damage, hc.(component.Health).Current)
+			}
+			g.addMessage(HazardHurtMessage(p.class.Name, hit.Hazard, hit.Damage))
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/factory"
+	"emoji-roguelike/internal/generate"
+)
+
+func TestStandingInHazardHurtsPlayer(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	killAllEnemies(g)
+	pos := g.playerPosition()
+	factory.LeaveHazard(g.world, g.floor, assets.GlyphCinderWraith, pos.X, pos.Y)
+	def := assets.DeathHazards[assets.GlyphCinderWraith]
+	hp := g.playerHP()
+
+	g.processAction(ActionWait)
+	if got := g.playerHP(); got != hp-def.Damage {
+		t.Errorf("HP after a turn in the %s = %d; want %d", def.Name, got, hp-def.Damage)
+	}
+	if want := HazardHurtMessage("you", def.Name, def.Damage); !slices.Contains(g.messages, want) {
+		t.Errorf("messages %q; want %q", g.messages, want)
+	}
+	if g.runLog.CauseOfDeath != def.Name {
+		t.Errorf("cause of death = %q; want %q", g.runLog.CauseOfDeath, def.Name)
+	}
+}
+
+func TestHazardKillIsCreditedAndCanWinTheRun(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	g.floorsVisited[MaxFloors] = true
+	g.loadFloor(MaxFloors)
+	killAllEnemies(g)
+	player := g.playerPosition()
+	boss := factory.NewEnemy(g.world, generate.EnemySpawnEntry{
+		Glyph: assets.BossGlyph(g.floor), MaxHP: 1, SightRange: 1,
+	}, player.X+2, player.Y, nil)
+	pos := g.world.Get(boss, component.CPosition).(component.Position)
+	factory.LeaveHazard(g.world, g.floor, assets.GlyphCinderWraith, pos.X, pos.Y)
+	gold := g.gold
+
+	g.applyHazards()
+	if g.world.Alive(boss) {
+		t.Fatal("the boss should die in the hazard")
+	}
+	if g.state != StateVictory {
+		t.Errorf("state = %v; want victory when the boss dies in a hazard", g.state)
+	}
+	if g.runLog.EnemiesKilled[assets.BossGlyph(g.floor)] != 1 || g.gold <= gold {
+		t.Errorf("kills %v, gold %d→%d; want the kill credited to the player", g.runLog.EnemiesKilled, gold, g.gold)
+	}
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/factory"
+	"emoji-roguelike/internal/generate"
+	"strings"
+	"testing"
+)
+
+// hintRow draws the play screen and returns the HUD separator row, where the
+// context hints are shown.
+func hintRow(g *Game) string {
+	g.drawPlay()
+	w, h := g.screen.Size()
+	var sb strings.Builder
+	for x := range w {
+		r, _, _, _ := g.screen.GetContent(x, h-5)
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func TestContextHintsFollowPlayer(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	g := newAbilityTestGame(t, "warden")
+	g.loadTutorialFloor() // fixed layout: no furniture, one flask, one staircase
+
+	row := hintRow(g)
+	if !strings.Contains(row, "[z] Ability") {
+		t.Errorf("ready ability should be hinted; row %q", row)
+	}
+	if strings.Contains(row, "Descend") || strings.Contains(row, "Pick up") {
+		t.Errorf("no stairs or item underfoot at the start; row %q", row)
+	}
+
+	g.world.Add(g.playerID, component.Position{X: 12, Y: 4}) // on the flask
+	if row := hintRow(g); !strings.Contains(row, "[,] Pick up") {
+		t.Errorf("item underfoot should be hinted; row %q", row)
+	}
+
+	g.world.Add(g.playerID, component.Position{X: g.gmap.Width - 3, Y: 4})
+	if row := hintRow(g); !strings.Contains(row, "[>] Descend") {
+		t.Errorf("stairs underfoot should be hinted; row %q", row)
+	}
+
+	g.processAction(ActionSpecialAbility)
+	if row := hintRow(g); strings.Contains(row, "[z] Ability") {
+		t.Errorf("ability on cooldown should not be hinted; row %q", row)
+	}
+}
+
+func TestContextHintsShowFurnitureDirection(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	g := newAbilityTestGame(t, "warden")
+	g.loadTutorialFloor()
+	pos := g.playerPosition()
+	factory.NewFurniture(g.world, generate.FurnitureSpawnEntry{Glyph: "🪑", Name: "Chair"}, pos.X, pos.Y-1)
+	if row := hintRow(g); !strings.Contains(row, "[↑] Interact") {
+		t.Errorf("furniture to the north should be hinted with ↑; row %q", row)
+	}
+}
@@ -0,0 +1,52 @@
+package game
+
+import (
+	"emoji-roguelike/internal/component"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// cornerBackground renders one frame and returns the background colour of the
+// top-left map cell.
+func cornerBackground(g *Game) tcell.Color {
+	g.renderer.DrawFrame(g.world, g.gmap, g.playerID)
+	_, _, style, _ := g.screen.GetContent(0, 0)
+	_, bg, _ := style.Decompose()
+	return bg
+}
+
+func TestHeavyHitFlashesBorderForOneFrame(t *testing.T) {
+	g := newAbilityTestGame(t, "arcanist")
+	maxHP := g.world.Get(g.playerID, component.CHealth).(component.Health).Max
+
+	g.flashOnHeavyHit(1)
+	if cornerBackground(g) == tcell.ColorDarkRed {
+		t.Error("a light hit should not flash")
+	}
+	g.flashOnHeavyHit(maxHP)
+	if cornerBackground(g) != tcell.ColorDarkRed {
+		t.Error("a heavy hit should flash the border red")
+	}
+	if cornerBackground(g) == tcell.ColorDarkRed {
+		t.Error("the flash should clear after one frame")
+	}
+}
+
+func TestToggleHitFlash(t *testing.T) {
+	g := newAbilityTestGame(t, "arcanist")
+	maxHP := g.world.Get(g.playerID, component.CHealth).(component.Health).Max
+
+	g.processAction(ActionToggleFlash)
+	if !g.hitFlashOff {
+		t.Fatal("toggle should turn the hit flash off")
+	}
+	g.flashOnHeavyHit(maxHP)
+	if cornerBackground(g) == tcell.ColorDarkRed {
+		t.Error("a disabled flash should not draw")
+	}
+	g.processAction(ActionToggleFlash)
+	if g.hitFlashOff {
+		t.Error("a second toggle should turn the hit flash back on")
+	}
+}
@@ -0,0 +1,146 @@
+package game
+
+import (
+	"fmt"
+
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/render"
+)
+
+// HotbarSlots is how many consumables a player can bind for quick use.
+const HotbarSlots = 4
+
+// HotbarKeys use hotbar slots 1–4 from the map, and bind the selected item to
+// them in the inventory. They are the shifted digits: the digits themselves
+// move on the numpad.
+var HotbarKeys = [HotbarSlots]rune{'!', '@', '#', '$'}
+
+// HotbarSlot returns the hotbar slot key r uses, if any.
+func HotbarSlot(r rune) (int, bool) {
+	for i, k := range HotbarKeys {
+		if k == r {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Hotbar binds consumables to the HotbarKeys by glyph, so a slot outlives the
+// items in it: once the last one is used the slot stays bound, and picking
+// up another refills it. "" leaves a slot unbound.
+type Hotbar [HotbarSlots]string
+
+// Bind binds the backpack item under the inventory cursor to slot, moving it
+// off any slot it was bound to before, and returns a status message.
+func (h *Hotbar) Bind(inv component.Inventory, panel, cursor, slot int) string {
+	if panel != 0 || cursor < 0 || cursor >= len(inv.Backpack) {
+		return "Select a backpack consumable to bind."
+	}
+	item := inv.Backpack[cursor]
+	if !item.IsConsumable {
+		return "Only consumables go on the hotbar."
+	}
+	for i := range h {
+		if h[i] == item.Glyph {
+			h[i] = ""
+		}
+	}
+	h[slot] = item.Glyph
+	return fmt.Sprintf("%s bound to [%c].", item.Name, HotbarKeys[slot])
+}
+
+// Find returns the backpack index of the first item in inv bound to slot, or
+// -1 if the slot is unbound or empty.
+func (h Hotbar) Find(inv component.Inventory, slot int) int {
+	if h[slot] == "" {
+		return -1
+	}
+	for i, item := range inv.Backpack {
+		if item.IsConsumable && item.Glyph == h[slot] {
+			return i
+		}
+	}
+	return -1
+}
+
+// Tag marks a backpack item bound to the hotbar with its key, or returns ""
+// for an unbound one.
+func (h Hotbar) Tag(item component.Item) string {
+	for i, glyph := range h {
+		if item.IsConsumable && glyph == item.Glyph {
+			return fmt.Sprintf(" [%c]", HotbarKeys[i])
+		}
+	}
+	return ""
+}
+
+// HUD describes the bound slots for the renderer, with how many of each inv
+// holds.
+func (h Hotbar) HUD(inv component.Inventory) []render.HotbarSlot {
+	var slots []render.HotbarSlot
+	for i, glyph := range h {
+		if glyph == "" {
+			continue
+		}
+		n := 0
+		for _, item := range inv.Backpack {
+			if item.IsConsumable && item.Glyph == glyph {
+				n++
+			}
+		}
+		slots = append(slots, render.HotbarSlot{Key: HotbarKeys[i], Glyph: glyph, Count: n})
+	}
+	return slots
+}
+
+// EmptyMessage explains why hotbar slot could not be used: nothing is bound
+// to it, or none of its consumable is left.
+func (h Hotbar) EmptyMessage(slot int) string {
+	if h[slot] == "" {
+		return fmt.Sprintf("Nothing bound to [%c]. Bind a consumable from the inventory.", HotbarKeys[slot])
+	}
+	return fmt.Sprintf("No %s left.", h[slot])
+}
+
+// useHotbar uses the consumable bound to hotbar slot without opening the
+// inventory. Reports whether it took a turn.
+func (g *Game) useHotbar(slot int) bool {
+	ic := g.world.Get(g.playerID, component.CInventory)
+	if ic == nil {
+		return false
+	}
+	inv := ic.(component.Inventory)
+	i := g.hotbar.Find(inv, slot)
+	if i < 0 {
+		g.addMessage(g.hotbar.EmptyMessage(slot))
+		return false
+	}
+	wand := inv.Backpack[i].Charges > 0
+	msg, used := g.invUseConsumable(&inv, 0, i)
+	g.saveInventory(inv)
+	if !used || wand { // a plain consumable announces its own effect
+		g.addMessage(msg)
+	}
+	return used
+}
+
+// coopUseHotbar is useHotbar for co-op player p.
+func (g *CoopGame) coopUseHotbar(p *coopPlayer, slot int) bool {
+	ic := g.world.Get(p.id, component.CInventory)
+	if ic == nil {
+		return false
+	}
+	inv := ic.(component.Inventory)
+	i := p.hotbar.Find(inv, slot)
+	if i < 0 {
+		g.addMessage(fmt.Sprintf("%s: %s", p.class.Name, p.hotbar.EmptyMessage(slot)))
+		return false
+	}
+	wand := inv.Backpack[i].Charges > 0
+	msg, used := g.coopInvUseConsumable(p, &inv, 0, i)
+	g.coopSaveInventory(p, inv)
+	if !used || wand {
+		g.addMessage(fmt.Sprintf("%s: %s", p.class.Name, msg))
+	}
+	return used
+}
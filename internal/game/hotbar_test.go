@@ -0,0 +1,96 @@
+package game
+
+import (
+	"testing"
+
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+var (
+	testFlask = component.Item{Name: "Hyperflask", Glyph: assets.GlyphHyperflask, IsConsumable: true}
+	testPrism = component.Item{Name: "Prism Shard", Glyph: assets.GlyphPrismShard, IsConsumable: true}
+)
+
+func TestHotbarKeysMapToActions(t *testing.T) {
+	for i, r := range HotbarKeys {
+		if got := keyToAction(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone)); got != ActionHotbar1+Action(i) {
+			t.Errorf("keyToAction(%q) = %v; want hotbar slot %d", r, got, i+1)
+		}
+	}
+	if _, ok := HotbarSlot('1'); ok {
+		t.Error("'1' should stay numpad movement, not a hotbar slot")
+	}
+}
+
+func TestHotbarBind(t *testing.T) {
+	inv := component.Inventory{Backpack: []component.Item{
+		testFlask,
+		{Name: "Blade", Slot: component.SlotOneHand, BonusATK: 2},
+	}}
+	var h Hotbar
+
+	if msg := h.Bind(inv, 0, 1, 0); h[0] != "" {
+		t.Errorf("bound equipment to a slot (%q)", msg)
+	}
+	if msg := h.Bind(inv, 1, 0, 0); h[0] != "" {
+		t.Errorf("bound from the equipped panel (%q)", msg)
+	}
+	h.Bind(inv, 0, 0, 0)
+	h.Bind(inv, 0, 0, 2)
+	if h[0] != "" || h[2] != testFlask.Glyph {
+		t.Errorf("hotbar %q; want the flask moved to slot 3", h)
+	}
+	if got := h.Tag(testFlask); got != " [#]" {
+		t.Errorf("Tag = %q; want \" [#]\"", got)
+	}
+}
+
+func TestHotbarRefillsAfterStackEmpties(t *testing.T) {
+	inv := component.Inventory{Backpack: []component.Item{testPrism, testFlask}}
+	h := Hotbar{testFlask.Glyph}
+
+	if i := h.Find(inv, 0); i != 1 {
+		t.Fatalf("Find = %d; want the flask at 1", i)
+	}
+	inv.Backpack = inv.Backpack[:1]
+	if i := h.Find(inv, 0); i != -1 {
+		t.Errorf("Find = %d with no flask left; want -1", i)
+	}
+	if got := h.HUD(inv); len(got) != 1 || got[0].Count != 0 {
+		t.Errorf("HUD = %+v; want the empty slot still shown", got)
+	}
+	inv.Backpack = append(inv.Backpack, testFlask, testFlask)
+	if got := h.HUD(inv); got[0].Count != 2 {
+		t.Errorf("HUD count = %d after picking up two; want 2", got[0].Count)
+	}
+}
+
+func TestUseHotbarSpendsTurnAndItem(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	killAllEnemies(g)
+	inv := g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	inv.Backpack = []component.Item{testPrism, testFlask}
+	g.world.Add(g.playerID, inv)
+	g.hotbar[1] = testFlask.Glyph
+	turns := g.runLog.TurnsPlayed
+
+	g.processAction(ActionHotbar2)
+	if g.runLog.TurnsPlayed != turns+1 {
+		t.Errorf("turns played %d; want using the hotbar to take a turn", g.runLog.TurnsPlayed-turns)
+	}
+	inv = g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	if len(inv.Backpack) != 1 || inv.Backpack[0].Glyph != testPrism.Glyph {
+		t.Errorf("backpack %+v; want only the prism shard left", inv.Backpack)
+	}
+
+	g.processAction(ActionHotbar2)
+	if g.runLog.TurnsPlayed != turns+1 {
+		t.Error("an empty slot should not take a turn")
+	}
+	if want := g.hotbar.EmptyMessage(1); g.messages[len(g.messages)-1] != want {
+		t.Errorf("last message %q; want %q", g.messages[len(g.messages)-1], want)
+	}
+}
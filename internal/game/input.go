@@ -25,6 +25,20 @@ const (
 	ActionHelp
 	ActionUseStairs
 	ActionLevelUp
+	ActionToggleFlash
+	ActionMenu
+	ActionExamine
+	ActionTravel
+	ActionFreeLook
+	ActionBlock
+	// ActionHotbar1 to ActionHotbar4 use the hotbar slots; see HotbarKeys.
+	ActionHotbar1
+	ActionHotbar2
+	ActionHotbar3
+	ActionHotbar4
+	// ActionDisconnect is never bound to a key: coop's waitPlayerAction
+	// returns it when a player's connection drops, as opposed to ActionQuit.
+	ActionDisconnect
 )
 
 // keyToAction maps a tcell key event to a game action.
@@ -42,7 +56,7 @@ func keyToAction(ev *tcell.EventKey) Action {
 	case tcell.KeyEnter:
 		return ActionUseStairs
 	case tcell.KeyEscape:
-		return ActionQuit
+		return ActionMenu
 	}
 
 	// Rune keys.
@@ -103,6 +117,19 @@ func keyToAction(ev *tcell.EventKey) Action {
 		return ActionLevelUp
 	case '?':
 		return ActionHelp
+	case 'f', 'F':
+		return ActionToggleFlash
+	case 'e', 'E':
+		return ActionExamine
+	case 't', 'T':
+		return ActionTravel
+	case 'v', 'V':
+		return ActionFreeLook
+	case 'p', 'P':
+		return ActionBlock
+	}
+	if slot, ok := HotbarSlot(ev.Rune()); ok {
+		return ActionHotbar1 + Action(slot)
 	}
 	return ActionNone
 }
@@ -129,3 +156,14 @@ func actionToDelta(a Action) (int, int) {
 	}
 	return 0, 0
 }
+
+// deltaToAction converts a single step (dx, dy) back to its movement action,
+// or ActionNone when it is not one.
+func deltaToAction(dx, dy int) Action {
+	for a := ActionMoveN; a <= ActionMoveSW; a++ {
+		if adx, ady := actionToDelta(a); adx == dx && ady == dy {
+			return a
+		}
+	}
+	return ActionNone
+}
@@ -1,8 +1,10 @@
 package game
 
 import (
+	"emoji-roguelike/assets"
 	"emoji-roguelike/internal/component"
 	"emoji-roguelike/internal/factory"
+	"emoji-roguelike/internal/system"
 	"fmt"
 
 	"github.com/gdamore/tcell/v2"
@@ -58,8 +60,7 @@ func (g *Game) runInventoryScreen() bool {
 			switch ev.Key() {
 			case tcell.KeyEscape:
 				// Save inventory back and close.
-				g.world.Add(g.playerID, inv)
-				g.recalcPlayerMaxHP()
+				g.saveInventory(inv)
 				return turnUsed
 
 			case tcell.KeyTab:
@@ -93,19 +94,19 @@ func (g *Game) runInventoryScreen() bool {
 					if used {
 						turnUsed = true
 						// Save and close after using a consumable.
-						g.world.Add(g.playerID, inv)
-						g.recalcPlayerMaxHP()
+						g.saveInventory(inv)
 						return turnUsed
 					}
 				case 'd', 'D':
 					msg := g.invDrop(&inv, panel, &cursor)
 					statusMsg = msg
 				case 'i', 'I', 'q', 'Q':
-					g.world.Add(g.playerID, inv)
-					g.recalcPlayerMaxHP()
+					g.saveInventory(inv)
 					return turnUsed
 				default:
-					if ev.Rune() >= '1' && ev.Rune() <= '9' {
+					if slot, ok := HotbarSlot(ev.Rune()); ok {
+						statusMsg = g.hotbar.Bind(inv, panel, cursor, slot)
+					} else if ev.Rune() >= '1' && ev.Rune() <= '9' {
 						idx := int(ev.Rune()-'0') - 1 // convert to 0-based
 						if idx < len(inv.Backpack) {
 							panel = 0
@@ -118,6 +119,15 @@ func (g *Game) runInventoryScreen() bool {
 	}
 }
 
+// saveInventory writes inv back to the player and refits MaxHP to the gear
+// now worn, telling the player if shedding MaxHP cost them current HP.
+func (g *Game) saveInventory(inv component.Inventory) {
+	g.world.Add(g.playerID, inv)
+	if lost := g.recalcPlayerMaxHP(); lost > 0 {
+		g.addMessage(maxHPLossMessage(lost))
+	}
+}
+
 // invEquipOrUnequip handles the equip/unequip action in the inventory.
 func (g *Game) invEquipOrUnequip(inv *component.Inventory, panel, cursor int) string {
 	if panel == 0 {
@@ -145,7 +155,7 @@ func (g *Game) invEquip(inv *component.Inventory, cursor int) string {
 		inv.Backpack = removeAt(inv.Backpack, cursor)
 		inv.Head = item
 		if !old.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, old)
+			inv.Stow(old)
 		}
 		return fmt.Sprintf("Equipped %s.", item.Name)
 
@@ -154,7 +164,7 @@ func (g *Game) invEquip(inv *component.Inventory, cursor int) string {
 		inv.Backpack = removeAt(inv.Backpack, cursor)
 		inv.Body = item
 		if !old.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, old)
+			inv.Stow(old)
 		}
 		return fmt.Sprintf("Equipped %s.", item.Name)
 
@@ -163,7 +173,7 @@ func (g *Game) invEquip(inv *component.Inventory, cursor int) string {
 		inv.Backpack = removeAt(inv.Backpack, cursor)
 		inv.Feet = item
 		if !old.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, old)
+			inv.Stow(old)
 		}
 		return fmt.Sprintf("Equipped %s.", item.Name)
 
@@ -172,32 +182,24 @@ func (g *Game) invEquip(inv *component.Inventory, cursor int) string {
 		inv.Backpack = removeAt(inv.Backpack, cursor)
 		inv.MainHand = item
 		if !old.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, old)
+			inv.Stow(old)
 		}
 		return fmt.Sprintf("Equipped %s.", item.Name)
 
 	case component.SlotTwoHand:
-		// Count extra items that need to go to backpack.
-		extra := 0
-		if !inv.MainHand.IsEmpty() {
-			extra++
-		}
-		if !inv.OffHand.IsEmpty() {
-			extra++
+		var displaced []component.Item
+		for _, held := range []component.Item{inv.OffHand, inv.MainHand} {
+			if !held.IsEmpty() {
+				displaced = append(displaced, held)
+			}
 		}
-		// After removing cursor item: len-1 slots used. Need len-1+extra <= Capacity.
-		if len(inv.Backpack)-1+extra > inv.Capacity {
+		// The cursor item leaves the backpack before the held items enter it.
+		if !inv.CanHold(len(displaced) - 1) {
 			return "Not enough backpack space to swap."
 		}
 		inv.Backpack = removeAt(inv.Backpack, cursor)
-		if !inv.OffHand.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, inv.OffHand)
-			inv.OffHand = component.Item{}
-		}
-		if !inv.MainHand.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, inv.MainHand)
-		}
-		inv.MainHand = item
+		inv.Stow(displaced...)
+		inv.MainHand, inv.OffHand = item, component.Item{}
 		return fmt.Sprintf("Equipped %s (two-handed).", item.Name)
 
 	case component.SlotOffHand:
@@ -208,7 +210,7 @@ func (g *Game) invEquip(inv *component.Inventory, cursor int) string {
 		inv.Backpack = removeAt(inv.Backpack, cursor)
 		inv.OffHand = item
 		if !old.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, old)
+			inv.Stow(old)
 		}
 		return fmt.Sprintf("Equipped %s.", item.Name)
 	}
@@ -217,7 +219,7 @@ func (g *Game) invEquip(inv *component.Inventory, cursor int) string {
 
 // invUnequip moves an equipment slot item back to the backpack.
 func (g *Game) invUnequip(inv *component.Inventory, cursor int) string {
-	if len(inv.Backpack) >= inv.Capacity {
+	if !inv.CanHold(1) {
 		return "Backpack full — drop something first."
 	}
 	var item component.Item
@@ -255,7 +257,7 @@ func (g *Game) invUnequip(inv *component.Inventory, cursor int) string {
 	default:
 		return "Invalid slot."
 	}
-	inv.Backpack = append(inv.Backpack, item)
+	inv.Stow(item)
 	return fmt.Sprintf("Unequipped %s.", item.Name)
 }
 
@@ -272,11 +274,35 @@ func (g *Game) invUseConsumable(inv *component.Inventory, panel, cursor int) (st
 	if !item.IsConsumable {
 		return "Equipment must be equipped, not used.", false
 	}
+	if item.Charges > 0 {
+		return useCharge(inv, cursor, g.zapWand)
+	}
+	if why := g.consumableRefusal(item); why != "" {
+		return why, false
+	}
 	inv.Backpack = removeAt(inv.Backpack, cursor)
 	g.applyConsumable(item)
 	return fmt.Sprintf("Used %s.", item.Name), true
 }
 
+// consumableRefusal explains why item cannot be used where the player stands,
+// or returns "" if it can. A refused item stays in the backpack and costs no
+// turn.
+func (g *Game) consumableRefusal(item component.Item) string {
+	pos := g.playerPosition()
+	switch item.Glyph {
+	case assets.GlyphSnareKit, assets.GlyphCaltropPouch:
+		if !system.CanPlaceTrap(g.world, g.gmap, pos.X, pos.Y) {
+			return "You can't set a trap here."
+		}
+	case assets.GlyphDecoyDoll:
+		if _, _, ok := system.FindDeploySpot(g.world, g.gmap, pos); !ok {
+			return "No room to set a decoy down here."
+		}
+	}
+	return ""
+}
+
 // invDrop drops the selected item at the player's position.
 func (g *Game) invDrop(inv *component.Inventory, panel int, cursor *int) string {
 	pos := g.playerPosition()
@@ -365,7 +391,7 @@ func (g *Game) drawInventoryScreen(inv component.Inventory, panel, cursor int, s
 
 	// Row 2: column headers
 	g.putText(0, 2, "── EQUIPPED ──────────────────", white)
-	g.putText(mid, 2, "── BACKPACK ─────────────────", white)
+	g.putText(mid, 2, "── BACKPACK ─ [!@#$] Hotbar ─", white)
 
 	// Vertical divider
 	for y := 2; y <= 12; y++ {
@@ -402,9 +428,7 @@ func (g *Game) drawInventoryScreen(inv component.Inventory, panel, cursor int, s
 	}
 
 	// Row 8: equipment bonus totals
-	atkB := inv.Head.BonusATK + inv.Body.BonusATK + inv.Feet.BonusATK + inv.MainHand.BonusATK + inv.OffHand.BonusATK
-	defB := inv.Head.BonusDEF + inv.Body.BonusDEF + inv.Feet.BonusDEF + inv.MainHand.BonusDEF + inv.OffHand.BonusDEF
-	hpB := inv.Head.BonusMaxHP + inv.Body.BonusMaxHP + inv.Feet.BonusMaxHP + inv.MainHand.BonusMaxHP + inv.OffHand.BonusMaxHP
+	atkB, defB, hpB := inv.EquipBonuses()
 	g.putText(0, 8, fmt.Sprintf("  Equip bonus: ATK%+d DEF%+d HP%+d", atkB, defB, hpB), cyan)
 
 	// Backpack panel (rows 3–10: up to 8 items)
@@ -421,9 +445,12 @@ func (g *Game) drawInventoryScreen(inv component.Inventory, panel, cursor int, s
 			pfx = "► "
 		}
 		tag := ""
-		if item.IsConsumable {
+		if item.Charges > 0 {
+			tag = wandTag(item)
+		} else if item.IsConsumable {
 			tag = " [use]"
 		}
+		tag += g.hotbar.Tag(item)
 		bonuses := formatBonuses(item)
 		line := fmt.Sprintf("%s[%d] %s %s%s%s", pfx, i+1, item.Glyph, item.Name, bonuses, tag)
 		g.putText(mid, row, line, style)
@@ -463,8 +490,14 @@ func (g *Game) drawInventoryScreen(inv component.Inventory, panel, cursor int, s
 		slotName := slotLabel(selItem.Slot)
 		desc := fmt.Sprintf("%s — %s  ATK%+d DEF%+d MaxHP%+d",
 			selItem.Name, slotName, selItem.BonusATK, selItem.BonusDEF, selItem.BonusMaxHP)
+		if selItem.Slot == component.SlotOneHand || selItem.Slot == component.SlotTwoHand {
+			desc += proficiencyTag(g.weaponHits[selItem.Name])
+		}
 		g.putText(0, 12, desc, white)
 	}
+	if panel == 0 && !selEmpty {
+		DrawEquipComparison(g.screen, 14, inv, selItem)
+	}
 	if statusMsg != "" {
 		g.putText(0, 13, statusMsg, green)
 	}
@@ -472,6 +505,17 @@ func (g *Game) drawInventoryScreen(inv component.Inventory, panel, cursor int, s
 	g.screen.Show()
 }
 
+// proficiencyTag describes the proficiency earned with a weapon after hits
+// landed hits.
+func proficiencyTag(hits int) string {
+	level := system.ProficiencyLevel(hits)
+	if level == system.ProficiencyMax {
+		return fmt.Sprintf("  Proficiency %d/%d (ATK%+d)", level, system.ProficiencyMax, level)
+	}
+	return fmt.Sprintf("  Proficiency %d/%d (ATK%+d, %d hits to next)",
+		level, system.ProficiencyMax, level, (level+1)*system.ProficiencyStep-hits)
+}
+
 // removeAt returns a new slice with the element at index i removed.
 func removeAt(s []component.Item, i int) []component.Item {
 	out := make([]component.Item, 0, len(s)-1)
@@ -480,7 +524,8 @@ func removeAt(s []component.Item, i int) []component.Item {
 	return out
 }
 
-// formatBonuses returns a compact bonus string for an item (e.g. " +4A +3D").
+// formatBonuses returns a compact bonus string for an item (e.g. " +4A +3D"),
+// followed by its durability.
 func formatBonuses(item component.Item) string {
 	if item.IsConsumable {
 		return ""
@@ -495,7 +540,10 @@ func formatBonuses(item component.Item) string {
 	if item.BonusMaxHP != 0 {
 		s += fmt.Sprintf(" %+dHP", item.BonusMaxHP)
 	}
-	return s
+	if item.CDRPercent != 0 {
+		s += fmt.Sprintf(" -%d%%CD", item.CDRPercent)
+	}
+	return s + DurabilityTag(item)
 }
 
 // slotLabel returns a human-readable slot name.
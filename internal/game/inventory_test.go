@@ -0,0 +1,121 @@
+package game
+
+import (
+	"testing"
+
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/system"
+)
+
+// vitalPlate is a body piece granting +8 MaxHP.
+var vitalPlate = component.Item{Name: "Vital Plate", Slot: component.SlotBody, BonusMaxHP: 8}
+
+// wearAndShed equips vitalPlate, sets current HP via setHP, then unequips it,
+// saving the inventory after each step as the inventory screen does on close.
+func wearAndShed(g *Game, setHP func(max int) int) component.Health {
+	inv := g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	inv.Backpack = append(inv.Backpack, vitalPlate)
+	g.invEquip(&inv, len(inv.Backpack)-1)
+	g.saveInventory(inv)
+
+	hp := g.world.Get(g.playerID, component.CHealth).(component.Health)
+	hp.Current = setHP(hp.Max)
+	g.world.Add(g.playerID, hp)
+
+	g.invUnequip(&inv, 1)
+	g.saveInventory(inv)
+	return g.world.Get(g.playerID, component.CHealth).(component.Health)
+}
+
+func TestUnequipMaxHPAtFullHPWarns(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	base := g.world.Get(g.playerID, component.CHealth).(component.Health).Max
+
+	hp := wearAndShed(g, func(max int) int { return max })
+	if hp.Max != base || hp.Current != base {
+		t.Errorf("HP after unequipping = %d/%d; want %d/%d", hp.Current, hp.Max, base, base)
+	}
+	if !hasMessage(g, maxHPLossMessage(vitalPlate.BonusMaxHP)) {
+		t.Error("losing HP to an unequip should say so")
+	}
+}
+
+func TestUnequipMaxHPAtPartialHPKeepsCurrent(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	base := g.world.Get(g.playerID, component.CHealth).(component.Health).Max
+
+	hp := wearAndShed(g, func(max int) int { return max - 10 })
+	if hp.Max != base || hp.Current != base+vitalPlate.BonusMaxHP-10 {
+		t.Errorf("HP after unequipping = %d/%d; want %d/%d",
+			hp.Current, hp.Max, base+vitalPlate.BonusMaxHP-10, base)
+	}
+	if hasMessage(g, "Your max HP drops") {
+		t.Error("no HP was lost, so there should be no warning")
+	}
+}
+
+var (
+	greatblade = component.Item{Name: "Greatblade", Slot: component.SlotTwoHand, BonusATK: 5}
+	buckler    = component.Item{Name: "Buckler", Slot: component.SlotOffHand, BonusDEF: 2}
+)
+
+// backpackIndex returns the backpack position of the item named name, or -1.
+func backpackIndex(inv component.Inventory, name string) int {
+	for i, it := range inv.Backpack {
+		if it.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// TestDropTwoHanderFreesOffHand equips a two-hander, drops it from the weapon
+// slot and checks that an off-hand item can then be equipped without being
+// orphaned in the backpack.
+func TestDropTwoHanderFreesOffHand(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	inv := g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	inv.Backpack = append(inv.Backpack, greatblade, buckler)
+
+	g.invEquip(&inv, backpackIndex(inv, greatblade.Name))
+	if msg := g.invEquip(&inv, backpackIndex(inv, buckler.Name)); inv.OffHand.Name == buckler.Name {
+		t.Fatalf("equipped an off-hand beside a two-hander: %q", msg)
+	}
+	cursor := 3 // weapon slot
+	g.invDrop(&inv, 1, &cursor)
+	if !inv.MainHand.IsEmpty() {
+		t.Fatalf("MainHand = %q after dropping it", inv.MainHand.Name)
+	}
+
+	g.invEquip(&inv, backpackIndex(inv, buckler.Name))
+	if inv.OffHand.Name != buckler.Name {
+		t.Errorf("OffHand = %q; want the buckler once the two-hander is gone", inv.OffHand.Name)
+	}
+	if backpackIndex(inv, buckler.Name) >= 0 || backpackIndex(inv, greatblade.Name) >= 0 {
+		t.Errorf("backpack still holds the buckler or greatblade: %v", inv.Backpack)
+	}
+}
+
+// TestTrapKitKeptWhenTileIsTaken uses a Snare Kit on a tile that already holds
+// a trap: the use is refused, the kit stays in the backpack and no turn passes.
+func TestTrapKitKeptWhenTileIsTaken(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	pos := g.playerPosition()
+	if _, ok := system.PlaceTrap(g.world, g.gmap, g.playerID, pos.X, pos.Y, component.TrapSpike); !ok {
+		t.Fatal("could not arm the first trap")
+	}
+	inv := g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	inv.Backpack = []component.Item{{Name: "Snare Kit", Glyph: assets.GlyphSnareKit, IsConsumable: true}}
+
+	msg, used := g.invUseConsumable(&inv, 0, 0)
+	if used {
+		t.Errorf("invUseConsumable = %q, used; want the use refused", msg)
+	}
+	if len(inv.Backpack) != 1 {
+		t.Errorf("backpack = %+v; want the Snare Kit kept", inv.Backpack)
+	}
+	if n := len(g.world.Query(component.CTrap)); n != 1 {
+		t.Errorf("%d traps on the floor; want 1", n)
+	}
+}
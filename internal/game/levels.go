@@ -2,44 +2,93 @@ package game
 
 import (
 	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/gamemap"
 	"emoji-roguelike/internal/generate"
-	"math"
 	"math/rand"
 )
 
 const MaxFloors = 10
 
-// levelConfig builds a generate.Config for the given floor number.
-func levelConfig(floor int, rng *rand.Rand) *generate.Config {
-	t := 0.0
-	if MaxFloors > 1 {
-		t = float64(floor-1) / float64(MaxFloors-1)
-	}
+// New Game+ scaling: each ascension adds ngPlusStatPct percent to every
+// enemy's ATK and HP, and ngPlusBudgetPct percent to each floor's enemy budget.
+const (
+	ngPlusStatPct   = 25
+	ngPlusBudgetPct = 15
+)
 
-	return &generate.Config{
-		MapWidth:      lerpi(40, 90, t),
-		MapHeight:     lerpi(20, 50, t),
-		MinLeafSize:   8,
-		MaxLeafSize:   lerpi(20, 10, t),
+// levelConfig builds a generate.Config for the given floor number from its
+// assets.FloorConfigs entry.
+func levelConfig(floor int, rng *rand.Rand) *generate.Config {
+	fc := assets.FloorConfigFor(floor)
+	cfg := &generate.Config{
+		MapWidth:      fc.Width,
+		MapHeight:     fc.Height,
+		MinLeafSize:   assets.MinLeafSize,
+		MaxLeafSize:   fc.MaxLeafSize,
 		SplitRatio:    0.5,
 		MinRoomSize:   4,
 		RoomPadding:   1,
-		CorridorStyle: generate.CorridorLShaped,
+		CorridorStyle: fc.Corridors,
 		FloorNumber:   floor,
-		EnemyBudget:   lerpi(5, 55, t),
-		ItemCount:     lerpi(3, 8, t),
-		EquipCount:    lerpi(1, 3, t),
+		EnemyBudget:   fc.EnemyBudget,
+		ItemCount:     fc.ItemCount,
+		EquipCount:    fc.EquipCount,
 		EnemyTable:       assets.EnemyTable(floor),
 		ItemTable:        itemTableForFloor(floor),
 		EquipTable:       assets.EquipTablesForFloor(floor),
 		InscriptionTexts: assets.WallWritingsFor(floor),
 		InscriptionCount: 2 + rng.Intn(4), // 2–5 per floor
+		RuneCount:        assets.RuneCount(floor),
+		PuzzleGlyphs:     assets.PuzzleFor(floor).Glyphs,
+		PuzzleHint:       assets.PuzzleFor(floor).Hint,
+		VaultGold:        assets.PuzzleFor(floor).Gold,
 		EliteEnemy:       assets.EliteEnemy(floor),
 		CommonFurniture:  assets.FurnitureFor(floor).Common,
 		RareFurniture:    assets.FurnitureFor(floor).Rare,
 		FurniturePerRoom: 2, // 1–2 pieces per room
+		GoldPileCount:    fc.GoldPiles,
+		GoldPileMax:      fc.GoldPileMax,
+		VendingChance:    fc.VendingChance,
+		AltarChance:      assets.AltarChance,
+		AltarOfferings:   assets.AltarOfferings,
+		Infighting:       assets.Infighting(floor),
+		Affix:            generate.RollAffix(fc.AffixChance, rng),
+		ChuteChance:      assets.ChuteChance(floor),
+		ChuteWarning:     assets.ChuteWarning,
+		SanctuaryChance:  assets.SanctuaryChance(floor),
 		Rand:             rng,
 	}
+	if fc.Dark {
+		cfg.Affix = gamemap.AffixDarkness
+	}
+	return cfg
+}
+
+// ascendConfig hardens cfg for an NG+ run at level ngPlus: every enemy,
+// the floor elite included, hits harder and lasts longer, and more of them
+// spawn. Level 0 leaves cfg unchanged.
+func ascendConfig(cfg *generate.Config, ngPlus int) {
+	if ngPlus <= 0 {
+		return
+	}
+	table := make([]generate.EnemySpawnEntry, len(cfg.EnemyTable))
+	for i, e := range cfg.EnemyTable {
+		table[i] = ascendEnemy(e, ngPlus)
+	}
+	cfg.EnemyTable = table
+	if cfg.EliteEnemy != nil {
+		elite := ascendEnemy(*cfg.EliteEnemy, ngPlus)
+		cfg.EliteEnemy = &elite
+	}
+	cfg.EnemyBudget += cfg.EnemyBudget * ngPlusBudgetPct * ngPlus / 100
+}
+
+// ascendEnemy returns e with its ATK and HP scaled for NG+ level ngPlus.
+func ascendEnemy(e generate.EnemySpawnEntry, ngPlus int) generate.EnemySpawnEntry {
+	pct := 100 + ngPlusStatPct*ngPlus
+	e.Attack = e.Attack * pct / 100
+	e.MaxHP = e.MaxHP * pct / 100
+	return e
 }
 
 // itemTableForFloor returns the consumable item table for a given floor,
@@ -48,11 +97,21 @@ func itemTableForFloor(floor int) []generate.ItemSpawnEntry {
 	base := assets.ItemTable(floor)
 	var extra []generate.ItemSpawnEntry
 
+	if floor >= 2 {
+		extra = append(extra, generate.ItemSpawnEntry{Glyph: assets.GlyphSnareKit, Name: "Snare Kit"})
+		extra = append(extra, generate.ItemSpawnEntry{Glyph: assets.GlyphBindingWand, Name: "Binding Wand"})
+		extra = append(extra, generate.ItemSpawnEntry{Glyph: assets.GlyphDecoyDoll, Name: "Decoy Doll"})
+	}
 	if floor >= 3 {
 		extra = append(extra, generate.ItemSpawnEntry{Glyph: assets.GlyphResonanceBurst, Name: "Resonance Burst"})
+		extra = append(extra, generate.ItemSpawnEntry{Glyph: assets.GlyphCaltropPouch, Name: "Caltrop Pouch"})
+	}
+	if floor >= 4 {
+		extra = append(extra, generate.ItemSpawnEntry{Glyph: assets.GlyphTempoTonic, Name: "Tempo Tonic"})
+		extra = append(extra, generate.ItemSpawnEntry{Glyph: assets.GlyphLightningWand, Name: "Lightning Wand"})
 	}
 	if floor >= 5 {
-		extra = append(extra, generate.ItemSpawnEntry{Glyph: assets.GlyphNanoSyringe, Name: "Nano-Syringe"})
+		extra = append(extra, generate.ItemSpawnEntry{Glyph: assets.GlyphNanoSyringe, Name: "Nano-Syringe", Heals: true})
 	}
 	if floor >= 6 {
 		extra = append(extra, generate.ItemSpawnEntry{Glyph: assets.GlyphPhaseRod, Name: "Phase Rod"})
@@ -69,7 +128,3 @@ func itemTableForFloor(floor int) []generate.ItemSpawnEntry {
 	copy(combined[len(base):], extra)
 	return combined
 }
-
-func lerpi(a, b int, t float64) int {
-	return int(math.Round(float64(a) + t*float64(b-a)))
-}
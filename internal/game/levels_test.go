@@ -0,0 +1,30 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/gamemap"
+)
+
+func TestLevelConfigReadsFloorConfigs(t *testing.T) {
+	for floor := 1; floor <= MaxFloors; floor++ {
+		fc := assets.FloorConfigs[floor]
+		cfg := levelConfig(floor, rand.New(rand.NewSource(1)))
+		if cfg.MapWidth != fc.Width || cfg.MapHeight != fc.Height || cfg.MaxLeafSize != fc.MaxLeafSize ||
+			cfg.EnemyBudget != fc.EnemyBudget || cfg.ItemCount != fc.ItemCount || cfg.GoldPileMax != fc.GoldPileMax {
+			t.Errorf("floor %d config does not match its FloorConfigs entry %+v", floor, fc)
+		}
+	}
+}
+
+func TestLevelConfigDarkFloor(t *testing.T) {
+	saved := assets.FloorConfigs[2]
+	defer func() { assets.FloorConfigs[2] = saved }()
+	assets.FloorConfigs[2].AffixChance = 0
+	assets.FloorConfigs[2].Dark = true
+	if cfg := levelConfig(2, rand.New(rand.NewSource(1))); cfg.Affix != gamemap.AffixDarkness {
+		t.Errorf("dark floor rolled affix %s; want Darkness", cfg.Affix.Name())
+	}
+}
@@ -3,6 +3,7 @@ package game
 import (
 	"emoji-roguelike/assets"
 	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/system"
 	"fmt"
 	"math/rand"
 
@@ -115,13 +116,20 @@ func (g *Game) effectiveFOVRadius() int {
 	return g.fovRadius + g.skillBonusFOV
 }
 
-// effectiveCooldown returns the class ability cooldown minus skill reductions (min 1).
+// updateFOV recomputes the player's field of view with the algorithm chosen
+// in the settings.
+func (g *Game) updateFOV() {
+	system.UpdateFOVWith(g.world, g.gmap, g.playerID, g.effectiveFOVRadius(), g.profile.FOV)
+}
+
+// effectiveCooldown returns the class ability cooldown minus skill reductions
+// and equipment CDR (min 1).
 func (g *Game) effectiveCooldown() int {
 	cd := g.selectedClass.AbilityCooldown - g.computeSkillBonuses().CooldownReduce
 	if cd < 1 && g.selectedClass.AbilityCooldown > 0 {
 		cd = 1
 	}
-	return cd
+	return system.ApplyCDR(cd, system.GetCDRPercent(g.world, g.playerID))
 }
 
 // effectiveRegenInterval returns the passive regen interval minus skill reductions (min 1).
@@ -164,7 +172,7 @@ func (g *Game) runLevelUpScreen() bool {
 	}
 
 	// Pick 3 random skills to offer.
-	offered := pickNSkills(available, 3, g.rng)
+	offered := pickNSkills(available, 3, g.combatRng)
 
 	selected := 0
 	for {
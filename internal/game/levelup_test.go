@@ -17,8 +17,9 @@ func newLevelTestGame(t *testing.T, classID string) *Game {
 		t.Fatalf("SimulationScreen.Init: %v", err)
 	}
 	g := &Game{
-		screen: ss,
-		rng:    rand.New(rand.NewSource(42)),
+		screen:    ss,
+		genRng:    rand.New(rand.NewSource(42)),
+		combatRng: rand.New(rand.NewSource(42)),
 	}
 	g.resetForRun()
 	for _, c := range assets.Classes {
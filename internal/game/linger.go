@@ -0,0 +1,38 @@
+package game
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/system"
+)
+
+// Lingering: every LingerTurns turns a player spends on one floor, the floor
+// speaks a lore line (see assets.LingerLine), and for the first
+// LingerMaxStir of those its enemies grow more alert (see system.Stir),
+// nudging the player onward.
+const (
+	LingerTurns   = 150
+	LingerMaxStir = 3
+)
+
+// StirMessage tells players a lingering floor's enemies have grown more
+// alert.
+const StirMessage = "You have lingered too long. The floor's denizens grow restless."
+
+// noteLinger speaks a lore line, and stirs the floor's enemies, each time
+// the player's turns on floor pass another multiple of LingerTurns. before
+// is the floor's turn count ahead of this action.
+func (g *Game) noteLinger(floor, before int) {
+	if g.floor != floor || g.tutorial != nil {
+		return
+	}
+	stage := g.runLog.floorTurns(floor) / LingerTurns
+	if stage <= before/LingerTurns {
+		return
+	}
+	if line := assets.LingerLine(floor, stage); line != "" {
+		g.addMessage(line)
+	}
+	if stage <= LingerMaxStir && system.Stir(g.world) > 0 {
+		g.addMessage(StirMessage)
+	}
+}
@@ -0,0 +1,61 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+func TestLingeringSpeaksLoreAndStirsEnemies(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	sight := map[ecs.EntityID]int{}
+	for _, id := range g.world.Query(component.CAI) {
+		sight[id] = g.world.Get(id, component.CAI).(component.AI).SightRange
+	}
+	if len(sight) == 0 {
+		t.Fatal("test floor has no enemies")
+	}
+
+	g.runLog.addFloorTurns(g.floor, LingerTurns-1)
+	g.noteLinger(g.floor, LingerTurns-2)
+	if slices.Contains(g.messages, StirMessage) {
+		t.Fatal("floor stirred before the player lingered LingerTurns turns")
+	}
+
+	g.runLog.addFloorTurns(g.floor, 1)
+	g.noteLinger(g.floor, LingerTurns-1)
+	if line := assets.LingerLine(g.floor, 1); !slices.Contains(g.messages, line) {
+		t.Errorf("messages %q; want the linger line %q", g.messages, line)
+	}
+	if !slices.Contains(g.messages, StirMessage) {
+		t.Errorf("messages %q; want the stir warning", g.messages)
+	}
+	for _, id := range g.world.Query(component.CAI) {
+		if got := g.world.Get(id, component.CAI).(component.AI).SightRange; got != sight[id]+1 {
+			t.Errorf("enemy %d sight = %d; want %d", id, got, sight[id]+1)
+		}
+	}
+}
+
+func TestLingeringStopsStirringAfterMaxStages(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	turns := (LingerMaxStir + 1) * LingerTurns
+	g.runLog.addFloorTurns(g.floor, turns)
+	g.noteLinger(g.floor, turns-1)
+	if slices.Contains(g.messages, StirMessage) {
+		t.Errorf("floor stirred at stage %d; stirring should stop after %d", LingerMaxStir+1, LingerMaxStir)
+	}
+}
+
+func TestLingerLineCyclesFloorLore(t *testing.T) {
+	if got, want := assets.LingerLine(9, 3), assets.LingerLore[9][2]; got != want {
+		t.Errorf("LingerLine(9, 3) = %q; want %q", got, want)
+	}
+	snippets := assets.FloorLoreSnippets(1)
+	if got, want := assets.LingerLine(1, len(snippets)+1), snippets[0]; got != want {
+		t.Errorf("LingerLine(1, %d) = %q; want it to wrap to %q", len(snippets)+1, got, want)
+	}
+}
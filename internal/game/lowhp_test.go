@@ -0,0 +1,54 @@
+package game
+
+import (
+	"testing"
+
+	"emoji-roguelike/internal/component"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// hpReadoutColor draws the play screen and returns the foreground colour of
+// the HUD's "HP:" readout.
+func hpReadoutColor(t *testing.T, g *Game) tcell.Color {
+	t.Helper()
+	g.drawPlay()
+	cells, _, _ := g.screen.(tcell.SimulationScreen).GetContents()
+	for i := range len(cells) - 2 {
+		if string(cells[i].Runes)+string(cells[i+1].Runes)+string(cells[i+2].Runes) == "HP:" {
+			fg, _, _ := cells[i].Style.Decompose()
+			return fg
+		}
+	}
+	t.Fatal("no HP readout on the HUD")
+	return tcell.ColorDefault
+}
+
+func setPlayerHP(g *Game, current int) {
+	hp := g.world.Get(g.playerID, component.CHealth).(component.Health)
+	hp.Current = current
+	g.world.Add(g.playerID, hp)
+}
+
+func TestLowHPWarnsUntilHealed(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	if fg := hpReadoutColor(t, g); fg != tcell.ColorWhite {
+		t.Errorf("healthy HP readout colour = %v; want white", fg)
+	}
+	if cornerBackground(g) == tcell.ColorMaroon {
+		t.Error("healthy player's map edge should not be tinted")
+	}
+
+	setPlayerHP(g, 1)
+	if fg := hpReadoutColor(t, g); fg != tcell.ColorRed && fg != tcell.ColorDarkRed {
+		t.Errorf("low HP readout colour = %v; want a red pulse", fg)
+	}
+	if cornerBackground(g) != tcell.ColorMaroon {
+		t.Error("low HP should tint the map edge")
+	}
+
+	g.restorePlayerHP(1000)
+	if fg := hpReadoutColor(t, g); fg != tcell.ColorWhite {
+		t.Errorf("HP readout colour after healing = %v; want white again", fg)
+	}
+}
@@ -0,0 +1,55 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// defaultMessageLimit is how many recent messages are kept in memory for the
+// HUD when no -messages limit is given.
+const defaultMessageLimit = 50
+
+// SetMessageLimit caps how many recent messages are kept in memory. Values
+// below 1 restore defaultMessageLimit.
+func (g *Game) SetMessageLimit(n int) {
+	g.messageLimit = max(n, 0)
+}
+
+// SetMessageLog turns on saving each run's full message log to disk. The
+// file's path is recorded in RunLog.MessageLogPath. Call before Run.
+func (g *Game) SetMessageLog(on bool) {
+	g.keepMessageLog = on
+}
+
+// capMessages drops the oldest messages beyond limit, or beyond
+// defaultMessageLimit when limit is 0.
+func capMessages(msgs []string, limit int) []string {
+	if limit == 0 {
+		limit = defaultMessageLimit
+	}
+	if len(msgs) > limit {
+		return msgs[len(msgs)-limit:]
+	}
+	return msgs
+}
+
+// saveMessageLog writes lines, one per line, to a file named after the run's
+// timestamp in the logs directory next to runs.jsonl, and returns its path.
+// Like saveRunLog it is best-effort: on any error it returns "".
+func saveMessageLog(ts time.Time, lines []string) string {
+	dir, err := runLogDir()
+	if err != nil {
+		return ""
+	}
+	dir = filepath.Join(dir, "logs")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return ""
+	}
+	path := filepath.Join(dir, ts.Format("20060102-150405")+".txt")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		return ""
+	}
+	return path
+}
@@ -0,0 +1,71 @@
+package game
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMessageLimitCapsHUDLog(t *testing.T) {
+	g := newAbilityTestGame(t, "arcanist")
+	g.SetMessageLimit(3)
+	for i := range 5 {
+		g.addMessage(fmt.Sprintf("msg %d", i))
+	}
+	if got := strings.Join(g.messages, ","); got != "msg 2,msg 3,msg 4" {
+		t.Errorf("messages = %q; want the last three", got)
+	}
+
+	g.SetMessageLimit(0)
+	for i := range defaultMessageLimit + 5 {
+		g.addMessage(fmt.Sprintf("msg %d", i))
+	}
+	if len(g.messages) != defaultMessageLimit {
+		t.Errorf("len(messages) = %d; want the default %d", len(g.messages), defaultMessageLimit)
+	}
+}
+
+func TestMessageLogSavesFullNarrative(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	g := newAbilityTestGame(t, "arcanist")
+	g.SetMessageLimit(2)
+	g.SetMessageLog(true)
+	for i := range 5 {
+		g.addMessage(fmt.Sprintf("msg %d", i))
+	}
+	g.finalizeRunLog()
+
+	path := g.runLog.MessageLogPath
+	if path == "" {
+		t.Fatal("MessageLogPath not set")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read message log: %v", err)
+	}
+	if !strings.Contains(string(data), "msg 0\n") || !strings.Contains(string(data), "msg 4\n") {
+		t.Errorf("message log = %q; want every message, not just the HUD's last two", data)
+	}
+
+	g.resetForRun()
+	if len(g.narrative) != 0 {
+		t.Errorf("narrative carried into the next run: %v", g.narrative)
+	}
+}
+
+func TestMessageLogOffWritesNothing(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmp)
+	g := newAbilityTestGame(t, "arcanist")
+	g.addMessage("hello")
+	g.finalizeRunLog()
+
+	if g.runLog.MessageLogPath != "" {
+		t.Errorf("MessageLogPath = %q; want none without SetMessageLog", g.runLog.MessageLogPath)
+	}
+	if _, err := os.Stat(filepath.Join(tmp, "emoji-roguelike", "logs")); !os.IsNotExist(err) {
+		t.Errorf("logs directory created with the message log off")
+	}
+}
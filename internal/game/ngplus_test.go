@@ -0,0 +1,60 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestAscendConfigToughensEnemies(t *testing.T) {
+	base := levelConfig(5, rand.New(rand.NewSource(1)))
+	cfg := levelConfig(5, rand.New(rand.NewSource(1)))
+	ascendConfig(cfg, 2)
+
+	for i, e := range cfg.EnemyTable {
+		b := base.EnemyTable[i]
+		if e.Attack != b.Attack*150/100 || e.MaxHP != b.MaxHP*150/100 {
+			t.Errorf("%s at NG+2: ATK %d HP %d; want %d and %d",
+				e.Name, e.Attack, e.MaxHP, b.Attack*150/100, b.MaxHP*150/100)
+		}
+	}
+	if cfg.EliteEnemy != nil && cfg.EliteEnemy.MaxHP <= base.EliteEnemy.MaxHP {
+		t.Error("the floor elite should be scaled too")
+	}
+	if cfg.EnemyBudget <= base.EnemyBudget {
+		t.Errorf("enemy budget %d; want more than %d", cfg.EnemyBudget, base.EnemyBudget)
+	}
+
+	again := levelConfig(5, rand.New(rand.NewSource(1)))
+	if again.EnemyTable[0].Attack != base.EnemyTable[0].Attack {
+		t.Error("ascendConfig must not change the shared enemy table")
+	}
+}
+
+func TestAscendConfigLeavesNormalRunsAlone(t *testing.T) {
+	base := levelConfig(3, rand.New(rand.NewSource(1)))
+	cfg := levelConfig(3, rand.New(rand.NewSource(1)))
+	ascendConfig(cfg, 0)
+	if cfg.EnemyBudget != base.EnemyBudget || cfg.EnemyTable[0].MaxHP != base.EnemyTable[0].MaxHP {
+		t.Error("NG+0 should leave the config unchanged")
+	}
+}
+
+func TestAscendingRunKeepsFurnitureUpgrades(t *testing.T) {
+	g := newAbilityTestGame(t, "arcanist")
+	g.furnitureATK, g.furnitureDEF, g.furnitureThorns = 3, 2, 1
+
+	g.ngPlus = 1
+	g.resetForRun()
+	if g.furnitureATK != 3 || g.furnitureDEF != 2 || g.furnitureThorns != 1 {
+		t.Errorf("NG+ run lost its upgrades: ATK %d DEF %d thorns %d", g.furnitureATK, g.furnitureDEF, g.furnitureThorns)
+	}
+	if g.runLog.NGPlus != 1 {
+		t.Errorf("RunLog.NGPlus = %d; want 1", g.runLog.NGPlus)
+	}
+
+	g.ngPlus = 0
+	g.resetForRun()
+	if g.furnitureATK != 0 || g.furnitureDEF != 0 || g.furnitureThorns != 0 {
+		t.Error("a fresh run should start without furniture upgrades")
+	}
+}
@@ -0,0 +1,314 @@
+package game
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/system"
+	"fmt"
+	"slices"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Pause menu entries, in display order.
+const (
+	pauseResume = iota
+	pauseSettings
+	pauseStats
+	pauseSaveQuit
+	pauseQuit
+)
+
+// pauseItems lists the pause menu entries. In the tutorial, Save & Quit is
+// replaced by Skip Tutorial.
+func (g *Game) pauseItems() []string {
+	leave := "Save & Quit"
+	if g.tutorial != nil {
+		leave = "Skip Tutorial"
+	}
+	return []string{"Resume", "Settings", "View Stats", leave, "Quit"}
+}
+
+// runPauseMenu opens the pause menu over the paused game and blocks until the
+// player resumes or leaves. Returns true if the game should exit.
+func (g *Game) runPauseMenu() bool {
+	cursor := 0
+	for {
+		choice, ok := g.runMenu(" Paused ", g.pauseItems, &cursor)
+		if !ok {
+			return false
+		}
+		switch choice {
+		case pauseResume:
+			return false
+		case pauseSettings:
+			g.runSettingsMenu()
+		case pauseStats:
+			g.runStatsScreen()
+		case pauseSaveQuit:
+			if g.tutorial != nil {
+				g.finishTutorial()
+				return false
+			}
+			g.saveAndQuit()
+			return true
+		case pauseQuit:
+			if g.confirmQuit(g.drawPlay) {
+				return true
+			}
+		}
+	}
+}
+
+// settingsItems lists the settings menu entries with their current values.
+// New toggles are added here and handled in runSettingsMenu.
+func (g *Game) settingsItems() []string {
+	return []string{
+		"Heavy-hit flash: " + onOff(!g.hitFlashOff),
+		"Autopickup: " + g.profile.Autopickup.String(),
+		"Confirm risky moves: " + onOff(!g.profile.RiskConfirmOff),
+		"Threat tint on enemies: " + onOff(g.profile.ThreatTint),
+		"Enemy density (new floors): " + g.densityLabel(),
+		"Camera: " + cameraLabel(g.profile.CameraEdgeScroll),
+		"Line-drawn walls: " + onOff(g.profile.LineWalls),
+		"ASCII map (no emoji): " + onOff(g.asciiFlag || g.profile.ASCII),
+		"Terminal bell: " + onOff(g.profile.Bell),
+		"Field of view: " + g.profile.FOV.String(),
+		"Controls",
+	}
+}
+
+// runSettingsMenu shows the settings submenu until the player backs out.
+func (g *Game) runSettingsMenu() {
+	cursor := 0
+	for {
+		choice, ok := g.runMenu(" Settings ", g.settingsItems, &cursor)
+		if !ok {
+			return
+		}
+		switch choice {
+		case 0:
+			g.hitFlashOff = !g.hitFlashOff
+		case 1:
+			g.profile.Autopickup = g.profile.Autopickup.next()
+			saveProfile(g.profile)
+		case 2:
+			g.profile.RiskConfirmOff = !g.profile.RiskConfirmOff
+			saveProfile(g.profile)
+		case 3:
+			g.profile.ThreatTint = !g.profile.ThreatTint
+			saveProfile(g.profile)
+		case 4:
+			g.cycleEnemyDensity()
+		case 5:
+			g.profile.CameraEdgeScroll = !g.profile.CameraEdgeScroll
+			saveProfile(g.profile)
+		case 6:
+			g.profile.LineWalls = !g.profile.LineWalls
+			saveProfile(g.profile)
+		case 7:
+			g.profile.ASCII = !(g.asciiFlag || g.profile.ASCII)
+			g.asciiFlag = false
+			saveProfile(g.profile)
+		case 8:
+			g.profile.Bell = !g.profile.Bell
+			saveProfile(g.profile)
+		case 9:
+			if g.profile.FOV == system.FOVSymmetric {
+				g.profile.FOV = system.FOVShadowcast
+			} else {
+				g.profile.FOV = system.FOVSymmetric
+			}
+			saveProfile(g.profile)
+			if g.world != nil {
+				g.updateFOV()
+			}
+		case 10:
+			g.runHelpScreen()
+		}
+	}
+}
+
+// runStatsScreen shows the current run's statistics. Any key dismisses it.
+func (g *Game) runStatsScreen() {
+	kills, items := 0, 0
+	for _, n := range g.runLog.EnemiesKilled {
+		kills += n
+	}
+	for _, n := range g.runLog.ItemsUsed {
+		items += n
+	}
+	lines := []string{
+		fmt.Sprintf("Class:          %s", g.selectedClass.Name),
+		fmt.Sprintf("Level:          %d (%d XP)", g.playerLevel, g.playerXP),
+		fmt.Sprintf("Floor:          %d — %s", g.floor, assets.FloorName(g.floor)),
+		fmt.Sprintf("Turns:          %d", g.runLog.TurnsPlayed),
+		fmt.Sprintf("Enemies Slain:  %d", kills),
+		fmt.Sprintf("Items Used:     %d", items),
+		fmt.Sprintf("Damage Dealt:   %d", g.runLog.DamageDealt),
+		fmt.Sprintf("Damage Taken:   %d", g.runLog.DamageTaken),
+		fmt.Sprintf("Gold:           %d (%d earned)", g.gold, g.runLog.GoldEarned),
+		fmt.Sprintf("Floors Cleared: %d", g.runLog.FloorsCleared),
+		"",
+		"[any key to close]",
+	}
+	for {
+		g.drawPlay()
+		g.drawOverlayBox(" Run Stats ", lines, -1)
+		g.screen.Show()
+		switch g.screen.PollEvent().(type) {
+		case *tcell.EventResize:
+			g.screen.Sync()
+		case *tcell.EventKey:
+			return
+		}
+	}
+}
+
+// saveAndQuit records the unfinished run in the run history.
+func (g *Game) saveAndQuit() {
+	g.runLog.Abandoned = true
+	g.runLog.CauseOfDeath = ""
+	g.finalizeRunLog()
+	saveRunLog(g.runLog)
+}
+
+// runMenu shows a list of entries over the paused game and blocks until the
+// player picks one (Enter or its number) or backs out (Esc / q). items is
+// re-read every frame so entries can show live values; *cursor persists the
+// highlighted entry between calls.
+func (g *Game) runMenu(title string, items func() []string, cursor *int) (int, bool) {
+	for {
+		entries := items()
+		*cursor = max(0, min(*cursor, len(entries)-1))
+		lines := make([]string, len(entries))
+		for i, e := range entries {
+			lines[i] = fmt.Sprintf("%d. %s", i+1, e)
+		}
+		g.drawPlay()
+		g.drawOverlayBox(title, lines, *cursor)
+		g.screen.Show()
+
+		ev, ok := g.screen.PollEvent().(*tcell.EventKey)
+		if !ok {
+			g.screen.Sync()
+			continue
+		}
+		switch ev.Key() {
+		case tcell.KeyEscape:
+			return 0, false
+		case tcell.KeyEnter:
+			return *cursor, true
+		case tcell.KeyUp:
+			*cursor = (*cursor + len(entries) - 1) % len(entries)
+		case tcell.KeyDown:
+			*cursor = (*cursor + 1) % len(entries)
+		case tcell.KeyRune:
+			switch r := ev.Rune(); {
+			case r == 'k' || r == 'K':
+				*cursor = (*cursor + len(entries) - 1) % len(entries)
+			case r == 'j' || r == 'J':
+				*cursor = (*cursor + 1) % len(entries)
+			case r == 'q' || r == 'Q':
+				return 0, false
+			case r >= '1' && int(r-'1') < len(entries):
+				*cursor = int(r - '1')
+				return *cursor, true
+			}
+		}
+	}
+}
+
+// drawOverlayBox draws a bordered box centred on screen holding lines, with
+// line highlight (or none if negative) shown in reverse video.
+func (g *Game) drawOverlayBox(header string, lines []string, highlight int) {
+	width := len([]rune(header)) + 4
+	for _, l := range lines {
+		width = max(width, len([]rune(l))+6)
+	}
+	hdrStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true)
+	bodyStyle := tcell.StyleDefault.Foreground(tcell.ColorSilver)
+	borderStyle := tcell.StyleDefault.Foreground(tcell.ColorGray)
+
+	sw, sh := g.screen.Size()
+	boxH := len(lines) + 2
+	x0 := (sw - width) / 2
+	y0 := (sh - boxH) / 2
+	for row := y0; row < y0+boxH; row++ {
+		for col := x0; col < x0+width; col++ {
+			g.screen.SetContent(col, row, ' ', nil, tcell.StyleDefault)
+		}
+	}
+	for col := x0; col < x0+width; col++ {
+		g.screen.SetContent(col, y0, '─', nil, borderStyle)
+		g.screen.SetContent(col, y0+boxH-1, '─', nil, borderStyle)
+	}
+	for row := y0; row < y0+boxH; row++ {
+		g.screen.SetContent(x0, row, '│', nil, borderStyle)
+		g.screen.SetContent(x0+width-1, row, '│', nil, borderStyle)
+	}
+	g.screen.SetContent(x0, y0, '┌', nil, borderStyle)
+	g.screen.SetContent(x0+width-1, y0, '┐', nil, borderStyle)
+	g.screen.SetContent(x0, y0+boxH-1, '└', nil, borderStyle)
+	g.screen.SetContent(x0+width-1, y0+boxH-1, '┘', nil, borderStyle)
+	g.putText(x0+(width-len([]rune(header)))/2, y0, header, hdrStyle)
+
+	for i, line := range lines {
+		style := bodyStyle
+		if i == highlight {
+			style = style.Reverse(true)
+		}
+		g.putText(x0+3, y0+1+i, line, style)
+	}
+}
+
+// densitySteps are the enemy densities the settings menu cycles through. The
+// sandbox follows the last step and wraps back to the first.
+var densitySteps = []float64{1, 1.5, 2, 0.5}
+
+// cycleEnemyDensity moves to the next setting after the current density. A
+// density set by the -density flag that is not one of densitySteps cycles
+// back to normal.
+func (g *Game) cycleEnemyDensity() {
+	if g.sandbox {
+		g.sandbox, g.enemyDensity = false, densitySteps[0]
+		return
+	}
+	i := slices.Index(densitySteps, g.normalDensity())
+	if i == len(densitySteps)-1 {
+		g.sandbox = true
+		return
+	}
+	g.enemyDensity = densitySteps[i+1]
+}
+
+// normalDensity returns the enemy density with the unset 0 read as 1.
+func (g *Game) normalDensity() float64 {
+	if g.enemyDensity <= 0 {
+		return 1
+	}
+	return g.enemyDensity
+}
+
+// densityLabel renders the enemy density for the settings menu.
+func (g *Game) densityLabel() string {
+	if g.sandbox {
+		return "None (sandbox)"
+	}
+	return fmt.Sprintf("%g×", g.normalDensity())
+}
+
+// onOff renders a boolean setting.
+func onOff(on bool) string {
+	if on {
+		return "On"
+	}
+	return "Off"
+}
+
+// cameraLabel names the camera setting.
+func cameraLabel(edgeScroll bool) string {
+	if edgeScroll {
+		return "Edge scroll"
+	}
+	return "Follow"
+}
@@ -0,0 +1,217 @@
+package game
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/system"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPauseMenuResume(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	injectKeys(g, tcell.KeyEnter)
+	if g.runPauseMenu() {
+		t.Error("Resume should return to the game")
+	}
+
+	injectKeys(g, tcell.KeyEscape)
+	if g.runPauseMenu() {
+		t.Error("Esc should close the pause menu without quitting")
+	}
+}
+
+func TestPauseMenuSettingsTogglesFlash(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	ss := g.screen.(tcell.SimulationScreen)
+	ss.InjectKey(tcell.KeyRune, '2', tcell.ModNone) // Settings
+	ss.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)  // Heavy-hit flash
+	ss.InjectKey(tcell.KeyEscape, 0, tcell.ModNone) // back
+	ss.InjectKey(tcell.KeyEscape, 0, tcell.ModNone) // resume
+	if g.runPauseMenu() {
+		t.Fatal("backing out of settings should not quit")
+	}
+	if !g.hitFlashOff {
+		t.Error("settings toggle should turn the heavy-hit flash off")
+	}
+}
+
+func TestPauseMenuStatsReturnsToMenu(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	ss := g.screen.(tcell.SimulationScreen)
+	ss.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	ss.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	ss.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)  // View Stats
+	ss.InjectKey(tcell.KeyRune, 'x', tcell.ModNone) // close
+	ss.InjectKey(tcell.KeyEscape, 0, tcell.ModNone) // resume
+	if g.runPauseMenu() {
+		t.Error("closing the stats screen should return to the menu, not quit")
+	}
+}
+
+func TestPauseMenuSaveAndQuitRecordsRun(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmp)
+	g := newAbilityTestGame(t, "warden")
+	g.screen.(tcell.SimulationScreen).InjectKey(tcell.KeyRune, '4', tcell.ModNone)
+	if !g.runPauseMenu() {
+		t.Fatal("Save & Quit should exit the game")
+	}
+	data, err := os.ReadFile(filepath.Join(tmp, "emoji-roguelike", "runs.jsonl"))
+	if err != nil {
+		t.Fatalf("runs.jsonl not written: %v", err)
+	}
+	if !strings.Contains(string(data), `"abandoned":true`) {
+		t.Errorf("saved run should be marked abandoned; got %q", data)
+	}
+}
+
+func TestPauseMenuQuitNeedsConfirmation(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	ss := g.screen.(tcell.SimulationScreen)
+	ss.InjectKey(tcell.KeyRune, '5', tcell.ModNone)
+	ss.InjectKey(tcell.KeyRune, 'n', tcell.ModNone) // decline
+	ss.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	if g.runPauseMenu() {
+		t.Error("declining the quit confirmation should keep playing")
+	}
+
+	ss.InjectKey(tcell.KeyRune, '5', tcell.ModNone)
+	ss.InjectKey(tcell.KeyRune, 'y', tcell.ModNone)
+	if !g.runPauseMenu() {
+		t.Error("confirming quit should exit the game")
+	}
+}
+
+func TestPauseMenuSettingsCyclesAutopickup(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmp)
+	g := newAbilityTestGame(t, "warden")
+	ss := g.screen.(tcell.SimulationScreen)
+	ss.InjectKey(tcell.KeyRune, '2', tcell.ModNone) // Settings
+	ss.InjectKey(tcell.KeyRune, '2', tcell.ModNone) // Autopickup: Gold only → All
+	ss.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	ss.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	g.runPauseMenu()
+	if g.profile.Autopickup != autopickupAll {
+		t.Errorf("autopickup = %v; want All", g.profile.Autopickup)
+	}
+	if loadProfile().Autopickup != autopickupAll {
+		t.Error("autopickup setting should be saved to the profile")
+	}
+}
+
+func TestSettingsCyclesEnemyDensity(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	ss := g.screen.(tcell.SimulationScreen)
+	ss.InjectKey(tcell.KeyRune, '5', tcell.ModNone) // Enemy density: 1× → 1.5×
+	ss.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	g.runSettingsMenu()
+	if g.enemyDensity != 1.5 {
+		t.Errorf("enemy density = %g; want 1.5", g.enemyDensity)
+	}
+	g.cycleEnemyDensity() // 2×
+	g.cycleEnemyDensity() // 0.5×
+	g.cycleEnemyDensity()
+	if !g.sandbox {
+		t.Errorf("density cycle should reach the sandbox after 0.5×; got %s", g.densityLabel())
+	}
+	g.cycleEnemyDensity()
+	if g.sandbox || g.normalDensity() != 1 {
+		t.Errorf("density cycle should wrap from the sandbox to normal; got %s", g.densityLabel())
+	}
+	g.SetEnemyDensity(0.7)
+	if g.cycleEnemyDensity(); g.enemyDensity != 1 {
+		t.Errorf("a flag-set density should cycle back to normal; got %g", g.enemyDensity)
+	}
+}
+
+func TestSettingsLineWallsDrawsBoxLines(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	g := newAbilityTestGame(t, "warden")
+	ss := g.screen.(tcell.SimulationScreen)
+	ss.InjectKey(tcell.KeyRune, '7', tcell.ModNone) // Line-drawn walls: Off → On
+	ss.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	g.runSettingsMenu()
+	if !g.profile.LineWalls {
+		t.Fatal("settings toggle should turn line-drawn walls on")
+	}
+
+	g.drawPlay()
+	cells, w, h := ss.GetContents()
+	var lines int
+	for i, c := range cells {
+		// The bottom five rows are the HUD, whose divider is also a line.
+		if i/w < h-5 && len(c.Runes) > 0 && strings.ContainsRune("│─┌┐└┘├┤┬┴┼■", c.Runes[0]) {
+			lines++
+		}
+	}
+	if lines == 0 {
+		t.Error("no box-drawing walls drawn with line walls on")
+	}
+}
+
+func TestSettingsASCIIMapDrawsPlayerAsAt(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	g := newAbilityTestGame(t, "warden")
+	ss := g.screen.(tcell.SimulationScreen)
+	ss.InjectKey(tcell.KeyRune, '8', tcell.ModNone) // ASCII map: Off → On
+	ss.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	g.runSettingsMenu()
+	if !loadProfile().ASCII {
+		t.Fatal("ASCII map setting should be saved to the profile")
+	}
+
+	g.drawPlay()
+	pos := g.playerPosition()
+	sx, sy, _ := g.renderer.WorldToScreen(pos.X, pos.Y)
+	if mainc, _, _, _ := ss.GetContent(sx, sy); mainc != '@' {
+		t.Errorf("player drawn as %q in ASCII mode; want '@'", mainc)
+	}
+}
+
+func TestASCIIFlagOverridesProfile(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	g.SetASCII(true)
+	g.drawPlay()
+	pos := g.playerPosition()
+	sx, sy, _ := g.renderer.WorldToScreen(pos.X, pos.Y)
+	ss := g.screen.(tcell.SimulationScreen)
+	if mainc, _, _, _ := ss.GetContent(sx, sy); mainc != '@' {
+		t.Errorf("player drawn as %q with -ascii; want '@'", mainc)
+	}
+	if got := g.settingsItems()[7]; got != "ASCII map (no emoji): On" {
+		t.Errorf("settings row = %q; want the flag shown as On", got)
+	}
+}
+
+func TestSandboxFloorHasNoEnemies(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	g.SetSandbox(true)
+	g.loadFloor(3)
+	for _, id := range g.world.Query(component.CAI) {
+		if g.hostileEnemy(id) {
+			t.Fatalf("sandbox floor spawned %s", g.entityName(id))
+		}
+	}
+}
+
+func TestSettingsSwitchesFOVAlgorithm(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	g := newAbilityTestGame(t, "warden")
+	ss := g.screen.(tcell.SimulationScreen)
+	ss.InjectKey(tcell.KeyUp, 0, tcell.ModNone) // wraps to Controls
+	ss.InjectKey(tcell.KeyUp, 0, tcell.ModNone) // Field of view
+	ss.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	ss.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	g.runSettingsMenu()
+	if loadProfile().FOV != system.FOVSymmetric {
+		t.Fatal("switching the field of view should save symmetric to the profile")
+	}
+	if got := g.settingsItems()[9]; got != "Field of view: Symmetric" {
+		t.Errorf("settings row = %q; want symmetric shown", got)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"emoji-roguelike/assets"
 	"emoji-roguelike/internal/component"
 	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/factory"
 	"emoji-roguelike/internal/system"
 )
 
@@ -145,6 +146,75 @@ func TestTryPickupMissingItemComp(t *testing.T) {
 	}
 }
 
+// ─── autopickup ───────────────────────────────────────────────────────────────
+
+// stepOntoLoot puts a flask and a gold pile one step east of the player on the
+// tutorial floor, sets the autopickup mode and walks onto them.
+func stepOntoLoot(t *testing.T, mode autopickupMode) (*Game, ecs.EntityID) {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	g := newAbilityTestGame(t, "arcanist")
+	g.loadTutorialFloor()
+	g.profile.Autopickup = mode
+	pos := playerPos(g)
+	itemID := placeFloorItem(g, assets.GlyphHyperflask, "Hyperflask", pos.X+1, pos.Y)
+	factory.NewGoldPile(g.world, 7, pos.X+1, pos.Y)
+	g.processAction(ActionMoveE)
+	if playerPos(g).X != pos.X+1 {
+		t.Fatal("player did not step east")
+	}
+	return g, itemID
+}
+
+func TestAutopickupGoldOnlyLeavesItems(t *testing.T) {
+	g, itemID := stepOntoLoot(t, autopickupGold)
+	if g.gold != 7 {
+		t.Errorf("gold = %d; want 7", g.gold)
+	}
+	if !g.world.Alive(itemID) {
+		t.Error("gold-only autopickup should leave items on the floor")
+	}
+}
+
+func TestAutopickupAllTakesItems(t *testing.T) {
+	g, itemID := stepOntoLoot(t, autopickupAll)
+	if g.gold != 7 || g.world.Alive(itemID) {
+		t.Errorf("autopickup all: gold=%d item alive=%v; want gold 7 and item taken", g.gold, g.world.Alive(itemID))
+	}
+}
+
+func TestAutopickupOffLeavesEverything(t *testing.T) {
+	g, itemID := stepOntoLoot(t, autopickupOff)
+	if g.gold != 0 || !g.world.Alive(itemID) {
+		t.Fatalf("autopickup off: gold=%d item alive=%v; want nothing taken", g.gold, g.world.Alive(itemID))
+	}
+	g.tryPickup()
+	if g.gold != 7 || g.world.Alive(itemID) {
+		t.Error("manual pickup should take both the gold and the item")
+	}
+}
+
+func TestAutopickupAllRespectsFullBackpack(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	g := newAbilityTestGame(t, "arcanist")
+	g.loadTutorialFloor()
+	g.profile.Autopickup = autopickupAll
+	inv := g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	for range inv.Capacity {
+		inv.Backpack = append(inv.Backpack, component.Item{Name: "Filler", Glyph: "🧪"})
+	}
+	g.world.Add(g.playerID, inv)
+	pos := playerPos(g)
+	itemID := placeFloorItem(g, assets.GlyphHyperflask, "Hyperflask", pos.X+1, pos.Y)
+	g.processAction(ActionMoveE)
+	if !g.world.Alive(itemID) {
+		t.Error("a full backpack should leave the item on the floor")
+	}
+	if !hasMessage(g, "you leave the Hyperflask") {
+		t.Error("expected a message about leaving the item")
+	}
+}
+
 // ─── applyConsumable ──────────────────────────────────────────────────────────
 
 func TestApplyConsumableTracksItemsUsed(t *testing.T) {
@@ -339,7 +409,7 @@ func TestApplyConsumableTesseractChangesPosition(t *testing.T) {
 	moved := false
 	for seed := int64(0); seed < 10; seed++ {
 		g := newAbilityTestGame(t, "arcanist")
-		g.rng = rand.New(rand.NewSource(seed))
+		g.combatRng = rand.New(rand.NewSource(seed))
 		before := playerPos(g)
 
 		g.applyConsumable(component.Item{Glyph: assets.GlyphTesseract})
@@ -0,0 +1,144 @@
+package game
+
+import (
+	"emoji-roguelike/assets"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Point-buy budget offered after class select.
+const (
+	allocPoints     = 5 // points to spend per run
+	allocCap        = 3 // most points in any one stat
+	allocHPPerPoint = 4 // max HP bought by one point
+)
+
+// StatAllocation records the points a player spent on each stat at the
+// point-buy screen. Every point is worth +1 of its stat, except HP, which is
+// worth allocHPPerPoint max HP.
+type StatAllocation struct {
+	ATK int `json:"atk,omitempty"`
+	DEF int `json:"def,omitempty"`
+	HP  int `json:"hp,omitempty"`
+	FOV int `json:"fov,omitempty"`
+}
+
+// allocRows names the point-buy rows in display order.
+var allocRows = [...]string{"ATK", "DEF", "Max HP", "FOV"}
+
+// slot returns the field counting points for allocRows[row].
+func (a *StatAllocation) slot(row int) *int {
+	return [...]*int{&a.ATK, &a.DEF, &a.HP, &a.FOV}[row]
+}
+
+// spent returns the total points allocated.
+func (a StatAllocation) spent() int {
+	return a.ATK + a.DEF + a.HP + a.FOV
+}
+
+// apply returns a copy of class with the allocation added to its base stats.
+func (a StatAllocation) apply(class assets.ClassDef) assets.ClassDef {
+	class.Attack += a.ATK
+	class.Defense += a.DEF
+	class.MaxHP += a.HP * allocHPPerPoint
+	class.FOVRadius += a.FOV
+	return class
+}
+
+// runStatAllocation shows the point-buy screen for the selected class and
+// blocks until the player confirms with Enter. Escape starts the run with no
+// points spent. The chosen allocation is applied to g.selectedClass and
+// recorded in the run log.
+func (g *Game) runStatAllocation() {
+	var alloc StatAllocation
+	row := 0
+	for {
+		g.drawStatAllocation(alloc, row)
+		ev, ok := g.screen.PollEvent().(*tcell.EventKey)
+		if !ok {
+			g.screen.Sync()
+			continue
+		}
+		add := 0
+		switch ev.Key() {
+		case tcell.KeyEnter:
+			g.applyAllocation(alloc)
+			return
+		case tcell.KeyEscape:
+			g.applyAllocation(StatAllocation{})
+			return
+		case tcell.KeyUp:
+			row = (row + len(allocRows) - 1) % len(allocRows)
+		case tcell.KeyDown:
+			row = (row + 1) % len(allocRows)
+		case tcell.KeyRight:
+			add = 1
+		case tcell.KeyLeft:
+			add = -1
+		case tcell.KeyRune:
+			switch ev.Rune() {
+			case 'k', 'K':
+				row = (row + len(allocRows) - 1) % len(allocRows)
+			case 'j', 'J':
+				row = (row + 1) % len(allocRows)
+			case 'l', 'L', '+', '=':
+				add = 1
+			case 'h', 'H', '-':
+				add = -1
+			case 'r', 'R':
+				alloc = StatAllocation{}
+			}
+		}
+		pts := alloc.slot(row)
+		switch {
+		case add > 0 && *pts < allocCap && alloc.spent() < allocPoints:
+			*pts++
+		case add < 0 && *pts > 0:
+			*pts--
+		}
+	}
+}
+
+// applyAllocation folds alloc into the selected class and logs it.
+func (g *Game) applyAllocation(alloc StatAllocation) {
+	g.selectedClass = alloc.apply(g.selectedClass)
+	g.fovRadius = g.selectedClass.FOVRadius
+	if alloc.spent() > 0 {
+		g.runLog.Allocation = &alloc
+	}
+}
+
+// drawStatAllocation renders the point-buy screen with row highlighted.
+func (g *Game) drawStatAllocation(alloc StatAllocation, row int) {
+	g.screen.Clear()
+	w, _ := g.screen.Size()
+	titleStyle := tcell.StyleDefault.Foreground(tcell.NewRGBColor(180, 100, 255)).Bold(true)
+	normalStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	dimStyle := tcell.StyleDefault.Foreground(tcell.ColorGray)
+	highlightStyle := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.NewRGBColor(180, 100, 255))
+	center := func(y int, text string, style tcell.Style) {
+		drawScreenText(g.screen, max((w-len([]rune(text)))/2, 0), y, text, style)
+	}
+
+	class := g.selectedClass
+	center(1, fmt.Sprintf("%s %s — Shape Your Build", class.Emoji, class.Name), titleStyle)
+	center(2, fmt.Sprintf("Points left: %d of %d (at most %d per stat)", allocPoints-alloc.spent(), allocPoints, allocCap), dimStyle)
+
+	boosted := alloc.apply(class)
+	base := [...]int{class.Attack, class.Defense, class.MaxHP, class.FOVRadius}
+	after := [...]int{boosted.Attack, boosted.Defense, boosted.MaxHP, boosted.FOVRadius}
+	for i, name := range allocRows {
+		prefix, style := "  ", normalStyle
+		if i == row {
+			prefix, style = "► ", highlightStyle
+		}
+		pts := *alloc.slot(i)
+		pips := strings.Repeat("●", pts) + strings.Repeat("·", allocCap-pts)
+		line := fmt.Sprintf("%s%-7s %3d → %-3d  %s", prefix, name, base[i], after[i], pips)
+		center(4+i, line, style)
+	}
+	center(4+len(allocRows)+1, "[↑/↓] Stat   [←/→] Remove/Add   [r] Reset   [Enter] Begin   [Esc] Skip", dimStyle)
+	g.screen.Show()
+}
@@ -0,0 +1,68 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestStatAllocationAppliesToClass(t *testing.T) {
+	g := newAbilityTestGame(t, "arcanist")
+	base := g.selectedClass
+	// Two points in ATK, one in HP, two in FOV.
+	injectKeys(g, tcell.KeyRight, tcell.KeyRight,
+		tcell.KeyDown, tcell.KeyDown, tcell.KeyRight,
+		tcell.KeyDown, tcell.KeyRight, tcell.KeyRight,
+		tcell.KeyEnter)
+	g.runStatAllocation()
+
+	got := g.selectedClass
+	if got.Attack != base.Attack+2 || got.Defense != base.Defense ||
+		got.MaxHP != base.MaxHP+allocHPPerPoint || got.FOVRadius != base.FOVRadius+2 {
+		t.Errorf("stats = ATK %d DEF %d HP %d FOV %d; want %d %d %d %d",
+			got.Attack, got.Defense, got.MaxHP, got.FOVRadius,
+			base.Attack+2, base.Defense, base.MaxHP+allocHPPerPoint, base.FOVRadius+2)
+	}
+	if g.fovRadius != got.FOVRadius {
+		t.Errorf("fovRadius = %d; want %d", g.fovRadius, got.FOVRadius)
+	}
+	want := StatAllocation{ATK: 2, HP: 1, FOV: 2}
+	if g.runLog.Allocation == nil || *g.runLog.Allocation != want {
+		t.Errorf("runLog.Allocation = %v; want %v", g.runLog.Allocation, want)
+	}
+}
+
+func TestStatAllocationRespectsCapAndBudget(t *testing.T) {
+	g := newAbilityTestGame(t, "arcanist")
+	base := g.selectedClass
+	// Four presses on ATK stop at the cap; three on DEF stop when the budget
+	// runs out. (The simulation screen queues only ten events.)
+	injectKeys(g, tcell.KeyRight, tcell.KeyRight, tcell.KeyRight, tcell.KeyRight,
+		tcell.KeyDown, tcell.KeyRight, tcell.KeyRight, tcell.KeyRight,
+		tcell.KeyEnter)
+	g.runStatAllocation()
+
+	if got := g.selectedClass.Attack - base.Attack; got != allocCap {
+		t.Errorf("ATK gained %d; want cap of %d", got, allocCap)
+	}
+	if got := g.selectedClass.Defense - base.Defense; got != allocPoints-allocCap {
+		t.Errorf("DEF gained %d; want the remaining %d points", got, allocPoints-allocCap)
+	}
+}
+
+func TestStatAllocationEscapeSkips(t *testing.T) {
+	g := newAbilityTestGame(t, "arcanist")
+	base := g.selectedClass
+	injectKeys(g, tcell.KeyRight, tcell.KeyRight, tcell.KeyEscape)
+	g.runStatAllocation()
+
+	got := g.selectedClass
+	if got.Attack != base.Attack || got.Defense != base.Defense ||
+		got.MaxHP != base.MaxHP || got.FOVRadius != base.FOVRadius {
+		t.Errorf("Escape changed the class stats to ATK %d DEF %d HP %d FOV %d",
+			got.Attack, got.Defense, got.MaxHP, got.FOVRadius)
+	}
+	if g.runLog.Allocation != nil {
+		t.Errorf("runLog.Allocation = %v; want nil when skipped", g.runLog.Allocation)
+	}
+}
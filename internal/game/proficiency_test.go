@@ -0,0 +1,49 @@
+package game
+
+import (
+	"emoji-roguelike/internal/component"
+	"strings"
+	"testing"
+)
+
+// TestWeaponProficiencySurvivesFloors checks that landing a hit with a weapon
+// counts toward its proficiency and that the count carries to the next floor.
+func TestWeaponProficiencySurvivesFloors(t *testing.T) {
+	g := newAbilityTestGame(t, "arcanist")
+	for _, id := range g.world.Query(component.CAI) {
+		g.world.DestroyEntity(id)
+	}
+	inv := g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	inv.MainHand = component.Item{Name: "Shard Blade", Glyph: "⚔️", Slot: component.SlotOneHand}
+	g.world.Add(g.playerID, inv)
+
+	pos := g.playerPosition()
+	var action Action
+	for _, a := range []Action{ActionMoveE, ActionMoveW, ActionMoveS, ActionMoveN} {
+		if dx, dy := actionToDelta(a); g.gmap.IsWalkable(pos.X+dx, pos.Y+dy) {
+			action = a
+			break
+		}
+	}
+	dx, dy := actionToDelta(action)
+	dummy := g.world.CreateEntity()
+	g.world.Add(dummy, component.Position{X: pos.X + dx, Y: pos.Y + dy})
+	g.world.Add(dummy, component.Combat{Attack: 0, Defense: 0})
+	g.world.Add(dummy, component.Health{Current: 100, Max: 100})
+	g.world.Add(dummy, component.Renderable{Glyph: "🦀"})
+	g.world.Add(dummy, component.TagBlocking{})
+
+	g.processAction(action)
+	if g.weaponHits["Shard Blade"] != 1 {
+		t.Fatalf("Shard Blade hits = %d; want 1", g.weaponHits["Shard Blade"])
+	}
+
+	g.loadFloor(2)
+	pc := g.world.Get(g.playerID, component.CProficiency)
+	if pc == nil || pc.(component.Proficiency).Hits["Shard Blade"] != 1 {
+		t.Errorf("proficiency not carried to floor 2: %v", pc)
+	}
+	if desc := g.describeSelf(); !strings.Contains(desc, "Proficiency 0/3") {
+		t.Errorf("describeSelf() = %q; want the weapon's proficiency", desc)
+	}
+}
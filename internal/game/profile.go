@@ -0,0 +1,100 @@
+package game
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"emoji-roguelike/internal/system"
+)
+
+// Profile holds player preferences that persist across runs.
+type Profile struct {
+	TutorialDone bool           `json:"tutorial_done"` // finished or skipped the tutorial
+	Autopickup   autopickupMode `json:"autopickup,omitempty"`
+	// RiskConfirmOff disables the prompt before moves that could get the
+	// player killed this turn.
+	RiskConfirmOff bool `json:"risk_confirm_off,omitempty"`
+	// ThreatTint colours the ground under each enemy by its threat rating.
+	ThreatTint bool `json:"threat_tint,omitempty"`
+	// CameraEdgeScroll holds the view still until the player nears its
+	// edge, instead of recentring every turn.
+	CameraEdgeScroll bool `json:"camera_edge_scroll,omitempty"`
+	// LineWalls draws walls with connected box-drawing lines instead of
+	// the floor theme's emoji.
+	LineWalls bool `json:"line_walls,omitempty"`
+	// ASCII draws the map with one ASCII character per tile and entity, for
+	// terminals that render emoji badly.
+	ASCII bool `json:"ascii,omitempty"`
+	// Bell rings the terminal bell on notable events: low HP, an ability
+	// charge coming back, and death.
+	Bell bool `json:"bell,omitempty"`
+	// FOV picks the field-of-view algorithm; symmetric guarantees that any
+	// open tile the player sees can see the player's tile back.
+	FOV system.FOVAlgorithm `json:"fov,omitempty"`
+}
+
+// autopickupMode selects what is picked up automatically when walking onto
+// it. The zero value keeps the original behaviour of collecting only gold.
+type autopickupMode uint8
+
+const (
+	autopickupGold autopickupMode = iota // gold piles only
+	autopickupAll                        // gold and items, while the backpack has room
+	autopickupOff                        // nothing; use , for gold too
+)
+
+// String names the mode for the settings menu.
+func (m autopickupMode) String() string {
+	switch m {
+	case autopickupAll:
+		return "All"
+	case autopickupOff:
+		return "Off"
+	}
+	return "Gold only"
+}
+
+// next cycles Off → Gold only → All → Off.
+func (m autopickupMode) next() autopickupMode {
+	switch m {
+	case autopickupOff:
+		return autopickupGold
+	case autopickupGold:
+		return autopickupAll
+	}
+	return autopickupOff
+}
+
+// loadProfile reads profile.json from the run log directory. A missing or
+// unreadable profile yields the zero Profile.
+func loadProfile() Profile {
+	var p Profile
+	dir, err := runLogDir()
+	if err != nil {
+		return p
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "profile.json"))
+	if err != nil {
+		return p
+	}
+	json.Unmarshal(data, &p) //nolint:errcheck — a corrupt profile falls back to defaults
+	return p
+}
+
+// saveProfile writes the profile to profile.json. Errors are silently
+// discarded, as with saveRunLog.
+func saveProfile(p Profile) {
+	dir, err := runLogDir()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return
+	}
+	os.WriteFile(filepath.Join(dir, "profile.json"), data, 0o644) //nolint:errcheck — best-effort write
+}
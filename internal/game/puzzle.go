@@ -0,0 +1,28 @@
+package game
+
+import "emoji-roguelike/internal/system"
+
+// SealedDoorMessage is shown when a player tries a vault's locked door.
+const SealedDoorMessage = "🔒 The door is sealed. Glyphs are carved around its frame."
+
+// PuzzleMessage describes a step onto a vault puzzle's glyph, or returns ""
+// when the step did nothing.
+func PuzzleMessage(step system.PuzzleStep) string {
+	switch step {
+	case system.PuzzleAdvance:
+		return "✨ The glyph beneath you glows."
+	case system.PuzzleReset:
+		return "💢 The glyphs gutter out. The sequence starts over."
+	case system.PuzzleSolved:
+		return "🔓 The last glyph blazes, and somewhere a sealed door unlocks."
+	}
+	return ""
+}
+
+// stepPuzzle advances the floor's vault puzzle for the player's new tile.
+func (g *Game) stepPuzzle() {
+	pos := g.playerPosition()
+	if msg := PuzzleMessage(system.StepPuzzle(g.gmap, pos.X, pos.Y)); msg != "" {
+		g.addMessage(msg)
+	}
+}
@@ -0,0 +1,29 @@
+package game
+
+import (
+	"testing"
+
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/gamemap"
+)
+
+func TestBumpingSealedDoorKeepsItShut(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	for _, id := range g.world.Query(component.CAI) {
+		g.world.DestroyEntity(id)
+	}
+	pos := g.playerPosition()
+	g.gmap.Set(pos.X+1, pos.Y, gamemap.MakeLockedDoor())
+	g.gmap.Puzzle = &gamemap.Puzzle{Doors: [][2]int{{pos.X + 1, pos.Y}}}
+
+	g.processAction(ActionMoveE)
+	if g.gmap.At(pos.X+1, pos.Y).Kind != gamemap.TileDoor || !hasMessage(g, SealedDoorMessage) {
+		t.Fatal("a sealed door should stay shut and say why")
+	}
+
+	g.gmap.At(pos.X+1, pos.Y).Locked = false
+	g.processAction(ActionMoveE)
+	if g.gmap.At(pos.X+1, pos.Y).Kind != gamemap.TileFloor {
+		t.Error("an unlocked vault door should open like any other")
+	}
+}
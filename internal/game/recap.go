@@ -0,0 +1,69 @@
+package game
+
+import (
+	"emoji-roguelike/assets"
+	"fmt"
+	"strings"
+)
+
+// recapSize is how many recent damage events RunLog keeps for the death recap.
+const recapSize = 4
+
+// DamageEvent is one source of damage the player took, kept for the death
+// recap.
+type DamageEvent struct {
+	Turn   int    `json:"turn"`
+	Source string `json:"source"` // enemy glyph, "poison", "self-burn" or "fall"
+	Damage int    `json:"damage"`
+	HPLeft int    `json:"hp_left"`
+}
+
+// noteDamage records a damage event, keeping only the last recapSize.
+func (rl *RunLog) noteDamage(turn int, source string, damage, hpLeft int) {
+	rl.RecentDamage = append(rl.RecentDamage, DamageEvent{Turn: turn, Source: source, Damage: damage, HPLeft: hpLeft})
+	if len(rl.RecentDamage) > recapSize {
+		rl.RecentDamage = rl.RecentDamage[len(rl.RecentDamage)-recapSize:]
+	}
+}
+
+// damageSourceName describes a damage source: the glyph and name of an enemy,
+// or the source itself for poison and self-burn.
+func damageSourceName(source string) string {
+	if name := assets.EnemyName(source); name != "" {
+		return source + " " + name
+	}
+	return source
+}
+
+// killerLabel describes the killing blow, e.g. "🐉 Prism Drake (12 damage)".
+// Falls back to CauseOfDeath when no damage events were recorded.
+func (rl RunLog) killerLabel() string {
+	if len(rl.RecentDamage) == 0 {
+		return rl.CauseOfDeath
+	}
+	last := rl.RecentDamage[len(rl.RecentDamage)-1]
+	return fmt.Sprintf("%s (%d damage)", damageSourceName(last.Source), last.Damage)
+}
+
+// hpTrajectory shows the player's HP across the recorded damage events,
+// e.g. "30 → 24 → 18 → 0".
+func (rl RunLog) hpTrajectory() string {
+	if len(rl.RecentDamage) == 0 {
+		return ""
+	}
+	first := rl.RecentDamage[0]
+	steps := []string{fmt.Sprint(first.HPLeft + first.Damage)}
+	for _, e := range rl.RecentDamage {
+		steps = append(steps, fmt.Sprint(max(e.HPLeft, 0)))
+	}
+	return strings.Join(steps, " → ")
+}
+
+// recapLines lists the recorded damage events oldest first, one per line.
+func (rl RunLog) recapLines() []string {
+	lines := make([]string, 0, len(rl.RecentDamage))
+	for _, e := range rl.RecentDamage {
+		lines = append(lines, fmt.Sprintf("T%-4d %s  -%d", e.Turn, damageSourceName(e.Source), e.Damage))
+	}
+	return lines
+}
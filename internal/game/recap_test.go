@@ -0,0 +1,55 @@
+package game
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"testing"
+)
+
+func TestNoteDamageKeepsLastEvents(t *testing.T) {
+	var rl RunLog
+	hp := 30
+	for turn := 1; turn <= recapSize+2; turn++ {
+		hp -= 4
+		rl.noteDamage(turn, assets.GlyphPrismDrake, 4, hp)
+	}
+	if len(rl.RecentDamage) != recapSize {
+		t.Fatalf("RecentDamage len = %d; want %d", len(rl.RecentDamage), recapSize)
+	}
+	if first := rl.RecentDamage[0].Turn; first != 3 {
+		t.Errorf("oldest kept turn = %d; want 3", first)
+	}
+	if got, want := rl.hpTrajectory(), "22 → 18 → 14 → 10 → 6"; got != want {
+		t.Errorf("hpTrajectory = %q; want %q", got, want)
+	}
+}
+
+func TestKillerLabelNamesEnemy(t *testing.T) {
+	var rl RunLog
+	rl.CauseOfDeath = assets.GlyphPrismDrake
+	if got := rl.killerLabel(); got != assets.GlyphPrismDrake {
+		t.Errorf("killerLabel with no events = %q; want the bare cause", got)
+	}
+	rl.noteDamage(7, assets.GlyphPrismDrake, 12, -3)
+	if got, want := rl.killerLabel(), assets.GlyphPrismDrake+" Prism Drake (12 damage)"; got != want {
+		t.Errorf("killerLabel = %q; want %q", got, want)
+	}
+	if got, want := rl.hpTrajectory(), "9 → 0"; got != want {
+		t.Errorf("hpTrajectory = %q; want %q (HP clamps at 0)", got, want)
+	}
+}
+
+func TestPoisonDamageRecordedForRecap(t *testing.T) {
+	g := newAbilityTestGame(t, "arcanist")
+	g.world.Add(g.playerID, component.Effects{Active: []component.ActiveEffect{
+		{Kind: component.EffectPoison, Magnitude: 3, TurnsRemaining: 2},
+	}})
+	g.applyPoisonDamage()
+
+	if len(g.runLog.RecentDamage) != 1 {
+		t.Fatalf("RecentDamage = %v; want one poison event", g.runLog.RecentDamage)
+	}
+	if e := g.runLog.RecentDamage[0]; e.Source != "poison" || e.Damage != 3 || e.HPLeft != g.playerHP() {
+		t.Errorf("event = %+v; want 3 poison damage leaving %d HP", e, g.playerHP())
+	}
+}
@@ -0,0 +1,121 @@
+package game
+
+import (
+	"fmt"
+
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/system"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// wanderingSmithTitle heads the between-floor repair modal.
+const wanderingSmithTitle = "⚒️ WANDERING SMITH"
+
+// BreakMessage tells a player that an equipped item broke from wear.
+func BreakMessage(name string) string {
+	return fmt.Sprintf("Your %s breaks! It's useless until a smith repairs it.", name)
+}
+
+// DurabilityTag describes how worn an equipped item is for the inventory
+// screens, or "" for items that never wear out.
+func DurabilityTag(it component.Item) string {
+	switch {
+	case it.MaxDurability <= 0:
+		return ""
+	case it.Broken():
+		return " [BROKEN]"
+	}
+	return fmt.Sprintf(" [%d/%d]", it.Durability, it.MaxDurability)
+}
+
+// RepairWares lists a repair job for every equipped item in inv that has
+// lost durability, in Inventory.Equipped order, priced by system.RepairCost.
+func RepairWares(inv component.Inventory) []assets.ShopEntry {
+	var wares []assets.ShopEntry
+	for _, it := range inv.Equipped() {
+		cost := system.RepairCost(it)
+		if cost == 0 {
+			continue
+		}
+		name := fmt.Sprintf("%s %d/%d", it.Name, it.Durability, it.MaxDurability)
+		if it.Broken() {
+			name = it.Name + " (broken)"
+		}
+		wares = append(wares, assets.ShopEntry{Glyph: it.Glyph, Name: name, Price: cost, Repair: true})
+	}
+	return wares
+}
+
+// RepairItem restores the item behind RepairWares(*inv)[idx] to full
+// durability if gold covers the cost, less discountPct percent, and returns
+// the status line to show.
+func RepairItem(gold *int, inv *component.Inventory, idx, discountPct int) string {
+	n := 0
+	for _, it := range []*component.Item{&inv.Head, &inv.Body, &inv.Feet, &inv.MainHand, &inv.OffHand} {
+		cost := Discount(system.RepairCost(*it), discountPct)
+		if cost == 0 {
+			continue
+		}
+		if n < idx {
+			n++
+			continue
+		}
+		if *gold < cost {
+			return fmt.Sprintf("Not enough gold. (%d💰 needed, you have %d💰)", cost, *gold)
+		}
+		*gold -= cost
+		it.Durability = it.MaxDurability
+		return fmt.Sprintf("Repaired %s %s. (%d💰 remaining)", it.Glyph, it.Name, *gold)
+	}
+	return "Nothing to repair."
+}
+
+// canAffordRepair reports whether gold covers at least one repair job on inv.
+func canAffordRepair(gold int, inv component.Inventory) bool {
+	for _, e := range RepairWares(inv) {
+		if gold >= e.Price {
+			return true
+		}
+	}
+	return false
+}
+
+// runRepairs runs a repair modal titled title for one purse and inventory.
+func runRepairs(screen tcell.Screen, nextEvent func() tcell.Event, title string, gold *int, inv *component.Inventory) {
+	RunShopModal(screen, nextEvent, title,
+		func() []assets.ShopEntry { return RepairWares(*inv) },
+		func() int { return *gold },
+		func(idx int) string { return RepairItem(gold, inv, idx, 0) })
+}
+
+// runSmith offers repairs between floors when the player has worn gear and
+// the gold to mend some of it.
+func (g *Game) runSmith() {
+	invComp := g.world.Get(g.playerID, component.CInventory)
+	if invComp == nil {
+		return
+	}
+	inv := invComp.(component.Inventory)
+	if !canAffordRepair(g.gold, inv) {
+		return
+	}
+	before := g.gold
+	runRepairs(g.screen, g.screen.PollEvent, wanderingSmithTitle, &g.gold, &inv)
+	g.world.Add(g.playerID, inv)
+	g.recalcPlayerMaxHP()
+	if spent := before - g.gold; spent > 0 {
+		g.addMessage(fmt.Sprintf("You spend %d gold on repairs.", spent))
+	}
+}
+
+// noteWornOut reports the player's item that broke from wear, if any, and
+// drops any MaxHP it was granting.
+func (g *Game) noteWornOut(name string) {
+	if name == "" {
+		return
+	}
+	g.addMessage(BreakMessage(name))
+	g.recalcPlayerMaxHP()
+}
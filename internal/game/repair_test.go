@@ -0,0 +1,68 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/system"
+)
+
+func wornInventory() component.Inventory {
+	return component.Inventory{
+		Head:     component.Item{Name: "Helm", Glyph: "🪖", Slot: component.SlotHead, BonusDEF: 1, Durability: 10, MaxDurability: 10},
+		Body:     component.Item{Name: "Mail", Glyph: "🧥", Slot: component.SlotBody, BonusDEF: 2, Durability: 4, MaxDurability: 10},
+		MainHand: component.Item{Name: "Sword", Glyph: "⚔️", Slot: component.SlotOneHand, BonusATK: 3, MaxDurability: 10},
+	}
+}
+
+func TestRepairWaresListsOnlyWornGear(t *testing.T) {
+	wares := RepairWares(wornInventory())
+	if len(wares) != 2 {
+		t.Fatalf("got %d repair jobs; want mail and sword", len(wares))
+	}
+	if !strings.HasPrefix(wares[0].Name, "Mail") || wares[1].Name != "Sword (broken)" {
+		t.Errorf("jobs = %q, %q", wares[0].Name, wares[1].Name)
+	}
+	if !wares[0].Repair || wares[0].Price != system.RepairCost(wornInventory().Body) {
+		t.Errorf("mail job = %+v", wares[0])
+	}
+}
+
+func TestRepairItemRestoresDurabilityForGold(t *testing.T) {
+	inv := wornInventory()
+	gold := 100
+	cost := system.RepairCost(inv.MainHand)
+
+	RepairItem(&gold, &inv, 1, 0)
+	if inv.MainHand.Broken() || inv.MainHand.Durability != inv.MainHand.MaxDurability {
+		t.Errorf("sword durability %d/%d after repair", inv.MainHand.Durability, inv.MainHand.MaxDurability)
+	}
+	if gold != 100-cost {
+		t.Errorf("gold = %d; want %d", gold, 100-cost)
+	}
+	if atk, _, _ := inv.EquipBonuses(); atk != 3 {
+		t.Errorf("repaired sword grants ATK %d; want 3", atk)
+	}
+}
+
+func TestRepairItemNeedsGold(t *testing.T) {
+	inv := wornInventory()
+	gold := 1
+	if msg := RepairItem(&gold, &inv, 1, 0); !strings.HasPrefix(msg, "Not enough gold") {
+		t.Errorf("status = %q", msg)
+	}
+	if !inv.MainHand.Broken() || gold != 1 {
+		t.Error("an unaffordable repair must change nothing")
+	}
+}
+
+func TestInventoryShowsDurability(t *testing.T) {
+	inv := wornInventory()
+	if got := formatBonuses(inv.Body); !strings.HasSuffix(got, "[4/10]") {
+		t.Errorf("formatBonuses(mail) = %q; want durability shown", got)
+	}
+	if got := formatBonuses(inv.MainHand); !strings.HasSuffix(got, "[BROKEN]") {
+		t.Errorf("formatBonuses(sword) = %q; want it marked broken", got)
+	}
+}
@@ -0,0 +1,49 @@
+package game
+
+import (
+	"fmt"
+
+	"emoji-roguelike/assets"
+)
+
+// Merchant reputation: every ReputationPerPct gold a player spends with the
+// city's merchants takes 1% off their prices there, up to MaxDiscountPct.
+const (
+	ReputationPerPct = 50
+	MaxDiscountPct   = 20
+)
+
+// ReputationDiscount returns the percentage off merchant prices earned by
+// reputation rep.
+func ReputationDiscount(rep int) int {
+	return min(max(rep, 0)/ReputationPerPct, MaxDiscountPct)
+}
+
+// Discount returns price with pct percent taken off. A price never drops
+// below 1 gold.
+func Discount(price, pct int) int {
+	if price <= 0 {
+		return price
+	}
+	return max(price*(100-pct)/100, 1)
+}
+
+// DiscountWares returns a copy of wares with pct percent taken off every
+// price.
+func DiscountWares(wares []assets.ShopEntry, pct int) []assets.ShopEntry {
+	out := make([]assets.ShopEntry, len(wares))
+	for i, e := range wares {
+		e.Price = Discount(e.Price, pct)
+		out[i] = e
+	}
+	return out
+}
+
+// DiscountTitle adds the player's reputation discount, if any, to a shop
+// modal's title.
+func DiscountTitle(title string, pct int) string {
+	if pct <= 0 {
+		return title
+	}
+	return fmt.Sprintf("%s  (Reputation: %d%% off)", title, pct)
+}
@@ -0,0 +1,46 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/system"
+)
+
+func TestReputationDiscountCaps(t *testing.T) {
+	for _, tc := range []struct{ rep, want int }{
+		{0, 0},
+		{ReputationPerPct - 1, 0},
+		{ReputationPerPct * 5, 5},
+		{ReputationPerPct * 1000, MaxDiscountPct},
+	} {
+		if got := ReputationDiscount(tc.rep); got != tc.want {
+			t.Errorf("ReputationDiscount(%d) = %d; want %d", tc.rep, got, tc.want)
+		}
+	}
+}
+
+func TestDiscountWaresKeepsPricesPositive(t *testing.T) {
+	wares := DiscountWares([]assets.ShopEntry{{Name: "a", Price: 100}, {Name: "b", Price: 1}}, MaxDiscountPct)
+	if wares[0].Price != 100*(100-MaxDiscountPct)/100 || wares[1].Price != 1 {
+		t.Errorf("discounted prices %d and %d; want %d and 1", wares[0].Price, wares[1].Price, 100*(100-MaxDiscountPct)/100)
+	}
+	if title := DiscountTitle("SHOP", 0); title != "SHOP" {
+		t.Errorf("DiscountTitle with no discount = %q; want the plain title", title)
+	}
+	if title := DiscountTitle("SHOP", 10); !strings.Contains(title, "10% off") {
+		t.Errorf("DiscountTitle = %q; want it to show 10%% off", title)
+	}
+}
+
+func TestRepairItemChargesDiscountedCost(t *testing.T) {
+	inv := wornInventory()
+	gold := 100
+	cost := Discount(system.RepairCost(inv.MainHand), MaxDiscountPct)
+
+	RepairItem(&gold, &inv, 1, MaxDiscountPct)
+	if gold != 100-cost {
+		t.Errorf("gold = %d; want %d after the discounted repair", gold, 100-cost)
+	}
+}
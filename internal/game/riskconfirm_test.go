@@ -0,0 +1,70 @@
+package game
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// newRiskyGame puts a heavy hitter two tiles east of a 5-HP player on the
+// tutorial floor, so stepping east walks into a lethal fight.
+func newRiskyGame(t *testing.T) (*Game, ecs.EntityID) {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	g := newAbilityTestGame(t, "warden")
+	g.loadTutorialFloor()
+	pos := g.playerPosition()
+	brute := g.world.CreateEntity()
+	g.world.Add(brute, component.Position{X: pos.X + 2, Y: pos.Y})
+	g.world.Add(brute, component.AI{Behavior: component.BehaviorStationary, SightRange: 8})
+	g.world.Add(brute, component.Combat{Attack: 40})
+	g.world.Add(brute, component.Health{Current: 50, Max: 50})
+	g.world.Add(brute, component.TagBlocking{})
+	g.world.Add(brute, component.Renderable{Glyph: "🦀", FGColor: tcell.ColorRed})
+	hp := g.world.Get(g.playerID, component.CHealth).(component.Health)
+	hp.Current = 5
+	g.world.Add(g.playerID, hp)
+	return g, brute
+}
+
+func TestRiskyMoveNeedsConfirmation(t *testing.T) {
+	g, _ := newRiskyGame(t)
+	start := g.playerPosition()
+	ss := g.screen.(tcell.SimulationScreen)
+
+	ss.InjectKey(tcell.KeyRune, 'n', tcell.ModNone)
+	g.processAction(ActionMoveE)
+	if g.playerPosition() != start {
+		t.Fatal("declining the prompt should keep the player in place")
+	}
+	if g.runLog.TurnsPlayed != 0 {
+		t.Error("declining the prompt should not spend a turn")
+	}
+
+	ss.InjectKey(tcell.KeyRune, 'y', tcell.ModNone)
+	g.processAction(ActionMoveE)
+	if g.playerPosition().X != start.X+1 {
+		t.Error("confirming the prompt should make the move")
+	}
+}
+
+func TestSafeMoveSkipsConfirmation(t *testing.T) {
+	g, _ := newRiskyGame(t)
+	start := g.playerPosition()
+	g.processAction(ActionMoveW) // away from the brute: no prompt, no key needed
+	if g.playerPosition().X != start.X-1 {
+		t.Error("a safe move should go ahead without a prompt")
+	}
+}
+
+func TestRiskConfirmCanBeTurnedOff(t *testing.T) {
+	g, _ := newRiskyGame(t)
+	g.profile.RiskConfirmOff = true
+	start := g.playerPosition()
+	g.processAction(ActionMoveE)
+	if g.playerPosition().X != start.X+1 {
+		t.Error("with the prompt off, the risky move should go ahead")
+	}
+}
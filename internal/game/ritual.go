@@ -0,0 +1,42 @@
+package game
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/system"
+	"fmt"
+)
+
+// ritualMessage describes the result of bumping a ritual altar: the offering
+// just placed, or how many are still wanted when the player had none.
+func ritualMessage(r component.Ritual, offered bool) string {
+	name := assets.ConsumableName(r.Offering)
+	switch {
+	case !offered:
+		return fmt.Sprintf("The altar waits for %d more %s %s. Find them on this floor.", r.Needed-r.Offered, r.Offering, name)
+	case r.Complete():
+		return fmt.Sprintf("You set the last %s %s in place. The altar blazes with light!", r.Offering, name)
+	}
+	return fmt.Sprintf("You set a %s %s on the altar (%d/%d).", r.Offering, name, r.Offered, r.Needed)
+}
+
+// advanceRitual offers one matching item from the backpack to the ritual on
+// id. Reports whether the ritual is now complete and its reward should be
+// granted.
+func (g *Game) advanceRitual(id ecs.EntityID) bool {
+	r, offered := system.MakeOffering(g.world, id, g.playerID)
+	if offered || !r.Complete() {
+		g.addMessage(ritualMessage(r, offered))
+	}
+	return r.Complete()
+}
+
+// coopAdvanceRitual is advanceRitual for co-op player p.
+func (g *CoopGame) coopAdvanceRitual(p *coopPlayer, id ecs.EntityID) bool {
+	r, offered := system.MakeOffering(g.world, id, p.id)
+	if offered || !r.Complete() {
+		g.addMessage(ritualMessage(r, offered))
+	}
+	return r.Complete()
+}
@@ -0,0 +1,46 @@
+package game
+
+import (
+	"testing"
+
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/factory"
+)
+
+func TestAltarRitualGrantsBonusOnLastOffering(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	pos := g.playerPosition()
+	altar := factory.NewAltar(g.world, pos.X, pos.Y)
+	inv := g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	for range assets.AltarOfferings - 1 {
+		inv.Backpack = append(inv.Backpack, component.Item{Glyph: assets.AltarOffering, Name: "Prism Shard"})
+	}
+	g.world.Add(g.playerID, inv)
+	baseATK := g.world.Get(g.playerID, component.CCombat).(component.Combat).Attack
+
+	for range assets.AltarOfferings {
+		g.interactFurniture(altar)
+	}
+	if !hasMessage(g, "waits for 1 more") {
+		t.Error("bumping the altar without a shard should say how many are still wanted")
+	}
+	if g.world.Get(altar, component.CFurniture).(component.Furniture).Used {
+		t.Fatal("altar granted its reward before every offering was made")
+	}
+
+	inv = g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	inv.Backpack = append(inv.Backpack, component.Item{Glyph: assets.AltarOffering, Name: "Prism Shard"})
+	g.world.Add(g.playerID, inv)
+	g.interactFurniture(altar)
+
+	if !g.world.Get(altar, component.CFurniture).(component.Furniture).Used {
+		t.Error("altar should be spent once the ritual completes")
+	}
+	if got := g.world.Get(g.playerID, component.CCombat).(component.Combat).Attack; got != baseATK+1 {
+		t.Errorf("ATK = %d after the ritual; want %d", got, baseATK+1)
+	}
+	if n := len(g.world.Get(g.playerID, component.CInventory).(component.Inventory).Backpack); n != 0 {
+		t.Errorf("%d items left in the backpack; every shard should be on the altar", n)
+	}
+}
@@ -0,0 +1,11 @@
+package game
+
+import "math/rand"
+
+// SplitSeed derives a run's two RNGs from one master seed: gen builds floors
+// and combat rolls everything that happens on them. Keeping them apart means
+// a seed builds the same dungeon however many dice combat has rolled.
+func SplitSeed(seed int64) (gen, combat *rand.Rand) {
+	master := rand.New(rand.NewSource(seed))
+	return rand.New(rand.NewSource(master.Int63())), rand.New(rand.NewSource(master.Int63()))
+}
@@ -0,0 +1,70 @@
+package game
+
+import (
+	"testing"
+
+	"emoji-roguelike/internal/component"
+)
+
+// floorLayout summarises g's current floor: every tile kind, and the glyph
+// of each enemy by position.
+func floorLayout(g *Game) (tiles []int, enemies map[component.Position]string) {
+	for y := range g.gmap.Height {
+		for x := range g.gmap.Width {
+			tiles = append(tiles, int(g.gmap.At(x, y).Kind))
+		}
+	}
+	enemies = map[component.Position]string{}
+	for _, id := range g.world.Query(component.CAI, component.CPosition, component.CRenderable) {
+		p := g.world.Get(id, component.CPosition).(component.Position)
+		enemies[p] = g.world.Get(id, component.CRenderable).(component.Renderable).Glyph
+	}
+	return tiles, enemies
+}
+
+func TestCombatRollsDoNotChangeLaterFloors(t *testing.T) {
+	a, b := newAbilityTestGame(t, "warden"), newAbilityTestGame(t, "warden")
+	a.genRng, a.combatRng = SplitSeed(7)
+	b.genRng, b.combatRng = SplitSeed(7)
+
+	// The same floor 1, fought through differently: b makes many more rolls.
+	a.loadFloor(1)
+	b.loadFloor(1)
+	a.combatRng.Intn(100)
+	for range 500 {
+		b.combatRng.Intn(100)
+	}
+
+	a.loadFloor(2)
+	b.loadFloor(2)
+	tilesA, enemiesA := floorLayout(a)
+	tilesB, enemiesB := floorLayout(b)
+	if len(tilesA) != len(tilesB) {
+		t.Fatalf("floor 2 sizes differ: %d and %d tiles", len(tilesA), len(tilesB))
+	}
+	for i := range tilesA {
+		if tilesA[i] != tilesB[i] {
+			t.Errorf("floor 2 tile %d differs: %d and %d", i, tilesA[i], tilesB[i])
+			break
+		}
+	}
+	if len(enemiesA) != len(enemiesB) {
+		t.Fatalf("floor 2 has %d and %d enemies", len(enemiesA), len(enemiesB))
+	}
+	for p, glyph := range enemiesA {
+		if enemiesB[p] != glyph {
+			t.Errorf("enemy at %v is %q and %q", p, glyph, enemiesB[p])
+		}
+	}
+}
+
+func TestSplitSeedIsDeterministic(t *testing.T) {
+	gen1, combat1 := SplitSeed(99)
+	gen2, combat2 := SplitSeed(99)
+	for range 10 {
+		if gen1.Int63() != gen2.Int63() || combat1.Int63() != combat2.Int63() {
+			t.Error("the same seed split into different streams")
+			break
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package game
+
+import (
+	"fmt"
+
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/system"
+)
+
+// RiddleAnswered is the message shown when a player answers a riddle rune.
+var RiddleAnswered = fmt.Sprintf("🔯 You wait, and the rune answers: its hidden cache opens. (+%d💰)", system.RiddleGold)
+
+// RuneMessage describes the effect of an active rune that has just fired, or
+// returns "" for kinds with nothing more to say than their carving.
+func RuneMessage(kind component.RuneKind) string {
+	switch kind {
+	case component.RuneWard:
+		return fmt.Sprintf("🔯 The rune flares. +%d DEF for %d turns.", system.RuneBoostMagnitude, system.RuneBoostTurns)
+	case component.RuneFury:
+		return fmt.Sprintf("🔯 The rune flares. +%d ATK for %d turns.", system.RuneBoostMagnitude, system.RuneBoostTurns)
+	case component.RuneHex:
+		return "🔯 The rune spits black smoke. You are poisoned!"
+	}
+	return ""
+}
+
+// readRune fires any active rune under the player.
+func (g *Game) readRune() {
+	if msg := RuneMessage(system.TriggerRune(g.world, g.playerID)); msg != "" {
+		g.addMessage(msg)
+	}
+}
+
+// answerRiddle pays out a riddle rune the player has just waited on.
+func (g *Game) answerRiddle() {
+	if system.AnswerRiddle(g.world, g.playerID) {
+		g.earnGold(system.RiddleGold)
+		g.addMessage(RiddleAnswered)
+	}
+}
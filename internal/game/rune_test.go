@@ -0,0 +1,56 @@
+package game
+
+import (
+	"testing"
+
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/gamemap"
+	"emoji-roguelike/internal/system"
+)
+
+// newRuneGame returns an enemy-free game with a rune of kind east of the
+// player.
+func newRuneGame(t *testing.T, kind component.RuneKind) *Game {
+	t.Helper()
+	g := newAbilityTestGame(t, "warden")
+	for _, id := range g.world.Query(component.CAI) {
+		g.world.DestroyEntity(id)
+	}
+	for _, id := range g.world.Query(component.CInscription) {
+		g.world.DestroyEntity(id)
+	}
+	pos := g.playerPosition()
+	g.gmap.Set(pos.X+1, pos.Y, gamemap.MakeFloor())
+	id := g.world.CreateEntity()
+	g.world.Add(id, component.Position{X: pos.X + 1, Y: pos.Y})
+	g.world.Add(id, component.Inscription{Text: "a rune", Rune: kind})
+	return g
+}
+
+func TestSteppingOnFuryRuneBoostsAttack(t *testing.T) {
+	g := newRuneGame(t, component.RuneFury)
+	g.processAction(ActionMoveE)
+	if got := system.GetAttackBonus(g.world, g.playerID); got != system.RuneBoostMagnitude {
+		t.Errorf("ATK bonus = %d; want %d", got, system.RuneBoostMagnitude)
+	}
+	if !hasMessage(g, RuneMessage(component.RuneFury)) {
+		t.Error("a firing rune should say what it did")
+	}
+}
+
+func TestWaitingOnRiddleRunePaysGold(t *testing.T) {
+	g := newRuneGame(t, component.RuneRiddle)
+	g.processAction(ActionWait) // not on the rune yet
+	if g.gold != 0 {
+		t.Fatalf("gold = %d before reaching the riddle", g.gold)
+	}
+	g.processAction(ActionMoveE)
+	g.processAction(ActionWait)
+	g.processAction(ActionWait)
+	if g.gold != system.RiddleGold {
+		t.Errorf("gold = %d; want %d, paid once", g.gold, system.RiddleGold)
+	}
+	if !hasMessage(g, RiddleAnswered) {
+		t.Error("answering the riddle should say so")
+	}
+}
@@ -0,0 +1,22 @@
+package game
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/system"
+)
+
+// sanctuaryMessage greets a player stepping into a sanctuary.
+const sanctuaryMessage = "A hush falls. Nothing hostile will follow you into this sanctuary."
+
+// tickSanctuary greets the player on entering a sanctuary and applies its
+// bonus regeneration.
+func (g *Game) tickSanctuary() {
+	in := system.InSanctuary(g.world, g.gmap, g.playerID)
+	if in && !g.sheltered {
+		g.addMessage(sanctuaryMessage)
+	}
+	g.sheltered = in
+	if in && g.runLog.TurnsPlayed%assets.SanctuaryRegenEvery == 0 {
+		g.restorePlayerHP(1)
+	}
+}
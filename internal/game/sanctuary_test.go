@@ -0,0 +1,30 @@
+package game
+
+import (
+	"testing"
+
+	"emoji-roguelike/internal/component"
+)
+
+func TestSanctuaryRegensAndGreets(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	for _, id := range g.world.Query(component.CAI) {
+		g.world.DestroyEntity(id)
+	}
+	g.selectedClass.PassiveRegen = 0
+	pos := g.playerPosition()
+	g.gmap.At(pos.X, pos.Y).Sanctuary = true
+	hp := g.world.Get(g.playerID, component.CHealth).(component.Health)
+	hp.Current = hp.Max - 5
+	g.world.Add(g.playerID, hp)
+
+	for range 4 {
+		g.processAction(ActionWait)
+	}
+	if got := g.playerHP(); got != hp.Current+2 {
+		t.Errorf("HP after 4 turns in a sanctuary = %d; want %d", got, hp.Current+2)
+	}
+	if !hasMessage(g, sanctuaryMessage) {
+		t.Error("entering a sanctuary should say so")
+	}
+}
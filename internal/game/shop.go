@@ -0,0 +1,351 @@
+package game
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/factory"
+	"emoji-roguelike/internal/system"
+	"fmt"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Shop headers for the between-floor merchant and dungeon vending machines.
+const (
+	merchantTitle = "🛍️ WANDERING MERCHANT"
+	vendingTitle  = "🏧 VENDING MACHINE"
+)
+
+// cheapestShopPrice returns the lowest price in assets.ShopCatalogue.
+func cheapestShopPrice() int {
+	cheapest := assets.ShopCatalogue[0].Price
+	for _, e := range assets.ShopCatalogue[1:] {
+		cheapest = min(cheapest, e.Price)
+	}
+	return cheapest
+}
+
+// shopBuy moves one catalogue entry into inv if gold covers its price and
+// returns the status line to show.
+func shopBuy(gold *int, inv *component.Inventory, entry assets.ShopEntry) string {
+	if *gold < entry.Price {
+		return fmt.Sprintf("Not enough gold. (%d💰 needed, you have %d💰)", entry.Price, *gold)
+	}
+	if !inv.Stow(factory.ShopItem(entry)) {
+		return "Backpack full! Drop something first."
+	}
+	*gold -= entry.Price
+	return fmt.Sprintf("Bought %s %s. (%d💰 remaining)", entry.Glyph, entry.Name, *gold)
+}
+
+// RunShopModal blocks on the shop UI until the player closes it (Esc/q, or
+// a nil event from nextEvent). listing returns the wares currently on offer,
+// gold the purse to display, and buy attempts to purchase listing()[idx],
+// returning the status line to show.
+func RunShopModal(screen tcell.Screen, nextEvent func() tcell.Event, title string,
+	listing func() []assets.ShopEntry, gold func() int, buy func(idx int) string) {
+	cursor := 0
+	statusMsg := ""
+	for {
+		wares := listing()
+		cursor = max(min(cursor, len(wares)-1), 0)
+		DrawShopScreen(screen, title, wares, cursor, gold(), statusMsg)
+
+		ev := nextEvent()
+		if ev == nil {
+			return
+		}
+		statusMsg = ""
+		switch ev := ev.(type) {
+		case *tcell.EventResize:
+			screen.Sync()
+		case *tcell.EventKey:
+			switch ev.Key() {
+			case tcell.KeyEscape:
+				return
+			case tcell.KeyUp:
+				cursor = max(cursor-1, 0)
+			case tcell.KeyDown:
+				cursor = min(cursor+1, len(wares)-1)
+			case tcell.KeyEnter:
+				if len(wares) > 0 {
+					statusMsg = buy(cursor)
+				}
+			default:
+				r := ev.Rune()
+				switch {
+				case r == 'q' || r == 'Q':
+					return
+				case r == 'k' || r == 'K':
+					cursor = max(cursor-1, 0)
+				case r == 'j' || r == 'J':
+					cursor = min(cursor+1, len(wares)-1)
+				case r >= 'a' && r <= 'h':
+					if idx := int(r - 'a'); idx < len(wares) {
+						cursor = idx
+						statusMsg = buy(idx)
+					}
+				}
+			}
+		}
+	}
+}
+
+// runMerchant runs the between-floor merchant for one purse and inventory.
+func runMerchant(screen tcell.Screen, nextEvent func() tcell.Event, gold *int, inv *component.Inventory) {
+	catalogue := assets.ShopCatalogue
+	RunShopModal(screen, nextEvent, merchantTitle,
+		func() []assets.ShopEntry { return catalogue },
+		func() int { return *gold },
+		func(idx int) string { return shopBuy(gold, inv, catalogue[idx]) })
+}
+
+// VendingWares lists the remaining stock of vending machine id as shop
+// entries, or nil if id is not a vending machine.
+func VendingWares(w *ecs.World, id ecs.EntityID) []assets.ShopEntry {
+	vc := w.Get(id, component.CVending)
+	if vc == nil {
+		return nil
+	}
+	var wares []assets.ShopEntry
+	for _, slot := range vc.(component.VendingMachine).Stock {
+		wares = append(wares, assets.ShopEntry{Glyph: slot.Glyph, Name: slot.Name, Price: slot.Price, IsConsumable: true})
+	}
+	return wares
+}
+
+// runVendingMachine runs the shop UI for vending machine id. Each purchase
+// goes through shopBuy and removes the slot from the machine's stock.
+func runVendingMachine(screen tcell.Screen, nextEvent func() tcell.Event, w *ecs.World, id ecs.EntityID, gold *int, inv *component.Inventory) {
+	RunShopModal(screen, nextEvent, vendingTitle,
+		func() []assets.ShopEntry { return VendingWares(w, id) },
+		func() int { return *gold },
+		func(idx int) string {
+			wares := VendingWares(w, id)
+			if idx >= len(wares) {
+				return "Sold out."
+			}
+			before := *gold
+			msg := shopBuy(gold, inv, wares[idx])
+			if *gold < before {
+				system.TakeVendingSlot(w, id, idx)
+			}
+			return msg
+		})
+}
+
+// runShop opens the wandering merchant between floors.
+func (g *Game) runShop() {
+	invComp := g.world.Get(g.playerID, component.CInventory)
+	if invComp == nil {
+		return
+	}
+	inv := invComp.(component.Inventory)
+	before := g.gold
+	runMerchant(g.screen, g.screen.PollEvent, &g.gold, &inv)
+	g.world.Add(g.playerID, inv)
+	if spent := before - g.gold; spent > 0 {
+		g.addMessage(fmt.Sprintf("You spend %d gold with the merchant.", spent))
+	}
+}
+
+// useVendingMachine opens vending machine id for the player.
+func (g *Game) useVendingMachine(id ecs.EntityID) {
+	invComp := g.world.Get(g.playerID, component.CInventory)
+	if invComp == nil {
+		return
+	}
+	inv := invComp.(component.Inventory)
+	runVendingMachine(g.screen, g.screen.PollEvent, g.world, id, &g.gold, &inv)
+	g.world.Add(g.playerID, inv)
+}
+
+// descend offers the merchant's wares when the player can afford something,
+// then loads the next floor, offering a subclass perk on reaching
+// subclassFloor. Descending from the tutorial starts the run.
+func (g *Game) descend() {
+	if g.tutorial != nil {
+		g.finishTutorial()
+		return
+	}
+	if g.gold >= cheapestShopPrice() {
+		g.runShop()
+	}
+	g.runSmith()
+	from := g.floor
+	g.loadFloor(from + 1)
+	g.offerSubclass(from)
+}
+
+// earnGold credits gold to the player's purse and run log.
+func (g *Game) earnGold(n int) {
+	g.gold += n
+	g.runLog.GoldEarned += n
+}
+
+// collectGold picks up any gold pile under the player and reports whether
+// there was any.
+func (g *Game) collectGold() bool {
+	pos := g.playerPosition()
+	amount := system.CollectGold(g.world, pos.X, pos.Y)
+	if amount > 0 {
+		g.earnGold(amount)
+		g.addMessage(fmt.Sprintf("You scoop up %d gold. (%d💰)", amount, g.gold))
+	}
+	return amount > 0
+}
+
+// runCoopShops lets every living player who can afford something browse the
+// merchant, then the smith, on their own screen at the same time. Each player
+// shops against a copy of their inventory, which is written back once both
+// are done.
+func (g *CoopGame) runCoopShops() {
+	var invs [2]component.Inventory
+	var shopping [2]bool
+	var wg sync.WaitGroup
+	for i, p := range g.players {
+		if !p.alive {
+			continue
+		}
+		invComp := g.world.Get(p.id, component.CInventory)
+		if invComp == nil {
+			continue
+		}
+		invs[i] = invComp.(component.Inventory)
+		if p.gold < cheapestShopPrice() && !canAffordRepair(p.gold, invs[i]) {
+			continue
+		}
+		shopping[i] = true
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer restoreOnPanic(g.screens()...)
+			next := func() tcell.Event { return <-p.events }
+			if p.gold >= cheapestShopPrice() {
+				runMerchant(p.screen, next, &p.gold, &invs[i])
+			}
+			if canAffordRepair(p.gold, invs[i]) {
+				runRepairs(p.screen, next, wanderingSmithTitle, &p.gold, &invs[i])
+			}
+		}()
+	}
+	wg.Wait()
+	for i, p := range g.players {
+		if shopping[i] {
+			g.world.Add(p.id, invs[i])
+			g.coopRecalcPlayerMaxHP(p)
+		}
+	}
+}
+
+// coopUseVendingMachine opens vending machine id on p's screen. The other
+// player waits until p walks away from the machine.
+func (g *CoopGame) coopUseVendingMachine(p *coopPlayer, id ecs.EntityID) {
+	invComp := g.world.Get(p.id, component.CInventory)
+	if invComp == nil {
+		return
+	}
+	inv := invComp.(component.Inventory)
+	runVendingMachine(p.screen, func() tcell.Event { return <-p.events }, g.world, id, &p.gold, &inv)
+	g.world.Add(p.id, inv)
+}
+
+// coopDescend runs the between-floor shop, then loads the next floor.
+func (g *CoopGame) coopDescend() {
+	g.runCoopShops()
+	g.loadFloor(g.floor + 1)
+}
+
+// coopEarnGold credits gold to one player's purse and run log.
+func (g *CoopGame) coopEarnGold(p *coopPlayer, n int) {
+	p.gold += n
+	p.runLog.GoldEarned += n
+}
+
+// coopCollectGold picks up any gold pile under p.
+func (g *CoopGame) coopCollectGold(p *coopPlayer) {
+	pos := g.coopPlayerPosition(p)
+	if amount := system.CollectGold(g.world, pos.X, pos.Y); amount > 0 {
+		g.coopEarnGold(p, amount)
+		g.addMessage(fmt.Sprintf("%s scoops up %d gold. (%d💰)", p.class.Name, amount, p.gold))
+	}
+}
+
+// ─── Shared Rendering (used by single-player, coop and MUD) ─────────────────
+
+// DrawShopScreen renders the shop modal with title as its header.
+func DrawShopScreen(screen tcell.Screen, title string, items []assets.ShopEntry, cursor, gold int, statusMsg string) {
+	screen.Clear()
+	sw, _ := screen.Size()
+
+	white := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	gray := tcell.StyleDefault.Foreground(tcell.ColorGray)
+	yellow := tcell.StyleDefault.Foreground(tcell.ColorYellow)
+	green := tcell.StyleDefault.Foreground(tcell.ColorGreen)
+	highlight := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorAqua)
+	dim := tcell.StyleDefault.Foreground(tcell.ColorGray)
+
+	put := func(x, y int, s string, style tcell.Style) { drawScreenText(screen, x, y, s, style) }
+
+	put(0, 0, fmt.Sprintf("%s  [You have %d💰]", title, gold), yellow)
+	hints := "[j/k] Move  [a-h] Buy  [Enter] Buy selected  [Esc] Close"
+	if len([]rune(hints)) < sw {
+		put(sw-len([]rune(hints)), 0, hints, dim)
+	}
+	for x := range sw {
+		screen.SetContent(x, 1, '─', nil, gray)
+	}
+	put(0, 2, "  #  Item                          Price", white)
+	for x := range sw {
+		screen.SetContent(x, 3, '─', nil, gray)
+	}
+
+	for i, item := range items {
+		row := 4 + i
+		sel := cursor == i
+		style := white
+		pfx := "  "
+		if sel {
+			style = highlight
+			pfx = "► "
+		}
+		tag := "consumable"
+		if item.Repair {
+			tag = "repair"
+		} else if !item.IsConsumable {
+			tag = "equip"
+			if item.BonusATK != 0 {
+				tag += fmt.Sprintf(" ATK%+d", item.BonusATK)
+			}
+			if item.BonusDEF != 0 {
+				tag += fmt.Sprintf(" DEF%+d", item.BonusDEF)
+			}
+			if item.BonusMaxHP != 0 {
+				tag += fmt.Sprintf(" HP%+d", item.BonusMaxHP)
+			}
+		}
+		line := fmt.Sprintf("%s[%c] %s %-20s  %3d💰  [%s]",
+			pfx, 'a'+rune(i), item.Glyph, item.Name, item.Price, tag)
+		if item.Limited {
+			if item.Stock == 0 {
+				line += "  SOLD OUT"
+				if !sel {
+					style = dim
+				}
+			} else {
+				line += fmt.Sprintf("  (%d left)", item.Stock)
+			}
+		}
+		put(0, row, line, style)
+	}
+
+	for x := range sw {
+		screen.SetContent(x, 4+len(items), '─', nil, gray)
+	}
+	if statusMsg != "" {
+		put(0, 5+len(items), statusMsg, green)
+	}
+	screen.Show()
+}
@@ -0,0 +1,138 @@
+package game
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/factory"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestShopBuySpendsGoldAndFillsBackpack(t *testing.T) {
+	entry := assets.ShopCatalogue[0]
+	inv := component.Inventory{Capacity: 1}
+
+	gold := entry.Price - 1
+	shopBuy(&gold, &inv, entry)
+	if gold != entry.Price-1 || len(inv.Backpack) != 0 {
+		t.Fatalf("purchase without enough gold went through: gold=%d backpack=%d", gold, len(inv.Backpack))
+	}
+
+	gold = entry.Price * 2
+	shopBuy(&gold, &inv, entry)
+	if gold != entry.Price || len(inv.Backpack) != 1 || inv.Backpack[0].Glyph != entry.Glyph {
+		t.Fatalf("after buying: gold=%d backpack=%v", gold, inv.Backpack)
+	}
+
+	shopBuy(&gold, &inv, entry)
+	if gold != entry.Price {
+		t.Errorf("full backpack must not charge gold; gold=%d", gold)
+	}
+}
+
+// TestDescendOpensShopWhenAffordable verifies that a player with gold meets
+// the merchant between floors and keeps the purchase on the next floor.
+func TestDescendOpensShopWhenAffordable(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	entry := assets.ShopCatalogue[0]
+	g.gold = entry.Price + 5
+
+	ss := g.screen.(tcell.SimulationScreen)
+	ss.InjectKey(tcell.KeyRune, 'a', tcell.ModNone)
+	ss.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	g.descend()
+
+	if g.floor != 2 {
+		t.Fatalf("floor = %d after descend, want 2", g.floor)
+	}
+	if g.gold != 5 {
+		t.Errorf("gold = %d after buying %s, want 5", g.gold, entry.Name)
+	}
+	inv := g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	found := false
+	for _, it := range inv.Backpack {
+		if it.Glyph == entry.Glyph {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("purchased %s missing from backpack on floor 2", entry.Name)
+	}
+}
+
+// TestDescendSkipsShopWhenBroke verifies that the merchant modal is skipped
+// when nothing is affordable (it would otherwise block on input).
+func TestDescendSkipsShopWhenBroke(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	g.descend()
+	if g.floor != 2 {
+		t.Fatalf("floor = %d after descend, want 2", g.floor)
+	}
+}
+
+func TestWalkingOntoGoldPileCollectsIt(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	pos := g.playerPosition()
+	if !g.gmap.IsWalkable(pos.X+1, pos.Y) {
+		t.Skip("tile east of spawn is not walkable")
+	}
+	pile := factory.NewGoldPile(g.world, 7, pos.X+1, pos.Y)
+
+	g.processAction(ActionMoveE)
+
+	if g.gold != 7 || g.runLog.GoldEarned != 7 {
+		t.Errorf("gold=%d earned=%d, want 7/7", g.gold, g.runLog.GoldEarned)
+	}
+	if g.world.Alive(pile) {
+		t.Error("gold pile should be removed once collected")
+	}
+}
+
+func TestKillAwardsGold(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	pos := g.playerPosition()
+	enemy := g.world.CreateEntity()
+	g.world.Add(enemy, component.Position{X: pos.X + 1, Y: pos.Y})
+	g.world.Add(enemy, component.Health{Current: 1, Max: 1})
+	g.world.Add(enemy, component.Combat{})
+	g.world.Add(enemy, component.AI{Behavior: component.BehaviorChase, SightRange: 5})
+	g.world.Add(enemy, component.Renderable{Glyph: "🦀"})
+	g.world.Add(enemy, component.TagBlocking{})
+	g.world.Add(enemy, component.Effects{})
+
+	g.processAction(ActionMoveE)
+
+	if g.world.Alive(enemy) {
+		t.Fatal("1 HP enemy should die to the player's attack")
+	}
+	if g.gold < 1 || g.runLog.GoldEarned != g.gold {
+		t.Errorf("gold=%d earned=%d after kill, want a bounty of at least 1", g.gold, g.runLog.GoldEarned)
+	}
+}
+
+// TestVendingMachinePurchase verifies that bumping a vending machine opens
+// its stock and that a purchase charges gold and depletes the machine.
+func TestVendingMachinePurchase(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	pos := g.playerPosition()
+	id := factory.NewVendingMachine(g.world, g.combatRng, pos.X+1, pos.Y)
+	slot := g.world.Get(id, component.CVending).(component.VendingMachine).Stock[0]
+	g.gold = slot.Price
+
+	ss := g.screen.(tcell.SimulationScreen)
+	ss.InjectKey(tcell.KeyRune, 'a', tcell.ModNone)
+	ss.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	g.interactFurniture(id)
+
+	if g.gold != 0 {
+		t.Errorf("gold = %d after buying %s, want 0", g.gold, slot.Name)
+	}
+	inv := g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	if len(inv.Backpack) == 0 || inv.Backpack[len(inv.Backpack)-1].Glyph != slot.Glyph {
+		t.Errorf("backpack %v should end with %s", inv.Backpack, slot.Glyph)
+	}
+	if left := len(g.world.Get(id, component.CVending).(component.VendingMachine).Stock); left != factory.VendingStockSize-1 {
+		t.Errorf("machine stock = %d after one sale, want %d", left, factory.VendingStockSize-1)
+	}
+}
@@ -0,0 +1,246 @@
+package game
+
+import (
+	"fmt"
+
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// SimTimeout is the cause recorded for a simulated run that used up its
+// turns without winning or dying.
+const SimTimeout = "timeout"
+
+// SimResult is the outcome of one run played by the Simulate bot.
+type SimResult struct {
+	Class        string `json:"class"`
+	Seed         int64  `json:"seed"`
+	Victory      bool   `json:"victory"`
+	Floor        int    `json:"floor"` // deepest floor reached
+	Turns        int    `json:"turns"`
+	Level        int    `json:"level"`
+	Kills        int    `json:"kills"`
+	CauseOfDeath string `json:"cause_of_death"` // killer, SimTimeout, or "" on victory
+}
+
+// botScreen is the screen a simulated run's modals draw to; the map and HUD
+// are not drawn at all. It answers every modal the bot's turns open by
+// pressing Enter and Escape in turn, which takes the highlighted choice in
+// pickers and closes shops and prompts.
+type botScreen struct {
+	tcell.SimulationScreen
+	enter bool
+}
+
+func (s *botScreen) PollEvent() tcell.Event {
+	s.enter = !s.enter
+	if s.enter {
+		return tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)
+	}
+	return tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone)
+}
+
+// Simulate plays one run of the class with classID, seeded by seed, with a
+// simple bot at the controls and no real screen: it walks to the stairs
+// down, fights what blocks its way or will not let it go, and on the final
+// floor hunts the boss. The run ends in victory, death, or after maxTurns
+// turns. Nothing is saved to the run history.
+func Simulate(classID string, seed int64, maxTurns int) (SimResult, error) {
+	class, ok := findClass(classID)
+	if !ok {
+		return SimResult{}, fmt.Errorf("unknown class %q", classID)
+	}
+	ss := tcell.NewSimulationScreen("UTF-8")
+	if err := ss.Init(); err != nil {
+		return SimResult{}, fmt.Errorf("init screen: %w", err)
+	}
+	defer ss.Fini()
+	ss.SetSize(80, 24)
+
+	g := &Game{
+		screen:   &botScreen{SimulationScreen: ss},
+		headless: true,
+	}
+	g.genRng, g.combatRng = SplitSeed(seed)
+	g.resetForRun()
+	g.profile.RiskConfirmOff = true
+	g.selectedClass = class
+	g.fovRadius = class.FOVRadius
+	g.runLog.Class = class.Name
+	g.startRun()
+	// Actions that take no turn (a level-up dismissed, a blocked move) still
+	// count here, so a stuck bot cannot loop forever.
+	for steps := 0; steps < 2*maxTurns && g.runLog.TurnsPlayed < maxTurns; steps++ {
+		if g.state == StateDead || g.state == StateVictory {
+			break
+		}
+		g.playAction(g.botAction())
+	}
+
+	res := SimResult{
+		Class:   classID,
+		Seed:    seed,
+		Victory: g.state == StateVictory,
+		Floor:   g.runLog.FloorsReached,
+		Turns:   g.runLog.TurnsPlayed,
+		Level:   g.playerLevel,
+	}
+	for _, n := range g.runLog.EnemiesKilled {
+		res.Kills += n
+	}
+	switch g.state {
+	case StateVictory:
+	case StateDead:
+		res.CauseOfDeath = damageSourceName(g.runLog.CauseOfDeath)
+	default:
+		res.CauseOfDeath = SimTimeout
+	}
+	return res, nil
+}
+
+// findClass finds the class with id in assets.Classes.
+func findClass(id string) (assets.ClassDef, bool) {
+	for _, c := range assets.Classes {
+		if c.ID == id {
+			return c, true
+		}
+	}
+	return assets.ClassDef{}, false
+}
+
+// botAction picks the simulation bot's next action. It spends pending level
+// ups, takes the stairs it stands on, and otherwise steps toward its goal. An
+// enemy on that step is attacked; one next to the bot that the step would not
+// shake off corners it, and the bot turns to fight, with its ability when
+// ready. With no way forward it wanders.
+func (g *Game) botAction() Action {
+	if g.pendingLevels > 0 {
+		return ActionLevelUp
+	}
+	pos := g.playerPosition()
+	if g.gmap.At(pos.X, pos.Y).Kind == gamemap.TileStairsDown && g.floor < MaxFloors {
+		return ActionDescend
+	}
+
+	var adjacent []ecs.EntityID
+	for _, id := range g.world.Query(component.CAI, component.CPosition) {
+		if p := g.world.Get(id, component.CPosition).(component.Position); g.hostileEnemy(id) && chebyshev(pos, p) == 1 {
+			adjacent = append(adjacent, id)
+		}
+	}
+	var step component.Position
+	haveStep := false
+	if goal, ok := g.botGoal(); ok {
+		step, haveStep = g.botStep(pos, goal)
+	}
+
+	if haveStep && !g.botCornered(step, adjacent) {
+		return deltaToAction(step.X-pos.X, step.Y-pos.Y)
+	}
+	if len(adjacent) > 0 {
+		if g.selectedClass.AbilityCooldown > 0 && g.specialSpent < g.selectedClass.MaxCharges() {
+			return ActionSpecialAbility
+		}
+		p := g.world.Get(adjacent[0], component.CPosition).(component.Position)
+		return deltaToAction(p.X-pos.X, p.Y-pos.Y)
+	}
+	return ActionMoveN + Action(g.combatRng.Intn(int(ActionMoveSW-ActionMoveN)+1))
+}
+
+// botGoal is where the bot is heading: the stairs down, or on the final
+// floor the boss, falling back to the nearest enemy.
+func (g *Game) botGoal() (component.Position, bool) {
+	if g.floor < MaxFloors {
+		for y := range g.gmap.Height {
+			for x := range g.gmap.Width {
+				if g.gmap.At(x, y).Kind == gamemap.TileStairsDown {
+					return component.Position{X: x, Y: y}, true
+				}
+			}
+		}
+		return component.Position{}, false
+	}
+	pos, boss := g.playerPosition(), assets.BossGlyph(g.floor)
+	var goal component.Position
+	best := -1
+	for _, id := range g.world.Query(component.CAI, component.CPosition, component.CRenderable) {
+		if !g.hostileEnemy(id) {
+			continue
+		}
+		p := g.world.Get(id, component.CPosition).(component.Position)
+		if g.world.Get(id, component.CRenderable).(component.Renderable).Glyph == boss {
+			return p, true
+		}
+		if d := chebyshev(pos, p); best < 0 || d < best {
+			goal, best = p, d
+		}
+	}
+	return goal, best >= 0
+}
+
+// botStep returns the first step of a shortest walk from from to to through
+// doors the bot can open, cutting no corners. Enemies are ignored, as the
+// bot fights its way through them; furniture, NPCs and allies are walked
+// around.
+func (g *Game) botStep(from, to component.Position) (component.Position, bool) {
+	obstacles := map[component.Position]bool{}
+	for _, c := range []ecs.ComponentType{component.CFurniture, component.CNPC, component.CTagBlocking} {
+		for _, id := range g.world.Query(c, component.CPosition) {
+			if id != g.playerID && !g.hostileEnemy(id) {
+				obstacles[g.world.Get(id, component.CPosition).(component.Position)] = true
+			}
+		}
+	}
+	passable := func(x, y int) bool {
+		t := g.gmap.At(x, y)
+		return (t.Walkable || (t.Kind == gamemap.TileDoor && !t.Locked)) && !obstacles[component.Position{X: x, Y: y}]
+	}
+	key := func(p component.Position) int { return p.Y*g.gmap.Width + p.X }
+	cameFrom := map[int]component.Position{key(from): from}
+	queue := []component.Position{from}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur == to {
+			for cameFrom[key(cur)] != from {
+				cur = cameFrom[key(cur)]
+			}
+			return cur, cur != from
+		}
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				next := component.Position{X: cur.X + dx, Y: cur.Y + dy}
+				if _, seen := cameFrom[key(next)]; seen || !g.gmap.InBounds(next.X, next.Y) || !passable(next.X, next.Y) {
+					continue
+				}
+				if dx != 0 && dy != 0 && (!passable(cur.X+dx, cur.Y) || !passable(cur.X, cur.Y+dy)) {
+					continue
+				}
+				cameFrom[key(next)] = cur
+				queue = append(queue, next)
+			}
+		}
+	}
+	return component.Position{}, false
+}
+
+// botCornered reports whether stepping to step leaves one of the adjacent
+// enemies still in reach. An enemy standing on step does not count: the step
+// attacks it.
+func (g *Game) botCornered(step component.Position, adjacent []ecs.EntityID) bool {
+	for _, id := range adjacent {
+		if p := g.world.Get(id, component.CPosition).(component.Position); p != step && chebyshev(step, p) <= 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// chebyshev is the number of king's moves between a and b.
+func chebyshev(a, b component.Position) int {
+	return max(a.X-b.X, b.X-a.X, a.Y-b.Y, b.Y-a.Y)
+}
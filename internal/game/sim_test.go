@@ -0,0 +1,68 @@
+package game
+
+import (
+	"testing"
+
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/gamemap"
+	"emoji-roguelike/internal/render"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestSimulateEndsTheRun(t *testing.T) {
+	r, err := Simulate("warden", 1, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Class != "warden" || r.Seed != 1 || r.Floor < 1 || r.Turns > 300 {
+		t.Errorf("result = %+v", r)
+	}
+	if !r.Victory && r.CauseOfDeath == "" {
+		t.Error("a run that was not won needs a cause")
+	}
+	if _, err := Simulate("jester", 1, 10); err == nil {
+		t.Error("Simulate accepted an unknown class")
+	}
+}
+
+func TestBotHeadsForTheStairs(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	killAllEnemies(g)
+	pos := g.playerPosition()
+	goal, ok := g.botGoal()
+	if !ok || g.gmap.At(goal.X, goal.Y).Kind != gamemap.TileStairsDown {
+		t.Fatalf("bot goal %v, %v; want the stairs down", goal, ok)
+	}
+	step, ok := g.botStep(pos, goal)
+	if !ok || chebyshev(pos, step) != 1 {
+		t.Fatalf("first step %v from %v; want a neighbouring tile", step, pos)
+	}
+	if a := g.botAction(); a != deltaToAction(step.X-pos.X, step.Y-pos.Y) {
+		t.Errorf("bot action %v; want the step toward the stairs", a)
+	}
+
+	g.world.Add(g.playerID, component.Position{X: goal.X, Y: goal.Y})
+	if a := g.botAction(); a != ActionDescend {
+		t.Errorf("bot on the stairs chose %v; want to descend", a)
+	}
+}
+
+func TestHeadlessGameDrawsNothing(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	g.headless = true
+	g.loadFloor(2)
+	if _, ok := g.renderer.(render.NopRenderer); !ok {
+		t.Fatalf("headless renderer is %T; want render.NopRenderer", g.renderer)
+	}
+	ss := g.screen.(tcell.SimulationScreen)
+	ss.Clear()
+	g.drawPlay()
+	ss.Show()
+	cells, _, _ := ss.GetContents()
+	for _, c := range cells {
+		if len(c.Runes) > 0 && c.Runes[0] != ' ' {
+			t.Fatalf("headless drawPlay drew %q", string(c.Runes))
+		}
+	}
+}
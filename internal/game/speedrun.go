@@ -0,0 +1,154 @@
+package game
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// scoreScale keeps scores in whole numbers; see RunLog.score.
+const scoreScale = 1000
+
+// score rates a run for the leaderboard: floors reached × kills ÷ turns,
+// scaled by scoreScale. Fast, bloody, deep runs score highest.
+func (rl RunLog) score() int {
+	kills := 0
+	for _, n := range rl.EnemiesKilled {
+		kills += n
+	}
+	return rl.FloorsReached * kills * scoreScale / max(rl.TurnsPlayed, 1)
+}
+
+// grow returns s extended with zeros so that index i is valid.
+func grow(s []int, i int) []int {
+	for len(s) <= i {
+		s = append(s, 0)
+	}
+	return s
+}
+
+// addFloorTurns credits n turns to floor in FloorTurns. The tutorial floor
+// is not tracked.
+func (rl *RunLog) addFloorTurns(floor, n int) {
+	if floor < 1 || n <= 0 {
+		return
+	}
+	rl.FloorTurns = grow(rl.FloorTurns, floor-1)
+	rl.FloorTurns[floor-1] += n
+}
+
+// floorTurns returns the turns credited to floor so far.
+func (rl *RunLog) floorTurns(floor int) int {
+	if floor < 1 || floor > len(rl.FloorTurns) {
+		return 0
+	}
+	return rl.FloorTurns[floor-1]
+}
+
+// addPlayTime credits wall-clock play time to the current floor. Only time
+// spent on the map screen is counted, so menus and other modals pause the
+// clock.
+func (g *Game) addPlayTime(d time.Duration) {
+	if g.floor < 1 {
+		return
+	}
+	for len(g.floorPlay) < g.floor {
+		g.floorPlay = append(g.floorPlay, 0)
+	}
+	g.floorPlay[g.floor-1] += d
+}
+
+// playAction runs one player action from the map screen and keeps the
+// per-floor turn count, lingering, bell cues, floor clear bonus and tutorial
+// progress up to date.
+func (g *Game) playAction(action Action) {
+	floor, turns := g.floor, g.runLog.TurnsPlayed
+	floorTurns := g.runLog.floorTurns(floor)
+	g.processAction(action)
+	g.runLog.addFloorTurns(floor, g.runLog.TurnsPlayed-turns)
+	g.noteLinger(floor, floorTurns)
+	g.soundCues()
+	g.checkFloorCleared()
+	if g.tutorial != nil {
+		g.advanceTutorial()
+	}
+}
+
+// finalizeRunLog stamps the end-of-run fields shared by every way a run ends.
+func (g *Game) finalizeRunLog() {
+	g.runLog.Timestamp = time.Now()
+	g.runLog.Level = g.playerLevel
+	g.runLog.SkillsLearned = g.learnedSkills
+	g.runLog.FloorTimes = make([]int, len(g.floorPlay))
+	for i, d := range g.floorPlay {
+		g.runLog.FloorTimes[i] = int(d.Round(time.Second) / time.Second)
+	}
+	g.runLog.Score = g.runLog.score()
+	if g.keepMessageLog {
+		g.runLog.MessageLogPath = saveMessageLog(g.runLog.Timestamp, g.narrative)
+	}
+}
+
+// formatDuration shows seconds as m:ss.
+func formatDuration(secs int) string {
+	return fmt.Sprintf("%d:%02d", secs/60, secs%60)
+}
+
+// floorTimeEntries lists the time and turns spent on each floor played, e.g.
+// "F1 2:31/143", and the totals, for the end screen.
+func (rl RunLog) floorTimeEntries() (entries []string, totalSecs, totalTurns int) {
+	for i, secs := range rl.FloorTimes {
+		t := 0
+		if i < len(rl.FloorTurns) {
+			t = rl.FloorTurns[i]
+		}
+		if secs == 0 && t == 0 {
+			continue
+		}
+		entries = append(entries, fmt.Sprintf("F%d %s/%d", i+1, formatDuration(secs), t))
+		totalSecs += secs
+		totalTurns += t
+	}
+	return entries, totalSecs, totalTurns
+}
+
+// loadRunLogs reads every run saved in runs.jsonl, skipping malformed lines.
+func loadRunLogs() []RunLog {
+	dir, err := runLogDir()
+	if err != nil {
+		return nil
+	}
+	f, err := os.Open(filepath.Join(dir, "runs.jsonl"))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var runs []RunLog
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		var rl RunLog
+		if json.Unmarshal(sc.Bytes(), &rl) == nil {
+			runs = append(runs, rl)
+		}
+	}
+	return runs
+}
+
+// leaderboardRank returns where score places among the saved runs (1 = best)
+// and how many runs were compared. The current run is expected to be saved
+// already, so it counts itself.
+func leaderboardRank(score int) (rank, total int) {
+	runs := loadRunLogs()
+	rank = 1
+	for _, rl := range runs {
+		if rl.Score > score {
+			rank++
+		}
+	}
+	return rank, max(len(runs), 1)
+}
@@ -0,0 +1,64 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRunLogScore(t *testing.T) {
+	rl := RunLog{FloorsReached: 4, TurnsPlayed: 800, EnemiesKilled: map[string]int{"🦀": 10, "🐍": 10}}
+	if got := rl.score(); got != 100 { // 4 × 20 × 1000 ÷ 800
+		t.Errorf("score = %d; want 100", got)
+	}
+	if got := (RunLog{FloorsReached: 1}).score(); got != 0 {
+		t.Errorf("score with no kills or turns = %d; want 0", got)
+	}
+}
+
+func TestPlayActionCountsTurnsPerFloor(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	g.playAction(ActionWait)
+	g.playAction(ActionWait)
+	g.playAction(ActionToggleFlash) // free action: no turn
+	if !reflect.DeepEqual(g.runLog.FloorTurns, []int{2}) {
+		t.Errorf("FloorTurns = %v; want [2]", g.runLog.FloorTurns)
+	}
+	g.floor = 3
+	g.playAction(ActionWait)
+	if !reflect.DeepEqual(g.runLog.FloorTurns, []int{2, 0, 1}) {
+		t.Errorf("FloorTurns = %v; want [2 0 1]", g.runLog.FloorTurns)
+	}
+}
+
+func TestPlayTimeIsPerFloorAndSkipsTutorial(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	g.floor = tutorialFloor
+	g.addPlayTime(time.Hour)
+	g.floor = 2
+	g.addPlayTime(90 * time.Second)
+	g.addPlayTime(1400 * time.Millisecond)
+	g.finalizeRunLog()
+	if !reflect.DeepEqual(g.runLog.FloorTimes, []int{0, 91}) {
+		t.Errorf("FloorTimes = %v; want [0 91]", g.runLog.FloorTimes)
+	}
+
+	g.runLog.FloorTurns = []int{0, 40}
+	entries, secs, turns := g.runLog.floorTimeEntries()
+	if !reflect.DeepEqual(entries, []string{"F2 1:31/40"}) || secs != 91 || turns != 40 {
+		t.Errorf("floorTimeEntries = %v, %d, %d; want [F2 1:31/40], 91, 40", entries, secs, turns)
+	}
+}
+
+func TestLeaderboardRank(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	if rank, total := leaderboardRank(50); rank != 1 || total != 1 {
+		t.Errorf("empty history: rank %d of %d; want 1 of 1", rank, total)
+	}
+	for _, s := range []int{10, 80, 50, 120} {
+		saveRunLog(RunLog{Score: s})
+	}
+	if rank, total := leaderboardRank(50); rank != 3 || total != 4 {
+		t.Errorf("rank %d of %d; want 3 of 4", rank, total)
+	}
+}
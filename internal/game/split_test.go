@@ -0,0 +1,49 @@
+package game
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/factory"
+	"testing"
+)
+
+// TestBumpAttackSplitsLumenOoze checks that wounding a Lumen Ooze leaves two
+// oozes sharing its remaining HP by the end of the turn.
+func TestBumpAttackSplitsLumenOoze(t *testing.T) {
+	g := newAbilityTestGame(t, "arcanist")
+	for _, id := range g.world.Query(component.CAI) {
+		g.world.DestroyEntity(id)
+	}
+	pos := g.playerPosition()
+	var action Action
+	for _, a := range []Action{ActionMoveE, ActionMoveW, ActionMoveS, ActionMoveN} {
+		if dx, dy := actionToDelta(a); g.gmap.IsWalkable(pos.X+dx, pos.Y+dy) {
+			action = a
+			break
+		}
+	}
+	dx, dy := actionToDelta(action)
+	entry := assets.EnemyTables[2][len(assets.EnemyTables[2])-1]
+	if entry.Glyph != assets.GlyphLumenOoze {
+		t.Fatalf("expected the Lumen Ooze last on floor 2; got %s", entry.Name)
+	}
+	entry.MaxHP = 100 // survive the hit however hard it lands
+	factory.NewEnemy(g.world, entry, pos.X+dx, pos.Y+dy, nil)
+
+	g.processAction(action)
+
+	oozes, total := 0, 0
+	for _, id := range g.world.Query(component.CSplitter) {
+		oozes++
+		total += g.world.Get(id, component.CHealth).(component.Health).Current
+	}
+	if oozes != 2 {
+		t.Fatalf("got %d oozes after the hit; want 2", oozes)
+	}
+	if total >= 100 {
+		t.Errorf("oozes share %d HP; want less than the 100 they started with", total)
+	}
+	if !hasMessage(g, "splits in two") {
+		t.Error("expected a split message")
+	}
+}
@@ -0,0 +1,65 @@
+package game
+
+import (
+	"testing"
+
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+	"emoji-roguelike/internal/system"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// newStrikeGame returns a game whose only enemy stands east of the player,
+// ready to attack on the next turn.
+func newStrikeGame(t *testing.T) (*Game, ecs.EntityID) {
+	t.Helper()
+	g := newAbilityTestGame(t, "warden")
+	for _, id := range g.world.Query(component.CAI) {
+		g.world.DestroyEntity(id)
+	}
+	pos := g.playerPosition()
+	g.gmap.Set(pos.X+1, pos.Y, gamemap.MakeFloor())
+	system.UpdateFOV(g.world, g.gmap, g.playerID, g.effectiveFOVRadius())
+	crab := g.world.CreateEntity()
+	g.world.Add(crab, component.Position{X: pos.X + 1, Y: pos.Y})
+	g.world.Add(crab, component.AI{Behavior: component.BehaviorChase, SightRange: 8})
+	g.world.Add(crab, component.Combat{Attack: 1})
+	g.world.Add(crab, component.Health{Current: 50, Max: 50})
+	g.world.Add(crab, component.TagBlocking{})
+	g.world.Add(crab, component.Renderable{Glyph: "🦀", FGColor: tcell.ColorRed, RenderOrder: 5})
+	return g, crab
+}
+
+// strikeShown reports whether the enemy's cell has the strike background.
+func strikeShown(g *Game, id ecs.EntityID) bool {
+	pos := g.world.Get(id, component.CPosition).(component.Position)
+	sx, sy, _ := g.renderer.WorldToScreen(pos.X, pos.Y)
+	_, _, style, _ := g.screen.GetContent(sx, sy)
+	_, bg, _ := style.Decompose()
+	return bg == tcell.ColorRed
+}
+
+func TestEnemyAttackHighlightsForOneFrame(t *testing.T) {
+	g, crab := newStrikeGame(t)
+	g.processAction(ActionWait)
+	g.drawPlay()
+	if !strikeShown(g, crab) {
+		t.Fatal("the frame after an enemy attacks should highlight it")
+	}
+	g.drawPlay()
+	if strikeShown(g, crab) {
+		t.Error("the strike highlight should last only one frame")
+	}
+}
+
+func TestEnemyAttackHighlightFollowsFlashToggle(t *testing.T) {
+	g, crab := newStrikeGame(t)
+	g.hitFlashOff = true
+	g.processAction(ActionWait)
+	g.drawPlay()
+	if strikeShown(g, crab) {
+		t.Error("turning the hit flash off should also turn off strike highlights")
+	}
+}
@@ -0,0 +1,126 @@
+package game
+
+import (
+	"emoji-roguelike/assets"
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// subclassFloor is the milestone floor whose arrival offers a subclass perk.
+const subclassFloor = 6
+
+// subclassOffers is the most perks shown at once.
+const subclassOffers = 3
+
+// layerPerk returns a copy of class with donor's signature passive layered
+// on top: kill-restore and kill-heal chance stack, and regen takes the
+// shorter of the two intervals.
+func layerPerk(class, donor assets.ClassDef) assets.ClassDef {
+	class.KillRestoreHP += donor.KillRestoreHP
+	class.KillHealChance = min(class.KillHealChance+donor.KillHealChance, 100)
+	if donor.PassiveRegen > 0 && (class.PassiveRegen == 0 || donor.PassiveRegen < class.PassiveRegen) {
+		class.PassiveRegen = donor.PassiveRegen
+	}
+	return class
+}
+
+// subclassPerks returns the other classes whose passive would improve class,
+// in assets.Classes order.
+func subclassPerks(class assets.ClassDef) []assets.ClassDef {
+	var perks []assets.ClassDef
+	for _, donor := range assets.Classes {
+		if donor.ID == class.ID {
+			continue
+		}
+		got := layerPerk(class, donor)
+		if got.KillRestoreHP != class.KillRestoreHP || got.KillHealChance != class.KillHealChance ||
+			got.PassiveRegen != class.PassiveRegen {
+			perks = append(perks, donor)
+		}
+	}
+	return perks
+}
+
+// offerSubclass opens the subclass perk modal when a descent from floor from
+// has reached or passed subclassFloor and no perk has been taken yet.
+func (g *Game) offerSubclass(from int) {
+	if from < subclassFloor && g.floor >= subclassFloor && g.runLog.Subclass == "" {
+		g.runSubclassScreen()
+	}
+}
+
+// runSubclassScreen offers up to subclassOffers perks drawn from other
+// classes' passives. Enter layers the highlighted one onto the selected class
+// and records it in the run log; Escape declines.
+func (g *Game) runSubclassScreen() {
+	perks := subclassPerks(g.selectedClass)
+	if len(perks) == 0 {
+		return
+	}
+	if len(perks) > subclassOffers {
+		g.combatRng.Shuffle(len(perks), func(i, j int) { perks[i], perks[j] = perks[j], perks[i] })
+		perks = perks[:subclassOffers]
+	}
+
+	selected := 0
+	for {
+		drawSubclassScreen(g.screen, perks, selected)
+		g.screen.Show()
+
+		ev, ok := g.screen.PollEvent().(*tcell.EventKey)
+		if !ok {
+			g.screen.Sync()
+			continue
+		}
+		switch ev.Key() {
+		case tcell.KeyUp:
+			selected = (selected - 1 + len(perks)) % len(perks)
+		case tcell.KeyDown:
+			selected = (selected + 1) % len(perks)
+		case tcell.KeyEnter:
+			donor := perks[selected]
+			g.selectedClass = layerPerk(g.selectedClass, donor)
+			g.runLog.Subclass = donor.Name
+			g.addMessage(fmt.Sprintf("You take up the %s's art: %s", donor.Name, donor.PassiveDesc))
+			return
+		case tcell.KeyEscape:
+			return
+		}
+		switch ev.Rune() {
+		case 'k', 'K':
+			selected = (selected - 1 + len(perks)) % len(perks)
+		case 'j', 'J':
+			selected = (selected + 1) % len(perks)
+		}
+	}
+}
+
+// drawSubclassScreen renders the subclass perk modal with selected
+// highlighted.
+func drawSubclassScreen(screen tcell.Screen, perks []assets.ClassDef, selected int) {
+	screen.Clear()
+	sw, sh := screen.Size()
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true)
+	normalStyle := tcell.StyleDefault.Foreground(tcell.ColorSilver)
+	selectedStyle := tcell.StyleDefault.Foreground(tcell.ColorGreen).Bold(true)
+	descStyle := tcell.StyleDefault.Foreground(tcell.ColorAqua)
+
+	width := 56
+	x0 := max((sw-width)/2, 0)
+	y0 := max((sh-(4+len(perks)*3))/2, 0)
+
+	title := "A second path opens — choose a subclass perk"
+	putScreenText(screen, x0+(width-len([]rune(title)))/2, y0, title, titleStyle)
+	for i, donor := range perks {
+		y := y0 + 2 + i*3
+		style, prefix := normalStyle, "  "
+		if i == selected {
+			style, prefix = selectedStyle, "> "
+		}
+		putScreenText(screen, x0+2, y, fmt.Sprintf("%s%s %s", prefix, donor.Emoji, donor.Name), style)
+		putScreenText(screen, x0+5, y+1, donor.PassiveDesc, descStyle)
+	}
+	putScreenText(screen, x0+2, y0+2+len(perks)*3, "[j/k] select  [Enter] choose  [Esc] decline", normalStyle)
+}
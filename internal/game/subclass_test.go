@@ -0,0 +1,72 @@
+package game
+
+import (
+	"testing"
+
+	"emoji-roguelike/assets"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func classByID(t *testing.T, id string) assets.ClassDef {
+	t.Helper()
+	for _, c := range assets.Classes {
+		if c.ID == id {
+			return c
+		}
+	}
+	t.Fatalf("class %q not found", id)
+	return assets.ClassDef{}
+}
+
+func TestSubclassPerksSkipNonImproving(t *testing.T) {
+	// Symbiont regen (every 5 turns) already beats the Construct's (every 8).
+	var ids []string
+	for _, c := range subclassPerks(classByID(t, "symbiont")) {
+		ids = append(ids, c.ID)
+	}
+	want := []string{"arcanist", "revenant"}
+	if len(ids) != len(want) || ids[0] != want[0] || ids[1] != want[1] {
+		t.Errorf("symbiont perks = %v; want %v", ids, want)
+	}
+}
+
+func TestLayerPerkStacksPassives(t *testing.T) {
+	got := layerPerk(classByID(t, "construct"), classByID(t, "symbiont"))
+	if got.PassiveRegen != 5 {
+		t.Errorf("PassiveRegen = %d; want the symbiont's shorter 5", got.PassiveRegen)
+	}
+	got = layerPerk(classByID(t, "revenant"), classByID(t, "arcanist"))
+	if got.KillRestoreHP != 3 || got.KillHealChance != 30 {
+		t.Errorf("KillRestoreHP %d, KillHealChance %d; want 3 and 30", got.KillRestoreHP, got.KillHealChance)
+	}
+}
+
+// TestDescendToMilestoneOffersSubclass checks that reaching subclassFloor
+// opens the perk modal and that the chosen perk is applied and logged.
+func TestDescendToMilestoneOffersSubclass(t *testing.T) {
+	g := newAbilityTestGame(t, "revenant")
+	g.floor = subclassFloor - 1
+	injectKeys(g, tcell.KeyDown, tcell.KeyEnter) // arcanist, then construct
+	g.descend()
+
+	if g.floor != subclassFloor {
+		t.Fatalf("floor = %d; want %d", g.floor, subclassFloor)
+	}
+	if g.runLog.Subclass != "Chrono Construct" {
+		t.Errorf("runLog.Subclass = %q; want Chrono Construct", g.runLog.Subclass)
+	}
+	if g.selectedClass.PassiveRegen != 8 {
+		t.Errorf("PassiveRegen = %d; want 8 from the construct perk", g.selectedClass.PassiveRegen)
+	}
+}
+
+func TestSubclassScreenEscapeDeclines(t *testing.T) {
+	g := newAbilityTestGame(t, "dancer")
+	injectKeys(g, tcell.KeyEscape)
+	g.runSubclassScreen()
+
+	if g.runLog.Subclass != "" || g.selectedClass.KillRestoreHP != 0 {
+		t.Errorf("Escape took a perk: subclass %q, KillRestoreHP %d", g.runLog.Subclass, g.selectedClass.KillRestoreHP)
+	}
+}
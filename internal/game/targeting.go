@@ -0,0 +1,97 @@
+package game
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// blinkRange is the maximum distance in tiles of the Arcanist's targeted rift.
+const blinkRange = 8
+
+// runTargeting shows a cursor over the map starting at the player's position
+// and blocks until the player confirms a tile accepted by valid (Enter) or
+// cancels (Escape / q). Returns ok=false on cancel.
+func (g *Game) runTargeting(prompt string, valid func(x, y int) bool) (int, int, bool) {
+	pos := g.playerPosition()
+	cx, cy := pos.X, pos.Y
+	for {
+		g.drawTargeting(prompt, cx, cy, valid(cx, cy))
+
+		ev := g.screen.PollEvent()
+		switch ev := ev.(type) {
+		case *tcell.EventResize:
+			g.screen.Sync()
+		case *tcell.EventKey:
+			switch ev.Key() {
+			case tcell.KeyEscape:
+				return 0, 0, false
+			case tcell.KeyEnter:
+				if valid(cx, cy) {
+					return cx, cy, true
+				}
+				continue
+			}
+			if ev.Key() == tcell.KeyRune && (ev.Rune() == 'q' || ev.Rune() == 'Q') {
+				return 0, 0, false
+			}
+			dx, dy := actionToDelta(keyToAction(ev))
+			if g.gmap.InBounds(cx+dx, cy+dy) {
+				cx, cy = cx+dx, cy+dy
+			}
+		}
+	}
+}
+
+// drawTargeting renders the map with the targeting cursor highlighted green
+// (valid) or red (invalid) and a prompt on the top row.
+func (g *Game) drawTargeting(prompt string, cx, cy int, ok bool) {
+	pos := g.playerPosition()
+	g.renderer.CenterOn(pos.X, pos.Y)
+	g.renderer.DrawFrame(g.world, g.gmap, g.playerID)
+
+	bg := tcell.ColorDarkRed
+	if ok {
+		bg = tcell.ColorDarkGreen
+	}
+	if sx, sy, visible := g.renderer.WorldToScreen(cx, cy); visible {
+		for col := sx; col < sx+2; col++ {
+			mainc, combc, style, _ := g.screen.GetContent(col, sy)
+			g.screen.SetContent(col, sy, mainc, combc, style.Background(bg))
+		}
+	}
+	g.putText(0, 0, prompt, tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true))
+	g.screen.Show()
+}
+
+// validBlinkTarget reports whether (x, y) is a legal Dimensional Rift
+// destination: visible, walkable, unoccupied and within blinkRange.
+func (g *Game) validBlinkTarget(x, y int) bool {
+	pos := g.playerPosition()
+	if x == pos.X && y == pos.Y {
+		return false
+	}
+	dx, dy := x-pos.X, y-pos.Y
+	if dx*dx+dy*dy > blinkRange*blinkRange {
+		return false
+	}
+	if !g.gmap.IsWalkable(x, y) || !g.gmap.At(x, y).Visible {
+		return false
+	}
+	for _, ct := range []ecs.ComponentType{component.CTagBlocking, component.CFurniture, component.CNPC} {
+		for _, id := range g.world.Query(ct, component.CPosition) {
+			p := g.world.Get(id, component.CPosition).(component.Position)
+			if p.X == x && p.Y == y {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// blinkPlayer moves the player to (x, y) and refreshes FOV.
+func (g *Game) blinkPlayer(x, y int) {
+	g.world.Add(g.playerID, component.Position{X: x, Y: y})
+	g.updateFOV()
+}
@@ -0,0 +1,130 @@
+package game
+
+import (
+	"strings"
+	"time"
+
+	"emoji-roguelike/assets"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Title screen timing: the opening lore types out titleCharsPerFrame runes
+// every titleFrameInterval while the Spire shimmers.
+const (
+	titleFrameInterval = 40 * time.Millisecond
+	titleCharsPerFrame = 2
+)
+
+// titleSpire is the Spire drawn above the opening lore, apex first. Each row
+// is odd-width so it centres cleanly.
+var titleSpire = []string{
+	"|",
+	"/_\\",
+	"|▒|",
+	"/▒▒▒\\",
+	"|▒█▒|",
+	"/▒▒█▒▒\\",
+	"|▒▒█▒▒|",
+	"/▒▒▒█▒▒▒\\",
+	"|▒▒▒█▒▒▒|",
+	"/▒▒▒▒█▒▒▒▒\\",
+	"▀▀▀▀▀▀▀▀▀▀▀▀▀▀▀",
+}
+
+// titlePrism is the band of colours that climbs the Spire.
+var titlePrism = []tcell.Color{
+	tcell.NewRGBColor(255, 80, 80),
+	tcell.NewRGBColor(255, 170, 60),
+	tcell.NewRGBColor(255, 235, 90),
+	tcell.NewRGBColor(100, 255, 160),
+	tcell.NewRGBColor(80, 200, 255),
+	tcell.NewRGBColor(150, 120, 255),
+	tcell.NewRGBColor(220, 110, 255),
+}
+
+// showTitle plays the title screen. Returns false if the screen closes
+// before the player presses a key.
+func (g *Game) showTitle() bool {
+	return RunTitle(g.screen)
+}
+
+// RunTitle animates the title screen on screen until the player presses a
+// key, and returns false if the screen closes first. A ticker posts an
+// interrupt for every frame, so input is still read with PollEvent alone.
+// Exported so MUD sessions see it before class select.
+func RunTitle(screen tcell.Screen) bool {
+	ticker := time.NewTicker(titleFrameInterval)
+	done := make(chan struct{})
+	defer func() {
+		ticker.Stop()
+		close(done)
+	}()
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				_ = screen.PostEvent(tcell.NewEventInterrupt(nil))
+			}
+		}
+	}()
+
+	for frame := 0; ; {
+		DrawTitleScreen(screen, frame)
+		switch screen.PollEvent().(type) {
+		case nil:
+			return false
+		case *tcell.EventResize:
+			screen.Sync()
+		case *tcell.EventInterrupt:
+			frame++
+		case *tcell.EventKey:
+			return true
+		}
+	}
+}
+
+// DrawTitleScreen renders frame of the title animation: the Spire with a
+// prism band climbing it, a twinkling apex, and LoreOpening typed out up to
+// the frame.
+func DrawTitleScreen(screen tcell.Screen, frame int) {
+	screen.Clear()
+	w, h := screen.Size()
+	lore := strings.Split(assets.LoreOpening, "\n")
+	top := max((h-len(titleSpire)-len(lore)-4)/2, 0)
+
+	centre := func(y int, text string, style tcell.Style) {
+		drawScreenText(screen, max((w-len([]rune(text)))/2, 0), y, text, style)
+	}
+
+	apex := "✦"
+	if frame/8%2 == 1 {
+		apex = "✧"
+	}
+	centre(top, apex, tcell.StyleDefault.Foreground(tcell.ColorWhite).Bold(true))
+	for i, row := range titleSpire {
+		band := titlePrism[(len(titleSpire)-i+frame/3)%len(titlePrism)]
+		centre(top+1+i, row, tcell.StyleDefault.Foreground(band))
+	}
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.NewRGBColor(180, 100, 255)).Bold(true)
+	centre(top+len(titleSpire)+2, "✨ THE PRISMATIC SPIRE ✨", titleStyle)
+
+	shown := frame * titleCharsPerFrame
+	loreStyle := tcell.StyleDefault.Foreground(tcell.ColorSilver)
+	y := top + len(titleSpire) + 4
+	for _, line := range lore {
+		runes := []rune(line)
+		if shown <= 0 {
+			break
+		}
+		text := string(runes[:min(shown, len(runes))])
+		// Centre on the whole line so it stays put as it types out.
+		drawScreenText(screen, max((w-len(runes))/2, 0), y, text, loreStyle)
+		shown -= len(runes)
+		y++
+	}
+	screen.Show()
+}
@@ -0,0 +1,69 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// screenRows returns the simulation screen's contents one string per row.
+func screenRows(screen tcell.Screen) []string {
+	cells, w, h := screen.(tcell.SimulationScreen).GetContents()
+	rows := make([]string, h)
+	for y := range h {
+		var b strings.Builder
+		for x := range w {
+			if r := cells[y*w+x].Runes; len(r) > 0 {
+				b.WriteRune(r[0])
+			}
+		}
+		rows[y] = b.String()
+	}
+	return rows
+}
+
+func titleShows(screen tcell.Screen, sub string) bool {
+	for _, row := range screenRows(screen) {
+		if strings.Contains(row, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTitleTypesOutOpeningLore(t *testing.T) {
+	screen := newSimScreen()
+	DrawTitleScreen(screen, 0)
+	if !titleShows(screen, "PRISMATIC SPIRE") {
+		t.Error("the title should be shown from the first frame")
+	}
+	if titleShows(screen, "The Prismatic Spire —") {
+		t.Error("no lore should be typed out on the first frame")
+	}
+
+	DrawTitleScreen(screen, 10)
+	if !titleShows(screen, "The Prismatic") || titleShows(screen, "Press any key") {
+		t.Error("a few frames in, the lore should be partly typed out")
+	}
+
+	DrawTitleScreen(screen, 1000)
+	if !titleShows(screen, "Press any key to begin...") {
+		t.Error("the lore should end on its press-any-key prompt")
+	}
+}
+
+func TestRunTitleWaitsForKey(t *testing.T) {
+	screen := newSimScreen()
+	go func() {
+		time.Sleep(5 * titleFrameInterval)
+		screen.(tcell.SimulationScreen).InjectKey(tcell.KeyRune, ' ', tcell.ModNone)
+	}()
+	if !RunTitle(screen) {
+		t.Fatal("RunTitle should return true once a key is pressed")
+	}
+	if !titleShows(screen, "Th") {
+		t.Error("the lore should have started typing out while the title waited")
+	}
+}
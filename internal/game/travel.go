@@ -0,0 +1,80 @@
+package game
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/gamemap"
+	"emoji-roguelike/internal/system"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// travelMaxSteps caps how far one travel command walks.
+const travelMaxSteps = 100
+
+// runTravel asks for a direction and travels that way. Any key that is not a
+// direction cancels.
+func (g *Game) runTravel() {
+	for {
+		g.drawPlay()
+		g.putText(0, 0, "Travel which way? [direction]  [Esc] cancel", tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true))
+		g.screen.Show()
+
+		switch ev := g.screen.PollEvent().(type) {
+		case *tcell.EventResize:
+			g.screen.Sync()
+		case *tcell.EventKey:
+			if action := keyToAction(ev); deltaToAction(actionToDelta(action)) != ActionNone {
+				g.travel(action)
+			}
+			return
+		}
+	}
+}
+
+// travel steps the player in the direction of action, then keeps following
+// the corridor one turn per tile, with the AI acting after every step. It
+// stops on reaching a room or junction, when the move fails, when the player
+// is hurt or leaves the floor, or when travelInterrupted finds something
+// worth a look.
+func (g *Game) travel(action Action) {
+	floor := g.floor
+	for range travelMaxSteps {
+		from, hp := g.playerPosition(), g.playerHP()
+		g.processAction(action)
+		if g.state == StateDead || g.floor != floor {
+			return
+		}
+		pos := g.playerPosition()
+		if pos == from || g.playerHP() < hp || g.travelInterrupted(pos) {
+			return
+		}
+		dx, dy, ok := system.CorridorNext(g.gmap, pos.X, pos.Y, from.X, from.Y)
+		if !ok {
+			return
+		}
+		action = deltaToAction(dx, dy)
+		g.drawPlay()
+	}
+}
+
+// travelInterrupted reports whether the player at pos should stop travelling:
+// on stairs, on a tile holding anything (items, gold, inscriptions), or with a
+// hostile enemy in sight.
+func (g *Game) travelInterrupted(pos component.Position) bool {
+	if kind := g.gmap.At(pos.X, pos.Y).Kind; kind == gamemap.TileStairsDown || kind == gamemap.TileStairsUp {
+		return true
+	}
+	for _, id := range g.world.Query(component.CPosition) {
+		p := g.world.Get(id, component.CPosition).(component.Position)
+		if id == g.playerID {
+			continue
+		}
+		if p == pos {
+			return true
+		}
+		if g.gmap.At(p.X, p.Y).Visible && g.hostileEnemy(id) && !system.Concealed(g.world, g.playerID, id) {
+			return true
+		}
+	}
+	return false
+}
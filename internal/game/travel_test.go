@@ -0,0 +1,62 @@
+package game
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/factory"
+	"emoji-roguelike/internal/gamemap"
+	"emoji-roguelike/internal/system"
+	"testing"
+)
+
+// newCorridorGame puts the player alone at (1,1) on a map whose hallway runs
+// east to (5,1), bends south to (5,4) and opens into a room at (4..6, 5..7).
+func newCorridorGame(t *testing.T) *Game {
+	t.Helper()
+	g := newAbilityTestGame(t, "construct")
+	for _, id := range g.world.Query(component.CPosition) {
+		if id != g.playerID {
+			g.world.DestroyEntity(id)
+		}
+	}
+	gmap := gamemap.New(10, 10)
+	for x := 1; x <= 5; x++ {
+		gmap.Set(x, 1, gamemap.MakeFloor())
+	}
+	for y := 2; y <= 4; y++ {
+		gmap.Set(5, y, gamemap.MakeFloor())
+	}
+	for y := 5; y <= 7; y++ {
+		for x := 4; x <= 6; x++ {
+			gmap.Set(x, y, gamemap.MakeFloor())
+		}
+	}
+	g.gmap = gmap
+	g.world.Add(g.playerID, component.Position{X: 1, Y: 1})
+	system.UpdateFOV(g.world, g.gmap, g.playerID, g.effectiveFOVRadius())
+	return g
+}
+
+func TestTravelFollowsCorridorToRoom(t *testing.T) {
+	g := newCorridorGame(t)
+	turns := g.runLog.TurnsPlayed
+	g.travel(ActionMoveE)
+
+	if got := g.playerPosition(); got != (component.Position{X: 5, Y: 5}) {
+		t.Errorf("travel stopped at %v; want the room entrance (5,5)", got)
+	}
+	if got := g.runLog.TurnsPlayed - turns; got != 8 {
+		t.Errorf("travel took %d turns; want one per tile (8)", got)
+	}
+}
+
+func TestTravelStopsOnItem(t *testing.T) {
+	g := newCorridorGame(t)
+	g.profile.Autopickup = autopickupOff
+	factory.NewItemByGlyph(g.world, assets.GlyphHyperflask, 3, 1)
+	g.travel(ActionMoveE)
+
+	if got := g.playerPosition(); got != (component.Position{X: 3, Y: 1}) {
+		t.Errorf("travel stopped at %v; want the item at (3,1)", got)
+	}
+}
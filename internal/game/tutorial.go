@@ -0,0 +1,153 @@
+package game
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/factory"
+	"emoji-roguelike/internal/gamemap"
+	"fmt"
+)
+
+// tutorialFloor is the floor number the tutorial is played on. It borrows the
+// Emberveil tile theme.
+const tutorialFloor = 0
+
+// tutorial tracks progress through the tutorial floor. Step i's gate opens
+// once step i is complete, letting the player into the next room.
+type tutorial struct {
+	step  int
+	gates []component.Position
+	start component.Position
+}
+
+// tutorialStep is one lesson: a prompt and the condition that completes it.
+type tutorialStep struct {
+	prompt func(g *Game) string
+	done   func(g *Game) bool
+}
+
+var tutorialSteps = []tutorialStep{
+	{
+		prompt: func(*Game) string {
+			return "Move with hjkl (yubn for diagonals) or the arrow keys. Step east."
+		},
+		done: func(g *Game) bool { return g.playerPosition() != g.tutorial.start },
+	},
+	{
+		prompt: func(*Game) string {
+			return "Stand on the 🧪 Hyperflask and press g to pick it up."
+		},
+		done: func(g *Game) bool {
+			inv, ok := g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+			return ok && len(inv.Backpack) > 0
+		},
+	},
+	{
+		prompt: func(*Game) string {
+			return "Press i to open your inventory, then the item's number to use it."
+		},
+		done: func(g *Game) bool { return len(g.runLog.ItemsUsed) > 0 },
+	},
+	{
+		prompt: func(g *Game) string {
+			return fmt.Sprintf("Press z to use %s, your class ability. It recharges over time.", g.selectedClass.AbilityName)
+		},
+		done: func(g *Game) bool { return g.specialSpent > 0 || g.selectedClass.AbilityCooldown == 0 },
+	},
+	{
+		prompt: func(*Game) string {
+			return "Stand on the stairs and press > to descend and begin your run."
+		},
+		done: func(*Game) bool { return false }, // finished by descending
+	},
+}
+
+// tutorialInscriptions are read on entering each tutorial room.
+var tutorialInscriptions = []string{
+	"Every step you take is a turn. Enemies move only when you do.",
+	"Items lie where they fall. Walk over them and gather what you can carry.",
+	"Consumables heal, buff, or reveal. Equipment is worn from the same screen.",
+	"Each class has one ability on z. Press ? at any time for every key.",
+	"The stairs lead down into the Spire. Floor 10 holds the way out.",
+}
+
+// newTutorialFloor builds the hand-crafted tutorial floor into w: five rooms
+// in a row, one lesson each, separated by walls that become doors as lessons
+// are completed. Returns the map, the gate positions and the player start.
+func newTutorialFloor(w *ecs.World) (*gamemap.GameMap, []component.Position, int, int) {
+	const rooms, roomW, midY = 5, 7, 4
+	gmap := gamemap.New(rooms*(roomW+1)+1, 9)
+	for y := range gmap.Height {
+		for x := range gmap.Width {
+			gmap.Set(x, y, gamemap.MakeWall())
+		}
+	}
+	var gates []component.Position
+	for r := range rooms {
+		x0 := 1 + r*(roomW+1)
+		for y := 1; y < gmap.Height-1; y++ {
+			for x := x0; x < x0+roomW; x++ {
+				gmap.Set(x, y, gamemap.MakeFloor())
+			}
+		}
+		if r < rooms-1 {
+			gates = append(gates, component.Position{X: x0 + roomW, Y: midY})
+		}
+		// The first room's inscription sits one step east of the start.
+		ix := x0
+		if r == 0 {
+			ix = x0 + 2
+		}
+		factory.NewInscription(w, tutorialInscriptions[r], ix, midY)
+	}
+	factory.NewItemByGlyph(w, assets.GlyphHyperflask, 1+(roomW+1)+roomW/2, midY)
+	gmap.Set(gmap.Width-3, midY, gamemap.MakeStairsDown())
+	return gmap, gates, 2, midY
+}
+
+// loadTutorialFloor starts the tutorial with the selected class.
+func (g *Game) loadTutorialFloor() {
+	g.floor = tutorialFloor
+	g.world = ecs.NewWorld()
+	gmap, gates, px, py := newTutorialFloor(g.world)
+	g.gmap = gmap
+	g.playerID = factory.NewPlayer(g.world, px, py, g.selectedClass)
+	g.baseMaxHP = g.selectedClass.MaxHP
+	g.recalcPlayerMaxHP()
+	g.tutorial = &tutorial{gates: gates, start: component.Position{X: px, Y: py}}
+
+	g.updateFOV()
+	g.renderer = g.newRenderer(tutorialFloor)
+	g.renderer.CenterOn(px, py)
+	g.addMessage("Welcome to the Training Grounds. Press Esc and pick Skip Tutorial to start your run.")
+	g.addMessage(tutorialSteps[0].prompt(g))
+}
+
+// advanceTutorial completes every lesson whose condition now holds, opening
+// its gate and posting the next prompt.
+func (g *Game) advanceTutorial() {
+	t := g.tutorial
+	for t.step < len(tutorialSteps) && tutorialSteps[t.step].done(g) {
+		if t.step < len(t.gates) {
+			gate := t.gates[t.step]
+			g.gmap.Set(gate.X, gate.Y, gamemap.MakeDoor())
+			g.addMessage("A door appears in the east wall.")
+		}
+		t.step++
+		if t.step < len(tutorialSteps) {
+			g.addMessage(tutorialSteps[t.step].prompt(g))
+		}
+	}
+}
+
+// finishTutorial leaves the tutorial, remembers that it was seen, and starts
+// a fresh run on floor 1 with the same class.
+func (g *Game) finishTutorial() {
+	g.tutorial = nil
+	g.profile.TutorialDone = true
+	saveProfile(g.profile)
+	g.resetForRun()
+	g.runLog.Class = g.selectedClass.Name
+	g.startRun()
+}
@@ -0,0 +1,137 @@
+package game
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestNewTutorialFloorLayout(t *testing.T) {
+	w := ecs.NewWorld()
+	gmap, gates, px, py := newTutorialFloor(w)
+	if !gmap.IsWalkable(px, py) {
+		t.Errorf("player start (%d,%d) is not walkable", px, py)
+	}
+	if len(gates) != len(tutorialSteps)-1 {
+		t.Errorf("gates = %d; want one per lesson before the stairs (%d)", len(gates), len(tutorialSteps)-1)
+	}
+	for _, gate := range gates {
+		if gmap.At(gate.X, gate.Y).Kind != gamemap.TileWall {
+			t.Errorf("gate at %v should start as a wall", gate)
+		}
+	}
+	stairs := 0
+	for y := range gmap.Height {
+		for x := range gmap.Width {
+			if gmap.At(x, y).Kind == gamemap.TileStairsDown {
+				stairs++
+			}
+		}
+	}
+	if stairs != 1 {
+		t.Errorf("stairs down = %d; want 1", stairs)
+	}
+	if n := len(w.Query(component.CInscription)); n != len(tutorialInscriptions) {
+		t.Errorf("inscriptions = %d; want %d", n, len(tutorialInscriptions))
+	}
+}
+
+// TestTutorialPlaythrough walks through every lesson and checks that each
+// gate opens only once its lesson is done, and that descending starts the
+// run and remembers the tutorial.
+func TestTutorialPlaythrough(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	g := newAbilityTestGame(t, "warden")
+	g.loadTutorialFloor()
+	act := func(a Action) {
+		g.processAction(a)
+		if g.tutorial != nil {
+			g.advanceTutorial()
+		}
+	}
+	walkEast := func(toX int) {
+		for range 40 {
+			if g.playerPosition().X >= toX {
+				return
+			}
+			act(ActionMoveE)
+		}
+		t.Fatalf("could not walk east to x=%d; stuck at %v", toX, g.playerPosition())
+	}
+
+	if g.gmap.At(g.tutorial.gates[0].X, g.tutorial.gates[0].Y).Kind != gamemap.TileWall {
+		t.Fatal("first gate should be closed before moving")
+	}
+	act(ActionMoveE)
+	if g.tutorial.step != 1 {
+		t.Fatalf("step = %d after moving; want 1", g.tutorial.step)
+	}
+
+	// Walk through the opened door onto the Hyperflask and pick it up.
+	walkEast(12)
+	if g.tutorial.gates[1] != (component.Position{X: 16, Y: 4}) {
+		t.Fatalf("unexpected gate layout %v", g.tutorial.gates)
+	}
+	act(ActionMoveE) // one step past the flask must not open the next gate
+	if g.gmap.At(16, 4).Kind != gamemap.TileWall {
+		t.Fatal("second gate opened before picking anything up")
+	}
+	act(ActionMoveW)
+	act(ActionPickup)
+	if g.tutorial.step != 2 {
+		t.Fatalf("step = %d after pickup; want 2", g.tutorial.step)
+	}
+
+	g.screen.(tcell.SimulationScreen).InjectKey(tcell.KeyRune, 'u', tcell.ModNone)
+	act(ActionInventory)
+	if g.tutorial.step != 3 {
+		t.Fatalf("step = %d after using an item; want 3", g.tutorial.step)
+	}
+
+	act(ActionSpecialAbility)
+	if g.tutorial.step != 4 {
+		t.Fatalf("step = %d after the ability; want 4", g.tutorial.step)
+	}
+
+	walkEast(g.gmap.Width - 3)
+	act(ActionDescend)
+	if g.tutorial != nil || g.floor != 1 {
+		t.Fatalf("descending should leave the tutorial for floor 1; floor=%d tutorial=%v", g.floor, g.tutorial)
+	}
+	if !loadProfile().TutorialDone {
+		t.Error("finishing the tutorial should be remembered in the profile")
+	}
+	if len(g.runLog.ItemsUsed) != 0 {
+		t.Error("the run should start with a fresh run log")
+	}
+}
+
+func TestPauseMenuSkipsTutorial(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	g := newAbilityTestGame(t, "warden")
+	g.loadTutorialFloor()
+	g.screen.(tcell.SimulationScreen).InjectKey(tcell.KeyRune, '4', tcell.ModNone)
+	if g.runPauseMenu() {
+		t.Fatal("Skip Tutorial should start the run, not quit")
+	}
+	if g.tutorial != nil || g.floor != 1 {
+		t.Errorf("skip should land on floor 1; floor=%d", g.floor)
+	}
+	if !loadProfile().TutorialDone {
+		t.Error("skipping the tutorial should be remembered in the profile")
+	}
+}
+
+func TestProfileRoundTrip(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	if loadProfile().TutorialDone {
+		t.Fatal("a missing profile should default to TutorialDone=false")
+	}
+	saveProfile(Profile{TutorialDone: true})
+	if !loadProfile().TutorialDone {
+		t.Error("saved profile did not round-trip")
+	}
+}
@@ -0,0 +1,147 @@
+package game
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/factory"
+	"emoji-roguelike/internal/system"
+	"fmt"
+)
+
+// Wand effect strengths.
+const (
+	lightningDamage = 8 // Lightning Wand bolt, before cover
+	bindTurns       = 5 // turns a Binding Wand roots its target
+)
+
+// useCharge spends one charge of the wand at inv.Backpack[cursor] through
+// zap, which fires it and reports whether there was a target. A wand is
+// removed once its last charge is spent. Returns a status message and whether
+// a turn was spent.
+func useCharge(inv *component.Inventory, cursor int, zap func(component.Item) bool) (string, bool) {
+	item := inv.Backpack[cursor]
+	if !zap(item) {
+		return fmt.Sprintf("No enemy in sight within %d tiles.", system.WandRange), false
+	}
+	item.Charges--
+	if item.Charges <= 0 {
+		inv.Backpack = removeAt(inv.Backpack, cursor)
+		return fmt.Sprintf("Used %s. Its last charge is spent and it crumbles.", item.Name), true
+	}
+	inv.Backpack[cursor] = item
+	return fmt.Sprintf("Used %s (%d charges left).", item.Name, item.Charges), true
+}
+
+// rechargeWands restores one charge to every recharging wand in inv that is
+// below its full charge. Reports whether any wand was recharged.
+func rechargeWands(inv *component.Inventory) bool {
+	recharged := false
+	for i, item := range inv.Backpack {
+		if def, ok := assets.Wand(item.Glyph); ok && def.Recharges && item.Charges < def.Charges {
+			inv.Backpack[i].Charges++
+			recharged = true
+		}
+	}
+	return recharged
+}
+
+// zapWand fires a wand at the nearest enemy in sight. Returns false, leaving
+// the charge unspent, when there is nothing to fire at.
+func (g *Game) zapWand(item component.Item) bool {
+	target, ok := system.WandTarget(g.world, g.gmap, g.playerID)
+	if !ok {
+		return false
+	}
+	g.runLog.ItemsUsed[item.Glyph]++
+	name := g.entityName(target)
+	switch item.Glyph {
+	case assets.GlyphBindingWand:
+		system.ApplyEffect(g.world, target, component.ActiveEffect{
+			Kind: component.EffectRoot, Magnitude: 1, TurnsRemaining: bindTurns,
+		})
+		g.addMessage(fmt.Sprintf("Glowing threads bind the %s in place for %d turns.", name, bindTurns))
+
+	case assets.GlyphLightningWand:
+		pos := g.world.Get(target, component.CPosition).(component.Position)
+		var loot component.Loot
+		if lc := g.world.Get(target, component.CLoot); lc != nil {
+			loot = lc.(component.Loot)
+		}
+		res := system.Bolt(g.world, g.gmap, target, lightningDamage)
+		g.runLog.DamageDealt += res.Damage
+		if !res.Killed {
+			g.addMessage(fmt.Sprintf("Lightning arcs into the %s for %d damage!", name, res.Damage))
+			break
+		}
+		g.runLog.EnemiesKilled[name]++
+		gold := g.combatRng.Intn(4) + 1
+		g.earnGold(gold)
+		g.addMessage(fmt.Sprintf("Lightning blasts the %s apart! (+%d💰)", name, gold))
+		g.noteKill(pos)
+		factory.DropGold(g.world, g.combatRng, assets.ThreatForGlyph(name), pos.X, pos.Y)
+		factory.LeaveHazard(g.world, g.floor, name, pos.X, pos.Y)
+		if assets.IsEliteGlyph(name) {
+			g.grantXP(assets.XPForEliteKill(g.floor))
+		} else {
+			g.grantXP(assets.XPForKill(assets.ThreatForGlyph(name), g.floor))
+		}
+		for _, it := range factory.RollLoot(loot, g.floor, g.combatRng) {
+			factory.NewItemByGlyph(g.world, it.Glyph, pos.X, pos.Y)
+			g.addMessage(fmt.Sprintf("The %s drops something!", name))
+		}
+		g.dropChest(name, pos)
+		g.checkVictory()
+	}
+	return true
+}
+
+// coopZapWand fires a wand for p at the nearest enemy in sight, crediting any
+// kill to p. Returns false when there is nothing to fire at.
+func (g *CoopGame) coopZapWand(p *coopPlayer, item component.Item) bool {
+	target, ok := system.WandTarget(g.world, g.gmap, p.id)
+	if !ok {
+		return false
+	}
+	p.runLog.ItemsUsed[item.Glyph]++
+	name := g.entityName(target)
+	switch item.Glyph {
+	case assets.GlyphBindingWand:
+		system.ApplyEffect(g.world, target, component.ActiveEffect{
+			Kind: component.EffectRoot, Magnitude: 1, TurnsRemaining: bindTurns,
+		})
+		g.addMessage(fmt.Sprintf("Glowing threads bind the %s in place.", name))
+
+	case assets.GlyphLightningWand:
+		pos := g.world.Get(target, component.CPosition).(component.Position)
+		var loot component.Loot
+		if lc := g.world.Get(target, component.CLoot); lc != nil {
+			loot = lc.(component.Loot)
+		}
+		res := system.Bolt(g.world, g.gmap, target, lightningDamage)
+		p.runLog.DamageDealt += res.Damage
+		if !res.Killed {
+			g.addMessage(fmt.Sprintf("Lightning arcs into the %s for %d damage!", name, res.Damage))
+			break
+		}
+		g.addMessage(fmt.Sprintf("Lightning blasts the %s apart!", name))
+		g.coopNoteKill(pos)
+		factory.DropGold(g.world, g.combatRng, assets.ThreatForGlyph(name), pos.X, pos.Y)
+		factory.LeaveHazard(g.world, g.floor, name, pos.X, pos.Y)
+		p.runLog.EnemiesKilled[name]++
+		g.coopEarnGold(p, g.combatRng.Intn(4)+1)
+		for _, it := range factory.RollLoot(loot, g.floor, g.combatRng) {
+			factory.NewItemByGlyph(g.world, it.Glyph, pos.X, pos.Y)
+		}
+		g.coopDropChest(name, pos)
+		g.checkCoopVictory()
+	}
+	return true
+}
+
+// wandTag labels a charged item in the inventory with its charges left.
+func wandTag(item component.Item) string {
+	if item.Charges == 1 {
+		return " [1 charge]"
+	}
+	return fmt.Sprintf(" [%d charges]", item.Charges)
+}
@@ -0,0 +1,104 @@
+package game
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/system"
+	"testing"
+)
+
+// newWandGame returns a game on the tutorial floor with an enemy three tiles
+// east of the player and the given wand as the only backpack item.
+func newWandGame(t *testing.T, glyph string, charges int) (*Game, ecs.EntityID) {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	g := newAbilityTestGame(t, "warden")
+	g.loadTutorialFloor()
+	pos := g.playerPosition()
+	enemy := g.world.CreateEntity()
+	g.world.Add(enemy, component.Position{X: pos.X + 3, Y: pos.Y})
+	g.world.Add(enemy, component.AI{Behavior: component.BehaviorChase, SightRange: 8})
+	g.world.Add(enemy, component.Combat{Attack: 2, Defense: 30})
+	g.world.Add(enemy, component.Health{Current: 20, Max: 20})
+	g.world.Add(enemy, component.TagBlocking{})
+	g.world.Add(enemy, component.Renderable{Glyph: assets.GlyphCrystalCrawl})
+	inv := g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	inv.Backpack = []component.Item{{
+		Name: assets.ConsumableName(glyph), Glyph: glyph, IsConsumable: true, Charges: charges,
+	}}
+	g.world.Add(g.playerID, inv)
+	return g, enemy
+}
+
+func TestLightningWandSpendsChargeAndDamages(t *testing.T) {
+	g, enemy := newWandGame(t, assets.GlyphLightningWand, 3)
+	inv := g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	msg, used := g.invUseConsumable(&inv, 0, 0)
+	if !used {
+		t.Fatalf("zapping an enemy in range should spend a turn; got %q", msg)
+	}
+	if len(inv.Backpack) != 1 || inv.Backpack[0].Charges != 2 {
+		t.Fatalf("wand should stay with 2 charges; backpack = %+v", inv.Backpack)
+	}
+	if hp := g.world.Get(enemy, component.CHealth).(component.Health); hp.Current != 20-lightningDamage {
+		t.Errorf("enemy HP = %d; want %d (bolt ignores DEF)", hp.Current, 20-lightningDamage)
+	}
+	if g.runLog.ItemsUsed[assets.GlyphLightningWand] != 1 {
+		t.Error("zapping should be recorded as an item use")
+	}
+}
+
+func TestWandCrumblesOnLastCharge(t *testing.T) {
+	g, enemy := newWandGame(t, assets.GlyphBindingWand, 1)
+	inv := g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	if _, used := g.invUseConsumable(&inv, 0, 0); !used {
+		t.Fatal("binding an enemy in range should spend a turn")
+	}
+	if len(inv.Backpack) != 0 {
+		t.Errorf("a wand with no charges left should be removed; backpack = %+v", inv.Backpack)
+	}
+	if !system.HasEffect(g.world, enemy, component.EffectRoot) {
+		t.Error("the Binding Wand should root its target")
+	}
+}
+
+func TestWandWithoutTargetKeepsCharge(t *testing.T) {
+	g, enemy := newWandGame(t, assets.GlyphLightningWand, 3)
+	g.world.DestroyEntity(enemy)
+	inv := g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	if _, used := g.invUseConsumable(&inv, 0, 0); used {
+		t.Error("a wand with nothing to hit should not spend a turn")
+	}
+	if inv.Backpack[0].Charges != 3 {
+		t.Errorf("charges = %d; want 3 unspent", inv.Backpack[0].Charges)
+	}
+}
+
+func TestWandsRechargeOnNewFloor(t *testing.T) {
+	g := newAbilityTestGame(t, "warden")
+	inv := g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	inv.Backpack = []component.Item{
+		{Name: "Lightning Wand", Glyph: assets.GlyphLightningWand, IsConsumable: true, Charges: 1},
+		{Name: "Binding Wand", Glyph: assets.GlyphBindingWand, IsConsumable: true, Charges: 1},
+	}
+	g.world.Add(g.playerID, inv)
+
+	g.loadFloor(2)
+	inv = g.world.Get(g.playerID, component.CInventory).(component.Inventory)
+	if inv.Backpack[0].Charges != 2 {
+		t.Errorf("Lightning Wand charges = %d; want 2 after a new floor", inv.Backpack[0].Charges)
+	}
+	if inv.Backpack[1].Charges != 1 {
+		t.Errorf("Binding Wand charges = %d; it should not recharge", inv.Backpack[1].Charges)
+	}
+	if !hasMessage(g, "wands hum") {
+		t.Error("recharging should be announced")
+	}
+
+	full, _ := assets.Wand(assets.GlyphLightningWand)
+	inv.Backpack[0].Charges = full.Charges
+	if rechargeWands(&inv) {
+		t.Error("a fully charged wand should not recharge past its maximum")
+	}
+}
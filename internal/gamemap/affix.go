@@ -0,0 +1,36 @@
+package gamemap
+
+// Affix is a modifier that can curse a whole floor, trading extra danger or
+// scarcity for variety (and sometimes reward).
+type Affix uint8
+
+const (
+	AffixNone      Affix = iota
+	AffixBloodlust       // enemies hit harder but drop double loot
+	AffixBarren          // no healing items spawn
+	AffixDarkness        // sight shrinks to a few tiles
+	affixCount
+)
+
+// affixText holds each Affix's HUD name and the description shown on entry.
+var affixText = [affixCount]struct{ name, desc string }{
+	AffixBloodlust: {"Bloodlust", "Enemies deal 20% more damage, but drop double loot."},
+	AffixBarren:    {"Barren", "No healing items lie on this floor."},
+	AffixDarkness:  {"Darkness", "A perpetual darkness smothers your sight."},
+}
+
+// Name returns the affix's short name, or "" for AffixNone.
+func (a Affix) Name() string {
+	if a >= affixCount {
+		return ""
+	}
+	return affixText[a].name
+}
+
+// Desc returns a one-line explanation of the affix, or "" for AffixNone.
+func (a Affix) Desc() string {
+	if a >= affixCount {
+		return ""
+	}
+	return affixText[a].desc
+}
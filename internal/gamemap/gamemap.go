@@ -21,6 +21,10 @@ type GameMap struct {
 	Width, Height int
 	Tiles         [][]Tile
 	Rooms         []Rect
+	Infighting    bool    // enemies of rival factions attack each other
+	Leash         int     // Chebyshev tiles an enemy chases from home before returning; 0 = no leash
+	Affix         Affix   // floor-wide modifier, shown in the HUD
+	Puzzle        *Puzzle // sealed vault, if the floor has one
 }
 
 // New creates a GameMap filled with walls.
@@ -58,6 +62,14 @@ func (m *GameMap) IsWalkable(x, y int) bool {
 	return m.Tiles[y][x].Walkable
 }
 
+// IsSanctuary returns true when (x, y) is in bounds and inside a sanctuary.
+func (m *GameMap) IsSanctuary(x, y int) bool {
+	if !m.InBounds(x, y) {
+		return false
+	}
+	return m.Tiles[y][x].Sanctuary
+}
+
 // IsTransparent returns true when (x, y) is in bounds and transparent.
 func (m *GameMap) IsTransparent(x, y int) bool {
 	if !m.InBounds(x, y) {
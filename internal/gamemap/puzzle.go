@@ -0,0 +1,11 @@
+package gamemap
+
+// Puzzle is a vault whose doors stay locked until its glyph tiles are walked
+// in order. Stepping on a glyph out of turn starts the sequence over.
+type Puzzle struct {
+	Vault    Rect     // the sealed room
+	Doors    [][2]int // the vault's locked doors
+	Steps    [][2]int // glyph tiles, in the order they must be walked
+	Progress int      // glyphs walked in order so far
+	Solved   bool
+}
@@ -11,6 +11,7 @@ const (
 	TileStairsDown
 	TileGrass  // walkable outdoor terrain (parks, fields)
 	TileWater  // non-walkable water (rivers, lakes)
+	TileChute  // shaft that drops whoever steps in two floors down
 )
 
 // Tile holds the kind and visibility state for one map cell.
@@ -20,6 +21,8 @@ type Tile struct {
 	Transparent bool
 	Explored    bool
 	Visible     bool
+	Sanctuary   bool // part of a safe room enemies will not enter
+	Locked      bool // a door that will not open; see Puzzle
 }
 
 // MakeWall returns a blocking, opaque wall tile.
@@ -37,6 +40,12 @@ func MakeDoor() Tile {
 	return Tile{Kind: TileDoor, Walkable: false, Transparent: false}
 }
 
+// MakeLockedDoor returns a closed door that cannot be opened until it is
+// unlocked.
+func MakeLockedDoor() Tile {
+	return Tile{Kind: TileDoor, Walkable: false, Transparent: false, Locked: true}
+}
+
 // MakeStairsDown returns a downward staircase tile.
 func MakeStairsDown() Tile {
 	return Tile{Kind: TileStairsDown, Walkable: true, Transparent: true}
@@ -52,6 +61,12 @@ func MakeGrass() Tile {
 	return Tile{Kind: TileGrass, Walkable: true, Transparent: true}
 }
 
+// MakeChute returns a walkable chute tile that drops whoever steps onto it
+// two floors down.
+func MakeChute() Tile {
+	return Tile{Kind: TileChute, Walkable: true, Transparent: true}
+}
+
 // MakeWater returns a non-walkable, transparent water tile (rivers, lakes).
 func MakeWater() Tile {
 	return Tile{Kind: TileWater, Walkable: false, Transparent: true}
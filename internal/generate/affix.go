@@ -0,0 +1,41 @@
+package generate
+
+import (
+	"emoji-roguelike/internal/gamemap"
+	"math/rand"
+)
+
+// RollAffix picks a floor's affix: a random gamemap.Affix chance percent of
+// the time, and otherwise none.
+func RollAffix(chance int, rng *rand.Rand) gamemap.Affix {
+	if rng.Intn(100) >= chance {
+		return gamemap.AffixNone
+	}
+	return gamemap.Affix(1 + rng.Intn(int(gamemap.AffixDarkness)))
+}
+
+// doubleLoot returns entry with its loot doubled for a Bloodlust floor: every
+// independent drop rolls twice and the pool chance doubles, up to 100.
+func doubleLoot(entry EnemySpawnEntry) EnemySpawnEntry {
+	drops := make([]DropEntry, 0, 2*len(entry.Drops))
+	for _, d := range entry.Drops {
+		drops = append(drops, d)
+		if d.Weight == 0 {
+			drops = append(drops, d)
+		}
+	}
+	entry.Drops = drops
+	entry.PoolChance = min(2*entry.PoolChance, 100)
+	return entry
+}
+
+// withoutHealing returns the entries of table that do not restore HP.
+func withoutHealing(table []ItemSpawnEntry) []ItemSpawnEntry {
+	var out []ItemSpawnEntry
+	for _, e := range table {
+		if !e.Heals {
+			out = append(out, e)
+		}
+	}
+	return out
+}
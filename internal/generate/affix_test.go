@@ -0,0 +1,76 @@
+package generate
+
+import (
+	"emoji-roguelike/internal/gamemap"
+	"math/rand"
+	"testing"
+)
+
+func TestRollAffixNeverAtZeroChance(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for range 200 {
+		if a := RollAffix(0, rng); a != gamemap.AffixNone {
+			t.Fatalf("0%% chance rolled affix %s", a.Name())
+		}
+	}
+}
+
+func TestRollAffixOccasional(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	seen := map[gamemap.Affix]int{}
+	const rolls, chance = 2000, 20
+	for range rolls {
+		seen[RollAffix(chance, rng)]++
+	}
+	cursed := rolls - seen[gamemap.AffixNone]
+	if cursed < rolls*chance/200 || cursed > rolls*chance*2/100 {
+		t.Errorf("%d of %d floors cursed; want about %d%%", cursed, rolls, chance)
+	}
+	for _, a := range []gamemap.Affix{gamemap.AffixBloodlust, gamemap.AffixBarren, gamemap.AffixDarkness} {
+		if seen[a] == 0 {
+			t.Errorf("affix %s never rolled", a.Name())
+		}
+	}
+}
+
+func TestPopulateBarrenWithholdsHealing(t *testing.T) {
+	gmap := makeRoomedMap(5)
+	cfg := makeBaseConfig(0, 20, 0)
+	cfg.ItemTable = []ItemSpawnEntry{
+		{Glyph: "🧪", Name: "Hyperflask", Heals: true},
+		{Glyph: "📜", Name: "Memory Scroll"},
+	}
+	cfg.Affix = gamemap.AffixBarren
+	result := Populate(gmap, cfg)
+	if len(result.Items) != 20 {
+		t.Fatalf("placed %d items; want 20", len(result.Items))
+	}
+	for _, it := range result.Items {
+		if it.Entry.Heals {
+			t.Fatalf("healing item %s spawned on a Barren floor", it.Entry.Name)
+		}
+	}
+}
+
+func TestPopulateBloodlustDoublesLoot(t *testing.T) {
+	gmap := makeRoomedMap(5)
+	cfg := makeBaseConfig(10, 0, 0)
+	for i := range cfg.EnemyTable {
+		cfg.EnemyTable[i].Drops = []DropEntry{{Glyph: "🧪", Chance: 30}, {Glyph: "💎", Weight: 1}}
+		cfg.EnemyTable[i].PoolChance = 40
+	}
+	cfg.Affix = gamemap.AffixBloodlust
+	result := Populate(gmap, cfg)
+	if len(result.Enemies) == 0 {
+		t.Fatal("expected enemies")
+	}
+	for _, e := range result.Enemies {
+		if len(e.Entry.Drops) != 3 || e.Entry.PoolChance != 80 {
+			t.Errorf("%s loot = %d drops, pool %d%%; want 3 drops (chance entry doubled) and 80%%",
+				e.Entry.Glyph, len(e.Entry.Drops), e.Entry.PoolChance)
+		}
+	}
+	if len(cfg.EnemyTable[0].Drops) != 2 {
+		t.Error("doubling loot modified the shared enemy table")
+	}
+}
@@ -15,9 +15,15 @@ const (
 )
 
 // DropEntry describes one item that may drop from a defeated enemy.
+// Entries with a Weight join the enemy's weighted pool instead of rolling
+// their own Chance; Guaranteed entries always drop.
 type DropEntry struct {
-	Glyph  string
-	Chance int // 0–100
+	Glyph      string
+	Chance     int  // 0–100, independent roll
+	Guaranteed bool // always drops (elite and boss signature loot)
+	Weight     int  // >0: weighted pool entry, Chance is ignored
+	MinFloor   int  // pool entry only eligible on this floor or deeper
+	Rare       bool // pool weight grows with floor depth
 }
 
 // EnemySpawnEntry describes one possible enemy spawn with its threat cost.
@@ -29,17 +35,30 @@ type EnemySpawnEntry struct {
 	Defense       int
 	MaxHP         int
 	SightRange    int
-	SpecialKind   uint8 // 0=none 1=poison 2=weaken 3=lifedrain 4=stun 5=armorBreak
+	SpecialKind   uint8 // 0=none 1=poison 2=weaken 3=lifedrain 4=stun 5=armorBreak 6=disarm
 	SpecialChance int   // 0-100 percent
 	SpecialMag    int   // magnitude (poison dmg/turn, weaken atk penalty, lifedrain % * 10, armorBreak DEF penalty)
-	SpecialDur    int   // turns the status effect lasts
+	SpecialDur    int   // turns the status effect lasts (disarm: turns the item stays disabled)
+	Fearless      bool  // immune to morale rout (bosses, elites, constructs)
+	Support       bool  // buffs nearby enemies instead of attacking
+	SummonGlyph   string // minion summoned every SummonEvery turns ("" = none)
+	SummonCap     int    // most summoned minions alive at once
+	SummonEvery   int
+	Faction       uint8  // 0=none 1=construct 2=organic, as component.FactionID
+	Ambush        bool   // unseen until adjacent to a player without true sight
+	SplitGen      int    // times a hit may split it into weaker copies down its lineage (0 = never)
+	EchoEvery     int    // >0: copies a player's class ability every EchoEvery turns
+	AttacksPerTurn int    // swings at its target per turn (0 = one)
+	AreaAttack     bool   // each swing also lands on every other adjacent player
 	Drops         []DropEntry
+	PoolChance    int // 0–100 base chance to roll one item from the weighted pool
 }
 
 // ItemSpawnEntry describes one possible item spawn.
 type ItemSpawnEntry struct {
 	Glyph string
 	Name  string
+	Heals bool // restores HP; withheld from gamemap.AffixBarren floors
 }
 
 // EquipSpawnEntry describes one possible equipment spawn.
@@ -50,6 +69,7 @@ type EquipSpawnEntry struct {
 	Slot                         uint8 // 1=Head 2=Body 3=Feet 4=OneHand 5=TwoHand 6=OffHand
 	BaseATK, BaseDEF, BaseMaxHP  int
 	ATKScale, DEFScale, HPScale  int
+	CDRPercent                   int // % ability cooldown reduction (flat, not scaled)
 	MinFloor                     int
 }
 
@@ -76,6 +96,8 @@ type Config struct {
 	CorridorStyle       CorridorStyle
 	FloorNumber          int
 	EnemyBudget          int
+	EnemyDensity         float64 // scales EnemyBudget; 0 (unset) is the same as 1
+	NoEnemies            bool    // sandbox: place no enemies at all, elite included
 	ItemCount            int
 	EquipCount           int
 	EnemyTable           []EnemySpawnEntry
@@ -83,10 +105,25 @@ type Config struct {
 	EquipTable           []EquipSpawnEntry
 	InscriptionTexts     []string // pool of wall-writing texts to draw from
 	InscriptionCount     int      // how many to place (typically 2-5)
+	RuneCount            int      // active runes to place, each in a random placeable room
 	EliteEnemy           *EnemySpawnEntry // if non-nil, always spawned once in a random placeable room
 	CommonFurniture      []FurnitureSpawnEntry
 	RareFurniture        []FurnitureSpawnEntry
 	FurniturePerRoom     int // max furniture per room; actual = rng.Intn(max)+1
+	GoldPileCount        int // how many gold piles to scatter (0 = none)
+	GoldPileMax          int // each pile holds 1..GoldPileMax gold
+	VendingChance        int // 0–100 chance the floor gets one vending machine
+	AltarChance          int // 0–100 chance the floor gets a ritual altar
+	AltarOfferings       int // offerings scattered for the altar, one per other room where possible
+	Infighting           bool // enemies of rival factions attack each other
+	Leash                int  // tiles an enemy strays from its spawn before heading home; 0 = no leash
+	Affix                gamemap.Affix // floor-wide modifier; see RollAffix
+	ChuteChance          int    // 0–100 chance the floor gets a chute two floors down
+	ChuteWarning         string // inscription placed beside the chute
+	SanctuaryChance      int    // 0–100 chance the floor gets a sanctuary room
+	PuzzleGlyphs         []string // glyphs opening a sealed vault, in walking order; nil for no vault
+	PuzzleHint           string   // inscription telling the glyphs' order
+	VaultGold            int      // gold piled inside the vault
 	Rand                 *rand.Rand
 }
 
@@ -251,6 +288,9 @@ func (l *bspLeaf) connectChildren(gmap *gamemap.GameMap, cfg *Config) {
 // Generate runs BSP generation and returns the populated map plus player start.
 func Generate(cfg *Config) (*gamemap.GameMap, int, int) {
 	gmap := gamemap.New(cfg.MapWidth, cfg.MapHeight)
+	gmap.Infighting = cfg.Infighting
+	gmap.Leash = cfg.Leash
+	gmap.Affix = cfg.Affix
 
 	root := &bspLeaf{X: 0, Y: 0, W: cfg.MapWidth, H: cfg.MapHeight}
 
@@ -302,6 +342,29 @@ func Generate(cfg *Config) (*gamemap.GameMap, int, int) {
 		gmap.Set(sx, sy, gamemap.MakeStairsDown())
 	}
 
+	// Occasionally sink a chute into one of the middle rooms.
+	if len(gmap.Rooms) > 2 && cfg.ChuteChance > 0 && cfg.Rand.Intn(100) < cfg.ChuteChance {
+		room := gmap.Rooms[1+cfg.Rand.Intn(len(gmap.Rooms)-2)]
+		cx, cy := randomInRoom(room, cfg)
+		gmap.Set(cx, cy, gamemap.MakeChute())
+	}
+
+	// Occasionally consecrate a middle room as a sanctuary, never the chute's.
+	if len(gmap.Rooms) > 2 && cfg.SanctuaryChance > 0 && cfg.Rand.Intn(100) < cfg.SanctuaryChance {
+		room := gmap.Rooms[1+cfg.Rand.Intn(len(gmap.Rooms)-2)]
+		if _, _, chute := findChuteIn(gmap, room); !chute {
+			for y := room.Y1; y <= room.Y2; y++ {
+				for x := room.X1; x <= room.X2; x++ {
+					gmap.At(x, y).Sanctuary = true
+				}
+			}
+		}
+	}
+
+	if len(cfg.PuzzleGlyphs) > 0 {
+		sealVault(gmap, cfg, px, py)
+	}
+
 	return gmap, px, py
 }
 
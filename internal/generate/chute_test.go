@@ -0,0 +1,62 @@
+package generate
+
+import (
+	"emoji-roguelike/internal/gamemap"
+	"testing"
+)
+
+func TestGenerateChuteInMiddleRoom(t *testing.T) {
+	cfg := defaultTestConfig(7)
+	cfg.ChuteChance = 100
+	gmap, _, _ := Generate(cfg)
+	if len(gmap.Rooms) <= 2 {
+		t.Skip("seed produced too few rooms for a chute")
+	}
+	x, y, ok := findChute(gmap)
+	if !ok {
+		t.Fatal("expected a chute with ChuteChance 100")
+	}
+	in := func(r gamemap.Rect) bool { return x >= r.X1 && x <= r.X2 && y >= r.Y1 && y <= r.Y2 }
+	if in(gmap.Rooms[0]) || in(gmap.Rooms[len(gmap.Rooms)-1]) {
+		t.Errorf("chute at (%d,%d) is in the spawn or stairs room", x, y)
+	}
+}
+
+func TestGenerateNoChuteWhenChanceZero(t *testing.T) {
+	gmap, _, _ := Generate(defaultTestConfig(7))
+	if _, _, ok := findChute(gmap); ok {
+		t.Error("chute placed with ChuteChance 0")
+	}
+}
+
+func TestPopulateWarnsBesideChute(t *testing.T) {
+	gmap := makeRoomedMap(5)
+	gmap.Set(24, 5, gamemap.MakeChute()) // middle of the third room
+	cfg := makeBaseConfig(20, 10, 2)
+	cfg.ChuteWarning = "Mind the drop."
+	result := Populate(gmap, cfg)
+
+	warned := false
+	for _, ins := range result.Inscriptions {
+		if ins.Text != cfg.ChuteWarning {
+			continue
+		}
+		warned = true
+		if dx, dy := ins.X-24, ins.Y-5; dx*dx+dy*dy != 1 {
+			t.Errorf("warning at (%d,%d) is not beside the chute", ins.X, ins.Y)
+		}
+	}
+	if !warned {
+		t.Error("expected a warning inscription")
+	}
+	for _, e := range result.Enemies {
+		if e.X == 24 && e.Y == 5 {
+			t.Error("enemy placed on the chute")
+		}
+	}
+	for _, it := range result.Items {
+		if it.X == 24 && it.Y == 5 {
+			t.Error("item placed on the chute")
+		}
+	}
+}
@@ -68,6 +68,22 @@ func TestPopulateBudgetRespected(t *testing.T) {
 	}
 }
 
+func TestPopulateGoldPiles(t *testing.T) {
+	gmap := makeRoomedMap(5)
+	cfg := makeBaseConfig(0, 0, 0)
+	cfg.GoldPileCount = 4
+	cfg.GoldPileMax = 6
+	result := Populate(gmap, cfg)
+	if len(result.GoldPiles) != 4 {
+		t.Fatalf("expected 4 gold piles, got %d", len(result.GoldPiles))
+	}
+	for _, gp := range result.GoldPiles {
+		if gp.Amount < 1 || gp.Amount > cfg.GoldPileMax {
+			t.Errorf("gold pile amount %d outside 1..%d", gp.Amount, cfg.GoldPileMax)
+		}
+	}
+}
+
 func TestPopulateItemCount(t *testing.T) {
 	gmap := makeRoomedMap(5)
 	cfg := makeBaseConfig(0, 4, 0)
@@ -118,6 +134,25 @@ func TestPopulateInscriptionCountCappedByPool(t *testing.T) {
 	}
 }
 
+func TestPopulateRunesAvoidSpawnAndStairsRooms(t *testing.T) {
+	gmap := makeRoomedMap(5)
+	cfg := makeBaseConfig(0, 0, 0)
+	cfg.RuneCount = 4
+	result := Populate(gmap, cfg)
+	if len(result.Runes) != 4 {
+		t.Fatalf("expected 4 runes, got %d", len(result.Runes))
+	}
+	inRoom := func(room gamemap.Rect, x, y int) bool {
+		return x >= room.X1 && x <= room.X2 && y >= room.Y1 && y <= room.Y2
+	}
+	first, last := gmap.Rooms[0], gmap.Rooms[len(gmap.Rooms)-1]
+	for _, r := range result.Runes {
+		if inRoom(first, r.X, r.Y) || inRoom(last, r.X, r.Y) {
+			t.Errorf("rune at (%d,%d) is in the spawn or stairs room", r.X, r.Y)
+		}
+	}
+}
+
 func TestAffordableEnemiesFilter(t *testing.T) {
 	table := []EnemySpawnEntry{
 		{Glyph: "A", ThreatCost: 2},
@@ -222,6 +257,39 @@ func TestPopulateEliteNilNoExtra(t *testing.T) {
 	}
 }
 
+func TestPopulateEnemyDensity(t *testing.T) {
+	threat := func(density float64) int {
+		cfg := makeBaseConfig(40, 0, 0)
+		cfg.EnemyDensity = density
+		total := 0
+		for _, e := range Populate(makeRoomedMap(6), cfg).Enemies {
+			total += e.Entry.ThreatCost
+		}
+		return total
+	}
+	if half, full := threat(0.5), threat(1); half > 20 || half >= full {
+		t.Errorf("half density placed %d threat (full: %d); want at most 20 and less than full", half, full)
+	}
+	if unset, full := threat(0), threat(1); unset != full {
+		t.Errorf("unset density placed %d threat; want the same as density 1 (%d)", unset, full)
+	}
+}
+
+func TestPopulateNoEnemies(t *testing.T) {
+	gmap := makeRoomedMap(6)
+	cfg := makeBaseConfig(40, 2, 0)
+	cfg.NoEnemies = true
+	cfg.EliteEnemy = &EnemySpawnEntry{Glyph: "💠", Name: "Shardmind", MaxHP: 20}
+	cfg.EnemyTable = append(cfg.EnemyTable, EnemySpawnEntry{Glyph: "🐜", ThreatCost: 0, MaxHP: 1})
+	result := Populate(gmap, cfg)
+	if len(result.Enemies) != 0 {
+		t.Errorf("NoEnemies placed %d enemies; want none, elite and free enemies included", len(result.Enemies))
+	}
+	if len(result.Items) != 2 {
+		t.Errorf("NoEnemies placed %d items; items should be unaffected", len(result.Items))
+	}
+}
+
 func TestPopulateFurnitureSpawns(t *testing.T) {
 	// Furniture should appear in placeable rooms when tables are provided.
 	gmap := makeRoomedMap(5) // 3 placeable rooms
@@ -303,3 +371,44 @@ func TestPopulateFurnitureNoSpawnWhenPerRoomZero(t *testing.T) {
 		t.Errorf("expected 0 furniture with FurniturePerRoom=0, got %d", len(result.Furniture))
 	}
 }
+
+func TestPopulateVendingChance(t *testing.T) {
+	gmap := makeRoomedMap(5)
+	cfg := makeBaseConfig(0, 0, 0)
+	cfg.VendingChance = 100
+	if got := len(Populate(gmap, cfg).Vending); got != 1 {
+		t.Errorf("VendingChance 100: expected 1 vending machine, got %d", got)
+	}
+	cfg = makeBaseConfig(0, 0, 0)
+	if got := len(Populate(gmap, cfg).Vending); got != 0 {
+		t.Errorf("VendingChance 0: expected no vending machines, got %d", got)
+	}
+}
+
+func TestPopulateAltarScattersOfferings(t *testing.T) {
+	gmap := makeRoomedMap(5)
+	cfg := makeBaseConfig(0, 0, 0)
+	cfg.AltarChance = 100
+	cfg.AltarOfferings = 3
+	result := Populate(gmap, cfg)
+	if len(result.Altars) != 1 || len(result.Offerings) != 3 {
+		t.Fatalf("got %d altars and %d offerings; want 1 and 3", len(result.Altars), len(result.Offerings))
+	}
+	roomOf := func(p SpawnPoint) int {
+		for i, r := range gmap.Rooms {
+			if p.X >= r.X1 && p.X <= r.X2 && p.Y >= r.Y1 && p.Y <= r.Y2 {
+				return i
+			}
+		}
+		return -1
+	}
+	altarRoom := roomOf(result.Altars[0])
+	seen := map[int]bool{}
+	for _, o := range result.Offerings {
+		room := roomOf(o)
+		if room == altarRoom || seen[room] {
+			t.Errorf("offering at (%d,%d) shares room %d with the altar or another offering", o.X, o.Y, room)
+		}
+		seen[room] = true
+	}
+}
@@ -15,6 +15,12 @@ type InscriptionSpawn struct {
 	X, Y int
 }
 
+// GoldPileSpawn describes one pile of gold to place.
+type GoldPileSpawn struct {
+	Amount int
+	X, Y   int
+}
+
 // PopulateResult is returned by Populate with entity spawn data.
 type PopulateResult struct {
 	Enemies      []EnemySpawn
@@ -22,6 +28,18 @@ type PopulateResult struct {
 	Equipment    []EquipSpawn
 	Inscriptions []InscriptionSpawn
 	Furniture    []FurnitureSpawn
+	GoldPiles    []GoldPileSpawn
+	Vending      []SpawnPoint
+	Altars       []SpawnPoint
+	Offerings    []SpawnPoint // items the floor's altar asks for
+	Runes        []SpawnPoint // active runes; the factory picks each one's kind
+	PuzzleGlyphs []PuzzleGlyphSpawn
+}
+
+// PuzzleGlyphSpawn describes one glyph tile of the floor's vault puzzle.
+type PuzzleGlyphSpawn struct {
+	Glyph string
+	X, Y  int
 }
 
 // FurnitureSpawn describes one furniture piece to place.
@@ -48,7 +66,10 @@ type EquipSpawn struct {
 	X, Y  int
 }
 
-// Populate places enemies and items in the generated rooms.
+// Populate places enemies and items in the generated rooms. The enemy budget
+// is scaled by cfg.EnemyDensity; with cfg.NoEnemies set no enemies are placed,
+// elite included.
+// cfg.Affix may double enemy loot or withhold healing items.
 func Populate(gmap *gamemap.GameMap, cfg *Config) PopulateResult {
 	var result PopulateResult
 
@@ -58,6 +79,8 @@ func Populate(gmap *gamemap.GameMap, cfg *Config) PopulateResult {
 		return result
 	}
 	placeable := rooms[1 : len(rooms)-1]
+	// Enemies never start inside a sanctuary.
+	hostile := withoutSanctuaries(gmap, placeable)
 
 	// occupied tracks every position already claimed this pass so that no two
 	// entities share a tile.
@@ -68,19 +91,36 @@ func Populate(gmap *gamemap.GameMap, cfg *Config) PopulateResult {
 	}
 	claim := func(x, y int) { occupied[pt{x, y}] = true }
 
-	// Spawn the floor elite in a random placeable room (does not consume budget).
-	if cfg.EliteEnemy != nil && len(placeable) > 0 {
-		room := placeable[cfg.Rand.Intn(len(placeable))]
+	// Keep the chute clear and post its warning on a tile beside it.
+	if cx, cy, ok := findChute(gmap); ok {
+		claim(cx, cy)
+		if cfg.ChuteWarning != "" {
+			if wx, wy, ok := chuteWarningSpot(gmap, cx, cy); ok {
+				claim(wx, wy)
+				result.Inscriptions = append(result.Inscriptions, InscriptionSpawn{Text: cfg.ChuteWarning, X: wx, Y: wy})
+			}
+		}
+	}
+
+	// Spawn the floor elite in a random hostile room (does not consume budget).
+	if cfg.EliteEnemy != nil && len(hostile) > 0 && !cfg.NoEnemies {
+		room := hostile[cfg.Rand.Intn(len(hostile))]
 		x, y := pick(room)
 		claim(x, y)
 		result.Enemies = append(result.Enemies, EnemySpawn{Entry: *cfg.EliteEnemy, X: x, Y: y})
 	}
 
 	budget := cfg.EnemyBudget
+	if cfg.EnemyDensity > 0 {
+		budget = int(float64(budget) * cfg.EnemyDensity)
+	}
+	if cfg.NoEnemies {
+		budget = 0
+	}
 
-	// Phase 1: guarantee one enemy in every placeable room (cheapest that fits budget).
-	if len(cfg.EnemyTable) > 0 {
-		for _, room := range placeable {
+	// Phase 1: guarantee one enemy in every hostile room (cheapest that fits budget).
+	if len(cfg.EnemyTable) > 0 && !cfg.NoEnemies {
+		for _, room := range hostile {
 			aff := affordableEnemies(cfg.EnemyTable, budget)
 			if len(aff) == 0 {
 				break
@@ -95,10 +135,10 @@ func Populate(gmap *gamemap.GameMap, cfg *Config) PopulateResult {
 
 	// Phase 2: spend remaining budget on random rooms/enemies (as before).
 	for budget > 0 && len(cfg.EnemyTable) > 0 {
-		if len(placeable) == 0 {
+		if len(hostile) == 0 {
 			break
 		}
-		room := placeable[cfg.Rand.Intn(len(placeable))]
+		room := hostile[cfg.Rand.Intn(len(hostile))]
 		affordable := affordableEnemies(cfg.EnemyTable, budget)
 		if len(affordable) == 0 {
 			break
@@ -109,11 +149,20 @@ func Populate(gmap *gamemap.GameMap, cfg *Config) PopulateResult {
 		result.Enemies = append(result.Enemies, EnemySpawn{Entry: entry, X: x, Y: y})
 		budget -= entry.ThreatCost
 	}
+	if cfg.Affix == gamemap.AffixBloodlust {
+		for i := range result.Enemies {
+			result.Enemies[i].Entry = doubleLoot(result.Enemies[i].Entry)
+		}
+	}
 
 	// Place items in random rooms.
-	for i := 0; i < cfg.ItemCount && len(cfg.ItemTable) > 0; i++ {
+	itemTable := cfg.ItemTable
+	if cfg.Affix == gamemap.AffixBarren {
+		itemTable = withoutHealing(itemTable)
+	}
+	for i := 0; i < cfg.ItemCount && len(itemTable) > 0; i++ {
 		room := rooms[cfg.Rand.Intn(len(rooms))]
-		entry := cfg.ItemTable[cfg.Rand.Intn(len(cfg.ItemTable))]
+		entry := itemTable[cfg.Rand.Intn(len(itemTable))]
 		x, y := pick(room)
 		claim(x, y)
 		result.Items = append(result.Items, ItemSpawn{Entry: entry, X: x, Y: y})
@@ -160,6 +209,83 @@ func Populate(gmap *gamemap.GameMap, cfg *Config) PopulateResult {
 		}
 	}
 
+	// Scatter gold piles in placeable rooms.
+	for i := 0; i < cfg.GoldPileCount && cfg.GoldPileMax > 0 && len(placeable) > 0; i++ {
+		room := placeable[cfg.Rand.Intn(len(placeable))]
+		x, y := pick(room)
+		claim(x, y)
+		result.GoldPiles = append(result.GoldPiles, GoldPileSpawn{Amount: cfg.Rand.Intn(cfg.GoldPileMax) + 1, X: x, Y: y})
+	}
+
+	// Occasionally place a vending machine in a placeable room.
+	if cfg.VendingChance > 0 && len(placeable) > 0 && cfg.Rand.Intn(100) < cfg.VendingChance {
+		room := placeable[cfg.Rand.Intn(len(placeable))]
+		x, y := pick(room)
+		claim(x, y)
+		result.Vending = append(result.Vending, SpawnPoint{X: x, Y: y})
+	}
+
+	// Occasionally raise a ritual altar and scatter its offerings across the
+	// floor, each in a different room from the altar while rooms allow.
+	if cfg.AltarChance > 0 && len(placeable) > 0 && cfg.Rand.Intn(100) < cfg.AltarChance {
+		ai := cfg.Rand.Intn(len(placeable))
+		x, y := pick(placeable[ai])
+		claim(x, y)
+		result.Altars = append(result.Altars, SpawnPoint{X: x, Y: y})
+		others := make([]gamemap.Rect, 0, len(rooms)-1)
+		for _, room := range rooms {
+			if room != placeable[ai] {
+				others = append(others, room)
+			}
+		}
+		cfg.Rand.Shuffle(len(others), func(i, j int) { others[i], others[j] = others[j], others[i] })
+		for i := range cfg.AltarOfferings {
+			room := placeable[ai]
+			if len(others) > 0 {
+				room = others[i%len(others)]
+			}
+			x, y := pick(room)
+			claim(x, y)
+			result.Offerings = append(result.Offerings, SpawnPoint{X: x, Y: y})
+		}
+	}
+
+	// Place active runes last so floors without them generate as before.
+	for range cfg.RuneCount {
+		room := placeable[cfg.Rand.Intn(len(placeable))]
+		x, y := pick(room)
+		claim(x, y)
+		result.Runes = append(result.Runes, SpawnPoint{X: x, Y: y})
+	}
+
+	// Scatter a sealed vault's glyphs and their hint through the other rooms,
+	// one glyph per room while rooms allow, and pile its gold inside.
+	if p := gmap.Puzzle; p != nil {
+		var outside []gamemap.Rect
+		for _, room := range placeable {
+			if room != p.Vault {
+				outside = append(outside, room)
+			}
+		}
+		cfg.Rand.Shuffle(len(outside), func(i, j int) { outside[i], outside[j] = outside[j], outside[i] })
+		for i, glyph := range cfg.PuzzleGlyphs {
+			x, y := pick(outside[i%len(outside)])
+			claim(x, y)
+			p.Steps = append(p.Steps, [2]int{x, y})
+			result.PuzzleGlyphs = append(result.PuzzleGlyphs, PuzzleGlyphSpawn{Glyph: glyph, X: x, Y: y})
+		}
+		if cfg.PuzzleHint != "" {
+			x, y := pick(outside[cfg.Rand.Intn(len(outside))])
+			claim(x, y)
+			result.Inscriptions = append(result.Inscriptions, InscriptionSpawn{Text: cfg.PuzzleHint, X: x, Y: y})
+		}
+		if cfg.VaultGold > 0 {
+			x, y := pick(p.Vault)
+			claim(x, y)
+			result.GoldPiles = append(result.GoldPiles, GoldPileSpawn{Amount: cfg.VaultGold, X: x, Y: y})
+		}
+	}
+
 	return result
 }
 
@@ -215,3 +341,48 @@ func randomInRoom(room gamemap.Rect, cfg *Config) (int, int) {
 	y := y1 + cfg.Rand.Intn(max(1, h))
 	return x, y
 }
+
+// findChute returns the position of the chute in gmap's rooms, if any.
+func findChute(gmap *gamemap.GameMap) (int, int, bool) {
+	for _, room := range gmap.Rooms {
+		if x, y, ok := findChuteIn(gmap, room); ok {
+			return x, y, true
+		}
+	}
+	return 0, 0, false
+}
+
+// findChuteIn returns the position of a chute inside room, if any.
+func findChuteIn(gmap *gamemap.GameMap, room gamemap.Rect) (int, int, bool) {
+	for y := room.Y1; y <= room.Y2; y++ {
+		for x := room.X1; x <= room.X2; x++ {
+			if gmap.At(x, y).Kind == gamemap.TileChute {
+				return x, y, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// withoutSanctuaries returns the rooms that are not sanctuaries.
+func withoutSanctuaries(gmap *gamemap.GameMap, rooms []gamemap.Rect) []gamemap.Rect {
+	var out []gamemap.Rect
+	for _, room := range rooms {
+		if !gmap.IsSanctuary(room.X1, room.Y1) {
+			out = append(out, room)
+		}
+	}
+	return out
+}
+
+// chuteWarningSpot returns a plain floor tile orthogonally beside the chute
+// at (cx, cy).
+func chuteWarningSpot(gmap *gamemap.GameMap, cx, cy int) (int, int, bool) {
+	for _, d := range [4][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+		x, y := cx+d[0], cy+d[1]
+		if gmap.InBounds(x, y) && gmap.At(x, y).Kind == gamemap.TileFloor {
+			return x, y, true
+		}
+	}
+	return 0, 0, false
+}
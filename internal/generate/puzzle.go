@@ -0,0 +1,101 @@
+package generate
+
+import "emoji-roguelike/internal/gamemap"
+
+// sealVault locks every door of one middle room and records it as gmap's
+// puzzle vault. A room qualifies only if, with its doors locked, every other
+// room and the stairs down stay reachable from (px, py) and the room itself
+// does not. Nothing is sealed when no room qualifies.
+func sealVault(gmap *gamemap.GameMap, cfg *Config, px, py int) {
+	if len(gmap.Rooms) < 4 {
+		return
+	}
+	middle := gmap.Rooms[1 : len(gmap.Rooms)-1]
+	for _, i := range cfg.Rand.Perm(len(middle)) {
+		room := middle[i]
+		if gmap.IsSanctuary(room.X1, room.Y1) {
+			continue
+		}
+		if _, _, chute := findChuteIn(gmap, room); chute {
+			continue
+		}
+		doors := roomDoors(gmap, room)
+		if len(doors) == 0 {
+			continue
+		}
+		for _, d := range doors {
+			gmap.At(d[0], d[1]).Locked = true
+		}
+		if sealedOff(gmap, room, px, py) {
+			gmap.Puzzle = &gamemap.Puzzle{Vault: room, Doors: doors}
+			return
+		}
+		for _, d := range doors {
+			gmap.At(d[0], d[1]).Locked = false
+		}
+	}
+}
+
+// roomDoors returns the doors on room's outer perimeter.
+func roomDoors(gmap *gamemap.GameMap, room gamemap.Rect) [][2]int {
+	var doors [][2]int
+	for y := room.Y1 - 1; y <= room.Y2+1; y++ {
+		for x := room.X1 - 1; x <= room.X2+1; x++ {
+			inside := x >= room.X1 && x <= room.X2 && y >= room.Y1 && y <= room.Y2
+			if !inside && gmap.InBounds(x, y) && gmap.At(x, y).Kind == gamemap.TileDoor {
+				doors = append(doors, [2]int{x, y})
+			}
+		}
+	}
+	return doors
+}
+
+// sealedOff reports whether vault is cut off from (px, py) while every other
+// room is still reachable.
+func sealedOff(gmap *gamemap.GameMap, vault gamemap.Rect, px, py int) bool {
+	seen := reachableFrom(gmap, px, py)
+	for _, room := range gmap.Rooms {
+		cx, cy := room.Center()
+		if seen[[2]int{cx, cy}] != (room != vault) {
+			return false
+		}
+	}
+	return true
+}
+
+// reachableFrom flood-fills the tiles a player could walk to from (x, y),
+// opening unlocked doors but not locked ones. Diagonal steps between two
+// solid tiles are blocked, as in system.TryMove.
+func reachableFrom(gmap *gamemap.GameMap, x, y int) map[[2]int]bool {
+	passable := func(x, y int) bool {
+		if !gmap.InBounds(x, y) {
+			return false
+		}
+		t := gmap.At(x, y)
+		return t.Walkable || (t.Kind == gamemap.TileDoor && !t.Locked)
+	}
+	solid := func(x, y int) bool {
+		return !gmap.IsWalkable(x, y) && !gmap.IsTransparent(x, y)
+	}
+	seen := map[[2]int]bool{{x, y}: true}
+	queue := [][2]int{{x, y}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				nx, ny := cur[0]+dx, cur[1]+dy
+				next := [2]int{nx, ny}
+				if seen[next] || !passable(nx, ny) {
+					continue
+				}
+				if dx != 0 && dy != 0 && solid(nx, cur[1]) && solid(cur[0], ny) {
+					continue
+				}
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return seen
+}
@@ -0,0 +1,53 @@
+package generate
+
+import (
+	"testing"
+)
+
+// TestSealVaultLeavesRestReachable generates puzzle floors over many seeds
+// and checks that each vault is sealed off while the rest of the floor, its
+// glyphs and the stairs stay reachable.
+func TestSealVaultLeavesRestReachable(t *testing.T) {
+	sealed := 0
+	for seed := int64(0); seed < 30; seed++ {
+		cfg := defaultTestConfig(seed)
+		cfg.PuzzleGlyphs = []string{"🌑", "🌓", "🌕"}
+		cfg.PuzzleHint = "hint"
+		cfg.VaultGold = 40
+		gmap, px, py := Generate(cfg)
+		p := gmap.Puzzle
+		if p == nil {
+			continue
+		}
+		sealed++
+		for _, d := range p.Doors {
+			if !gmap.At(d[0], d[1]).Locked {
+				t.Errorf("seed=%d: vault door at %v is not locked", seed, d)
+			}
+		}
+		if !sealedOff(gmap, p.Vault, px, py) {
+			t.Errorf("seed=%d: vault is not sealed off from the rest of the floor", seed)
+		}
+
+		result := Populate(gmap, cfg)
+		if len(p.Steps) != len(cfg.PuzzleGlyphs) || len(result.PuzzleGlyphs) != len(cfg.PuzzleGlyphs) {
+			t.Fatalf("seed=%d: %d steps and %d glyphs; want %d", seed, len(p.Steps), len(result.PuzzleGlyphs), len(cfg.PuzzleGlyphs))
+		}
+		seen := reachableFrom(gmap, px, py)
+		for _, s := range p.Steps {
+			if !seen[s] {
+				t.Errorf("seed=%d: glyph at %v cannot be reached", seed, s)
+			}
+		}
+	}
+	if sealed == 0 {
+		t.Fatal("no seed produced a vault")
+	}
+}
+
+func TestNoPuzzleGlyphsNoVault(t *testing.T) {
+	gmap, _, _ := Generate(defaultTestConfig(1))
+	if gmap.Puzzle != nil {
+		t.Error("a floor without puzzle glyphs should have no vault")
+	}
+}
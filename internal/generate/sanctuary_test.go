@@ -0,0 +1,47 @@
+package generate
+
+import "testing"
+
+func TestGenerateSanctuaryInMiddleRoom(t *testing.T) {
+	cfg := defaultTestConfig(7)
+	cfg.SanctuaryChance = 100
+	gmap, _, _ := Generate(cfg)
+	if len(gmap.Rooms) <= 2 {
+		t.Skip("seed produced too few rooms for a sanctuary")
+	}
+	var sanctuaries int
+	for i, room := range gmap.Rooms {
+		if !gmap.IsSanctuary(room.X1, room.Y1) {
+			continue
+		}
+		sanctuaries++
+		if i == 0 || i == len(gmap.Rooms)-1 {
+			t.Errorf("room %d is a sanctuary; want only middle rooms", i)
+		}
+		if !gmap.IsSanctuary(room.X2, room.Y2) {
+			t.Errorf("room %d is only partly a sanctuary", i)
+		}
+	}
+	if sanctuaries != 1 {
+		t.Errorf("%d sanctuaries with SanctuaryChance 100; want 1", sanctuaries)
+	}
+}
+
+func TestPopulateKeepsEnemiesOutOfSanctuary(t *testing.T) {
+	gmap := makeRoomedMap(4)
+	holy := gmap.Rooms[1]
+	for y := holy.Y1; y <= holy.Y2; y++ {
+		for x := holy.X1; x <= holy.X2; x++ {
+			gmap.At(x, y).Sanctuary = true
+		}
+	}
+	result := Populate(gmap, makeBaseConfig(40, 0, 0))
+	if len(result.Enemies) == 0 {
+		t.Fatal("expected enemies in the other middle room")
+	}
+	for _, e := range result.Enemies {
+		if gmap.IsSanctuary(e.X, e.Y) {
+			t.Errorf("enemy placed in the sanctuary at (%d,%d)", e.X, e.Y)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package mud
+
+import (
+	"emoji-roguelike/internal/component"
+	"testing"
+)
+
+func TestBellCommandAndLowHPCue(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transitionFloorLocked(sess, 1)
+	floor := srv.floors[1]
+
+	hp := floor.World.Get(sess.PlayerID, component.CHealth).(component.Health)
+	hp.Current = 1
+	floor.World.Add(sess.PlayerID, hp)
+	srv.soundCuesLocked(floor)
+	if sess.PendingBell {
+		t.Fatal("the bell should stay silent until the player turns it on")
+	}
+
+	srv.handleCommand(sess, "/bell")
+	if !sess.BellOn {
+		t.Fatal("/bell should turn the bell on")
+	}
+	srv.soundCuesLocked(floor)
+	if !sess.PendingBell {
+		t.Error("low HP should ring the bell once it is on")
+	}
+	srv.handleCommand(sess, "/bell")
+	if sess.BellOn {
+		t.Error("/bell again should turn the bell off")
+	}
+}
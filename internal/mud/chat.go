@@ -62,18 +62,11 @@ func truncateBubbleText(text string) string {
 // BroadcastChat sends a chat message from the sender to all nearby players.
 // Caller must hold s.mu.
 func (s *Server) BroadcastChat(sender *Session, text string) {
-	floor, ok := s.floors[sender.FloorNum]
+	listeners, ok := s.listenersLocked(sender)
 	if !ok {
 		return
 	}
 
-	// Get sender position.
-	posComp := floor.World.Get(sender.PlayerID, component.CPosition)
-	if posComp == nil {
-		return
-	}
-	senderPos := posComp.(component.Position)
-
 	bubble := ChatBubble{
 		SenderID:       sender.ID,
 		SenderName:     sender.Name,
@@ -86,7 +79,27 @@ func (s *Server) BroadcastChat(sender *Session, text string) {
 	sender.AddMessage(fmt.Sprintf("You say: \"%s\"", text))
 	sender.ChatBubbles = append(sender.ChatBubbles, bubble)
 
-	// Deliver to nearby players on the same floor.
+	for _, sess := range listeners {
+		sess.AddMessage(fmt.Sprintf("%s says: \"%s\"", sender.Name, text))
+		sess.ChatBubbles = append(sess.ChatBubbles, bubble)
+	}
+}
+
+// listenersLocked returns the other players on sender's floor within
+// ChatRange of them. It reports false if sender has no position to speak
+// from. Caller must hold s.mu.
+func (s *Server) listenersLocked(sender *Session) ([]*Session, bool) {
+	floor, ok := s.floors[sender.FloorNum]
+	if !ok {
+		return nil, false
+	}
+	posComp := floor.World.Get(sender.PlayerID, component.CPosition)
+	if posComp == nil {
+		return nil, false
+	}
+	senderPos := posComp.(component.Position)
+
+	var out []*Session
 	for _, sess := range s.sessions {
 		if sess == sender || sess.FloorNum != sender.FloorNum {
 			continue
@@ -97,25 +110,43 @@ func (s *Server) BroadcastChat(sender *Session, text string) {
 		}
 		rpos := rposComp.(component.Position)
 		if chebyshev(senderPos.X, senderPos.Y, rpos.X, rpos.Y) <= ChatRange {
-			sess.AddMessage(fmt.Sprintf("%s says: \"%s\"", sender.Name, text))
-			sess.ChatBubbles = append(sess.ChatBubbles, bubble)
+			out = append(out, sess)
 		}
 	}
+	return out, true
 }
 
 // RunChat handles the chat input modal. The world keeps ticking and rendering
 // while the player types. Returns the typed message and true, or empty and false
 // if cancelled.
 func (s *Server) RunChat(sess *Session, eventCh <-chan tcell.Event) (string, bool) {
+	return s.runLineInput(sess, eventCh, "Say: ")
+}
+
+// RunCommand handles the slash-command input modal opened with /. Returns the
+// typed command with its leading slash and true, or empty and false if
+// cancelled.
+func (s *Server) RunCommand(sess *Session, eventCh <-chan tcell.Event) (string, bool) {
+	line, ok := s.runLineInput(sess, eventCh, "/")
+	if !ok {
+		return "", false
+	}
+	return "/" + line, true
+}
+
+// runLineInput reads one line of text typed after prompt on the bottom row,
+// keeping the world ticking and rendering underneath. Enter on an empty line
+// or Escape cancels.
+func (s *Server) runLineInput(sess *Session, eventCh <-chan tcell.Event, prompt string) (string, bool) {
 	var buf []rune
 
 	for {
-		// Draw the current world frame with chat input overlay.
-		s.mu.Lock()
+		// Draw the current world frame with the input overlay.
+		s.lockSession(sess)
 		s.RenderSession(sess)
-		s.drawChatInput(sess, buf)
-		s.mu.Unlock()
-		sess.Screen.Show()
+		s.drawChatInput(sess, prompt, buf)
+		s.unlockSession(sess)
+		sess.showFrame()
 
 		select {
 		case ev, ok := <-eventCh:
@@ -147,23 +178,23 @@ func (s *Server) RunChat(sess *Session, eventCh <-chan tcell.Event) (string, boo
 
 		case <-sess.RenderCh:
 			// World ticked — re-render with updated state.
-			s.mu.Lock()
+			s.lockSession(sess)
 			s.RenderSession(sess)
-			s.drawChatInput(sess, buf)
-			s.mu.Unlock()
-			sess.Screen.Show()
+			s.drawChatInput(sess, prompt, buf)
+			s.unlockSession(sess)
+			sess.showFrame()
 		}
 	}
 }
 
-// drawChatInput renders the "Say: text_" prompt on the bottom row of the HUD.
-func (s *Server) drawChatInput(sess *Session, buf []rune) {
+// drawChatInput renders the prompt and typed text, e.g. "Say: text_", on the
+// bottom row of the HUD.
+func (s *Server) drawChatInput(sess *Session, prompt string, buf []rune) {
 	_, sh := sess.Screen.Size()
 	y := sh - 1
 
-	prompt := "Say: " + string(buf) + "_"
 	style := tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true)
-	putText(sess.Screen, 0, y, prompt, style)
+	putText(sess.Screen, 0, y, prompt+string(buf)+"_", style)
 }
 
 // drawChatBubbles renders active speech bubbles above sender positions.
@@ -127,7 +127,7 @@ func chatTestSetup(positions [][2]int) (*Server, []*Session) {
 		Num:             1,
 		World:           w,
 		GMap:            gmap,
-		Rng:             rng,
+		CombatRng:       rng,
 		RespawnCooldown: -1,
 	}
 	srv.floors[1] = floor
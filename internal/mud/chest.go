@@ -0,0 +1,125 @@
+package mud
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/factory"
+	"emoji-roguelike/internal/game"
+	"fmt"
+	"slices"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// dropChestLocked leaves a treasure chest at pos when the enemy with glyph
+// was a boss or elite. The chest belongs to the party that fought there:
+// everyone within PartyRange, and the killer, picks their own reward. A kill
+// with no one nearby, such as a lone turret's, leaves a chest anyone can open.
+// Caller must hold s.mu.
+func (s *Server) dropChestLocked(floor *Floor, killer *Session, pos component.Position, glyph string) {
+	if !assets.DropsChest(glyph, floor.Num) {
+		return
+	}
+	var owners []string
+	for _, member := range s.partyLocked(floor, pos) {
+		owners = append(owners, member.Name)
+	}
+	if killer != nil && !slices.Contains(owners, killer.Name) {
+		owners = append(owners, killer.Name)
+	}
+	chest := component.Chest{Rewards: factory.ChestRewards(floor.Num, floor.CombatRng), Owners: owners}
+	factory.NewChest(floor.World, chest, pos.X, pos.Y)
+	floorMessage(s.sessions, floor.Num, game.ChestMessage(glyph))
+}
+
+// openChestLocked marks the treasure chest under the player, if any, for
+// RunLoop to open, and reports whether there was one. A chest won by another
+// party stays shut.
+// Caller must hold s.mu.
+func (s *Server) openChestLocked(floor *Floor, sess *Session) bool {
+	pc := floor.World.Get(sess.PlayerID, component.CPosition)
+	if pc == nil {
+		return false
+	}
+	id, ok := game.ChestAt(floor.World, pc.(component.Position))
+	if !ok {
+		return false
+	}
+	if !mayOpenChest(floor.World.Get(id, component.CChest).(component.Chest), sess) {
+		sess.AddMessage("The chest won't budge. Its treasure belongs to those who slew its guardian.")
+		return true
+	}
+	sess.PendingChest = id
+	return true
+}
+
+// mayOpenChest reports whether sess still has a reward to take from chest.
+func mayOpenChest(chest component.Chest, sess *Session) bool {
+	return chest.Owners == nil || slices.Contains(chest.Owners, sess.Name)
+}
+
+// RunChest opens the blocking treasure chest UI for chest id on the session's
+// floor. The pick is settled under the lock afterwards, so a chest emptied
+// or a backpack filled while the modal was open is caught.
+func (s *Server) RunChest(sess *Session, id ecs.EntityID, eventCh <-chan tcell.Event) {
+	s.lockSession(sess)
+	floor, ok := s.floors[sess.FloorNum]
+	var chest component.Chest
+	var inv component.Inventory
+	if ok {
+		cc, ic := floor.World.Get(id, component.CChest), floor.World.Get(sess.PlayerID, component.CInventory)
+		ok = cc != nil && ic != nil
+		if ok {
+			chest, inv = cc.(component.Chest), ic.(component.Inventory)
+		}
+	}
+	s.unlockSession(sess)
+	if !ok {
+		return
+	}
+
+	idx := game.RunChestModal(sess.Screen, sessionEvents(eventCh), chest.Rewards, &inv)
+	if idx < 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.takeChestRewardLocked(sess, id, idx)
+}
+
+// takeChestRewardLocked stows reward idx of chest id in the player's backpack
+// and crosses them off the chest's owners. The chest is gone once every
+// owner has chosen, or at once when it had none.
+// Caller must hold s.mu.
+func (s *Server) takeChestRewardLocked(sess *Session, id ecs.EntityID, idx int) {
+	floor, ok := s.floors[sess.FloorNum]
+	if !ok {
+		return
+	}
+	cc, ic := floor.World.Get(id, component.CChest), floor.World.Get(sess.PlayerID, component.CInventory)
+	if cc == nil || ic == nil {
+		sess.AddMessage("The chest is gone.")
+		return
+	}
+	chest, inv := cc.(component.Chest), ic.(component.Inventory)
+	if !mayOpenChest(chest, sess) || idx >= len(chest.Rewards) {
+		return
+	}
+	reward := chest.Rewards[idx]
+	if !inv.Stow(reward) {
+		sess.AddMessage("Backpack full! Drop something first.")
+		return
+	}
+	floor.World.Add(sess.PlayerID, inv)
+	sess.AddMessage(fmt.Sprintf("You take %s %s from the chest.", reward.Glyph, reward.Name))
+
+	if chest.Owners != nil {
+		chest.Owners = slices.DeleteFunc(chest.Owners, func(name string) bool { return name == sess.Name })
+	}
+	if len(chest.Owners) == 0 {
+		floor.World.DestroyEntity(id)
+		return
+	}
+	floor.World.Add(id, chest)
+}
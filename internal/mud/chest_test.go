@@ -0,0 +1,69 @@
+package mud
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/game"
+	"testing"
+)
+
+func TestPartyChestGivesEachMemberAPick(t *testing.T) {
+	srv, a, b, pos := newPartyOnFloor(t)
+	defer srv.mu.Unlock()
+	b.Name = "Ada"
+	floor := srv.floors[1]
+	elite := assets.EliteEnemy(1)
+
+	srv.dropChestLocked(floor, a, pos, elite.Glyph)
+	id, ok := game.ChestAt(floor.World, pos)
+	if !ok {
+		t.Fatal("slain elite left no chest")
+	}
+	if owners := floor.World.Get(id, component.CChest).(component.Chest).Owners; len(owners) != 2 {
+		t.Fatalf("owners = %v; want both party members", owners)
+	}
+
+	srv.takeChestRewardLocked(a, id, 0)
+	if backpackLen(floor, a) != 1 || !floor.World.Alive(id) {
+		t.Fatal("the first pick should fill a's backpack and leave the chest for b")
+	}
+	srv.takeChestRewardLocked(a, id, 1)
+	if backpackLen(floor, a) != 1 {
+		t.Error("a player should get only one pick")
+	}
+	srv.takeChestRewardLocked(b, id, 2)
+	if backpackLen(floor, b) != 1 || floor.World.Alive(id) {
+		t.Error("the chest should be gone once every owner has picked")
+	}
+}
+
+func TestChestStaysShutForOutsiders(t *testing.T) {
+	srv, a, b, pos := newPartyOnFloor(t)
+	defer srv.mu.Unlock()
+	b.Name = "Ada"
+	floor := srv.floors[1]
+
+	srv.dropChestLocked(floor, a, pos, assets.EliteEnemy(1).Glyph)
+	id, _ := game.ChestAt(floor.World, pos)
+	chest := floor.World.Get(id, component.CChest).(component.Chest)
+	chest.Owners = []string{a.Name}
+	floor.World.Add(id, chest)
+
+	floor.World.Add(b.PlayerID, pos)
+	if !srv.openChestLocked(floor, b) || b.PendingChest != 0 {
+		t.Error("an outsider should find the chest shut")
+	}
+	if !srv.openChestLocked(floor, a) || a.PendingChest != id {
+		t.Error("an owner should be able to open the chest")
+	}
+}
+
+func TestOrdinaryKillLeavesNoChest(t *testing.T) {
+	srv, a, _, pos := newPartyOnFloor(t)
+	defer srv.mu.Unlock()
+	floor := srv.floors[1]
+	srv.dropChestLocked(floor, a, pos, assets.EnemyTable(1)[0].Glyph)
+	if _, ok := game.ChestAt(floor.World, pos); ok {
+		t.Error("an ordinary enemy should not leave a chest")
+	}
+}
@@ -0,0 +1,41 @@
+package mud
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/gamemap"
+	"fmt"
+)
+
+// onChute reports whether sess stands on a chute tile of floor.
+// Caller must hold s.mu.
+func onChute(floor *Floor, sess *Session) bool {
+	pc := floor.World.Get(sess.PlayerID, component.CPosition)
+	if pc == nil {
+		return false
+	}
+	pos := pc.(component.Position)
+	return floor.GMap.InBounds(pos.X, pos.Y) && floor.GMap.At(pos.X, pos.Y).Kind == gamemap.TileChute
+}
+
+// fallDownChuteLocked drops sess two floors down, skipping the floor between,
+// and deals fall damage on landing that never drops them below 1 HP.
+// Caller must hold s.mu.
+func (s *Server) fallDownChuteLocked(sess *Session) {
+	sess.AddMessage("The ground gives way — you plunge down a chute!")
+	s.transitionFloorLocked(sess, sess.FloorNum+2)
+	floor := s.floors[sess.FloorNum]
+	hc := floor.World.Get(sess.PlayerID, component.CHealth)
+	if hc == nil {
+		return
+	}
+	hp := hc.(component.Health)
+	dmg := min(assets.ChuteFallDamage, hp.Current-1)
+	if dmg <= 0 {
+		return
+	}
+	hp.Current -= dmg
+	floor.World.Add(sess.PlayerID, hp)
+	sess.RunLog.DamageTaken += dmg
+	sess.AddMessage(fmt.Sprintf("You land hard, two floors down. (%d damage)", dmg))
+}
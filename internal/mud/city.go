@@ -232,6 +232,7 @@ func newCityFloor(rng *rand.Rand) *Floor {
 	placeNPC(assets.CityNPCs[7], 40, 47) // Townsfolk Maren — home south
 	placeNPC(assets.CityNPCs[8], 4, 49)  // Old Fisher Bram — market stall A back
 	placeNPC(assets.CityNPCs[9], 56, 11) // Sister Lena   — church east vestry
+	placeNPC(assets.CityNPCs[10], 20, 12) // Smith Hild    — smithy work floor (repairs)
 
 	// Animals
 	pigeon := assets.CityAnimals[2]
@@ -475,7 +476,8 @@ func newCityFloor(rng *rand.Rand) *Floor {
 		Num:             0,
 		World:           w,
 		GMap:            gmap,
-		Rng:             rng,
+		GenRng:          rng, // built by hand: one RNG serves for both
+		CombatRng:       rng,
 		SpawnX:          54,
 		SpawnY:          22,
 		StairsDownX:     stairsDownX,
@@ -384,7 +384,8 @@ func newChronolithsCityFloor(rng *rand.Rand) *Floor {
 		Num:             100,
 		World:           w,
 		GMap:            gmap,
-		Rng:             rng,
+		GenRng:          rng, // built by hand: one RNG serves for both
+		CombatRng:       rng,
 		SpawnX:          48,
 		SpawnY:          18,
 		StairsDownX:     stairsDownX,
@@ -4,6 +4,7 @@ import (
 	"emoji-roguelike/assets"
 	"emoji-roguelike/internal/component"
 	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/factory"
 	"math/rand"
 	"testing"
 )
@@ -89,6 +90,7 @@ func makeTestSessionOnCity(t *testing.T) (*Server, *Session) {
 		rng:    rng,
 	}
 	srv.floors[0] = newCityFloor(rand.New(rand.NewSource(1)))
+	srv.restockShopLocked()
 	sess := newTestSession(0, srv)
 
 	srv.mu.Lock()
@@ -113,7 +115,7 @@ func TestNPCInteractDialogue(t *testing.T) {
 	floor.World.Add(npcID, npc)
 
 	srv.mu.Lock()
-	floor.Rng = rng
+	floor.CombatRng = rng
 	srv.interactNPCLocked(floor, sess, npcID, npc)
 	srv.mu.Unlock()
 
@@ -141,7 +143,7 @@ func TestNPCInteractHealerPartialHP(t *testing.T) {
 	npcID := floor.World.CreateEntity()
 
 	srv.mu.Lock()
-	floor.Rng = rand.New(rand.NewSource(1))
+	floor.CombatRng = rand.New(rand.NewSource(1))
 	srv.interactNPCLocked(floor, sess, npcID, npc)
 	hpAfter := floor.World.Get(sess.PlayerID, component.CHealth).(component.Health)
 	srv.mu.Unlock()
@@ -172,7 +174,7 @@ func TestNPCInteractHealerFullHP(t *testing.T) {
 	npcID := floor.World.CreateEntity()
 
 	srv.mu.Lock()
-	floor.Rng = rand.New(rand.NewSource(1))
+	floor.CombatRng = rand.New(rand.NewSource(1))
 	srv.interactNPCLocked(floor, sess, npcID, npc)
 	hpAfter := floor.World.Get(sess.PlayerID, component.CHealth).(component.Health)
 	srv.mu.Unlock()
@@ -198,7 +200,7 @@ func TestNPCInteractShopSetsPendingNPC(t *testing.T) {
 	npcID := floor.World.CreateEntity()
 
 	srv.mu.Lock()
-	floor.Rng = rand.New(rand.NewSource(1))
+	floor.CombatRng = rand.New(rand.NewSource(1))
 	srv.interactNPCLocked(floor, sess, npcID, npc)
 	pending := sess.PendingNPC
 	srv.mu.Unlock()
@@ -242,6 +244,35 @@ func TestGoldDropOnKill(t *testing.T) {
 	}
 }
 
+func TestWalkingOntoGoldPileCollectsIt(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transitionFloorLocked(sess, 1)
+	floor1 := srv.floors[1]
+	if len(floor1.World.Query(component.CGoldPile)) == 0 {
+		t.Error("generated dungeon floor should contain gold piles")
+	}
+
+	pos := floor1.World.Get(sess.PlayerID, component.CPosition).(component.Position)
+	pile := factory.NewGoldPile(floor1.World, 7, pos.X+1, pos.Y)
+	goldBefore := sess.Gold
+	srv.processActionLocked(sess, ActionMoveE)
+
+	if got := sess.Gold - goldBefore; got != 7 {
+		t.Errorf("walking onto a 7-gold pile credited %d gold", got)
+	}
+	if floor1.World.Alive(pile) {
+		t.Error("gold pile should be removed once collected")
+	}
+	if sess.RunLog.GoldEarned < 7 {
+		t.Errorf("RunLog.GoldEarned = %d, want at least 7", sess.RunLog.GoldEarned)
+	}
+}
+
 func TestRespawnResetsGoldAndReturnsToCity(t *testing.T) {
 	srv := newTestServer()
 	sess := newTestSession(0, srv)
@@ -273,6 +304,66 @@ func TestRespawnResetsGoldAndReturnsToCity(t *testing.T) {
 	}
 }
 
+// TestRespawnThenDescendMatchesCleanPlayer checks that furniture bonuses
+// earned before death neither linger after respawn nor come back when the
+// used piece is touched again.
+func TestRespawnThenDescendMatchesCleanPlayer(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	clean := newTestSession(1, srv)
+	srv.AddSession(sess)
+	srv.AddSession(clean)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	city := srv.floors[0]
+	shrine := city.World.CreateEntity()
+	city.World.Add(shrine, component.Furniture{Glyph: "🗿", Name: "Test Shrine", BonusATK: 2, BonusDEF: 1, BonusMaxHP: 5})
+	srv.interactFurnitureLocked(city, sess, shrine)
+	srv.transitionFloorLocked(sess, 1)
+	if c := srv.floors[1].World.Get(sess.PlayerID, component.CCombat).(component.Combat); c.Attack != sess.Class.Attack+2 {
+		t.Fatalf("ATK after descending = %d; want furniture bonus carried as %d", c.Attack, sess.Class.Attack+2)
+	}
+
+	srv.respawnLocked(sess)
+	srv.interactFurnitureLocked(srv.floors[0], sess, shrine)
+	srv.transitionFloorLocked(sess, 1)
+	srv.transitionFloorLocked(clean, 1)
+
+	w := srv.floors[1].World
+	got := w.Get(sess.PlayerID, component.CCombat).(component.Combat)
+	want := w.Get(clean.PlayerID, component.CCombat).(component.Combat)
+	if got.Attack != want.Attack || got.Defense != want.Defense {
+		t.Errorf("respawned ATK/DEF = %d/%d; want clean player's %d/%d", got.Attack, got.Defense, want.Attack, want.Defense)
+	}
+	gotHP := w.Get(sess.PlayerID, component.CHealth).(component.Health)
+	wantHP := w.Get(clean.PlayerID, component.CHealth).(component.Health)
+	if gotHP.Max != wantHP.Max {
+		t.Errorf("respawned MaxHP = %d; want clean player's %d", gotHP.Max, wantHP.Max)
+	}
+}
+
+// TestFurnitureMaxHPSurvivesFloorTransition checks that a furniture MaxHP
+// bonus is not wiped when level growth is reapplied on the next floor.
+func TestFurnitureMaxHPSurvivesFloorTransition(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	city := srv.floors[0]
+	shrine := city.World.CreateEntity()
+	city.World.Add(shrine, component.Furniture{Glyph: "🗿", Name: "Test Shrine", BonusMaxHP: 5})
+	srv.interactFurnitureLocked(city, sess, shrine)
+	srv.transitionFloorLocked(sess, 1)
+
+	hp := srv.floors[1].World.Get(sess.PlayerID, component.CHealth).(component.Health)
+	if hp.Max != sess.Class.MaxHP+5 {
+		t.Errorf("MaxHP on floor 1 = %d; want %d", hp.Max, sess.Class.MaxHP+5)
+	}
+}
+
 // ─── Safe zone ────────────────────────────────────────────────────────────────
 
 func TestSafeZonePreventsAttack(t *testing.T) {
@@ -307,7 +398,7 @@ func TestSafeZonePreventsAttack(t *testing.T) {
 func TestTickFloorSafeZoneSkipsAI(t *testing.T) {
 	rng := rand.New(rand.NewSource(42))
 	floor := newCityFloor(rng)
-	floor.Rng = rand.New(rand.NewSource(42))
+	floor.CombatRng = rand.New(rand.NewSource(42))
 
 	screen := newSimScreen()
 	sess := NewSession(0, "Player", playerColors[0], screen)
@@ -377,9 +468,9 @@ func TestShopBuyDeductsGold(t *testing.T) {
 	// Give the session enough gold.
 	sess.Gold = 100
 
-	entry := assets.ShopCatalogue[0] // first item in shop
+	entry := srv.shop[0] // first item in shop
 	priceExpected := entry.Price
-	msg := srv.shopBuy(sess, assets.ShopCatalogue, 0)
+	msg := srv.shopBuy(sess, 0, 0)
 
 	if sess.Gold != 100-priceExpected {
 		t.Errorf("expected gold=%d after buy, got %d (msg: %s)", 100-priceExpected, sess.Gold, msg)
@@ -405,7 +496,7 @@ func TestShopBuyInsufficientGold(t *testing.T) {
 	srv, sess := makeTestSessionOnCity(t)
 	sess.Gold = 0
 
-	msg := srv.shopBuy(sess, assets.ShopCatalogue, 0)
+	msg := srv.shopBuy(sess, 0, 0)
 	if sess.Gold != 0 {
 		t.Error("gold should not change when purchase fails")
 	}
@@ -454,3 +545,42 @@ func TestSpawnStartItemsOnCityFloor(t *testing.T) {
 		t.Error("start items should be placed on city floor for symbiont class")
 	}
 }
+
+func TestNPCInteractSmithOpensRepairsOnlyForWornGear(t *testing.T) {
+	srv, sess := makeTestSessionOnCity(t)
+	floor := srv.floors[0]
+	smith := component.NPC{Name: "Smith Hild", Kind: component.NPCKindSmith, Lines: []string{"Your gear's sound."}}
+	npcID := floor.World.CreateEntity()
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.interactNPCLocked(floor, sess, npcID, smith)
+	if sess.PendingRepair {
+		t.Error("smith should not open repairs when nothing is worn")
+	}
+
+	inv := floor.World.Get(sess.PlayerID, component.CInventory).(component.Inventory)
+	inv.MainHand = component.Item{Name: "Sword", Slot: component.SlotOneHand, BonusATK: 2, MaxDurability: 10}
+	floor.World.Add(sess.PlayerID, inv)
+	srv.interactNPCLocked(floor, sess, npcID, smith)
+	if !sess.PendingRepair {
+		t.Error("smith should open repairs for a broken sword")
+	}
+}
+
+func TestRepairBuyMendsGear(t *testing.T) {
+	srv, sess := makeTestSessionOnCity(t)
+	floor := srv.floors[0]
+	srv.mu.Lock()
+	inv := floor.World.Get(sess.PlayerID, component.CInventory).(component.Inventory)
+	inv.MainHand = component.Item{Name: "Sword", Slot: component.SlotOneHand, BonusATK: 2, MaxDurability: 10}
+	floor.World.Add(sess.PlayerID, inv)
+	sess.Gold = 50
+	srv.mu.Unlock()
+
+	srv.repairBuy(sess, 0, 0)
+	inv = floor.World.Get(sess.PlayerID, component.CInventory).(component.Inventory)
+	if inv.MainHand.Durability != 10 || sess.Gold >= 50 {
+		t.Errorf("sword %d/10, gold %d; want it repaired for gold", inv.MainHand.Durability, sess.Gold)
+	}
+}
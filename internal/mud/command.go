@@ -0,0 +1,171 @@
+package mud
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/game"
+	"emoji-roguelike/internal/system"
+	"fmt"
+	"strings"
+)
+
+// commandHelp is what /help lists, one line per command.
+var commandHelp = []string{
+	"/who — list everyone online",
+	"/whisper NAME MESSAGE — message one player, on any floor",
+	"/party [MESSAGE] — list, or message, the players fighting beside you",
+	"/emote ACTION — act out ACTION to the players nearby",
+	"/stats — your level, HP, ATK, DEF, gold, reputation and kills",
+	"/bell — toggle the terminal bell for low HP, ability ready and death",
+	"/help — this list",
+}
+
+// handleCommand parses a slash command such as "/whisper Ada hi" and runs it
+// for sess. Command names are case-insensitive; anything unrecognised gets a
+// pointer to /help. Caller must hold s.mu.
+func (s *Server) handleCommand(sess *Session, line string) {
+	name, args, _ := strings.Cut(strings.TrimSpace(strings.TrimPrefix(line, "/")), " ")
+	args = strings.TrimSpace(args)
+	switch strings.ToLower(name) {
+	case "help":
+		sess.AddMessage("Commands:")
+		for _, h := range commandHelp {
+			sess.AddMessage("  " + h)
+		}
+	case "who":
+		sess.PendingWho = true
+	case "whisper":
+		s.whisperLocked(sess, args)
+	case "party":
+		s.partyCommandLocked(sess, args)
+	case "emote":
+		s.emoteLocked(sess, args)
+	case "bell":
+		sess.BellOn = !sess.BellOn
+		if sess.BellOn {
+			sess.AddMessage("Terminal bell on: it rings for low HP, a recharged ability and death.")
+		} else {
+			sess.AddMessage("Terminal bell off.")
+		}
+	case "stats":
+		for _, l := range s.statsLinesLocked(sess) {
+			sess.AddMessage(l)
+		}
+	default:
+		sess.AddMessage(fmt.Sprintf("Unknown command /%s. Type /help for a list.", name))
+	}
+}
+
+// whisperLocked sends the message in args, which starts with the recipient's
+// name, to that player alone. Names may contain spaces, so the longest
+// online name that args starts with wins. Caller must hold s.mu.
+func (s *Server) whisperLocked(sess *Session, args string) {
+	var to *Session
+	var text string
+	for _, other := range s.sessions {
+		n := len(other.Name)
+		if len(args) <= n || args[n] != ' ' || !strings.EqualFold(args[:n], other.Name) {
+			continue
+		}
+		if to == nil || n > len(to.Name) {
+			to, text = other, strings.TrimSpace(args[n:])
+		}
+	}
+	switch {
+	case to == nil && args == "":
+		sess.AddMessage("Usage: /whisper NAME MESSAGE")
+	case to == nil:
+		sess.AddMessage("No one by that name is online. Type /who to see who is.")
+	case text == "":
+		sess.AddMessage("Usage: /whisper NAME MESSAGE")
+	default:
+		sess.AddMessage(fmt.Sprintf("You whisper to %s: \"%s\"", to.Name, text))
+		if to != sess {
+			to.AddMessage(fmt.Sprintf("%s whispers: \"%s\"", sess.Name, text))
+		}
+	}
+}
+
+// partyCommandLocked lists the players close enough to share loot rolls with
+// sess, or sends them text when args is not empty. Caller must hold s.mu.
+func (s *Server) partyCommandLocked(sess *Session, text string) {
+	floor, ok := s.floors[sess.FloorNum]
+	if !ok {
+		return
+	}
+	pc := floor.World.Get(sess.PlayerID, component.CPosition)
+	if pc == nil {
+		sess.AddMessage("You have no party while you are dead.")
+		return
+	}
+	party := s.partyLocked(floor, pc.(component.Position))
+	if text != "" {
+		for _, member := range party {
+			member.AddMessage(fmt.Sprintf("[party] %s: \"%s\"", sess.Name, text))
+		}
+		if len(party) < 2 {
+			sess.AddMessage("No one is close enough to hear.")
+		}
+		return
+	}
+	if len(party) < 2 {
+		sess.AddMessage(fmt.Sprintf("No one is fighting beside you. Allies within %d tiles share loot rolls.", PartyRange))
+		return
+	}
+	names := make([]string, 0, len(party))
+	for _, member := range party {
+		hp := ""
+		if hc := floor.World.Get(member.PlayerID, component.CHealth); hc != nil {
+			h := hc.(component.Health)
+			hp = fmt.Sprintf(" %d/%d HP", h.Current, h.Max)
+		}
+		names = append(names, fmt.Sprintf("%s %s%s", member.Class.Emoji, member.Name, hp))
+	}
+	sess.AddMessage("Party: " + strings.Join(names, ", "))
+}
+
+// emoteLocked shows sess acting out action to themselves and every player
+// within ChatRange. Caller must hold s.mu.
+func (s *Server) emoteLocked(sess *Session, action string) {
+	if action == "" {
+		sess.AddMessage("Usage: /emote ACTION")
+		return
+	}
+	listeners, ok := s.listenersLocked(sess)
+	if !ok {
+		return
+	}
+	msg := fmt.Sprintf("* %s %s", sess.Name, action)
+	sess.AddMessage(msg)
+	for _, other := range listeners {
+		other.AddMessage(msg)
+	}
+}
+
+// statsLinesLocked summarises sess's character for /stats. Caller must hold
+// s.mu.
+func (s *Server) statsLinesLocked(sess *Session) []string {
+	kills := 0
+	for _, n := range sess.RunLog.EnemiesKilled {
+		kills += n
+	}
+	lines := []string{fmt.Sprintf("%s %s, Lv.%d %s (%d XP)", sess.Class.Emoji, sess.Name, sess.Level, sess.Class.Name, sess.XP)}
+	if floor, ok := s.floors[sess.FloorNum]; ok {
+		w := floor.World
+		hc, cc := w.Get(sess.PlayerID, component.CHealth), w.Get(sess.PlayerID, component.CCombat)
+		if hc != nil && cc != nil {
+			h, c := hc.(component.Health), cc.(component.Combat)
+			equipATK, equipDEF := equipBonuses(w, sess.PlayerID)
+			lines = append(lines, fmt.Sprintf("HP %d/%d  ATK %d  DEF %d", h.Current, h.Max,
+				c.Attack+system.GetAttackBonus(w, sess.PlayerID)+equipATK,
+				c.Defense+system.GetDefenseBonus(w, sess.PlayerID)+equipDEF))
+		}
+	}
+	lines = append(lines, fmt.Sprintf("💰%d  Kills %d  Deepest floor %d  Now on %s",
+		sess.Gold, kills, sess.RunLog.FloorsReached, assets.FloorName(sess.FloorNum)))
+	if sess.Reputation > 0 {
+		lines = append(lines, fmt.Sprintf("Reputation %d: %d%% off with the city's merchants",
+			sess.Reputation, game.ReputationDiscount(sess.Reputation)))
+	}
+	return lines
+}
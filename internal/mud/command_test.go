@@ -0,0 +1,103 @@
+package mud
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// hasLine reports whether any of sess's messages contains sub.
+func hasLine(sess *Session, sub string) bool {
+	return slices.ContainsFunc(sess.Messages, func(m string) bool { return strings.Contains(m, sub) })
+}
+
+func TestSlashOpensCommandInput(t *testing.T) {
+	ev := tcell.NewEventKey(tcell.KeyRune, '/', tcell.ModNone)
+	if got := keyToAction(ev); got != ActionCommand {
+		t.Errorf("keyToAction('/') = %v, want ActionCommand", got)
+	}
+}
+
+func TestHandleCommandHelpListsCommands(t *testing.T) {
+	srv, sessions := chatTestSetup([][2]int{{10, 10}})
+	srv.handleCommand(sessions[0], "/HELP")
+	for _, cmd := range []string{"/who", "/whisper", "/party", "/emote", "/stats", "/help"} {
+		if !hasLine(sessions[0], cmd) {
+			t.Errorf("/help does not list %s: %v", cmd, sessions[0].Messages)
+		}
+	}
+}
+
+func TestHandleCommandUnknown(t *testing.T) {
+	srv, sessions := chatTestSetup([][2]int{{10, 10}})
+	srv.handleCommand(sessions[0], "/dance")
+	if !hasLine(sessions[0], "Unknown command /dance") {
+		t.Errorf("messages = %v; want an unknown-command notice", sessions[0].Messages)
+	}
+}
+
+func TestHandleCommandWhoOpensList(t *testing.T) {
+	srv, sessions := chatTestSetup([][2]int{{10, 10}})
+	srv.handleCommand(sessions[0], "/who")
+	if !sessions[0].PendingWho {
+		t.Error("/who should open the who list")
+	}
+}
+
+func TestWhisperReachesOnlyRecipient(t *testing.T) {
+	// Far apart: whispers ignore distance.
+	srv, sessions := chatTestSetup([][2]int{{1, 1}, {40, 40}, {2, 1}})
+	sessions[1].Name = "Ada Lovelace"
+	srv.handleCommand(sessions[0], "/whisper ada lovelace meet at the stairs")
+
+	if !hasLine(sessions[1], `PlayerA whispers: "meet at the stairs"`) {
+		t.Errorf("recipient messages = %v", sessions[1].Messages)
+	}
+	if !hasLine(sessions[0], "You whisper to Ada Lovelace") {
+		t.Errorf("sender messages = %v", sessions[0].Messages)
+	}
+	if hasLine(sessions[2], "meet at the stairs") {
+		t.Error("a bystander overheard the whisper")
+	}
+
+	srv.handleCommand(sessions[0], "/whisper Nobody hi")
+	if !hasLine(sessions[0], "No one by that name") {
+		t.Errorf("messages = %v; want a no-such-player notice", sessions[0].Messages)
+	}
+}
+
+func TestEmoteReachesNearbyPlayers(t *testing.T) {
+	srv, sessions := chatTestSetup([][2]int{{10, 10}, {15, 10}, {40, 40}})
+	srv.handleCommand(sessions[0], "/emote waves")
+	for i, want := range []bool{true, true, false} {
+		if got := hasLine(sessions[i], "* PlayerA waves"); got != want {
+			t.Errorf("session %d saw the emote = %v; want %v", i, got, want)
+		}
+	}
+}
+
+func TestPartyCommandListsAndMessagesParty(t *testing.T) {
+	srv, sessions := chatTestSetup([][2]int{{10, 10}, {12, 10}, {30, 30}})
+	srv.handleCommand(sessions[0], "/party")
+	if !hasLine(sessions[0], "PlayerB") || hasLine(sessions[0], "PlayerC") {
+		t.Errorf("party list = %v; want PlayerB only beside PlayerA", sessions[0].Messages)
+	}
+
+	srv.handleCommand(sessions[0], "/party pull the golem")
+	if !hasLine(sessions[1], "[party] PlayerA") || hasLine(sessions[2], "pull the golem") {
+		t.Error("/party MESSAGE should reach the party and no one else")
+	}
+}
+
+func TestStatsCommandShowsCharacter(t *testing.T) {
+	srv, sessions := chatTestSetup([][2]int{{10, 10}})
+	sess := sessions[0]
+	sess.Gold = 42
+	sess.RunLog.EnemiesKilled = map[string]int{"🦀": 2, "🐜": 3}
+	srv.handleCommand(sess, "/stats")
+	if !hasLine(sess, "💰42") || !hasLine(sess, "Kills 5") {
+		t.Errorf("/stats = %v; want gold and kill count", sess.Messages)
+	}
+}
@@ -0,0 +1,90 @@
+package mud
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/factory"
+	"fmt"
+)
+
+// CorpseTicks is how long a fallen player's corpse lasts before it crumbles
+// (10 minutes at the 100 ms TickInterval).
+const CorpseTicks = 6000
+
+// dropCorpseLocked moves a fallen player's backpack and gold into a corpse
+// where they died, so they are not lost on respawn. Nothing is left when the
+// player carried nothing.
+// Caller must hold s.mu.
+func (s *Server) dropCorpseLocked(floor *Floor, sess *Session) {
+	posComp := floor.World.Get(sess.PlayerID, component.CPosition)
+	if posComp == nil {
+		return
+	}
+	pos := posComp.(component.Position)
+	corpse := component.Corpse{Owner: sess.Name, Gold: sess.Gold, TicksLeft: CorpseTicks}
+	if ic := floor.World.Get(sess.PlayerID, component.CInventory); ic != nil {
+		inv := ic.(component.Inventory)
+		corpse.Items, inv.Backpack = inv.Backpack, nil
+		floor.World.Add(sess.PlayerID, inv)
+	}
+	if len(corpse.Items) == 0 && corpse.Gold == 0 {
+		return
+	}
+	sess.Gold = 0
+	factory.NewCorpse(floor.World, corpse, pos.X, pos.Y)
+	floorMessage(s.sessions, floor.Num, fmt.Sprintf("🪦 %s's belongings lie where they fell.", sess.Name))
+}
+
+// lootCorpseLocked empties any corpse at the player's position into their
+// purse and backpack, leaving behind what does not fit. Reports whether there
+// was a corpse to loot.
+// Caller must hold s.mu.
+func (s *Server) lootCorpseLocked(floor *Floor, sess *Session) bool {
+	posComp := floor.World.Get(sess.PlayerID, component.CPosition)
+	invComp := floor.World.Get(sess.PlayerID, component.CInventory)
+	if posComp == nil || invComp == nil {
+		return false
+	}
+	pos := posComp.(component.Position)
+	for _, id := range floor.World.Query(component.CCorpse, component.CPosition) {
+		if floor.World.Get(id, component.CPosition).(component.Position) != pos {
+			continue
+		}
+		corpse := floor.World.Get(id, component.CCorpse).(component.Corpse)
+		inv := invComp.(component.Inventory)
+		taken := min(len(corpse.Items), max(inv.Capacity-len(inv.Backpack), 0))
+		inv.Stow(corpse.Items[:taken]...)
+		corpse.Items = corpse.Items[taken:]
+		floor.World.Add(sess.PlayerID, inv)
+		sess.Gold += corpse.Gold
+
+		whose := corpse.Owner + "'s"
+		if corpse.Owner == sess.Name {
+			whose = "your"
+		}
+		sess.AddMessage(fmt.Sprintf("You recover %d items and %d gold from %s corpse. (%d💰)",
+			taken, corpse.Gold, whose, sess.Gold))
+		corpse.Gold = 0
+		if len(corpse.Items) > 0 {
+			sess.AddMessage(fmt.Sprintf("Backpack full! %d items stay on the corpse.", len(corpse.Items)))
+			floor.World.Add(id, corpse)
+		} else {
+			floor.World.DestroyEntity(id)
+		}
+		return true
+	}
+	return false
+}
+
+// decayCorpses ages every corpse on w by one tick and removes those that have
+// crumbled.
+func decayCorpses(w *ecs.World) {
+	for _, id := range w.Query(component.CCorpse) {
+		corpse := w.Get(id, component.CCorpse).(component.Corpse)
+		if corpse.TicksLeft--; corpse.TicksLeft <= 0 {
+			w.DestroyEntity(id)
+			continue
+		}
+		w.Add(id, corpse)
+	}
+}
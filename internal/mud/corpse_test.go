@@ -0,0 +1,72 @@
+package mud
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"testing"
+)
+
+func TestDeathLeavesLootableCorpse(t *testing.T) {
+	srv := newTestServer()
+	fallen, finder := newTestSession(0, srv), newTestSession(1, srv)
+	srv.AddSession(fallen)
+	srv.AddSession(finder)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transitionFloorLocked(fallen, 1)
+	srv.transitionFloorLocked(finder, 1)
+	floor := srv.floors[1]
+	w := floor.World
+
+	inv := w.Get(fallen.PlayerID, component.CInventory).(component.Inventory)
+	inv.Backpack = []component.Item{{Name: "Potion A"}, {Name: "Potion B"}}
+	w.Add(fallen.PlayerID, inv)
+	fallen.Gold = 30
+	hp := w.Get(fallen.PlayerID, component.CHealth).(component.Health)
+	hp.Current = 0
+	w.Add(fallen.PlayerID, hp)
+
+	srv.tickFloorLocked(floor)
+
+	corpses := w.Query(component.CCorpse)
+	if len(corpses) != 1 {
+		t.Fatalf("found %d corpses after the death; want 1", len(corpses))
+	}
+	corpse := w.Get(corpses[0], component.CCorpse).(component.Corpse)
+	if len(corpse.Items) != 2 || corpse.Gold != 30 || corpse.Owner != fallen.Name {
+		t.Errorf("corpse = %+v; want both items and 30 gold", corpse)
+	}
+	if fallen.Gold != 0 || len(w.Get(fallen.PlayerID, component.CInventory).(component.Inventory).Backpack) != 0 {
+		t.Error("the fallen player should no longer carry what went into the corpse")
+	}
+
+	w.Add(finder.PlayerID, w.Get(corpses[0], component.CPosition).(component.Position))
+	if !srv.lootCorpseLocked(floor, finder) {
+		t.Fatal("lootCorpseLocked found no corpse under the finder")
+	}
+	if got := w.Get(finder.PlayerID, component.CInventory).(component.Inventory).Backpack; len(got) != 2 {
+		t.Errorf("finder's backpack holds %d items; want 2", len(got))
+	}
+	if finder.Gold != 30 {
+		t.Errorf("finder's gold = %d; want 30", finder.Gold)
+	}
+	if len(w.Query(component.CCorpse)) != 0 {
+		t.Error("an emptied corpse should be removed")
+	}
+}
+
+func TestCorpseCrumblesAfterTicks(t *testing.T) {
+	w := ecs.NewWorld()
+	id := w.CreateEntity()
+	w.Add(id, component.Corpse{Owner: "Ghost", Gold: 5, TicksLeft: 2})
+
+	decayCorpses(w)
+	if !w.Alive(id) {
+		t.Fatal("the corpse crumbled a tick early")
+	}
+	decayCorpses(w)
+	if w.Alive(id) {
+		t.Error("the corpse should crumble once its ticks run out")
+	}
+}
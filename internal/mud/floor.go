@@ -4,10 +4,10 @@ import (
 	"emoji-roguelike/assets"
 	"emoji-roguelike/internal/ecs"
 	"emoji-roguelike/internal/factory"
+	"emoji-roguelike/internal/game"
 	"emoji-roguelike/internal/gamemap"
 	"emoji-roguelike/internal/generate"
 	"fmt"
-	"math"
 	"math/rand"
 
 	"github.com/gdamore/tcell/v2"
@@ -15,13 +15,21 @@ import (
 
 const MaxFloors = 10
 
+// EnemyLeash is how far, in tiles, a dungeon enemy chases from where it
+// spawned before giving up and walking home, so no one can drag a whole
+// floor behind them.
+const EnemyLeash = 15
+
 // Floor holds the shared game state for one dungeon level.
 // All players on the same floor share one World and GameMap.
 type Floor struct {
 	Num   int
 	World *ecs.World
 	GMap  *gamemap.GameMap
-	Rng   *rand.Rand
+
+	// GenRng builds the floor and its respawned waves; CombatRng rolls
+	// everything that happens there. See game.SplitSeed.
+	GenRng, CombatRng *rand.Rand
 
 	// SpawnX/SpawnY is the default player spawn point (first room center).
 	SpawnX, SpawnY int
@@ -46,18 +54,33 @@ type Floor struct {
 	// A StairsDown tile at a portal position transitions to the portal's target
 	// instead of the default FloorNum+1.
 	Portals map[[2]int]int
+
+	// recentKills is the floor's morale counter: each kill adds
+	// system.MoraleKillWeight and it decays by 1 per tick.
+	recentKills int
+
+	// cleared is set once the floor's last enemy falls and the players there
+	// have been offered their clear bonus; a respawned wave resets it. Each
+	// player is still offered the bonus only once per visit.
+	cleared bool
+
+	// lingerTicks counts ticks with players on the floor, for lingerLocked.
+	// It restarts whenever the floor empties.
+	lingerTicks int
 }
 
-// newFloor generates a fresh dungeon floor using the same level config as the
-// single-player and coop modes.
-func newFloor(num int, rng *rand.Rand) *Floor {
+// newFloor generates a fresh dungeon floor from seed using the same level
+// config as the single-player and coop modes. Enemy stats are scaled to
+// scalePct percent.
+func newFloor(num int, seed int64, scalePct int) *Floor {
+	rng, combatRng := game.SplitSeed(seed)
 	cfg := levelConfig(num, rng)
 	gmap, px, py := generate.Generate(cfg)
 	w := ecs.NewWorld()
 
 	pop := generate.Populate(gmap, cfg)
 	for _, es := range pop.Enemies {
-		factory.NewEnemy(w, es.Entry, es.X, es.Y)
+		factory.NewEnemy(w, scaleEnemyEntry(es.Entry, scalePct), es.X, es.Y, rng)
 	}
 	for _, is := range pop.Items {
 		factory.NewItem(w, is.Entry, is.X, is.Y)
@@ -68,9 +91,21 @@ func newFloor(num int, rng *rand.Rand) *Floor {
 	for _, ins := range pop.Inscriptions {
 		factory.NewInscription(w, ins.Text, ins.X, ins.Y)
 	}
+	for _, r := range pop.Runes {
+		factory.NewRune(w, rng, r.X, r.Y)
+	}
+	for _, pg := range pop.PuzzleGlyphs {
+		factory.NewPuzzleGlyph(w, pg.Glyph, pg.X, pg.Y)
+	}
 	for _, fs := range pop.Furniture {
 		factory.NewFurniture(w, fs.Entry, fs.X, fs.Y)
 	}
+	for _, gp := range pop.GoldPiles {
+		factory.NewGoldPile(w, gp.Amount, gp.X, gp.Y)
+	}
+	for _, vm := range pop.Vending {
+		factory.NewVendingMachine(w, rng, vm.X, vm.Y)
+	}
 
 	// Derive stair positions from generated rooms.
 	stairsDownX, stairsDownY := px, py // fallback if only one room
@@ -93,7 +128,8 @@ func newFloor(num int, rng *rand.Rand) *Floor {
 		Num:             num,
 		World:           w,
 		GMap:            gmap,
-		Rng:             rng,
+		GenRng:          rng,
+		CombatRng:       combatRng,
 		SpawnX:          px,
 		SpawnY:          py,
 		StairsDownX:     stairsDownX,
@@ -101,6 +137,7 @@ func newFloor(num int, rng *rand.Rand) *Floor {
 		StairsUpX:       stairsUpX,
 		StairsUpY:       stairsUpY,
 		RespawnCooldown: -1,
+		cleared:         liveEnemies(w) == 0,
 	}
 }
 
@@ -108,45 +145,67 @@ func newFloor(num int, rng *rand.Rand) *Floor {
 // Supports both Spire (1-10) and Chronoliths (101-110) floor numbers.
 func levelConfig(floor int, rng *rand.Rand) *generate.Config {
 	df := assets.DungeonFloor(floor)
-	t := 0.0
-	if MaxFloors > 1 {
-		t = float64(df-1) / float64(MaxFloors-1)
-	}
+	fc := assets.FloorConfigFor(floor)
 	furn := assets.FurnitureFor(floor)
-	return &generate.Config{
-		MapWidth:         lerpi(40, 90, t),
-		MapHeight:        lerpi(20, 50, t),
-		MinLeafSize:      8,
-		MaxLeafSize:      lerpi(20, 10, t),
+	cfg := &generate.Config{
+		MapWidth:         fc.Width,
+		MapHeight:        fc.Height,
+		MinLeafSize:      assets.MinLeafSize,
+		MaxLeafSize:      fc.MaxLeafSize,
 		SplitRatio:       0.5,
 		MinRoomSize:      4,
 		RoomPadding:      1,
-		CorridorStyle:    generate.CorridorLShaped,
+		CorridorStyle:    fc.Corridors,
 		FloorNumber:      df,
-		EnemyBudget:      lerpi(5, 55, t),
-		ItemCount:        lerpi(3, 8, t),
-		EquipCount:       lerpi(1, 3, t),
+		EnemyBudget:      fc.EnemyBudget,
+		ItemCount:        fc.ItemCount,
+		EquipCount:       fc.EquipCount,
 		EnemyTable:       assets.EnemyTable(floor),
 		ItemTable:        itemTableForFloor(floor),
 		EquipTable:       assets.EquipTablesForFloor(df),
 		InscriptionTexts: assets.WallWritingsFor(floor),
 		InscriptionCount: 2 + rng.Intn(4),
+		RuneCount:        assets.RuneCount(floor),
+		PuzzleGlyphs:     assets.PuzzleFor(floor).Glyphs,
+		PuzzleHint:       assets.PuzzleFor(floor).Hint,
+		VaultGold:        assets.PuzzleFor(floor).Gold,
 		EliteEnemy:       assets.EliteEnemy(floor),
 		CommonFurniture:  furn.Common,
 		RareFurniture:    furn.Rare,
 		FurniturePerRoom: 2,
+		GoldPileCount:    fc.GoldPiles,
+		GoldPileMax:      fc.GoldPileMax,
+		VendingChance:    fc.VendingChance,
+		Infighting:       assets.Infighting(floor),
+		Leash:            EnemyLeash,
+		Affix:            generate.RollAffix(fc.AffixChance, rng),
+		ChuteChance:      assets.ChuteChance(floor),
+		ChuteWarning:     assets.ChuteWarning,
+		SanctuaryChance:  assets.SanctuaryChance(floor),
 		Rand:             rng,
 	}
+	if fc.Dark {
+		cfg.Affix = gamemap.AffixDarkness
+	}
+	return cfg
 }
 
 func itemTableForFloor(floor int) []generate.ItemSpawnEntry {
 	base := assets.ItemTable(floor)
 	var extra []generate.ItemSpawnEntry
+	if floor >= 2 {
+		extra = append(extra, generate.ItemSpawnEntry{Glyph: assets.GlyphSnareKit, Name: "Snare Kit"})
+		extra = append(extra, generate.ItemSpawnEntry{Glyph: assets.GlyphDecoyDoll, Name: "Decoy Doll"})
+	}
 	if floor >= 3 {
 		extra = append(extra, generate.ItemSpawnEntry{Glyph: assets.GlyphResonanceBurst, Name: "Resonance Burst"})
+		extra = append(extra, generate.ItemSpawnEntry{Glyph: assets.GlyphCaltropPouch, Name: "Caltrop Pouch"})
+	}
+	if floor >= 4 {
+		extra = append(extra, generate.ItemSpawnEntry{Glyph: assets.GlyphTempoTonic, Name: "Tempo Tonic"})
 	}
 	if floor >= 5 {
-		extra = append(extra, generate.ItemSpawnEntry{Glyph: assets.GlyphNanoSyringe, Name: "Nano-Syringe"})
+		extra = append(extra, generate.ItemSpawnEntry{Glyph: assets.GlyphNanoSyringe, Name: "Nano-Syringe", Heals: true})
 	}
 	if floor >= 6 {
 		extra = append(extra, generate.ItemSpawnEntry{Glyph: assets.GlyphPhaseRod, Name: "Phase Rod"})
@@ -163,10 +222,6 @@ func itemTableForFloor(floor int) []generate.ItemSpawnEntry {
 	return combined
 }
 
-func lerpi(a, b int, t float64) int {
-	return int(math.Round(float64(a) + t*float64(b-a)))
-}
-
 // globalMessage broadcasts a message to all sessions regardless of floor.
 func globalMessage(sessions []*Session, msg string) {
 	for _, s := range sessions {
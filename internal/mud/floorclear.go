@@ -0,0 +1,85 @@
+package mud
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/game"
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// offerClearBonusLocked offers the clear bonus to every living player on
+// floor, which has just lost its last enemy, who has not been offered it
+// since arriving.
+// Caller must hold s.mu.
+func (s *Server) offerClearBonusLocked(floor *Floor) {
+	for _, sess := range s.sessions {
+		if sess.FloorNum != floor.Num || sess.GetDeathCountdown() != 0 || sess.ClearBonusOffered {
+			continue
+		}
+		hc := floor.World.Get(sess.PlayerID, component.CHealth)
+		if hc == nil {
+			continue
+		}
+		sess.ClearBonusOffered = true
+		sess.RunLog.FloorsCleared++
+		sess.PendingClearBonus = game.ClearBonuses(floor.Num, hc.(component.Health).Max, floor.CombatRng)
+		sess.AddMessage("🏆 Floor cleared! Choose a reward for your thoroughness.")
+	}
+}
+
+// RunClearBonus opens the blocking clear bonus UI for bonuses. The pick is
+// settled under the lock afterwards, so a player who died or changed floors
+// while the modal was open gets nothing.
+func (s *Server) RunClearBonus(sess *Session, bonuses []game.ClearBonus, eventCh <-chan tcell.Event) {
+	s.lockSession(sess)
+	floorNum := sess.FloorNum
+	var inv component.Inventory
+	floor, ok := s.floors[floorNum]
+	if ok {
+		ic := floor.World.Get(sess.PlayerID, component.CInventory)
+		ok = ic != nil
+		if ok {
+			inv = ic.(component.Inventory)
+		}
+	}
+	s.unlockSession(sess)
+	if !ok {
+		return
+	}
+
+	idx := game.RunClearBonusModal(sess.Screen, sessionEvents(eventCh), bonuses, &inv)
+	if idx < 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sess.FloorNum != floorNum || sess.GetDeathCountdown() != 0 {
+		return
+	}
+	s.takeClearBonusLocked(floor, sess, bonuses[idx])
+}
+
+// takeClearBonusLocked grants bonus to the player on floor.
+// Caller must hold s.mu.
+func (s *Server) takeClearBonusLocked(floor *Floor, sess *Session, bonus game.ClearBonus) {
+	switch bonus.Kind {
+	case game.ClearHeal:
+		restoreHP(floor.World, sess.PlayerID, bonus.Amount)
+	case game.ClearGold:
+		sess.Gold += bonus.Amount
+		sess.RunLog.GoldEarned += bonus.Amount
+	case game.ClearItem:
+		ic := floor.World.Get(sess.PlayerID, component.CInventory)
+		if ic == nil {
+			return
+		}
+		inv := ic.(component.Inventory)
+		if !inv.Stow(bonus.Item) {
+			sess.AddMessage("Backpack full! Drop something first.")
+			return
+		}
+		floor.World.Add(sess.PlayerID, inv)
+	}
+	sess.AddMessage(fmt.Sprintf("You take your reward: %s.", bonus.Label()))
+}
@@ -0,0 +1,72 @@
+package mud
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/game"
+	"testing"
+)
+
+func TestClearingFloorOffersBonusOncePerVisit(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transitionFloorLocked(sess, 1)
+	floor := srv.floors[1]
+	for _, id := range floor.World.Query(component.CAI) {
+		floor.World.DestroyEntity(id)
+	}
+
+	srv.tickFloorLocked(floor)
+	if len(sess.PendingClearBonus) != 3 || sess.RunLog.FloorsCleared != 1 {
+		t.Fatalf("offered %d bonuses, %d clears logged; want 3 and 1", len(sess.PendingClearBonus), sess.RunLog.FloorsCleared)
+	}
+
+	sess.PendingClearBonus = nil
+	srv.tickFloorLocked(floor)
+	if sess.PendingClearBonus != nil || sess.RunLog.FloorsCleared != 1 {
+		t.Error("a floor that stays clear should not offer the bonus again")
+	}
+
+	srv.respawnEnemiesLocked(floor)
+	if liveEnemies(floor.World) == 0 {
+		t.Fatal("no wave respawned")
+	}
+	srv.tickFloorLocked(floor)
+	if floor.cleared {
+		t.Error("a fresh wave should make the floor clearable again")
+	}
+
+	for _, id := range floor.World.Query(component.CAI) {
+		floor.World.DestroyEntity(id)
+	}
+	srv.tickFloorLocked(floor)
+	if sess.PendingClearBonus != nil || sess.RunLog.FloorsCleared != 1 {
+		t.Error("clearing a respawned wave should not offer the bonus again on the same visit")
+	}
+
+	srv.transitionFloorLocked(sess, 2)
+	srv.transitionFloorLocked(sess, 1)
+	if sess.ClearBonusOffered {
+		t.Error("changing floors should make the bonus available again")
+	}
+}
+
+func TestTakeClearBonusGold(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transitionFloorLocked(sess, 1)
+	floor := srv.floors[1]
+	gold := sess.Gold
+
+	srv.takeClearBonusLocked(floor, sess, game.ClearBonus{Kind: game.ClearGold, Amount: game.ClearGoldPerFloor})
+	if sess.Gold != gold+game.ClearGoldPerFloor || sess.RunLog.GoldEarned != game.ClearGoldPerFloor {
+		t.Errorf("gold %d (earned %d); want the %d gold bonus", sess.Gold-gold, sess.RunLog.GoldEarned, game.ClearGoldPerFloor)
+	}
+}
@@ -0,0 +1,30 @@
+package mud
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/factory"
+	"emoji-roguelike/internal/system"
+	"fmt"
+)
+
+// dropGoldLocked may leave a gold pile where an enemy with the given glyph
+// died, on top of the bounty paid to its killer. Caller must hold s.mu.
+func (s *Server) dropGoldLocked(floor *Floor, pos component.Position, glyph string) {
+	factory.DropGold(floor.World, floor.CombatRng, assets.ThreatForGlyph(glyph), pos.X, pos.Y)
+}
+
+// collectGoldLocked picks up any gold pile at the player's position.
+// Caller must hold s.mu.
+func (s *Server) collectGoldLocked(floor *Floor, sess *Session) {
+	posComp := floor.World.Get(sess.PlayerID, component.CPosition)
+	if posComp == nil {
+		return
+	}
+	pos := posComp.(component.Position)
+	if amount := system.CollectGold(floor.World, pos.X, pos.Y); amount > 0 {
+		sess.Gold += amount
+		sess.RunLog.GoldEarned += amount
+		sess.AddMessage(fmt.Sprintf("You scoop up %d gold. (%d💰)", amount, sess.Gold))
+	}
+}
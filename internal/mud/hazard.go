@@ -0,0 +1,33 @@
+package mud
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/game"
+	"emoji-roguelike/internal/system"
+)
+
+// applyHazardsLocked lets floor's hazards hurt whoever stands in them this
+// tick. Damage to a player counts toward their death like poison does. An
+// enemy a hazard kills belongs to no one: it drops its spoils, and a boss
+// slain this way still wins the floor's players the run.
+// Caller must hold s.mu.
+func (s *Server) applyHazardsLocked(floor *Floor) {
+	for _, hit := range system.TickHazards(floor.World) {
+		if hit.Killed {
+			floorMessage(s.sessions, floor.Num, game.HazardKillMessage(hit.Glyph, hit.Hazard))
+			s.creditKillLocked(floor, nil, "", hit.Glyph, hit.Pos, hit.Loot)
+			continue
+		}
+		for _, sess := range s.sessions {
+			if sess.FloorNum != floor.Num || sess.PlayerID != hit.Target {
+				continue
+			}
+			sess.RunLog.DamageTaken += hit.Damage
+			sess.RunLog.CauseOfDeath = hit.Hazard
+			if hp := floor.World.Get(sess.PlayerID, component.CHealth); hp != nil && sess.Renderer != nil && !sess.HitFlashOff {
+				sess.Renderer.NoteDamage(hit.Damage, hp.(component.Health).Max)
+			}
+			sess.AddMessage(game.HazardHurtMessage("you", hit.Hazard, hit.Damage))
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package mud
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/factory"
+	"emoji-roguelike/internal/game"
+	"emoji-roguelike/internal/generate"
+	"slices"
+	"testing"
+)
+
+func TestHazardHurtsPlayerOnTick(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transitionFloorLocked(sess, 1)
+	floor := srv.floors[1]
+	pos := floor.World.Get(sess.PlayerID, component.CPosition).(component.Position)
+	factory.LeaveHazard(floor.World, floor.Num, assets.GlyphToxinSpore, pos.X, pos.Y)
+	def := assets.DeathHazards[assets.GlyphToxinSpore]
+	hp := floor.World.Get(sess.PlayerID, component.CHealth).(component.Health).Current
+
+	srv.applyHazardsLocked(floor)
+	if got := floor.World.Get(sess.PlayerID, component.CHealth).(component.Health).Current; got != hp-def.Damage {
+		t.Errorf("HP after a tick in the %s = %d; want %d", def.Name, got, hp-def.Damage)
+	}
+	if want := game.HazardHurtMessage("you", def.Name, def.Damage); !slices.Contains(sess.Messages, want) {
+		t.Errorf("messages %q; want %q", sess.Messages, want)
+	}
+}
+
+func TestHazardKillDropsItsSpoils(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transitionFloorLocked(sess, 1)
+	floor := srv.floors[1]
+	pos := floor.World.Get(sess.PlayerID, component.CPosition).(component.Position)
+	pos.X += 2
+	elite := factory.NewEnemy(floor.World, generate.EnemySpawnEntry{
+		Glyph: assets.EliteEnemy(1).Glyph, MaxHP: 1, SightRange: 1,
+	}, pos.X, pos.Y, nil)
+	factory.LeaveHazard(floor.World, floor.Num, assets.GlyphToxinSpore, pos.X, pos.Y)
+
+	srv.applyHazardsLocked(floor)
+	if floor.World.Alive(elite) {
+		t.Fatal("the elite should die in the hazard")
+	}
+	if _, ok := game.ChestAt(floor.World, pos); !ok {
+		t.Error("an elite killed by a hazard should still leave its chest")
+	}
+}
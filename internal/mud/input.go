@@ -1,6 +1,10 @@
 package mud
 
-import "github.com/gdamore/tcell/v2"
+import (
+	"emoji-roguelike/internal/game"
+
+	"github.com/gdamore/tcell/v2"
+)
 
 // Action represents a player-requested game action.
 type Action uint8
@@ -26,6 +30,15 @@ const (
 	ActionUseStairs
 	ActionChat
 	ActionLevelUp
+	ActionToggleFlash
+	ActionWho
+	ActionCommand
+	ActionBlock
+	// ActionHotbar1 to ActionHotbar4 use the hotbar slots; see game.HotbarKeys.
+	ActionHotbar1
+	ActionHotbar2
+	ActionHotbar3
+	ActionHotbar4
 )
 
 // keyToAction maps a tcell key event to a game action.
@@ -97,6 +110,17 @@ func keyToAction(ev *tcell.EventKey) Action {
 		return ActionHelp
 	case 't', 'T':
 		return ActionChat
+	case 'f', 'F':
+		return ActionToggleFlash
+	case 'w', 'W':
+		return ActionWho
+	case '/':
+		return ActionCommand
+	case 'p', 'P':
+		return ActionBlock
+	}
+	if slot, ok := game.HotbarSlot(ev.Rune()); ok {
+		return ActionHotbar1 + Action(slot)
 	}
 	return ActionNone
 }
@@ -1,8 +1,11 @@
 package mud
 
 import (
+	"emoji-roguelike/assets"
 	"emoji-roguelike/internal/component"
 	"emoji-roguelike/internal/factory"
+	"emoji-roguelike/internal/game"
+	"emoji-roguelike/internal/system"
 	"fmt"
 
 	"github.com/gdamore/tcell/v2"
@@ -13,15 +16,15 @@ import (
 // Modifies inventory in-place; writes back to ECS on exit.
 // Returns true if a consumable was used (a turn was spent).
 func (s *Server) RunInventory(sess *Session, eventCh <-chan tcell.Event) bool {
-	s.mu.Lock()
+	s.lockSession(sess)
 	floor, ok := s.floors[sess.FloorNum]
 	if !ok {
-		s.mu.Unlock()
+		s.unlockSession(sess)
 		return false
 	}
 	invComp := floor.World.Get(sess.PlayerID, component.CInventory)
 	if invComp == nil {
-		s.mu.Unlock()
+		s.unlockSession(sess)
 		return false
 	}
 	inv := invComp.(component.Inventory)
@@ -29,7 +32,8 @@ func (s *Server) RunInventory(sess *Session, eventCh <-chan tcell.Event) bool {
 	// while the modal is open, we discard the stale local copy.
 	snapshotFloor := sess.FloorNum
 	snapshotPlayer := sess.PlayerID
-	s.mu.Unlock()
+	hotbar := sess.Hotbar
+	s.unlockSession(sess)
 
 	panel := 0
 	cursor := 0
@@ -65,14 +69,13 @@ func (s *Server) RunInventory(sess *Session, eventCh <-chan tcell.Event) bool {
 			return
 		}
 		if f, ok2 := s.floors[sess.FloorNum]; ok2 {
-			f.World.Add(sess.PlayerID, inv)
-			recalcMaxHP(f.World, sess)
+			saveInventoryLocked(f, sess, inv)
 		}
 	}
 
 	for {
 		clamp()
-		drawInvScreen(sess.Screen, inv, panel, cursor, statusMsg)
+		drawInvScreen(sess.Screen, inv, hotbar, panel, cursor, statusMsg)
 
 		ev, ok := <-eventCh
 		if !ok || ev == nil {
@@ -122,7 +125,12 @@ func (s *Server) RunInventory(sess *Session, eventCh <-chan tcell.Event) bool {
 					save()
 					return turnUsed
 				default:
-					if ev.Rune() >= '1' && ev.Rune() <= '9' {
+					if slot, ok := game.HotbarSlot(ev.Rune()); ok {
+						s.lockSession(sess)
+						statusMsg = sess.Hotbar.Bind(inv, panel, cursor, slot)
+						hotbar = sess.Hotbar
+						s.unlockSession(sess)
+					} else if ev.Rune() >= '1' && ev.Rune() <= '9' {
 						idx := int(ev.Rune()-'0') - 1
 						if idx < len(inv.Backpack) {
 							panel = 0
@@ -146,15 +154,66 @@ func (s *Server) invUseConsumable(sess *Session, inv *component.Inventory, panel
 	if !item.IsConsumable {
 		return "Equipment must be equipped, not used.", false
 	}
+	s.lockSession(sess)
+	defer s.unlockSession(sess)
+	f, ok := s.floors[sess.FloorNum]
+	if ok {
+		if why := consumableRefusalLocked(f, sess, item); why != "" {
+			return why, false
+		}
+	}
 	inv.Backpack = removeAt(inv.Backpack, cursor)
-	s.mu.Lock()
-	if f, ok := s.floors[sess.FloorNum]; ok {
+	if ok {
 		s.applyConsumableLocked(f, sess, item)
 	}
-	s.mu.Unlock()
 	return fmt.Sprintf("Used %s.", item.Name), true
 }
 
+// consumableRefusalLocked explains why item cannot be used where sess's
+// player stands, or returns "" if it can. A refused item stays in the
+// backpack. Caller must hold s.mu.
+func consumableRefusalLocked(floor *Floor, sess *Session, item component.Item) string {
+	pc := floor.World.Get(sess.PlayerID, component.CPosition)
+	if pc == nil {
+		return ""
+	}
+	pos := pc.(component.Position)
+	switch item.Glyph {
+	case assets.GlyphSnareKit, assets.GlyphCaltropPouch:
+		if !system.CanPlaceTrap(floor.World, floor.GMap, pos.X, pos.Y) {
+			return "You can't set a trap here."
+		}
+	case assets.GlyphDecoyDoll:
+		if _, _, ok := system.FindDeploySpot(floor.World, floor.GMap, pos); !ok {
+			return "No room to set a decoy down here."
+		}
+	}
+	return ""
+}
+
+// useHotbarLocked uses the consumable bound to hotbar slot without opening
+// the inventory. Caller must hold s.mu.
+func (s *Server) useHotbarLocked(floor *Floor, sess *Session, slot int) {
+	ic := floor.World.Get(sess.PlayerID, component.CInventory)
+	if ic == nil {
+		return
+	}
+	inv := ic.(component.Inventory)
+	i := sess.Hotbar.Find(inv, slot)
+	if i < 0 {
+		sess.AddMessage(sess.Hotbar.EmptyMessage(slot))
+		return
+	}
+	item := inv.Backpack[i]
+	if why := consumableRefusalLocked(floor, sess, item); why != "" {
+		sess.AddMessage(why)
+		return
+	}
+	inv.Backpack = removeAt(inv.Backpack, i)
+	saveInventoryLocked(floor, sess, inv)
+	s.applyConsumableLocked(floor, sess, item)
+}
+
 func (s *Server) invDrop(sess *Session, inv *component.Inventory, panel int, cursor *int) string {
 	// Determine which item to drop from the local inventory copy first.
 	var item component.Item
@@ -217,6 +276,16 @@ func (s *Server) invDrop(sess *Session, inv *component.Inventory, panel int, cur
 
 // ─── inventory manipulation helpers ──────────────────────────────────────────
 
+// saveInventoryLocked writes inv back to the session's player and refits
+// MaxHP to the gear now worn, skill bonuses included, telling the player if
+// shedding MaxHP cost them current HP. Caller must hold s.mu.
+func saveInventoryLocked(floor *Floor, sess *Session, inv component.Inventory) {
+	floor.World.Add(sess.PlayerID, inv)
+	if lost := recalcMaxHPWithSkills(floor.World, sess); lost > 0 {
+		sess.AddMessage(fmt.Sprintf("Your max HP drops, and %d HP goes with it.", lost))
+	}
+}
+
 func invEquipOrUnequip(inv *component.Inventory, panel, cursor int) string {
 	if panel == 0 {
 		if cursor < 0 || cursor >= len(inv.Backpack) {
@@ -238,7 +307,7 @@ func invEquip(inv *component.Inventory, cursor int) string {
 		inv.Backpack = removeAt(inv.Backpack, cursor)
 		inv.Head = item
 		if !old.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, old)
+			inv.Stow(old)
 		}
 		return fmt.Sprintf("Equipped %s.", item.Name)
 	case component.SlotBody:
@@ -246,7 +315,7 @@ func invEquip(inv *component.Inventory, cursor int) string {
 		inv.Backpack = removeAt(inv.Backpack, cursor)
 		inv.Body = item
 		if !old.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, old)
+			inv.Stow(old)
 		}
 		return fmt.Sprintf("Equipped %s.", item.Name)
 	case component.SlotFeet:
@@ -254,7 +323,7 @@ func invEquip(inv *component.Inventory, cursor int) string {
 		inv.Backpack = removeAt(inv.Backpack, cursor)
 		inv.Feet = item
 		if !old.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, old)
+			inv.Stow(old)
 		}
 		return fmt.Sprintf("Equipped %s.", item.Name)
 	case component.SlotOneHand:
@@ -262,29 +331,23 @@ func invEquip(inv *component.Inventory, cursor int) string {
 		inv.Backpack = removeAt(inv.Backpack, cursor)
 		inv.MainHand = item
 		if !old.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, old)
+			inv.Stow(old)
 		}
 		return fmt.Sprintf("Equipped %s.", item.Name)
 	case component.SlotTwoHand:
-		extra := 0
-		if !inv.MainHand.IsEmpty() {
-			extra++
-		}
-		if !inv.OffHand.IsEmpty() {
-			extra++
+		var displaced []component.Item
+		for _, held := range []component.Item{inv.OffHand, inv.MainHand} {
+			if !held.IsEmpty() {
+				displaced = append(displaced, held)
+			}
 		}
-		if len(inv.Backpack)-1+extra > inv.Capacity {
+		// The cursor item leaves the backpack before the held items enter it.
+		if !inv.CanHold(len(displaced) - 1) {
 			return "Not enough backpack space to swap."
 		}
 		inv.Backpack = removeAt(inv.Backpack, cursor)
-		if !inv.OffHand.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, inv.OffHand)
-			inv.OffHand = component.Item{}
-		}
-		if !inv.MainHand.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, inv.MainHand)
-		}
-		inv.MainHand = item
+		inv.Stow(displaced...)
+		inv.MainHand, inv.OffHand = item, component.Item{}
 		return fmt.Sprintf("Equipped %s (two-handed).", item.Name)
 	case component.SlotOffHand:
 		if inv.MainHand.Slot == component.SlotTwoHand {
@@ -294,7 +357,7 @@ func invEquip(inv *component.Inventory, cursor int) string {
 		inv.Backpack = removeAt(inv.Backpack, cursor)
 		inv.OffHand = item
 		if !old.IsEmpty() {
-			inv.Backpack = append(inv.Backpack, old)
+			inv.Stow(old)
 		}
 		return fmt.Sprintf("Equipped %s.", item.Name)
 	}
@@ -302,7 +365,7 @@ func invEquip(inv *component.Inventory, cursor int) string {
 }
 
 func invUnequip(inv *component.Inventory, cursor int) string {
-	if len(inv.Backpack) >= inv.Capacity {
+	if !inv.CanHold(1) {
 		return "Backpack full — drop something first."
 	}
 	var item component.Item
@@ -335,7 +398,7 @@ func invUnequip(inv *component.Inventory, cursor int) string {
 	default:
 		return "Invalid slot."
 	}
-	inv.Backpack = append(inv.Backpack, item)
+	inv.Stow(item)
 	return fmt.Sprintf("Unequipped %s.", item.Name)
 }
 
@@ -347,8 +410,8 @@ func removeAt(s []component.Item, i int) []component.Item {
 }
 
 func formatBonuses(item component.Item) string {
-	if item.BonusATK == 0 && item.BonusDEF == 0 && item.BonusMaxHP == 0 {
-		return ""
+	if item.BonusATK == 0 && item.BonusDEF == 0 && item.BonusMaxHP == 0 && item.CDRPercent == 0 {
+		return game.DurabilityTag(item)
 	}
 	s := " ("
 	if item.BonusATK != 0 {
@@ -366,7 +429,13 @@ func formatBonuses(item component.Item) string {
 		}
 		s += fmt.Sprintf("HP%+d", item.BonusMaxHP)
 	}
-	return s + ")"
+	if item.CDRPercent != 0 {
+		if len(s) > 2 {
+			s += " "
+		}
+		s += fmt.Sprintf("CDR-%d%%", item.CDRPercent)
+	}
+	return s + ")" + game.DurabilityTag(item)
 }
 
 func slotLabel(slot component.ItemSlot) string {
@@ -389,7 +458,7 @@ func slotLabel(slot component.ItemSlot) string {
 
 // ─── draw ─────────────────────────────────────────────────────────────────────
 
-func drawInvScreen(screen tcell.Screen, inv component.Inventory, panel, cursor int, statusMsg string) {
+func drawInvScreen(screen tcell.Screen, inv component.Inventory, hotbar game.Hotbar, panel, cursor int, statusMsg string) {
 	screen.Clear()
 	sw, _ := screen.Size()
 	mid := sw / 2
@@ -417,7 +486,7 @@ func drawInvScreen(screen tcell.Screen, inv component.Inventory, panel, cursor i
 		screen.SetContent(x, 1, '─', nil, gray)
 	}
 	put(0, 2, "── EQUIPPED ──────────────────", white)
-	put(mid, 2, "── BACKPACK ─────────────────", white)
+	put(mid, 2, "── BACKPACK ─ [!@#$] Hotbar ─", white)
 	for y := 2; y <= 12; y++ {
 		screen.SetContent(mid-1, y, '│', nil, gray)
 	}
@@ -445,9 +514,7 @@ func drawInvScreen(screen tcell.Screen, inv component.Inventory, panel, cursor i
 		put(0, row, fmt.Sprintf("%s%s %s", pfx, slot.label, itemStr), style)
 	}
 
-	atkB := inv.Head.BonusATK + inv.Body.BonusATK + inv.Feet.BonusATK + inv.MainHand.BonusATK + inv.OffHand.BonusATK
-	defB := inv.Head.BonusDEF + inv.Body.BonusDEF + inv.Feet.BonusDEF + inv.MainHand.BonusDEF + inv.OffHand.BonusDEF
-	hpB := inv.Head.BonusMaxHP + inv.Body.BonusMaxHP + inv.Feet.BonusMaxHP + inv.MainHand.BonusMaxHP + inv.OffHand.BonusMaxHP
+	atkB, defB, hpB := inv.EquipBonuses()
 	put(0, 8, fmt.Sprintf("  Equip bonus: ATK%+d DEF%+d HP%+d", atkB, defB, hpB), cyan)
 
 	for i, item := range inv.Backpack {
@@ -466,6 +533,7 @@ func drawInvScreen(screen tcell.Screen, inv component.Inventory, panel, cursor i
 		if item.IsConsumable {
 			tag = " [use]"
 		}
+		tag += hotbar.Tag(item)
 		put(mid, row, fmt.Sprintf("%s[%d] %s %s%s%s", pfx, i+1, item.Glyph, item.Name, formatBonuses(item), tag), style)
 	}
 	if len(inv.Backpack) == 0 {
@@ -500,6 +568,9 @@ func drawInvScreen(screen tcell.Screen, inv component.Inventory, panel, cursor i
 		put(0, 12, fmt.Sprintf("%s — %s  ATK%+d DEF%+d MaxHP%+d",
 			selItem.Name, slotLabel(selItem.Slot), selItem.BonusATK, selItem.BonusDEF, selItem.BonusMaxHP), white)
 	}
+	if panel == 0 && !selEmpty {
+		game.DrawEquipComparison(screen, 14, inv, selItem)
+	}
 	if statusMsg != "" {
 		put(0, 13, statusMsg, green)
 	}
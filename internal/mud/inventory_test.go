@@ -0,0 +1,187 @@
+package mud
+
+import (
+	"strings"
+	"testing"
+
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+)
+
+// vitalPlate is a body piece granting +8 MaxHP.
+var vitalPlate = component.Item{Name: "Vital Plate", Slot: component.SlotBody, BonusMaxHP: 8}
+
+// wearAndShed equips vitalPlate on sess, sets current HP via setHP, then
+// unequips it, saving the inventory after each step as RunInventory does.
+func wearAndShed(floor *Floor, sess *Session, setHP func(max int) int) component.Health {
+	inv := floor.World.Get(sess.PlayerID, component.CInventory).(component.Inventory)
+	inv.Backpack = append(inv.Backpack, vitalPlate)
+	invEquip(&inv, len(inv.Backpack)-1)
+	saveInventoryLocked(floor, sess, inv)
+
+	hp := floor.World.Get(sess.PlayerID, component.CHealth).(component.Health)
+	hp.Current = setHP(hp.Max)
+	floor.World.Add(sess.PlayerID, hp)
+
+	invUnequip(&inv, 1)
+	saveInventoryLocked(floor, sess, inv)
+	return floor.World.Get(sess.PlayerID, component.CHealth).(component.Health)
+}
+
+func lostHPMessage(sess *Session) bool {
+	for _, m := range sess.Messages {
+		if strings.Contains(m, "Your max HP drops") {
+			return true
+		}
+	}
+	return false
+}
+
+func TestUnequipMaxHPAtFullHPWarns(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transitionFloorLocked(sess, 1)
+	floor := srv.floors[1]
+	base := floor.World.Get(sess.PlayerID, component.CHealth).(component.Health).Max
+
+	hp := wearAndShed(floor, sess, func(max int) int { return max })
+	if hp.Max != base || hp.Current != base {
+		t.Errorf("HP after unequipping = %d/%d; want %d/%d", hp.Current, hp.Max, base, base)
+	}
+	if !lostHPMessage(sess) {
+		t.Error("losing HP to an unequip should say so")
+	}
+}
+
+func TestUnequipMaxHPAtPartialHPKeepsCurrent(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transitionFloorLocked(sess, 1)
+	floor := srv.floors[1]
+	base := floor.World.Get(sess.PlayerID, component.CHealth).(component.Health).Max
+
+	hp := wearAndShed(floor, sess, func(max int) int { return max - 10 })
+	if hp.Max != base || hp.Current != base+vitalPlate.BonusMaxHP-10 {
+		t.Errorf("HP after unequipping = %d/%d; want %d/%d",
+			hp.Current, hp.Max, base+vitalPlate.BonusMaxHP-10, base)
+	}
+	if lostHPMessage(sess) {
+		t.Error("no HP was lost, so there should be no warning")
+	}
+}
+
+func TestDropTwoHanderFreesOffHand(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+	srv.mu.Lock()
+	srv.transitionFloorLocked(sess, 1)
+	inv := srv.floors[1].World.Get(sess.PlayerID, component.CInventory).(component.Inventory)
+	srv.mu.Unlock()
+
+	greatblade := component.Item{Name: "Greatblade", Slot: component.SlotTwoHand, BonusATK: 5}
+	buckler := component.Item{Name: "Buckler", Slot: component.SlotOffHand, BonusDEF: 2}
+	inv.Backpack = append(inv.Backpack, greatblade, buckler)
+	index := func(name string) int {
+		for i, it := range inv.Backpack {
+			if it.Name == name {
+				return i
+			}
+		}
+		return -1
+	}
+
+	invEquip(&inv, index(greatblade.Name))
+	cursor := 3 // weapon slot
+	srv.invDrop(sess, &inv, 1, &cursor)
+	invEquip(&inv, index(buckler.Name))
+
+	if !inv.MainHand.IsEmpty() || inv.OffHand.Name != buckler.Name {
+		t.Errorf("MainHand %q, OffHand %q; want empty and the buckler", inv.MainHand.Name, inv.OffHand.Name)
+	}
+	if index(buckler.Name) >= 0 || index(greatblade.Name) >= 0 {
+		t.Errorf("backpack still holds the buckler or greatblade: %v", inv.Backpack)
+	}
+}
+
+// fullPack returns an inventory whose backpack is exactly at capacity, with a
+// one-handed blade and a buckler in hand and a greatsword in the backpack.
+func fullPack() component.Inventory {
+	inv := component.Inventory{
+		Capacity: 3,
+		MainHand: component.Item{Name: "Blade", Slot: component.SlotOneHand},
+		OffHand:  component.Item{Name: "Buckler", Slot: component.SlotOffHand},
+	}
+	inv.Backpack = []component.Item{
+		{Name: "Greatsword", Slot: component.SlotTwoHand},
+		{Name: "Dagger", Slot: component.SlotOneHand},
+		{Name: "Tonic", IsConsumable: true},
+	}
+	return inv
+}
+
+func TestFullBackpackRefusesUnequip(t *testing.T) {
+	inv := fullPack()
+	if msg := invUnequip(&inv, 3); msg != "Backpack full — drop something first." {
+		t.Errorf("unequip with a full backpack: %q", msg)
+	}
+	if len(inv.Backpack) != inv.Capacity || inv.MainHand.Name != "Blade" {
+		t.Errorf("refused unequip changed the inventory: %d items, main hand %q", len(inv.Backpack), inv.MainHand.Name)
+	}
+}
+
+func TestFullBackpackRefusesTwoHandSwap(t *testing.T) {
+	inv := fullPack()
+	// The greatsword frees one slot but the blade and buckler need two.
+	if msg := invEquip(&inv, 0); msg != "Not enough backpack space to swap." {
+		t.Errorf("two-hand swap with a full backpack: %q", msg)
+	}
+	if len(inv.Backpack) != inv.Capacity || inv.MainHand.Name != "Blade" || inv.OffHand.Name != "Buckler" {
+		t.Errorf("refused swap changed the inventory: %d items, hands %q/%q",
+			len(inv.Backpack), inv.MainHand.Name, inv.OffHand.Name)
+	}
+}
+
+func TestFullBackpackAllowsOneForOneSwap(t *testing.T) {
+	inv := fullPack()
+	invEquip(&inv, 1) // dagger for blade
+	if inv.MainHand.Name != "Dagger" || len(inv.Backpack) != inv.Capacity {
+		t.Errorf("main hand %q with %d items; want the dagger and a still-full backpack",
+			inv.MainHand.Name, len(inv.Backpack))
+	}
+}
+
+func TestHotbarUsesBoundConsumable(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transitionFloorLocked(sess, 1)
+	floor := srv.floors[1]
+	flask := component.Item{Name: "Hyperflask", Glyph: assets.GlyphHyperflask, IsConsumable: true}
+	inv := floor.World.Get(sess.PlayerID, component.CInventory).(component.Inventory)
+	inv.Backpack = []component.Item{flask}
+	saveInventoryLocked(floor, sess, inv)
+	sess.Hotbar.Bind(inv, 0, 0, 3)
+
+	srv.processActionLocked(sess, ActionHotbar4)
+	inv = floor.World.Get(sess.PlayerID, component.CInventory).(component.Inventory)
+	if len(inv.Backpack) != 0 {
+		t.Errorf("backpack %+v; want the flask used", inv.Backpack)
+	}
+	if sess.RunLog.ItemsUsed[flask.Glyph] != 1 {
+		t.Error("using the hotbar should count the flask as used")
+	}
+
+	srv.processActionLocked(sess, ActionHotbar4)
+	if want := sess.Hotbar.EmptyMessage(3); sess.Messages[len(sess.Messages)-1] != want {
+		t.Errorf("last message %q; want %q", sess.Messages[len(sess.Messages)-1], want)
+	}
+}
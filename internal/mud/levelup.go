@@ -5,6 +5,7 @@ import (
 	"emoji-roguelike/internal/component"
 	"emoji-roguelike/internal/ecs"
 	"emoji-roguelike/internal/game"
+	"emoji-roguelike/internal/system"
 	"fmt"
 	"math/rand"
 
@@ -40,14 +41,15 @@ func applyLevelGrowthLocked(w *ecs.World, sess *Session) {
 		return
 	}
 	hp, atk, def := assets.GrowthForLevel(growth, sess.Level)
-	// BaseMaxHP starts at class base; add growth HP.
-	sess.BaseMaxHP = sess.Class.MaxHP + hp
+	// BaseMaxHP starts at class base; add growth and furniture HP.
+	sess.BaseMaxHP = sess.Class.MaxHP + hp + sess.FurnitureMaxHP
 
 	if sess.PlayerID == ecs.NilEntity {
 		return
 	}
 
-	// Apply ATK/DEF growth on top of class base + furniture.
+	// Set ATK/DEF outright from class base + growth + furniture, so calling
+	// this again on the same entity never stacks bonuses.
 	if cc := w.Get(sess.PlayerID, component.CCombat); cc != nil {
 		c := cc.(component.Combat)
 		c.Attack = sess.Class.Attack + atk + sess.FurnitureATK
@@ -87,13 +89,14 @@ func applySkillBonusesLocked(w *ecs.World, sess *Session) {
 	w.Add(sess.PlayerID, sb)
 }
 
-// effectiveCooldown returns the cooldown for the session's class ability.
-func effectiveCooldown(sess *Session) int {
+// effectiveCooldown returns the cooldown for the session's class ability,
+// after skill reductions and equipment CDR on the given world.
+func effectiveCooldown(w *ecs.World, sess *Session) int {
 	cd := sess.Class.AbilityCooldown - computeSessionSkillBonuses(sess).CooldownReduce
 	if cd < 1 && sess.Class.AbilityCooldown > 0 {
 		cd = 1
 	}
-	return cd
+	return system.ApplyCDR(cd, system.GetCDRPercent(w, sess.PlayerID))
 }
 
 // effectiveRegenInterval returns the regen interval for the session.
@@ -159,7 +162,7 @@ func (s *Server) RunLevelUp(sess *Session, eventCh <-chan tcell.Event) {
 			case tcell.KeyDown:
 				selected = (selected + 1) % len(offered)
 			case tcell.KeyEnter:
-				s.mu.Lock()
+				s.lockSession(sess)
 				sess.LearnedSkills = append(sess.LearnedSkills, offered[selected].ID)
 				sess.PendingLevels--
 				if floor, ok := s.floors[sess.FloorNum]; ok {
@@ -168,7 +171,7 @@ func (s *Server) RunLevelUp(sess *Session, eventCh <-chan tcell.Event) {
 					recalcMaxHPWithSkills(floor.World, sess)
 				}
 				sess.AddMessage(fmt.Sprintf("Learned %s: %s", offered[selected].Name, offered[selected].Description))
-				s.mu.Unlock()
+				s.unlockSession(sess)
 				return
 			case tcell.KeyEscape:
 				return
@@ -227,26 +230,26 @@ func (s *Server) RunSpecialization(sess *Session, eventCh <-chan tcell.Event) {
 	}
 }
 
-// recalcMaxHPWithSkills recalculates MaxHP including skill bonuses.
-func recalcMaxHPWithSkills(w *ecs.World, sess *Session) {
+// recalcMaxHPWithSkills recalculates MaxHP including skill bonuses. Current
+// HP is clamped to the new MaxHP; returns the HP lost to the clamp.
+func recalcMaxHPWithSkills(w *ecs.World, sess *Session) int {
 	invComp := w.Get(sess.PlayerID, component.CInventory)
 	if invComp == nil {
-		return
+		return 0
 	}
-	inv := invComp.(component.Inventory)
 	sb := computeSessionSkillBonuses(sess)
-	bonus := inv.Head.BonusMaxHP + inv.Body.BonusMaxHP + inv.Feet.BonusMaxHP +
-		inv.MainHand.BonusMaxHP + inv.OffHand.BonusMaxHP + sb.BonusMaxHP
+	_, _, equipHP := invComp.(component.Inventory).EquipBonuses()
+	bonus := equipHP + sb.BonusMaxHP
 	hpComp := w.Get(sess.PlayerID, component.CHealth)
 	if hpComp == nil {
-		return
+		return 0
 	}
 	hp := hpComp.(component.Health)
 	hp.Max = sess.BaseMaxHP + bonus
-	if hp.Current > hp.Max {
-		hp.Current = hp.Max
-	}
+	lost := max(hp.Current-hp.Max, 0)
+	hp.Current -= lost
 	w.Add(sess.PlayerID, hp)
+	return lost
 }
 
 func pickNSkillsMud(pool []assets.SkillDef, n int, rng *rand.Rand) []assets.SkillDef {
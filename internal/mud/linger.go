@@ -0,0 +1,30 @@
+package mud
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/game"
+	"emoji-roguelike/internal/system"
+)
+
+// FloorLingerTicks is how many ticks players may keep a floor occupied before
+// it speaks a lore line and, for the first game.LingerMaxStir times, its
+// enemies grow more alert (~5 minutes at 100 ms/tick).
+const FloorLingerTicks = 3000
+
+// lingerLocked counts one more occupied tick on floor and, at each multiple
+// of FloorLingerTicks, tells the players there a lore line and stirs the
+// floor's enemies.
+// Caller must hold s.mu.
+func (s *Server) lingerLocked(floor *Floor) {
+	floor.lingerTicks++
+	if floor.lingerTicks%FloorLingerTicks != 0 {
+		return
+	}
+	stage := floor.lingerTicks / FloorLingerTicks
+	if line := assets.LingerLine(floor.Num, stage); line != "" {
+		floorMessage(s.sessions, floor.Num, line)
+	}
+	if stage <= game.LingerMaxStir && system.Stir(floor.World) > 0 {
+		floorMessage(s.sessions, floor.Num, game.StirMessage)
+	}
+}
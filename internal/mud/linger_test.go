@@ -0,0 +1,38 @@
+package mud
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/game"
+	"slices"
+	"testing"
+)
+
+func TestLingeringPlayersStirTheFloor(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transitionFloorLocked(sess, 1)
+	floor := srv.floors[1]
+	enemies := floor.World.Query(component.CAI)
+	if len(enemies) == 0 {
+		t.Fatal("floor 1 has no enemies")
+	}
+	enemy := enemies[0]
+	sight := floor.World.Get(enemy, component.CAI).(component.AI).SightRange
+
+	floor.lingerTicks = FloorLingerTicks - 1
+	srv.lingerLocked(floor)
+	if line := assets.LingerLine(1, 1); !slices.Contains(sess.Messages, line) {
+		t.Errorf("messages %q; want the linger line %q", sess.Messages, line)
+	}
+	if !slices.Contains(sess.Messages, game.StirMessage) {
+		t.Errorf("messages %q; want the stir warning", sess.Messages)
+	}
+	if got := floor.World.Get(enemy, component.CAI).(component.AI).SightRange; got != sight+1 {
+		t.Errorf("enemy sight = %d; want %d", got, sight+1)
+	}
+}
@@ -3,10 +3,18 @@ package mud
 import (
 	"emoji-roguelike/assets"
 	"emoji-roguelike/internal/game"
+	"runtime/debug"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
 )
 
+// Title plays the animated title screen on a newly connected player's screen
+// until they press a key. Returns false if they disconnect first.
+func Title(screen tcell.Screen) bool {
+	return game.RunTitle(screen)
+}
+
 // ClassSelect blocks on the session's screen until the player picks a class.
 // Returns false if the player disconnects without selecting.
 func ClassSelect(screen tcell.Screen) (assets.ClassDef, bool) {
@@ -38,7 +46,7 @@ func ClassSelect(screen tcell.Screen) (assets.ClassDef, bool) {
 				selected = (selected + 1) % len(assets.Classes)
 			case 'q', 'Q':
 				return assets.ClassDef{}, false
-			case '1', '2', '3', '4', '5', '6':
+			case '1', '2', '3', '4', '5', '6', '7', '8':
 				idx := int(ev.Rune() - '1')
 				if idx >= 0 && idx < len(assets.Classes) {
 					return assets.Classes[idx], true
@@ -66,6 +74,7 @@ func (s *Server) RunLoop(sess *Session) {
 	}()
 
 	defer s.Log.Info("session ended", "player", sess.Name)
+	defer s.recoverSession(sess)
 
 	// Show help screen on first join so new players learn the controls.
 	runHelp(sess, eventCh)
@@ -121,10 +130,47 @@ func (s *Server) RunLoop(sess *Session) {
 				case ActionChat:
 					if sess.GetDeathCountdown() == 0 {
 						if text, ok := s.RunChat(sess, eventCh); ok {
-							s.mu.Lock()
-							s.BroadcastChat(sess, text)
-							s.mu.Unlock()
+							s.lockSession(sess)
+							if strings.HasPrefix(text, "/") {
+								s.handleCommand(sess, text)
+							} else {
+								s.BroadcastChat(sess, text)
+							}
+							s.unlockSession(sess)
+						}
+						select {
+						case sess.RenderCh <- struct{}{}:
+						default:
 						}
+					}
+				case ActionCommand:
+					if sess.GetDeathCountdown() == 0 {
+						if line, ok := s.RunCommand(sess, eventCh); ok {
+							s.lockSession(sess)
+							s.handleCommand(sess, line)
+							s.unlockSession(sess)
+						}
+						select {
+						case sess.RenderCh <- struct{}{}:
+						default:
+						}
+					}
+				case ActionToggleFlash:
+					s.lockSession(sess)
+					sess.HitFlashOff = !sess.HitFlashOff
+					if sess.HitFlashOff {
+						sess.AddMessage("Heavy-hit flash off.")
+					} else {
+						sess.AddMessage("Heavy-hit flash on.")
+					}
+					s.unlockSession(sess)
+					select {
+					case sess.RenderCh <- struct{}{}:
+					default:
+					}
+				case ActionWho:
+					if sess.GetDeathCountdown() == 0 {
+						s.renderWhoList(sess, eventCh)
 						select {
 						case sess.RenderCh <- struct{}{}:
 						default:
@@ -144,21 +190,36 @@ func (s *Server) RunLoop(sess *Session) {
 			}
 
 		case <-sess.RenderCh:
-			s.mu.Lock()
+			s.lockSession(sess)
 			pendingNPC := sess.PendingNPC
 			sess.PendingNPC = 0 // clear under lock
+			pendingVending := sess.PendingVending
+			sess.PendingVending = 0
+			pendingWho := sess.PendingWho
+			sess.PendingWho = false
+			pendingRepair := sess.PendingRepair
+			sess.PendingRepair = false
+			pendingChest := sess.PendingChest
+			sess.PendingChest = 0
+			pendingClear := sess.PendingClearBonus
+			sess.PendingClearBonus = nil
+			pendingBell := sess.PendingBell
+			sess.PendingBell = false
 			s.RenderSession(sess)
-			s.mu.Unlock()
-			sess.Screen.Show()
+			s.unlockSession(sess)
+			sess.showFrame()
+			if pendingBell {
+				_ = sess.Screen.Beep()
+			}
 
 			// Interactive victory screen: countdown finished, waiting for input.
 			if sess.IsVictory() && sess.GetDeathCountdown() == 0 {
 				if s.runVictory(sess, eventCh) {
 					// Player chose to restart — respawn to Emberveil.
-					s.mu.Lock()
+					s.lockSession(sess)
 					sess.ClearVictory()
 					s.respawnLocked(sess)
-					s.mu.Unlock()
+					s.unlockSession(sess)
 					select {
 					case sess.RenderCh <- struct{}{}:
 					default:
@@ -177,10 +238,86 @@ func (s *Server) RunLoop(sess *Session) {
 				default:
 				}
 			}
+			if pendingVending != 0 && sess.GetDeathCountdown() == 0 {
+				s.RunVending(sess, pendingVending, eventCh)
+				select {
+				case sess.RenderCh <- struct{}{}:
+				default:
+				}
+			}
+			if pendingRepair && sess.GetDeathCountdown() == 0 {
+				s.RunRepair(sess, eventCh)
+				select {
+				case sess.RenderCh <- struct{}{}:
+				default:
+				}
+			}
+			if pendingChest != 0 && sess.GetDeathCountdown() == 0 {
+				s.RunChest(sess, pendingChest, eventCh)
+				select {
+				case sess.RenderCh <- struct{}{}:
+				default:
+				}
+			}
+			if pendingClear != nil && sess.GetDeathCountdown() == 0 {
+				s.RunClearBonus(sess, pendingClear, eventCh)
+				select {
+				case sess.RenderCh <- struct{}{}:
+				default:
+				}
+			}
+			if pendingWho && sess.GetDeathCountdown() == 0 {
+				s.renderWhoList(sess, eventCh)
+				select {
+				case sess.RenderCh <- struct{}{}:
+				default:
+				}
+			}
+			s.lockSession(sess)
+			pendingRolls := len(sess.PendingRolls) > 0
+			s.unlockSession(sess)
+			if pendingRolls && sess.GetDeathCountdown() == 0 {
+				s.RunLootRolls(sess, eventCh)
+				select {
+				case sess.RenderCh <- struct{}{}:
+				default:
+				}
+			}
 		}
 	}
 }
 
+// recoverSession stops a panic in a session's goroutine from taking the
+// whole server down: it logs the panic with its stack, releases s.mu if the
+// session held it, and drops the connection. RunLoop then returns normally,
+// so the transport's deferred RemoveSession and Fini still run.
+func (s *Server) recoverSession(sess *Session) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	s.Log.Error("session panicked", "player", sess.Name, "panic", r, "stack", string(debug.Stack()))
+	if sess.holdsMu {
+		s.unlockSession(sess)
+	}
+	if sess.Disconnect != nil {
+		sess.Disconnect()
+	}
+}
+
+// lockSession takes s.mu from sess's goroutine, noting that it holds the lock
+// so recoverSession can release it if a panic unwinds past the holder.
+func (s *Server) lockSession(sess *Session) {
+	s.mu.Lock()
+	sess.holdsMu = true
+}
+
+// unlockSession releases s.mu taken by lockSession.
+func (s *Server) unlockSession(sess *Session) {
+	sess.holdsMu = false
+	s.mu.Unlock()
+}
+
 // runHelp shows a keybinding reference overlay. Any key dismisses it.
 func runHelp(sess *Session, eventCh <-chan tcell.Event) {
 	lines := []string{
@@ -194,7 +331,10 @@ func runHelp(sess *Session, eventCh <-chan tcell.Event) {
 		"  i                   Inventory",
 		"  Enter               Use stairs",
 		"  z                   Special ability",
+		"  p                   Block (shield: parry)",
+		"  ! @ # $             Use hotbar slot 1-4",
 		"  t                   Chat (proximity)",
+		"  /                   Command (/help)",
 		"",
 		"── Stairs (alternate) ────────────────",
 		"  >                   Descend",
@@ -202,6 +342,8 @@ func runHelp(sess *Session, eventCh <-chan tcell.Event) {
 		"",
 		"── Game ──────────────────────────────",
 		"  q / Esc             Disconnect",
+		"  f                   Toggle hit flash",
+		"  w                   Who's online",
 		"  ?                   This help",
 		"",
 		"  [any key to close]",
@@ -267,9 +409,9 @@ func runHelp(sess *Session, eventCh <-chan tcell.Event) {
 func (s *Server) runVictory(sess *Session, eventCh <-chan tcell.Event) bool {
 	for {
 		// Render the victory screen (which includes [R]/[Q] prompt since countdown == 0).
-		s.mu.Lock()
+		s.lockSession(sess)
 		drawVictoryScreen(sess)
-		s.mu.Unlock()
+		s.unlockSession(sess)
 		sess.Screen.Show()
 
 		ev, ok := <-eventCh
@@ -0,0 +1,92 @@
+package mud
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+	"emoji-roguelike/internal/render"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// panicScreen is a simulation screen whose Show panics.
+type panicScreen struct {
+	tcell.SimulationScreen
+}
+
+func (panicScreen) Show() { panic("boom") }
+
+func TestRunLoopLogsPanic(t *testing.T) {
+	srv := newTestServer()
+	var buf bytes.Buffer
+	srv.Log = slog.New(slog.NewTextHandler(&buf, nil))
+	sess := newTestSession(0, srv)
+	screen := panicScreen{sess.Screen.(tcell.SimulationScreen)}
+	sess.Screen = screen
+	srv.AddSession(sess)
+	defer screen.SimulationScreen.Fini() // stops RunLoop's input reader
+
+	r := func() (r any) {
+		defer func() { r = recover() }()
+		srv.RunLoop(sess)
+		return nil
+	}()
+	if r != nil {
+		t.Fatalf("RunLoop panicked with %v; want the panic recovered", r)
+	}
+	if out := buf.String(); !strings.Contains(out, "session panicked") || !strings.Contains(out, "TestPlayer") {
+		t.Errorf("log = %q; want the panic logged with the player's name", out)
+	}
+}
+
+// panicRenderer is a renderer whose DrawFrame panics, which RenderSession
+// calls with s.mu held.
+type panicRenderer struct {
+	render.NopRenderer
+}
+
+func (panicRenderer) DrawFrame(*ecs.World, *gamemap.GameMap, ecs.EntityID) { panic("boom") }
+
+func TestSessionPanicLeavesServerRunning(t *testing.T) {
+	srv := newTestServer()
+	srv.Log = slog.New(slog.DiscardHandler)
+	crasher, other := newTestSession(0, srv), newTestSession(1, srv)
+	srv.AddSession(crasher)
+	srv.AddSession(other)
+	defer srv.RemoveSession(other)
+	srv.mu.Lock()
+	srv.transitionFloorLocked(crasher, 1)
+	srv.transitionFloorLocked(other, 1)
+	srv.mu.Unlock()
+	crasher.Renderer = panicRenderer{}
+	disconnected := false
+	crasher.Disconnect = func() { disconnected = true }
+	screen := crasher.Screen.(tcell.SimulationScreen)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // dismiss the join help
+	defer screen.Fini()
+
+	srv.RunLoop(crasher) // renders, panicking under s.mu
+	srv.RemoveSession(crasher)
+
+	if !srv.mu.TryLock() {
+		t.Fatal("s.mu still held after the session panicked")
+	}
+	srv.mu.Unlock()
+	if !disconnected {
+		t.Error("the panicking session should be disconnected")
+	}
+	srv.tick()
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if len(srv.sessions) != 1 || srv.sessions[0] != other {
+		t.Errorf("%d sessions left; want only the other player", len(srv.sessions))
+	}
+	if srv.floors[1].World.Get(other.PlayerID, component.CPosition) == nil {
+		t.Error("the other player should still be on the floor")
+	}
+}
@@ -0,0 +1,278 @@
+package mud
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/factory"
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Party loot rolls. The MUD has no formal parties: the live players within
+// PartyRange of a kill fought it together, and when there is more than one of
+// them each drop goes to a need/greed roll instead of falling to the floor
+// for whoever grabs it first.
+const (
+	PartyRange    = 6   // Chebyshev tiles from a kill that count a player in
+	LootRollTicks = 100 // ticks to choose before an undecided roll counts as a pass (10 s at TickInterval)
+)
+
+// rollChoice is one party member's answer to a loot roll.
+type rollChoice uint8
+
+const (
+	rollUndecided rollChoice = iota
+	rollNeed
+	rollGreed
+	rollPass
+)
+
+// rollNames labels the winning choice in roll results.
+var rollNames = [...]string{rollNeed: "Need", rollGreed: "Greed"}
+
+// lootRoll is one dropped item the party is rolling on.
+type lootRoll struct {
+	id        int
+	floorNum  int
+	pos       component.Position // where the item falls if everyone passes
+	item      component.Item
+	choices   map[*Session]rollChoice
+	ticksLeft int
+}
+
+// partyLocked returns the live players on floor within PartyRange of pos.
+// Caller must hold s.mu.
+func (s *Server) partyLocked(floor *Floor, pos component.Position) []*Session {
+	var party []*Session
+	for _, sess := range s.sessions {
+		if sess.FloorNum != floor.Num || sess.GetDeathCountdown() != 0 {
+			continue
+		}
+		if pc := floor.World.Get(sess.PlayerID, component.CPosition); pc != nil {
+			if p := pc.(component.Position); chebyshev(p.X, p.Y, pos.X, pos.Y) <= PartyRange {
+				party = append(party, sess)
+			}
+		}
+	}
+	return party
+}
+
+// dropLootLocked places the drops of the enemy named name that killer slew at
+// pos. A solo kill leaves them on the floor as before; a party kill opens a
+// roll per item for everyone in the party.
+// Caller must hold s.mu.
+func (s *Server) dropLootLocked(floor *Floor, killer *Session, pos component.Position, name string, items []component.Item) {
+	party := s.partyLocked(floor, pos)
+	if len(party) < 2 {
+		for _, it := range items {
+			factory.NewItemByGlyph(floor.World, it.Glyph, pos.X, pos.Y)
+			killer.AddMessage(fmt.Sprintf("The %s drops something!", name))
+		}
+		return
+	}
+	for _, it := range items {
+		s.nextRollID++
+		r := &lootRoll{
+			id:        s.nextRollID,
+			floorNum:  floor.Num,
+			pos:       pos,
+			item:      it,
+			choices:   make(map[*Session]rollChoice, len(party)),
+			ticksLeft: LootRollTicks,
+		}
+		for _, sess := range party {
+			r.choices[sess] = rollUndecided
+			sess.PendingRolls = append(sess.PendingRolls, r.id)
+			sess.AddMessage(fmt.Sprintf("🎲 The %s drops %s. Roll: [n]eed, [g]reed or [p]ass.", name, it.Name))
+		}
+		s.rolls = append(s.rolls, r)
+	}
+}
+
+// findRoll returns the open roll with the given id, or nil once it has been
+// settled.
+func (s *Server) findRoll(id int) *lootRoll {
+	for _, r := range s.rolls {
+		if r.id == id {
+			return r
+		}
+	}
+	return nil
+}
+
+// chooseRollLocked records sess's choice on roll id and settles the roll once
+// everyone has chosen. Reports whether the choice was still accepted.
+// Caller must hold s.mu.
+func (s *Server) chooseRollLocked(sess *Session, id int, choice rollChoice) bool {
+	r := s.findRoll(id)
+	if r == nil {
+		return false
+	}
+	if _, ok := r.choices[sess]; !ok {
+		return false
+	}
+	r.choices[sess] = choice
+	for _, c := range r.choices {
+		if c == rollUndecided {
+			return true
+		}
+	}
+	s.settleRollLocked(r)
+	return true
+}
+
+// tickRollsLocked counts down every open roll and settles those whose time
+// has run out, treating undecided players as passing.
+// Caller must hold s.mu.
+func (s *Server) tickRollsLocked() {
+	for _, r := range append([]*lootRoll(nil), s.rolls...) {
+		if r.ticksLeft--; r.ticksLeft <= 0 {
+			s.settleRollLocked(r)
+		}
+	}
+}
+
+// settleRollLocked awards r's item: need beats greed, and a d100 picks among
+// players with the same choice who are still on the floor. The winner gets
+// the item in their backpack, or at their feet when it is full. If everyone
+// passed, the item drops where the enemy fell.
+// Caller must hold s.mu.
+func (s *Server) settleRollLocked(r *lootRoll) {
+	for i, open := range s.rolls {
+		if open == r {
+			s.rolls = append(s.rolls[:i], s.rolls[i+1:]...)
+			break
+		}
+	}
+	floor, ok := s.floors[r.floorNum]
+	if !ok {
+		return
+	}
+	tell := func(msg string) {
+		for sess := range r.choices {
+			sess.AddMessage(msg)
+		}
+	}
+
+	var winner *Session
+	best, tier := -1, rollUndecided
+	for _, want := range []rollChoice{rollNeed, rollGreed} {
+		// Roll in session order so the outcome doesn't hang on map order.
+		for _, sess := range s.sessions {
+			c, ok := r.choices[sess]
+			if !ok || c != want || sess.FloorNum != r.floorNum || sess.GetDeathCountdown() != 0 {
+				continue
+			}
+			if roll := floor.CombatRng.Intn(100) + 1; roll > best {
+				winner, best = sess, roll
+			}
+		}
+		if winner != nil {
+			tier = want
+			break
+		}
+	}
+	if winner == nil {
+		factory.NewItemByGlyph(floor.World, r.item.Glyph, r.pos.X, r.pos.Y)
+		tell(fmt.Sprintf("🎲 Everyone passed on %s; it falls to the floor.", r.item.Name))
+		return
+	}
+
+	var inv component.Inventory
+	if ic := floor.World.Get(winner.PlayerID, component.CInventory); ic != nil {
+		inv = ic.(component.Inventory)
+	}
+	if inv.Stow(r.item) {
+		floor.World.Add(winner.PlayerID, inv)
+	} else if pc := floor.World.Get(winner.PlayerID, component.CPosition); pc != nil {
+		p := pc.(component.Position)
+		factory.NewItemByGlyph(floor.World, r.item.Glyph, p.X, p.Y)
+	}
+	tell(fmt.Sprintf("🎲 %s wins %s (%s %d).", winner.Name, r.item.Name, rollNames[tier], best))
+}
+
+// RunLootRolls shows a need/greed prompt for each roll waiting on sess, one
+// after another. Rolls settled in the meantime are skipped. Escape passes.
+func (s *Server) RunLootRolls(sess *Session, eventCh <-chan tcell.Event) {
+	for {
+		s.lockSession(sess)
+		var r *lootRoll
+		for len(sess.PendingRolls) > 0 && r == nil {
+			r = s.findRoll(sess.PendingRolls[0])
+			sess.PendingRolls = sess.PendingRolls[1:]
+		}
+		var item component.Item
+		if r != nil {
+			item = r.item
+		}
+		s.unlockSession(sess)
+		if r == nil {
+			return
+		}
+
+		choice := rollPass
+	prompt:
+		for {
+			drawLootRoll(sess.Screen, item)
+			ev, ok := <-eventCh
+			if !ok {
+				break prompt // disconnected: pass
+			}
+			switch ev := ev.(type) {
+			case *tcell.EventResize:
+				sess.Screen.Sync()
+			case *tcell.EventKey:
+				if ev.Key() == tcell.KeyEscape {
+					break prompt
+				}
+				switch ev.Rune() {
+				case 'n', 'N':
+					choice = rollNeed
+					break prompt
+				case 'g', 'G':
+					choice = rollGreed
+					break prompt
+				case 'p', 'P':
+					break prompt
+				}
+			}
+		}
+
+		s.lockSession(sess)
+		if !s.chooseRollLocked(sess, r.id, choice) {
+			sess.AddMessage(fmt.Sprintf("Too late — the roll for %s is over.", item.Name))
+		}
+		s.unlockSession(sess)
+	}
+}
+
+// drawLootRoll renders the need/greed prompt for item.
+func drawLootRoll(screen tcell.Screen, item component.Item) {
+	title := fmt.Sprintf(" 🎲 Party loot: %s %s ", item.Glyph, item.Name)
+	keys := " [n] Need   [g] Greed   [p] Pass "
+	width := max(len([]rune(title)), len([]rune(keys))) + 4
+	hdrStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true)
+	bodyStyle := tcell.StyleDefault.Foreground(tcell.ColorSilver)
+	borderStyle := tcell.StyleDefault.Foreground(tcell.ColorGray)
+
+	screen.Clear()
+	sw, sh := screen.Size()
+	boxH := 4
+	x0 := (sw - width) / 2
+	y0 := (sh - boxH) / 2
+	for col := x0; col < x0+width; col++ {
+		screen.SetContent(col, y0, '─', nil, borderStyle)
+		screen.SetContent(col, y0+boxH-1, '─', nil, borderStyle)
+	}
+	for row := y0; row < y0+boxH; row++ {
+		screen.SetContent(x0, row, '│', nil, borderStyle)
+		screen.SetContent(x0+width-1, row, '│', nil, borderStyle)
+	}
+	screen.SetContent(x0, y0, '┌', nil, borderStyle)
+	screen.SetContent(x0+width-1, y0, '┐', nil, borderStyle)
+	screen.SetContent(x0, y0+boxH-1, '└', nil, borderStyle)
+	screen.SetContent(x0+width-1, y0+boxH-1, '┘', nil, borderStyle)
+	putText(screen, x0+2, y0+1, title, hdrStyle)
+	putText(screen, x0+2, y0+2, keys, bodyStyle)
+	screen.Show()
+}
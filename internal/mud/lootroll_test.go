@@ -0,0 +1,95 @@
+package mud
+
+import (
+	"emoji-roguelike/internal/component"
+	"testing"
+)
+
+// newPartyOnFloor puts two players side by side on floor 1 and returns them
+// with the kill position between them. The caller must unlock srv.mu.
+func newPartyOnFloor(t *testing.T) (*Server, *Session, *Session, component.Position) {
+	t.Helper()
+	srv := newTestServer()
+	a, b := newTestSession(0, srv), newTestSession(1, srv)
+	srv.AddSession(a)
+	srv.AddSession(b)
+	srv.mu.Lock()
+	srv.transitionFloorLocked(a, 1)
+	srv.transitionFloorLocked(b, 1)
+	pos := srv.floors[1].World.Get(a.PlayerID, component.CPosition).(component.Position)
+	return srv, a, b, pos
+}
+
+func backpackLen(floor *Floor, sess *Session) int {
+	return len(floor.World.Get(sess.PlayerID, component.CInventory).(component.Inventory).Backpack)
+}
+
+func TestPartyLootRollNeedBeatsGreed(t *testing.T) {
+	srv, a, b, pos := newPartyOnFloor(t)
+	defer srv.mu.Unlock()
+	floor := srv.floors[1]
+	before := len(floor.World.Query(component.CTagItem))
+
+	srv.dropLootLocked(floor, a, pos, "🦠", []component.Item{{Name: "Hyperflask", Glyph: "🧪"}})
+	if len(srv.rolls) != 1 || len(a.PendingRolls) != 1 || len(b.PendingRolls) != 1 {
+		t.Fatalf("rolls %d, pending %d/%d; want one roll offered to both players",
+			len(srv.rolls), len(a.PendingRolls), len(b.PendingRolls))
+	}
+	if got := len(floor.World.Query(component.CTagItem)); got != before {
+		t.Error("a party drop should not land on the floor before the roll")
+	}
+
+	id := a.PendingRolls[0]
+	srv.chooseRollLocked(a, id, rollGreed)
+	srv.chooseRollLocked(b, id, rollNeed)
+
+	if len(srv.rolls) != 0 {
+		t.Fatal("the roll should settle once everyone has chosen")
+	}
+	if backpackLen(floor, b) != 1 || backpackLen(floor, a) != 0 {
+		t.Errorf("backpacks a=%d b=%d; want the Need roller to win", backpackLen(floor, a), backpackLen(floor, b))
+	}
+	if srv.chooseRollLocked(a, id, rollNeed) {
+		t.Error("a choice on a settled roll should be refused")
+	}
+}
+
+func TestPartyLootRollTimesOutToFloor(t *testing.T) {
+	srv, a, _, pos := newPartyOnFloor(t)
+	defer srv.mu.Unlock()
+	floor := srv.floors[1]
+	before := len(floor.World.Query(component.CTagItem))
+
+	srv.dropLootLocked(floor, a, pos, "🦠", []component.Item{{Name: "Hyperflask", Glyph: "🧪"}})
+	for range LootRollTicks {
+		srv.tickRollsLocked()
+	}
+
+	if len(srv.rolls) != 0 {
+		t.Fatal("an unanswered roll should settle when its time runs out")
+	}
+	if got := len(floor.World.Query(component.CTagItem)); got != before+1 {
+		t.Errorf("items on the floor = %d; want the passed-on drop added (%d)", got, before+1)
+	}
+}
+
+func TestSoloKillDropsLootOnFloor(t *testing.T) {
+	srv := newTestServer()
+	solo := newTestSession(0, srv)
+	srv.AddSession(solo)
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transitionFloorLocked(solo, 1)
+	floor := srv.floors[1]
+	pos := floor.World.Get(solo.PlayerID, component.CPosition).(component.Position)
+	before := len(floor.World.Query(component.CTagItem))
+
+	srv.dropLootLocked(floor, solo, pos, "🦠", []component.Item{{Name: "Hyperflask", Glyph: "🧪"}})
+
+	if len(srv.rolls) != 0 || len(solo.PendingRolls) != 0 {
+		t.Error("a solo kill should not open a roll")
+	}
+	if got := len(floor.World.Query(component.CTagItem)); got != before+1 {
+		t.Errorf("items on the floor = %d; want %d", got, before+1)
+	}
+}
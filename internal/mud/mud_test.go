@@ -4,7 +4,10 @@ import (
 	"emoji-roguelike/assets"
 	"emoji-roguelike/internal/component"
 	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/factory"
 	"emoji-roguelike/internal/gamemap"
+	"emoji-roguelike/internal/generate"
+	"emoji-roguelike/internal/system"
 	"log/slog"
 	"math/rand"
 	"strings"
@@ -116,6 +119,74 @@ func TestFovApplyNilGridNoOp(t *testing.T) {
 	// Visibility is unchanged (ApplyFOV is a no-op with nil grid).
 }
 
+func TestFovExploredIsPerPlayer(t *testing.T) {
+	gmap := gamemap.New(10, 10)
+	for y := range 10 {
+		for x := range 10 {
+			gmap.Set(x, y, gamemap.MakeFloor())
+		}
+	}
+	seer := &Session{RenderCh: make(chan struct{}, 1)}
+	other := &Session{RenderCh: make(chan struct{}, 1)}
+	gmap.At(2, 3).Visible = true
+	seer.SnapshotFOV(gmap)
+	gmap.At(2, 3).Visible = false
+	other.SnapshotFOV(gmap)
+
+	other.ApplyFOV(gmap)
+	if gmap.At(2, 3).Explored {
+		t.Error("a tile only the other player saw should not show as explored")
+	}
+	seer.ApplyFOV(gmap)
+	if !gmap.At(2, 3).Explored {
+		t.Error("a tile the player saw should stay explored after it leaves view")
+	}
+}
+
+func TestFovExploredSurvivesFloorRevisit(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transitionFloorLocked(sess, 1)
+	var seen [][2]int
+	for y, row := range sess.Explored[1] {
+		for x, ok := range row {
+			if ok {
+				seen = append(seen, [2]int{x, y})
+			}
+		}
+	}
+	if len(seen) == 0 {
+		t.Fatal("arriving on floor 1 should map the tiles in view")
+	}
+
+	srv.transitionFloorLocked(sess, 2)
+	srv.transitionFloorLocked(sess, 1)
+	gmap := srv.floors[1].GMap
+	sess.ApplyFOV(gmap)
+	for _, p := range seen {
+		if !gmap.At(p[0], p[1]).Explored {
+			t.Fatalf("tile %v mapped on the first visit is unexplored on return", p)
+		}
+	}
+}
+
+func TestRevealFloorMarksSessionMemory(t *testing.T) {
+	gmap := gamemap.New(5, 5)
+	gmap.Set(1, 1, gamemap.MakeFloor())
+	sess := &Session{RenderCh: make(chan struct{}, 1), FloorNum: 3}
+	sess.RevealFloor(gmap)
+	if !sess.Explored[3][1][1] {
+		t.Error("revealed floor tile should be in the player's memory")
+	}
+	if sess.Explored[3][0][0] {
+		t.Error("walls should not be revealed")
+	}
+}
+
 // ─── findFreeSpawn ────────────────────────────────────────────────────────────
 
 func newOpenFloor(num int) *Floor {
@@ -231,7 +302,7 @@ func TestServerMultipleSessionsDistinctColors(t *testing.T) {
 func TestStairsUpOnNonFirstFloor(t *testing.T) {
 	for floorNum := 1; floorNum <= 5; floorNum++ {
 		rng := rand.New(rand.NewSource(int64(floorNum) * 7))
-		floor := newFloor(floorNum, rng)
+		floor := newFloor(floorNum, rng.Int63(), 100)
 
 		found := false
 		for y := range floor.GMap.Height {
@@ -249,8 +320,7 @@ func TestStairsUpOnNonFirstFloor(t *testing.T) {
 
 func TestFloor1HasStairsUp(t *testing.T) {
 	// In the MUD, floor 1 has stairs up so players can return to Emberveil.
-	rng := rand.New(rand.NewSource(42))
-	floor := newFloor(1, rng)
+	floor := newFloor(1, 42, 100)
 
 	found := false
 	for y := range floor.GMap.Height {
@@ -268,7 +338,7 @@ func TestFloor1HasStairsUp(t *testing.T) {
 func TestStairsDownOnAllFloors(t *testing.T) {
 	for floorNum := 1; floorNum <= 5; floorNum++ {
 		rng := rand.New(rand.NewSource(int64(floorNum) * 13))
-		floor := newFloor(floorNum, rng)
+		floor := newFloor(floorNum, rng.Int63(), 100)
 
 		found := false
 		for y := range floor.GMap.Height {
@@ -344,11 +414,151 @@ func TestFloorTransitionDescendLandsNearStairsUp(t *testing.T) {
 	}
 }
 
+func TestFloorTransitionClearsTurrets(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+
+	srv.mu.Lock()
+	srv.transitionFloorLocked(sess, 1)
+	floor1 := srv.floors[1]
+	oldPlayer := sess.PlayerID
+	pos := floor1.World.Get(oldPlayer, component.CPosition).(component.Position)
+	factory.NewTurret(floor1.World, oldPlayer, pos.X, pos.Y)
+	srv.transitionFloorLocked(sess, 2)
+	remaining := len(floor1.World.Query(component.CTurret))
+	srv.mu.Unlock()
+
+	if remaining != 0 {
+		t.Errorf("turrets left on floor 1 after owner left = %d; want 0", remaining)
+	}
+}
+
+func TestSnareKitArmsTrapClearedOnFloorTransition(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transitionFloorLocked(sess, 1)
+	floor1 := srv.floors[1]
+	srv.applyConsumableLocked(floor1, sess, component.Item{Glyph: assets.GlyphSnareKit, IsConsumable: true})
+
+	traps := floor1.World.Query(component.CTrap)
+	if len(traps) != 1 {
+		t.Fatalf("traps after using a Snare Kit = %d; want 1", len(traps))
+	}
+	if tr := floor1.World.Get(traps[0], component.CTrap).(component.Trap); tr.Owner != sess.PlayerID || tr.Kind != component.TrapSnare {
+		t.Errorf("trap = %+v; want a snare owned by the player", tr)
+	}
+
+	srv.transitionFloorLocked(sess, 2)
+	if n := len(floor1.World.Query(component.CTrap)); n != 0 {
+		t.Errorf("traps left on floor 1 after owner left = %d; want 0", n)
+	}
+}
+
+func TestEnemyCountIgnoresTurrets(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transitionFloorLocked(sess, 1)
+	floor := srv.floors[1]
+	for _, id := range floor.World.Query(component.CAI) {
+		floor.World.DestroyEntity(id)
+	}
+	pos := floor.World.Get(sess.PlayerID, component.CPosition).(component.Position)
+	factory.NewTurret(floor.World, sess.PlayerID, pos.X, pos.Y)
+	floor.RespawnCooldown = -1
+
+	srv.tickFloorLocked(floor)
+
+	if floor.RespawnCooldown != EnemyRespawnDelay {
+		t.Errorf("RespawnCooldown = %d; want %d (turret must not count as an enemy)", floor.RespawnCooldown, EnemyRespawnDelay)
+	}
+}
+
+func TestSummonedMinionsHoldOffRespawn(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transitionFloorLocked(sess, 3)
+	floor := srv.floors[3]
+	for _, id := range floor.World.Query(component.CAI) {
+		floor.World.DestroyEntity(id)
+	}
+	var matron generate.EnemySpawnEntry
+	for _, e := range assets.EnemyTables[3] {
+		if e.Glyph == assets.GlyphBroodMatron {
+			matron = e
+		}
+	}
+	pos := floor.World.Get(sess.PlayerID, component.CPosition).(component.Position)
+	x, y, ok := system.FindDeploySpot(floor.World, floor.GMap, pos)
+	if !ok {
+		t.Fatal("no free tile beside the player")
+	}
+	summoner := factory.NewEnemy(floor.World, matron, x, y, nil)
+	floor.RespawnCooldown = -1
+
+	srv.tickFloorLocked(floor)
+	minions := floor.World.Get(summoner, component.CSummoner).(component.Summoner).Minions
+	if len(minions) != 1 {
+		t.Fatalf("minions after one tick = %d; want 1", len(minions))
+	}
+
+	floor.World.DestroyEntity(summoner)
+	srv.tickFloorLocked(floor)
+	if !floor.World.Alive(minions[0]) {
+		t.Fatal("minion should outlive its summoner")
+	}
+	if floor.RespawnCooldown != -1 {
+		t.Errorf("RespawnCooldown = %d; want -1 while a minion lives", floor.RespawnCooldown)
+	}
+}
+
+func TestNoteKillRoutsSurvivorsAndDecays(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transitionFloorLocked(sess, 1)
+	floor := srv.floors[1]
+	for _, id := range floor.World.Query(component.CAI) {
+		floor.World.DestroyEntity(id)
+	}
+	pos := floor.World.Get(sess.PlayerID, component.CPosition).(component.Position)
+	survivor := floor.World.CreateEntity()
+	floor.World.Add(survivor, component.Position{X: pos.X + 2, Y: pos.Y})
+	floor.World.Add(survivor, component.AI{Behavior: component.BehaviorChase, SightRange: 6})
+
+	srv.noteKillLocked(floor, pos)
+	srv.noteKillLocked(floor, pos)
+	if !floor.World.Has(survivor, component.CRout) {
+		t.Fatal("two quick kills against a group of one should rout the survivor")
+	}
+
+	before := floor.recentKills
+	srv.tickFloorLocked(floor)
+	if floor.recentKills != before-1 {
+		t.Errorf("recentKills after tick = %d; want %d", floor.recentKills, before-1)
+	}
+}
+
 // ─── Enemy respawn ────────────────────────────────────────────────────────────
 
 func TestRespawnEnemiesLocked(t *testing.T) {
 	rng := rand.New(rand.NewSource(42))
-	floor := newFloor(3, rng) // floor 3 has a varied enemy table
+	floor := newFloor(3, 42, 100) // floor 3 has a varied enemy table
 
 	// Clear all enemies.
 	for _, id := range floor.World.Query(component.CAI) {
@@ -372,8 +582,7 @@ func TestRespawnEnemiesLocked(t *testing.T) {
 }
 
 func TestFloorRespawnCooldownInitiallyIdle(t *testing.T) {
-	rng := rand.New(rand.NewSource(42))
-	floor := newFloor(1, rng)
+	floor := newFloor(1, 42, 100)
 	if floor.RespawnCooldown != -1 {
 		t.Errorf("expected RespawnCooldown=-1 on new floor, got %d", floor.RespawnCooldown)
 	}
@@ -385,7 +594,7 @@ func TestMultiPlayerAITargetsNearest(t *testing.T) {
 	// Place Alice close to an enemy, Bob far away.
 	// After tickFloorLocked, only Alice should have taken damage.
 	floor := newOpenFloor(1)
-	floor.Rng = rand.New(rand.NewSource(42))
+	floor.CombatRng = rand.New(rand.NewSource(42))
 
 	makeSessionPlayer := func(id int, name string, x, y int) *Session {
 		screen := newSimScreen()
@@ -818,3 +1027,27 @@ func TestInspectPlayerNoEquipmentNoGearLine(t *testing.T) {
 		t.Errorf("expected exactly 1 message (no gear), got %d: %v", len(sess0.Messages), sess0.Messages)
 	}
 }
+
+func TestDescendWarnsPlayersLeftBehind(t *testing.T) {
+	srv := newTestServer()
+	leader, mate, elsewhere := newTestSession(0, srv), newTestSession(1, srv), newTestSession(2, srv)
+	for _, sess := range []*Session{leader, mate, elsewhere} {
+		srv.AddSession(sess)
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transitionFloorLocked(elsewhere, 2)
+	before := len(elsewhere.Messages)
+	srv.warnLeftBehindLocked(leader, 2)
+
+	if got := mate.Messages[len(mate.Messages)-1]; !strings.Contains(got, leader.Name) {
+		t.Errorf("floormate's last message = %q; want a warning naming %s", got, leader.Name)
+	}
+	if len(elsewhere.Messages) != before {
+		t.Errorf("a player on another floor got %q; want no warning", elsewhere.Messages[len(elsewhere.Messages)-1])
+	}
+	if got := leader.Messages; len(got) > 0 && strings.Contains(got[len(got)-1], "heads down") {
+		t.Error("the descending player should not be warned about themselves")
+	}
+}
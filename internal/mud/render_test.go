@@ -0,0 +1,152 @@
+package mud
+
+import (
+	"sync"
+	"testing"
+
+	"emoji-roguelike/internal/component"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// renderOnce does what RunLoop does on a render signal: draw under s.mu,
+// then flush to the screen outside it.
+func renderOnce(srv *Server, sess *Session) {
+	srv.mu.Lock()
+	srv.RenderSession(sess)
+	srv.mu.Unlock()
+	sess.Screen.Show()
+}
+
+// TestRenderWhileTicking drives the ticker alongside several sessions that
+// render on every signal while players come and go. Run with -race to check
+// the locking contract between tick, signalRender and RenderSession.
+func TestRenderWhileTicking(t *testing.T) {
+	srv := newTestServer()
+	const players = 4
+	sessions := make([]*Session, players)
+	for i := range sessions {
+		sessions[i] = newTestSession(i, srv)
+		srv.AddSession(sessions[i])
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for _, sess := range sessions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				case <-sess.RenderCh:
+					renderOnce(srv, sess)
+				}
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := players; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			visitor := newTestSession(i, srv)
+			srv.AddSession(visitor)
+			srv.RemoveSession(visitor)
+		}
+	}()
+
+	for i := range 50 {
+		sessions[i%players].SetAction(ActionWait)
+		srv.tick()
+	}
+	close(stop)
+	wg.Wait()
+
+	for _, sess := range sessions {
+		renderOnce(srv, sess)
+	}
+}
+
+// TestSignalRenderCoalescesPendingSignals checks that a session which has not
+// drained its pending signal gets no second one, that each skipped signal is
+// counted, and that a client merely busy in a modal is never dropped.
+func TestSignalRenderCoalescesPendingSignals(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	disconnected := false
+	sess.Disconnect = func() { disconnected = true }
+
+	for range RenderBacklogLimit + 1 {
+		srv.signalRender([]*Session{sess})
+	}
+	if got := len(sess.RenderCh); got != 1 {
+		t.Errorf("pending signals = %d; want 1", got)
+	}
+	if got := sess.DroppedRenders(); got != RenderBacklogLimit {
+		t.Errorf("DroppedRenders = %d; want %d", got, RenderBacklogLimit)
+	}
+	if disconnected {
+		t.Error("a client that is not stuck writing a frame should not be disconnected")
+	}
+}
+
+func TestSignalRenderDisconnectsStuckClient(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	disconnects := 0
+	sess.Disconnect = func() { disconnects++ }
+	sess.flushing.Store(true)
+
+	srv.signalRender([]*Session{sess}) // fills the slot
+	for range RenderBacklogLimit - 1 {
+		srv.signalRender([]*Session{sess})
+	}
+	if disconnects != 0 {
+		t.Fatalf("disconnected after %d stuck ticks; limit is %d", RenderBacklogLimit-1, RenderBacklogLimit)
+	}
+	srv.signalRender([]*Session{sess})
+	srv.signalRender([]*Session{sess})
+	if disconnects != 1 {
+		t.Errorf("disconnects = %d; want exactly 1 once the backlog limit is reached", disconnects)
+	}
+}
+
+func TestSignalRenderResetsBacklogOnDelivery(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	disconnected := false
+	sess.Disconnect = func() { disconnected = true }
+	sess.flushing.Store(true)
+
+	for range RenderBacklogLimit - 1 {
+		srv.signalRender([]*Session{sess})
+	}
+	<-sess.RenderCh // the client catches up
+	for range RenderBacklogLimit - 1 {
+		srv.signalRender([]*Session{sess})
+	}
+	if disconnected {
+		t.Error("a client that keeps catching up should not be disconnected")
+	}
+}
+
+func TestRenderSessionRefitsAfterResize(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+	sess.Screen.(tcell.SimulationScreen).SetSize(100, 30)
+
+	srv.mu.Lock()
+	srv.RenderSession(sess)
+	pos := srv.floors[sess.FloorNum].World.Get(sess.PlayerID, component.CPosition).(component.Position)
+	srv.mu.Unlock()
+	if sx, sy, ok := sess.Renderer.WorldToScreen(pos.X, pos.Y); !ok || sx != 50 || sy != 12 {
+		t.Errorf("player drawn at (%d,%d) visible=%v; want the centre (50,12) of a 100x25 view", sx, sy, ok)
+	}
+}
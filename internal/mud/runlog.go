@@ -21,6 +21,7 @@ type RunLog struct {
 	DamageDealt      int            `json:"damage_dealt"`
 	DamageTaken      int            `json:"damage_taken"`
 	GoldEarned       int            `json:"gold_earned"`
+	FloorsCleared    int            `json:"floors_cleared,omitempty"`
 	CauseOfDeath     string         `json:"cause_of_death"`
 	Level            int            `json:"level"`
 	SkillsLearned    []string       `json:"skills_learned,omitempty"`
@@ -0,0 +1,60 @@
+package mud
+
+import (
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/generate"
+)
+
+// Enemy scaling tuning; see Server.EnemyScaling.
+const (
+	scalePowerStep  = 4   // player power per scaling step
+	scalePctPerStep = 10  // extra enemy ATK, DEF and HP per step, in percent
+	scaleMaxPct     = 200 // most an enemy stat can be scaled, in percent
+)
+
+// sessionPowerLocked rates how far sess has outgrown a fresh character: the
+// ATK and DEF its equipment grants plus the levels it has gained.
+// Caller must hold s.mu.
+func (s *Server) sessionPowerLocked(sess *Session) int {
+	power := max(sess.Level-1, 0)
+	if floor, ok := s.floors[sess.FloorNum]; ok && sess.PlayerID != ecs.NilEntity {
+		atk, def := equipBonuses(floor.World, sess.PlayerID)
+		power += atk + def
+	}
+	return power
+}
+
+// floorPowerLocked returns the highest power among the live players on
+// floorNum, or 0 when nobody is there.
+// Caller must hold s.mu.
+func (s *Server) floorPowerLocked(floorNum int) int {
+	power := 0
+	for _, sess := range s.sessions {
+		if sess.FloorNum == floorNum && sess.GetDeathCountdown() == 0 {
+			power = max(power, s.sessionPowerLocked(sess))
+		}
+	}
+	return power
+}
+
+// enemyScalePct returns the percentage applied to enemy stats spawned for
+// players of the given power: 100 unless EnemyScaling is on.
+func (s *Server) enemyScalePct(power int) int {
+	if !s.EnemyScaling {
+		return 100
+	}
+	return min(100+power/scalePowerStep*scalePctPerStep, scaleMaxPct)
+}
+
+// scaleEnemyEntry returns a copy of entry with its ATK, DEF and HP scaled to
+// pct percent. Drops are untouched, so loot stays the same whoever lands the
+// kill.
+func scaleEnemyEntry(entry generate.EnemySpawnEntry, pct int) generate.EnemySpawnEntry {
+	if pct == 100 {
+		return entry
+	}
+	entry.Attack = entry.Attack * pct / 100
+	entry.Defense = entry.Defense * pct / 100
+	entry.MaxHP = max(entry.MaxHP*pct/100, 1)
+	return entry
+}
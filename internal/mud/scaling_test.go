@@ -0,0 +1,56 @@
+package mud
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/generate"
+	"testing"
+)
+
+func TestEnemyScalingOffLeavesStats(t *testing.T) {
+	srv := newTestServer()
+	if got := srv.enemyScalePct(40); got != 100 {
+		t.Errorf("enemyScalePct with scaling off = %d; want 100", got)
+	}
+	srv.EnemyScaling = true
+	if got := srv.enemyScalePct(40); got != scaleMaxPct {
+		t.Errorf("enemyScalePct(40) = %d; want the %d%% cap", got, scaleMaxPct)
+	}
+}
+
+func TestEnemyScalingFollowsStrongestPlayer(t *testing.T) {
+	srv := newTestServer()
+	srv.EnemyScaling = true
+	newbie, veteran := newTestSession(0, srv), newTestSession(1, srv)
+	srv.AddSession(newbie)
+	srv.AddSession(veteran)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transitionFloorLocked(newbie, 1)
+	srv.transitionFloorLocked(veteran, 1)
+	newbie.Level, veteran.Level = 1, 1 // ignore any level-up from floor-entry XP
+	w := srv.floors[1].World
+	inv := w.Get(veteran.PlayerID, component.CInventory).(component.Inventory)
+	inv.MainHand = component.Item{Name: "Test Blade", BonusATK: 6}
+	inv.Body = component.Item{Name: "Test Plate", BonusDEF: 2}
+	w.Add(veteran.PlayerID, inv)
+
+	power := srv.floorPowerLocked(1)
+	if power != 8 {
+		t.Fatalf("floorPowerLocked = %d; want the veteran's 8", power)
+	}
+	pct := srv.enemyScalePct(power)
+	if pct != 100+2*scalePctPerStep {
+		t.Fatalf("enemyScalePct(%d) = %d; want %d", power, pct, 100+2*scalePctPerStep)
+	}
+
+	entry := generate.EnemySpawnEntry{Attack: 10, Defense: 5, MaxHP: 20,
+		Drops: []generate.DropEntry{{Glyph: "💎", Chance: 50}}}
+	got := scaleEnemyEntry(entry, pct)
+	if got.Attack != 12 || got.Defense != 6 || got.MaxHP != 24 {
+		t.Errorf("scaled stats = ATK %d DEF %d HP %d; want 12 6 24", got.Attack, got.Defense, got.MaxHP)
+	}
+	if len(got.Drops) != 1 || got.Drops[0] != entry.Drops[0] {
+		t.Errorf("scaled drops = %v; want the loot left alone", got.Drops)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"emoji-roguelike/internal/component"
 	"emoji-roguelike/internal/ecs"
 	"emoji-roguelike/internal/factory"
+	"emoji-roguelike/internal/game"
 	"emoji-roguelike/internal/gamemap"
 	"emoji-roguelike/internal/render"
 	"emoji-roguelike/internal/system"
@@ -17,6 +18,7 @@ import (
 	"log/slog"
 	"strings"
 	"math/rand"
+	"slices"
 	"sync"
 	"time"
 
@@ -36,6 +38,10 @@ const EnemyRespawnDelay = 60
 // MaxSessions is the maximum number of concurrent player connections.
 const MaxSessions = 50
 
+// RenderBacklogLimit is how many consecutive ticks a client may spend stuck
+// writing one frame before it is disconnected (~30 seconds at 100 ms/tick).
+const RenderBacklogLimit = 300
+
 // Server manages all floors and sessions for the MUD.
 type Server struct {
 	mu       sync.Mutex
@@ -45,6 +51,27 @@ type Server struct {
 	rng      *rand.Rand
 	Log      *slog.Logger
 	GameTick int // monotonically increasing tick counter
+	// EnemyScaling toughens enemies spawned on a floor to match the strongest
+	// player there, so a veteran's presence keeps a shared floor
+	// challenging. Set before Run.
+	EnemyScaling bool
+	// EnemyCap bounds how many enemies a floor may hold at once, per 1000
+	// tiles of its map, so respawns, summons and splits cannot crowd a busy
+	// floor until ticks slow down. 0 uses DefaultEnemyCap. Set before Run.
+	EnemyCap int
+	// ASCII draws every session's map with single ASCII characters instead
+	// of emoji, for clients whose terminals mangle emoji widths. Set before
+	// Run.
+	ASCII bool
+
+	// rolls are the party loot rolls still waiting on choices; see
+	// dropLootLocked.
+	rolls      []*lootRoll
+	nextRollID int
+
+	// shop is the city shop's shelves, shared by every player and rotated
+	// by restockShopLocked.
+	shop []assets.ShopEntry
 }
 
 // NextSessionID returns a unique session ID and an assigned player color.
@@ -68,6 +95,7 @@ func NewServer(rng *rand.Rand, log *slog.Logger) *Server {
 	}
 	s.floors[0] = newCityFloor(rand.New(rand.NewSource(rng.Int63())))
 	s.floors[100] = newChronolithsCityFloor(rand.New(rand.NewSource(rng.Int63())))
+	s.restockShopLocked()
 	return s
 }
 
@@ -105,8 +133,11 @@ func (s *Server) RemoveSession(sess *Session) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// Remove entity from its floor.
+	// Remove entity (and any turrets, decoys or traps it placed) from its floor.
 	if floor, ok := s.floors[sess.FloorNum]; ok && sess.PlayerID != ecs.NilEntity {
+		system.ClearTurrets(floor.World, sess.PlayerID)
+		system.ClearDecoys(floor.World, sess.PlayerID)
+		system.ClearTraps(floor.World, sess.PlayerID)
 		floor.World.DestroyEntity(sess.PlayerID)
 	}
 
@@ -121,14 +152,35 @@ func (s *Server) RemoveSession(sess *Session) {
 	s.Log.Info("player left", "player", sess.Name, "sessions", len(s.sessions))
 }
 
-// SignalRender sends a non-blocking render signal to all sessions.
-// Called after tick() completes so sessions can redraw.
-func (s *Server) signalRender() {
-	for _, sess := range s.sessions {
+// signalRender sends a non-blocking render signal to each of sessions.
+// Called after tick() completes so sessions can redraw. It runs without
+// s.mu, so sessions must be a copy taken under the lock: RemoveSession
+// shifts s.sessions in place.
+//
+// A session whose signal is still pending is not signalled again; it will
+// render the latest state when it gets to the pending one. If it is stuck
+// writing a frame for RenderBacklogLimit ticks in a row, its link is too
+// slow to keep up and it is disconnected.
+func (s *Server) signalRender(sessions []*Session) {
+	for _, sess := range sessions {
 		select {
 		case sess.RenderCh <- struct{}{}:
+			sess.renderBacklog = 0
+			continue
 		default:
 		}
+		sess.droppedRenders.Add(1)
+		if !sess.flushing.Load() {
+			continue // busy in a modal, not behind on output
+		}
+		sess.renderBacklog++
+		if sess.renderBacklog == RenderBacklogLimit {
+			s.Log.Warn("disconnecting backed-up client", "player", sess.Name,
+				"dropped", sess.DroppedRenders())
+			if sess.Disconnect != nil {
+				sess.Disconnect()
+			}
+		}
 	}
 }
 
@@ -157,21 +209,31 @@ func (s *Server) tick() {
 		}
 	}
 
+	// 1a. Settle party loot rolls whose time is up.
+	s.tickRollsLocked()
+
 	// 1b. Decay chat bubbles for all sessions.
 	for _, sess := range s.sessions {
 		sess.ChatBubbles = decayBubbles(sess.ChatBubbles)
 	}
 
+	// 1c. Rotate fresh stock onto the city shop's shelves.
+	if s.GameTick%ShopRestockTicks == 0 {
+		s.restockShopLocked()
+		globalMessage(s.sessions, "🛍️ Fresh stock has arrived at Yeva's Provisions.")
+	}
+
 	// 2. Tick each active floor (effects, AI, passive regen, death checks).
 	for _, floor := range s.floors {
 		s.tickFloorLocked(floor)
 	}
 
+	recipients := slices.Clone(s.sessions)
 	s.mu.Unlock()
 
 	// 3. Signal all sessions to render (outside the lock so slow SSH writes
 	// don't block the next tick from starting).
-	s.signalRender()
+	s.signalRender(recipients)
 }
 
 // tickFloorLocked advances AI and effects for one floor.
@@ -183,16 +245,15 @@ func (s *Server) tickFloorLocked(floor *Floor) {
 			if sess.FloorNum != floor.Num || sess.GetDeathCountdown() != 0 {
 				continue
 			}
-			if sess.SpecialCooldown > 0 {
-				sess.SpecialCooldown--
-			}
+			sess.SpecialSpent, sess.SpecialCooldown = system.TickCharges(sess.SpecialSpent, sess.SpecialCooldown,
+				system.CooldownTick(floor.World, sess.PlayerID), effectiveCooldown(floor.World, sess))
 			sess.TurnCount++
 			if ri := effectiveRegenInterval(sess); ri > 0 && sess.TurnCount%ri == 0 {
 				restoreHP(floor.World, sess.PlayerID, 1)
 			}
 			sess.RunLog.TurnsPlayed++
 		}
-		system.ProcessNPCMovement(floor.World, floor.GMap, s.GameTick%component.DayCycleTicks, floor.Rng)
+		system.ProcessNPCMovement(floor.World, floor.GMap, s.GameTick%component.DayCycleTicks, floor.CombatRng)
 		return
 	}
 
@@ -210,39 +271,60 @@ func (s *Server) tickFloorLocked(floor *Floor) {
 			s.applyDoTLocked(floor, sess)
 		}
 	}
+	s.applyHazardsLocked(floor)
 
 	// Tick effects (reduces all duration counters).
 	system.TickEffects(floor.World)
+	floor.recentKills = system.DecayMorale(floor.recentKills)
+	decayCorpses(floor.World)
 
 	// Per-player: ability cooldown and passive regen.
 	for _, sess := range s.sessions {
 		if sess.FloorNum != floor.Num || sess.GetDeathCountdown() != 0 {
 			continue
 		}
-		if sess.SpecialCooldown > 0 {
-			sess.SpecialCooldown--
-		}
+		sess.SpecialSpent, sess.SpecialCooldown = system.TickCharges(sess.SpecialSpent, sess.SpecialCooldown,
+			system.CooldownTick(floor.World, sess.PlayerID), effectiveCooldown(floor.World, sess))
 		sess.TurnCount++
 		if ri := effectiveRegenInterval(sess); ri > 0 && sess.TurnCount%ri == 0 {
 			restoreHP(floor.World, sess.PlayerID, 1)
 		}
+		if system.InSanctuary(floor.World, floor.GMap, sess.PlayerID) && sess.TurnCount%assets.SanctuaryRegenEvery == 0 {
+			restoreHP(floor.World, sess.PlayerID, 1)
+		}
 		sess.RunLog.TurnsPlayed++
 	}
 
 	// Run AI (no-op if no players).
 	if len(playerIDs) == 0 {
+		floor.lingerTicks = 0
 		return
 	}
+	s.lingerLocked(floor)
 
-	hits := system.ProcessAI(floor.World, floor.GMap, playerIDs, floor.Rng)
+	s.resolveTurretShotsLocked(floor, system.ProcessTurrets(floor.World, floor.GMap, floor.CombatRng))
+
+	hits := system.ProcessAI(floor.World, floor.GMap, playerIDs, floor.CombatRng)
+	s.resolveTrapTriggersLocked(floor, system.CollectSprungTraps(floor.World))
+	s.resolveSummonsLocked(floor, system.CollectSummons(floor.World))
+	for _, c := range system.CollectEchoes(floor.World) {
+		floorMessage(s.sessions, floor.Num, game.EchoMessage(c.Glyph, c.ClassID))
+	}
+	s.resolveSplitsLocked(floor, system.CollectSplits(floor.World, floor.GMap))
 
 	// Process hits: attribute damage, apply thorns, generate messages.
 	for _, h := range hits {
+		if sess := s.sessionByPlayerID(h.VictimID); sess != nil && sess.Renderer != nil && !sess.HitFlashOff {
+			sess.Renderer.NoteStrike(h.AttackerID)
+		}
 		if h.Damage > 0 {
 			// Attribute damage directly via VictimID.
 			if sess := s.sessionByPlayerID(h.VictimID); sess != nil {
 				sess.RunLog.DamageTaken += h.Damage
 				sess.RunLog.CauseOfDeath = h.EnemyGlyph
+				if hp := floor.World.Get(sess.PlayerID, component.CHealth); hp != nil && sess.Renderer != nil && !sess.HitFlashOff {
+					sess.Renderer.NoteDamage(h.Damage, hp.(component.Health).Max)
+				}
 			}
 			// Thorns: reflect damage to the attacker.
 			if h.AttackerID != ecs.NilEntity && floor.World.Alive(h.AttackerID) {
@@ -262,6 +344,9 @@ func (s *Server) tickFloorLocked(floor *Floor) {
 				}
 			}
 		}
+		if sess := s.sessionByPlayerID(h.VictimID); sess != nil {
+			noteWornOutLocked(floor, sess, h.WornOut)
+		}
 		// Send hit messages to all players on the floor.
 		if msg := hitMessage(h, s.victimName(h.VictimID)); msg != "" {
 			floorMessage(s.sessions, floor.Num, msg)
@@ -282,15 +367,23 @@ func (s *Server) tickFloorLocked(floor *Floor) {
 			saveRunLog(sess.RunLog, s.Log)
 			s.Log.Info("player died", "player", sess.Name, "floor", floor.Num, "cause", sess.RunLog.CauseOfDeath, "turns", sess.RunLog.TurnsPlayed)
 			sess.SetDeathCountdown(DeathTicks)
+			sess.PendingBell = sess.PendingBell || sess.BellOn
+			s.dropCorpseLocked(floor, sess)
 			// Entity stays in world while countdown runs so others can see the
 			// corpse position; it's cleaned up in respawnLocked.
 		}
 	}
 
+	s.soundCuesLocked(floor)
+
 	// Enemy respawn: when the floor is cleared and players are present,
 	// start a countdown; spawn a new wave when it expires.
-	enemyCount := len(floor.World.Query(component.CAI))
+	enemyCount := liveEnemies(floor.World)
 	if len(playerIDs) > 0 && enemyCount == 0 {
+		if !floor.cleared {
+			floor.cleared = true
+			s.offerClearBonusLocked(floor)
+		}
 		if floor.RespawnCooldown < 0 {
 			// Floor just cleared — start the countdown.
 			floor.RespawnCooldown = EnemyRespawnDelay
@@ -302,11 +395,168 @@ func (s *Server) tickFloorLocked(floor *Floor) {
 		}
 	} else if enemyCount > 0 {
 		floor.RespawnCooldown = -1 // reset when enemies are alive
+		floor.cleared = false
+	}
+}
+
+// soundCuesLocked marks the bell to ring for each living player on floor who
+// has it on and whose HP just fell low or whose ability charge came back.
+// Caller must hold s.mu.
+func (s *Server) soundCuesLocked(floor *Floor) {
+	now := time.Now()
+	for _, sess := range s.sessions {
+		if !sess.BellOn || sess.FloorNum != floor.Num || sess.GetDeathCountdown() != 0 {
+			continue
+		}
+		if hc := floor.World.Get(sess.PlayerID, component.CHealth); hc != nil && sess.Bell.Cue(hc.(component.Health), sess.SpecialSpent, now) {
+			sess.PendingBell = true
+		}
+	}
+}
+
+// noteKillLocked feeds a kill at pos into the floor's morale counter and routs
+// the nearby survivors if their morale breaks.
+// Caller must hold s.mu.
+func (s *Server) noteKillLocked(floor *Floor, pos component.Position) {
+	floor.recentKills = system.RecordKill(floor.recentKills)
+	if system.BreakMorale(floor.World, pos, floor.recentKills) > 0 {
+		floorMessage(s.sessions, floor.Num, "The survivors panic and flee!")
+	}
+}
+
+// resolveTurretShotsLocked credits turret kills to the turret's owner: kill
+// count, gold, XP and loot drops, as if the owner landed the blow.
+// Caller must hold s.mu.
+func (s *Server) resolveTurretShotsLocked(floor *Floor, shots []system.TurretShot) {
+	for _, sh := range shots {
+		sess := s.sessionByPlayerID(sh.Owner)
+		if sess != nil {
+			sess.RunLog.DamageDealt += sh.Damage
+		}
+		if !sh.Killed {
+			continue
+		}
+		s.creditKillLocked(floor, sess, "turret destroys", sh.TargetGlyph, sh.TargetPos, sh.Loot)
+		if sess == nil {
+			floorMessage(s.sessions, floor.Num, fmt.Sprintf("A turret destroys the %s!", sh.TargetGlyph))
+		}
+	}
+}
+
+// creditKillLocked drops the spoils of an enemy with glyph slain at pos
+// without a player's blow, by a trap, turret or hazard. The kill count, gold
+// and XP go to sess if there is one, announced as "<name>'s <verb> the
+// <glyph>!"; with no killer, the caller announces the kill and every player
+// on the floor is checked for the boss victory instead.
+// Caller must hold s.mu.
+func (s *Server) creditKillLocked(floor *Floor, sess *Session, verb, glyph string, pos component.Position, loot component.Loot) {
+	s.noteKillLocked(floor, pos)
+	s.dropGoldLocked(floor, pos, glyph)
+	factory.LeaveHazard(floor.World, floor.Num, glyph, pos.X, pos.Y)
+	for _, it := range factory.RollLoot(loot, floor.Num, floor.CombatRng) {
+		factory.NewItemByGlyph(floor.World, it.Glyph, pos.X, pos.Y)
+	}
+	s.dropChestLocked(floor, sess, pos, glyph)
+	if sess == nil {
+		for _, other := range s.sessions {
+			if other.FloorNum == floor.Num && other.GetDeathCountdown() == 0 {
+				s.checkVictoryLocked(floor, other)
+			}
+		}
+		return
+	}
+	sess.RunLog.EnemiesKilled[glyph]++
+	gold := floor.CombatRng.Intn(4) + 1
+	sess.Gold += gold
+	sess.RunLog.GoldEarned += gold
+	floorMessage(s.sessions, floor.Num, fmt.Sprintf("%s's %s the %s! (+%d💰)", sess.Name, verb, glyph, gold))
+	if assets.IsEliteGlyph(glyph) {
+		grantXPLocked(sess, assets.XPForEliteKill(floor.Num))
+	} else {
+		grantXPLocked(sess, assets.XPForKill(assets.ThreatForGlyph(glyph), floor.Num))
 	}
+	s.checkVictoryLocked(floor, sess)
+}
+
+// resolveTrapTriggersLocked reports sprung traps and credits trap kills to the
+// trap's owner: kill count, gold, XP and loot drops.
+// Caller must hold s.mu.
+func (s *Server) resolveTrapTriggersLocked(floor *Floor, triggers []system.TrapTrigger) {
+	for _, tr := range triggers {
+		sess := s.sessionByPlayerID(tr.Owner)
+		if sess != nil {
+			sess.RunLog.DamageDealt += tr.Damage
+		}
+		if tr.Kind == component.TrapSnare {
+			floorMessage(s.sessions, floor.Num, fmt.Sprintf("The %s is caught in a snare!", tr.VictimGlyph))
+		} else {
+			floorMessage(s.sessions, floor.Num, fmt.Sprintf("The %s steps on spikes! (%d damage)", tr.VictimGlyph, tr.Damage))
+		}
+		if !tr.Killed {
+			continue
+		}
+		s.creditKillLocked(floor, sess, "trap kills", tr.VictimGlyph, tr.Pos, tr.Loot)
+		if sess == nil {
+			floorMessage(s.sessions, floor.Num, fmt.Sprintf("A trap kills the %s!", tr.VictimGlyph))
+		}
+	}
+}
+
+// resolveSummonsLocked creates the minions called by summoners this tick.
+// Minions are ordinary enemies, so they hold off the floor's respawn timer
+// like any other. Calls made while the floor is at its enemy cap fizzle.
+// Caller must hold s.mu.
+func (s *Server) resolveSummonsLocked(floor *Floor, summons []system.Summon) {
+	room := s.enemyRoom(floor)
+	for _, sm := range summons {
+		if room == 0 {
+			return
+		}
+		if factory.NewMinion(floor.World, sm.Summoner, sm.Minion, sm.Pos.X, sm.Pos.Y) != ecs.NilEntity {
+			floorMessage(s.sessions, floor.Num, fmt.Sprintf("The %s calls forth a %s!", sm.SummonerGlyph, sm.Minion))
+			room--
+		}
+	}
+}
+
+// resolveSplitsLocked creates the copies shed by splitting enemies this tick.
+// While the floor is at its enemy cap a splitter keeps the HP it would have
+// shed instead.
+// Caller must hold s.mu.
+func (s *Server) resolveSplitsLocked(floor *Floor, splits []system.Split) {
+	room := s.enemyRoom(floor)
+	for _, sp := range splits {
+		if room == 0 {
+			if hc := floor.World.Get(sp.Parent, component.CHealth); hc != nil {
+				hp := hc.(component.Health)
+				hp.Current += sp.HP
+				hp.Max = max(hp.Max, hp.Current)
+				floor.World.Add(sp.Parent, hp)
+			}
+			continue
+		}
+		if factory.NewSplitCopy(floor.World, sp.Parent, sp.Pos.X, sp.Pos.Y, sp.HP, sp.MaxHP, sp.Gen) != ecs.NilEntity {
+			floorMessage(s.sessions, floor.Num, fmt.Sprintf("The %s splits in two!", sp.Glyph))
+			room--
+		}
+	}
+}
+
+// noteWornOutLocked tells sess that an item of theirs broke from wear, if one
+// did, and refits their MaxHP without it. Caller must hold s.mu.
+func noteWornOutLocked(floor *Floor, sess *Session, name string) {
+	if name == "" {
+		return
+	}
+	sess.AddMessage(game.BreakMessage(name))
+	recalcMaxHPWithSkills(floor.World, sess)
 }
 
 // hitMessage returns the floor-visible message for an enemy special attack.
 func hitMessage(h system.EnemyHitResult, victimName string) string {
+	if h.Parried {
+		return fmt.Sprintf("%s parries the %s's blow!", victimName, h.EnemyGlyph)
+	}
 	switch h.SpecialApplied {
 	case 1:
 		return fmt.Sprintf("The %s poisons %s!", h.EnemyGlyph, victimName)
@@ -318,6 +568,8 @@ func hitMessage(h system.EnemyHitResult, victimName string) string {
 		return fmt.Sprintf("The %s stuns %s!", h.EnemyGlyph, victimName)
 	case 5:
 		return fmt.Sprintf("The %s shatters %s's defenses!", h.EnemyGlyph, victimName)
+	case 6:
+		return fmt.Sprintf("The %s knocks %s's %s loose!", h.EnemyGlyph, victimName, h.DisarmedItem)
 	}
 	return ""
 }
@@ -352,6 +604,17 @@ func (s *Server) processActionLocked(sess *Session, action Action) {
 	switch action {
 	case ActionWait:
 		sess.AddMessage("You wait.")
+		if system.AnswerRiddle(floor.World, sess.PlayerID) {
+			sess.Gold += system.RiddleGold
+			sess.RunLog.GoldEarned += system.RiddleGold
+			sess.AddMessage(game.RiddleAnswered)
+		}
+
+	case ActionBlock:
+		sess.AddMessage(game.BlockMessage(system.Block(floor.World, sess.PlayerID)))
+
+	case ActionHotbar1, ActionHotbar2, ActionHotbar3, ActionHotbar4:
+		s.useHotbarLocked(floor, sess, int(action-ActionHotbar1))
 
 	case ActionDescend, ActionUseStairs:
 		posComp := floor.World.Get(sess.PlayerID, component.CPosition)
@@ -370,6 +633,7 @@ func (s *Server) processActionLocked(sess *Session, action Action) {
 				}
 			}
 			if target <= assets.DungeonMaxFloor(target) {
+				s.warnLeftBehindLocked(sess, target)
 				s.transitionFloorLocked(sess, target)
 				return
 			}
@@ -421,11 +685,10 @@ func (s *Server) processActionLocked(sess *Session, action Action) {
 	case ActionSpecialAbility:
 		if sess.Class.AbilityCooldown == 0 {
 			sess.AddMessage("No special ability.")
-		} else if sess.SpecialCooldown > 0 {
+		} else if sess.SpecialSpent >= sess.Class.MaxCharges() {
 			sess.AddMessage(fmt.Sprintf("%s recharging (%d turns).", sess.Class.AbilityName, sess.SpecialCooldown))
-		} else {
-			s.useSpecialAbilityLocked(floor, sess)
-			sess.SpecialCooldown = effectiveCooldown(sess)
+		} else if s.useSpecialAbilityLocked(floor, sess) {
+			sess.SpecialSpent, sess.SpecialCooldown = system.SpendCharge(sess.SpecialSpent, sess.SpecialCooldown, effectiveCooldown(floor.World, sess))
 		}
 
 	default:
@@ -436,9 +699,15 @@ func (s *Server) processActionLocked(sess *Session, action Action) {
 		result, target := system.TryMove(floor.World, floor.GMap, sess.PlayerID, dx, dy)
 		switch result {
 		case system.MoveOK:
+			if onChute(floor, sess) && sess.FloorNum+2 <= assets.DungeonMaxFloor(sess.FloorNum) {
+				s.fallDownChuteLocked(sess)
+				return
+			}
 			system.UpdateFOV(floor.World, floor.GMap, sess.PlayerID, effectiveFOVRadius(sess))
 			sess.SnapshotFOV(floor.GMap)
 			s.checkInscriptionLocked(floor, sess)
+			s.stepPuzzleLocked(floor, sess)
+			s.collectGoldLocked(floor, sess)
 
 		case system.MoveInteract:
 			if npc := floor.World.Get(target, component.CNPC); npc != nil {
@@ -459,27 +728,32 @@ func (s *Server) processActionLocked(sess *Session, action Action) {
 				return
 			}
 			name := entityGlyph(floor.World, target)
+			title := game.ChampionName(floor.World, target, name)
 			posComp := floor.World.Get(target, component.CPosition)
 			if posComp == nil {
 				return
 			}
 			enemyPos := posComp.(component.Position)
-			var lootDrops []component.LootEntry
+			var loot component.Loot
 			if lc := floor.World.Get(target, component.CLoot); lc != nil {
-				lootDrops = lc.(component.Loot).Drops
+				loot = lc.(component.Loot)
 			}
-			res := system.Attack(floor.World, floor.Rng, sess.PlayerID, target)
+			res := system.Attack(floor.World, floor.CombatRng, sess.PlayerID, target)
 			if res.Dodged {
-				sess.AddMessage(fmt.Sprintf("The %s dodges your attack!", name))
+				sess.AddMessage(fmt.Sprintf("The %s dodges your attack!", title))
 				return
 			}
 			sess.RunLog.DamageDealt += res.Damage
+			noteWornOutLocked(floor, sess, res.WornOut)
 			if res.Killed {
 				sess.RunLog.EnemiesKilled[name]++
-				gold := floor.Rng.Intn(4) + 1
+				s.noteKillLocked(floor, enemyPos)
+				s.dropGoldLocked(floor, enemyPos, name)
+				factory.LeaveHazard(floor.World, floor.Num, name, enemyPos.X, enemyPos.Y)
+				gold := floor.CombatRng.Intn(4) + 1
 				sess.Gold += gold
 				sess.RunLog.GoldEarned += gold
-				floorMessage(s.sessions, floor.Num, fmt.Sprintf("%s kills the %s! (+%d💰)", sess.Name, name, gold))
+				floorMessage(s.sessions, floor.Num, fmt.Sprintf("%s kills the %s! (+%d💰)", sess.Name, title, gold))
 				// Grant XP for kill.
 				if assets.IsEliteGlyph(name) {
 					grantXPLocked(sess, assets.XPForEliteKill(floor.Num))
@@ -492,19 +766,15 @@ func (s *Server) processActionLocked(sess *Session, action Action) {
 						sess.AddMessage(lore)
 					}
 				}
-				for _, d := range lootDrops {
-					if floor.Rng.Intn(100) < d.Chance {
-						factory.NewItemByGlyph(floor.World, d.Glyph, enemyPos.X, enemyPos.Y)
-						sess.AddMessage(fmt.Sprintf("The %s drops something!", name))
-					}
-				}
+				s.dropLootLocked(floor, sess, enemyPos, name, factory.RollLoot(loot, floor.Num, floor.CombatRng))
+				s.dropChestLocked(floor, sess, enemyPos, name)
 				if sess.Class.KillRestoreHP > 0 {
 					restoreHP(floor.World, sess.PlayerID, sess.Class.KillRestoreHP)
 					sess.AddMessage(fmt.Sprintf("The kill feeds you. (+%d HP)", sess.Class.KillRestoreHP))
 				}
 				sb := computeSessionSkillBonuses(sess)
 				healChance := sess.Class.KillHealChance + sb.KillHealAdd
-				if healChance > 0 && floor.Rng.Intn(100) < healChance {
+				if healChance > 0 && floor.CombatRng.Intn(100) < healChance {
 					restoreHP(floor.World, sess.PlayerID, 2)
 					sess.AddMessage("Wild magic sparks! (+2 HP)")
 				}
@@ -516,7 +786,7 @@ func (s *Server) processActionLocked(sess *Session, action Action) {
 				}
 				s.checkVictoryLocked(floor, sess)
 			} else {
-				sess.AddMessage(fmt.Sprintf("You hit the %s for %d damage.", name, res.Damage))
+				sess.AddMessage(fmt.Sprintf("You hit the %s for %d damage.", title, res.Damage))
 			}
 
 		case system.MoveBlocked:
@@ -526,7 +796,9 @@ func (s *Server) processActionLocked(sess *Session, action Action) {
 			}
 			pos := posComp.(component.Position)
 			tx, ty := pos.X+dx, pos.Y+dy
-			if floor.GMap.InBounds(tx, ty) && floor.GMap.At(tx, ty).Kind == gamemap.TileDoor {
+			if floor.GMap.InBounds(tx, ty) && floor.GMap.At(tx, ty).Locked {
+				sess.AddMessage(game.SealedDoorMessage)
+			} else if floor.GMap.InBounds(tx, ty) && floor.GMap.At(tx, ty).Kind == gamemap.TileDoor {
 				floor.GMap.Set(tx, ty, gamemap.MakeFloor())
 				system.UpdateFOV(floor.World, floor.GMap, sess.PlayerID, effectiveFOVRadius(sess))
 				sess.SnapshotFOV(floor.GMap)
@@ -543,6 +815,7 @@ func (s *Server) processActionLocked(sess *Session, action Action) {
 // Caller must hold s.mu.
 func (s *Server) transitionFloorLocked(sess *Session, targetFloor int) {
 	oldFloor, hasOld := s.floors[sess.FloorNum]
+	power := s.sessionPowerLocked(sess) // before the old entity and its gear go
 
 	// Save HP and inventory before destroying old entity.
 	savedHP := -1
@@ -555,19 +828,24 @@ func (s *Server) transitionFloorLocked(sess *Session, targetFloor int) {
 			v := inv.(component.Inventory)
 			savedInv = &v
 		}
+		system.ClearTurrets(oldFloor.World, sess.PlayerID)
+		system.ClearDecoys(oldFloor.World, sess.PlayerID)
+		system.ClearTraps(oldFloor.World, sess.PlayerID)
 		oldFloor.World.DestroyEntity(sess.PlayerID)
 	}
 
 	// Get or create the target floor.
 	floor, ok := s.floors[targetFloor]
 	if !ok {
-		floor = newFloor(targetFloor, rand.New(rand.NewSource(s.rng.Int63())))
+		floor = newFloor(targetFloor, s.rng.Int63(), s.enemyScalePct(power))
 		s.floors[targetFloor] = floor
 	}
+	system.AttuneEchoes(floor.World, []string{sess.Class.ID})
 
 	// Direction-aware spawn: descending → near stairs up; ascending → near stairs down.
 	fromFloor := sess.FloorNum
 	sess.FloorNum = targetFloor
+	sess.ClearBonusOffered = false
 	if targetFloor > sess.RunLog.FloorsReached {
 		sess.RunLog.FloorsReached = targetFloor
 	}
@@ -591,16 +869,6 @@ func (s *Server) transitionFloorLocked(sess *Session, targetFloor int) {
 		floor.World.Add(sess.PlayerID, r)
 	}
 
-	// Reapply furniture combat bonuses.
-	if sess.FurnitureATK != 0 || sess.FurnitureDEF != 0 {
-		if cc := floor.World.Get(sess.PlayerID, component.CCombat); cc != nil {
-			c := cc.(component.Combat)
-			c.Attack += sess.FurnitureATK
-			c.Defense += sess.FurnitureDEF
-			floor.World.Add(sess.PlayerID, c)
-		}
-	}
-
 	// Restore HP (capped at max).
 	if savedHP > 0 {
 		if hpComp := floor.World.Get(sess.PlayerID, component.CHealth); hpComp != nil {
@@ -617,7 +885,7 @@ func (s *Server) transitionFloorLocked(sess *Session, targetFloor int) {
 		floor.World.Add(sess.PlayerID, *savedInv)
 	}
 
-	// Reapply level growth and skill bonuses to the new entity.
+	// Reapply level growth, furniture and skill bonuses to the new entity.
 	applyLevelGrowthLocked(floor.World, sess)
 	applySkillBonusesLocked(floor.World, sess)
 	recalcMaxHPWithSkills(floor.World, sess)
@@ -625,6 +893,7 @@ func (s *Server) transitionFloorLocked(sess *Session, targetFloor int) {
 	// AbilityFreeOnFloor: reset cooldown on each floor entry.
 	if sess.Class.AbilityFreeOnFloor {
 		sess.SpecialCooldown = 0
+		sess.SpecialSpent = 0
 	}
 
 	// Grant XP for first-time floor entry.
@@ -636,6 +905,7 @@ func (s *Server) transitionFloorLocked(sess *Session, targetFloor int) {
 	system.UpdateFOV(floor.World, floor.GMap, sess.PlayerID, effectiveFOVRadius(sess))
 	sess.SnapshotFOV(floor.GMap)
 	sess.Renderer = render.NewRenderer(sess.Screen, targetFloor)
+	sess.Renderer.SetASCII(s.ASCII)
 	sess.Renderer.CenterOn(spawnX, spawnY)
 
 	df := assets.DungeonFloor(targetFloor)
@@ -646,8 +916,24 @@ func (s *Server) transitionFloorLocked(sess *Session, targetFloor int) {
 	} else {
 		sess.AddMessage(fmt.Sprintf("%s ascends to %s (Floor %d).", sess.Name, assets.FloorName(targetFloor), df))
 	}
+	if affix := floor.GMap.Affix; affix != gamemap.AffixNone {
+		sess.AddMessage(fmt.Sprintf("This floor is cursed with %s. %s", affix.Name(), affix.Desc()))
+	}
 	if lore := assets.FloorLoreSnippets(targetFloor); len(lore) > 0 {
-		sess.AddMessage(lore[floor.Rng.Intn(len(lore))])
+		sess.AddMessage(lore[floor.CombatRng.Intn(len(lore))])
+	}
+}
+
+// warnLeftBehindLocked tells the other players on sess's floor that sess is
+// taking the stairs to targetFloor. MUD floors run independently, so nobody
+// is pulled along; this just keeps a group from losing track of each other.
+// Caller must hold s.mu.
+func (s *Server) warnLeftBehindLocked(sess *Session, targetFloor int) {
+	for _, other := range s.sessions {
+		if other != sess && other.FloorNum == sess.FloorNum {
+			other.AddMessage(fmt.Sprintf("%s heads down to %s. Take the stairs to follow.",
+				sess.Name, assets.FloorName(targetFloor)))
+		}
 	}
 }
 
@@ -656,11 +942,12 @@ func (s *Server) transitionFloorLocked(sess *Session, targetFloor int) {
 func (s *Server) spawnPlayerLocked(sess *Session, floorNum int) {
 	floor, ok := s.floors[floorNum]
 	if !ok {
-		floor = newFloor(floorNum, rand.New(rand.NewSource(s.rng.Int63())))
+		floor = newFloor(floorNum, s.rng.Int63(), s.enemyScalePct(s.sessionPowerLocked(sess)))
 		s.floors[floorNum] = floor
 	}
 
 	sess.FloorNum = floorNum
+	sess.ClearBonusOffered = false
 	sx, sy := findFreeSpawn(floor, s.sessions, floor.SpawnX, floor.SpawnY)
 	sess.PlayerID = factory.NewPlayer(floor.World, sx, sy, sess.Class)
 
@@ -671,16 +958,6 @@ func (s *Server) spawnPlayerLocked(sess *Session, floorNum int) {
 		floor.World.Add(sess.PlayerID, r)
 	}
 
-	// Apply any pre-existing furniture bonuses (e.g. on respawn).
-	if sess.FurnitureATK != 0 || sess.FurnitureDEF != 0 {
-		if cc := floor.World.Get(sess.PlayerID, component.CCombat); cc != nil {
-			c := cc.(component.Combat)
-			c.Attack += sess.FurnitureATK
-			c.Defense += sess.FurnitureDEF
-			floor.World.Add(sess.PlayerID, c)
-		}
-	}
-
 	// Spawn class start items adjacent to the player (city spawn only).
 	if floorNum == 0 {
 		for i, glyph := range sess.Class.StartItems {
@@ -694,7 +971,7 @@ func (s *Server) spawnPlayerLocked(sess *Session, floorNum int) {
 		}
 	}
 
-	// Reapply level growth and skill bonuses.
+	// Apply level growth, furniture and skill bonuses.
 	applyLevelGrowthLocked(floor.World, sess)
 	applySkillBonusesLocked(floor.World, sess)
 	recalcMaxHPWithSkills(floor.World, sess)
@@ -706,18 +983,23 @@ func (s *Server) spawnPlayerLocked(sess *Session, floorNum int) {
 	system.UpdateFOV(floor.World, floor.GMap, sess.PlayerID, effectiveFOVRadius(sess))
 	sess.SnapshotFOV(floor.GMap)
 	sess.Renderer = render.NewRenderer(sess.Screen, floorNum)
+	sess.Renderer.SetASCII(s.ASCII)
 	sess.Renderer.CenterOn(sx, sy)
 }
 
 // respawnLocked resets a dead session and returns them to Emberveil (floor 0).
 // Caller must hold s.mu.
 func (s *Server) respawnLocked(sess *Session) {
-	// Destroy old entity if still present.
+	// Destroy old entity (and any turrets, decoys or traps it placed) if still present.
 	if floor, ok := s.floors[sess.FloorNum]; ok && sess.PlayerID != ecs.NilEntity {
+		system.ClearTurrets(floor.World, sess.PlayerID)
+		system.ClearDecoys(floor.World, sess.PlayerID)
+		system.ClearTraps(floor.World, sess.PlayerID)
 		floor.World.DestroyEntity(sess.PlayerID)
 	}
 
-	// Reset per-run stats but keep class and furniture bonuses.
+	// Reset per-run stats, furniture bonuses included; only the class is
+	// kept. Furniture already used stays used, so its bonus is gone for good.
 	sess.RunLog = RunLog{
 		EnemiesKilled: make(map[string]int),
 		ItemsUsed:     make(map[string]int),
@@ -726,20 +1008,24 @@ func (s *Server) respawnLocked(sess *Session) {
 	sess.DiscoveredEnemies = make(map[string]bool)
 	sess.TurnCount = 0
 	sess.SpecialCooldown = 0
+	sess.SpecialSpent = 0
 	sess.FurnitureATK = 0
 	sess.FurnitureDEF = 0
+	sess.FurnitureMaxHP = 0
 	sess.FurnitureThorns = 0
 	sess.FurnitureKR = false
 	sess.FovRadius = sess.Class.FOVRadius
 	sess.BaseMaxHP = sess.Class.MaxHP
 	sess.PlayerID = ecs.NilEntity
 	sess.Gold = 0
+	sess.Hotbar = game.Hotbar{}
 	sess.Level = 1
 	sess.XP = 0
 	sess.PendingLevels = 0
 	sess.LearnedSkills = nil
 	sess.Branch = ""
 	sess.FloorsVisited = make(map[int]bool)
+	sess.Explored = nil
 
 	respawnCity := assets.DungeonCityFloor(sess.FloorNum)
 	sess.AddMessage(fmt.Sprintf("You respawn in %s...", assets.FloorName(respawnCity)))
@@ -750,7 +1036,9 @@ func (s *Server) respawnLocked(sess *Session) {
 // ─── Per-session rendering ────────────────────────────────────────────────────
 
 // RenderSession renders the current world state to a session's screen.
-// Must be called while holding s.mu (to safely access ECS and gmap).
+// Must be called while holding s.mu (to safely access ECS and gmap). It only
+// fills the screen's cell buffer; callers flush with Screen.Show after
+// releasing s.mu, so a slow connection never holds up the tick.
 func (s *Server) RenderSession(sess *Session) {
 	floor, ok := s.floors[sess.FloorNum]
 	if !ok || sess.Renderer == nil {
@@ -789,8 +1077,13 @@ func (s *Server) RenderSession(sess *Session) {
 	// Embed player count and gold in className field for HUD display.
 	className := fmt.Sprintf("%s [%d online] 💰%d", sess.Class.Name, len(s.sessions), sess.Gold)
 
-	sess.Renderer.DrawHUD(floor.World, sess.PlayerID, sess.FloorNum, className,
-		sess.Messages, bonusATK, bonusDEF, sess.Class.AbilityName, sess.SpecialCooldown, sess.Level, sess.PendingLevels)
+	sess.Renderer.SetTurn(sess.RunLog.TurnsPlayed)
+	if ic := floor.World.Get(sess.PlayerID, component.CInventory); ic != nil {
+		sess.Renderer.SetHotbar(sess.Hotbar.HUD(ic.(component.Inventory)))
+	}
+	sess.Renderer.DrawHUD(floor.World, floor.GMap, sess.PlayerID, sess.FloorNum, className,
+		sess.Messages, bonusATK, bonusDEF, sess.Class.AbilityName, sess.SpecialCooldown, effectiveCooldown(floor.World, sess),
+		sess.Class.MaxCharges()-sess.SpecialSpent, sess.Class.MaxCharges(), sess.Level, sess.PendingLevels)
 }
 
 // ─── Helpers ──────────────────────────────────────────────────────────────────
@@ -801,31 +1094,13 @@ func restoreHP(w *ecs.World, id ecs.EntityID, n int) {
 		return
 	}
 	h := hp.(component.Health)
+	before := h.Current
 	h.Current += n
 	if h.Current > h.Max {
 		h.Current = h.Max
 	}
 	w.Add(id, h)
-}
-
-func recalcMaxHP(w *ecs.World, sess *Session) {
-	invComp := w.Get(sess.PlayerID, component.CInventory)
-	if invComp == nil {
-		return
-	}
-	inv := invComp.(component.Inventory)
-	bonus := inv.Head.BonusMaxHP + inv.Body.BonusMaxHP + inv.Feet.BonusMaxHP +
-		inv.MainHand.BonusMaxHP + inv.OffHand.BonusMaxHP
-	hpComp := w.Get(sess.PlayerID, component.CHealth)
-	if hpComp == nil {
-		return
-	}
-	hp := hpComp.(component.Health)
-	hp.Max = sess.BaseMaxHP + bonus
-	if hp.Current > hp.Max {
-		hp.Current = hp.Max
-	}
-	w.Add(sess.PlayerID, hp)
+	system.HealThreat(w, id, h.Current-before)
 }
 
 func equipBonuses(w *ecs.World, id ecs.EntityID) (atk, def int) {
@@ -833,10 +1108,9 @@ func equipBonuses(w *ecs.World, id ecs.EntityID) (atk, def int) {
 	if c == nil {
 		return 0, 0
 	}
-	inv := c.(component.Inventory)
-	atk = inv.MainHand.BonusATK + inv.OffHand.BonusATK + inv.Head.BonusATK + inv.Body.BonusATK + inv.Feet.BonusATK
-	def = inv.MainHand.BonusDEF + inv.OffHand.BonusDEF + inv.Head.BonusDEF + inv.Body.BonusDEF + inv.Feet.BonusDEF
-	return atk, def
+	atk, def, _ = c.(component.Inventory).EquipBonuses()
+	lostATK, lostDEF := system.DisarmPenalty(w, id)
+	return atk - lostATK, def - lostDEF
 }
 
 func entityGlyph(w *ecs.World, id ecs.EntityID) string {
@@ -973,11 +1247,38 @@ func (s *Server) checkInscriptionLocked(floor *Floor, sess *Session) {
 			sess.RunLog.InscriptionsRead++
 			grantXPLocked(sess, assets.XPForInscription)
 			sess.AddMessage("📝 " + text)
+			if msg := game.RuneMessage(system.TriggerRune(floor.World, sess.PlayerID)); msg != "" {
+				sess.AddMessage(msg)
+			}
 			return
 		}
 	}
 }
 
+// stepPuzzleLocked advances the floor's vault puzzle for the player's new
+// tile. Everyone on the floor hears when the vault unlocks.
+// Caller must hold s.mu.
+func (s *Server) stepPuzzleLocked(floor *Floor, sess *Session) {
+	posComp := floor.World.Get(sess.PlayerID, component.CPosition)
+	if posComp == nil {
+		return
+	}
+	pos := posComp.(component.Position)
+	step := system.StepPuzzle(floor.GMap, pos.X, pos.Y)
+	msg := game.PuzzleMessage(step)
+	if step != system.PuzzleSolved {
+		if msg != "" {
+			sess.AddMessage(msg)
+		}
+		return
+	}
+	for _, other := range s.sessions {
+		if other.FloorNum == sess.FloorNum {
+			other.AddMessage(msg)
+		}
+	}
+}
+
 // tryPickupLocked picks up an item at the player's position.
 func (s *Server) tryPickupLocked(floor *Floor, sess *Session) {
 	posComp := floor.World.Get(sess.PlayerID, component.CPosition)
@@ -985,6 +1286,9 @@ func (s *Server) tryPickupLocked(floor *Floor, sess *Session) {
 		return
 	}
 	pos := posComp.(component.Position)
+	if s.lootCorpseLocked(floor, sess) || s.openChestLocked(floor, sess) {
+		return
+	}
 	for _, itemID := range floor.World.Query(component.CTagItem, component.CPosition) {
 		ipos := floor.World.Get(itemID, component.CPosition).(component.Position)
 		if ipos.X != pos.X || ipos.Y != pos.Y {
@@ -1001,11 +1305,10 @@ func (s *Server) tryPickupLocked(floor *Floor, sess *Session) {
 			return
 		}
 		inv := invComp.(component.Inventory)
-		if len(inv.Backpack) >= inv.Capacity {
+		if !inv.Stow(item) {
 			sess.AddMessage("Backpack full! Drop something first.")
 			return
 		}
-		inv.Backpack = append(inv.Backpack, item)
 		floor.World.Add(sess.PlayerID, inv)
 		floor.World.DestroyEntity(itemID)
 		grantXPLocked(sess, assets.XPForPickup)
@@ -1027,7 +1330,7 @@ func (s *Server) interactNPCLocked(floor *Floor, sess *Session, _ ecs.EntityID,
 		hp := hpComp.(component.Health)
 		if hp.Current == hp.Max {
 			if len(npc.Lines) > 0 {
-				line := npc.Lines[floor.Rng.Intn(len(npc.Lines))]
+				line := npc.Lines[floor.CombatRng.Intn(len(npc.Lines))]
 				sess.AddMessage(fmt.Sprintf("💬 %s: \"%s\"", npc.Name, line))
 			}
 			return
@@ -1040,15 +1343,25 @@ func (s *Server) interactNPCLocked(floor *Floor, sess *Session, _ ecs.EntityID,
 	case component.NPCKindShop:
 		sess.PendingNPC = 1 // any non-zero signals RunShop; exact ID not needed
 
+	case component.NPCKindSmith:
+		ic := floor.World.Get(sess.PlayerID, component.CInventory)
+		if ic == nil || len(game.RepairWares(ic.(component.Inventory))) == 0 {
+			if len(npc.Lines) > 0 {
+				sess.AddMessage(fmt.Sprintf("💬 %s: \"%s\"", npc.Name, npc.Lines[len(npc.Lines)-1]))
+			}
+			return
+		}
+		sess.PendingRepair = true
+
 	case component.NPCKindAnimal:
 		if len(npc.Lines) > 0 {
-			line := npc.Lines[floor.Rng.Intn(len(npc.Lines))]
+			line := npc.Lines[floor.CombatRng.Intn(len(npc.Lines))]
 			sess.AddMessage(line) // no speech marks for animals
 		}
 
 	default: // NPCKindDialogue
 		if len(npc.Lines) > 0 {
-			line := npc.Lines[floor.Rng.Intn(len(npc.Lines))]
+			line := npc.Lines[floor.CombatRng.Intn(len(npc.Lines))]
 			sess.AddMessage(fmt.Sprintf("💬 %s: \"%s\"", npc.Name, line))
 		}
 	}
@@ -1092,12 +1405,19 @@ func (s *Server) interactFurnitureLocked(floor *Floor, sess *Session, id ecs.Ent
 	}
 	f := fc.(component.Furniture)
 	sess.AddMessage(fmt.Sprintf("%s %s: %s", f.Glyph, f.Name, f.Description))
+	if f.Name == noticeBoardName {
+		sess.PendingWho = true
+	}
 	if f.IsRepeatable {
 		return // atmospheric furniture — description only, no bonus
 	}
 	if f.Used {
 		return
 	}
+	if floor.World.Has(id, component.CVending) {
+		sess.PendingVending = id
+		return
+	}
 	hasBonus := f.BonusATK != 0 || f.BonusDEF != 0 || f.BonusMaxHP != 0 ||
 		f.HealHP != 0 || f.PassiveKind != 0
 	if !hasBonus {
@@ -1122,6 +1442,7 @@ func (s *Server) interactFurnitureLocked(floor *Floor, sess *Session, id ecs.Ent
 		sess.AddMessage(fmt.Sprintf("Permanent DEF +%d!", f.BonusDEF))
 	}
 	if f.BonusMaxHP != 0 {
+		sess.FurnitureMaxHP += f.BonusMaxHP
 		sess.BaseMaxHP += f.BonusMaxHP
 		if hp := floor.World.Get(sess.PlayerID, component.CHealth); hp != nil {
 			h := hp.(component.Health)
@@ -1156,15 +1477,16 @@ func (s *Server) interactFurnitureLocked(floor *Floor, sess *Session, id ecs.Ent
 	floor.World.Add(id, f)
 }
 
-// useSpecialAbilityLocked fires the class active ability.
-func (s *Server) useSpecialAbilityLocked(floor *Floor, sess *Session) {
+// useSpecialAbilityLocked fires the class active ability and reports whether
+// it fired.
+func (s *Server) useSpecialAbilityLocked(floor *Floor, sess *Session) bool {
 	switch sess.Class.ID {
 	case "arcanist":
 		rooms := floor.GMap.Rooms
 		if len(rooms) == 0 {
-			return
+			return false
 		}
-		room := rooms[floor.Rng.Intn(len(rooms))]
+		room := rooms[floor.CombatRng.Intn(len(rooms))]
 		x, y := room.Center()
 		floor.World.Add(sess.PlayerID, component.Position{X: x, Y: y})
 		system.UpdateFOV(floor.World, floor.GMap, sess.PlayerID, effectiveFOVRadius(sess))
@@ -1174,13 +1496,12 @@ func (s *Server) useSpecialAbilityLocked(floor *Floor, sess *Session) {
 	case "revenant":
 		hpComp := floor.World.Get(sess.PlayerID, component.CHealth)
 		if hpComp == nil {
-			return
+			return false
 		}
 		hp := hpComp.(component.Health)
 		if hp.Current <= 5 {
 			sess.AddMessage("Too wounded to bargain with death!")
-			sess.SpecialCooldown = 0
-			return
+			return false
 		}
 		hp.Current -= 5
 		floor.World.Add(sess.PlayerID, hp)
@@ -1205,14 +1526,14 @@ func (s *Server) useSpecialAbilityLocked(floor *Floor, sess *Session) {
 		sess.AddMessage("Vanish! You fade from perception for 8 turns.")
 
 	case "oracle":
-		for y := range floor.GMap.Height {
-			for x := range floor.GMap.Width {
-				if floor.GMap.At(x, y).Walkable {
-					floor.GMap.At(x, y).Explored = true
-				}
-			}
+		sess.RevealFloor(floor.GMap)
+		system.ApplyEffect(floor.World, sess.PlayerID, component.ActiveEffect{
+			Kind: component.EffectTrueSight, Magnitude: 1, TurnsRemaining: 10,
+		})
+		sess.AddMessage("Farsight! The entire floor is revealed, and nothing can lurk unseen for 10 turns.")
+		if piles := len(floor.World.Query(component.CGoldPile)); piles > 0 {
+			sess.AddMessage(fmt.Sprintf("You sense %d gold piles glinting in the dark.", piles))
 		}
-		sess.AddMessage("Farsight! The entire floor is revealed.")
 
 	case "symbiont":
 		restoreHP(floor.World, sess.PlayerID, 10)
@@ -1220,7 +1541,28 @@ func (s *Server) useSpecialAbilityLocked(floor *Floor, sess *Session) {
 			Kind: component.EffectAttackBoost, Magnitude: 4, TurnsRemaining: 6,
 		})
 		sess.AddMessage("Parasite Surge! (+10 HP, +4 ATK for 6 turns)")
+
+	case "tinker":
+		posComp := floor.World.Get(sess.PlayerID, component.CPosition)
+		if posComp == nil {
+			return false
+		}
+		x, y, ok := system.FindDeploySpot(floor.World, floor.GMap, posComp.(component.Position))
+		if !ok {
+			sess.AddMessage("No room to deploy a turret here!")
+			return false
+		}
+		factory.NewTurret(floor.World, sess.PlayerID, x, y)
+		sess.AddMessage(fmt.Sprintf("Turret deployed! (%d shots, %d turns)", factory.TurretAmmo, factory.TurretLifespan))
+
+	case "warden":
+		n := system.TauntEnemies(floor.World, floor.GMap, sess.PlayerID, 6, 5)
+		system.ApplyEffect(floor.World, sess.PlayerID, component.ActiveEffect{
+			Kind: component.EffectDefenseBoost, Magnitude: 3, TurnsRemaining: 5,
+		})
+		floorMessage(s.sessions, floor.Num, fmt.Sprintf("%s issues a Challenge! %d enemies turn on them. (+3 DEF for 5 turns)", sess.Name, n))
 	}
+	return true
 }
 
 // applyConsumableLocked applies a consumed item's effect.
@@ -1244,7 +1586,7 @@ func (s *Server) applyConsumableLocked(floor *Floor, sess *Session, item compone
 	case assets.GlyphTesseract:
 		rooms := floor.GMap.Rooms
 		if len(rooms) > 0 {
-			room := rooms[floor.Rng.Intn(len(rooms))]
+			room := rooms[floor.CombatRng.Intn(len(rooms))]
 			x, y := room.Center()
 			floor.World.Add(sess.PlayerID, component.Position{X: x, Y: y})
 			system.UpdateFOV(floor.World, floor.GMap, sess.PlayerID, effectiveFOVRadius(sess))
@@ -1252,13 +1594,7 @@ func (s *Server) applyConsumableLocked(floor *Floor, sess *Session, item compone
 		}
 		sess.AddMessage("The Tesseract Cube warps you to a random location!")
 	case assets.GlyphMemoryScroll:
-		for y := range floor.GMap.Height {
-			for x := range floor.GMap.Width {
-				if floor.GMap.At(x, y).Walkable {
-					floor.GMap.At(x, y).Explored = true
-				}
-			}
-		}
+		sess.RevealFloor(floor.GMap)
 		sess.AddMessage("The Memory Scroll reveals the entire floor.")
 	case assets.GlyphSporeDraught:
 		restoreHP(floor.World, sess.PlayerID, 20)
@@ -1294,46 +1630,130 @@ func (s *Server) applyConsumableLocked(floor *Floor, sess *Session, item compone
 			Kind: component.EffectDefenseBoost, Magnitude: 6, TurnsRemaining: 15,
 		})
 		sess.AddMessage("The Phase Rod envelops you in prismatic shielding. (+6 DEF, 15 turns)")
+	case assets.GlyphTempoTonic:
+		system.ApplyEffect(floor.World, sess.PlayerID, component.ActiveEffect{
+			Kind: component.EffectHaste, Magnitude: 1, TurnsRemaining: 10,
+		})
+		sess.AddMessage("Time quickens around you. (Haste: ability recharges 2x for 10 turns)")
+	case assets.GlyphSnareKit, assets.GlyphCaltropPouch:
+		kind, name := component.TrapSnare, "snare"
+		if glyph == assets.GlyphCaltropPouch {
+			kind, name = component.TrapSpike, "spike trap"
+		}
+		pc := floor.World.Get(sess.PlayerID, component.CPosition)
+		if pc == nil {
+			break
+		}
+		pos := pc.(component.Position)
+		if _, ok := system.PlaceTrap(floor.World, floor.GMap, sess.PlayerID, pos.X, pos.Y, kind); ok {
+			sess.AddMessage(fmt.Sprintf("You arm a hidden %s at your feet. Lure something onto it.", name))
+		}
+	case assets.GlyphDecoyDoll:
+		pc := floor.World.Get(sess.PlayerID, component.CPosition)
+		if pc == nil {
+			break
+		}
+		x, y, ok := system.FindDeploySpot(floor.World, floor.GMap, pc.(component.Position))
+		if !ok {
+			break
+		}
+		factory.NewDecoy(floor.World, sess.PlayerID, x, y)
+		sess.AddMessage(fmt.Sprintf("You set down a decoy. Enemies that see it go after it instead of you. (%d turns)", factory.DecoyLifespan))
 	case assets.GlyphApexCore:
 		sess.BaseMaxHP += 3
-		if hpComp := floor.World.Get(sess.PlayerID, component.CHealth); hpComp != nil {
-			hp := hpComp.(component.Health)
-			hp.Max += 3
-			hp.Current += 3
-			floor.World.Add(sess.PlayerID, hp)
-		}
+		recalcMaxHPWithSkills(floor.World, sess)
+		restoreHP(floor.World, sess.PlayerID, 3)
 		sess.AddMessage("The Apex Core integrates into your biology. (+3 MaxHP permanently)")
 	}
 }
 
-// respawnEnemiesLocked spawns a partial enemy wave on the given floor.
+// unwatchedRoomsLocked returns the rooms no live player stands in or beside,
+// so a respawned wave never lands on top of someone.
+// Caller must hold s.mu.
+func (s *Server) unwatchedRoomsLocked(floor *Floor, rooms []gamemap.Rect) []gamemap.Rect {
+	var players []component.Position
+	for _, sess := range s.sessions {
+		if sess.FloorNum != floor.Num || sess.GetDeathCountdown() != 0 || sess.PlayerID == ecs.NilEntity {
+			continue
+		}
+		if pc := floor.World.Get(sess.PlayerID, component.CPosition); pc != nil {
+			players = append(players, pc.(component.Position))
+		}
+	}
+	var out []gamemap.Rect
+	for _, room := range rooms {
+		near := gamemap.Rect{X1: room.X1 - 1, Y1: room.Y1 - 1, X2: room.X2 + 1, Y2: room.Y2 + 1}
+		watched := false
+		for _, p := range players {
+			if near.Intersects(gamemap.Rect{X1: p.X, Y1: p.Y, X2: p.X, Y2: p.Y}) {
+				watched = true
+				break
+			}
+		}
+		if !watched {
+			out = append(out, room)
+		}
+	}
+	return out
+}
+
+// freeRespawnSpot returns room's centre, or the nearest free tile beside it
+// when something already stands there, so a wave does not stack on one tile.
+func freeRespawnSpot(floor *Floor, room gamemap.Rect) (int, int, bool) {
+	cx, cy := room.Center()
+	for _, id := range floor.World.Query(component.CTagBlocking, component.CPosition) {
+		if p := floor.World.Get(id, component.CPosition).(component.Position); p.X == cx && p.Y == cy {
+			return system.FindDeploySpot(floor.World, floor.GMap, p)
+		}
+	}
+	return cx, cy, true
+}
+
+// respawnEnemiesLocked spawns a partial enemy wave on the given floor, no
+// bigger than the floor's enemy cap allows.
 // Used when a cleared floor has active players and the respawn timer fires.
 // Caller must hold s.mu.
 func (s *Server) respawnEnemiesLocked(floor *Floor) {
-	cfg := levelConfig(floor.Num, floor.Rng)
+	cfg := levelConfig(floor.Num, floor.GenRng)
 	if len(cfg.EnemyTable) == 0 || len(floor.GMap.Rooms) == 0 {
 		return
 	}
 
 	budget := max(cfg.EnemyBudget/3, 3)
+	pct := s.enemyScalePct(s.floorPowerLocked(floor.Num))
 
 	// Skip the first room (player spawn area) when picking placement rooms.
 	startIdx := 1
 	if len(floor.GMap.Rooms) < 2 {
 		startIdx = 0
 	}
-	rooms := floor.GMap.Rooms[startIdx:]
+	rooms := s.unwatchedRoomsLocked(floor, floor.GMap.Rooms[startIdx:])
+	if len(rooms) == 0 {
+		return // everyone is spread through the floor; try again next countdown
+	}
 
-	for attempts := 0; budget > 0 && attempts < 30; attempts++ {
-		entry := cfg.EnemyTable[floor.Rng.Intn(len(cfg.EnemyTable))]
+	spare := s.enemyRoom(floor)
+	for attempts := 0; budget > 0 && spare > 0 && attempts < 30; attempts++ {
+		entry := cfg.EnemyTable[floor.GenRng.Intn(len(cfg.EnemyTable))]
 		if entry.ThreatCost > budget {
 			continue
 		}
-		room := rooms[floor.Rng.Intn(len(rooms))]
-		cx, cy := room.Center()
-		factory.NewEnemy(floor.World, entry, cx, cy)
+		room := rooms[floor.GenRng.Intn(len(rooms))]
+		cx, cy, ok := freeRespawnSpot(floor, room)
+		if !ok {
+			continue
+		}
+		factory.NewEnemy(floor.World, scaleEnemyEntry(entry, pct), cx, cy, floor.GenRng)
 		budget -= entry.ThreatCost
+		spare--
+	}
+	var classIDs []string
+	for _, sess := range s.sessions {
+		if sess.FloorNum == floor.Num {
+			classIDs = append(classIDs, sess.Class.ID)
+		}
 	}
+	system.AttuneEchoes(floor.World, classIDs)
 
 	floorMessage(s.sessions, floor.Num, "🌑 The dungeon stirs as new threats emerge from the shadows...")
 }
@@ -3,6 +3,7 @@ package mud
 import (
 	"emoji-roguelike/assets"
 	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/game"
 	"emoji-roguelike/internal/gamemap"
 	"emoji-roguelike/internal/render"
 	"sync"
@@ -34,15 +35,22 @@ type Session struct {
 	PlayerID ecs.EntityID
 	FloorNum int
 
-	// Per-player persistent stats (survive floor transitions and respawns).
+	// Per-player persistent stats (survive floor transitions; respawn resets
+	// them to the class base).
 	FovRadius       int
 	BaseMaxHP       int
 	FurnitureATK    int
 	FurnitureDEF    int
+	FurnitureMaxHP  int
 	FurnitureThorns int
 	FurnitureKR     bool
-	SpecialCooldown int
-	Gold            int // current gold; earned by killing enemies, spent at shop
+	SpecialCooldown int         // turns until the next ability charge is restored
+	SpecialSpent    int         // ability charges used and not yet restored
+	Gold            int         // current gold; earned by killing enemies, spent at shop
+	Hotbar          game.Hotbar // consumables on the quick-use keys
+	// Reputation is the gold spent with the city's merchants. Unlike Gold
+	// it survives death, and it earns a discount; see game.ReputationDiscount.
+	Reputation int
 
 	// Leveling state.
 	Level         int
@@ -55,13 +63,38 @@ type Session struct {
 	// PendingNPC is set by the tick goroutine to trigger a shop modal.
 	// Read and cleared in RunLoop's RenderCh handler (both under s.mu).
 	PendingNPC ecs.EntityID
+	// PendingVending is the vending machine the player just bumped; it opens
+	// the vending modal the same way PendingNPC opens the shop.
+	PendingVending ecs.EntityID
+	// PendingRolls queues the party loot rolls this player has yet to answer;
+	// RunLoop opens a need/greed prompt for each.
+	PendingRolls []int
+	// PendingWho is set when the player bumps a city notice board; it
+	// opens the who list.
+	PendingWho bool
+	// PendingRepair is set when the player bumps the smith with worn gear; it
+	// opens the repair modal.
+	PendingRepair bool
+	// PendingChest is the treasure chest the player just asked to open; it
+	// opens the chest modal.
+	PendingChest ecs.EntityID
+	// PendingClearBonus holds the rewards offered for clearing the floor; it
+	// opens the clear bonus modal.
+	PendingClearBonus []game.ClearBonus
+	// ClearBonusOffered is set once the player has been offered the clear
+	// bonus on the current floor, so respawned waves don't offer it again.
+	// It resets whenever the player changes floors.
+	ClearBonusOffered bool
 
 	// I/O
 	Screen   tcell.Screen
-	Renderer *render.Renderer
+	Renderer render.Renderer
 
 	// Per-player FOV snapshot: FovGrid[y][x] = visible from this player's perspective.
 	FovGrid [][]bool
+	// Explored[floor][y][x] = this player has mapped the tile. Kept across
+	// floor transitions so a revisited floor shows what they'd already seen.
+	Explored map[int][][]bool
 
 	// Pending action (last key wins).
 	actionMu sync.Mutex
@@ -73,10 +106,29 @@ type Session struct {
 	DiscoveredEnemies map[string]bool
 	TurnCount         int
 	ChatBubbles       []ChatBubble
+	HitFlashOff       bool // player disabled the heavy-hit screen flash
+	HideFloor         bool // keep this player's floor off other players' who lists
+	BellOn            bool // ring the terminal bell on notable events; see /bell
+	Bell              game.Bell
+	// PendingBell is set by the tick goroutine when the bell should ring;
+	// RunLoop rings it after the next frame, outside s.mu.
+	PendingBell bool
 
 	// Render trigger: ticker sends here; session's goroutine drains and renders.
+	// The one-slot buffer coalesces signals: a pending signal already renders
+	// the latest state, so later ones are dropped and counted.
 	RenderCh chan struct{}
 
+	// Disconnect closes the player's connection. Set by the transport; the
+	// server calls it when a client stays backed up for RenderBacklogLimit
+	// ticks. Nil means the session cannot be dropped.
+	Disconnect func()
+
+	droppedRenders atomic.Int64 // render signals dropped because one was pending
+	flushing       atomic.Bool  // a frame is being written to the connection
+	renderBacklog  int          // consecutive ticks stuck mid-flush; signalRender only
+	holdsMu        bool         // the session goroutine holds Server.mu; see lockSession
+
 	// deathCountdown > 0 means the player is dead and waiting to respawn.
 	// Decremented each tick; when it reaches 0, respawn fires.
 	// Accessed atomically: the tick goroutine writes under s.mu, while
@@ -108,6 +160,18 @@ func NewSession(id int, name string, color tcell.Color, screen tcell.Screen) *Se
 	}
 }
 
+// DroppedRenders returns how many render signals were dropped because the
+// session still had one pending. Safe to call from any goroutine.
+func (s *Session) DroppedRenders() int64 { return s.droppedRenders.Load() }
+
+// showFrame flushes the drawn frame to the connection, marking the session
+// as flushing so signalRender can tell a slow link from a busy modal.
+func (s *Session) showFrame() {
+	s.flushing.Store(true)
+	s.Screen.Show()
+	s.flushing.Store(false)
+}
+
 // GetDeathCountdown returns the current death countdown value.
 // Safe to call from any goroutine.
 func (s *Session) GetDeathCountdown() int { return int(s.deathCountdown.Load()) }
@@ -155,29 +219,33 @@ func (s *Session) AddMessage(msg string) {
 	}
 }
 
-// SnapshotFOV saves the current gmap.Tile.Visible state into s.FovGrid.
+// SnapshotFOV saves the current gmap.Tile.Visible state into s.FovGrid and
+// adds every visible tile to this player's explored memory for the floor.
 // Call this right after system.UpdateFOV, before the gmap state is clobbered
 // by another player's FOV update.
 func (s *Session) SnapshotFOV(gmap *gamemap.GameMap) {
 	if len(s.FovGrid) != gmap.Height {
-		s.FovGrid = make([][]bool, gmap.Height)
-		for y := range s.FovGrid {
-			s.FovGrid[y] = make([]bool, gmap.Width)
-		}
+		s.FovGrid = newGrid(gmap)
 	}
+	explored := s.exploredGrid(gmap)
 	for y := range gmap.Height {
 		for x := range gmap.Width {
 			s.FovGrid[y][x] = gmap.At(x, y).Visible
+			if s.FovGrid[y][x] {
+				explored[y][x] = true
+			}
 		}
 	}
 }
 
-// ApplyFOV writes s.FovGrid back into gmap.Tile.Visible so that the renderer
-// sees this player's field of view.
+// ApplyFOV writes s.FovGrid back into gmap.Tile.Visible, and this player's
+// explored memory into gmap.Tile.Explored, so that the renderer sees this
+// player's field of view and map knowledge.
 func (s *Session) ApplyFOV(gmap *gamemap.GameMap) {
 	if s.FovGrid == nil {
 		return
 	}
+	explored := s.exploredGrid(gmap)
 	for y := range gmap.Height {
 		for x := range gmap.Width {
 			if y < len(s.FovGrid) && x < len(s.FovGrid[y]) {
@@ -185,6 +253,44 @@ func (s *Session) ApplyFOV(gmap *gamemap.GameMap) {
 			} else {
 				gmap.At(x, y).Visible = false
 			}
+			gmap.At(x, y).Explored = explored[y][x]
 		}
 	}
 }
+
+// RevealFloor marks every walkable tile of gmap as explored, both on the map
+// and in this player's memory of the current floor.
+func (s *Session) RevealFloor(gmap *gamemap.GameMap) {
+	explored := s.exploredGrid(gmap)
+	for y := range gmap.Height {
+		for x := range gmap.Width {
+			if gmap.At(x, y).Walkable {
+				gmap.At(x, y).Explored = true
+				explored[y][x] = true
+			}
+		}
+	}
+}
+
+// exploredGrid returns this player's explored memory for the current floor,
+// allocating it sized to gmap on first use.
+func (s *Session) exploredGrid(gmap *gamemap.GameMap) [][]bool {
+	if s.Explored == nil {
+		s.Explored = make(map[int][][]bool)
+	}
+	grid := s.Explored[s.FloorNum]
+	if len(grid) != gmap.Height || (len(grid) > 0 && len(grid[0]) != gmap.Width) {
+		grid = newGrid(gmap)
+		s.Explored[s.FloorNum] = grid
+	}
+	return grid
+}
+
+// newGrid allocates a gmap-sized grid of false values.
+func newGrid(gmap *gamemap.GameMap) [][]bool {
+	grid := make([][]bool, gmap.Height)
+	for y := range grid {
+		grid[y] = make([]bool, gmap.Width)
+	}
+	return grid
+}
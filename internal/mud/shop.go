@@ -3,203 +3,206 @@ package mud
 import (
 	"emoji-roguelike/assets"
 	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/factory"
+	"emoji-roguelike/internal/game"
+	"emoji-roguelike/internal/system"
 	"fmt"
+	"slices"
 
 	"github.com/gdamore/tcell/v2"
 )
 
+// City shop stock: every ShopRestockTicks (~5 minutes at 100 ms/tick) the
+// shop rotates ShopWares entries of assets.ShopCatalogue onto its shelves,
+// ShopConsumableStock of each consumable and ShopEquipmentStock of each piece
+// of gear. Every player on the server buys from the same shelves.
+const (
+	ShopRestockTicks    = 3000
+	ShopWares           = 6
+	ShopConsumableStock = 5
+	ShopEquipmentStock  = 2
+)
+
+// restockShopLocked replaces the city shop's shelves with a fresh, fully
+// stocked selection of wares, kept in catalogue order.
+// Caller must hold s.mu.
+func (s *Server) restockShopLocked() {
+	picks := s.rng.Perm(len(assets.ShopCatalogue))[:min(ShopWares, len(assets.ShopCatalogue))]
+	slices.Sort(picks)
+	s.shop = make([]assets.ShopEntry, 0, len(picks))
+	for _, i := range picks {
+		entry := assets.ShopCatalogue[i]
+		entry.Limited, entry.Stock = true, ShopEquipmentStock
+		if entry.IsConsumable {
+			entry.Stock = ShopConsumableStock
+		}
+		s.shop = append(s.shop, entry)
+	}
+}
+
 // RunShop opens the blocking shop UI for a session.
 // eventCh supplies keyboard events from the session's input goroutine.
-// The player presses a–h to buy an item, Esc/q to close.
+// The player presses a–h to buy an item, Esc/q to close. The shelves are
+// re-read under the lock on every redraw, so purchases by other players and
+// restocks show up while the modal is open.
 func (s *Server) RunShop(sess *Session, eventCh <-chan tcell.Event) {
-	catalogue := assets.ShopCatalogue
-	cursor := 0
-	statusMsg := ""
+	pct := s.discountPct(sess)
+	wares := func() []assets.ShopEntry {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return game.DiscountWares(s.shop, pct)
+	}
+	game.RunShopModal(sess.Screen, sessionEvents(eventCh), game.DiscountTitle("🛍️ YEVA'S PROVISIONS", pct), wares,
+		func() int { return sess.Gold },
+		func(idx int) string { return s.shopBuy(sess, idx, pct) })
+}
 
-	for {
-		drawShopScreen(sess.Screen, catalogue, cursor, sess.Gold, statusMsg)
+// discountPct returns the merchant discount sess's reputation has earned.
+// A shop visit keeps the discount it opened with; reputation earned during
+// the visit counts from the next one.
+func (s *Server) discountPct(sess *Session) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return game.ReputationDiscount(sess.Reputation)
+}
 
-		ev, ok := <-eventCh
-		if !ok || ev == nil {
-			return
-		}
-		statusMsg = ""
-		switch ev := ev.(type) {
-		case *tcell.EventResize:
-			sess.Screen.Sync()
-		case *tcell.EventKey:
-			switch ev.Key() {
-			case tcell.KeyEscape:
-				return
-			case tcell.KeyUp:
-				if cursor > 0 {
-					cursor--
-				}
-			case tcell.KeyDown:
-				if cursor < len(catalogue)-1 {
-					cursor++
-				}
-			case tcell.KeyEnter:
-				statusMsg = s.shopBuy(sess, catalogue, cursor)
-			default:
-				r := ev.Rune()
-				switch {
-				case r == 'q' || r == 'Q':
-					return
-				case r == 'k' || r == 'K':
-					if cursor > 0 {
-						cursor--
-					}
-				case r == 'j' || r == 'J':
-					if cursor < len(catalogue)-1 {
-						cursor++
-					}
-				case r >= 'a' && r <= 'h':
-					idx := int(r - 'a')
-					if idx < len(catalogue) {
-						cursor = idx
-						statusMsg = s.shopBuy(sess, catalogue, idx)
-					}
-				}
-			}
+// RunVending opens the blocking vending-machine UI for machine id on the
+// session's floor. Stock is re-read under the lock on every redraw so two
+// players at the same machine never buy the same slot.
+func (s *Server) RunVending(sess *Session, id ecs.EntityID, eventCh <-chan tcell.Event) {
+	wares := func() []assets.ShopEntry {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		floor, ok := s.floors[sess.FloorNum]
+		if !ok {
+			return nil
 		}
+		return game.VendingWares(floor.World, id)
 	}
+	game.RunShopModal(sess.Screen, sessionEvents(eventCh), "🏧 VENDING MACHINE", wares,
+		func() int { return sess.Gold },
+		func(idx int) string { return s.vendBuy(sess, id, idx) })
 }
 
-// shopBuy attempts to purchase the item at the given catalogue index.
-func (s *Server) shopBuy(sess *Session, catalogue []assets.ShopEntry, idx int) string {
-	if idx < 0 || idx >= len(catalogue) {
-		return "Nothing selected."
-	}
-	entry := catalogue[idx]
-	if sess.Gold < entry.Price {
-		return fmt.Sprintf("Not enough gold. (%d💰 needed, you have %d💰)", entry.Price, sess.Gold)
+// RunRepair opens the blocking repair UI at the Hearth Forge. Jobs are
+// re-read under the lock on every redraw, so they track the gear the player
+// actually has on.
+func (s *Server) RunRepair(sess *Session, eventCh <-chan tcell.Event) {
+	pct := s.discountPct(sess)
+	jobs := func() []assets.ShopEntry {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		floor, ok := s.floors[sess.FloorNum]
+		if !ok {
+			return nil
+		}
+		ic := floor.World.Get(sess.PlayerID, component.CInventory)
+		if ic == nil {
+			return nil
+		}
+		return game.DiscountWares(game.RepairWares(ic.(component.Inventory)), pct)
 	}
+	game.RunShopModal(sess.Screen, sessionEvents(eventCh), game.DiscountTitle("⚒️ THE HEARTH FORGE", pct), jobs,
+		func() int { return sess.Gold },
+		func(idx int) string { return s.repairBuy(sess, idx, pct) })
+}
 
+// repairBuy pays for repair job idx on the session's equipped gear, less
+// discountPct percent.
+func (s *Server) repairBuy(sess *Session, idx, discountPct int) string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-
 	floor, ok := s.floors[sess.FloorNum]
 	if !ok {
-		return "Cannot buy here."
-	}
-	invComp := floor.World.Get(sess.PlayerID, component.CInventory)
-	if invComp == nil {
-		return "Cannot buy here."
+		return "Cannot repair here."
 	}
-	inv := invComp.(component.Inventory)
-	if len(inv.Backpack) >= inv.Capacity {
-		return "Backpack full! Drop something first."
+	ic := floor.World.Get(sess.PlayerID, component.CInventory)
+	if ic == nil {
+		return "Cannot repair here."
 	}
-
-	item := shopEntryToItem(entry)
-	inv.Backpack = append(inv.Backpack, item)
-	floor.World.Add(sess.PlayerID, inv)
-	sess.Gold -= entry.Price
-	return fmt.Sprintf("Bought %s %s. (%d💰 remaining)", entry.Glyph, entry.Name, sess.Gold)
+	inv := ic.(component.Inventory)
+	before := sess.Gold
+	msg := game.RepairItem(&sess.Gold, &inv, idx, discountPct)
+	saveInventoryLocked(floor, sess, inv)
+	sess.Reputation += before - sess.Gold
+	return msg
 }
 
-// shopEntryToItem converts a ShopEntry into a component.Item.
-func shopEntryToItem(e assets.ShopEntry) component.Item {
-	if e.IsConsumable {
-		return component.Item{
-			Name:         e.Name,
-			Glyph:        e.Glyph,
-			Slot:         component.SlotConsumable,
-			IsConsumable: true,
+// sessionEvents adapts a session's event channel to RunShopModal's event
+// source; a closed channel yields nil, which closes the modal.
+func sessionEvents(eventCh <-chan tcell.Event) func() tcell.Event {
+	return func() tcell.Event {
+		ev, ok := <-eventCh
+		if !ok {
+			return nil
 		}
-	}
-	slot := slotStringToItemSlot(e.Slot)
-	return component.Item{
-		Name:         e.Name,
-		Glyph:        e.Glyph,
-		Slot:         slot,
-		BonusATK:     e.BonusATK,
-		BonusDEF:     e.BonusDEF,
-		BonusMaxHP:   e.BonusMaxHP,
-		IsConsumable: false,
+		return ev
 	}
 }
 
-// slotStringToItemSlot converts the string slot name used in ShopEntry to
-// the component.ItemSlot constant.
-func slotStringToItemSlot(s string) component.ItemSlot {
-	switch s {
-	case "head":
-		return component.SlotHead
-	case "body":
-		return component.SlotBody
-	case "feet":
-		return component.SlotFeet
-	case "onehand":
-		return component.SlotOneHand
-	case "twohand":
-		return component.SlotTwoHand
-	case "offhand":
-		return component.SlotOffHand
-	}
-	return component.SlotConsumable
+// vendBuy buys slot idx from vending machine id and removes it from stock.
+func (s *Server) vendBuy(sess *Session, id ecs.EntityID, idx int) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	floor, ok := s.floors[sess.FloorNum]
+	if !ok {
+		return "Cannot buy here."
+	}
+	wares := game.VendingWares(floor.World, id)
+	if idx < 0 || idx >= len(wares) {
+		return "Sold out."
+	}
+	msg, bought := s.buyLocked(floor, sess, wares[idx])
+	if bought {
+		system.TakeVendingSlot(floor.World, id, idx)
+	}
+	return msg
 }
 
-// drawShopScreen renders the shop modal to the session's screen.
-func drawShopScreen(screen tcell.Screen, items []assets.ShopEntry, cursor, gold int, statusMsg string) {
-	screen.Clear()
-	sw, _ := screen.Size()
-
-	white := tcell.StyleDefault.Foreground(tcell.ColorWhite)
-	gray := tcell.StyleDefault.Foreground(tcell.ColorGray)
-	yellow := tcell.StyleDefault.Foreground(tcell.ColorYellow)
-	green := tcell.StyleDefault.Foreground(tcell.ColorGreen)
-	highlight := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorAqua)
-	dim := tcell.StyleDefault.Foreground(tcell.ColorGray)
-
-	put := func(x, y int, s string, style tcell.Style) { putText(screen, x, y, s, style) }
+// shopBuy buys one unit of ware idx from the city shop's shelves, less
+// discountPct percent. The gold paid adds to the player's reputation.
+func (s *Server) shopBuy(sess *Session, idx, discountPct int) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	put(0, 0, fmt.Sprintf("🛍️ YEVA'S PROVISIONS  [You have %d💰]", gold), yellow)
-	hints := "[j/k] Move  [a-h] Buy  [Enter] Buy selected  [Esc] Close"
-	if len([]rune(hints)) < sw {
-		put(sw-len([]rune(hints)), 0, hints, dim)
+	if idx < 0 || idx >= len(s.shop) {
+		return "Nothing selected."
 	}
-	for x := range sw {
-		screen.SetContent(x, 1, '─', nil, gray)
+	entry := s.shop[idx]
+	entry.Price = game.Discount(entry.Price, discountPct)
+	if entry.Limited && entry.Stock == 0 {
+		return fmt.Sprintf("%s %s is sold out. Check back after the next restock.", entry.Glyph, entry.Name)
 	}
-	put(0, 2, "  #  Item                          Price", white)
-	for x := range sw {
-		screen.SetContent(x, 3, '─', nil, gray)
+	floor, ok := s.floors[sess.FloorNum]
+	if !ok {
+		return "Cannot buy here."
 	}
-
-	for i, item := range items {
-		row := 4 + i
-		sel := cursor == i
-		style := white
-		pfx := "  "
-		if sel {
-			style = highlight
-			pfx = "► "
-		}
-		tag := "consumable"
-		if !item.IsConsumable {
-			tag = "equip"
-			if item.BonusATK != 0 {
-				tag += fmt.Sprintf(" ATK%+d", item.BonusATK)
-			}
-			if item.BonusDEF != 0 {
-				tag += fmt.Sprintf(" DEF%+d", item.BonusDEF)
-			}
-			if item.BonusMaxHP != 0 {
-				tag += fmt.Sprintf(" HP%+d", item.BonusMaxHP)
-			}
+	msg, bought := s.buyLocked(floor, sess, entry)
+	if bought {
+		sess.Reputation += entry.Price
+		if entry.Limited {
+			s.shop[idx].Stock--
 		}
-		line := fmt.Sprintf("%s[%c] %s %-20s  %3d💰  [%s]",
-			pfx, 'a'+rune(i), item.Glyph, item.Name, item.Price, tag)
-		put(0, row, line, style)
 	}
+	return msg
+}
 
-	for x := range sw {
-		screen.SetContent(x, 4+len(items), '─', nil, gray)
+// buyLocked charges sess for entry and puts the item in their backpack.
+// Reports whether the purchase went through. Caller must hold s.mu.
+func (s *Server) buyLocked(floor *Floor, sess *Session, entry assets.ShopEntry) (string, bool) {
+	if sess.Gold < entry.Price {
+		return fmt.Sprintf("Not enough gold. (%d💰 needed, you have %d💰)", entry.Price, sess.Gold), false
 	}
-	if statusMsg != "" {
-		put(0, 5+len(items), statusMsg, green)
+	invComp := floor.World.Get(sess.PlayerID, component.CInventory)
+	if invComp == nil {
+		return "Cannot buy here.", false
 	}
-	screen.Show()
+	inv := invComp.(component.Inventory)
+	if !inv.Stow(factory.ShopItem(entry)) {
+		return "Backpack full! Drop something first.", false
+	}
+	floor.World.Add(sess.PlayerID, inv)
+	sess.Gold -= entry.Price
+	return fmt.Sprintf("Bought %s %s. (%d💰 remaining)", entry.Glyph, entry.Name, sess.Gold), true
 }
@@ -0,0 +1,63 @@
+package mud
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/game"
+	"strings"
+	"testing"
+)
+
+func TestShopShelvesRotateAndRestock(t *testing.T) {
+	srv := newTestServer()
+	if len(srv.shop) != min(ShopWares, len(assets.ShopCatalogue)) {
+		t.Fatalf("shop holds %d wares; want %d", len(srv.shop), ShopWares)
+	}
+	for _, e := range srv.shop {
+		want := ShopEquipmentStock
+		if e.IsConsumable {
+			want = ShopConsumableStock
+		}
+		if !e.Limited || e.Stock != want {
+			t.Errorf("%s stocked %d (limited %v); want %d", e.Name, e.Stock, e.Limited, want)
+		}
+	}
+
+	srv.shop[0].Stock = 0
+	srv.GameTick = ShopRestockTicks - 1
+	srv.tick()
+	if srv.shop[0].Stock == 0 {
+		t.Error("a restock should refill sold-out wares")
+	}
+}
+
+func TestShopBuySellsOut(t *testing.T) {
+	srv, sess := makeTestSessionOnCity(t)
+	sess.Gold = 1000
+	srv.shop[0].Stock = 1
+
+	srv.shopBuy(sess, 0, 0)
+	if srv.shop[0].Stock != 0 {
+		t.Fatalf("stock after buying the last unit = %d; want 0", srv.shop[0].Stock)
+	}
+	gold := sess.Gold
+	if msg := srv.shopBuy(sess, 0, 0); !strings.Contains(msg, "sold out") || sess.Gold != gold {
+		t.Errorf("buying a sold-out ware: %q, spent %d gold; want a sold-out refusal", msg, gold-sess.Gold)
+	}
+}
+
+func TestShopSpendingEarnsDiscount(t *testing.T) {
+	srv, sess := makeTestSessionOnCity(t)
+	sess.Gold = 1000
+	price := srv.shop[0].Price
+
+	srv.shopBuy(sess, 0, 0)
+	if sess.Reputation != price {
+		t.Fatalf("reputation after spending %d gold = %d", price, sess.Reputation)
+	}
+
+	gold := sess.Gold
+	srv.shopBuy(sess, 0, game.MaxDiscountPct)
+	if paid, want := gold-sess.Gold, game.Discount(price, game.MaxDiscountPct); paid != want {
+		t.Errorf("paid %d with a %d%% discount; want %d", paid, game.MaxDiscountPct, want)
+	}
+}
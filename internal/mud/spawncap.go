@@ -0,0 +1,41 @@
+package mud
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+// Enemy cap tuning; see Server.EnemyCap.
+const (
+	DefaultEnemyCap = 12 // live enemies allowed per 1000 map tiles
+	minEnemyCap     = 8  // fewest enemies any dungeon floor allows
+)
+
+// enemyCap returns how many live enemies floor may hold at once: EnemyCap
+// per 1000 tiles of its map, so the big deep floors allow more than the
+// cramped early ones.
+func (s *Server) enemyCap(floor *Floor) int {
+	perK := s.EnemyCap
+	if perK <= 0 {
+		perK = DefaultEnemyCap
+	}
+	return max(floor.GMap.Width*floor.GMap.Height*perK/1000, minEnemyCap)
+}
+
+// liveEnemies counts the AI-driven entities in w that fight players, leaving
+// out allies.
+func liveEnemies(w *ecs.World) int {
+	n := 0
+	for _, id := range w.Query(component.CAI) {
+		if w.Get(id, component.CAI).(component.AI).Behavior != component.BehaviorAlly {
+			n++
+		}
+	}
+	return n
+}
+
+// enemyRoom returns how many more enemies floor may spawn before it reaches
+// its cap.
+func (s *Server) enemyRoom(floor *Floor) int {
+	return max(s.enemyCap(floor)-liveEnemies(floor.World), 0)
+}
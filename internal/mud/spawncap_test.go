@@ -0,0 +1,184 @@
+package mud
+
+import (
+	"testing"
+
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/factory"
+	"emoji-roguelike/internal/gamemap"
+	"emoji-roguelike/internal/system"
+)
+
+// crowdedFloorLocked puts sess on dungeon floor 1 and clears its enemies,
+// returning the floor. Caller must hold s.mu.
+func crowdedFloorLocked(srv *Server, sess *Session) *Floor {
+	srv.transitionFloorLocked(sess, 1)
+	floor := srv.floors[1]
+	for _, id := range floor.World.Query(component.CAI) {
+		floor.World.DestroyEntity(id)
+	}
+	return floor
+}
+
+// summonsAt returns n minion calls made by summoner around the floor spawn.
+func summonsAt(floor *Floor, summoner ecs.EntityID, n int) []system.Summon {
+	out := make([]system.Summon, n)
+	for i := range out {
+		out[i] = system.Summon{Summoner: summoner, SummonerGlyph: "🔮", Minion: assets.GlyphGlimmerMite,
+			Pos: component.Position{X: floor.SpawnX, Y: floor.SpawnY}}
+	}
+	return out
+}
+
+func TestEnemyCapScalesWithFloorSize(t *testing.T) {
+	srv := newTestServer()
+	small := &Floor{GMap: &gamemap.GameMap{Width: 30, Height: 20}}
+	big := &Floor{GMap: &gamemap.GameMap{Width: 90, Height: 50}}
+	if got := srv.enemyCap(small); got != minEnemyCap {
+		t.Errorf("small floor cap = %d; want the %d minimum", got, minEnemyCap)
+	}
+	if got := srv.enemyCap(big); got != 90*50*DefaultEnemyCap/1000 {
+		t.Errorf("big floor cap = %d; want %d", got, 90*50*DefaultEnemyCap/1000)
+	}
+	srv.EnemyCap = 2 * DefaultEnemyCap
+	if got := srv.enemyCap(big); got != 90*50*2*DefaultEnemyCap/1000 {
+		t.Errorf("big floor cap with EnemyCap doubled = %d; want %d", got, 90*50*2*DefaultEnemyCap/1000)
+	}
+}
+
+func TestSummonsStopAtEnemyCap(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	floor := crowdedFloorLocked(srv, sess)
+
+	limit := srv.enemyCap(floor)
+	summoner := factory.NewEnemy(floor.World, assets.EnemyTable(1)[0], floor.SpawnX, floor.SpawnY, nil)
+	srv.resolveSummonsLocked(floor, summonsAt(floor, summoner, limit+5))
+	if got := liveEnemies(floor.World); got != limit {
+		t.Errorf("%d enemies after a flood of summons; want the cap of %d", got, limit)
+	}
+}
+
+func TestSplitAtEnemyCapKeepsHP(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	floor := crowdedFloorLocked(srv, sess)
+
+	parent := factory.NewEnemy(floor.World, assets.EnemyTable(1)[0], floor.SpawnX, floor.SpawnY, nil)
+	floor.World.Add(parent, component.Splitter{MaxGen: 2})
+	floor.World.Add(parent, component.Health{Current: 5, Max: 10})
+	srv.resolveSummonsLocked(floor, summonsAt(floor, parent, srv.enemyCap(floor)))
+
+	srv.resolveSplitsLocked(floor, []system.Split{{Parent: parent, Glyph: "🫧",
+		Pos: component.Position{X: floor.SpawnX, Y: floor.SpawnY}, HP: 5, MaxHP: 5, Gen: 1}})
+	if got := liveEnemies(floor.World); got != srv.enemyCap(floor) {
+		t.Errorf("%d enemies after a capped split; want %d", got, srv.enemyCap(floor))
+	}
+	if hp := floor.World.Get(parent, component.CHealth).(component.Health); hp.Current != 10 {
+		t.Errorf("splitter HP = %d; want the 10 it would have shed back", hp.Current)
+	}
+}
+
+func TestRespawnFillsOnlyToEnemyCap(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	floor := crowdedFloorLocked(srv, sess)
+
+	limit := srv.enemyCap(floor)
+	summoner := factory.NewEnemy(floor.World, assets.EnemyTable(1)[0], floor.SpawnX, floor.SpawnY, nil)
+	srv.resolveSummonsLocked(floor, summonsAt(floor, summoner, limit-2))
+	srv.respawnEnemiesLocked(floor)
+	if got := liveEnemies(floor.World); got > limit {
+		t.Errorf("%d enemies after a respawn wave; want at most %d", got, limit)
+	}
+}
+
+// BenchmarkTickCrowdedFloor ticks a floor whose summoners have tried to fill
+// it far past its cap, with several players fighting there.
+func BenchmarkTickCrowdedFloor(b *testing.B) {
+	srv := newTestServer()
+	var sessions []*Session
+	for i := range 4 {
+		sess := newTestSession(i, srv)
+		srv.AddSession(sess)
+		sessions = append(sessions, sess)
+	}
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	for _, sess := range sessions {
+		srv.transitionFloorLocked(sess, 1)
+	}
+	floor := srv.floors[1]
+	summoner := factory.NewEnemy(floor.World, assets.EnemyTable(1)[0], floor.SpawnX, floor.SpawnY, nil)
+	srv.resolveSummonsLocked(floor, summonsAt(floor, summoner, 1000))
+
+	for b.Loop() {
+		for _, sess := range sessions {
+			if hc := floor.World.Get(sess.PlayerID, component.CHealth); hc != nil {
+				hp := hc.(component.Health)
+				hp.Current = hp.Max
+				floor.World.Add(sess.PlayerID, hp)
+			}
+		}
+		srv.tickFloorLocked(floor)
+	}
+	if n := liveEnemies(floor.World); n > srv.enemyCap(floor) {
+		b.Fatalf("%d enemies on a floor capped at %d", n, srv.enemyCap(floor))
+	}
+}
+
+func TestRespawnAvoidsPlayersRoom(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	floor := crowdedFloorLocked(srv, sess)
+	if len(floor.GMap.Rooms) < 3 {
+		t.Skip("floor has too few rooms to leave one safe")
+	}
+
+	room := floor.GMap.Rooms[1]
+	px, py := room.Center()
+	floor.World.Add(sess.PlayerID, component.Position{X: px, Y: py})
+	for range 20 {
+		srv.respawnEnemiesLocked(floor)
+		for _, id := range floor.World.Query(component.CAI) {
+			p := floor.World.Get(id, component.CPosition).(component.Position)
+			if p.X >= room.X1-1 && p.X <= room.X2+1 && p.Y >= room.Y1-1 && p.Y <= room.Y2+1 {
+				t.Fatalf("enemy respawned at (%d,%d), in or beside the player's room %v", p.X, p.Y, room)
+			}
+			floor.World.DestroyEntity(id)
+		}
+	}
+}
+
+func TestRespawnWaitsWhenEveryRoomIsWatched(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(0, srv)
+	srv.AddSession(sess)
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	floor := crowdedFloorLocked(srv, sess)
+
+	rooms := floor.GMap.Rooms
+	if got := srv.unwatchedRoomsLocked(floor, rooms); len(got) != len(rooms)-1 {
+		t.Errorf("%d unwatched rooms with the player in the first; want %d", len(got), len(rooms)-1)
+	}
+	floor.GMap.Rooms = rooms[:1]
+	srv.respawnEnemiesLocked(floor)
+	if n := liveEnemies(floor.World); n != 0 {
+		t.Errorf("%d enemies spawned into the only room, where the player stands; want none", n)
+	}
+}
@@ -0,0 +1,104 @@
+package mud
+
+import (
+	"emoji-roguelike/assets"
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// noticeBoardName is the city square furniture that opens the who list when
+// bumped.
+const noticeBoardName = "Notice Board"
+
+// whoLinesLocked lists every connected player for viewer: name, class, level
+// and floor. Players who hide their floor show it only to themselves.
+// Caller must hold s.mu.
+func (s *Server) whoLinesLocked(viewer *Session) []string {
+	lines := make([]string, 0, len(s.sessions))
+	for _, sess := range s.sessions {
+		where := assets.FloorName(sess.FloorNum)
+		switch {
+		case sess.HideFloor && sess != viewer:
+			where = "somewhere unknown"
+		case sess.HideFloor:
+			where += " (hidden)"
+		}
+		lines = append(lines, fmt.Sprintf("%s %-16s Lv.%-2d %-18s %s",
+			sess.Class.Emoji, sess.Name, sess.Level, sess.Class.Name, where))
+	}
+	return lines
+}
+
+// renderWhoList shows the who list until the player presses a key other than
+// p, which toggles whether their own floor is shown. The list is refreshed on
+// every server tick so arrivals and descents appear as they happen.
+func (s *Server) renderWhoList(sess *Session, eventCh <-chan tcell.Event) {
+	for {
+		s.lockSession(sess)
+		lines := s.whoLinesLocked(sess)
+		hidden := sess.HideFloor
+		s.unlockSession(sess)
+		drawWhoList(sess.Screen, lines, hidden)
+
+		select {
+		case <-sess.RenderCh:
+		case ev, ok := <-eventCh:
+			if !ok {
+				return
+			}
+			switch ev := ev.(type) {
+			case *tcell.EventResize:
+				sess.Screen.Sync()
+			case *tcell.EventKey:
+				if ev.Rune() != 'p' && ev.Rune() != 'P' {
+					return
+				}
+				s.lockSession(sess)
+				sess.HideFloor = !sess.HideFloor
+				s.unlockSession(sess)
+			}
+		}
+	}
+}
+
+// drawWhoList renders the who list box.
+func drawWhoList(screen tcell.Screen, lines []string, hidden bool) {
+	title := fmt.Sprintf(" 📋 Adventurers online: %d ", len(lines))
+	keys := " [p] Show my floor   [any key] Close "
+	if !hidden {
+		keys = " [p] Hide my floor   [any key] Close "
+	}
+	width := max(len([]rune(title)), len([]rune(keys)))
+	for _, line := range lines {
+		width = max(width, len([]rune(line))+1)
+	}
+	width += 4
+	hdrStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true)
+	bodyStyle := tcell.StyleDefault.Foreground(tcell.ColorSilver)
+	borderStyle := tcell.StyleDefault.Foreground(tcell.ColorGray)
+
+	screen.Clear()
+	sw, sh := screen.Size()
+	boxH := len(lines) + 5
+	x0 := max((sw-width)/2, 0)
+	y0 := max((sh-boxH)/2, 0)
+	for col := x0; col < x0+width; col++ {
+		screen.SetContent(col, y0, '─', nil, borderStyle)
+		screen.SetContent(col, y0+boxH-1, '─', nil, borderStyle)
+	}
+	for row := y0; row < y0+boxH; row++ {
+		screen.SetContent(x0, row, '│', nil, borderStyle)
+		screen.SetContent(x0+width-1, row, '│', nil, borderStyle)
+	}
+	screen.SetContent(x0, y0, '┌', nil, borderStyle)
+	screen.SetContent(x0+width-1, y0, '┐', nil, borderStyle)
+	screen.SetContent(x0, y0+boxH-1, '└', nil, borderStyle)
+	screen.SetContent(x0+width-1, y0+boxH-1, '┘', nil, borderStyle)
+	putText(screen, x0+2, y0+1, title, hdrStyle)
+	for i, line := range lines {
+		putText(screen, x0+2, y0+3+i, line, bodyStyle)
+	}
+	putText(screen, x0+2, y0+boxH-2, keys, bodyStyle)
+	screen.Show()
+}
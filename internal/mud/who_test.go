@@ -0,0 +1,53 @@
+package mud
+
+import (
+	"strings"
+	"testing"
+
+	"emoji-roguelike/internal/component"
+)
+
+func TestWhoListHidesPrivateFloors(t *testing.T) {
+	srv := newTestServer()
+	alice := newTestSession(1, srv)
+	alice.Name = "Alice"
+	bob := newTestSession(2, srv)
+	bob.Name = "Bob"
+	srv.AddSession(alice)
+	srv.AddSession(bob)
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transitionFloorLocked(bob, 1)
+	bob.HideFloor = true
+
+	lines := srv.whoLinesLocked(alice)
+	if len(lines) != 2 {
+		t.Fatalf("who list has %d lines; want 2", len(lines))
+	}
+	if !strings.Contains(lines[0], "Alice") || !strings.Contains(lines[0], "Emberveil") {
+		t.Errorf("Alice's line = %q; want her name and Emberveil", lines[0])
+	}
+	if !strings.Contains(lines[1], "Bob") || !strings.Contains(lines[1], "somewhere unknown") {
+		t.Errorf("Bob's line for Alice = %q; want his floor hidden", lines[1])
+	}
+	if own := srv.whoLinesLocked(bob)[1]; !strings.Contains(own, "(hidden)") || strings.Contains(own, "unknown") {
+		t.Errorf("Bob's own line = %q; want his floor marked hidden", own)
+	}
+}
+
+func TestNoticeBoardOpensWhoList(t *testing.T) {
+	srv := newTestServer()
+	sess := newTestSession(1, srv)
+	srv.AddSession(sess)
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	floor := srv.floors[0]
+	for _, id := range floor.World.Query(component.CFurniture) {
+		if floor.World.Get(id, component.CFurniture).(component.Furniture).Name == noticeBoardName {
+			srv.interactFurnitureLocked(floor, sess, id)
+		}
+	}
+	if !sess.PendingWho {
+		t.Error("bumping the notice board should open the who list")
+	}
+}
@@ -7,6 +7,7 @@ type Camera struct {
 	OffsetY   int
 	ViewWidth int // in terminal columns
 	ViewHeight int // in terminal rows
+	OriginX   int // screen column where the view starts (non-zero in split views)
 }
 
 // NewCamera creates a camera centered on (cx, cy).
@@ -29,10 +30,36 @@ func (c *Camera) WorldToScreen(wx, wy int) (sx, sy int, visible bool) {
 	sx = (wx - c.OffsetX) * 2
 	sy = wy - c.OffsetY
 	visible = sx >= 0 && sx < c.ViewWidth && sy >= 0 && sy < c.ViewHeight
+	sx += c.OriginX
 	return
 }
 
 // ScreenToWorld converts screen (sx, sy) to world coordinates.
 func (c *Camera) ScreenToWorld(sx, sy int) (int, int) {
-	return sx/2 + c.OffsetX, sy + c.OffsetY
+	return (sx-c.OriginX)/2 + c.OffsetX, sy + c.OffsetY
+}
+
+// KeepInView scrolls the camera the least distance that keeps world position
+// (cx, cy) at least margin tiles inside every edge of the view. The margin
+// shrinks on views too small to honour it.
+func (c *Camera) KeepInView(cx, cy, margin int) {
+	tilesW := c.ViewWidth / 2
+	mx := min(margin, (tilesW-1)/2)
+	my := min(margin, (c.ViewHeight-1)/2)
+	if cx < c.OffsetX+mx {
+		c.OffsetX = cx - mx
+	} else if cx > c.OffsetX+tilesW-1-mx {
+		c.OffsetX = cx - (tilesW - 1 - mx)
+	}
+	if cy < c.OffsetY+my {
+		c.OffsetY = cy - my
+	} else if cy > c.OffsetY+c.ViewHeight-1-my {
+		c.OffsetY = cy - (c.ViewHeight - 1 - my)
+	}
+}
+
+// Pan shifts the view by (dx, dy) world tiles.
+func (c *Camera) Pan(dx, dy int) {
+	c.OffsetX += dx
+	c.OffsetY += dy
 }
@@ -4,7 +4,10 @@ import (
 	"emoji-roguelike/assets"
 	"emoji-roguelike/internal/component"
 	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/mattn/go-runewidth"
@@ -12,20 +15,39 @@ import (
 
 // DrawHUD renders the status bar and message log at the bottom of the screen.
 // bonusATK and bonusDEF are the combined effect+equipment bonus values computed by game.go.
-// abilityName is the class active ability name; abilityCooldown is turns remaining (0 = ready).
+// abilityName is the class active ability name; abilityCooldown is turns remaining (0 = ready)
+// and abilityMaxCooldown is the effective full cooldown after reductions.
+// abilityCharges/abilityMaxCharges are shown as "2/3" for multi-charge abilities.
 // level is the player's current level; pendingLevels > 0 shows a LEVEL UP notification.
-func (r *Renderer) DrawHUD(w *ecs.World, playerID ecs.EntityID, floor int, className string, messages []string, bonusATK, bonusDEF int, abilityName string, abilityCooldown int, level, pendingLevels int) {
+// Context hints for the player's tile and surroundings are drawn on the separator line.
+func (r *TcellRenderer) DrawHUD(w *ecs.World, gmap *gamemap.GameMap, playerID ecs.EntityID, floor int, className string, messages []string, bonusATK, bonusDEF int, abilityName string, abilityCooldown, abilityMaxCooldown, abilityCharges, abilityMaxCharges int, level, pendingLevels int) {
 	_, screenH := r.screen.Size()
 	hudY := screenH - 5
 
-	// Separator line.
+	// Separator line, with context hints right-aligned on it.
 	r.drawHLine(hudY, tcell.ColorGray)
+	x := 1
+	if r.threatNote != "" {
+		note := " " + r.threatNote + " "
+		r.drawText(x, hudY, note, tcell.StyleDefault.Foreground(r.threatColor).Bold(true))
+		x += runewidth.StringWidth(note) + 1
+	}
+	if text := r.hotbarText(); text != "" {
+		r.drawText(x, hudY, text, tcell.StyleDefault.Foreground(tcell.ColorAqua))
+	}
+	if hints := contextHints(w, gmap, playerID, abilityName != "" && abilityCharges > 0); len(hints) > 0 {
+		text := " " + strings.Join(hints, "  ") + " "
+		screenW, _ := r.screen.Size()
+		r.drawText(max(screenW-runewidth.StringWidth(text)-1, 0), hudY, text, tcell.StyleDefault.Foreground(tcell.ColorLightGreen))
+	}
 
 	// Row 1: Class Lv.N HP ATK DEF Floor [LEVEL UP!]
 	hpText := "HP: ?"
+	low := false
 	if c := w.Get(playerID, component.CHealth); c != nil {
 		hp := c.(component.Health)
 		hpText = fmt.Sprintf("HP: %d/%d", hp.Current, hp.Max)
+		low = hp.Low()
 	}
 
 	atkText := ""
@@ -54,8 +76,19 @@ func (r *Renderer) DrawHUD(w *ecs.World, playerID ecs.EntityID, floor int, class
 	} else {
 		floorText = fmt.Sprintf("  Floor:%d %s", df, name)
 	}
-	statusLine := classText + lvText + hpText + atkText + floorText
+	if gmap != nil && gmap.Affix != gamemap.AffixNone {
+		floorText += " [" + gmap.Affix.Name() + "]"
+	}
+	turnText := ""
+	if r.turn > 0 {
+		turnText = fmt.Sprintf("  Turn:%d", r.turn)
+	}
+	statusLine := classText + lvText + hpText + atkText + turnText + floorText
 	r.drawText(0, hudY+1, statusLine, tcell.StyleDefault.Foreground(tcell.ColorWhite))
+	// Low HP pulses the readout red; it returns to white once HP recovers.
+	if low {
+		r.drawText(len([]rune(classText+lvText)), hudY+1, hpText, lowHPStyle(time.Now()))
+	}
 
 	// Append LEVEL UP! notification in bright green.
 	if pendingLevels > 0 {
@@ -70,30 +103,35 @@ func (r *Renderer) DrawHUD(w *ecs.World, playerID ecs.EntityID, floor int, class
 	}
 	headG := "--"
 	if !inv.Head.IsEmpty() {
-		headG = inv.Head.Glyph
+		headG = r.itemGlyph(inv.Head.Glyph)
 	}
 	bodyG := "--"
 	if !inv.Body.IsEmpty() {
-		bodyG = inv.Body.Glyph
+		bodyG = r.itemGlyph(inv.Body.Glyph)
 	}
 	feetG := "--"
 	if !inv.Feet.IsEmpty() {
-		feetG = inv.Feet.Glyph
+		feetG = r.itemGlyph(inv.Feet.Glyph)
 	}
 	weapG := "--"
 	if !inv.MainHand.IsEmpty() {
-		weapG = inv.MainHand.Glyph
+		weapG = r.itemGlyph(inv.MainHand.Glyph)
 	}
 	offG := "--"
 	if !inv.OffHand.IsEmpty() {
-		offG = inv.OffHand.Glyph
+		offG = r.itemGlyph(inv.OffHand.Glyph)
 	}
 	abilityStatus := ""
-	if abilityName != "" {
+	if abilityName != "" && abilityMaxCharges > 1 {
+		abilityStatus = fmt.Sprintf("  [z]%s %d/%d", abilityName, abilityCharges, abilityMaxCharges)
+		if abilityCooldown > 0 {
+			abilityStatus += fmt.Sprintf(":%d/%dt", abilityCooldown, abilityMaxCooldown)
+		}
+	} else if abilityName != "" {
 		if abilityCooldown > 0 {
-			abilityStatus = fmt.Sprintf("  [z]%s:%dt", abilityName, abilityCooldown)
+			abilityStatus = fmt.Sprintf("  [z]%s:%d/%dt", abilityName, abilityCooldown, abilityMaxCooldown)
 		} else {
-			abilityStatus = fmt.Sprintf("  [z]%s:RDY", abilityName)
+			abilityStatus = fmt.Sprintf("  [z]%s:RDY(%dt)", abilityName, abilityMaxCooldown)
 		}
 	}
 	levelUpHint := ""
@@ -124,6 +162,83 @@ func (r *Renderer) DrawHUD(w *ecs.World, playerID ecs.EntityID, floor int, class
 	r.screen.Show()
 }
 
+// hotbarText lists the bound hotbar slots for the HUD divider, each as its
+// key, glyph and count, or "" when nothing is bound.
+func (r *TcellRenderer) hotbarText() string {
+	if len(r.hotbar) == 0 {
+		return ""
+	}
+	parts := make([]string, len(r.hotbar))
+	for i, s := range r.hotbar {
+		parts[i] = fmt.Sprintf("[%c]%s%d", s.Key, r.itemGlyph(s.Glyph), s.Count)
+	}
+	return " " + strings.Join(parts, " ") + " "
+}
+
+// itemGlyph returns glyph as the HUD shows it: unchanged, or as its ASCII
+// character in ASCII mode.
+func (r *TcellRenderer) itemGlyph(glyph string) string {
+	if !r.ascii {
+		return glyph
+	}
+	if ch, ok := assets.ASCIIGlyph(glyph); ok {
+		return string(ch)
+	}
+	return string(assets.ASCIIUnknown)
+}
+
+// interactArrows maps a neighbour offset (dx+1, dy+1) to the arrow key hint
+// for bumping it.
+var interactArrows = [3][3]string{
+	{"↖", "←", "↙"},
+	{"↑", "", "↓"},
+	{"↗", "→", "↘"},
+}
+
+// contextHints lists the key hints that apply where the player stands: stairs
+// or an item underfoot, furniture or an NPC to bump, and a ready ability.
+func contextHints(w *ecs.World, gmap *gamemap.GameMap, playerID ecs.EntityID, abilityReady bool) []string {
+	pc := w.Get(playerID, component.CPosition)
+	if pc == nil || gmap == nil {
+		return nil
+	}
+	pos := pc.(component.Position)
+	var hints []string
+	if gmap.InBounds(pos.X, pos.Y) {
+		switch gmap.At(pos.X, pos.Y).Kind {
+		case gamemap.TileStairsDown:
+			hints = append(hints, "[>] Descend")
+		case gamemap.TileStairsUp:
+			hints = append(hints, "[<] Ascend")
+		}
+	}
+	for _, id := range w.Query(component.CItem, component.CPosition) {
+		if w.Get(id, component.CPosition).(component.Position) == pos {
+			hints = append(hints, "[,] Pick up")
+			break
+		}
+	}
+	for _, comp := range []ecs.ComponentType{component.CFurniture, component.CNPC} {
+		arrow := ""
+		for _, id := range w.Query(comp, component.CPosition) {
+			p := w.Get(id, component.CPosition).(component.Position)
+			dx, dy := p.X-pos.X, p.Y-pos.Y
+			if (dx != 0 || dy != 0) && dx >= -1 && dx <= 1 && dy >= -1 && dy <= 1 {
+				arrow = interactArrows[dx+1][dy+1]
+				break
+			}
+		}
+		if arrow != "" {
+			hints = append(hints, "["+arrow+"] Interact")
+			break
+		}
+	}
+	if abilityReady {
+		hints = append(hints, "[z] Ability")
+	}
+	return hints
+}
+
 // wrapText breaks text into lines that fit within width terminal columns,
 // correctly accounting for wide characters (emoji) that occupy 2 columns.
 func wrapText(text string, width int) []string {
@@ -150,6 +265,9 @@ func wrapText(text string, width int) []string {
 			col += rw
 			split++
 		}
+		if split == 0 {
+			split = 1 // a rune wider than the line still has to go somewhere
+		}
 		if split == len(runes) {
 			lines = append(lines, string(runes))
 			break
@@ -165,7 +283,7 @@ func wrapText(text string, width int) []string {
 	return lines
 }
 
-func (r *Renderer) drawHLine(y int, color tcell.Color) {
+func (r *TcellRenderer) drawHLine(y int, color tcell.Color) {
 	w, _ := r.screen.Size()
 	style := tcell.StyleDefault.Foreground(color)
 	for x := range w {
@@ -173,7 +291,7 @@ func (r *Renderer) drawHLine(y int, color tcell.Color) {
 	}
 }
 
-func (r *Renderer) drawText(x, y int, text string, style tcell.Style) {
+func (r *TcellRenderer) drawText(x, y int, text string, style tcell.Style) {
 	col := x
 	for _, ch := range text {
 		r.screen.SetContent(col, y, ch, nil, style)
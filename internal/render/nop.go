@@ -0,0 +1,35 @@
+package render
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// NopRenderer is a Renderer that draws nothing and keeps no camera. Every
+// world position is off screen.
+type NopRenderer struct{}
+
+func (NopRenderer) SetFloor(int)                                         {}
+func (NopRenderer) SetLineWalls(bool)                                    {}
+func (NopRenderer) SetASCII(bool)                                        {}
+func (NopRenderer) SetEnemyTints(map[ecs.EntityID]tcell.Color)           {}
+func (NopRenderer) SetThreatNote(string, tcell.Color)                    {}
+func (NopRenderer) SetTurn(int)                                          {}
+func (NopRenderer) SetHotbar([]HotbarSlot)                               {}
+func (NopRenderer) CenterOn(int, int)                                    {}
+func (NopRenderer) Mode() CameraMode                                     { return CameraFollow }
+func (NopRenderer) SetMode(CameraMode)                                   {}
+func (NopRenderer) Follow(int, int)                                      {}
+func (NopRenderer) Pan(int, int)                                         {}
+func (NopRenderer) ViewCenter() (x, y int)                               { return 0, 0 }
+func (NopRenderer) WorldToScreen(int, int) (sx, sy int, visible bool)    { return 0, 0, false }
+func (NopRenderer) NoteDamage(int, int)                                  {}
+func (NopRenderer) NoteStrike(ecs.EntityID)                              {}
+func (NopRenderer) DrawFrame(*ecs.World, *gamemap.GameMap, ecs.EntityID) {}
+func (NopRenderer) DrawHUD(*ecs.World, *gamemap.GameMap, ecs.EntityID, int, string, []string, int, int, string, int, int, int, int, int, int) {
+}
+func (NopRenderer) DrawSharedFrame(*ecs.World, *gamemap.GameMap, []component.Position)        {}
+func (NopRenderer) DrawSharedHUD(*ecs.World, int, gamemap.Affix, []SharedHUDPlayer, []string) {}
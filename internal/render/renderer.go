@@ -1,55 +1,285 @@
 package render
 
 import (
+	"emoji-roguelike/assets"
 	"emoji-roguelike/internal/component"
 	"emoji-roguelike/internal/ecs"
 	"emoji-roguelike/internal/gamemap"
 	"sort"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/mattn/go-runewidth"
 )
 
-// Renderer draws the game world onto a tcell screen.
-type Renderer struct {
-	screen  tcell.Screen
-	camera  *Camera
-	floor   int // 1-indexed floor number for color selection
+// Renderer draws the game world and HUD. TcellRenderer is the real one;
+// NopRenderer draws nothing, so a headless game loop can run without a
+// screen to draw on.
+type Renderer interface {
+	SetFloor(floor int)
+	SetLineWalls(on bool)
+	SetASCII(on bool)
+	SetEnemyTints(tints map[ecs.EntityID]tcell.Color)
+	SetThreatNote(note string, color tcell.Color)
+	SetTurn(turn int)
+	SetHotbar(slots []HotbarSlot)
+
+	CenterOn(x, y int)
+	Mode() CameraMode
+	SetMode(mode CameraMode)
+	Follow(x, y int)
+	Pan(dx, dy int)
+	ViewCenter() (x, y int)
+	WorldToScreen(wx, wy int) (sx, sy int, visible bool)
+
+	NoteDamage(damage, maxHP int)
+	NoteStrike(id ecs.EntityID)
+
+	DrawFrame(w *ecs.World, gmap *gamemap.GameMap, playerID ecs.EntityID)
+	DrawHUD(w *ecs.World, gmap *gamemap.GameMap, playerID ecs.EntityID, floor int, className string, messages []string, bonusATK, bonusDEF int, abilityName string, abilityCooldown, abilityMaxCooldown, abilityCharges, abilityMaxCharges int, level, pendingLevels int)
+	DrawSharedFrame(w *ecs.World, gmap *gamemap.GameMap, players []component.Position)
+	DrawSharedHUD(w *ecs.World, floor int, affix gamemap.Affix, players []SharedHUDPlayer, messages []string)
+}
+
+// TcellRenderer draws the game world onto a tcell screen.
+type TcellRenderer struct {
+	screen tcell.Screen
+	camera *Camera
+	floor  int  // 1-indexed floor number for color selection
+	flash  bool // draw the heavy-hit border on the next frame
+	// strikes are the enemies that attacked since the last frame; each is
+	// drawn once in strikeBG. See NoteStrike.
+	strikes map[ecs.EntityID]bool
+	// Camera behaviour; see Follow.
+	mode     CameraMode
+	recenter bool // snap back to the followed position on the next Follow
+	// lineWalls draws walls with box-drawing characters; see wallGlyph.
+	lineWalls bool
+	// ascii draws every tile and entity as one ASCII character; see
+	// assets.ASCIIGlyph.
+	ascii bool
+	// Threat display, set by the game before drawing.
+	tints       map[ecs.EntityID]tcell.Color // background behind each listed entity
+	threatNote  string                       // adjacent-enemy threat shown on the HUD
+	threatColor tcell.Color
+	turn        int          // turns played this run, shown on the HUD; 0 hides it
+	hotbar      []HotbarSlot // bound quick-use slots, shown on the HUD divider
 }
 
-// NewRenderer creates a Renderer for the given screen.
-func NewRenderer(screen tcell.Screen, floor int) *Renderer {
+// HotbarSlot is one bound quick-use slot as the HUD shows it.
+type HotbarSlot struct {
+	Key   rune   // the key that uses it
+	Glyph string // the consumable bound to it
+	Count int    // how many the backpack holds; 0 shows the slot empty
+}
+
+// CameraMode selects how the camera tracks the player.
+type CameraMode uint8
+
+const (
+	// CameraFollow keeps the player centred every frame.
+	CameraFollow CameraMode = iota
+	// CameraLocked holds the view still until the player comes within
+	// EdgeMargin tiles of its edge, reducing screen motion.
+	CameraLocked
+	// CameraFreeLook ignores the player; the view moves only through Pan.
+	CameraFreeLook
+)
+
+// EdgeMargin is how close, in tiles, the player may come to the view edge
+// before a CameraLocked camera scrolls.
+const EdgeMargin = 5
+
+// HeavyHitPercent is the share of max HP a single hit must deal before
+// NoteDamage flashes the screen border.
+const HeavyHitPercent = 20
+
+// hudRows is how many rows at the bottom of the screen the HUD reserves.
+const hudRows = 5
+
+// NewRenderer creates a TcellRenderer for the given screen.
+func NewRenderer(screen tcell.Screen, floor int) Renderer {
 	w, h := screen.Size()
-	// Reserve bottom 5 rows for the HUD.
-	viewH := h - 5
-	return &Renderer{
+	return &TcellRenderer{
 		screen: screen,
-		camera: NewCamera(0, 0, w, viewH),
+		camera: NewCamera(0, 0, w, max(h-hudRows, 0)),
 		floor:  floor,
 	}
 }
 
+// fitScreen resizes the viewport to the screen's current size, keeping the
+// same world position at its centre. Called before every camera move and
+// frame, so a terminal resized mid-game is picked up on the next draw.
+func (r *TcellRenderer) fitScreen() {
+	w, h := r.screen.Size()
+	viewH := max(h-hudRows, 0)
+	if w == r.camera.ViewWidth && viewH == r.camera.ViewHeight {
+		return
+	}
+	cx, cy := r.ViewCenter()
+	r.camera.ViewWidth, r.camera.ViewHeight = w, viewH
+	r.camera.Center(cx, cy)
+}
+
 // SetFloor updates the floor theme index.
-func (r *Renderer) SetFloor(floor int) { r.floor = floor }
+func (r *TcellRenderer) SetFloor(floor int) { r.floor = floor }
+
+// SetLineWalls switches walls between the floor theme's emoji and
+// box-drawing lines joined to their neighbours.
+func (r *TcellRenderer) SetLineWalls(on bool) { r.lineWalls = on }
+
+// SetASCII switches between emoji and single ASCII characters for the map.
+func (r *TcellRenderer) SetASCII(on bool) { r.ascii = on }
+
+// SetEnemyTints sets a background colour drawn behind each listed entity,
+// such as its threat rating. nil clears all tints.
+func (r *TcellRenderer) SetEnemyTints(tints map[ecs.EntityID]tcell.Color) { r.tints = tints }
+
+// SetThreatNote sets the threat summary drawn at the left of the HUD divider.
+// An empty note hides it.
+func (r *TcellRenderer) SetThreatNote(note string, color tcell.Color) {
+	r.threatNote, r.threatColor = note, color
+}
+
+// SetTurn sets the run's turn count shown on the HUD status line. Zero
+// hides it.
+func (r *TcellRenderer) SetTurn(turn int) { r.turn = turn }
+
+// SetHotbar sets the quick-use slots drawn on the HUD divider. nil hides
+// the hotbar.
+func (r *TcellRenderer) SetHotbar(slots []HotbarSlot) { r.hotbar = slots }
 
 // CenterOn recenters the camera on world position (x, y).
-func (r *Renderer) CenterOn(x, y int) { r.camera.Center(x, y) }
+func (r *TcellRenderer) CenterOn(x, y int) {
+	r.fitScreen()
+	r.camera.Center(x, y)
+}
+
+// Mode returns the current camera mode.
+func (r *TcellRenderer) Mode() CameraMode { return r.mode }
+
+// SetMode switches the camera mode. Leaving free-look recentres the view on
+// the next Follow so the player is never left off screen.
+func (r *TcellRenderer) SetMode(mode CameraMode) {
+	if mode == r.mode {
+		return
+	}
+	r.recenter = r.mode == CameraFreeLook
+	r.mode = mode
+}
+
+// Follow moves the camera after the player at world position (x, y) according
+// to the current mode. It does nothing in free-look.
+func (r *TcellRenderer) Follow(x, y int) {
+	r.fitScreen()
+	switch {
+	case r.mode == CameraFreeLook:
+	case r.mode == CameraFollow || r.recenter:
+		r.camera.Center(x, y)
+		r.recenter = false
+	default:
+		r.camera.KeepInView(x, y, EdgeMargin)
+	}
+}
+
+// Pan shifts the view by (dx, dy) world tiles. Meant for free-look; in the
+// other modes the next Follow moves the camera back.
+func (r *TcellRenderer) Pan(dx, dy int) { r.camera.Pan(dx, dy) }
+
+// ViewCenter returns the world position at the middle of the view.
+func (r *TcellRenderer) ViewCenter() (x, y int) {
+	return r.camera.OffsetX + (r.camera.ViewWidth/2)/2, r.camera.OffsetY + r.camera.ViewHeight/2
+}
 
 // WorldToScreen converts world coordinates to screen coordinates.
 // visible is false when the position falls outside the viewport.
-func (r *Renderer) WorldToScreen(wx, wy int) (sx, sy int, visible bool) {
+func (r *TcellRenderer) WorldToScreen(wx, wy int) (sx, sy int, visible bool) {
 	return r.camera.WorldToScreen(wx, wy)
 }
 
+// NoteDamage arms a one-frame red border flash when damage is at least
+// HeavyHitPercent of maxHP. The next DrawFrame draws and clears it.
+func (r *TcellRenderer) NoteDamage(damage, maxHP int) {
+	if maxHP > 0 && damage*100 >= maxHP*HeavyHitPercent {
+		r.flash = true
+	}
+}
+
+// NoteStrike marks id as having just attacked. The next frame draws it in
+// strike colours to telegraph the blow, then forgets it.
+func (r *TcellRenderer) NoteStrike(id ecs.EntityID) {
+	if r.strikes == nil {
+		r.strikes = make(map[ecs.EntityID]bool)
+	}
+	r.strikes[id] = true
+}
+
+// strikeBG is the background behind an enemy on the frame after it attacks.
+const strikeBG = tcell.ColorRed
+
+// Low-HP warning: while the player's health is low (see component.Health.Low)
+// the HP readout pulses between the two lowHPColors, switching every
+// LowHPPulse, and the map viewport's edge is tinted lowHPEdge.
+const (
+	LowHPPulse = 500 * time.Millisecond
+	lowHPEdge  = tcell.ColorMaroon
+)
+
+var lowHPColors = [2]tcell.Color{tcell.ColorRed, tcell.ColorDarkRed}
+
+// lowHP reports whether id's health is low.
+func lowHP(w *ecs.World, id ecs.EntityID) bool {
+	hc := w.Get(id, component.CHealth)
+	return hc != nil && hc.(component.Health).Low()
+}
+
+// lowHPStyle is the HP readout style for a low-HP player at time now.
+func lowHPStyle(now time.Time) tcell.Style {
+	phase := now.UnixMilli() / LowHPPulse.Milliseconds() % 2
+	return tcell.StyleDefault.Foreground(lowHPColors[phase]).Bold(true)
+}
+
 // DrawFrame renders tiles, entities, and the HUD.
-func (r *Renderer) DrawFrame(w *ecs.World, gmap *gamemap.GameMap, playerID ecs.EntityID) {
+func (r *TcellRenderer) DrawFrame(w *ecs.World, gmap *gamemap.GameMap, playerID ecs.EntityID) {
+	r.fitScreen()
 	r.screen.Clear()
 	r.drawMap(gmap)
-	r.drawEntities(w, gmap)
+	r.drawSightMemory(w, gmap, playerID)
+	r.drawEntities(w, gmap, playerID)
+	clear(r.strikes)
+	if lowHP(w, playerID) {
+		r.tintEdges(lowHPEdge)
+	}
+	if r.flash {
+		r.tintEdges(tcell.ColorDarkRed)
+		r.flash = false
+	}
+}
+
+// tintEdges sets the background of the edge cells of the map viewport to
+// color, keeping whatever glyphs are already drawn there. The heavy-hit flash
+// and the low-HP warning both use it.
+func (r *TcellRenderer) tintEdges(color tcell.Color) {
+	vw, vh := r.camera.ViewWidth, r.camera.ViewHeight
+	tint := func(x, y int) {
+		mainc, combc, style, _ := r.screen.GetContent(x, y)
+		if mainc == 0 {
+			mainc = ' '
+		}
+		r.screen.SetContent(x, y, mainc, combc, style.Background(color))
+	}
+	for x := 0; x < vw; x++ {
+		tint(x, 0)
+		tint(x, vh-1)
+	}
+	for y := 1; y < vh-1; y++ {
+		tint(0, y)
+		tint(vw-1, y)
+	}
 }
 
 // drawMap renders all visible/explored tiles using per-floor emoji glyphs.
-func (r *Renderer) drawMap(gmap *gamemap.GameMap) {
+func (r *TcellRenderer) drawMap(gmap *gamemap.GameMap) {
 	theme := TileTheme(r.floor)
 	style := tcell.StyleDefault.Background(tcell.ColorBlack)
 
@@ -64,6 +294,15 @@ func (r *Renderer) drawMap(gmap *gamemap.GameMap) {
 				continue
 			}
 
+			if tile.Kind == gamemap.TileWall && r.lineWalls {
+				r.putWall(sx, sy, wallGlyph(gmap, x, y), tile.Visible)
+				continue
+			}
+			if r.ascii {
+				r.putASCII(sx, sy, asciiTile(tile), asciiTileStyle(tile))
+				continue
+			}
+
 			var glyph string
 			if tile.Visible {
 				switch tile.Kind {
@@ -73,10 +312,15 @@ func (r *Renderer) drawMap(gmap *gamemap.GameMap) {
 					glyph = theme.Floor
 				case gamemap.TileDoor:
 					glyph = "🚪"
+					if tile.Locked {
+						glyph = "🔒"
+					}
 				case gamemap.TileStairsDown:
 					glyph = "🔽"
 				case gamemap.TileStairsUp:
 					glyph = "🔼"
+				case gamemap.TileChute:
+					glyph = "🕳️"
 				case gamemap.TileGrass:
 					glyph = "🟩"
 				case gamemap.TileWater:
@@ -91,10 +335,15 @@ func (r *Renderer) drawMap(gmap *gamemap.GameMap) {
 					glyph = theme.DimWall
 				case gamemap.TileDoor:
 					glyph = "🚪"
+					if tile.Locked {
+						glyph = "🔒"
+					}
 				case gamemap.TileStairsDown:
 					glyph = "🔽"
 				case gamemap.TileStairsUp:
 					glyph = "🔼"
+				case gamemap.TileChute:
+					glyph = "🕳️"
 				case gamemap.TileGrass:
 					glyph = "🟩"
 				case gamemap.TileWater:
@@ -103,12 +352,93 @@ func (r *Renderer) drawMap(gmap *gamemap.GameMap) {
 					glyph = theme.DimFloor
 				}
 			}
+			if tile.Sanctuary && tile.Kind == gamemap.TileFloor {
+				glyph = sanctuaryFloor // remembered as well as seen
+			}
 
 			r.putGlyph(sx, sy, glyph, style)
 		}
 	}
 }
 
+// sanctuaryFloor marks the floor of a sanctuary room.
+const sanctuaryFloor = "🟨"
+
+// wallLines maps a bitmask of joined neighbours (N=1, E=2, S=4, W=8) to the
+// box-drawing character for that junction.
+var wallLines = [16]rune{
+	'■', '│', '─', '└', '│', '│', '┌', '├',
+	'─', '┘', '─', '┴', '┐', '┤', '┬', '┼',
+}
+
+// joinsWall reports whether a line wall should reach into (x, y): seen walls
+// and doors do, so lines run through doorways. Unseen tiles never join,
+// keeping the outline from giving away unexplored rock.
+func joinsWall(gmap *gamemap.GameMap, x, y int) bool {
+	if !gmap.InBounds(x, y) {
+		return false
+	}
+	t := gmap.At(x, y)
+	return (t.Visible || t.Explored) && (t.Kind == gamemap.TileWall || t.Kind == gamemap.TileDoor)
+}
+
+// wallGlyph returns the two screen columns for the wall at (x, y): the
+// junction joining its orthogonal neighbours, then a run of line toward the
+// east neighbour when the two connect, so walls stay unbroken across the
+// double-width tile.
+func wallGlyph(gmap *gamemap.GameMap, x, y int) [2]rune {
+	mask := 0
+	for bit, d := range [4][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}} {
+		if joinsWall(gmap, x+d[0], y+d[1]) {
+			mask |= 1 << bit
+		}
+	}
+	fill := ' '
+	if mask&2 != 0 {
+		fill = '─'
+	}
+	return [2]rune{wallLines[mask], fill}
+}
+
+// putWall draws a line wall at screen position (x, y), dimmed when the tile
+// is remembered rather than in view.
+func (r *TcellRenderer) putWall(x, y int, glyph [2]rune, lit bool) {
+	style := tcell.StyleDefault.Background(tcell.ColorBlack).Foreground(tcell.ColorGray)
+	if lit {
+		style = style.Foreground(tcell.ColorSilver)
+	}
+	r.screen.SetContent(x, y, glyph[0], nil, style)
+	r.screen.SetContent(x+1, y, glyph[1], nil, style)
+}
+
+// drawSightMemory draws the enemies and items playerID remembers on explored
+// tiles that are out of sight, dimmed to set them apart from what is in view.
+func (r *TcellRenderer) drawSightMemory(w *ecs.World, gmap *gamemap.GameMap, playerID ecs.EntityID) {
+	mem, ok := w.Get(playerID, component.CSightMemory).(component.SightMemory)
+	if !ok {
+		return
+	}
+	style := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorBlack).Dim(true)
+	for pos, glyph := range mem.Seen {
+		if !gmap.InBounds(pos.X, pos.Y) || gmap.At(pos.X, pos.Y).Visible {
+			continue
+		}
+		sx, sy, onScreen := r.camera.WorldToScreen(pos.X, pos.Y)
+		if !onScreen {
+			continue
+		}
+		if !r.ascii {
+			r.putGlyph(sx, sy, glyph, style)
+			continue
+		}
+		ch, ok := assets.ASCIIGlyph(glyph)
+		if !ok {
+			ch = assets.ASCIIUnknown
+		}
+		r.putASCII(sx, sy, ch, style)
+	}
+}
+
 // renderableEntity holds sorting info for entity rendering.
 type renderableEntity struct {
 	id    ecs.EntityID
@@ -117,8 +447,9 @@ type renderableEntity struct {
 	rend  component.Renderable
 }
 
-// drawEntities renders all entities with Renderable + Position, ordered by RenderOrder.
-func (r *Renderer) drawEntities(w *ecs.World, gmap *gamemap.GameMap) {
+// drawEntities renders all entities with Renderable + Position, ordered by
+// RenderOrder. Ambushing enemies hidden from playerID are left out.
+func (r *TcellRenderer) drawEntities(w *ecs.World, gmap *gamemap.GameMap, playerID ecs.EntityID) {
 	ids := w.Query(component.CRenderable, component.CPosition)
 	entities := make([]renderableEntity, 0, len(ids))
 
@@ -134,11 +465,15 @@ func (r *Renderer) drawEntities(w *ecs.World, gmap *gamemap.GameMap) {
 		if gmap.InBounds(pos.X, pos.Y) && !gmap.At(pos.X, pos.Y).Visible {
 			continue
 		}
+		if lurking(w, playerID, id, pos) {
+			continue
+		}
 		entities = append(entities, renderableEntity{id: id, order: rend.RenderOrder, pos: pos, rend: rend})
 	}
 
-	// Sort ascending by render order (lower = drawn first / behind).
-	sort.Slice(entities, func(i, j int) bool {
+	// Sort ascending by render order (lower = drawn first / behind). The sort
+	// is stable so entities sharing a tile and order always stack the same way.
+	sort.SliceStable(entities, func(i, j int) bool {
 		return entities[i].order < entities[j].order
 	})
 
@@ -148,12 +483,152 @@ func (r *Renderer) drawEntities(w *ecs.World, gmap *gamemap.GameMap) {
 			continue
 		}
 		style := tcell.StyleDefault.Foreground(e.rend.FGColor).Background(tcell.ColorBlack)
-		r.putGlyph(sx, sy, e.rend.Glyph, style)
+		if bg, ok := r.tints[e.id]; ok {
+			style = style.Background(bg)
+		}
+		if empoweredEnemy(w, e.id) {
+			// Buffed enemies glow so players can see why a fight got harder.
+			style = style.Background(empoweredBG).Bold(true)
+		}
+		if r.strikes[e.id] {
+			style = style.Foreground(tcell.ColorWhite).Background(strikeBG).Bold(true)
+		}
+		if r.ascii {
+			r.putASCII(sx, sy, asciiEntity(w, e.id, e.rend.Glyph), style)
+		} else {
+			r.putGlyph(sx, sy, e.rend.Glyph, style)
+		}
 	}
 }
 
+// asciiTile returns the ASCII character for a tile.
+func asciiTile(tile *gamemap.Tile) rune {
+	switch tile.Kind {
+	case gamemap.TileWall:
+		return assets.ASCIIWall
+	case gamemap.TileDoor:
+		return assets.ASCIIDoor
+	case gamemap.TileStairsDown:
+		return assets.ASCIIStairsDown
+	case gamemap.TileStairsUp:
+		return assets.ASCIIStairsUp
+	case gamemap.TileChute:
+		return assets.ASCIIChute
+	case gamemap.TileGrass:
+		return assets.ASCIIGrass
+	case gamemap.TileWater:
+		return assets.ASCIIWater
+	}
+	if tile.Sanctuary {
+		return assets.ASCIISanctuary
+	}
+	return assets.ASCIIFloor
+}
+
+// asciiTileStyle colours an ASCII tile, dimming those remembered but out of
+// view.
+func asciiTileStyle(tile *gamemap.Tile) tcell.Style {
+	style := tcell.StyleDefault.Background(tcell.ColorBlack)
+	if !tile.Visible {
+		return style.Foreground(tcell.ColorDimGray)
+	}
+	if tile.Sanctuary && tile.Kind == gamemap.TileFloor {
+		return style.Foreground(tcell.ColorGold)
+	}
+	switch tile.Kind {
+	case gamemap.TileWall, gamemap.TileStairsDown, gamemap.TileStairsUp:
+		return style.Foreground(tcell.ColorWhite)
+	case gamemap.TileDoor:
+		return style.Foreground(tcell.ColorSandyBrown)
+	case gamemap.TileChute:
+		return style.Foreground(tcell.ColorRed)
+	case gamemap.TileGrass:
+		return style.Foreground(tcell.ColorGreen)
+	case gamemap.TileWater:
+		return style.Foreground(tcell.ColorBlue)
+	}
+	return style.Foreground(tcell.ColorGray)
+}
+
+// asciiEntity returns the ASCII character for entity id drawn as glyph.
+// Glyphs missing from the assets table fall back on what the entity is, so
+// players always show as @ whatever their class emoji.
+func asciiEntity(w *ecs.World, id ecs.EntityID, glyph string) rune {
+	if w.Has(id, component.CTagPlayer) {
+		return assets.ASCIIPlayer
+	}
+	if ch, ok := assets.ASCIIGlyph(glyph); ok {
+		return ch
+	}
+	switch {
+	case w.Has(id, component.CNPC):
+		return assets.ASCIINPC
+	case w.Has(id, component.CFurniture):
+		return assets.ASCIIFurniture
+	}
+	return assets.ASCIIUnknown
+}
+
+// empoweredBG is the background behind an enemy carrying an ATK or DEF buff.
+const empoweredBG = tcell.ColorDarkGoldenrod
+
+// empoweredEnemy reports whether id is a hostile AI entity with an active
+// attack or defense boost.
+func empoweredEnemy(w *ecs.World, id ecs.EntityID) bool {
+	ac := w.Get(id, component.CAI)
+	if ac == nil || ac.(component.AI).Behavior == component.BehaviorAlly {
+		return false
+	}
+	ec := w.Get(id, component.CEffects)
+	if ec == nil {
+		return false
+	}
+	for _, e := range ec.(component.Effects).Active {
+		if e.Kind == component.EffectAttackBoost || e.Kind == component.EffectDefenseBoost {
+			return true
+		}
+	}
+	return false
+}
+
+// lurking reports whether id, at pos, is an ambushing enemy that viewer cannot
+// see yet: it is not adjacent and the viewer lacks true sight. With no viewer
+// (a shared screen) it is shown once any player can see it. This mirrors
+// system.Concealed.
+func lurking(w *ecs.World, viewer, id ecs.EntityID, pos component.Position) bool {
+	ac := w.Get(id, component.CAI)
+	if ac == nil || !ac.(component.AI).Ambush {
+		return false
+	}
+	viewers := []ecs.EntityID{viewer}
+	if viewer == ecs.NilEntity {
+		viewers = w.Query(component.CTagPlayer)
+	}
+	for _, v := range viewers {
+		if ec, ok := w.Get(v, component.CEffects).(component.Effects); ok {
+			for _, e := range ec.Active {
+				if e.Kind == component.EffectTrueSight {
+					return false
+				}
+			}
+		}
+		if vp, ok := w.Get(v, component.CPosition).(component.Position); ok &&
+			vp.X-pos.X >= -1 && vp.X-pos.X <= 1 && vp.Y-pos.Y >= -1 && vp.Y-pos.Y <= 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// putASCII draws ch at screen position (x, y) and blanks the tile's second
+// column.
+func (r *TcellRenderer) putASCII(x, y int, ch rune, style tcell.Style) {
+	r.screen.SetContent(x, y, ch, nil, style)
+	r.screen.SetContent(x+1, y, ' ', nil, style)
+}
+
 // putGlyph draws a single glyph (ASCII or multi-rune emoji) at screen position (x, y).
-func (r *Renderer) putGlyph(x, y int, glyph string, style tcell.Style) {
+func (r *TcellRenderer) putGlyph(x, y int, glyph string, style tcell.Style) {
 	runes := []rune(glyph)
 	if len(runes) == 0 {
 		return
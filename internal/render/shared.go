@@ -0,0 +1,114 @@
+package render
+
+import (
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// DrawSharedFrame renders one view for several players on a single screen.
+// The camera frames the midpoint of all players when they fit in the viewport
+// together; otherwise the view splits into side-by-side halves centered on the
+// first two players. Visibility comes from the map as-is, so run a combined
+// FOV pass (system.UpdateSharedFOV) first.
+func (r *TcellRenderer) DrawSharedFrame(w *ecs.World, gmap *gamemap.GameMap, players []component.Position) {
+	if len(players) == 0 {
+		r.screen.Clear()
+		return
+	}
+	r.fitScreen()
+	minX, maxX := players[0].X, players[0].X
+	minY, maxY := players[0].Y, players[0].Y
+	for _, p := range players[1:] {
+		minX, maxX = min(minX, p.X), max(maxX, p.X)
+		minY, maxY = min(minY, p.Y), max(maxY, p.Y)
+	}
+	r.camera.Center((minX+maxX)/2, (minY+maxY)/2)
+	fits := true
+	for _, p := range players {
+		if _, _, visible := r.camera.WorldToScreen(p.X, p.Y); !visible {
+			fits = false
+		}
+	}
+	if fits || len(players) < 2 {
+		r.DrawFrame(w, gmap, ecs.NilEntity)
+		return
+	}
+
+	// Split view: two half-width cameras separated by a divider column.
+	full := r.camera
+	half := (full.ViewWidth / 2) &^ 1 // keep emoji cells aligned
+	left := NewCamera(players[0].X, players[0].Y, half-2, full.ViewHeight)
+	right := NewCamera(players[1].X, players[1].Y, full.ViewWidth-half, full.ViewHeight)
+	right.OriginX = half
+
+	r.screen.Clear()
+	for _, cam := range []*Camera{left, right} {
+		r.camera = cam
+		r.drawMap(gmap)
+		r.drawEntities(w, gmap, ecs.NilEntity)
+	}
+	r.camera = full
+	divider := tcell.StyleDefault.Foreground(tcell.ColorGray)
+	for y := 0; y < full.ViewHeight; y++ {
+		r.screen.SetContent(half-1, y, '│', nil, divider)
+	}
+	r.flash = false
+	clear(r.strikes)
+}
+
+// SharedHUDPlayer is one player's line in the shared-screen HUD.
+type SharedHUDPlayer struct {
+	ID   ecs.EntityID
+	Name string
+	Gold int
+}
+
+// DrawSharedHUD draws a compact HUD for a shared screen: one status row per
+// player followed by the latest messages. The floor's affix, if any, follows
+// its name.
+func (r *TcellRenderer) DrawSharedHUD(w *ecs.World, floor int, affix gamemap.Affix, players []SharedHUDPlayer, messages []string) {
+	screenW, screenH := r.screen.Size()
+	hudY := screenH - 5
+	r.drawHLine(hudY, tcell.ColorGray)
+
+	row := hudY + 1
+	for i, p := range players {
+		hpText := "HP: ?"
+		low := false
+		if c := w.Get(p.ID, component.CHealth); c != nil {
+			hp := c.(component.Health)
+			hpText = fmt.Sprintf("HP: %d/%d", hp.Current, hp.Max)
+			low = hp.Low()
+		}
+		prefix := fmt.Sprintf("P%d [%s 💰%d]  ", i+1, p.Name, p.Gold)
+		line := prefix + hpText
+		if i == 0 {
+			line += "  " + assets.FloorName(floor)
+			if affix != gamemap.AffixNone {
+				line += " [" + affix.Name() + "]"
+			}
+		}
+		r.drawText(0, row, line, tcell.StyleDefault.Foreground(tcell.ColorWhite))
+		if low {
+			r.drawText(len([]rune(prefix)), row, hpText, lowHPStyle(time.Now()))
+		}
+		row++
+	}
+
+	var lines []string
+	for _, msg := range messages {
+		lines = append(lines, wrapText(msg, screenW)...)
+	}
+	for _, line := range lines[max(len(lines)-(hudY+5-row), 0):] {
+		r.drawText(0, row, line, tcell.StyleDefault.Foreground(tcell.ColorLightYellow))
+		row++
+	}
+
+	r.screen.Show()
+}
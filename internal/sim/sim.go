@@ -0,0 +1,174 @@
+// Package sim plays many headless runs with the game package's bot across
+// classes and seeds, and aggregates the outcomes for balance tuning.
+package sim
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"slices"
+	"strconv"
+	"sync"
+
+	"emoji-roguelike/assets"
+	"emoji-roguelike/internal/game"
+)
+
+// DefaultMaxTurns is the turn budget of one simulated run when Config
+// leaves it unset.
+const DefaultMaxTurns = 5000
+
+// Config selects which runs Run plays: Seeds consecutive seeds from
+// FirstSeed for each of Classes (every class when empty), each cut off
+// after MaxTurns turns, spread over Workers goroutines.
+type Config struct {
+	Classes   []string
+	Seeds     int
+	FirstSeed int64
+	MaxTurns  int
+	Workers   int
+}
+
+// Summary aggregates the runs of one class.
+type Summary struct {
+	Class    string         `json:"class"`
+	Runs     int            `json:"runs"`
+	Wins     int            `json:"wins"`
+	WinRate  float64        `json:"win_rate"`
+	AvgFloor float64        `json:"avg_floor"`
+	AvgTurns float64        `json:"avg_turns"`
+	AvgLevel float64        `json:"avg_level"`
+	Deaths   map[string]int `json:"deaths"` // runs ended by each cause
+}
+
+// Run plays every run cfg selects and returns the results grouped by class,
+// in seed order. An unknown class fails the whole batch.
+func Run(cfg Config) ([]game.SimResult, error) {
+	classes := cfg.Classes
+	if len(classes) == 0 {
+		for _, c := range assets.Classes {
+			classes = append(classes, c.ID)
+		}
+	}
+	turns := cfg.MaxTurns
+	if turns <= 0 {
+		turns = DefaultMaxTurns
+	}
+	workers := max(cfg.Workers, 1)
+
+	results := make([]game.SimResult, len(classes)*max(cfg.Seeds, 0))
+	errs := make([]error, len(results))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				class, seed := classes[i/cfg.Seeds], cfg.FirstSeed+int64(i%cfg.Seeds)
+				results[i], errs[i] = game.Simulate(class, seed, turns)
+			}
+		}()
+	}
+	for i := range results {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// Summarize aggregates results per class, in the order classes first appear.
+func Summarize(results []game.SimResult) []Summary {
+	var out []Summary
+	for _, r := range results {
+		i := slices.IndexFunc(out, func(s Summary) bool { return s.Class == r.Class })
+		if i < 0 {
+			out = append(out, Summary{Class: r.Class, Deaths: map[string]int{}})
+			i = len(out) - 1
+		}
+		s := &out[i]
+		s.Runs++
+		if r.Victory {
+			s.Wins++
+		} else {
+			s.Deaths[r.CauseOfDeath]++
+		}
+		s.AvgFloor += float64(r.Floor)
+		s.AvgTurns += float64(r.Turns)
+		s.AvgLevel += float64(r.Level)
+	}
+	for i := range out {
+		s := &out[i]
+		n := float64(s.Runs)
+		s.WinRate, s.AvgFloor, s.AvgTurns, s.AvgLevel = float64(s.Wins)/n, s.AvgFloor/n, s.AvgTurns/n, s.AvgLevel/n
+	}
+	return out
+}
+
+// TopDeath returns the cause that ended the most of s's runs, or "" if every
+// run was won. Ties go to the cause that sorts first.
+func (s Summary) TopDeath() string {
+	top := ""
+	for cause, n := range s.Deaths {
+		if m := s.Deaths[top]; top == "" || n > m || (n == m && cause < top) {
+			top = cause
+		}
+	}
+	return top
+}
+
+// WriteCSV writes one row per run, after a header row.
+func WriteCSV(w io.Writer, results []game.SimResult) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"class", "seed", "victory", "floor", "turns", "level", "kills", "cause_of_death"})
+	for _, r := range results {
+		_ = cw.Write([]string{
+			r.Class,
+			strconv.FormatInt(r.Seed, 10),
+			strconv.FormatBool(r.Victory),
+			strconv.Itoa(r.Floor),
+			strconv.Itoa(r.Turns),
+			strconv.Itoa(r.Level),
+			strconv.Itoa(r.Kills),
+			r.CauseOfDeath,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteSummaryCSV writes one row per class summary, after a header row.
+func WriteSummaryCSV(w io.Writer, summaries []Summary) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"class", "runs", "wins", "win_rate", "avg_floor", "avg_turns", "avg_level", "top_death"})
+	for _, s := range summaries {
+		_ = cw.Write([]string{
+			s.Class,
+			strconv.Itoa(s.Runs),
+			strconv.Itoa(s.Wins),
+			strconv.FormatFloat(s.WinRate, 'f', 3, 64),
+			strconv.FormatFloat(s.AvgFloor, 'f', 2, 64),
+			strconv.FormatFloat(s.AvgTurns, 'f', 1, 64),
+			strconv.FormatFloat(s.AvgLevel, 'f', 2, 64),
+			s.TopDeath(),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the summaries and every run as one indented JSON object.
+func WriteJSON(w io.Writer, results []game.SimResult) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Summary []Summary        `json:"summary"`
+		Runs    []game.SimResult `json:"runs"`
+	}{Summarize(results), results})
+}
@@ -0,0 +1,83 @@
+package sim
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"emoji-roguelike/internal/game"
+)
+
+func TestRunPlaysEverySeed(t *testing.T) {
+	results, err := Run(Config{Classes: []string{"warden", "arcanist"}, Seeds: 2, FirstSeed: 7, MaxTurns: 150, Workers: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("got %d results; want 4", len(results))
+	}
+	for i, want := range []struct {
+		class string
+		seed  int64
+	}{{"warden", 7}, {"warden", 8}, {"arcanist", 7}, {"arcanist", 8}} {
+		if r := results[i]; r.Class != want.class || r.Seed != want.seed || r.Floor < 1 || r.Turns > 150 {
+			t.Errorf("result %d = %+v; want %s seed %d within 150 turns", i, r, want.class, want.seed)
+		}
+	}
+}
+
+func TestRunRejectsUnknownClass(t *testing.T) {
+	if _, err := Run(Config{Classes: []string{"jester"}, Seeds: 1, MaxTurns: 10}); err == nil {
+		t.Error("Run accepted an unknown class")
+	}
+}
+
+func TestSummarizeAggregatesPerClass(t *testing.T) {
+	sums := Summarize([]game.SimResult{
+		{Class: "warden", Victory: true, Floor: 10, Turns: 900, Level: 40},
+		{Class: "oracle", Floor: 2, Turns: 100, Level: 10, CauseOfDeath: "poison"},
+		{Class: "warden", Floor: 4, Turns: 300, Level: 20, CauseOfDeath: "🧠 Thought Leech"},
+		{Class: "oracle", Floor: 4, Turns: 300, Level: 20, CauseOfDeath: "poison"},
+	})
+	if len(sums) != 2 || sums[0].Class != "warden" || sums[1].Class != "oracle" {
+		t.Fatalf("summaries %+v; want warden then oracle", sums)
+	}
+	w := sums[0]
+	if w.Runs != 2 || w.Wins != 1 || w.WinRate != 0.5 || w.AvgFloor != 7 || w.AvgTurns != 600 || w.AvgLevel != 30 {
+		t.Errorf("warden summary = %+v", w)
+	}
+	if got := sums[1].TopDeath(); got != "poison" || sums[1].Deaths["poison"] != 2 {
+		t.Errorf("oracle top death = %q, deaths %v; want poison twice", got, sums[1].Deaths)
+	}
+	if got := (Summary{Deaths: map[string]int{}}).TopDeath(); got != "" {
+		t.Errorf("TopDeath with no deaths = %q; want empty", got)
+	}
+}
+
+func TestWriteCSVAndJSON(t *testing.T) {
+	results := []game.SimResult{{Class: "warden", Seed: 3, Floor: 5, Turns: 400, Level: 30, Kills: 9, CauseOfDeath: game.SimTimeout}}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, results); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || lines[1] != "warden,3,false,5,400,30,9,timeout" {
+		t.Errorf("CSV = %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteJSON(&buf, results); err != nil {
+		t.Fatal(err)
+	}
+	var out struct {
+		Summary []Summary
+		Runs    []game.SimResult
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Summary) != 1 || out.Summary[0].Deaths[game.SimTimeout] != 1 || len(out.Runs) != 1 || out.Runs[0] != results[0] {
+		t.Errorf("JSON round trip = %+v", out)
+	}
+}
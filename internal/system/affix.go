@@ -0,0 +1,22 @@
+package system
+
+import (
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+	"math/rand"
+)
+
+// Floor affix tuning.
+const (
+	BloodlustDamagePct = 120 // enemy damage on a Bloodlust floor, in percent
+	DarkSightRadius    = 3   // FOV radius cap on a Darkness floor (sight reaches 2 tiles)
+)
+
+// enemyAttack resolves an attack by enemy id on target, hitting harder on a
+// Bloodlust floor.
+func enemyAttack(w *ecs.World, gmap *gamemap.GameMap, rng *rand.Rand, id, target ecs.EntityID) AttackResult {
+	if gmap.Affix == gamemap.AffixBloodlust {
+		return attack(w, rng, id, target, BloodlustDamagePct)
+	}
+	return Attack(w, rng, id, target)
+}
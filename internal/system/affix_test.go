@@ -0,0 +1,43 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+	"math/rand"
+	"testing"
+)
+
+func TestDarknessCapsFOV(t *testing.T) {
+	gmap := openMapFOV(20, 20)
+	gmap.Affix = gamemap.AffixDarkness
+	w := ecs.NewWorld()
+	player := makePlayerAt(w, 10, 10)
+
+	UpdateFOV(w, gmap, player, 8)
+
+	// FOV lights tiles strictly inside the radius.
+	if !gmap.At(10+DarkSightRadius-1, 10).Visible {
+		t.Errorf("tile %d east should still be visible in the dark", DarkSightRadius-1)
+	}
+	if gmap.At(10+DarkSightRadius, 10).Visible {
+		t.Errorf("tile %d east should be hidden by darkness", DarkSightRadius)
+	}
+}
+
+// TestBloodlustEnemiesHitHarder compares one enemy blow on a normal floor and
+// on a Bloodlust floor with the same dice.
+func TestBloodlustEnemiesHitHarder(t *testing.T) {
+	hit := func(affix gamemap.Affix) int {
+		w, gmap, player := newAIWorld(5, 5)
+		gmap.Affix = affix
+		enemy := addEnemy(w, 6, 5, component.BehaviorChase, 8)
+		w.Add(enemy, component.Combat{Attack: 21, Defense: 0})
+		ProcessAI(w, gmap, []ecs.EntityID{player}, rand.New(rand.NewSource(3)))
+		return 30 - w.Get(player, component.CHealth).(component.Health).Current
+	}
+	normal, cursed := hit(gamemap.AffixNone), hit(gamemap.AffixBloodlust)
+	if want := normal * BloodlustDamagePct / 100; cursed != want {
+		t.Errorf("Bloodlust hit for %d; want %d (%d%% of %d)", cursed, want, BloodlustDamagePct, normal)
+	}
+}
@@ -0,0 +1,71 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+// Aggro tuning.
+const (
+	TauntThreat    = 50 // threat a taunt adds, so the taunter holds aggro after it ends
+	HealThreatPct  = 50 // percent of HP healed added as threat on every engaged enemy
+	ThreatDecayPct = 10 // percent of each threat entry shed every enemy turn
+)
+
+// AddThreat raises playerID's threat on enemyID by amount.
+func AddThreat(w *ecs.World, enemyID, playerID ecs.EntityID, amount int) {
+	if amount <= 0 || !w.Has(enemyID, component.CAI) {
+		return
+	}
+	ag := component.Aggro{}
+	if c := w.Get(enemyID, component.CAggro); c != nil {
+		ag = c.(component.Aggro)
+	}
+	if ag.Threat == nil {
+		ag.Threat = make(map[ecs.EntityID]int)
+	}
+	ag.Threat[playerID] += amount
+	w.Add(enemyID, ag)
+}
+
+// HealThreat adds threat for healerID restoring healed HP to every enemy that
+// is already engaged with a player, i.e. has a threat table.
+func HealThreat(w *ecs.World, healerID ecs.EntityID, healed int) {
+	if healed <= 0 {
+		return
+	}
+	amount := max(healed*HealThreatPct/100, 1)
+	for _, id := range w.Query(component.CAggro) {
+		if len(w.Get(id, component.CAggro).(component.Aggro).Threat) > 0 {
+			AddThreat(w, id, healerID, amount)
+		}
+	}
+}
+
+// threatOn returns playerID's threat on enemyID.
+func threatOn(w *ecs.World, enemyID, playerID ecs.EntityID) int {
+	c := w.Get(enemyID, component.CAggro)
+	if c == nil {
+		return 0
+	}
+	return c.(component.Aggro).Threat[playerID]
+}
+
+// decayThreat sheds ThreatDecayPct of each of enemyID's threat entries, at
+// least 1, and forgets players whose threat is spent or who are gone.
+func decayThreat(w *ecs.World, enemyID ecs.EntityID) {
+	c := w.Get(enemyID, component.CAggro)
+	if c == nil {
+		return
+	}
+	ag := c.(component.Aggro)
+	for pid, t := range ag.Threat {
+		t -= max(t*ThreatDecayPct/100, 1)
+		if t <= 0 || !w.Alive(pid) {
+			delete(ag.Threat, pid)
+			continue
+		}
+		ag.Threat[pid] = t
+	}
+	w.Add(enemyID, ag)
+}
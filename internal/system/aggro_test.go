@@ -0,0 +1,100 @@
+package system
+
+import (
+	"math/rand"
+	"testing"
+
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+)
+
+// newAggroWorld puts a near player two tiles west of a chasing enemy and a
+// far player four tiles east of it.
+func newAggroWorld() (*ecs.World, *gamemap.GameMap, ecs.EntityID, ecs.EntityID, ecs.EntityID) {
+	w, gmap, near := newAIWorld(4, 6)
+	far := w.CreateEntity()
+	w.Add(far, component.Position{X: 10, Y: 6})
+	w.Add(far, component.TagPlayer{})
+	w.Add(far, component.TagBlocking{})
+	w.Add(far, component.Combat{Attack: 3, Defense: 1})
+	w.Add(far, component.Health{Current: 30, Max: 30})
+	enemy := addEnemy(w, 6, 6, component.BehaviorChase, 8)
+	return w, gmap, near, far, enemy
+}
+
+func stepAggroAI(w *ecs.World, gmap *gamemap.GameMap, near, far, enemy ecs.EntityID) component.Position {
+	ProcessAI(w, gmap, []ecs.EntityID{near, far}, rand.New(rand.NewSource(1)))
+	return w.Get(enemy, component.CPosition).(component.Position)
+}
+
+func TestAggroDefaultsToNearestPlayer(t *testing.T) {
+	w, gmap, near, far, enemy := newAggroWorld()
+	if pos := stepAggroAI(w, gmap, near, far, enemy); pos.X != 5 {
+		t.Errorf("enemy moved to %v; want toward the nearer player", pos)
+	}
+}
+
+func TestAggroFollowsHighestThreat(t *testing.T) {
+	w, gmap, near, far, enemy := newAggroWorld()
+	AddThreat(w, enemy, near, 5)
+	AddThreat(w, enemy, far, 20)
+	if pos := stepAggroAI(w, gmap, near, far, enemy); pos.X != 7 {
+		t.Errorf("enemy moved to %v; want toward the far player holding more threat", pos)
+	}
+}
+
+func TestPlayerDamageBuildsThreat(t *testing.T) {
+	w, _, near, far, enemy := newAggroWorld()
+	res := Attack(w, rand.New(rand.NewSource(1)), far, enemy)
+	if got := threatOn(w, enemy, far); got != res.Damage || got == 0 {
+		t.Errorf("threat after a %d-damage hit = %d; want the damage dealt", res.Damage, got)
+	}
+	if threatOn(w, enemy, near) != 0 {
+		t.Error("a player who did nothing should have no threat")
+	}
+}
+
+func TestHealingDrawsEngagedEnemies(t *testing.T) {
+	w, gmap, near, far, enemy := newAggroWorld()
+	idle := addEnemy(w, 15, 15, component.BehaviorChase, 8)
+	AddThreat(w, enemy, near, 2)
+
+	HealThreat(w, far, 20)
+	if got := threatOn(w, enemy, far); got != 20*HealThreatPct/100 {
+		t.Errorf("heal threat = %d; want %d", got, 20*HealThreatPct/100)
+	}
+	if w.Has(idle, component.CAggro) {
+		t.Error("healing should not draw an enemy that is not yet engaged")
+	}
+	if pos := stepAggroAI(w, gmap, near, far, enemy); pos.X != 7 {
+		t.Errorf("enemy moved to %v; want toward the healer", pos)
+	}
+}
+
+func TestTauntLeavesLastingThreat(t *testing.T) {
+	w, gmap, near, far, enemy := newAggroWorld()
+	AddThreat(w, enemy, near, 10)
+	TauntEnemies(w, gmap, far, 6, 1)
+	stepAggroAI(w, gmap, near, far, enemy) // the taunt's one turn
+	if pos := stepAggroAI(w, gmap, near, far, enemy); pos.X != 8 {
+		t.Errorf("enemy at %v after the taunt; want it still closing on the taunter", pos)
+	}
+}
+
+func TestThreatDecaysAndIsForgotten(t *testing.T) {
+	w, gmap, near, far, enemy := newAggroWorld()
+	AddThreat(w, enemy, far, 3)
+	for range 3 {
+		decayThreat(w, enemy)
+	}
+	if got := threatOn(w, enemy, far); got != 0 {
+		t.Errorf("threat = %d after decaying; want 0", got)
+	}
+	if n := len(w.Get(enemy, component.CAggro).(component.Aggro).Threat); n != 0 {
+		t.Errorf("threat table holds %d entries; want spent ones forgotten", n)
+	}
+	if pos := stepAggroAI(w, gmap, near, far, enemy); pos.X != 5 {
+		t.Errorf("enemy moved to %v; want back to the nearest player", pos)
+	}
+}
@@ -15,11 +15,18 @@ type EnemyHitResult struct {
 	VictimID       ecs.EntityID // player entity that was hit
 	SpecialApplied uint8
 	DrainedAmount  int
+	DisarmedItem   string
+	WornOut        string // the victim's armor, if the hit broke it
 	Damage         int
+	Parried        bool // the victim's shield turned the attack aside
 }
 
 // ProcessAI runs one turn of AI for all AI-controlled entities and returns
-// the results of any attacks made against the player(s).
+// the results of any attacks made against the player(s). Each enemy goes after
+// the visible player with the most threat on its Aggro table, or the nearest
+// one if none has any; a decoy in sight outranks them all. Decoys then age by
+// a turn. On a map with Infighting on, an enemy with no player in range
+// attacks an adjacent enemy of a rival faction instead.
 func ProcessAI(w *ecs.World, gmap *gamemap.GameMap, playerIDs []ecs.EntityID, rng *rand.Rand) []EnemyHitResult {
 	if len(playerIDs) == 0 {
 		return nil
@@ -28,12 +35,38 @@ func ProcessAI(w *ecs.World, gmap *gamemap.GameMap, playerIDs []ecs.EntityID, rn
 	var hits []EnemyHitResult
 	for _, id := range w.Query(component.CAI, component.CPosition) {
 		aiComp := w.Get(id, component.CAI).(component.AI)
+		if aiComp.Behavior == component.BehaviorAlly {
+			continue // allies are driven by ProcessTurrets
+		}
+		aiComp = tickRout(w, id, aiComp)
+		decayThreat(w, id)
+		if HasEffect(w, id, component.EffectRoot) {
+			continue // snared: cannot move or attack
+		}
 		posComp := w.Get(id, component.CPosition).(component.Position)
 
-		_, targetPos, inRange := nearestPlayer(w, playerIDs, posComp, aiComp.SightRange)
+		targetPos, inRange := tauntTarget(w, id)
+		if inRange {
+			aiComp.SightRange = math.MaxInt32 // taunted enemies pursue regardless of sight
+		} else if leashHome(w, gmap, id, posComp) {
+			continue // strayed too far: heading home instead
+		} else if dpos, ok := decoyTarget(w, gmap, posComp, aiComp.SightRange); ok {
+			targetPos, inRange = dpos, true
+		} else {
+			targetPos, inRange = senseTarget(w, gmap, id, playerIDs, posComp, aiComp)
+		}
 		if !inRange {
+			if gmap.Infighting {
+				infight(w, gmap, id, posComp, rng)
+			}
 			continue
 		}
+		if summonMinion(w, gmap, id, posComp) {
+			continue // spent its turn calling a minion
+		}
+		if echoAbility(w, gmap, id, posComp, targetPos) {
+			continue // spent its turn copying a class ability
+		}
 
 		var attacked bool
 		var res AttackResult
@@ -44,31 +77,188 @@ func ProcessAI(w *ecs.World, gmap *gamemap.GameMap, playerIDs []ecs.EntityID, rn
 			// never moves
 		case component.BehaviorCowardly:
 			attacked, res, glyph, victimID = cowardlyMove(w, gmap, id, posComp, targetPos, aiComp, rng)
+		case component.BehaviorFlee:
+			fleeMove(w, gmap, id, posComp, targetPos)
+		case component.BehaviorSupport:
+			supportMove(w, gmap, id, posComp, targetPos)
 		default:
 			attacked, res, glyph, victimID = chaseMove(w, gmap, id, posComp, targetPos, aiComp, rng)
 		}
+		if pc := w.Get(id, component.CPosition); !attacked && pc != nil && pc.(component.Position) != posComp {
+			springTrap(w, id) // entered a new tile: set off any trap there
+		}
 		if attacked {
-			hits = append(hits, EnemyHitResult{
-				EnemyGlyph:     glyph,
-				AttackerID:     id,
-				VictimID:       victimID,
-				SpecialApplied: res.SpecialApplied,
-				DrainedAmount:  res.DrainedAmount,
-				Damage:         res.Damage,
-			})
+			hits = append(hits, enemyHit(glyph, id, victimID, res))
+			hits = append(hits, followUpAttacks(w, gmap, rng, id, victimID, playerIDs, glyph)...)
 		}
 	}
+	ageDecoys(w)
 	return hits
 }
 
-// nearestPlayer returns the ID and position of the player from playerIDs
-// that is closest to enemyPos and within sightRange.
-// Returns ecs.NilEntity and zero Position if none qualify.
-func nearestPlayer(w *ecs.World, playerIDs []ecs.EntityID,
+// enemyHit records the attack of enemy id, drawn as glyph, on victim.
+func enemyHit(glyph string, id, victim ecs.EntityID, res AttackResult) EnemyHitResult {
+	return EnemyHitResult{
+		EnemyGlyph:     glyph,
+		AttackerID:     id,
+		VictimID:       victim,
+		SpecialApplied: res.SpecialApplied,
+		DrainedAmount:  res.DrainedAmount,
+		DisarmedItem:   res.DisarmedItem,
+		WornOut:        res.WornOut,
+		Damage:         res.Damage,
+		Parried:        res.Parried,
+	}
+}
+
+// followUpAttacks makes the rest of enemy id's attacks this turn after its
+// first swing at victim: the remaining swings of its AttacksPerTurn at
+// victim and, with AreaAttack, as many at each other player in playerIDs
+// next to it. A player stops being hit once dead.
+func followUpAttacks(w *ecs.World, gmap *gamemap.GameMap, rng *rand.Rand, id, victim ecs.EntityID,
+	playerIDs []ecs.EntityID, glyph string) []EnemyHitResult {
+	cc := w.Get(id, component.CCombat)
+	if cc == nil {
+		return nil
+	}
+	cbt := cc.(component.Combat)
+	targets := []ecs.EntityID{victim}
+	if cbt.AreaAttack {
+		targets = append(targets, adjacentPlayers(w, gmap, id, victim, playerIDs)...)
+	}
+	var hits []EnemyHitResult
+	for i, target := range targets {
+		swings := max(cbt.AttacksPerTurn, 1)
+		if i == 0 {
+			swings-- // the first swing at victim has already landed
+		}
+		for range swings {
+			if !w.Has(id, component.CHealth) || !w.Has(target, component.CHealth) {
+				break
+			}
+			hits = append(hits, enemyHit(glyph, id, target, enemyAttack(w, gmap, rng, id, target)))
+		}
+	}
+	return hits
+}
+
+// adjacentPlayers returns the players in playerIDs, other than except, that
+// stand next to enemy id outside a sanctuary. A diagonal neighbour behind a
+// wall corner is out of reach, as it is for TryMove.
+func adjacentPlayers(w *ecs.World, gmap *gamemap.GameMap, id, except ecs.EntityID, playerIDs []ecs.EntityID) []ecs.EntityID {
+	ec := w.Get(id, component.CPosition)
+	if ec == nil {
+		return nil
+	}
+	epos := ec.(component.Position)
+	var out []ecs.EntityID
+	for _, pid := range playerIDs {
+		pc := w.Get(pid, component.CPosition)
+		if pid == except || pid == ecs.NilEntity || pc == nil {
+			continue
+		}
+		pos := pc.(component.Position)
+		if max(abs(pos.X-epos.X), abs(pos.Y-epos.Y)) != 1 || gmap.IsSanctuary(pos.X, pos.Y) {
+			continue
+		}
+		if pos.X != epos.X && pos.Y != epos.Y && solidTile(gmap, pos.X, epos.Y) && solidTile(gmap, epos.X, pos.Y) {
+			continue
+		}
+		out = append(out, pid)
+	}
+	return out
+}
+
+// AIMemoryTurns is how many turns an enemy keeps pursuing a player's last
+// known position after losing line of sight.
+const AIMemoryTurns = 3
+
+// senseTarget picks the position enemy id should act on this turn: the player
+// it can see chosen by targetPlayer, or failing that the player's last known
+// position while its memory lasts. Seeing a player refreshes the memory;
+// reaching the last known position without finding anyone clears it.
+func senseTarget(w *ecs.World, gmap *gamemap.GameMap, id ecs.EntityID, playerIDs []ecs.EntityID,
+	pos component.Position, aiComp component.AI) (component.Position, bool) {
+	if _, ppos, ok := targetPlayer(w, gmap, id, playerIDs, pos, aiComp.SightRange); ok {
+		aiComp.LastKnown = ppos
+		aiComp.Memory = AIMemoryTurns
+		w.Add(id, aiComp)
+		return ppos, true
+	}
+	if aiComp.Memory <= 0 {
+		return component.Position{}, false
+	}
+	if aiComp.LastKnown == pos {
+		aiComp.Memory = 0 // arrived and found no one: give up
+		w.Add(id, aiComp)
+		return component.Position{}, false
+	}
+	aiComp.Memory--
+	w.Add(id, aiComp)
+	return aiComp.LastKnown, true
+}
+
+// tauntTarget returns the position of the player that has taunted entity id,
+// consuming one turn of the taunt. The taunt is removed once it expires or its
+// target is gone.
+func tauntTarget(w *ecs.World, id ecs.EntityID) (component.Position, bool) {
+	tc := w.Get(id, component.CTaunt)
+	if tc == nil {
+		return component.Position{}, false
+	}
+	taunt := tc.(component.Taunt)
+	pc := w.Get(taunt.Target, component.CPosition)
+	taunt.TurnsRemaining--
+	if pc == nil || taunt.TurnsRemaining < 0 {
+		w.Remove(id, component.CTaunt)
+		return component.Position{}, false
+	}
+	if taunt.TurnsRemaining == 0 {
+		w.Remove(id, component.CTaunt)
+	} else {
+		w.Add(id, taunt)
+	}
+	return pc.(component.Position), true
+}
+
+// TauntEnemies forces every hostile AI entity within radius of the taunter and
+// in its line of sight to target it for the given number of turns, and adds
+// TauntThreat so they keep after it once the taunt ends. Returns how many
+// were taunted.
+func TauntEnemies(w *ecs.World, gmap *gamemap.GameMap, taunter ecs.EntityID, radius, turns int) int {
+	pc := w.Get(taunter, component.CPosition)
+	if pc == nil {
+		return 0
+	}
+	pos := pc.(component.Position)
+	n := 0
+	for _, id := range w.Query(component.CAI, component.CPosition) {
+		if w.Get(id, component.CAI).(component.AI).Behavior == component.BehaviorAlly {
+			continue
+		}
+		epos := w.Get(id, component.CPosition).(component.Position)
+		dx := float64(epos.X - pos.X)
+		dy := float64(epos.Y - pos.Y)
+		if math.Sqrt(dx*dx+dy*dy) > float64(radius) || !HasLineOfSight(gmap, pos.X, pos.Y, epos.X, epos.Y) {
+			continue
+		}
+		w.Add(id, component.Taunt{Target: taunter, TurnsRemaining: turns})
+		AddThreat(w, id, taunter, TauntThreat)
+		n++
+	}
+	return n
+}
+
+// targetPlayer returns the ID and position of the player from playerIDs that
+// enemy id at enemyPos goes after: of those within sightRange and in line of
+// sight, the one with the most threat on it, or the nearest when none has
+// any. Returns ecs.NilEntity and zero Position if none qualify.
+func targetPlayer(w *ecs.World, gmap *gamemap.GameMap, id ecs.EntityID, playerIDs []ecs.EntityID,
 	enemyPos component.Position, sightRange int) (ecs.EntityID, component.Position, bool) {
 	best := ecs.NilEntity
 	var bestPos component.Position
 	bestDist := math.MaxFloat64
+	bestThreat := 0
 	for _, pid := range playerIDs {
 		if pid == ecs.NilEntity {
 			continue
@@ -81,10 +271,15 @@ func nearestPlayer(w *ecs.World, playerIDs []ecs.EntityID,
 		dx := float64(pos.X - enemyPos.X)
 		dy := float64(pos.Y - enemyPos.Y)
 		dist := math.Sqrt(dx*dx + dy*dy)
-		if dist <= float64(sightRange) && dist < bestDist {
+		if dist > float64(sightRange) || !HasLineOfSight(gmap, enemyPos.X, enemyPos.Y, pos.X, pos.Y) {
+			continue
+		}
+		threat := threatOn(w, id, pid)
+		if threat > bestThreat || (threat == bestThreat && dist < bestDist) {
 			best = pid
 			bestPos = pos
 			bestDist = dist
+			bestThreat = threat
 		}
 	}
 	return best, bestPos, best != ecs.NilEntity
@@ -101,30 +296,89 @@ func chaseMove(w *ecs.World, gmap *gamemap.GameMap, id ecs.EntityID,
 		return false, AttackResult{}, "", ecs.NilEntity
 	}
 
+	// Keep following a detour already under way; greedy steps would lead
+	// straight back into the obstacle.
+	if len(w.Get(id, component.CAI).(component.AI).Path) > 0 {
+		return followPath(w, gmap, id, pos, playerPos, rng)
+	}
+
 	// Normalize to unit step.
 	stepX, stepY := sign(dx), sign(dy)
 
-	result, target := TryMove(w, gmap, id, stepX, 0)
-	if result == MoveAttack {
-		if w.Has(target, component.CTagPlayer) {
-			glyph := enemyGlyph(w, id)
-			res := Attack(w, rng, id, target)
-			return true, res, glyph, target
+	if stepX != 0 {
+		result, target := TryMove(w, gmap, id, stepX, 0)
+		if result == MoveAttack {
+			return attackIfPlayer(w, gmap, rng, id, target)
+		}
+		if result == MoveOK {
+			return false, AttackResult{}, "", ecs.NilEntity
+		}
+	}
+	// Try vertical.
+	if stepY != 0 {
+		result, target := TryMove(w, gmap, id, 0, stepY)
+		if result == MoveAttack {
+			return attackIfPlayer(w, gmap, rng, id, target)
+		}
+		if result == MoveOK {
+			return false, AttackResult{}, "", ecs.NilEntity
 		}
+	}
+	// Both direct steps are blocked: route around the obstacle.
+	return followPath(w, gmap, id, pos, playerPos, rng)
+}
+
+// attackIfPlayer has enemy id attack target when target is a player. A decoy
+// takes the blow without it counting as a hit on anyone; any other blocker
+// ends the enemy's turn.
+func attackIfPlayer(w *ecs.World, gmap *gamemap.GameMap, rng *rand.Rand, id, target ecs.EntityID) (bool, AttackResult, string, ecs.EntityID) {
+	if w.Has(target, component.CDecoy) {
+		Attack(w, rng, id, target)
 		return false, AttackResult{}, "", ecs.NilEntity
 	}
-	if result == MoveOK {
+	if !w.Has(target, component.CTagPlayer) {
 		return false, AttackResult{}, "", ecs.NilEntity
 	}
-	// Try vertical.
-	result, target = TryMove(w, gmap, id, 0, stepY)
-	if result == MoveAttack {
-		if w.Has(target, component.CTagPlayer) {
-			glyph := enemyGlyph(w, id)
-			res := Attack(w, rng, id, target)
-			return true, res, glyph, target
-		}
+	glyph := enemyGlyph(w, id)
+	res := enemyAttack(w, gmap, rng, id, target)
+	return true, res, glyph, target
+}
+
+// pathStale reports whether the path cached on ai is no use for reaching goal
+// from pos: it has run out, no longer starts next to pos, or goal has moved
+// more than PathRetarget tiles from where it leads.
+func pathStale(ai component.AI, pos, goal component.Position) bool {
+	return len(ai.Path) == 0 || manhattan(ai.Path[0], pos) != 1 ||
+		abs(ai.PathGoal.X-goal.X) > PathRetarget || abs(ai.PathGoal.Y-goal.Y) > PathRetarget
+}
+
+// followPath steps enemy id one tile along an A* route toward goal, using the
+// path cached on its AI component and recomputing it when stale.
+func followPath(w *ecs.World, gmap *gamemap.GameMap, id ecs.EntityID,
+	pos, goal component.Position, rng *rand.Rand) (bool, AttackResult, string, ecs.EntityID) {
+
+	ai := w.Get(id, component.CAI).(component.AI)
+	if pathStale(ai, pos, goal) {
+		ai.Path = FindPath(gmap, pos, goal, ChasePathNodes)
+		ai.PathGoal = goal
+	}
+	if len(ai.Path) == 0 {
+		w.Add(id, ai)
+		return false, AttackResult{}, "", ecs.NilEntity
 	}
+	next := ai.Path[0]
+	result, target := TryMove(w, gmap, id, next.X-pos.X, next.Y-pos.Y)
+	switch result {
+	case MoveOK:
+		ai.Path = ai.Path[1:]
+	case MoveAttack:
+		ai.Path = nil
+		w.Add(id, ai)
+		return attackIfPlayer(w, gmap, rng, id, target)
+	default:
+		ai.Path = nil // blocked by terrain or an ally; replan next turn
+	}
+	w.Add(id, ai)
 	return false, AttackResult{}, "", ecs.NilEntity
 }
 
@@ -139,11 +393,11 @@ func cowardlyMove(w *ecs.World, gmap *gamemap.GameMap, id ecs.EntityID,
 		return false, AttackResult{}, "", ecs.NilEntity
 	}
 
-	if dist <= 1.5 {
+	if dist <= 1.5 && !gmap.IsSanctuary(playerPos.X, playerPos.Y) {
 		// Adjacent — find player entity and attack.
 		for _, playerEnt := range w.Query(component.CTagPlayer) {
 			glyph := enemyGlyph(w, id)
-			res := Attack(w, rng, id, playerEnt)
+			res := enemyAttack(w, gmap, rng, id, playerEnt)
 			return true, res, glyph, playerEnt
 		}
 		return false, AttackResult{}, "", ecs.NilEntity
@@ -158,6 +412,17 @@ func cowardlyMove(w *ecs.World, gmap *gamemap.GameMap, id ecs.EntityID,
 	return false, AttackResult{}, "", ecs.NilEntity
 }
 
+// fleeMove steps a routed enemy directly away from the player, never attacking.
+func fleeMove(w *ecs.World, gmap *gamemap.GameMap, id ecs.EntityID, pos, playerPos component.Position) {
+	stepX, stepY := -sign(playerPos.X-pos.X), -sign(playerPos.Y-pos.Y)
+	if stepX != 0 && TryMoveSimple(w, gmap, id, stepX, 0) == MoveOK {
+		return
+	}
+	if stepY != 0 {
+		TryMoveSimple(w, gmap, id, 0, stepY)
+	}
+}
+
 // enemyGlyph returns the glyph of an enemy entity (safe to call before Attack).
 func enemyGlyph(w *ecs.World, id ecs.EntityID) string {
 	c := w.Get(id, component.CRenderable)
@@ -179,3 +179,216 @@ func TestAIMultiPlayerTargetsNearest(t *testing.T) {
 		t.Errorf("far player should not have taken damage; HP=%d", farHP)
 	}
 }
+
+func TestAITauntedEnemyIgnoresCloserPlayer(t *testing.T) {
+	// Near player adjacent at (2,5); warden at (8,5) taunts the enemy at (3,5).
+	// The enemy should step toward the warden instead of hitting the near player.
+	rng := rand.New(rand.NewSource(0))
+	w, gmap, nearPlayer := newAIWorld(2, 5)
+	warden := w.CreateEntity()
+	w.Add(warden, component.Position{X: 8, Y: 5})
+	w.Add(warden, component.TagPlayer{})
+	w.Add(warden, component.TagBlocking{})
+	w.Add(warden, component.Combat{Attack: 3, Defense: 5})
+	w.Add(warden, component.Health{Current: 40, Max: 40})
+	enemy := addEnemy(w, 3, 5, component.BehaviorChase, 5)
+
+	if n := TauntEnemies(w, gmap, warden, 6, 3); n != 1 {
+		t.Fatalf("TauntEnemies = %d; want 1", n)
+	}
+
+	hits := ProcessAI(w, gmap, []ecs.EntityID{nearPlayer, warden}, rng)
+	if len(hits) != 0 {
+		t.Errorf("taunted enemy attacked someone: %+v", hits)
+	}
+	pos := w.Get(enemy, component.CPosition).(component.Position)
+	if pos.X != 4 || pos.Y != 5 {
+		t.Errorf("taunted enemy at (%d,%d); want (4,5) moving toward the warden", pos.X, pos.Y)
+	}
+}
+
+func TestAITauntExpires(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	w, gmap, player := newAIWorld(10, 10)
+	enemy := addEnemy(w, 2, 2, component.BehaviorStationary, 5)
+	w.Add(enemy, component.Taunt{Target: player, TurnsRemaining: 2})
+
+	ProcessAI(w, gmap, []ecs.EntityID{player}, rng)
+	if !w.Has(enemy, component.CTaunt) {
+		t.Fatal("taunt removed after one turn; want one turn remaining")
+	}
+	ProcessAI(w, gmap, []ecs.EntityID{player}, rng)
+	if w.Has(enemy, component.CTaunt) {
+		t.Error("taunt should be removed once it expires")
+	}
+}
+
+func TestTauntEnemiesSkipsOutOfRangeAndAllies(t *testing.T) {
+	w, gmap, player := newAIWorld(2, 2)
+	far := addEnemy(w, 15, 15, component.BehaviorChase, 5)
+	ally := addEnemy(w, 3, 2, component.BehaviorAlly, 5)
+
+	if n := TauntEnemies(w, gmap, player, 6, 5); n != 0 {
+		t.Errorf("TauntEnemies = %d; want 0", n)
+	}
+	if w.Has(far, component.CTaunt) || w.Has(ally, component.CTaunt) {
+		t.Error("out-of-range enemies and allies must not be taunted")
+	}
+}
+
+func TestTauntEnemiesNeedsLineOfSight(t *testing.T) {
+	// Warden at (2,5), enemy at (6,5) with a wall column at x=4 between them.
+	w, gmap, warden := newAIWorld(2, 5)
+	for y := 0; y < 20; y++ {
+		gmap.Set(4, y, gamemap.MakeWall())
+	}
+	enemy := addEnemy(w, 6, 5, component.BehaviorChase, 5)
+
+	if n := TauntEnemies(w, gmap, warden, 6, 5); n != 0 {
+		t.Errorf("TauntEnemies = %d; want 0 through a wall", n)
+	}
+	if w.Has(enemy, component.CTaunt) {
+		t.Error("an enemy behind a wall must not be taunted")
+	}
+}
+
+func TestAIWallBlocksAggro(t *testing.T) {
+	w, gmap, player := newAIWorld(5, 5)
+	for y := range 20 {
+		gmap.Set(7, y, gamemap.MakeWall())
+	}
+	e := addEnemy(w, 9, 5, component.BehaviorChase, 10)
+	rng := rand.New(rand.NewSource(0))
+
+	ProcessAI(w, gmap, []ecs.EntityID{player}, rng)
+
+	if pos := w.Get(e, component.CPosition).(component.Position); pos.X != 9 || pos.Y != 5 {
+		t.Errorf("enemy behind a wall moved to (%d,%d); want it to stay at (9,5)", pos.X, pos.Y)
+	}
+}
+
+func TestAIPursuesLastKnownPositionAroundCorner(t *testing.T) {
+	w, gmap, player := newAIWorld(5, 5)
+	e := addEnemy(w, 10, 5, component.BehaviorChase, 10)
+	rng := rand.New(rand.NewSource(0))
+
+	ProcessAI(w, gmap, []ecs.EntityID{player}, rng) // spots the player, steps to (9,5)
+
+	// Player ducks out of sight behind a wall column.
+	w.Add(player, component.Position{X: 5, Y: 12})
+	for y := 6; y < 20; y++ {
+		gmap.Set(6, y, gamemap.MakeWall())
+	}
+
+	ProcessAI(w, gmap, []ecs.EntityID{player}, rng)
+	pos := w.Get(e, component.CPosition).(component.Position)
+	if pos.X != 8 {
+		t.Errorf("enemy should keep heading for the last known position; at x=%d, want 8", pos.X)
+	}
+	if ai := w.Get(e, component.CAI).(component.AI); ai.Memory != AIMemoryTurns-1 {
+		t.Errorf("memory = %d; want %d", ai.Memory, AIMemoryTurns-1)
+	}
+}
+
+func TestAIMemoryExpires(t *testing.T) {
+	w, gmap, player := newAIWorld(1, 1)
+	for y := range 20 {
+		gmap.Set(5, y, gamemap.MakeWall())
+	}
+	e := addEnemy(w, 10, 10, component.BehaviorChase, 10)
+	w.Add(e, component.AI{Behavior: component.BehaviorChase, SightRange: 10,
+		LastKnown: component.Position{X: 0, Y: 10}, Memory: 2})
+	rng := rand.New(rand.NewSource(0))
+
+	for range 4 {
+		ProcessAI(w, gmap, []ecs.EntityID{player}, rng)
+	}
+	if ai := w.Get(e, component.CAI).(component.AI); ai.Memory != 0 {
+		t.Errorf("memory = %d; want 0 after expiry", ai.Memory)
+	}
+	if pos := w.Get(e, component.CPosition).(component.Position); pos.X != 8 {
+		t.Errorf("enemy at x=%d; want 8 (two remembered steps, then stop)", pos.X)
+	}
+}
+
+// addPlayer adds a player with 30 HP at (x, y).
+func addPlayer(w *ecs.World, x, y int) ecs.EntityID {
+	p := w.CreateEntity()
+	w.Add(p, component.Position{X: x, Y: y})
+	w.Add(p, component.TagPlayer{})
+	w.Add(p, component.TagBlocking{})
+	w.Add(p, component.Combat{Attack: 3, Defense: 1})
+	w.Add(p, component.Health{Current: 30, Max: 30})
+	w.Add(p, component.Effects{})
+	return p
+}
+
+func TestAIMultiHitEnemySwingsEachTurn(t *testing.T) {
+	w, gmap, player := newAIWorld(5, 5)
+	enemy := addEnemy(w, 6, 5, component.BehaviorChase, 5)
+	w.Add(enemy, component.Combat{Attack: 4, AttacksPerTurn: 3})
+
+	hits := ProcessAI(w, gmap, []ecs.EntityID{player}, rand.New(rand.NewSource(0)))
+	if len(hits) != 3 {
+		t.Fatalf("got %d hits; want 3", len(hits))
+	}
+	total := 0
+	for _, h := range hits {
+		if h.AttackerID != enemy || h.VictimID != player {
+			t.Errorf("hit attributed to %v on %v; want %v on %v", h.AttackerID, h.VictimID, enemy, player)
+		}
+		total += h.Damage
+	}
+	if hp := w.Get(player, component.CHealth).(component.Health).Current; hp != 30-total {
+		t.Errorf("player HP = %d; want %d after %d damage", hp, 30-total, total)
+	}
+}
+
+func TestAIMultiHitStopsOnceVictimDies(t *testing.T) {
+	w, gmap, player := newAIWorld(5, 5)
+	w.Add(player, component.Health{Current: 1, Max: 30})
+	enemy := addEnemy(w, 6, 5, component.BehaviorChase, 5)
+	w.Add(enemy, component.Combat{Attack: 4, AttacksPerTurn: 3})
+
+	if hits := ProcessAI(w, gmap, []ecs.EntityID{player}, rand.New(rand.NewSource(0))); len(hits) != 1 {
+		t.Errorf("got %d hits; want the killing blow only", len(hits))
+	}
+}
+
+func TestAIAreaAttackHitsEveryAdjacentPlayer(t *testing.T) {
+	w := ecs.NewWorld()
+	gmap := openMap(20, 20)
+	a, b, far := addPlayer(w, 5, 5), addPlayer(w, 7, 6), addPlayer(w, 9, 5)
+	enemy := addEnemy(w, 6, 5, component.BehaviorChase, 5)
+	w.Add(enemy, component.Combat{Attack: 4, AttacksPerTurn: 2, AreaAttack: true})
+
+	hits := ProcessAI(w, gmap, []ecs.EntityID{a, b, far}, rand.New(rand.NewSource(0)))
+	perVictim := map[ecs.EntityID]int{}
+	for _, h := range hits {
+		if h.AttackerID != enemy {
+			t.Errorf("hit attributed to %v; want %v", h.AttackerID, enemy)
+		}
+		perVictim[h.VictimID]++
+	}
+	if perVictim[a] != 2 || perVictim[b] != 2 || perVictim[far] != 0 {
+		t.Errorf("hits per player = %v; want 2 on each adjacent player and none on the far one", perVictim)
+	}
+}
+
+func TestAIAreaAttackSkipsPlayerBehindCorner(t *testing.T) {
+	w := ecs.NewWorld()
+	gmap := openMap(20, 20)
+	gmap.Set(7, 5, gamemap.MakeWall())
+	gmap.Set(6, 6, gamemap.MakeWall())
+	a, cornered := addPlayer(w, 5, 5), addPlayer(w, 7, 6)
+	enemy := addEnemy(w, 6, 5, component.BehaviorChase, 5)
+	w.Add(enemy, component.Combat{Attack: 4, AreaAttack: true})
+
+	perVictim := map[ecs.EntityID]int{}
+	for _, h := range ProcessAI(w, gmap, []ecs.EntityID{a, cornered}, rand.New(rand.NewSource(0))) {
+		perVictim[h.VictimID]++
+	}
+	if perVictim[a] != 1 || perVictim[cornered] != 0 {
+		t.Errorf("hits per player = %v; want one on the open neighbour and none through the wall corner", perVictim)
+	}
+}
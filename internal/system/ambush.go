@@ -0,0 +1,22 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+// Concealed reports whether id is an ambushing enemy hidden from viewerID.
+// Ambushers stay unseen until they are adjacent to the viewer, unless the
+// viewer has true sight. They still act as normal while hidden.
+func Concealed(w *ecs.World, viewerID, id ecs.EntityID) bool {
+	ac := w.Get(id, component.CAI)
+	if ac == nil || !ac.(component.AI).Ambush || HasEffect(w, viewerID, component.EffectTrueSight) {
+		return false
+	}
+	vp, ok1 := w.Get(viewerID, component.CPosition).(component.Position)
+	p, ok2 := w.Get(id, component.CPosition).(component.Position)
+	if !ok1 || !ok2 {
+		return true
+	}
+	return abs(p.X-vp.X) > 1 || abs(p.Y-vp.Y) > 1
+}
@@ -0,0 +1,40 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"testing"
+)
+
+func TestConcealedAmbusher(t *testing.T) {
+	w, _, player := newAIWorld(5, 5)
+	lurker := addEnemy(w, 8, 5, component.BehaviorChase, 6)
+	w.Add(lurker, component.AI{Behavior: component.BehaviorChase, SightRange: 6, Ambush: true})
+	plain := addEnemy(w, 8, 6, component.BehaviorChase, 6)
+
+	if !Concealed(w, player, lurker) {
+		t.Error("an ambusher three tiles away should be concealed")
+	}
+	if Concealed(w, player, plain) {
+		t.Error("an ordinary enemy is never concealed")
+	}
+	w.Add(lurker, component.Position{X: 6, Y: 6})
+	if Concealed(w, player, lurker) {
+		t.Error("an adjacent ambusher should be seen")
+	}
+	w.Add(lurker, component.Position{X: 8, Y: 5})
+	ApplyEffect(w, player, component.ActiveEffect{Kind: component.EffectTrueSight, Magnitude: 1, TurnsRemaining: 3})
+	if Concealed(w, player, lurker) {
+		t.Error("true sight should reveal an ambusher at range")
+	}
+}
+
+func TestFOVDoesNotRememberConcealedAmbusher(t *testing.T) {
+	w, gmap, player := newAIWorld(5, 5)
+	lurker := addEnemy(w, 8, 5, component.BehaviorChase, 6)
+	w.Add(lurker, component.AI{Behavior: component.BehaviorChase, SightRange: 6, Ambush: true})
+
+	UpdateFOV(w, gmap, player, 8)
+	if _, ok := seen(w, player)[component.Position{X: 8, Y: 5}]; ok {
+		t.Error("a concealed ambusher should not be remembered")
+	}
+}
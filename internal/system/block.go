@@ -0,0 +1,79 @@
+package system
+
+import (
+	"math/rand"
+
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+const (
+	// BlockDEF is the DEF a block adds until the blocker's next turn.
+	BlockDEF = 8
+	// blockTurns keeps a block up through one enemy turn: effects tick once
+	// before the enemies act and again before the next one.
+	blockTurns = 2
+	// ParryBase and ParryPerDEF make up the chance a blocking shield turns
+	// aside the next blow: ParryBase percent plus ParryPerDEF per point of
+	// the shield's DEF, at most ParryMax.
+	ParryBase   = 20
+	ParryPerDEF = 5
+	ParryMax    = 60
+)
+
+// Block braces entity id in place of acting: BlockDEF extra defense on top of
+// any boost it already has until its next turn, and, with a shield in its
+// off hand, a ParryChance to turn aside the next blow entirely. Returns the
+// parry chance granted, 0 without a shield.
+func Block(w *ecs.World, id ecs.EntityID) int {
+	effs := component.Effects{}
+	if c := w.Get(id, component.CEffects); c != nil {
+		effs = c.(component.Effects)
+	}
+	// Appended rather than applied, so the block stacks with a longer boost.
+	effs.Active = append(effs.Active, component.ActiveEffect{
+		Kind: component.EffectDefenseBoost, Magnitude: BlockDEF, TurnsRemaining: blockTurns,
+	})
+	w.Add(id, effs)
+	chance := ParryChance(w, id)
+	if chance > 0 {
+		ApplyEffect(w, id, component.ActiveEffect{Kind: component.EffectParry, Magnitude: chance, TurnsRemaining: blockTurns})
+	}
+	return chance
+}
+
+// ParryChance returns the percent chance entity id's off-hand shield gives a
+// block to parry, or 0 if it has none: the off hand is empty, holds nothing
+// with DEF, or is broken or knocked loose.
+func ParryChance(w *ecs.World, id ecs.EntityID) int {
+	c := w.Get(id, component.CInventory)
+	if c == nil {
+		return 0
+	}
+	shield := c.(component.Inventory).OffHand
+	if shield.IsEmpty() || shield.Broken() || shield.BonusDEF <= 0 {
+		return 0
+	}
+	if it, ok := DisarmedItem(w, id); ok && it == shield {
+		return 0
+	}
+	return min(ParryBase+ParryPerDEF*shield.BonusDEF, ParryMax)
+}
+
+// parry spends entity id's raised parry, if it has one, and reports whether
+// the roll turned the blow aside.
+func parry(w *ecs.World, rng *rand.Rand, id ecs.EntityID) bool {
+	c := w.Get(id, component.CEffects)
+	if c == nil {
+		return false
+	}
+	effs := c.(component.Effects)
+	for i, e := range effs.Active {
+		if e.Kind == component.EffectParry {
+			effs.Active = append(effs.Active[:i:i], effs.Active[i+1:]...)
+			w.Add(id, effs)
+			return rng.Intn(100) < e.Magnitude
+		}
+	}
+	return false
+}
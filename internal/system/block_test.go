@@ -0,0 +1,90 @@
+package system
+
+import (
+	"math/rand"
+	"testing"
+
+	"emoji-roguelike/internal/component"
+)
+
+func TestBlockLastsThroughOneEnemyTurn(t *testing.T) {
+	w, _, defender := makeCombatants(4, 0, 30)
+	Block(w, defender)
+
+	TickEffects(w) // the tick before the enemies act
+	if got := GetDefenseBonus(w, defender); got != BlockDEF {
+		t.Errorf("DEF bonus during the enemy turn = %d; want %d", got, BlockDEF)
+	}
+	TickEffects(w)
+	if got := GetDefenseBonus(w, defender); got != 0 {
+		t.Errorf("DEF bonus a turn later = %d; want the block gone", got)
+	}
+}
+
+func TestBlockStacksWithLongerDefenseBoost(t *testing.T) {
+	w, _, defender := makeCombatants(4, 0, 30)
+	ApplyEffect(w, defender, component.ActiveEffect{Kind: component.EffectDefenseBoost, Magnitude: 3, TurnsRemaining: 10})
+	Block(w, defender)
+
+	TickEffects(w)
+	if got := GetDefenseBonus(w, defender); got != 3+BlockDEF {
+		t.Errorf("DEF bonus = %d; want the boost and the block (%d)", got, 3+BlockDEF)
+	}
+	TickEffects(w)
+	if got := GetDefenseBonus(w, defender); got != 3 {
+		t.Errorf("DEF bonus after the block = %d; want the boost alone", got)
+	}
+}
+
+func TestParryChance(t *testing.T) {
+	shield := component.Item{Name: "Phase Mirror", Slot: component.SlotOffHand, BonusDEF: 3}
+	broken := shield
+	broken.MaxDurability = 10
+	cases := []struct {
+		name     string
+		inv      *component.Inventory
+		disarmed bool
+		want     int
+	}{
+		{"no inventory", nil, false, 0},
+		{"empty off hand", &component.Inventory{}, false, 0},
+		{"shield", &component.Inventory{OffHand: shield}, false, ParryBase + 3*ParryPerDEF},
+		{"huge shield is capped", &component.Inventory{OffHand: component.Item{Name: "Wall", BonusDEF: 50}}, false, ParryMax},
+		{"no DEF", &component.Inventory{OffHand: component.Item{Name: "Cell", BonusATK: 2}}, false, 0},
+		{"broken", &component.Inventory{OffHand: broken}, false, 0},
+		{"knocked loose", &component.Inventory{OffHand: shield}, true, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w, _, defender := makeCombatants(4, 0, 30)
+			if tc.inv != nil {
+				w.Add(defender, *tc.inv)
+			}
+			if tc.disarmed {
+				ApplyEffect(w, defender, component.ActiveEffect{Kind: component.EffectDisarm, Magnitude: 5, TurnsRemaining: 5})
+			}
+			if got := ParryChance(w, defender); got != tc.want {
+				t.Errorf("ParryChance = %d; want %d", got, tc.want)
+			}
+			if got := Block(w, defender); got != tc.want {
+				t.Errorf("Block granted %d%% parry; want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParryTurnsAsideOneBlow(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	w, attacker, defender := makeCombatants(4, 0, 30)
+	ApplyEffect(w, defender, component.ActiveEffect{Kind: component.EffectParry, Magnitude: 100, TurnsRemaining: 2})
+
+	if res := Attack(w, rng, attacker, defender); !res.Parried || res.Damage != 0 {
+		t.Errorf("first blow: parried %v for %d damage; want parried for none", res.Parried, res.Damage)
+	}
+	if hp := w.Get(defender, component.CHealth).(component.Health).Current; hp != 30 {
+		t.Errorf("HP = %d after a parry; want 30", hp)
+	}
+	if res := Attack(w, rng, attacker, defender); res.Parried {
+		t.Error("second blow parried; a parry turns aside one blow only")
+	}
+}
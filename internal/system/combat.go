@@ -10,9 +10,12 @@ import (
 type AttackResult struct {
 	Damage         int
 	Killed         bool
-	Dodged         bool  // true if the defender dodged the attack entirely
-	SpecialApplied uint8 // 0=none 1=poison 2=weaken 3=lifedrain
-	DrainedAmount  int   // HP healed by lifedrain
+	Dodged         bool   // true if the defender dodged the attack entirely
+	Parried        bool   // true if the defender's shield turned the attack aside
+	SpecialApplied uint8  // 0=none 1=poison 2=weaken 3=lifedrain 4=stun 5=armorBreak 6=disarm
+	DrainedAmount  int    // HP healed by lifedrain
+	DisarmedItem   string // name of the item a disarm knocked loose
+	WornOut        string // name of an equipped item that broke from wear
 }
 
 // equipATKBonus returns total ATK bonus from equipped items (players only).
@@ -21,9 +24,9 @@ func equipATKBonus(w *ecs.World, id ecs.EntityID) int {
 	if c == nil {
 		return 0
 	}
-	inv := c.(component.Inventory)
-	return inv.MainHand.BonusATK + inv.OffHand.BonusATK +
-		inv.Head.BonusATK + inv.Body.BonusATK + inv.Feet.BonusATK
+	atk, _, _ := c.(component.Inventory).EquipBonuses()
+	penalty, _ := DisarmPenalty(w, id)
+	return atk - penalty
 }
 
 // equipDEFBonus returns total DEF bonus from equipped items (players only).
@@ -32,9 +35,9 @@ func equipDEFBonus(w *ecs.World, id ecs.EntityID) int {
 	if c == nil {
 		return 0
 	}
-	inv := c.(component.Inventory)
-	return inv.MainHand.BonusDEF + inv.OffHand.BonusDEF +
-		inv.Head.BonusDEF + inv.Body.BonusDEF + inv.Feet.BonusDEF
+	_, def, _ := c.(component.Inventory).EquipBonuses()
+	_, penalty := DisarmPenalty(w, id)
+	return def - penalty
 }
 
 // skillATKBonus returns the ATK bonus from CSkillBonuses (if present).
@@ -55,10 +58,47 @@ func skillDEFBonus(w *ecs.World, id ecs.EntityID) int {
 	return c.(component.SkillBonuses).BonusDEF
 }
 
+// baseDamage is the damage attackerID deals defenderID before the random
+// roll: total attack minus total defense, at least 1. Both must have Combat.
+func baseDamage(w *ecs.World, attackerID, defenderID ecs.EntityID) int {
+	atk := w.Get(attackerID, component.CCombat).(component.Combat).Attack
+	def := w.Get(defenderID, component.CCombat).(component.Combat).Defense
+	atk += GetAttackBonus(w, attackerID) + equipATKBonus(w, attackerID) + skillATKBonus(w, attackerID) + ProficiencyBonus(w, attackerID)
+	def += GetDefenseBonus(w, defenderID) + equipDEFBonus(w, defenderID) + skillDEFBonus(w, defenderID) - GetArmorBreakPenalty(w, defenderID)
+	return max(atk-def, 1)
+}
+
+// EstimateIncomingDamage returns the most melee damage the AI combatants
+// adjacent to at could deal defenderID in one enemy turn, taking the top of
+// every damage roll. Dodges, specials, ranged attackers and enemies that
+// would first have to move are ignored, so this is a rough worst case.
+func EstimateIncomingDamage(w *ecs.World, defenderID ecs.EntityID, at component.Position) int {
+	if w.Get(defenderID, component.CCombat) == nil {
+		return 0
+	}
+	total := 0
+	for _, id := range w.Query(component.CAI, component.CCombat, component.CPosition) {
+		if id == defenderID || IsStunned(w, id) {
+			continue
+		}
+		p := w.Get(id, component.CPosition).(component.Position)
+		if dx, dy := p.X-at.X, p.Y-at.Y; dx >= -1 && dx <= 1 && dy >= -1 && dy <= 1 {
+			total += baseDamage(w, id, defenderID) + 2
+		}
+	}
+	return total
+}
+
 // Attack resolves one attack from attacker against defender.
 // Damage formula: max(1, atk+bonus-def) + rand.Intn(3)
 // If defender HP drops to ≤ 0, it is destroyed and Killed=true.
 func Attack(w *ecs.World, rng *rand.Rand, attackerID, defenderID ecs.EntityID) AttackResult {
+	return attack(w, rng, attackerID, defenderID, 100)
+}
+
+// attack implements Attack, scaling the rolled damage to dmgPct percent
+// (minimum 1) before it is applied.
+func attack(w *ecs.World, rng *rand.Rand, attackerID, defenderID ecs.EntityID, dmgPct int) AttackResult {
 	atkComp := w.Get(attackerID, component.CCombat)
 	defComp := w.Get(defenderID, component.CCombat)
 	hpComp := w.Get(defenderID, component.CHealth)
@@ -74,26 +114,34 @@ func Attack(w *ecs.World, rng *rand.Rand, attackerID, defenderID ecs.EntityID) A
 			return AttackResult{Dodged: true}
 		}
 	}
+	if parry(w, rng, defenderID) {
+		return AttackResult{Parried: true}
+	}
 
 	cbt := atkComp.(component.Combat)
-	def := defComp.(component.Combat).Defense
 	hp := hpComp.(component.Health)
 
-	atk := cbt.Attack + GetAttackBonus(w, attackerID) + equipATKBonus(w, attackerID) + skillATKBonus(w, attackerID)
-	def += GetDefenseBonus(w, defenderID) + equipDEFBonus(w, defenderID) + skillDEFBonus(w, defenderID) - GetArmorBreakPenalty(w, defenderID)
-	base := atk - def
-	if base < 1 {
-		base = 1
+	dmg := baseDamage(w, attackerID, defenderID) + rng.Intn(3)
+	if dmgPct != 100 {
+		dmg = max(dmg*dmgPct/100, 1)
 	}
-	dmg := base + rng.Intn(3)
 
 	hp.Current -= dmg
 	w.Add(defenderID, hp)
 
 	result := AttackResult{Damage: dmg}
+	result.WornOut = wearWeapon(w, attackerID)
 	if hp.Current <= 0 {
 		result.Killed = true
 		w.DestroyEntity(defenderID)
+	} else {
+		if name := wearArmor(w, defenderID); name != "" {
+			result.WornOut = name
+		}
+		markSplit(w, defenderID, hp)
+		if w.Has(attackerID, component.CTagPlayer) {
+			AddThreat(w, defenderID, attackerID, dmg)
+		}
 	}
 
 	// Special attack: only triggered when defender is alive (not destroyed mid-attack
@@ -140,6 +188,11 @@ func Attack(w *ecs.World, rng *rand.Rand, attackerID, defenderID ecs.EntityID) A
 				Magnitude:      cbt.SpecialMag,
 				TurnsRemaining: cbt.SpecialDur,
 			})
+		case 6: // disarm
+			result.DisarmedItem = disarm(w, rng, defenderID, cbt.SpecialDur)
+			if result.DisarmedItem == "" {
+				result.SpecialApplied = 0 // nothing worth knocking loose
+			}
 		}
 	}
 
@@ -171,3 +171,30 @@ func TestSpecialAttackLifedrain(t *testing.T) {
 		t.Errorf("attacker HP %d exceeds max %d after lifedrain", atkHP.Current, atkHP.Max)
 	}
 }
+
+func TestEstimateIncomingDamageCountsAdjacentEnemies(t *testing.T) {
+	w, _, player := newAIWorld(5, 5)
+	addEnemy(w, 6, 5, component.BehaviorChase, 8) // adjacent: ATK 4 vs DEF 1 → 3+2
+	addEnemy(w, 4, 4, component.BehaviorChase, 8) // diagonal: another 5
+	addEnemy(w, 8, 5, component.BehaviorChase, 8) // two tiles away: ignored
+
+	if got := EstimateIncomingDamage(w, player, component.Position{X: 5, Y: 5}); got != 10 {
+		t.Errorf("incoming at (5,5) = %d; want 10", got)
+	}
+	// Estimating for the tile the player would step to counts its neighbours.
+	if got := EstimateIncomingDamage(w, player, component.Position{X: 7, Y: 5}); got != 10 {
+		t.Errorf("incoming at (7,5) = %d; want 10", got)
+	}
+	if got := EstimateIncomingDamage(w, player, component.Position{X: 2, Y: 8}); got != 0 {
+		t.Errorf("incoming far from enemies = %d; want 0", got)
+	}
+}
+
+func TestEstimateIncomingDamageSkipsStunned(t *testing.T) {
+	w, _, player := newAIWorld(5, 5)
+	e := addEnemy(w, 6, 5, component.BehaviorChase, 8)
+	ApplyEffect(w, e, component.ActiveEffect{Kind: component.EffectStun, Magnitude: 1, TurnsRemaining: 2})
+	if got := EstimateIncomingDamage(w, player, component.Position{X: 5, Y: 5}); got != 0 {
+		t.Errorf("stunned enemy counted: incoming = %d; want 0", got)
+	}
+}
@@ -0,0 +1,71 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+// MaxCDRPercent caps total ability cooldown reduction from equipment.
+const MaxCDRPercent = 50
+
+// GetCDRPercent returns the total cooldown reduction percentage from equipped
+// items that are not broken, capped at MaxCDRPercent.
+func GetCDRPercent(w *ecs.World, id ecs.EntityID) int {
+	c := w.Get(id, component.CInventory)
+	if c == nil {
+		return 0
+	}
+	pct := 0
+	for _, it := range c.(component.Inventory).Equipped() {
+		if !it.Broken() {
+			pct += it.CDRPercent
+		}
+	}
+	return min(pct, MaxCDRPercent)
+}
+
+// ApplyCDR reduces a base cooldown by pct percent, never going below 1 for a
+// non-zero base.
+func ApplyCDR(base, pct int) int {
+	if base <= 0 {
+		return base
+	}
+	return max(base*(100-pct)/100, 1)
+}
+
+// CooldownTick returns how many turns an entity's ability cooldown should
+// drop this turn: 2 while hasted, otherwise 1.
+func CooldownTick(w *ecs.World, id ecs.EntityID) int {
+	if HasEffect(w, id, component.EffectHaste) {
+		return 2
+	}
+	return 1
+}
+
+// SpendCharge consumes one ability charge and starts the recharge timer at
+// fullCooldown if it is not already running. spent counts charges used and not
+// yet restored.
+func SpendCharge(spent, cooldown, fullCooldown int) (int, int) {
+	if cooldown <= 0 {
+		cooldown = fullCooldown
+	}
+	return spent + 1, cooldown
+}
+
+// TickCharges advances an ability's recharge timer by tick turns. When the
+// timer runs out one spent charge is restored, and the timer restarts at
+// fullCooldown while further charges remain spent.
+func TickCharges(spent, cooldown, tick, fullCooldown int) (int, int) {
+	if cooldown <= 0 {
+		return spent, 0
+	}
+	cooldown -= tick
+	if cooldown > 0 {
+		return spent, cooldown
+	}
+	spent = max(spent-1, 0)
+	if spent > 0 {
+		return spent, fullCooldown
+	}
+	return 0, 0
+}
@@ -0,0 +1,65 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"testing"
+)
+
+func TestApplyCDR(t *testing.T) {
+	cases := []struct{ base, pct, want int }{
+		{10, 0, 10},
+		{10, 25, 7},
+		{10, 50, 5},
+		{1, 50, 1}, // never below 1
+		{0, 50, 0}, // no ability stays at 0
+	}
+	for _, c := range cases {
+		if got := ApplyCDR(c.base, c.pct); got != c.want {
+			t.Errorf("ApplyCDR(%d, %d) = %d, want %d", c.base, c.pct, got, c.want)
+		}
+	}
+}
+
+func TestGetCDRPercentCapped(t *testing.T) {
+	w, _, pid := newAIWorld(1, 1)
+	inv := component.Inventory{
+		Head:    component.Item{CDRPercent: 25},
+		OffHand: component.Item{CDRPercent: 25},
+		Feet:    component.Item{CDRPercent: 25},
+	}
+	w.Add(pid, inv)
+	if got := GetCDRPercent(w, pid); got != MaxCDRPercent {
+		t.Errorf("GetCDRPercent = %d, want cap %d", got, MaxCDRPercent)
+	}
+}
+
+func TestCooldownTickHaste(t *testing.T) {
+	w, _, pid := newAIWorld(1, 1)
+	if got := CooldownTick(w, pid); got != 1 {
+		t.Errorf("CooldownTick without haste = %d, want 1", got)
+	}
+	ApplyEffect(w, pid, component.ActiveEffect{Kind: component.EffectHaste, Magnitude: 1, TurnsRemaining: 3})
+	if got := CooldownTick(w, pid); got != 2 {
+		t.Errorf("CooldownTick with haste = %d, want 2", got)
+	}
+}
+
+func TestSpendAndTickCharges(t *testing.T) {
+	spent, cd := SpendCharge(0, 0, 4)
+	spent, cd = SpendCharge(spent, cd, 4) // timer already running: not reset
+	if spent != 2 || cd != 4 {
+		t.Fatalf("after two spends: spent=%d cd=%d; want 2, 4", spent, cd)
+	}
+	for range 4 {
+		spent, cd = TickCharges(spent, cd, 1, 4)
+	}
+	if spent != 1 || cd != 4 {
+		t.Fatalf("after one cooldown: spent=%d cd=%d; want 1, 4", spent, cd)
+	}
+	for range 4 {
+		spent, cd = TickCharges(spent, cd, 1, 4)
+	}
+	if spent != 0 || cd != 0 {
+		t.Errorf("after two cooldowns: spent=%d cd=%d; want 0, 0", spent, cd)
+	}
+}
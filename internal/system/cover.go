@@ -0,0 +1,41 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+	"math/rand"
+)
+
+const (
+	// CoverPerWall is the ranged damage reduction granted by each wall
+	// orthogonally adjacent to the defender.
+	CoverPerWall = 25
+	// MaxCover caps the total ranged damage reduction from cover.
+	MaxCover = 50
+)
+
+// CoverBonus returns the percentage by which ranged damage against an entity
+// standing at (x, y) is reduced: CoverPerWall for each orthogonally adjacent
+// opaque tile, capped at MaxCover. Melee attacks ignore cover.
+func CoverBonus(gmap *gamemap.GameMap, x, y int) int {
+	pct := 0
+	for _, d := range [4][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+		nx, ny := x+d[0], y+d[1]
+		if gmap.InBounds(nx, ny) && !gmap.IsTransparent(nx, ny) {
+			pct += CoverPerWall
+		}
+	}
+	return min(pct, MaxCover)
+}
+
+// RangedAttack resolves a ranged attack like Attack, but the damage is
+// reduced by the defender's CoverBonus.
+func RangedAttack(w *ecs.World, gmap *gamemap.GameMap, rng *rand.Rand, attackerID, defenderID ecs.EntityID) AttackResult {
+	cover := 0
+	if pc := w.Get(defenderID, component.CPosition); pc != nil {
+		pos := pc.(component.Position)
+		cover = CoverBonus(gmap, pos.X, pos.Y)
+	}
+	return attack(w, rng, attackerID, defenderID, 100-cover)
+}
@@ -0,0 +1,51 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/gamemap"
+	"math/rand"
+	"testing"
+)
+
+func TestCoverBonus(t *testing.T) {
+	gmap := openMap(10, 10)
+	if got := CoverBonus(gmap, 5, 5); got != 0 {
+		t.Errorf("open floor cover = %d; want 0", got)
+	}
+	gmap.Set(6, 5, gamemap.MakeWall())
+	if got := CoverBonus(gmap, 5, 5); got != CoverPerWall {
+		t.Errorf("one adjacent wall cover = %d; want %d", got, CoverPerWall)
+	}
+	gmap.Set(5, 4, gamemap.MakeWall())
+	gmap.Set(4, 5, gamemap.MakeWall())
+	if got := CoverBonus(gmap, 5, 5); got != MaxCover {
+		t.Errorf("three adjacent walls cover = %d; want cap %d", got, MaxCover)
+	}
+	gmap.Set(6, 6, gamemap.MakeWall())
+	gmap.Set(6, 5, gamemap.MakeFloor())
+	gmap.Set(5, 4, gamemap.MakeFloor())
+	gmap.Set(4, 5, gamemap.MakeFloor())
+	if got := CoverBonus(gmap, 5, 5); got != 0 {
+		t.Errorf("diagonal wall should not give cover; got %d", got)
+	}
+}
+
+func TestRangedAttackReducedByCoverMeleeUnaffected(t *testing.T) {
+	damage := func(ranged bool) int {
+		w, gmap, _ := newAIWorld(1, 1)
+		gmap.Set(6, 5, gamemap.MakeWall())
+		gmap.Set(4, 5, gamemap.MakeWall())
+		attacker := addEnemy(w, 5, 8, component.BehaviorStationary, 5)
+		w.Add(attacker, component.Combat{Attack: 12})
+		target := addEnemy(w, 5, 5, component.BehaviorStationary, 5)
+		rng := rand.New(rand.NewSource(7))
+		if ranged {
+			return RangedAttack(w, gmap, rng, attacker, target).Damage
+		}
+		return Attack(w, rng, attacker, target).Damage
+	}
+	melee, ranged := damage(false), damage(true)
+	if want := melee * (100 - MaxCover) / 100; ranged != want {
+		t.Errorf("ranged damage in full cover = %d; want %d (melee %d)", ranged, want, melee)
+	}
+}
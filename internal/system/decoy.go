@@ -0,0 +1,56 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+	"math"
+)
+
+// decoyTarget returns the position of the nearest decoy within sightRange of
+// enemyPos and in line of sight. A decoy outranks every player's threat, so
+// an enemy that sees one goes after it first. Query returns IDs in ascending
+// order, so ties go to the lower entity ID.
+func decoyTarget(w *ecs.World, gmap *gamemap.GameMap, enemyPos component.Position, sightRange int) (component.Position, bool) {
+	best := ecs.NilEntity
+	var bestPos component.Position
+	bestDist := math.MaxFloat64
+	for _, id := range w.Query(component.CDecoy, component.CPosition) {
+		pos := w.Get(id, component.CPosition).(component.Position)
+		dx := float64(pos.X - enemyPos.X)
+		dy := float64(pos.Y - enemyPos.Y)
+		dist := math.Sqrt(dx*dx + dy*dy)
+		if dist > float64(sightRange) || !HasLineOfSight(gmap, enemyPos.X, enemyPos.Y, pos.X, pos.Y) {
+			continue
+		}
+		if dist < bestDist {
+			best = id
+			bestPos = pos
+			bestDist = dist
+		}
+	}
+	return bestPos, best != ecs.NilEntity
+}
+
+// ageDecoys ages every decoy by one turn, destroying those whose lifespan
+// has run out.
+func ageDecoys(w *ecs.World) {
+	for _, id := range w.Query(component.CDecoy) {
+		decoy := w.Get(id, component.CDecoy).(component.Decoy)
+		decoy.TurnsLeft--
+		if decoy.TurnsLeft <= 0 {
+			w.DestroyEntity(id)
+			continue
+		}
+		w.Add(id, decoy)
+	}
+}
+
+// ClearDecoys destroys every decoy placed by owner.
+func ClearDecoys(w *ecs.World, owner ecs.EntityID) {
+	for _, id := range w.Query(component.CDecoy) {
+		if w.Get(id, component.CDecoy).(component.Decoy).Owner == owner {
+			w.DestroyEntity(id)
+		}
+	}
+}
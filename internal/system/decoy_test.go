@@ -0,0 +1,88 @@
+package system
+
+import (
+	"math/rand"
+	"testing"
+
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+// addDecoy adds a decoy owned by owner at (x, y), built as factory.NewDecoy
+// builds one.
+func addDecoy(w *ecs.World, owner ecs.EntityID, x, y, hp, turns int) ecs.EntityID {
+	id := w.CreateEntity()
+	w.Add(id, component.Position{X: x, Y: y})
+	w.Add(id, component.Health{Current: hp, Max: hp})
+	w.Add(id, component.Combat{})
+	w.Add(id, component.TagBlocking{})
+	w.Add(id, component.Decoy{Owner: owner, TurnsLeft: turns})
+	return id
+}
+
+func TestAIAttacksDecoyInsteadOfAdjacentPlayer(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	w, gmap, player := newAIWorld(5, 5)
+	addEnemy(w, 6, 5, component.BehaviorChase, 10)
+	decoy := addDecoy(w, player, 7, 5, 30, 5)
+
+	hits := ProcessAI(w, gmap, []ecs.EntityID{player}, rng)
+	if len(hits) != 0 {
+		t.Errorf("enemy hit the player %d time(s); want it to go after the decoy", len(hits))
+	}
+	if hp := w.Get(decoy, component.CHealth).(component.Health); hp.Current >= hp.Max {
+		t.Error("the decoy should have taken the enemy's blow")
+	}
+}
+
+func TestAIIgnoresDecoyOutOfSight(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	w, gmap, player := newAIWorld(5, 5)
+	addEnemy(w, 6, 5, component.BehaviorChase, 3)
+	addDecoy(w, player, 15, 5, 30, 5)
+
+	if hits := ProcessAI(w, gmap, []ecs.EntityID{player}, rng); len(hits) != 1 {
+		t.Errorf("got %d hit(s); want the enemy to attack the player it sees", len(hits))
+	}
+}
+
+func TestDecoyFallsApartWhenLifespanRunsOut(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	w, gmap, player := newAIWorld(5, 5)
+	decoy := addDecoy(w, player, 8, 8, 30, 2)
+
+	ProcessAI(w, gmap, []ecs.EntityID{player}, rng)
+	if !w.Alive(decoy) {
+		t.Fatal("decoy gone after one turn; want it to last two")
+	}
+	ProcessAI(w, gmap, []ecs.EntityID{player}, rng)
+	if w.Alive(decoy) {
+		t.Error("decoy still standing after its lifespan")
+	}
+}
+
+func TestPlayerTradesPlacesWithDecoy(t *testing.T) {
+	w, gmap, player := newAIWorld(5, 5)
+	decoy := addDecoy(w, player, 6, 5, 30, 5)
+
+	if res, _ := TryMove(w, gmap, player, 1, 0); res != MoveOK {
+		t.Fatalf("TryMove onto the decoy = %v; want MoveOK", res)
+	}
+	if p := w.Get(player, component.CPosition).(component.Position); p != (component.Position{X: 6, Y: 5}) {
+		t.Errorf("player at %v; want (6,5)", p)
+	}
+	if p := w.Get(decoy, component.CPosition).(component.Position); p != (component.Position{X: 5, Y: 5}) {
+		t.Errorf("decoy at %v; want swapped to (5,5)", p)
+	}
+}
+
+func TestClearDecoysKeepsOtherOwners(t *testing.T) {
+	w, _, player := newAIWorld(5, 5)
+	mine := addDecoy(w, player, 6, 5, 30, 5)
+	theirs := addDecoy(w, player+100, 7, 5, 30, 5)
+
+	ClearDecoys(w, player)
+	if w.Alive(mine) || !w.Alive(theirs) {
+		t.Errorf("after ClearDecoys: own decoy alive %v, other's alive %v; want false, true", w.Alive(mine), w.Alive(theirs))
+	}
+}
@@ -0,0 +1,61 @@
+package system
+
+import (
+	"math/rand"
+
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+// disarm knocks loose a random equipped item of id's that grants ATK or DEF,
+// so it gives neither for turns turns, and returns its name. Nothing is
+// destroyed: a disarmed weapon only leaves its wielder fighting with unarmed
+// stats until the effect ends. Returns "" when id has nothing worth
+// disarming or an item is already loose.
+func disarm(w *ecs.World, rng *rand.Rand, id ecs.EntityID, turns int) string {
+	c := w.Get(id, component.CInventory)
+	if c == nil || turns <= 0 || HasEffect(w, id, component.EffectDisarm) {
+		return ""
+	}
+	slots := c.(component.Inventory).Equipped()
+	var candidates []int
+	for i, it := range slots {
+		if !it.IsEmpty() && !it.Broken() && (it.BonusATK > 0 || it.BonusDEF > 0) {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	i := candidates[rng.Intn(len(candidates))]
+	ApplyEffect(w, id, component.ActiveEffect{Kind: component.EffectDisarm, Magnitude: i + 1, TurnsRemaining: turns})
+	return slots[i].Name
+}
+
+// DisarmedItem returns the item in id's disarmed equipment slot, if any. The
+// slot, not the item, is jammed: whatever is equipped there stays inert
+// until the effect ends.
+func DisarmedItem(w *ecs.World, id ecs.EntityID) (component.Item, bool) {
+	ec, ic := w.Get(id, component.CEffects), w.Get(id, component.CInventory)
+	if ec == nil || ic == nil {
+		return component.Item{}, false
+	}
+	slots := ic.(component.Inventory).Equipped()
+	for _, e := range ec.(component.Effects).Active {
+		if e.Kind == component.EffectDisarm && e.Magnitude >= 1 && e.Magnitude <= len(slots) {
+			it := slots[e.Magnitude-1]
+			return it, !it.IsEmpty()
+		}
+	}
+	return component.Item{}, false
+}
+
+// DisarmPenalty returns the ATK and DEF id's disarmed item would otherwise
+// grant, to subtract from its equipment bonuses.
+func DisarmPenalty(w *ecs.World, id ecs.EntityID) (atk, def int) {
+	it, ok := DisarmedItem(w, id)
+	if !ok || it.Broken() { // a broken item grants nothing to lose
+		return 0, 0
+	}
+	return it.BonusATK, it.BonusDEF
+}
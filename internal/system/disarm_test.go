@@ -0,0 +1,68 @@
+package system
+
+import (
+	"math/rand"
+	"testing"
+
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+// makeDisarmer returns a world with an attacker that always disarms and a
+// sturdy defender holding inv.
+func makeDisarmer(inv component.Inventory) (*ecs.World, ecs.EntityID, ecs.EntityID) {
+	w, attacker, defender := makeCombatants(4, 0, 1000)
+	w.Add(attacker, component.Combat{Attack: 4, SpecialKind: 6, SpecialChance: 100, SpecialDur: 5})
+	w.Add(defender, inv)
+	return w, attacker, defender
+}
+
+func TestDisarmSuppressesOneEquippedItem(t *testing.T) {
+	sword := component.Item{Name: "Sword", Slot: component.SlotOneHand, BonusATK: 5}
+	w, attacker, defender := makeDisarmer(component.Inventory{MainHand: sword})
+
+	res := Attack(w, rand.New(rand.NewSource(1)), attacker, defender)
+	if res.SpecialApplied != 6 || res.DisarmedItem != "Sword" {
+		t.Fatalf("special %d item %q; want the sword disarmed", res.SpecialApplied, res.DisarmedItem)
+	}
+	if got := equipATKBonus(w, defender); got != 0 {
+		t.Errorf("equipment ATK = %d while disarmed; want unarmed (0)", got)
+	}
+	inv := w.Get(defender, component.CInventory).(component.Inventory)
+	if inv.MainHand.Name != "Sword" {
+		t.Error("a disarmed weapon must stay equipped, never destroyed")
+	}
+
+	for range 5 {
+		TickEffects(w)
+	}
+	if got := equipATKBonus(w, defender); got != 5 {
+		t.Errorf("equipment ATK = %d after the effect ends; want 5", got)
+	}
+}
+
+func TestDisarmOneItemAtATime(t *testing.T) {
+	w, attacker, defender := makeDisarmer(component.Inventory{
+		MainHand: component.Item{Name: "Sword", Slot: component.SlotOneHand, BonusATK: 5},
+		Body:     component.Item{Name: "Mail", Slot: component.SlotBody, BonusDEF: 3},
+	})
+	rng := rand.New(rand.NewSource(2))
+	first := Attack(w, rng, attacker, defender)
+	second := Attack(w, rng, attacker, defender)
+	if first.DisarmedItem == "" {
+		t.Fatal("first hit should disarm something")
+	}
+	if second.SpecialApplied != 0 || second.DisarmedItem != "" {
+		t.Errorf("second hit disarmed %q while %q was loose", second.DisarmedItem, first.DisarmedItem)
+	}
+}
+
+func TestDisarmNeedsSomethingToDisarm(t *testing.T) {
+	w, attacker, defender := makeDisarmer(component.Inventory{
+		Head: component.Item{Name: "Circlet", Slot: component.SlotHead, BonusMaxHP: 4},
+	})
+	res := Attack(w, rand.New(rand.NewSource(1)), attacker, defender)
+	if res.SpecialApplied != 0 || HasEffect(w, defender, component.EffectDisarm) {
+		t.Error("an HP-only item should not be disarmed")
+	}
+}
@@ -0,0 +1,81 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+// Repair pricing: every RepairPointsPerGold points of lost durability cost a
+// gold, rounded up, and mending a broken item costs RepairBrokenFee more.
+const (
+	RepairPointsPerGold = 3
+	RepairBrokenFee     = 5
+)
+
+// wearItem takes a point of durability from it and reports whether that
+// broke it. Items that never wear, or are already broken, are unchanged.
+func wearItem(it *component.Item) bool {
+	if it.IsEmpty() || it.MaxDurability <= 0 || it.Broken() {
+		return false
+	}
+	it.Durability--
+	return it.Broken()
+}
+
+// wearWeapon wears id's main-hand weapon after it lands a hit. Returns the
+// weapon's name if it broke.
+func wearWeapon(w *ecs.World, id ecs.EntityID) string {
+	c := w.Get(id, component.CInventory)
+	if c == nil {
+		return ""
+	}
+	inv := c.(component.Inventory)
+	broke := wearItem(&inv.MainHand)
+	w.Add(id, inv)
+	if broke {
+		return inv.MainHand.Name
+	}
+	return ""
+}
+
+// wearArmor wears the least-worn armor id has on after it is hit, so wear
+// spreads evenly across every piece. Returns the piece's name if it broke.
+func wearArmor(w *ecs.World, id ecs.EntityID) string {
+	c := w.Get(id, component.CInventory)
+	if c == nil {
+		return ""
+	}
+	inv := c.(component.Inventory)
+	var piece *component.Item
+	for _, it := range []*component.Item{&inv.Head, &inv.Body, &inv.Feet, &inv.OffHand} {
+		if it.IsEmpty() || it.MaxDurability <= 0 || it.Broken() {
+			continue
+		}
+		if piece == nil || it.Durability > piece.Durability {
+			piece = it
+		}
+	}
+	if piece == nil {
+		return ""
+	}
+	broke := wearItem(piece)
+	w.Add(id, inv)
+	if broke {
+		return piece.Name
+	}
+	return ""
+}
+
+// RepairCost returns the gold it costs to restore it to full durability, or
+// 0 if it needs no repair.
+func RepairCost(it component.Item) int {
+	lost := it.MaxDurability - it.Durability
+	if it.IsEmpty() || lost <= 0 {
+		return 0
+	}
+	cost := (lost + RepairPointsPerGold - 1) / RepairPointsPerGold
+	if it.Broken() {
+		cost += RepairBrokenFee
+	}
+	return cost
+}
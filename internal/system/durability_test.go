@@ -0,0 +1,81 @@
+package system
+
+import (
+	"math/rand"
+	"testing"
+
+	"emoji-roguelike/internal/component"
+)
+
+func TestAttackWearsWeaponAndArmor(t *testing.T) {
+	w, attacker, defender := makeCombatants(5, 0, 1000)
+	w.Add(attacker, component.Inventory{
+		MainHand: component.Item{Name: "Sword", Slot: component.SlotOneHand, BonusATK: 2, Durability: 10, MaxDurability: 10},
+	})
+	w.Add(defender, component.Inventory{
+		Head: component.Item{Name: "Helm", Slot: component.SlotHead, BonusDEF: 1, Durability: 4, MaxDurability: 10},
+		Body: component.Item{Name: "Mail", Slot: component.SlotBody, BonusDEF: 2, Durability: 9, MaxDurability: 10},
+	})
+
+	Attack(w, rand.New(rand.NewSource(1)), attacker, defender)
+	if d := w.Get(attacker, component.CInventory).(component.Inventory).MainHand.Durability; d != 9 {
+		t.Errorf("sword durability = %d; want 9 after landing a hit", d)
+	}
+	inv := w.Get(defender, component.CInventory).(component.Inventory)
+	if inv.Body.Durability != 8 || inv.Head.Durability != 4 {
+		t.Errorf("helm %d, mail %d; want the least-worn piece (mail) worn to 8",
+			inv.Head.Durability, inv.Body.Durability)
+	}
+}
+
+func TestBrokenWeaponFallsBackToUnarmed(t *testing.T) {
+	w, attacker, defender := makeCombatants(5, 0, 1000)
+	w.Add(attacker, component.Inventory{
+		MainHand: component.Item{Name: "Sword", Slot: component.SlotOneHand, BonusATK: 4, Durability: 1, MaxDurability: 10},
+	})
+
+	res := Attack(w, rand.New(rand.NewSource(1)), attacker, defender)
+	if res.WornOut != "Sword" {
+		t.Fatalf("WornOut = %q; want the sword to break", res.WornOut)
+	}
+	if got := equipATKBonus(w, attacker); got != 0 {
+		t.Errorf("broken sword still grants ATK %d", got)
+	}
+	if inv := w.Get(attacker, component.CInventory).(component.Inventory); inv.MainHand.Name != "Sword" {
+		t.Error("a broken weapon stays equipped until it is repaired")
+	}
+	res = Attack(w, rand.New(rand.NewSource(1)), attacker, defender)
+	if res.WornOut != "" {
+		t.Error("a broken item should not break again")
+	}
+}
+
+func TestItemsWithoutDurabilityNeverWear(t *testing.T) {
+	w, attacker, defender := makeCombatants(5, 0, 1000)
+	w.Add(attacker, component.Inventory{
+		MainHand: component.Item{Name: "Heirloom", Slot: component.SlotOneHand, BonusATK: 3},
+	})
+	for range 5 {
+		Attack(w, rand.New(rand.NewSource(1)), attacker, defender)
+	}
+	if got := equipATKBonus(w, attacker); got != 3 {
+		t.Errorf("ATK bonus = %d; want 3 from an item that never wears", got)
+	}
+}
+
+func TestRepairCost(t *testing.T) {
+	cases := []struct {
+		it   component.Item
+		want int
+	}{
+		{component.Item{Name: "New", Durability: 10, MaxDurability: 10}, 0},
+		{component.Item{Name: "Ageless"}, 0},
+		{component.Item{Name: "Worn", Durability: 6, MaxDurability: 10}, 2},
+		{component.Item{Name: "Broken", Durability: 0, MaxDurability: 9}, 3 + RepairBrokenFee},
+	}
+	for _, c := range cases {
+		if got := RepairCost(c.it); got != c.want {
+			t.Errorf("RepairCost(%s) = %d; want %d", c.it.Name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+)
+
+// Echo tuning: each class ability's enemy-sized copy.
+const (
+	EchoBargainCost    = 5 // HP a revenant echo spends on Death's Bargain
+	EchoBargainATK     = 6
+	EchoBargainTurns   = 8
+	EchoOverclockATK   = 6
+	EchoOverclockTurns = 6
+	EchoSurgeHeal      = 10
+	EchoSurgeATK       = 4
+	EchoSurgeTurns     = 6
+	EchoChallengeDEF   = 3
+	EchoChallengeTurns = 5
+	EchoFarsightRange  = 20 // sight range an oracle echo gains from Farsight
+)
+
+// EchoCast is a class ability copied by an echo during ProcessAI.
+type EchoCast struct {
+	Echo    ecs.EntityID
+	Glyph   string
+	ClassID string
+}
+
+// AttuneEchoes gives every echo that has no class yet one of classIDs to
+// mirror, taking them in turn so a coop floor's echoes copy both players.
+// Returns how many echoes were attuned.
+func AttuneEchoes(w *ecs.World, classIDs []string) int {
+	if len(classIDs) == 0 {
+		return 0
+	}
+	n := 0
+	for _, id := range w.Query(component.CEcho) {
+		e := w.Get(id, component.CEcho).(component.Echo)
+		if e.ClassID != "" {
+			continue
+		}
+		e.ClassID = classIDs[n%len(classIDs)]
+		w.Add(id, e)
+		n++
+	}
+	return n
+}
+
+// echoAbility spends echo id's turn on its copy of a class ability when its
+// cooldown is up and the copy would help: the movement abilities close the
+// gap to a distant target, the buffs fire when it would otherwise attack.
+// Returns false, and lets the echo act normally, when it does not cast.
+func echoAbility(w *ecs.World, gmap *gamemap.GameMap, id ecs.EntityID, pos, targetPos component.Position) bool {
+	ec := w.Get(id, component.CEcho)
+	if ec == nil {
+		return false
+	}
+	e := ec.(component.Echo)
+	if e.ClassID == "" || e.Pending {
+		return false
+	}
+	if e.Cooldown > 0 {
+		e.Cooldown--
+		w.Add(id, e)
+		return false
+	}
+	if !castEcho(w, gmap, id, e.ClassID, pos, targetPos) {
+		return false
+	}
+	e.Pending = true
+	e.Cooldown = e.Every
+	w.Add(id, e)
+	return true
+}
+
+// castEcho applies classID's ability to echo id, if it is worth casting now.
+func castEcho(w *ecs.World, gmap *gamemap.GameMap, id ecs.EntityID, classID string, pos, targetPos component.Position) bool {
+	adjacent := abs(targetPos.X-pos.X) <= 1 && abs(targetPos.Y-pos.Y) <= 1
+	switch classID {
+	case "arcanist": // Dimensional Rift: step through to the target's side
+		if adjacent {
+			return false
+		}
+		x, y, ok := FindDeploySpot(w, gmap, targetPos)
+		if !ok {
+			return false
+		}
+		w.Add(id, component.Position{X: x, Y: y})
+	case "dancer": // Vanish: fade from sight while closing in
+		ai := w.Get(id, component.CAI).(component.AI)
+		if adjacent || ai.Ambush {
+			return false
+		}
+		ai.Ambush = true
+		w.Add(id, ai)
+	case "oracle": // Farsight: spot the target from across the floor
+		ai := w.Get(id, component.CAI).(component.AI)
+		if ai.SightRange >= EchoFarsightRange {
+			return false
+		}
+		ai.SightRange = EchoFarsightRange
+		w.Add(id, ai)
+	case "revenant": // Death's Bargain: blood for fury
+		hc := w.Get(id, component.CHealth)
+		if !adjacent || hc == nil || hc.(component.Health).Current <= EchoBargainCost {
+			return false
+		}
+		hp := hc.(component.Health)
+		hp.Current -= EchoBargainCost
+		w.Add(id, hp)
+		ApplyEffect(w, id, component.ActiveEffect{Kind: component.EffectAttackBoost, Magnitude: EchoBargainATK, TurnsRemaining: EchoBargainTurns})
+	case "construct": // Overclock
+		if !adjacent {
+			return false
+		}
+		ApplyEffect(w, id, component.ActiveEffect{Kind: component.EffectAttackBoost, Magnitude: EchoOverclockATK, TurnsRemaining: EchoOverclockTurns})
+	case "symbiont": // Parasite Surge
+		if !adjacent {
+			return false
+		}
+		if hc := w.Get(id, component.CHealth); hc != nil {
+			hp := hc.(component.Health)
+			hp.Current = min(hp.Current+EchoSurgeHeal, hp.Max)
+			w.Add(id, hp)
+		}
+		ApplyEffect(w, id, component.ActiveEffect{Kind: component.EffectAttackBoost, Magnitude: EchoSurgeATK, TurnsRemaining: EchoSurgeTurns})
+	case "warden": // Challenge: brace for the blow
+		if !adjacent {
+			return false
+		}
+		ApplyEffect(w, id, component.ActiveEffect{Kind: component.EffectDefenseBoost, Magnitude: EchoChallengeDEF, TurnsRemaining: EchoChallengeTurns})
+	default: // the tinker's turret has no echo: it fights normally
+		return false
+	}
+	return true
+}
+
+// CollectEchoes returns every echo cast made since the last call and clears
+// it from its echo. An echo killed before collection leaves nothing behind.
+func CollectEchoes(w *ecs.World) []EchoCast {
+	var out []EchoCast
+	for _, id := range w.Query(component.CEcho) {
+		e := w.Get(id, component.CEcho).(component.Echo)
+		if !e.Pending {
+			continue
+		}
+		out = append(out, EchoCast{Echo: id, Glyph: enemyGlyph(w, id), ClassID: e.ClassID})
+		e.Pending = false
+		w.Add(id, e)
+	}
+	return out
+}
@@ -0,0 +1,98 @@
+package system
+
+import (
+	"math/rand"
+	"testing"
+
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+func addEcho(w *ecs.World, x, y int, classID string, every int) ecs.EntityID {
+	id := addEnemy(w, x, y, component.BehaviorChase, 8)
+	w.Add(id, component.Echo{ClassID: classID, Every: every})
+	return id
+}
+
+func TestAttuneEchoesTakesClassesInTurn(t *testing.T) {
+	w := ecs.NewWorld()
+	a := addEcho(w, 1, 1, "", 4)
+	b := addEcho(w, 2, 2, "", 4)
+	tagged := addEcho(w, 3, 3, "warden", 4)
+
+	if n := AttuneEchoes(w, []string{"arcanist", "oracle"}); n != 2 {
+		t.Errorf("attuned %d echoes; want 2", n)
+	}
+	got := map[string]bool{}
+	for _, id := range []ecs.EntityID{a, b} {
+		got[w.Get(id, component.CEcho).(component.Echo).ClassID] = true
+	}
+	if !got["arcanist"] || !got["oracle"] {
+		t.Errorf("echo classes = %v; want one of each player's class", got)
+	}
+	if c := w.Get(tagged, component.CEcho).(component.Echo).ClassID; c != "warden" {
+		t.Errorf("already attuned echo changed to %q", c)
+	}
+}
+
+func TestArcanistEchoRiftsBesidePlayer(t *testing.T) {
+	w, gmap, player := newAIWorld(2, 2)
+	echo := addEcho(w, 9, 2, "arcanist", 5)
+
+	ProcessAI(w, gmap, []ecs.EntityID{player}, rand.New(rand.NewSource(1)))
+	pos := w.Get(echo, component.CPosition).(component.Position)
+	if abs(pos.X-2) > 1 || abs(pos.Y-2) > 1 {
+		t.Errorf("arcanist echo at %v; want beside the player at (2,2)", pos)
+	}
+	casts := CollectEchoes(w)
+	if len(casts) != 1 || casts[0].Echo != echo || casts[0].ClassID != "arcanist" {
+		t.Fatalf("casts = %+v; want one arcanist cast", casts)
+	}
+	if len(CollectEchoes(w)) != 0 {
+		t.Error("a cast should be collected only once")
+	}
+	if e := w.Get(echo, component.CEcho).(component.Echo); e.Cooldown != 5 {
+		t.Errorf("cooldown = %d; want Every (5)", e.Cooldown)
+	}
+}
+
+func TestBuffEchoCastsOnlyWhenItWouldAttack(t *testing.T) {
+	w, gmap, player := newAIWorld(2, 2)
+	echo := addEcho(w, 8, 2, "warden", 5)
+
+	ProcessAI(w, gmap, []ecs.EntityID{player}, rand.New(rand.NewSource(1)))
+	if len(CollectEchoes(w)) != 0 || HasEffect(w, echo, component.EffectDefenseBoost) {
+		t.Fatal("warden echo out of reach should close in, not brace")
+	}
+
+	w.Add(echo, component.Position{X: 3, Y: 2})
+	hits := ProcessAI(w, gmap, []ecs.EntityID{player}, rand.New(rand.NewSource(1)))
+	if len(CollectEchoes(w)) != 1 || !HasEffect(w, echo, component.EffectDefenseBoost) {
+		t.Error("adjacent warden echo should copy Challenge")
+	}
+	if len(hits) != 0 {
+		t.Error("casting should spend the echo's turn instead of attacking")
+	}
+}
+
+func TestRevenantEchoPaysHPForATK(t *testing.T) {
+	w, gmap, player := newAIWorld(2, 2)
+	echo := addEcho(w, 3, 2, "revenant", 5)
+
+	ProcessAI(w, gmap, []ecs.EntityID{player}, rand.New(rand.NewSource(1)))
+	if hp := w.Get(echo, component.CHealth).(component.Health).Current; hp != 20-EchoBargainCost {
+		t.Errorf("HP = %d; want %d after Death's Bargain", hp, 20-EchoBargainCost)
+	}
+	if GetAttackBonus(w, echo) != EchoBargainATK {
+		t.Errorf("attack bonus = %d; want %d", GetAttackBonus(w, echo), EchoBargainATK)
+	}
+}
+
+func TestUnattunedEchoFightsNormally(t *testing.T) {
+	w, gmap, player := newAIWorld(2, 2)
+	addEcho(w, 3, 2, "", 0)
+	hits := ProcessAI(w, gmap, []ecs.EntityID{player}, rand.New(rand.NewSource(1)))
+	if len(CollectEchoes(w)) != 0 || len(hits) != 1 {
+		t.Errorf("unattuned echo: %d hits; want a plain attack", len(hits))
+	}
+}
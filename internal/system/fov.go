@@ -23,20 +23,110 @@ var octants = [8][4]int{
 	{1, 0, 0, -1},
 }
 
-// UpdateFOV resets visibility and runs recursive shadowcasting from the player.
+// FOVAlgorithm selects how a player's field of view is computed.
+type FOVAlgorithm uint8
+
+const (
+	// FOVShadowcast is recursive shadowcasting, the default. It is
+	// permissive near walls, so one tile may see another that cannot see
+	// it back.
+	FOVShadowcast FOVAlgorithm = iota
+	// FOVSymmetric is symmetric shadowcasting: a floor tile that can see
+	// another is always seen by it, so any open tile the player sees has
+	// the player in view too.
+	FOVSymmetric
+)
+
+// String names the algorithm for the settings menu.
+func (a FOVAlgorithm) String() string {
+	if a == FOVSymmetric {
+		return "Symmetric"
+	}
+	return "Shadowcast"
+}
+
+// UpdateFOV resets visibility and runs recursive shadowcasting from the player,
+// then refreshes the player's memory of enemies and items in view.
 func UpdateFOV(w *ecs.World, gmap *gamemap.GameMap, playerID ecs.EntityID, radius int) {
-	// Clear current visibility.
+	UpdateFOVWith(w, gmap, playerID, radius, FOVShadowcast)
+}
+
+// UpdateFOVWith is UpdateFOV computing the field of view with algo.
+func UpdateFOVWith(w *ecs.World, gmap *gamemap.GameMap, playerID ecs.EntityID, radius int, algo FOVAlgorithm) {
+	clearVisible(gmap)
+	addFOV(w, gmap, playerID, radius, algo)
+	rememberSighted(w, gmap, playerID)
+}
+
+// UpdateSharedFOV resets visibility and marks every tile seen by any of the
+// given players, so one view can show the union of their sight. radii[i] is
+// the FOV radius of playerIDs[i].
+func UpdateSharedFOV(w *ecs.World, gmap *gamemap.GameMap, playerIDs []ecs.EntityID, radii []int) {
+	clearVisible(gmap)
+	for i, id := range playerIDs {
+		addFOV(w, gmap, id, radii[i], FOVShadowcast)
+	}
+	for _, id := range playerIDs {
+		rememberSighted(w, gmap, id)
+	}
+}
+
+// rememberSighted updates playerID's SightMemory from the tiles now visible:
+// entries on visible tiles are forgotten, then every hostile enemy and item in
+// view is recorded at its position, enemies taking precedence over items.
+func rememberSighted(w *ecs.World, gmap *gamemap.GameMap, playerID ecs.EntityID) {
+	if w.Get(playerID, component.CPosition) == nil {
+		return
+	}
+	mem, ok := w.Get(playerID, component.CSightMemory).(component.SightMemory)
+	if !ok {
+		mem = component.SightMemory{Seen: make(map[component.Position]string)}
+		w.Add(playerID, mem)
+	}
+	for pos := range mem.Seen {
+		if gmap.InBounds(pos.X, pos.Y) && gmap.At(pos.X, pos.Y).Visible {
+			delete(mem.Seen, pos)
+		}
+	}
+	for _, id := range w.Query(component.CPosition, component.CRenderable) {
+		pos := w.Get(id, component.CPosition).(component.Position)
+		if !gmap.InBounds(pos.X, pos.Y) || !gmap.At(pos.X, pos.Y).Visible {
+			continue
+		}
+		glyph := w.Get(id, component.CRenderable).(component.Renderable).Glyph
+		if ac := w.Get(id, component.CAI); ac != nil && ac.(component.AI).Behavior != component.BehaviorAlly {
+			if !Concealed(w, playerID, id) {
+				mem.Seen[pos] = glyph
+			}
+		} else if w.Has(id, component.CItem) {
+			if _, taken := mem.Seen[pos]; !taken {
+				mem.Seen[pos] = glyph
+			}
+		}
+	}
+}
+
+// clearVisible marks every tile not visible.
+func clearVisible(gmap *gamemap.GameMap) {
 	for y := 0; y < gmap.Height; y++ {
 		for x := 0; x < gmap.Width; x++ {
 			gmap.At(x, y).Visible = false
 		}
 	}
+}
 
+// addFOV marks the tiles the player can see with algo visible and explored,
+// leaving tiles that are already visible untouched. A Darkness floor caps
+// radius at DarkSightRadius.
+func addFOV(w *ecs.World, gmap *gamemap.GameMap, playerID ecs.EntityID, radius int, algo FOVAlgorithm) {
 	posComp := w.Get(playerID, component.CPosition)
 	if posComp == nil {
 		return
 	}
 	pos := posComp.(component.Position)
+	if gmap.Affix == gamemap.AffixDarkness {
+		radius = min(radius, DarkSightRadius)
+	}
 
 	// Origin is always visible.
 	if gmap.InBounds(pos.X, pos.Y) {
@@ -45,6 +135,10 @@ func UpdateFOV(w *ecs.World, gmap *gamemap.GameMap, playerID ecs.EntityID, radiu
 		t.Explored = true
 	}
 
+	if algo == FOVSymmetric {
+		castSymmetric(gmap, pos.X, pos.Y, radius)
+		return
+	}
 	// Cast light in all 8 octants.
 	for _, m := range octants {
 		castLight(gmap, pos.X, pos.Y, 1, 1.0, 0.0, radius, m[0], m[1], m[2], m[3])
@@ -121,3 +215,31 @@ func castLight(gmap *gamemap.GameMap, cx, cy, row int, start, end float64, radiu
 		}
 	}
 }
+
+// HasLineOfSight reports whether (x1, y1) can be seen from (x0, y0): every
+// tile strictly between the two points along a Bresenham line must be
+// transparent. The endpoints themselves are not tested, so an entity standing
+// in a doorway can still see and be seen.
+func HasLineOfSight(gmap *gamemap.GameMap, x0, y0, x1, y1 int) bool {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := sign(x1-x0), sign(y1-y0)
+	err := dx + dy
+	x, y := x0, y0
+	for {
+		if x == x1 && y == y1 {
+			return true
+		}
+		if (x != x0 || y != y0) && !gmap.IsTransparent(x, y) {
+			return false
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y += sy
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package system
+
+import "emoji-roguelike/internal/gamemap"
+
+// slope is an exact fraction num/den (den > 0). Symmetric shadowcasting
+// tracks the edges of its beams with these so that tile corners never round
+// the wrong way.
+type slope struct{ num, den int }
+
+// castSymmetric lights the tiles visible from (cx, cy) with symmetric
+// shadowcasting (after Albert Ford): each of the four quadrants is scanned
+// row by row, and a floor tile is lit only if its centre lies inside the
+// beam. That is what makes it symmetric: if a floor tile at A can see one at
+// B, B can see A. Walls are lit whenever the beam touches them, so rooms
+// still show their outlines.
+func castSymmetric(gmap *gamemap.GameMap, cx, cy, radius int) {
+	for q := range 4 {
+		scanRow(gmap, cx, cy, radius, q, 1, slope{-1, 1}, slope{1, 1})
+	}
+}
+
+// quadrantTile maps (depth, col) in quadrant q to world coordinates. depth
+// counts rows away from the origin and col runs across the row.
+func quadrantTile(cx, cy, q, depth, col int) (int, int) {
+	switch q {
+	case 0: // north
+		return cx + col, cy - depth
+	case 1: // east
+		return cx + depth, cy + col
+	case 2: // south
+		return cx + col, cy + depth
+	}
+	return cx - depth, cy + col // west
+}
+
+// scanRow lights the row at depth in quadrant q between the start and end
+// slopes, then scans the rows beyond each stretch of it that is not wall.
+func scanRow(gmap *gamemap.GameMap, cx, cy, radius, q, depth int, start, end slope) {
+	if depth > radius {
+		return
+	}
+	// The row's first and last columns: depth*start rounded with ties up,
+	// depth*end rounded with ties down.
+	minCol := floorDiv(2*depth*start.num+start.den, 2*start.den)
+	maxCol := -floorDiv(-(2*depth*end.num - end.den), 2*end.den)
+
+	seen, prevWall := false, false
+	for col := minCol; col <= maxCol; col++ {
+		x, y := quadrantTile(cx, cy, q, depth, col)
+		wall := !gmap.InBounds(x, y) || !gmap.IsTransparent(x, y)
+		centred := col*start.den >= depth*start.num && col*end.den <= depth*end.num
+		if (wall || centred) && col*col+depth*depth < radius*radius && gmap.InBounds(x, y) {
+			t := gmap.At(x, y)
+			t.Visible = true
+			t.Explored = true
+		}
+		if seen && prevWall && !wall {
+			start = slope{2*col - 1, 2 * depth}
+		}
+		if seen && !prevWall && wall {
+			scanRow(gmap, cx, cy, radius, q, depth+1, start, slope{2*col - 1, 2 * depth})
+		}
+		seen, prevWall = true, wall
+	}
+	if seen && !prevWall {
+		scanRow(gmap, cx, cy, radius, q, depth+1, start, end)
+	}
+}
+
+// floorDiv returns a/b rounded down, for b > 0.
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+	return q
+}
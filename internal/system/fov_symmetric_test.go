@@ -0,0 +1,93 @@
+package system
+
+import (
+	"math/rand"
+	"testing"
+
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+)
+
+// scatteredWallsMap returns a w×h open map with about pct percent of its
+// tiles walled off at random.
+func scatteredWallsMap(seed int64, w, h, pct int) *gamemap.GameMap {
+	rng := rand.New(rand.NewSource(seed))
+	gmap := openMapFOV(w, h)
+	for y := range h {
+		for x := range w {
+			if rng.Intn(100) < pct {
+				gmap.Set(x, y, gamemap.MakeWall())
+			}
+		}
+	}
+	return gmap
+}
+
+// asymmetricPairs counts the pairs of floor tiles on gmap where one sees the
+// other with algo but is not seen back.
+func asymmetricPairs(gmap *gamemap.GameMap, radius int, algo FOVAlgorithm) int {
+	type tile struct{ x, y int }
+	var floors []tile
+	for y := range gmap.Height {
+		for x := range gmap.Width {
+			if gmap.IsTransparent(x, y) {
+				floors = append(floors, tile{x, y})
+			}
+		}
+	}
+	sees := make(map[[2]tile]bool)
+	w := ecs.NewWorld()
+	for _, from := range floors {
+		UpdateFOVWith(w, gmap, makePlayerAt(w, from.x, from.y), radius, algo)
+		for _, to := range floors {
+			sees[[2]tile{from, to}] = gmap.At(to.x, to.y).Visible
+		}
+	}
+	n := 0
+	for pair, ok := range sees {
+		if ok && !sees[[2]tile{pair[1], pair[0]}] {
+			n++
+		}
+	}
+	return n
+}
+
+func TestSymmetricFOVIsMutual(t *testing.T) {
+	maps := map[string]*gamemap.GameMap{
+		"open":      openMapFOV(15, 12),
+		"pillars":   scatteredWallsMap(1, 18, 14, 15),
+		"cluttered": scatteredWallsMap(2, 18, 14, 30),
+		"maze-like": scatteredWallsMap(3, 18, 14, 45),
+	}
+	shadowcastAsymmetric := 0
+	for name, gmap := range maps {
+		if n := asymmetricPairs(gmap, 8, FOVSymmetric); n != 0 {
+			t.Errorf("%s: %d one-way sight lines with symmetric FOV; want none", name, n)
+		}
+		shadowcastAsymmetric += asymmetricPairs(gmap, 8, FOVShadowcast)
+	}
+	if shadowcastAsymmetric == 0 {
+		t.Error("shadowcasting was symmetric on every map; these maps no longer tell the algorithms apart")
+	}
+}
+
+func TestSymmetricFOVWallBlocksLight(t *testing.T) {
+	gmap := openMapFOV(20, 20)
+	gmap.Set(10, 8, gamemap.MakeWall())
+	w := ecs.NewWorld()
+	player := makePlayerAt(w, 10, 10)
+
+	UpdateFOVWith(w, gmap, player, 8, FOVSymmetric)
+	if !gmap.At(10, 8).Visible {
+		t.Error("the wall tile at (10,8) should be visible")
+	}
+	if gmap.At(10, 7).Visible {
+		t.Error("tile (10,7) behind the wall at (10,8) should not be visible")
+	}
+	if !gmap.At(10, 10).Visible || !gmap.At(17, 10).Visible {
+		t.Error("the origin and open tiles within the radius should be visible")
+	}
+	if gmap.At(18, 10).Visible {
+		t.Error("tile (18,10) lies on the radius and should not be visible")
+	}
+}
@@ -59,6 +59,25 @@ func TestFOVClearsOldVisibility(t *testing.T) {
 	}
 }
 
+func TestSharedFOVIsUnionOfPlayers(t *testing.T) {
+	gmap := openMapFOV(30, 10)
+	w := ecs.NewWorld()
+	a := makePlayerAt(w, 2, 5)
+	b := makePlayerAt(w, 27, 5)
+
+	UpdateSharedFOV(w, gmap, []ecs.EntityID{a, b}, []int{3, 3})
+
+	if !gmap.At(3, 5).Visible || !gmap.At(26, 5).Visible {
+		t.Error("tiles near either player should be visible")
+	}
+	if gmap.At(15, 5).Visible {
+		t.Error("a tile out of both players' sight should stay dark")
+	}
+	if !gmap.At(3, 5).Explored || !gmap.At(26, 5).Explored {
+		t.Error("both players' sight should be marked explored")
+	}
+}
+
 func TestFOVNearbyTilesVisible(t *testing.T) {
 	// Tiles at cardinal distance 3 on a fully open map must be lit with radius=5.
 	// The FOV radius condition is: dx²+dy² < radius² → 9 < 25 → true.
@@ -125,3 +144,96 @@ func TestFOVNoPlayerPositionNoPanic(t *testing.T) {
 
 	UpdateFOV(w, gmap, player, 5) // must not panic
 }
+
+func TestHasLineOfSight(t *testing.T) {
+	gmap := openMapFOV(20, 20)
+	gmap.Set(10, 8, gamemap.MakeWall())
+
+	if HasLineOfSight(gmap, 10, 10, 10, 5) {
+		t.Error("wall at (10,8) should block sight from (10,10) to (10,5)")
+	}
+	if !HasLineOfSight(gmap, 10, 10, 15, 10) {
+		t.Error("open row should have line of sight")
+	}
+	if !HasLineOfSight(gmap, 10, 10, 10, 8) {
+		t.Error("a wall endpoint itself should be visible")
+	}
+	if HasLineOfSight(gmap, 10, 5, 10, 10) != HasLineOfSight(gmap, 10, 10, 10, 5) {
+		t.Error("line of sight along a straight line should be symmetric")
+	}
+}
+
+func seen(w *ecs.World, player ecs.EntityID) map[component.Position]string {
+	return w.Get(player, component.CSightMemory).(component.SightMemory).Seen
+}
+
+func TestFOVRemembersEnemiesAndItemsOutOfSight(t *testing.T) {
+	gmap := openMapFOV(30, 10)
+	w := ecs.NewWorld()
+	player := makePlayerAt(w, 5, 5)
+	enemy := w.CreateEntity()
+	w.Add(enemy, component.Position{X: 8, Y: 5})
+	w.Add(enemy, component.Renderable{Glyph: "🦀"})
+	w.Add(enemy, component.AI{Behavior: component.BehaviorChase})
+	item := w.CreateEntity()
+	w.Add(item, component.Position{X: 5, Y: 8})
+	w.Add(item, component.Renderable{Glyph: "🧪"})
+	w.Add(item, component.CItemComp{})
+	ally := w.CreateEntity()
+	w.Add(ally, component.Position{X: 3, Y: 5})
+	w.Add(ally, component.Renderable{Glyph: "🤖"})
+	w.Add(ally, component.AI{Behavior: component.BehaviorAlly})
+
+	UpdateFOV(w, gmap, player, 6)
+	mem := seen(w, player)
+	if mem[component.Position{X: 8, Y: 5}] != "🦀" || mem[component.Position{X: 5, Y: 8}] != "🧪" {
+		t.Fatalf("enemy and item in view should be remembered; got %v", mem)
+	}
+	if _, ok := mem[component.Position{X: 3, Y: 5}]; ok {
+		t.Error("allies should not be remembered")
+	}
+
+	// Walk away: both leave view but stay remembered where they were.
+	w.Add(player, component.Position{X: 25, Y: 5})
+	UpdateFOV(w, gmap, player, 6)
+	if gmap.At(8, 5).Visible {
+		t.Fatal("test setup: (8,5) should be out of sight")
+	}
+	if seen(w, player)[component.Position{X: 8, Y: 5}] != "🦀" {
+		t.Error("enemy should be remembered after leaving view")
+	}
+}
+
+func TestFOVForgetsEntitiesProvenGone(t *testing.T) {
+	gmap := openMapFOV(30, 10)
+	w := ecs.NewWorld()
+	player := makePlayerAt(w, 25, 5)
+	w.Add(player, component.SightMemory{Seen: map[component.Position]string{
+		{X: 8, Y: 5}:  "🦀", // back in view and empty: forgotten
+		{X: 24, Y: 5}: "🦀", // in view and empty: forgotten
+		{X: 2, Y: 5}:  "🧪", // still out of view: kept
+	}})
+	enemy := w.CreateEntity()
+	w.Add(enemy, component.Position{X: 22, Y: 5}) // moved while unseen
+	w.Add(enemy, component.Renderable{Glyph: "🦀"})
+	w.Add(enemy, component.AI{Behavior: component.BehaviorChase})
+
+	w.Add(player, component.Position{X: 16, Y: 5})
+	UpdateFOV(w, gmap, player, 10)
+	mem := seen(w, player)
+	if _, ok := mem[component.Position{X: 8, Y: 5}]; ok {
+		t.Error("a memory whose tile is in view with nothing there should be cleared")
+	}
+	if _, ok := mem[component.Position{X: 24, Y: 5}]; ok {
+		t.Error("the enemy's old position should be cleared once seen empty")
+	}
+	if mem[component.Position{X: 22, Y: 5}] != "🦀" {
+		t.Error("the enemy should be remembered at its new position")
+	}
+	if gmap.At(2, 5).Visible {
+		t.Fatal("test setup: (2,5) should be out of sight")
+	}
+	if mem[component.Position{X: 2, Y: 5}] != "🧪" {
+		t.Error("a memory out of view should be kept")
+	}
+}
@@ -0,0 +1,19 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+// CollectGold removes every gold pile at (x, y) and returns their total.
+func CollectGold(w *ecs.World, x, y int) int {
+	total := 0
+	for _, id := range w.Query(component.CGoldPile, component.CPosition) {
+		if p := w.Get(id, component.CPosition).(component.Position); p.X != x || p.Y != y {
+			continue
+		}
+		total += w.Get(id, component.CGoldPile).(component.GoldPile).Amount
+		w.DestroyEntity(id)
+	}
+	return total
+}
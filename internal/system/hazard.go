@@ -0,0 +1,59 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+// HazardHit reports damage a hazard dealt to the entity standing in it.
+type HazardHit struct {
+	Target ecs.EntityID
+	Glyph  string // the victim's glyph
+	Hazard string // the hazard's name, e.g. "acid pool"
+	Damage int
+	Killed bool               // an enemy the hazard killed; it has been destroyed
+	Pos    component.Position // where the victim stood
+	Loot   component.Loot     // a killed enemy's loot table, captured before destruction
+}
+
+// TickHazards hurts every creature standing in a hazard, ignoring defense,
+// then ages each hazard by a turn and removes the spent ones. Enemies it
+// kills are destroyed; a player at 0 HP is left for the caller's death
+// check. Call it once per world tick, alongside damage over time.
+func TickHazards(w *ecs.World) []HazardHit {
+	var hits []HazardHit
+	for _, hid := range w.Query(component.CHazard, component.CPosition) {
+		hazard := w.Get(hid, component.CHazard).(component.Hazard)
+		pos := w.Get(hid, component.CPosition).(component.Position)
+		for _, id := range w.Query(component.CHealth, component.CPosition) {
+			if w.Get(id, component.CPosition).(component.Position) != pos || !hazardVictim(w, id) {
+				continue
+			}
+			hp := w.Get(id, component.CHealth).(component.Health)
+			hp.Current -= hazard.Damage
+			w.Add(id, hp)
+			hit := HazardHit{Target: id, Glyph: enemyGlyph(w, id), Hazard: hazard.Name, Damage: hazard.Damage, Pos: pos}
+			if hp.Current <= 0 && !w.Has(id, component.CTagPlayer) {
+				hit.Killed = true
+				if lc := w.Get(id, component.CLoot); lc != nil {
+					hit.Loot = lc.(component.Loot)
+				}
+				w.DestroyEntity(id)
+			}
+			hits = append(hits, hit)
+		}
+		hazard.TurnsLeft--
+		if hazard.TurnsLeft <= 0 {
+			w.DestroyEntity(hid)
+		} else {
+			w.Add(hid, hazard)
+		}
+	}
+	return hits
+}
+
+// hazardVictim reports whether id is a creature a hazard can hurt: a player
+// or an AI-driven enemy or ally, not furniture or a turret.
+func hazardVictim(w *ecs.World, id ecs.EntityID) bool {
+	return w.Has(id, component.CTagPlayer) || w.Has(id, component.CAI)
+}
@@ -0,0 +1,71 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"testing"
+)
+
+// addHazard places a hazard dealing damage for turns turns at (x, y).
+func addHazard(w *ecs.World, x, y, damage, turns int) ecs.EntityID {
+	id := w.CreateEntity()
+	w.Add(id, component.Position{X: x, Y: y})
+	w.Add(id, component.Hazard{Name: "acid pool", Damage: damage, TurnsLeft: turns})
+	return id
+}
+
+func TestTickHazardsHurtsOccupantsAndExpires(t *testing.T) {
+	w, _, player := newAIWorld(5, 5)
+	hazard := addHazard(w, 5, 5, 3, 2)
+	bystander := addEnemy(w, 6, 5, component.BehaviorChase, 5)
+
+	hits := TickHazards(w)
+	if len(hits) != 1 || hits[0].Target != player || hits[0].Damage != 3 || hits[0].Killed {
+		t.Fatalf("hits = %+v; want 3 damage to the player only", hits)
+	}
+	if hp := w.Get(player, component.CHealth).(component.Health).Current; hp != 27 {
+		t.Errorf("player HP = %d; want 27", hp)
+	}
+	if hp := w.Get(bystander, component.CHealth).(component.Health).Current; hp != 20 {
+		t.Errorf("an enemy outside the hazard lost HP: %d", hp)
+	}
+
+	TickHazards(w)
+	if w.Alive(hazard) {
+		t.Error("the hazard should be gone once its turns run out")
+	}
+	if hits := TickHazards(w); len(hits) != 0 {
+		t.Errorf("an expired hazard still hurt %+v", hits)
+	}
+}
+
+func TestTickHazardsKillsEnemies(t *testing.T) {
+	w, _, _ := newAIWorld(1, 1)
+	enemy := addEnemy(w, 5, 5, component.BehaviorChase, 5)
+	w.Add(enemy, component.Health{Current: 2, Max: 20})
+	addHazard(w, 5, 5, 2, 3)
+
+	hits := TickHazards(w)
+	if len(hits) != 1 || !hits[0].Killed || hits[0].Glyph != "🦀" {
+		t.Fatalf("hits = %+v; want the crab killed", hits)
+	}
+	if hits[0].Pos != (component.Position{X: 5, Y: 5}) {
+		t.Errorf("kill reported at %+v; want (5,5) for its drops", hits[0].Pos)
+	}
+	if w.Alive(enemy) {
+		t.Error("an enemy killed by a hazard should be destroyed")
+	}
+}
+
+func TestTickHazardsLeavesDyingPlayerForDeathCheck(t *testing.T) {
+	w, _, player := newAIWorld(5, 5)
+	w.Add(player, component.Health{Current: 1, Max: 30})
+	addHazard(w, 5, 5, 5, 1)
+
+	if hits := TickHazards(w); len(hits) != 1 || hits[0].Killed {
+		t.Fatalf("hits = %+v; a player is never reported killed", hits)
+	}
+	if !w.Alive(player) {
+		t.Error("the player entity should survive for the caller's death check")
+	}
+}
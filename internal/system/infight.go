@@ -0,0 +1,34 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+	"math/rand"
+)
+
+// infight has enemy id attack an adjacent enemy of a rival faction, if any.
+// Reports whether it attacked, spending its turn.
+func infight(w *ecs.World, gmap *gamemap.GameMap, id ecs.EntityID, pos component.Position, rng *rand.Rand) bool {
+	fc := w.Get(id, component.CFaction)
+	if fc == nil {
+		return false
+	}
+	side := fc.(component.Faction).ID
+	for _, other := range w.Query(component.CFaction, component.CPosition) {
+		if other == id || w.Get(other, component.CFaction).(component.Faction).ID == side {
+			continue
+		}
+		op := w.Get(other, component.CPosition).(component.Position)
+		dx, dy := op.X-pos.X, op.Y-pos.Y
+		if abs(dx) > 1 || abs(dy) > 1 {
+			continue
+		}
+		// TryMove settles whether the rival can be reached, corners included.
+		if res, target := TryMove(w, gmap, id, dx, dy); res == MoveAttack && target == other {
+			Attack(w, rng, id, other)
+			return true
+		}
+	}
+	return false
+}
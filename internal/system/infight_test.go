@@ -0,0 +1,60 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"math/rand"
+	"testing"
+)
+
+// rivalPair places a construct and an organic side by side at (15,15) and
+// (16,15), far from a player at (1,1).
+func rivalPair(t *testing.T, infighting bool) (w *ecs.World, player, golem, leech ecs.EntityID, runAI func()) {
+	t.Helper()
+	w, gmap, player := newAIWorld(1, 1)
+	gmap.Infighting = infighting
+	golem = addEnemy(w, 15, 15, component.BehaviorChase, 6)
+	w.Add(golem, component.Faction{ID: component.FactionConstruct})
+	leech = addEnemy(w, 16, 15, component.BehaviorChase, 6)
+	w.Add(leech, component.Faction{ID: component.FactionOrganic})
+	rng := rand.New(rand.NewSource(1))
+	return w, player, golem, leech, func() { ProcessAI(w, gmap, []ecs.EntityID{player}, rng) }
+}
+
+func hpOf(w *ecs.World, id ecs.EntityID) int {
+	return w.Get(id, component.CHealth).(component.Health).Current
+}
+
+func TestRivalFactionsInfight(t *testing.T) {
+	w, _, golem, leech, runAI := rivalPair(t, true)
+	runAI()
+	if hpOf(w, golem) == 20 || hpOf(w, leech) == 20 {
+		t.Errorf("rivals left each other alone: golem %d HP, leech %d HP", hpOf(w, golem), hpOf(w, leech))
+	}
+}
+
+func TestInfightingOffByDefault(t *testing.T) {
+	w, _, golem, leech, runAI := rivalPair(t, false)
+	runAI()
+	if hpOf(w, golem) != 20 || hpOf(w, leech) != 20 {
+		t.Error("rivals fought on a floor without infighting")
+	}
+}
+
+func TestSameFactionDoesNotInfight(t *testing.T) {
+	w, _, golem, leech, runAI := rivalPair(t, true)
+	w.Add(leech, component.Faction{ID: component.FactionConstruct})
+	runAI()
+	if hpOf(w, golem) != 20 || hpOf(w, leech) != 20 {
+		t.Error("enemies of one faction fought each other")
+	}
+}
+
+func TestPlayerInRangeTakesPriorityOverInfighting(t *testing.T) {
+	w, player, golem, leech, runAI := rivalPair(t, true)
+	w.Add(player, component.Position{X: 15, Y: 13})
+	runAI()
+	if hpOf(w, golem) != 20 || hpOf(w, leech) != 20 {
+		t.Error("rivals fought while a player was in sight")
+	}
+}
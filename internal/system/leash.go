@@ -0,0 +1,66 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+)
+
+// LeashRegenPct is the share of its MaxHP a leashed enemy regains each turn
+// it spends walking home.
+const LeashRegenPct = 10
+
+// leashHome spends enemy id's turn walking back toward its home once it has
+// strayed further than the map's leash, regaining HP as it goes. The enemy
+// ignores players until it is home again, then is fully healed. Returns false,
+// leaving the enemy to act normally, when it is within its leash.
+func leashHome(w *ecs.World, gmap *gamemap.GameMap, id ecs.EntityID, pos component.Position) bool {
+	if gmap.Leash <= 0 {
+		return false
+	}
+	ai := w.Get(id, component.CAI).(component.AI)
+	if !ai.Returning {
+		if max(abs(pos.X-ai.Home.X), abs(pos.Y-ai.Home.Y)) <= gmap.Leash {
+			return false
+		}
+		ai.Returning = true
+		ai.Memory = 0
+		ai.Path = nil
+	}
+
+	hc := w.Get(id, component.CHealth)
+	if pos == ai.Home {
+		ai.Returning = false
+		w.Add(id, ai)
+		if hc != nil {
+			hp := hc.(component.Health)
+			hp.Current = hp.Max
+			w.Add(id, hp)
+		}
+		return true
+	}
+	if hc != nil {
+		hp := hc.(component.Health)
+		hp.Current = min(hp.Current+max(hp.Max*LeashRegenPct/100, 1), hp.Max)
+		w.Add(id, hp)
+	}
+
+	if pathStale(ai, pos, ai.Home) {
+		ai.Path = FindPath(gmap, pos, ai.Home, ChasePathNodes)
+		ai.PathGoal = ai.Home
+	}
+	if len(ai.Path) == 0 {
+		ai.Returning = false // no way home: take up the chase where it stands
+		ai.Home = pos
+		w.Add(id, ai)
+		return false
+	}
+	next := ai.Path[0]
+	if TryMoveSimple(w, gmap, id, next.X-pos.X, next.Y-pos.Y) == MoveOK {
+		ai.Path = ai.Path[1:]
+	} else {
+		ai.Path = nil // something stands in the way; replan next turn
+	}
+	w.Add(id, ai)
+	return true
+}
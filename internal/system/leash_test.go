@@ -0,0 +1,88 @@
+package system
+
+import (
+	"math/rand"
+	"testing"
+
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+// addLeashedEnemy adds a chasing enemy at x,y whose home is at homeX,homeY.
+func addLeashedEnemy(w *ecs.World, x, y, homeX, homeY int) ecs.EntityID {
+	id := addEnemy(w, x, y, component.BehaviorChase, 10)
+	ai := w.Get(id, component.CAI).(component.AI)
+	ai.Home = component.Position{X: homeX, Y: homeY}
+	w.Add(id, ai)
+	return id
+}
+
+func TestLeashedEnemyTurnsForHome(t *testing.T) {
+	w, gmap, player := newAIWorld(2, 2)
+	gmap.Leash = 4
+	enemy := addLeashedEnemy(w, 4, 2, 10, 2)
+	hp := w.Get(enemy, component.CHealth).(component.Health)
+	hp.Current = 10
+	w.Add(enemy, hp)
+
+	hits := ProcessAI(w, gmap, []ecs.EntityID{player}, rand.New(rand.NewSource(1)))
+	if len(hits) != 0 {
+		t.Error("an enemy past its leash should not attack")
+	}
+	if pos := w.Get(enemy, component.CPosition).(component.Position); pos.X != 5 {
+		t.Errorf("enemy at %v; want one step back toward home", pos)
+	}
+	ai := w.Get(enemy, component.CAI).(component.AI)
+	if !ai.Returning {
+		t.Error("enemy should be returning home")
+	}
+	if got := w.Get(enemy, component.CHealth).(component.Health).Current; got != 12 {
+		t.Errorf("HP = %d; want 12 after a turn of leash regen", got)
+	}
+}
+
+func TestReturningEnemyIgnoresPlayerUntilHome(t *testing.T) {
+	w, gmap, player := newAIWorld(2, 2)
+	gmap.Leash = 4
+	enemy := addLeashedEnemy(w, 4, 2, 10, 2)
+	hp := w.Get(enemy, component.CHealth).(component.Health)
+	hp.Current = 1
+	w.Add(enemy, hp)
+
+	for range 10 {
+		if hits := ProcessAI(w, gmap, []ecs.EntityID{player}, rand.New(rand.NewSource(1))); len(hits) != 0 {
+			t.Fatal("a returning enemy should not attack")
+		}
+		w.Add(player, component.Position{X: w.Get(enemy, component.CPosition).(component.Position).X - 1, Y: 2})
+		if !w.Get(enemy, component.CAI).(component.AI).Returning {
+			break
+		}
+	}
+	if pos := w.Get(enemy, component.CPosition).(component.Position); pos.X != 10 || pos.Y != 2 {
+		t.Errorf("enemy at %v; want home at (10,2)", pos)
+	}
+	if got := w.Get(enemy, component.CHealth).(component.Health).Current; got != 20 {
+		t.Errorf("HP = %d; want full once home", got)
+	}
+}
+
+func TestNoLeashKeepsChasing(t *testing.T) {
+	w, gmap, player := newAIWorld(2, 2)
+	enemy := addLeashedEnemy(w, 3, 2, 18, 18)
+	if hits := ProcessAI(w, gmap, []ecs.EntityID{player}, rand.New(rand.NewSource(1))); len(hits) != 1 {
+		t.Errorf("%d hits; without a leash an adjacent enemy attacks however far from home", len(hits))
+	}
+	if w.Get(enemy, component.CAI).(component.AI).Returning {
+		t.Error("enemy should not be returning without a leash")
+	}
+}
+
+func TestTauntedEnemyIgnoresLeash(t *testing.T) {
+	w, gmap, player := newAIWorld(2, 2)
+	gmap.Leash = 4
+	enemy := addLeashedEnemy(w, 3, 2, 18, 18)
+	w.Add(enemy, component.Taunt{Target: player, TurnsRemaining: 3})
+	if hits := ProcessAI(w, gmap, []ecs.EntityID{player}, rand.New(rand.NewSource(1))); len(hits) != 1 {
+		t.Errorf("%d hits; a taunted enemy should fight past its leash", len(hits))
+	}
+}
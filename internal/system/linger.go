@@ -0,0 +1,26 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+// StirSightBonus is how much farther each Stir lets hostile enemies see.
+const StirSightBonus = 1
+
+// Stir makes every hostile enemy in w more alert, extending its sight range
+// by StirSightBonus so it notices players from farther away. Used to nudge
+// players who linger on a floor. Returns how many enemies stirred.
+func Stir(w *ecs.World) int {
+	n := 0
+	for _, id := range w.Query(component.CAI) {
+		ai := w.Get(id, component.CAI).(component.AI)
+		if ai.Behavior == component.BehaviorAlly {
+			continue
+		}
+		ai.SightRange += StirSightBonus
+		w.Add(id, ai)
+		n++
+	}
+	return n
+}
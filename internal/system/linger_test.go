@@ -0,0 +1,22 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"testing"
+)
+
+func TestStirExtendsHostileSight(t *testing.T) {
+	w, _, _ := newAIWorld(1, 1)
+	enemy := addEnemy(w, 10, 10, component.BehaviorChase, 4)
+	ally := addEnemy(w, 12, 12, component.BehaviorAlly, 4)
+
+	if n := Stir(w); n != 1 {
+		t.Errorf("Stir stirred %d enemies; want 1", n)
+	}
+	if got := w.Get(enemy, component.CAI).(component.AI).SightRange; got != 4+StirSightBonus {
+		t.Errorf("enemy sight = %d; want %d", got, 4+StirSightBonus)
+	}
+	if got := w.Get(ally, component.CAI).(component.AI).SightRange; got != 4 {
+		t.Errorf("ally sight = %d; want it unchanged", got)
+	}
+}
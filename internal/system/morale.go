@@ -0,0 +1,94 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"math"
+)
+
+const (
+	// MoraleKillWeight is added to a floor's recent-kill counter for each kill.
+	// The counter decays by 1 per turn, so a kill counts as "recent" for this
+	// many turns.
+	MoraleKillWeight = 4
+	// MoraleRadius is how far from a kill enemies are shaken by it.
+	MoraleRadius = 8
+	// MoraleBreakKills is the number of recent kills that always breaks morale.
+	MoraleBreakKills = 3
+	// RoutTurns is how long a routed enemy flees before regrouping.
+	RoutTurns = 5
+)
+
+// RecordKill returns the recent-kill counter after one more kill.
+func RecordKill(recentKills int) int {
+	return recentKills + MoraleKillWeight
+}
+
+// DecayMorale returns the recent-kill counter after one turn has passed.
+func DecayMorale(recentKills int) int {
+	return max(recentKills-1, 0)
+}
+
+// BreakMorale checks whether the enemies near a kill at pos panic. Morale
+// breaks once MoraleBreakKills kills are recent, or once at least two recent
+// kills match or outnumber the hostiles left within MoraleRadius (half the
+// group is down). Every non-fearless hostile in range then flees for
+// RoutTurns. Returns the number of enemies routed.
+func BreakMorale(w *ecs.World, pos component.Position, recentKills int) int {
+	kills := (recentKills + MoraleKillWeight - 1) / MoraleKillWeight
+	if kills < 2 {
+		return 0
+	}
+	var nearby []ecs.EntityID
+	for _, id := range w.Query(component.CAI, component.CPosition) {
+		if w.Get(id, component.CAI).(component.AI).Behavior == component.BehaviorAlly {
+			continue
+		}
+		epos := w.Get(id, component.CPosition).(component.Position)
+		dx := float64(epos.X - pos.X)
+		dy := float64(epos.Y - pos.Y)
+		if math.Sqrt(dx*dx+dy*dy) <= MoraleRadius {
+			nearby = append(nearby, id)
+		}
+	}
+	if kills < MoraleBreakKills && kills < len(nearby) {
+		return 0
+	}
+	n := 0
+	for _, id := range nearby {
+		aiComp := w.Get(id, component.CAI).(component.AI)
+		if aiComp.Fearless {
+			continue
+		}
+		if rc := w.Get(id, component.CRout); rc != nil {
+			r := rc.(component.Rout)
+			r.TurnsRemaining = RoutTurns
+			w.Add(id, r)
+			continue
+		}
+		w.Add(id, component.Rout{Prior: aiComp.Behavior, TurnsRemaining: RoutTurns})
+		aiComp.Behavior = component.BehaviorFlee
+		w.Add(id, aiComp)
+		n++
+	}
+	return n
+}
+
+// tickRout ages an entity's rout by one turn and restores its prior behaviour
+// once it expires. Returns the entity's current AI component.
+func tickRout(w *ecs.World, id ecs.EntityID, aiComp component.AI) component.AI {
+	rc := w.Get(id, component.CRout)
+	if rc == nil {
+		return aiComp
+	}
+	r := rc.(component.Rout)
+	r.TurnsRemaining--
+	if r.TurnsRemaining > 0 {
+		w.Add(id, r)
+		return aiComp
+	}
+	w.Remove(id, component.CRout)
+	aiComp.Behavior = r.Prior
+	w.Add(id, aiComp)
+	return aiComp
+}
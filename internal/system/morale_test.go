@@ -0,0 +1,111 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"math/rand"
+	"testing"
+)
+
+func TestBreakMoraleNeedsRecentKills(t *testing.T) {
+	w, _, _ := newAIWorld(5, 5)
+	e := addEnemy(w, 8, 5, component.BehaviorChase, 10)
+	pos := component.Position{X: 7, Y: 5}
+
+	if n := BreakMorale(w, pos, RecordKill(0)); n != 0 {
+		t.Fatalf("a single kill routed %d enemies; want 0", n)
+	}
+	if w.Get(e, component.CAI).(component.AI).Behavior != component.BehaviorChase {
+		t.Error("enemy should keep chasing after one kill")
+	}
+}
+
+func TestBreakMoraleWhenHalfTheGroupFalls(t *testing.T) {
+	w, _, _ := newAIWorld(5, 5)
+	a := addEnemy(w, 8, 5, component.BehaviorChase, 10)
+	b := addEnemy(w, 9, 6, component.BehaviorCowardly, 10)
+	pos := component.Position{X: 7, Y: 5}
+
+	recent := RecordKill(RecordKill(0)) // two kills, two survivors
+	if n := BreakMorale(w, pos, recent); n != 2 {
+		t.Fatalf("routed %d enemies; want 2", n)
+	}
+	for _, id := range []ecs.EntityID{a, b} {
+		if got := w.Get(id, component.CAI).(component.AI).Behavior; got != component.BehaviorFlee {
+			t.Errorf("entity %d behavior = %d; want BehaviorFlee", id, got)
+		}
+	}
+	if r := w.Get(b, component.CRout).(component.Rout); r.Prior != component.BehaviorCowardly {
+		t.Errorf("rout prior = %d; want BehaviorCowardly", r.Prior)
+	}
+}
+
+func TestBreakMoraleHoldsAgainstLargeGroup(t *testing.T) {
+	w, _, _ := newAIWorld(5, 5)
+	for i := range 4 {
+		addEnemy(w, 8+i, 5, component.BehaviorChase, 10)
+	}
+	recent := RecordKill(RecordKill(0)) // two kills, four survivors
+	if n := BreakMorale(w, component.Position{X: 7, Y: 5}, recent); n != 0 {
+		t.Errorf("routed %d enemies; want 0 while survivors outnumber the fallen", n)
+	}
+}
+
+func TestBreakMoraleSkipsFearlessAndDistant(t *testing.T) {
+	w, _, _ := newAIWorld(1, 1)
+	boss := addEnemy(w, 8, 5, component.BehaviorChase, 10)
+	ai := w.Get(boss, component.CAI).(component.AI)
+	ai.Fearless = true
+	w.Add(boss, ai)
+	far := addEnemy(w, 19, 19, component.BehaviorChase, 10)
+
+	recent := 0
+	for range MoraleBreakKills {
+		recent = RecordKill(recent)
+	}
+	if n := BreakMorale(w, component.Position{X: 7, Y: 5}, recent); n != 0 {
+		t.Fatalf("routed %d enemies; want 0", n)
+	}
+	if w.Has(boss, component.CRout) || w.Has(far, component.CRout) {
+		t.Error("fearless and out-of-range enemies must not be routed")
+	}
+}
+
+func TestDecayMoraleForgetsOldKills(t *testing.T) {
+	recent := RecordKill(RecordKill(0))
+	for range MoraleKillWeight {
+		recent = DecayMorale(recent)
+	}
+	w, _, _ := newAIWorld(5, 5)
+	addEnemy(w, 8, 5, component.BehaviorChase, 10)
+	if n := BreakMorale(w, component.Position{X: 7, Y: 5}, recent); n != 0 {
+		t.Errorf("routed %d enemies after kills decayed; want 0", n)
+	}
+	if got := DecayMorale(0); got != 0 {
+		t.Errorf("DecayMorale(0) = %d; want 0", got)
+	}
+}
+
+func TestRoutedEnemyFleesThenRegroups(t *testing.T) {
+	w, gmap, player := newAIWorld(5, 5)
+	e := addEnemy(w, 6, 5, component.BehaviorChase, 10)
+	w.Add(e, component.Rout{Prior: component.BehaviorChase, TurnsRemaining: 2})
+	w.Add(e, component.AI{Behavior: component.BehaviorFlee, SightRange: 10})
+	rng := rand.New(rand.NewSource(1))
+
+	hits := ProcessAI(w, gmap, []ecs.EntityID{player}, rng)
+	if len(hits) != 0 {
+		t.Fatalf("routed enemy attacked: %v", hits)
+	}
+	if pos := w.Get(e, component.CPosition).(component.Position); pos.X != 7 {
+		t.Errorf("routed enemy at x=%d; want 7 (one step away)", pos.X)
+	}
+
+	ProcessAI(w, gmap, []ecs.EntityID{player}, rng)
+	if w.Has(e, component.CRout) {
+		t.Error("rout should expire after its turns run out")
+	}
+	if got := w.Get(e, component.CAI).(component.AI).Behavior; got != component.BehaviorChase {
+		t.Errorf("behavior after rout = %d; want BehaviorChase", got)
+	}
+}
@@ -18,6 +18,9 @@ const (
 
 // TryMove attempts to move entity id by (dx, dy) on gmap.
 // Returns the outcome and (if MoveAttack or MoveInteract) the target entity.
+// A diagonal step that would squeeze between two solid tiles is blocked, as
+// is any attack or interaction across such a corner. Enemies are blocked at
+// the edge of a sanctuary and cannot attack anyone inside it.
 func TryMove(w *ecs.World, gmap *gamemap.GameMap, id ecs.EntityID, dx, dy int) (MoveResult, ecs.EntityID) {
 	posComp := w.Get(id, component.CPosition)
 	if posComp == nil {
@@ -26,6 +29,13 @@ func TryMove(w *ecs.World, gmap *gamemap.GameMap, id ecs.EntityID, dx, dy int) (
 	pos := posComp.(component.Position)
 	nx, ny := pos.X+dx, pos.Y+dy
 
+	if dx != 0 && dy != 0 && solidTile(gmap, pos.X+dx, pos.Y) && solidTile(gmap, pos.X, pos.Y+dy) {
+		return MoveBlocked, ecs.NilEntity
+	}
+	if gmap.IsSanctuary(nx, ny) && isEnemy(w, id) {
+		return MoveBlocked, ecs.NilEntity
+	}
+
 	// Check for NPCs (interactable, non-hostile) before furniture and combat checks.
 	for _, eid := range w.Query(component.CNPC, component.CPosition) {
 		if eid == id {
@@ -55,6 +65,12 @@ func TryMove(w *ecs.World, gmap *gamemap.GameMap, id ecs.EntityID, dx, dy int) (
 		}
 		otherPos := w.Get(other, component.CPosition).(component.Position)
 		if otherPos.X == nx && otherPos.Y == ny {
+			if w.Has(other, component.CDecoy) && w.Has(id, component.CTagPlayer) {
+				// Players trade places with a decoy rather than attack it.
+				w.Add(other, pos)
+				w.Add(id, component.Position{X: nx, Y: ny})
+				return MoveOK, ecs.NilEntity
+			}
 			return MoveAttack, other
 		}
 	}
@@ -68,3 +84,16 @@ func TryMove(w *ecs.World, gmap *gamemap.GameMap, id ecs.EntityID, dx, dy int) (
 	w.Add(id, component.Position{X: nx, Y: ny})
 	return MoveOK, ecs.NilEntity
 }
+
+// isEnemy reports whether id is a hostile AI-driven entity.
+func isEnemy(w *ecs.World, id ecs.EntityID) bool {
+	ac := w.Get(id, component.CAI)
+	return ac != nil && ac.(component.AI).Behavior != component.BehaviorAlly
+}
+
+// solidTile reports whether (x, y) is a wall-like tile that diagonal moves
+// cannot cut between: impassable and opaque, such as walls and closed doors.
+// Water is impassable but open, so it does not pinch a diagonal.
+func solidTile(gmap *gamemap.GameMap, x, y int) bool {
+	return !gmap.IsWalkable(x, y) && !gmap.IsTransparent(x, y)
+}
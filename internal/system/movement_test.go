@@ -68,3 +68,53 @@ func TestTryMoveIntoEntityReturnsAttack(t *testing.T) {
 		t.Fatalf("player should not have moved, got (%d,%d)", pos.X, pos.Y)
 	}
 }
+
+func TestTryMoveDiagonalCornerCutting(t *testing.T) {
+	tests := []struct {
+		name  string
+		tiles map[[2]int]gamemap.Tile // overrides around the player at (3,3)
+		want  MoveResult
+	}{
+		{"open", nil, MoveOK},
+		{"one wall", map[[2]int]gamemap.Tile{{4, 3}: gamemap.MakeWall()}, MoveOK},
+		{"other wall", map[[2]int]gamemap.Tile{{3, 4}: gamemap.MakeWall()}, MoveOK},
+		{"pinched by walls", map[[2]int]gamemap.Tile{{4, 3}: gamemap.MakeWall(), {3, 4}: gamemap.MakeWall()}, MoveBlocked},
+		{"pinched by wall and door", map[[2]int]gamemap.Tile{{4, 3}: gamemap.MakeWall(), {3, 4}: gamemap.MakeDoor()}, MoveBlocked},
+		{"between water", map[[2]int]gamemap.Tile{{4, 3}: gamemap.MakeWater(), {3, 4}: gamemap.MakeWater()}, MoveOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, gmap, player := setupMoveWorld()
+			for p, tile := range tt.tiles {
+				gmap.Set(p[0], p[1], tile)
+			}
+			result, _ := TryMove(w, gmap, player, 1, 1)
+			if result != tt.want {
+				t.Fatalf("diagonal move: got %v, want %v", result, tt.want)
+			}
+			want := component.Position{X: 4, Y: 4}
+			if result != MoveOK {
+				want = component.Position{X: 3, Y: 3}
+			}
+			if pos := w.Get(player, component.CPosition).(component.Position); pos != want {
+				t.Errorf("position = %v; want %v", pos, want)
+			}
+		})
+	}
+}
+
+func TestTryMoveNoAttackAcrossPinchedCorner(t *testing.T) {
+	w, gmap, player := setupMoveWorld()
+	gmap.Set(4, 3, gamemap.MakeWall())
+	gmap.Set(3, 4, gamemap.MakeWall())
+	enemy := w.CreateEntity()
+	w.Add(enemy, component.Position{X: 4, Y: 4})
+	w.Add(enemy, component.TagBlocking{})
+
+	if result, target := TryMove(w, gmap, player, 1, 1); result != MoveBlocked || target != ecs.NilEntity {
+		t.Errorf("attack across a pinched corner: got %v on %v, want MoveBlocked", result, target)
+	}
+	if result, _ := TryMove(w, gmap, enemy, -1, -1); result != MoveBlocked {
+		t.Errorf("enemy attack across a pinched corner: got %v, want MoveBlocked", result)
+	}
+}
@@ -0,0 +1,89 @@
+package system
+
+import (
+	"container/heap"
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/gamemap"
+)
+
+// ChasePathNodes caps how many tiles FindPath may expand for one chasing
+// enemy, bounding the cost of an unreachable or far-away target.
+const ChasePathNodes = 400
+
+// PathRetarget is how far (in tiles, either axis) the target may move from
+// where a cached path leads before the path is recomputed.
+const PathRetarget = 2
+
+// pathDirs are the orthogonal steps FindPath explores, matching the steps
+// chasing enemies take.
+var pathDirs = [4][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+
+// FindPath returns the shortest orthogonal walking route from from to to on
+// gmap using A*, excluding from and ending at to. Only map walkability is
+// considered; entities are the caller's concern, so to may be occupied.
+// Returns nil when to is unreachable or more than maxNodes tiles would have
+// to be expanded to find out.
+func FindPath(gmap *gamemap.GameMap, from, to component.Position, maxNodes int) []component.Position {
+	if from == to || !gmap.InBounds(to.X, to.Y) || !gmap.IsWalkable(to.X, to.Y) {
+		return nil
+	}
+	key := func(p component.Position) int { return p.Y*gmap.Width + p.X }
+	cameFrom := map[int]component.Position{}
+	cost := map[int]int{key(from): 0}
+	open := &pathQueue{{pos: from, f: manhattan(from, to)}}
+
+	for expanded := 0; open.Len() > 0; expanded++ {
+		if expanded >= maxNodes {
+			return nil
+		}
+		cur := heap.Pop(open).(pathNode).pos
+		if cur == to {
+			var path []component.Position
+			for p := to; p != from; p = cameFrom[key(p)] {
+				path = append(path, p)
+			}
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path
+		}
+		for _, d := range pathDirs {
+			next := component.Position{X: cur.X + d[0], Y: cur.Y + d[1]}
+			if !gmap.InBounds(next.X, next.Y) || !gmap.IsWalkable(next.X, next.Y) {
+				continue
+			}
+			g := cost[key(cur)] + 1
+			if old, seen := cost[key(next)]; seen && old <= g {
+				continue
+			}
+			cost[key(next)] = g
+			cameFrom[key(next)] = cur
+			heap.Push(open, pathNode{pos: next, f: g + manhattan(next, to)})
+		}
+	}
+	return nil
+}
+
+func manhattan(a, b component.Position) int {
+	return abs(a.X-b.X) + abs(a.Y-b.Y)
+}
+
+// pathNode is an open-set entry ordered by estimated total cost f.
+type pathNode struct {
+	pos component.Position
+	f   int
+}
+
+// pathQueue is a min-heap of pathNodes for FindPath.
+type pathQueue []pathNode
+
+func (q pathQueue) Len() int           { return len(q) }
+func (q pathQueue) Less(i, j int) bool { return q[i].f < q[j].f }
+func (q pathQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *pathQueue) Push(x any)        { *q = append(*q, x.(pathNode)) }
+func (q *pathQueue) Pop() any {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
@@ -0,0 +1,110 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+	"math/rand"
+	"testing"
+)
+
+// uWallMap returns a 20×20 open map with a cup-shaped wall opening south:
+// a base along y=8 from x=8 to 12 and arms down to y=12. An enemy at (10,10)
+// sits inside the cup, directly below a player at (10,4).
+func uWallMap() *gamemap.GameMap {
+	gmap := openMap(20, 20)
+	for x := 8; x <= 12; x++ {
+		gmap.Set(x, 8, gamemap.MakeWall())
+	}
+	for y := 8; y <= 12; y++ {
+		gmap.Set(8, y, gamemap.MakeWall())
+		gmap.Set(12, y, gamemap.MakeWall())
+	}
+	return gmap
+}
+
+func TestFindPathRoutesAroundUWall(t *testing.T) {
+	gmap := uWallMap()
+	from, to := component.Position{X: 10, Y: 10}, component.Position{X: 10, Y: 4}
+	path := FindPath(gmap, from, to, ChasePathNodes)
+	if len(path) == 0 {
+		t.Fatal("expected a path out of the cup")
+	}
+	if path[len(path)-1] != to {
+		t.Errorf("path ends at %v; want %v", path[len(path)-1], to)
+	}
+	prev := from
+	for _, p := range path {
+		if manhattan(prev, p) != 1 {
+			t.Fatalf("path step %v -> %v is not one orthogonal move", prev, p)
+		}
+		if !gmap.IsWalkable(p.X, p.Y) {
+			t.Fatalf("path crosses unwalkable tile %v", p)
+		}
+		prev = p
+	}
+	// Out the bottom (y=13), round an arm and back up: 3+3+9+3 = 18 steps.
+	if len(path) != 18 {
+		t.Errorf("path length = %d; want shortest route of 18", len(path))
+	}
+}
+
+func TestFindPathUnreachable(t *testing.T) {
+	gmap := openMap(10, 10)
+	for y := range 10 {
+		gmap.Set(5, y, gamemap.MakeWall())
+	}
+	if path := FindPath(gmap, component.Position{X: 1, Y: 1}, component.Position{X: 8, Y: 1}, ChasePathNodes); path != nil {
+		t.Errorf("expected nil path through a solid wall; got %v", path)
+	}
+}
+
+func TestFindPathNodeCap(t *testing.T) {
+	gmap := uWallMap()
+	from, to := component.Position{X: 10, Y: 10}, component.Position{X: 10, Y: 4}
+	if path := FindPath(gmap, from, to, 5); path != nil {
+		t.Errorf("expected nil once the node cap is hit; got %d steps", len(path))
+	}
+}
+
+// TestChaseRoutesAroundUWall checks that a chasing enemy trapped in a cup,
+// where both greedy steps run into the wall, follows A* out and reaches the
+// player.
+func TestChaseRoutesAroundUWall(t *testing.T) {
+	w, _, player := newAIWorld(10, 4)
+	gmap := uWallMap()
+	enemy := addEnemy(w, 10, 10, component.BehaviorChase, 30)
+	// The cup blocks line of sight, so taunt the enemy to keep it hunting.
+	w.Add(enemy, component.Taunt{Target: player, TurnsRemaining: 100})
+	rng := rand.New(rand.NewSource(1))
+
+	for turn := range 30 {
+		if hits := ProcessAI(w, gmap, []ecs.EntityID{player}, rng); len(hits) > 0 {
+			return
+		}
+		if turn == 1 {
+			ai := w.Get(enemy, component.CAI).(component.AI)
+			if len(ai.Path) == 0 || ai.PathGoal != (component.Position{X: 10, Y: 4}) {
+				t.Errorf("expected a cached path toward the player; got %v to %v", ai.Path, ai.PathGoal)
+			}
+		}
+	}
+	t.Fatalf("enemy never reached the player; stuck at %v",
+		w.Get(enemy, component.CPosition).(component.Position))
+}
+
+func TestChaseRecomputesPathWhenTargetMoves(t *testing.T) {
+	w, _, player := newAIWorld(10, 4)
+	gmap := uWallMap()
+	enemy := addEnemy(w, 10, 9, component.BehaviorChase, 30)
+	w.Add(enemy, component.Taunt{Target: player, TurnsRemaining: 100})
+	rng := rand.New(rand.NewSource(1))
+
+	ProcessAI(w, gmap, []ecs.EntityID{player}, rng)
+	w.Add(player, component.Position{X: 10, Y: 4 - PathRetarget - 1})
+	ProcessAI(w, gmap, []ecs.EntityID{player}, rng)
+	ai := w.Get(enemy, component.CAI).(component.AI)
+	if want := (component.Position{X: 10, Y: 4 - PathRetarget - 1}); ai.PathGoal != want {
+		t.Errorf("PathGoal = %v after the player moved away; want %v", ai.PathGoal, want)
+	}
+}
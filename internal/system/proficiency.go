@@ -0,0 +1,45 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+// Weapon proficiency tuning.
+const (
+	ProficiencyStep = 10 // hits landed per proficiency level
+	ProficiencyMax  = 3  // highest level, each worth +1 ATK
+)
+
+// ProficiencyLevel returns the proficiency level earned by hits landed with
+// one weapon.
+func ProficiencyLevel(hits int) int {
+	return min(hits/ProficiencyStep, ProficiencyMax)
+}
+
+// RecordWeaponHit counts a landed hit toward id's proficiency with its
+// main-hand weapon. It does nothing when id fights bare-handed or tracks no
+// proficiency.
+func RecordWeaponHit(w *ecs.World, id ecs.EntityID) {
+	pc, ic := w.Get(id, component.CProficiency), w.Get(id, component.CInventory)
+	if pc == nil || ic == nil {
+		return
+	}
+	if weapon := ic.(component.Inventory).MainHand; !weapon.IsEmpty() {
+		pc.(component.Proficiency).Hits[weapon.Name]++
+	}
+}
+
+// ProficiencyBonus returns the ATK bonus id has earned with its main-hand
+// weapon.
+func ProficiencyBonus(w *ecs.World, id ecs.EntityID) int {
+	pc, ic := w.Get(id, component.CProficiency), w.Get(id, component.CInventory)
+	if pc == nil || ic == nil {
+		return 0
+	}
+	weapon := ic.(component.Inventory).MainHand
+	if weapon.IsEmpty() {
+		return 0
+	}
+	return ProficiencyLevel(pc.(component.Proficiency).Hits[weapon.Name])
+}
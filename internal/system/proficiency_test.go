@@ -0,0 +1,65 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"testing"
+)
+
+// armPlayer equips a Shard Blade on player and starts tracking proficiency in
+// hits.
+func armPlayer(w *ecs.World, player ecs.EntityID, hits map[string]int) {
+	w.Add(player, component.Inventory{MainHand: component.Item{Name: "Shard Blade", Slot: component.SlotOneHand}})
+	w.Add(player, component.Proficiency{Hits: hits})
+}
+
+func TestRecordWeaponHitCountsMainHand(t *testing.T) {
+	w, _, player := newAIWorld(5, 5)
+	hits := map[string]int{}
+	armPlayer(w, player, hits)
+	RecordWeaponHit(w, player)
+	RecordWeaponHit(w, player)
+	if hits["Shard Blade"] != 2 {
+		t.Errorf("Shard Blade hits = %d; want 2", hits["Shard Blade"])
+	}
+
+	w.Add(player, component.Inventory{})
+	RecordWeaponHit(w, player)
+	if len(hits) != 1 || hits["Shard Blade"] != 2 {
+		t.Errorf("bare-handed hit was recorded: %v", hits)
+	}
+}
+
+func TestProficiencyBonusScalesAndCaps(t *testing.T) {
+	w, _, player := newAIWorld(5, 5)
+	hits := map[string]int{}
+	armPlayer(w, player, hits)
+	for _, tc := range []struct{ hits, want int }{
+		{0, 0},
+		{ProficiencyStep - 1, 0},
+		{ProficiencyStep, 1},
+		{ProficiencyStep * 2, 2},
+		{ProficiencyStep * 10, ProficiencyMax},
+	} {
+		hits["Shard Blade"] = tc.hits
+		if got := ProficiencyBonus(w, player); got != tc.want {
+			t.Errorf("%d hits: bonus = %d; want %d", tc.hits, got, tc.want)
+		}
+	}
+
+	// Proficiency belongs to the weapon: a different one starts from scratch.
+	w.Add(player, component.Inventory{MainHand: component.Item{Name: "Echo Cutter", Slot: component.SlotOneHand}})
+	if got := ProficiencyBonus(w, player); got != 0 {
+		t.Errorf("bonus with an unpractised weapon = %d; want 0", got)
+	}
+}
+
+func TestProficiencyAddsToAttackDamage(t *testing.T) {
+	w, _, player := newAIWorld(5, 5)
+	enemy := addEnemy(w, 6, 5, component.BehaviorChase, 5)
+	armPlayer(w, player, map[string]int{"Shard Blade": ProficiencyStep * 2})
+	// ATK 3 + 2 proficiency against DEF 0.
+	if got := baseDamage(w, player, enemy); got != 5 {
+		t.Errorf("baseDamage = %d; want 5 with two proficiency levels", got)
+	}
+}
@@ -0,0 +1,51 @@
+package system
+
+import "emoji-roguelike/internal/gamemap"
+
+// PuzzleStep describes what stepping onto a tile did to the floor's puzzle.
+type PuzzleStep uint8
+
+const (
+	PuzzleNone    PuzzleStep = iota // not a glyph, or nothing to solve
+	PuzzleAdvance                   // the next glyph in the sequence
+	PuzzleReset                     // a glyph out of turn; the sequence starts over
+	PuzzleSolved                    // the final glyph; the vault's doors unlock
+)
+
+// StepPuzzle records a step onto (x, y) against gmap's puzzle. A wrong glyph
+// resets the sequence, unless it is the first glyph, which starts it afresh.
+// Stepping back onto the glyph just walked changes nothing. Solving unlocks
+// the vault's doors; they still have to be opened.
+func StepPuzzle(gmap *gamemap.GameMap, x, y int) PuzzleStep {
+	p := gmap.Puzzle
+	if p == nil || p.Solved {
+		return PuzzleNone
+	}
+	at := [2]int{x, y}
+	idx := -1
+	for i, s := range p.Steps {
+		if s == at {
+			idx = i
+			break
+		}
+	}
+	switch {
+	case idx < 0 || idx == p.Progress-1:
+		return PuzzleNone
+	case idx == p.Progress:
+		p.Progress++
+	case idx == 0:
+		p.Progress = 1
+	default:
+		p.Progress = 0
+		return PuzzleReset
+	}
+	if p.Progress < len(p.Steps) {
+		return PuzzleAdvance
+	}
+	p.Solved = true
+	for _, d := range p.Doors {
+		gmap.At(d[0], d[1]).Locked = false
+	}
+	return PuzzleSolved
+}
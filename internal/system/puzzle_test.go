@@ -0,0 +1,60 @@
+package system
+
+import (
+	"testing"
+
+	"emoji-roguelike/internal/gamemap"
+)
+
+// newPuzzleMap returns a map with a three-glyph puzzle along row 1 and one
+// locked vault door.
+func newPuzzleMap() *gamemap.GameMap {
+	gmap := gamemap.New(10, 5)
+	gmap.Set(5, 4, gamemap.MakeLockedDoor())
+	gmap.Puzzle = &gamemap.Puzzle{
+		Doors: [][2]int{{5, 4}},
+		Steps: [][2]int{{1, 1}, {2, 1}, {3, 1}},
+	}
+	return gmap
+}
+
+func TestStepPuzzleInOrderUnlocks(t *testing.T) {
+	gmap := newPuzzleMap()
+	for i, want := range []PuzzleStep{PuzzleAdvance, PuzzleAdvance, PuzzleSolved} {
+		if got := StepPuzzle(gmap, i+1, 1); got != want {
+			t.Fatalf("step %d = %v; want %v", i, got, want)
+		}
+	}
+	if gmap.At(5, 4).Locked {
+		t.Error("solving the puzzle should unlock the vault door")
+	}
+	if got := StepPuzzle(gmap, 1, 1); got != PuzzleNone {
+		t.Errorf("step after solving = %v; want PuzzleNone", got)
+	}
+}
+
+func TestStepPuzzleWrongGlyphResets(t *testing.T) {
+	gmap := newPuzzleMap()
+	StepPuzzle(gmap, 1, 1)
+	if got := StepPuzzle(gmap, 3, 1); got != PuzzleReset {
+		t.Fatalf("out-of-turn glyph = %v; want PuzzleReset", got)
+	}
+	if gmap.Puzzle.Progress != 0 || !gmap.At(5, 4).Locked {
+		t.Errorf("after a reset: progress %d, door locked %v", gmap.Puzzle.Progress, gmap.At(5, 4).Locked)
+	}
+}
+
+func TestStepPuzzleFirstGlyphRestarts(t *testing.T) {
+	gmap := newPuzzleMap()
+	StepPuzzle(gmap, 1, 1)
+	StepPuzzle(gmap, 2, 1)
+	if got := StepPuzzle(gmap, 2, 1); got != PuzzleNone {
+		t.Errorf("stepping back onto the last glyph = %v; want PuzzleNone", got)
+	}
+	if got := StepPuzzle(gmap, 1, 1); got != PuzzleAdvance || gmap.Puzzle.Progress != 1 {
+		t.Errorf("first glyph mid-sequence = %v, progress %d; want a fresh start", got, gmap.Puzzle.Progress)
+	}
+	if got := StepPuzzle(gmap, 5, 2); got != PuzzleNone {
+		t.Errorf("plain tile = %v; want PuzzleNone", got)
+	}
+}
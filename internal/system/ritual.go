@@ -0,0 +1,33 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+// MakeOffering moves one item the ritual on id accepts from playerID's
+// backpack onto it. Returns the ritual's progress and whether an item was
+// offered; nothing is taken once the ritual is complete.
+func MakeOffering(w *ecs.World, id, playerID ecs.EntityID) (component.Ritual, bool) {
+	rc := w.Get(id, component.CRitual)
+	if rc == nil {
+		return component.Ritual{}, false
+	}
+	r := rc.(component.Ritual)
+	ic := w.Get(playerID, component.CInventory)
+	if ic == nil || r.Complete() {
+		return r, false
+	}
+	inv := ic.(component.Inventory)
+	for i, item := range inv.Backpack {
+		if item.Glyph != r.Offering {
+			continue
+		}
+		inv.Backpack = append(inv.Backpack[:i:i], inv.Backpack[i+1:]...)
+		w.Add(playerID, inv)
+		r.Offered++
+		w.Add(id, r)
+		return r, true
+	}
+	return r, false
+}
@@ -0,0 +1,31 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"testing"
+)
+
+func TestMakeOfferingTakesOneMatchingItem(t *testing.T) {
+	w := ecs.NewWorld()
+	altar := w.CreateEntity()
+	w.Add(altar, component.Ritual{Offering: "💎", Needed: 2})
+	player := w.CreateEntity()
+	w.Add(player, component.Inventory{Backpack: []component.Item{
+		{Glyph: "🧪"}, {Glyph: "💎"}, {Glyph: "💎"}, {Glyph: "💎"},
+	}})
+
+	for want := 1; want <= 2; want++ {
+		r, ok := MakeOffering(w, altar, player)
+		if !ok || r.Offered != want {
+			t.Fatalf("offering %d: got Offered %d, ok %v", want, r.Offered, ok)
+		}
+	}
+	if r, ok := MakeOffering(w, altar, player); ok || !r.Complete() {
+		t.Errorf("a complete ritual should take nothing more; ok %v, complete %v", ok, r.Complete())
+	}
+	backpack := w.Get(player, component.CInventory).(component.Inventory).Backpack
+	if len(backpack) != 2 || backpack[0].Glyph != "🧪" || backpack[1].Glyph != "💎" {
+		t.Errorf("backpack = %v; want the flask and one shard left", backpack)
+	}
+}
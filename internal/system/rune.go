@@ -0,0 +1,81 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+const (
+	// RuneBoostMagnitude is the ATK or DEF a fury or ward rune grants.
+	RuneBoostMagnitude = 2
+	// RuneBoostTurns is how long a fury or ward rune's boost lasts.
+	RuneBoostTurns = 10
+	// RuneHexPoison is the poison per turn a hex rune inflicts.
+	RuneHexPoison = 1
+	// RuneHexTurns is how long a hex rune's poison lasts.
+	RuneHexTurns = 5
+	// RiddleGold is the reward for answering a riddle rune.
+	RiddleGold = 15
+)
+
+// activeRuneAt returns the unspent active rune at entity id's position.
+func activeRuneAt(w *ecs.World, id ecs.EntityID) (ecs.EntityID, component.Inscription, bool) {
+	pc := w.Get(id, component.CPosition)
+	if pc == nil {
+		return ecs.NilEntity, component.Inscription{}, false
+	}
+	pos := pc.(component.Position)
+	for _, rid := range w.Query(component.CInscription, component.CPosition) {
+		ins := w.Get(rid, component.CInscription).(component.Inscription)
+		if ins.Rune == component.RuneNone || ins.Spent {
+			continue
+		}
+		if w.Get(rid, component.CPosition).(component.Position) == pos {
+			return rid, ins, true
+		}
+	}
+	return ecs.NilEntity, component.Inscription{}, false
+}
+
+// TriggerRune fires the active rune under entity id and returns its kind, or
+// RuneNone if there is none. Ward and fury runes grant a boost and hex runes
+// poison; each then goes dark. A riddle rune does nothing until answered with
+// AnswerRiddle, so it is reported every time it is stepped on.
+func TriggerRune(w *ecs.World, id ecs.EntityID) component.RuneKind {
+	rid, ins, ok := activeRuneAt(w, id)
+	if !ok {
+		return component.RuneNone
+	}
+	switch ins.Rune {
+	case component.RuneWard:
+		ApplyEffect(w, id, component.ActiveEffect{
+			Kind: component.EffectDefenseBoost, Magnitude: RuneBoostMagnitude, TurnsRemaining: RuneBoostTurns,
+		})
+	case component.RuneFury:
+		ApplyEffect(w, id, component.ActiveEffect{
+			Kind: component.EffectAttackBoost, Magnitude: RuneBoostMagnitude, TurnsRemaining: RuneBoostTurns,
+		})
+	case component.RuneHex:
+		ApplyEffect(w, id, component.ActiveEffect{
+			Kind: component.EffectPoison, Magnitude: RuneHexPoison, TurnsRemaining: RuneHexTurns,
+		})
+	case component.RuneRiddle:
+		return ins.Rune
+	}
+	ins.Spent = true
+	w.Add(rid, ins)
+	return ins.Rune
+}
+
+// AnswerRiddle reports whether entity id, by waiting, has answered an
+// unanswered riddle rune it stands on. The rune is then spent; the caller
+// pays out RiddleGold.
+func AnswerRiddle(w *ecs.World, id ecs.EntityID) bool {
+	rid, ins, ok := activeRuneAt(w, id)
+	if !ok || ins.Rune != component.RuneRiddle {
+		return false
+	}
+	ins.Spent = true
+	w.Add(rid, ins)
+	return true
+}
@@ -0,0 +1,68 @@
+package system
+
+import (
+	"testing"
+
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+// placeRune puts an active rune of kind under the player.
+func placeRune(w *ecs.World, player ecs.EntityID, kind component.RuneKind) ecs.EntityID {
+	id := w.CreateEntity()
+	w.Add(id, w.Get(player, component.CPosition))
+	w.Add(id, component.Inscription{Text: "rune", Rune: kind})
+	return id
+}
+
+func TestTriggerRuneFiresOnce(t *testing.T) {
+	w, _, player := setupMoveWorld()
+	placeRune(w, player, component.RuneWard)
+
+	if got := TriggerRune(w, player); got != component.RuneWard {
+		t.Fatalf("TriggerRune = %v; want RuneWard", got)
+	}
+	if got := GetDefenseBonus(w, player); got != RuneBoostMagnitude {
+		t.Errorf("DEF bonus = %d; want %d", got, RuneBoostMagnitude)
+	}
+	if got := TriggerRune(w, player); got != component.RuneNone {
+		t.Errorf("second TriggerRune = %v; a spent rune should not fire again", got)
+	}
+}
+
+func TestHexRunePoisons(t *testing.T) {
+	w, _, player := setupMoveWorld()
+	placeRune(w, player, component.RuneHex)
+	TriggerRune(w, player)
+	if got := GetPoisonDamage(w, player); got != RuneHexPoison {
+		t.Errorf("poison damage = %d; want %d", got, RuneHexPoison)
+	}
+}
+
+func TestPlainInscriptionIsNotARune(t *testing.T) {
+	w, _, player := setupMoveWorld()
+	placeRune(w, player, component.RuneNone)
+	if got := TriggerRune(w, player); got != component.RuneNone {
+		t.Errorf("TriggerRune on plain text = %v; want RuneNone", got)
+	}
+	if AnswerRiddle(w, player) {
+		t.Error("plain text has no riddle to answer")
+	}
+}
+
+func TestRiddleRunePaysOnceWhenWaitedOn(t *testing.T) {
+	w, _, player := setupMoveWorld()
+	placeRune(w, player, component.RuneRiddle)
+
+	// Reading the riddle leaves it open, however often it is read.
+	TriggerRune(w, player)
+	if got := TriggerRune(w, player); got != component.RuneRiddle {
+		t.Fatalf("re-reading the riddle = %v; want RuneRiddle", got)
+	}
+	if !AnswerRiddle(w, player) {
+		t.Fatal("waiting on the riddle should answer it")
+	}
+	if AnswerRiddle(w, player) {
+		t.Error("an answered riddle should not pay again")
+	}
+}
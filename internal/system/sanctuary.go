@@ -0,0 +1,18 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+)
+
+// InSanctuary reports whether entity id stands inside a sanctuary, where
+// enemies cannot reach it.
+func InSanctuary(w *ecs.World, gmap *gamemap.GameMap, id ecs.EntityID) bool {
+	pc := w.Get(id, component.CPosition)
+	if pc == nil {
+		return false
+	}
+	pos := pc.(component.Position)
+	return gmap.IsSanctuary(pos.X, pos.Y)
+}
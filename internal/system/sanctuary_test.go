@@ -0,0 +1,30 @@
+package system
+
+import (
+	"testing"
+
+	"emoji-roguelike/internal/component"
+)
+
+func TestEnemyCannotEnterOrStrikeIntoSanctuary(t *testing.T) {
+	w, gmap, player := setupMoveWorld()
+	gmap.At(3, 3).Sanctuary = true
+	gmap.At(4, 4).Sanctuary = true
+	enemy := w.CreateEntity()
+	w.Add(enemy, component.Position{X: 4, Y: 3})
+	w.Add(enemy, component.AI{Behavior: component.BehaviorChase, SightRange: 8})
+	w.Add(enemy, component.TagBlocking{})
+
+	if res, _ := TryMove(w, gmap, enemy, -1, 0); res != MoveBlocked {
+		t.Errorf("enemy attacking into a sanctuary: got %v, want MoveBlocked", res)
+	}
+	if res, _ := TryMove(w, gmap, enemy, 0, 1); res != MoveBlocked {
+		t.Errorf("enemy stepping into a sanctuary: got %v, want MoveBlocked", res)
+	}
+	if !InSanctuary(w, gmap, player) {
+		t.Error("player on a sanctuary tile should be in the sanctuary")
+	}
+	if res, _ := TryMove(w, gmap, player, 1, 1); res != MoveOK {
+		t.Errorf("player walking within a sanctuary: got %v, want MoveOK", res)
+	}
+}
@@ -0,0 +1,74 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+)
+
+// Split is a splitting enemy dividing in two after a hit. The caller creates
+// the copy with factory.NewSplitCopy.
+type Split struct {
+	Parent ecs.EntityID
+	Glyph  string
+	Pos    component.Position // free tile the copy appears on
+	HP     int                // the copy's current HP
+	MaxHP  int                // the copy's max HP
+	Gen    int                // the copy's generation
+}
+
+// markSplit flags defenderID to split when a hit left it alive with HP to
+// share and it is below its generation cap.
+func markSplit(w *ecs.World, defenderID ecs.EntityID, hp component.Health) {
+	sc := w.Get(defenderID, component.CSplitter)
+	if sc == nil {
+		return
+	}
+	s := sc.(component.Splitter)
+	if s.Gen >= s.MaxGen || hp.Current < 2 {
+		return
+	}
+	s.Pending = true
+	w.Add(defenderID, s)
+}
+
+// CollectSplits divides every splitter hit since the last call: its HP and
+// max HP are halved and the other half becomes a copy on a free tile beside
+// it, one generation further along. A splitter with no free tile beside it
+// stays whole. A splitter killed before collection leaves nothing behind.
+func CollectSplits(w *ecs.World, gmap *gamemap.GameMap) []Split {
+	var out []Split
+	taken := make(map[component.Position]bool)
+	for _, id := range w.Query(component.CSplitter, component.CHealth, component.CPosition) {
+		s := w.Get(id, component.CSplitter).(component.Splitter)
+		if !s.Pending {
+			continue
+		}
+		s.Pending = false
+		w.Add(id, s)
+		x, y, ok := FindDeploySpot(w, gmap, w.Get(id, component.CPosition).(component.Position))
+		at := component.Position{X: x, Y: y}
+		if !ok || taken[at] {
+			continue
+		}
+		taken[at] = true
+
+		hp := w.Get(id, component.CHealth).(component.Health)
+		child := hp.Current / 2
+		hp.Current -= child
+		halfMax := hp.Max / 2
+		hp.Max = max(halfMax, hp.Current)
+		w.Add(id, hp)
+		s.Gen++
+		w.Add(id, s)
+		out = append(out, Split{
+			Parent: id,
+			Glyph:  enemyGlyph(w, id),
+			Pos:    at,
+			HP:     child,
+			MaxHP:  max(halfMax, child),
+			Gen:    s.Gen,
+		})
+	}
+	return out
+}
@@ -0,0 +1,89 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/gamemap"
+	"math/rand"
+	"testing"
+)
+
+func TestAttackSplitsWoundedSplitter(t *testing.T) {
+	w, gmap, player := newAIWorld(5, 5)
+	slime := addEnemy(w, 6, 5, component.BehaviorChase, 6)
+	w.Add(slime, component.Splitter{MaxGen: 2})
+	rng := rand.New(rand.NewSource(1))
+
+	res := Attack(w, rng, player, slime)
+	if res.Killed {
+		t.Fatal("slime should survive the first hit")
+	}
+	wounded := 20 - res.Damage
+	splits := CollectSplits(w, gmap)
+	if len(splits) != 1 {
+		t.Fatalf("got %d splits; want 1", len(splits))
+	}
+	sp := splits[0]
+	hp := w.Get(slime, component.CHealth).(component.Health)
+	if hp.Current+sp.HP != wounded || hp.Current-sp.HP > 1 {
+		t.Errorf("HP split %d/%d; want %d shared evenly", hp.Current, sp.HP, wounded)
+	}
+	if hp.Max != 10 || sp.MaxHP != 10 {
+		t.Errorf("max HP = %d (parent) and %d (copy); want both halved to 10", hp.Max, sp.MaxHP)
+	}
+	if s := w.Get(slime, component.CSplitter).(component.Splitter); s.Gen != 1 || sp.Gen != 1 || s.Pending {
+		t.Errorf("after split: parent %+v, copy gen %d; want both at gen 1, nothing pending", s, sp.Gen)
+	}
+	if dx, dy := sp.Pos.X-6, sp.Pos.Y-5; dx < -1 || dx > 1 || dy < -1 || dy > 1 || sp.Pos == (component.Position{X: 5, Y: 5}) {
+		t.Errorf("copy placed at %v; want a free tile beside the slime", sp.Pos)
+	}
+	if again := CollectSplits(w, gmap); len(again) != 0 {
+		t.Errorf("split collected twice: %v", again)
+	}
+}
+
+func TestSplitStopsAtGenerationCap(t *testing.T) {
+	w, gmap, player := newAIWorld(5, 5)
+	slime := addEnemy(w, 6, 5, component.BehaviorChase, 6)
+	w.Add(slime, component.Splitter{Gen: 2, MaxGen: 2})
+
+	Attack(w, rand.New(rand.NewSource(1)), player, slime)
+	if splits := CollectSplits(w, gmap); len(splits) != 0 {
+		t.Errorf("slime at its generation cap split anyway: %v", splits)
+	}
+}
+
+func TestSplitNeedsFreeTile(t *testing.T) {
+	w, gmap, player := newAIWorld(5, 5)
+	slime := addEnemy(w, 6, 5, component.BehaviorChase, 6)
+	w.Add(slime, component.Splitter{MaxGen: 2})
+	// Wall in every neighbour of the slime except the player's tile.
+	for y := 4; y <= 6; y++ {
+		for x := 5; x <= 7; x++ {
+			if (x != 6 || y != 5) && (x != 5 || y != 5) {
+				gmap.Set(x, y, gamemap.MakeWall())
+			}
+		}
+	}
+
+	res := Attack(w, rand.New(rand.NewSource(1)), player, slime)
+	if splits := CollectSplits(w, gmap); len(splits) != 0 {
+		t.Fatalf("hemmed-in slime split: %v", splits)
+	}
+	if hp := w.Get(slime, component.CHealth).(component.Health); hp.Current != 20-res.Damage || hp.Max != 20 {
+		t.Errorf("hemmed-in slime HP = %d/%d; want it whole at %d/20", hp.Current, hp.Max, 20-res.Damage)
+	}
+}
+
+func TestKilledSplitterDoesNotSplit(t *testing.T) {
+	w, gmap, player := newAIWorld(5, 5)
+	slime := addEnemy(w, 6, 5, component.BehaviorChase, 6)
+	w.Add(slime, component.Splitter{MaxGen: 2})
+	w.Add(slime, component.Health{Current: 1, Max: 20})
+
+	if res := Attack(w, rand.New(rand.NewSource(1)), player, slime); !res.Killed {
+		t.Fatal("expected the hit to kill the slime")
+	}
+	if splits := CollectSplits(w, gmap); len(splits) != 0 {
+		t.Errorf("dead slime split: %v", splits)
+	}
+}
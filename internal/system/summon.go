@@ -0,0 +1,85 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+)
+
+// Summon is a minion call made by a summoner during ProcessAI. The caller
+// creates the minion with factory.NewMinion.
+type Summon struct {
+	Summoner      ecs.EntityID
+	SummonerGlyph string
+	Minion        string // minion glyph
+	Pos           component.Position
+}
+
+// summonMinion spends summoner id's turn calling a minion onto a free tile
+// beside it when its cooldown is up and it is below its cap. Dead minions are
+// forgotten first so they no longer count toward the cap. Returns false, and
+// lets the summoner act normally, when it does not summon.
+func summonMinion(w *ecs.World, gmap *gamemap.GameMap, id ecs.EntityID, pos component.Position) bool {
+	sc := w.Get(id, component.CSummoner)
+	if sc == nil {
+		return false
+	}
+	s := sc.(component.Summoner)
+	var alive []ecs.EntityID
+	for _, m := range s.Minions {
+		if w.Alive(m) {
+			alive = append(alive, m)
+		}
+	}
+	s.Minions = alive
+	defer func() { w.Add(id, s) }()
+
+	if s.Cooldown > 0 {
+		s.Cooldown--
+		return false
+	}
+	if s.Pending || len(s.Minions) >= s.Cap {
+		return false
+	}
+	x, y, ok := FindDeploySpot(w, gmap, pos)
+	if !ok || summonPendingAt(w, x, y) {
+		return false
+	}
+	s.Pending = true
+	s.At = component.Position{X: x, Y: y}
+	s.Cooldown = s.Every
+	return true
+}
+
+// summonPendingAt reports whether any summoner has a minion pending at (x, y).
+func summonPendingAt(w *ecs.World, x, y int) bool {
+	for _, id := range w.Query(component.CSummoner) {
+		s := w.Get(id, component.CSummoner).(component.Summoner)
+		if s.Pending && s.At.X == x && s.At.Y == y {
+			return true
+		}
+	}
+	return false
+}
+
+// CollectSummons returns every summon made since the last call and clears it
+// from its summoner. A summoner killed before collection leaves nothing
+// behind, since its component is destroyed with it.
+func CollectSummons(w *ecs.World) []Summon {
+	var out []Summon
+	for _, id := range w.Query(component.CSummoner) {
+		s := w.Get(id, component.CSummoner).(component.Summoner)
+		if !s.Pending {
+			continue
+		}
+		out = append(out, Summon{
+			Summoner:      id,
+			SummonerGlyph: enemyGlyph(w, id),
+			Minion:        s.Minion,
+			Pos:           s.At,
+		})
+		s.Pending = false
+		w.Add(id, s)
+	}
+	return out
+}
@@ -0,0 +1,85 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"math/rand"
+	"testing"
+)
+
+func addSummoner(w *ecs.World, x, y, cap, every int) ecs.EntityID {
+	id := addEnemy(w, x, y, component.BehaviorStationary, 8)
+	w.Add(id, component.Summoner{Minion: "🐜", Cap: cap, Every: every})
+	return id
+}
+
+// runAI runs one ProcessAI turn and creates any summoned minions, as the
+// game loop does.
+func runAI(w *ecs.World, player ecs.EntityID) []Summon {
+	gmap := openMap(20, 20)
+	ProcessAI(w, gmap, []ecs.EntityID{player}, rand.New(rand.NewSource(1)))
+	summons := CollectSummons(w)
+	for _, sm := range summons {
+		m := addEnemy(w, sm.Pos.X, sm.Pos.Y, component.BehaviorStationary, 8)
+		s := w.Get(sm.Summoner, component.CSummoner).(component.Summoner)
+		s.Minions = append(s.Minions, m)
+		w.Add(sm.Summoner, s)
+	}
+	return summons
+}
+
+func TestSummonerSummonsOnCadenceUpToCap(t *testing.T) {
+	w, _, player := newAIWorld(2, 2)
+	summoner := addSummoner(w, 6, 6, 2, 2)
+
+	var got []int
+	for turn := range 8 {
+		if len(runAI(w, player)) > 0 {
+			got = append(got, turn)
+		}
+	}
+	// Summons on turn 0, then every third turn (two cooldown turns between)
+	// until the cap of two is reached.
+	if len(got) != 2 || got[0] != 0 || got[1] != 3 {
+		t.Errorf("summon turns = %v; want [0 3]", got)
+	}
+	s := w.Get(summoner, component.CSummoner).(component.Summoner)
+	if len(s.Minions) != 2 {
+		t.Errorf("minions = %d; want 2", len(s.Minions))
+	}
+}
+
+func TestSummonerReplacesDeadMinion(t *testing.T) {
+	w, _, player := newAIWorld(2, 2)
+	summoner := addSummoner(w, 6, 6, 1, 0)
+
+	if len(runAI(w, player)) != 1 {
+		t.Fatal("expected a first summon")
+	}
+	if len(runAI(w, player)) != 0 {
+		t.Fatal("summoner at cap should not summon")
+	}
+	s := w.Get(summoner, component.CSummoner).(component.Summoner)
+	w.DestroyEntity(s.Minions[0])
+	if len(runAI(w, player)) != 1 {
+		t.Error("a dead minion should free a slot under the cap")
+	}
+}
+
+func TestSummonerIdleWithoutTarget(t *testing.T) {
+	w, _, player := newAIWorld(2, 2)
+	addSummoner(w, 18, 18, 3, 0)
+	if n := len(runAI(w, player)); n != 0 {
+		t.Errorf("summoner out of sight range summoned %d minions", n)
+	}
+}
+
+func TestCollectSummonsDropsKilledSummoner(t *testing.T) {
+	w, gmap, player := newAIWorld(2, 2)
+	summoner := addSummoner(w, 6, 6, 3, 0)
+	ProcessAI(w, gmap, []ecs.EntityID{player}, rand.New(rand.NewSource(1)))
+	w.DestroyEntity(summoner)
+	if got := CollectSummons(w); len(got) != 0 {
+		t.Errorf("CollectSummons = %v; want none after the summoner died", got)
+	}
+}
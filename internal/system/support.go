@@ -0,0 +1,60 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+)
+
+// Support caster tuning.
+const (
+	SupportRadius    = 4 // tiles within which a support caster buffs allies
+	SupportBuffATK   = 2
+	SupportBuffDEF   = 2
+	SupportBuffTurns = 4
+	SupportKeepAway  = 2 // backs away from a player this close when it has no one to buff
+)
+
+// supportMove runs one turn for a support caster: it empowers every hostile
+// ally within SupportRadius and line of sight that is not already buffed. With
+// no one to buff it backs away from a player who gets too close. It never
+// attacks.
+func supportMove(w *ecs.World, gmap *gamemap.GameMap, id ecs.EntityID, pos, playerPos component.Position) {
+	if BuffAllies(w, gmap, id) > 0 {
+		return
+	}
+	dx, dy := playerPos.X-pos.X, playerPos.Y-pos.Y
+	if dx*dx+dy*dy <= SupportKeepAway*SupportKeepAway {
+		fleeMove(w, gmap, id, pos, playerPos)
+	}
+}
+
+// BuffAllies applies SupportBuffATK and SupportBuffDEF for SupportBuffTurns to
+// each non-allied AI entity within SupportRadius of caster and in its line of
+// sight, skipping the caster itself and anyone still carrying both buffs.
+// Returns how many entities were buffed.
+func BuffAllies(w *ecs.World, gmap *gamemap.GameMap, caster ecs.EntityID) int {
+	pc := w.Get(caster, component.CPosition)
+	if pc == nil {
+		return 0
+	}
+	pos := pc.(component.Position)
+	n := 0
+	for _, id := range w.Query(component.CAI, component.CPosition) {
+		if id == caster || w.Get(id, component.CAI).(component.AI).Behavior == component.BehaviorAlly {
+			continue
+		}
+		if HasEffect(w, id, component.EffectAttackBoost) && HasEffect(w, id, component.EffectDefenseBoost) {
+			continue
+		}
+		epos := w.Get(id, component.CPosition).(component.Position)
+		dx, dy := epos.X-pos.X, epos.Y-pos.Y
+		if dx*dx+dy*dy > SupportRadius*SupportRadius || !HasLineOfSight(gmap, pos.X, pos.Y, epos.X, epos.Y) {
+			continue
+		}
+		ApplyEffect(w, id, component.ActiveEffect{Kind: component.EffectAttackBoost, Magnitude: SupportBuffATK, TurnsRemaining: SupportBuffTurns})
+		ApplyEffect(w, id, component.ActiveEffect{Kind: component.EffectDefenseBoost, Magnitude: SupportBuffDEF, TurnsRemaining: SupportBuffTurns})
+		n++
+	}
+	return n
+}
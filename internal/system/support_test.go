@@ -0,0 +1,54 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"math/rand"
+	"testing"
+)
+
+func TestSupportCasterBuffsAllyInsteadOfAttacking(t *testing.T) {
+	w, gmap, player := newAIWorld(5, 5)
+	caster := addEnemy(w, 6, 5, component.BehaviorSupport, 8)
+	ally := addEnemy(w, 8, 5, component.BehaviorStationary, 8)
+
+	rng := rand.New(rand.NewSource(1))
+	hits := ProcessAI(w, gmap, []ecs.EntityID{player}, rng)
+	if len(hits) != 0 {
+		t.Errorf("support caster should never attack; got %d hits", len(hits))
+	}
+	if GetAttackBonus(w, ally) != SupportBuffATK || GetDefenseBonus(w, ally) != SupportBuffDEF {
+		t.Errorf("ally bonuses = %d/%d; want %d/%d",
+			GetAttackBonus(w, ally), GetDefenseBonus(w, ally), SupportBuffATK, SupportBuffDEF)
+	}
+	if HasEffect(w, caster, component.EffectAttackBoost) {
+		t.Error("support caster should not buff itself")
+	}
+}
+
+func TestBuffAlliesSkipsOutOfRangeAndAllied(t *testing.T) {
+	w, gmap, _ := newAIWorld(0, 0)
+	caster := addEnemy(w, 5, 5, component.BehaviorSupport, 8)
+	far := addEnemy(w, 5, 5+SupportRadius+1, component.BehaviorChase, 8)
+	turret := addEnemy(w, 6, 5, component.BehaviorAlly, 8)
+
+	if n := BuffAllies(w, gmap, caster); n != 0 {
+		t.Errorf("BuffAllies = %d; want 0", n)
+	}
+	if HasEffect(w, far, component.EffectAttackBoost) || HasEffect(w, turret, component.EffectAttackBoost) {
+		t.Error("out-of-range enemies and player allies must not be buffed")
+	}
+}
+
+func TestSupportCasterBacksAwayWhenNothingToBuff(t *testing.T) {
+	w, gmap, player := newAIWorld(5, 5)
+	caster := addEnemy(w, 6, 5, component.BehaviorSupport, 8)
+
+	rng := rand.New(rand.NewSource(1))
+	if hits := ProcessAI(w, gmap, []ecs.EntityID{player}, rng); len(hits) != 0 {
+		t.Errorf("support caster should never attack; got %d hits", len(hits))
+	}
+	if pos := w.Get(caster, component.CPosition).(component.Position); pos.X != 7 {
+		t.Errorf("caster at %v; want it to step away to x=7", pos)
+	}
+}
@@ -0,0 +1,49 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+// ThreatLevel is a rough rating of how dangerous a fight with one enemy is.
+type ThreatLevel uint8
+
+const (
+	ThreatTrivial  ThreatLevel = iota // costs under a third of the player's HP
+	ThreatModerate                    // costs a third or more of the player's HP
+	ThreatDeadly                      // likely to kill the player
+)
+
+// String names the level for examine mode and the HUD.
+func (t ThreatLevel) String() string {
+	switch t {
+	case ThreatModerate:
+		return "Moderate"
+	case ThreatDeadly:
+		return "Deadly"
+	}
+	return "Trivial"
+}
+
+// AssessThreat rates enemyID against playerID by estimating the damage the
+// player takes while trading average hits until the enemy falls, and
+// comparing it to the player's current HP. ATK, DEF and active effects on
+// both sides count; dodges, specials and ranged attacks do not.
+func AssessThreat(w *ecs.World, playerID, enemyID ecs.EntityID) ThreatLevel {
+	php, ok1 := w.Get(playerID, component.CHealth).(component.Health)
+	ehp, ok2 := w.Get(enemyID, component.CHealth).(component.Health)
+	if !ok1 || !ok2 || w.Get(playerID, component.CCombat) == nil || w.Get(enemyID, component.CCombat) == nil {
+		return ThreatTrivial
+	}
+	dealt := baseDamage(w, playerID, enemyID) + 1 // average of the 0–2 roll
+	taken := baseDamage(w, enemyID, playerID) + 1
+	rounds := (ehp.Current + dealt - 1) / dealt
+	cost := taken * rounds
+	switch {
+	case cost >= php.Current:
+		return ThreatDeadly
+	case cost*3 >= php.Current:
+		return ThreatModerate
+	}
+	return ThreatTrivial
+}
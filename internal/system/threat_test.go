@@ -0,0 +1,43 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"testing"
+)
+
+func TestAssessThreatLevels(t *testing.T) {
+	// Player: 30 HP, ATK 3, DEF 1 (newAIWorld).
+	tests := []struct {
+		name    string
+		atk, hp int
+		want    ThreatLevel
+	}{
+		{"weak", 1, 2, ThreatTrivial},         // 2 dmg/round × 1 round = 2
+		{"even", 6, 8, ThreatModerate},        // 6 dmg/round × 2 rounds = 12 ≥ 10
+		{"brute", 10, 40, ThreatDeadly},       // 10 dmg/round × 10 rounds ≥ 30
+		{"glass cannon", 40, 1, ThreatDeadly}, // one hit of 40 still kills
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w, _, player := newAIWorld(5, 5)
+			e := addEnemy(w, 6, 5, component.BehaviorChase, 8)
+			w.Add(e, component.Combat{Attack: tc.atk})
+			w.Add(e, component.Health{Current: tc.hp, Max: tc.hp})
+			if got := AssessThreat(w, player, e); got != tc.want {
+				t.Errorf("AssessThreat = %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAssessThreatFollowsPlayerHP(t *testing.T) {
+	w, _, player := newAIWorld(5, 5)
+	e := addEnemy(w, 6, 5, component.BehaviorChase, 8) // ATK 4, 20 HP: 4 × 5 = 20
+	if got := AssessThreat(w, player, e); got != ThreatModerate {
+		t.Fatalf("at full HP: %v; want Moderate", got)
+	}
+	w.Add(player, component.Health{Current: 12, Max: 30})
+	if got := AssessThreat(w, player, e); got != ThreatDeadly {
+		t.Errorf("at 12 HP: %v; want Deadly", got)
+	}
+}
@@ -0,0 +1,144 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+)
+
+const (
+	// MaxPlayerTraps caps how many traps one player can have armed at once;
+	// placing another removes that player's oldest trap.
+	MaxPlayerTraps = 3
+	// SpikeTrapDamage is the damage a spike trap deals, ignoring defense.
+	SpikeTrapDamage = 8
+	// SnareTrapDamage is the damage a snare trap deals, ignoring defense.
+	SnareTrapDamage = 2
+	// SnareRootTurns is how long a snare roots its victim.
+	SnareRootTurns = 3
+)
+
+// TrapTrigger reports one trap sprung by an enemy.
+type TrapTrigger struct {
+	Owner       ecs.EntityID // player that placed the trap
+	Kind        component.TrapKind
+	VictimGlyph string
+	Pos         component.Position
+	Loot        component.Loot
+	Damage      int
+	Killed      bool
+}
+
+// PlaceTrap arms a hidden trap of the given kind at (x, y), owned by owner.
+// The tile must be walkable and free of other traps. If owner already has
+// MaxPlayerTraps armed, the oldest is removed first.
+func PlaceTrap(w *ecs.World, gmap *gamemap.GameMap, owner ecs.EntityID, x, y int, kind component.TrapKind) (ecs.EntityID, bool) {
+	if !CanPlaceTrap(w, gmap, x, y) {
+		return ecs.NilEntity, false
+	}
+	var owned []ecs.EntityID
+	for _, id := range w.Query(component.CTrap, component.CPosition) {
+		if w.Get(id, component.CTrap).(component.Trap).Owner == owner {
+			owned = append(owned, id)
+		}
+	}
+	for len(owned) >= MaxPlayerTraps {
+		oldest := 0
+		for i, id := range owned {
+			if id < owned[oldest] {
+				oldest = i
+			}
+		}
+		w.DestroyEntity(owned[oldest])
+		owned = append(owned[:oldest], owned[oldest+1:]...)
+	}
+	id := w.CreateEntity()
+	w.Add(id, component.Position{X: x, Y: y})
+	w.Add(id, component.Trap{Owner: owner, Kind: kind})
+	return id, true
+}
+
+// CanPlaceTrap reports whether PlaceTrap would arm a trap at (x, y): the tile
+// is walkable and holds no trap yet.
+func CanPlaceTrap(w *ecs.World, gmap *gamemap.GameMap, x, y int) bool {
+	if !gmap.IsWalkable(x, y) {
+		return false
+	}
+	for _, id := range w.Query(component.CTrap, component.CPosition) {
+		if p := w.Get(id, component.CPosition).(component.Position); p.X == x && p.Y == y {
+			return false
+		}
+	}
+	return true
+}
+
+// springTrap fires an armed trap under enemy id, if any, and records the
+// outcome on the trap for CollectSprungTraps.
+func springTrap(w *ecs.World, id ecs.EntityID) {
+	pc := w.Get(id, component.CPosition)
+	if pc == nil {
+		return
+	}
+	pos := pc.(component.Position)
+	for _, tid := range w.Query(component.CTrap, component.CPosition) {
+		trap := w.Get(tid, component.CTrap).(component.Trap)
+		if trap.Sprung || w.Get(tid, component.CPosition).(component.Position) != pos {
+			continue
+		}
+		trap.Sprung = true
+		trap.VictimGlyph = enemyGlyph(w, id)
+		if lc := w.Get(id, component.CLoot); lc != nil {
+			trap.Loot = lc.(component.Loot)
+		}
+		trap.Damage = SpikeTrapDamage
+		if trap.Kind == component.TrapSnare {
+			trap.Damage = SnareTrapDamage
+			ApplyEffect(w, id, component.ActiveEffect{
+				Kind: component.EffectRoot, Magnitude: 1, TurnsRemaining: SnareRootTurns,
+			})
+		}
+		if hc := w.Get(id, component.CHealth); hc != nil {
+			hp := hc.(component.Health)
+			hp.Current -= trap.Damage
+			w.Add(id, hp)
+			if hp.Current <= 0 {
+				trap.Killed = true
+				w.DestroyEntity(id)
+			}
+		}
+		w.Add(tid, trap)
+		return
+	}
+}
+
+// CollectSprungTraps returns a report for every trap sprung since the last
+// call and removes those traps. Call it right after ProcessAI.
+func CollectSprungTraps(w *ecs.World) []TrapTrigger {
+	var out []TrapTrigger
+	for _, tid := range w.Query(component.CTrap, component.CPosition) {
+		trap := w.Get(tid, component.CTrap).(component.Trap)
+		if !trap.Sprung {
+			continue
+		}
+		out = append(out, TrapTrigger{
+			Owner:       trap.Owner,
+			Kind:        trap.Kind,
+			VictimGlyph: trap.VictimGlyph,
+			Pos:         w.Get(tid, component.CPosition).(component.Position),
+			Loot:        trap.Loot,
+			Damage:      trap.Damage,
+			Killed:      trap.Killed,
+		})
+		w.DestroyEntity(tid)
+	}
+	return out
+}
+
+// ClearTraps removes every trap placed by owner.
+func ClearTraps(w *ecs.World, owner ecs.EntityID) {
+	for _, id := range w.Query(component.CTrap) {
+		if w.Get(id, component.CTrap).(component.Trap).Owner == owner {
+			w.DestroyEntity(id)
+		}
+	}
+}
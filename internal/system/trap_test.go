@@ -0,0 +1,91 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"math/rand"
+	"testing"
+)
+
+func TestPlaceTrapCapsOwnerTraps(t *testing.T) {
+	w, gmap, player := newAIWorld(5, 5)
+	var first ecs.EntityID
+	for i := range MaxPlayerTraps + 1 {
+		id, ok := PlaceTrap(w, gmap, player, 2+i, 2, component.TrapSpike)
+		if !ok {
+			t.Fatalf("PlaceTrap %d failed", i)
+		}
+		if i == 0 {
+			first = id
+		}
+	}
+	if n := len(w.Query(component.CTrap)); n != MaxPlayerTraps {
+		t.Errorf("armed traps = %d; want cap %d", n, MaxPlayerTraps)
+	}
+	if w.Alive(first) {
+		t.Error("the oldest trap should be removed when the cap is exceeded")
+	}
+	if _, ok := PlaceTrap(w, gmap, player, 5, 2, component.TrapSpike); ok {
+		t.Error("PlaceTrap should refuse a tile that already holds a trap")
+	}
+}
+
+func TestSpikeTrapSprungByEnteringEnemy(t *testing.T) {
+	w, gmap, player := newAIWorld(2, 5)
+	PlaceTrap(w, gmap, player, 5, 5, component.TrapSpike)
+	e := addEnemy(w, 6, 5, component.BehaviorChase, 10)
+	w.Add(e, component.Health{Current: SpikeTrapDamage, Max: 20})
+
+	ProcessAI(w, gmap, []ecs.EntityID{player}, rand.New(rand.NewSource(0)))
+
+	if w.Alive(e) {
+		t.Fatal("spike trap should kill an enemy with HP <= SpikeTrapDamage")
+	}
+	triggers := CollectSprungTraps(w)
+	if len(triggers) != 1 {
+		t.Fatalf("triggers = %d; want 1", len(triggers))
+	}
+	tr := triggers[0]
+	if tr.Owner != player || !tr.Killed || tr.Damage != SpikeTrapDamage || tr.Pos != (component.Position{X: 5, Y: 5}) {
+		t.Errorf("unexpected trigger %+v", tr)
+	}
+	if n := len(w.Query(component.CTrap)); n != 0 {
+		t.Errorf("sprung trap should be removed; %d remain", n)
+	}
+}
+
+func TestSnareTrapRootsEnemy(t *testing.T) {
+	w, gmap, player := newAIWorld(2, 5)
+	PlaceTrap(w, gmap, player, 5, 5, component.TrapSnare)
+	e := addEnemy(w, 6, 5, component.BehaviorChase, 10)
+	rng := rand.New(rand.NewSource(0))
+
+	ProcessAI(w, gmap, []ecs.EntityID{player}, rng)
+	CollectSprungTraps(w)
+	if !HasEffect(w, e, component.EffectRoot) {
+		t.Fatal("snare should root the enemy")
+	}
+	ProcessAI(w, gmap, []ecs.EntityID{player}, rng)
+	if pos := w.Get(e, component.CPosition).(component.Position); pos.X != 5 {
+		t.Errorf("rooted enemy moved to x=%d; want it held at 5", pos.X)
+	}
+}
+
+func TestTrapIgnoresPlayers(t *testing.T) {
+	w, gmap, owner := newAIWorld(2, 5)
+	ally := w.CreateEntity()
+	w.Add(ally, component.Position{X: 6, Y: 5})
+	w.Add(ally, component.TagPlayer{})
+	w.Add(ally, component.Health{Current: 30, Max: 30})
+	PlaceTrap(w, gmap, owner, 5, 5, component.TrapSpike)
+
+	TryMove(w, gmap, ally, -1, 0) // the ally walks onto the trap
+	ProcessAI(w, gmap, []ecs.EntityID{owner, ally}, rand.New(rand.NewSource(0)))
+
+	if n := len(CollectSprungTraps(w)); n != 0 {
+		t.Errorf("a player set off %d traps; want 0", n)
+	}
+	if hp := w.Get(ally, component.CHealth).(component.Health); hp.Current != 30 {
+		t.Errorf("ally HP = %d; want 30", hp.Current)
+	}
+}
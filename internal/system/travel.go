@@ -0,0 +1,38 @@
+package system
+
+import "emoji-roguelike/internal/gamemap"
+
+// orthogonal lists the four cardinal step offsets.
+var orthogonal = [4][2]int{{0, -1}, {0, 1}, {1, 0}, {-1, 0}}
+
+// IsCorridor reports whether (x, y) is walkable with exactly two walkable
+// orthogonal neighbours — a stretch or bend of hallway rather than a room or
+// junction.
+func IsCorridor(gmap *gamemap.GameMap, x, y int) bool {
+	if !gmap.IsWalkable(x, y) {
+		return false
+	}
+	open := 0
+	for _, d := range orthogonal {
+		if gmap.IsWalkable(x+d[0], y+d[1]) {
+			open++
+		}
+	}
+	return open == 2
+}
+
+// CorridorNext returns the step that continues along the corridor at (x, y)
+// for a traveller who arrived from (fromX, fromY). ok is false when (x, y)
+// is not a corridor.
+func CorridorNext(gmap *gamemap.GameMap, x, y, fromX, fromY int) (dx, dy int, ok bool) {
+	if !IsCorridor(gmap, x, y) {
+		return 0, 0, false
+	}
+	for _, d := range orthogonal {
+		nx, ny := x+d[0], y+d[1]
+		if gmap.IsWalkable(nx, ny) && (nx != fromX || ny != fromY) {
+			return d[0], d[1], true
+		}
+	}
+	return 0, 0, false
+}
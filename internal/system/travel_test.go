@@ -0,0 +1,55 @@
+package system
+
+import (
+	"emoji-roguelike/internal/gamemap"
+	"testing"
+)
+
+// corridorMap carves an L-shaped hallway from (1,1) east to (5,1) and south
+// to (5,4), where it opens into a 3×3 room.
+func corridorMap() *gamemap.GameMap {
+	gmap := gamemap.New(10, 10)
+	for x := 1; x <= 5; x++ {
+		gmap.Set(x, 1, gamemap.MakeFloor())
+	}
+	for y := 2; y <= 4; y++ {
+		gmap.Set(5, y, gamemap.MakeFloor())
+	}
+	for y := 5; y <= 7; y++ {
+		for x := 4; x <= 6; x++ {
+			gmap.Set(x, y, gamemap.MakeFloor())
+		}
+	}
+	return gmap
+}
+
+func TestIsCorridor(t *testing.T) {
+	gmap := corridorMap()
+	for _, c := range []struct {
+		x, y int
+		want bool
+	}{
+		{3, 1, true},  // straight stretch
+		{5, 1, true},  // bend
+		{1, 1, false}, // dead end
+		{5, 5, false}, // room entrance
+		{0, 0, false}, // wall
+	} {
+		if got := IsCorridor(gmap, c.x, c.y); got != c.want {
+			t.Errorf("IsCorridor(%d,%d) = %v; want %v", c.x, c.y, got, c.want)
+		}
+	}
+}
+
+func TestCorridorNextFollowsBend(t *testing.T) {
+	gmap := corridorMap()
+	if dx, dy, ok := CorridorNext(gmap, 5, 1, 4, 1); !ok || dx != 0 || dy != 1 {
+		t.Errorf("CorridorNext at the bend = (%d,%d,%v); want (0,1,true)", dx, dy, ok)
+	}
+	if dx, dy, ok := CorridorNext(gmap, 3, 1, 4, 1); !ok || dx != -1 || dy != 0 {
+		t.Errorf("CorridorNext heading west = (%d,%d,%v); want (-1,0,true)", dx, dy, ok)
+	}
+	if _, _, ok := CorridorNext(gmap, 5, 5, 5, 4); ok {
+		t.Error("CorridorNext in a room entrance should report ok=false")
+	}
+}
@@ -0,0 +1,116 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+	"math"
+	"math/rand"
+)
+
+// TurretShot holds the outcome of one shot fired by an allied turret.
+type TurretShot struct {
+	TurretID    ecs.EntityID
+	Owner       ecs.EntityID // player entity that deployed the turret
+	TargetGlyph string
+	TargetPos   component.Position
+	Loot        component.Loot // target's loot table, captured before the shot
+	Damage      int
+	Killed      bool
+}
+
+// ProcessTurrets fires every allied turret at the nearest visible enemy within
+// its sight range, then ages it by one turn. Shots are ranged attacks, so
+// enemies in cover take reduced damage. Turrets that run out of ammo or
+// lifespan are destroyed. Returns one TurretShot per shot fired.
+func ProcessTurrets(w *ecs.World, gmap *gamemap.GameMap, rng *rand.Rand) []TurretShot {
+	var shots []TurretShot
+	for _, id := range w.Query(component.CTurret, component.CAI, component.CPosition) {
+		turret := w.Get(id, component.CTurret).(component.Turret)
+		aiComp := w.Get(id, component.CAI).(component.AI)
+		pos := w.Get(id, component.CPosition).(component.Position)
+
+		if turret.Ammo > 0 {
+			if target, ok := nearestHostile(w, gmap, pos, aiComp.SightRange); ok {
+				shot := TurretShot{
+					TurretID:    id,
+					Owner:       turret.Owner,
+					TargetGlyph: enemyGlyph(w, target),
+					TargetPos:   w.Get(target, component.CPosition).(component.Position),
+				}
+				if lc := w.Get(target, component.CLoot); lc != nil {
+					shot.Loot = lc.(component.Loot)
+				}
+				res := RangedAttack(w, gmap, rng, id, target)
+				shot.Damage = res.Damage
+				shot.Killed = res.Killed
+				shots = append(shots, shot)
+				turret.Ammo--
+			}
+		}
+
+		turret.TurnsLeft--
+		if turret.Ammo <= 0 || turret.TurnsLeft <= 0 {
+			w.DestroyEntity(id)
+			continue
+		}
+		w.Add(id, turret)
+	}
+	return shots
+}
+
+// nearestHostile returns the closest non-allied AI entity with health within
+// rangeLimit of pos and in line of sight, so turrets never fire through walls.
+// Ties are broken by the lower entity ID so the choice does not depend on
+// Query's map iteration order.
+func nearestHostile(w *ecs.World, gmap *gamemap.GameMap, pos component.Position, rangeLimit int) (ecs.EntityID, bool) {
+	best := ecs.NilEntity
+	bestDist := math.MaxFloat64
+	for _, id := range w.Query(component.CAI, component.CHealth, component.CPosition) {
+		if w.Get(id, component.CAI).(component.AI).Behavior == component.BehaviorAlly {
+			continue
+		}
+		epos := w.Get(id, component.CPosition).(component.Position)
+		dx := float64(epos.X - pos.X)
+		dy := float64(epos.Y - pos.Y)
+		dist := math.Sqrt(dx*dx + dy*dy)
+		if dist > float64(rangeLimit) || !HasLineOfSight(gmap, pos.X, pos.Y, epos.X, epos.Y) {
+			continue
+		}
+		if dist < bestDist || (dist == bestDist && id < best) {
+			best = id
+			bestDist = dist
+		}
+	}
+	return best, best != ecs.NilEntity
+}
+
+// ClearTurrets destroys every turret deployed by owner.
+func ClearTurrets(w *ecs.World, owner ecs.EntityID) {
+	for _, id := range w.Query(component.CTurret) {
+		if w.Get(id, component.CTurret).(component.Turret).Owner == owner {
+			w.DestroyEntity(id)
+		}
+	}
+}
+
+// FindDeploySpot returns the first walkable tile adjacent to pos that holds no
+// blocking entity, furniture, NPC or existing turret. Orthogonal neighbours are
+// tried before diagonal ones.
+func FindDeploySpot(w *ecs.World, gmap *gamemap.GameMap, pos component.Position) (int, int, bool) {
+	offsets := [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}, {1, 1}, {-1, 1}, {1, -1}, {-1, -1}}
+	occupied := make(map[[2]int]bool)
+	for _, ct := range []ecs.ComponentType{component.CTagBlocking, component.CFurniture, component.CNPC, component.CTurret} {
+		for _, id := range w.Query(ct, component.CPosition) {
+			p := w.Get(id, component.CPosition).(component.Position)
+			occupied[[2]int{p.X, p.Y}] = true
+		}
+	}
+	for _, o := range offsets {
+		x, y := pos.X+o[0], pos.Y+o[1]
+		if gmap.IsWalkable(x, y) && !occupied[[2]int{x, y}] {
+			return x, y, true
+		}
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,136 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+	"math/rand"
+	"testing"
+)
+
+// addTurret adds an allied turret owned by owner at (x, y).
+func addTurret(w *ecs.World, owner ecs.EntityID, x, y, ammo, turns int) ecs.EntityID {
+	id := w.CreateEntity()
+	w.Add(id, component.Position{X: x, Y: y})
+	w.Add(id, component.AI{Behavior: component.BehaviorAlly, SightRange: 6})
+	w.Add(id, component.Combat{Attack: 5})
+	w.Add(id, component.Turret{Owner: owner, Ammo: ammo, TurnsLeft: turns})
+	return id
+}
+
+func TestTurretShootsEnemyInRange(t *testing.T) {
+	w, gmap, player := newAIWorld(2, 2)
+	turret := addTurret(w, player, 3, 2, 8, 15)
+	enemy := addEnemy(w, 7, 2, component.BehaviorStationary, 5)
+
+	shots := ProcessTurrets(w, gmap, rand.New(rand.NewSource(42)))
+	if len(shots) != 1 {
+		t.Fatalf("shots = %d; want 1", len(shots))
+	}
+	if shots[0].Owner != player || shots[0].TurretID != turret {
+		t.Errorf("shot attributed to turret %d/owner %d; want %d/%d", shots[0].TurretID, shots[0].Owner, turret, player)
+	}
+	if hp := w.Get(enemy, component.CHealth).(component.Health); hp.Current >= hp.Max {
+		t.Error("enemy took no damage from turret")
+	}
+	if ammo := w.Get(turret, component.CTurret).(component.Turret).Ammo; ammo != 7 {
+		t.Errorf("ammo after one shot = %d; want 7", ammo)
+	}
+}
+
+func TestTurretIgnoresOutOfRangeEnemy(t *testing.T) {
+	w, gmap, player := newAIWorld(2, 2)
+	turret := addTurret(w, player, 3, 2, 8, 15)
+	addEnemy(w, 15, 15, component.BehaviorStationary, 5)
+
+	if shots := ProcessTurrets(w, gmap, rand.New(rand.NewSource(42))); len(shots) != 0 {
+		t.Errorf("shots = %d; want 0", len(shots))
+	}
+	if ammo := w.Get(turret, component.CTurret).(component.Turret).Ammo; ammo != 8 {
+		t.Errorf("ammo spent without a target: got %d, want 8", ammo)
+	}
+}
+
+func TestTurretDoesNotShootThroughWalls(t *testing.T) {
+	w, gmap, player := newAIWorld(2, 2)
+	addTurret(w, player, 3, 2, 8, 15)
+	gmap.Set(5, 2, gamemap.MakeWall())
+	walled := addEnemy(w, 7, 2, component.BehaviorStationary, 5)
+	visible := addEnemy(w, 3, 7, component.BehaviorStationary, 5)
+
+	shots := ProcessTurrets(w, gmap, rand.New(rand.NewSource(42)))
+	if len(shots) != 1 {
+		t.Fatalf("shots = %d; want 1", len(shots))
+	}
+	if hp := w.Get(walled, component.CHealth).(component.Health); hp.Current != hp.Max {
+		t.Error("turret shot the nearer enemy through a wall")
+	}
+	if shots[0].TargetPos != w.Get(visible, component.CPosition).(component.Position) {
+		t.Errorf("turret shot at %+v; want the enemy in view at (3,7)", shots[0].TargetPos)
+	}
+}
+
+func TestTurretExpires(t *testing.T) {
+	cases := []struct {
+		name        string
+		ammo, turns int
+		withEnemy   bool
+	}{
+		{"lifespan", 8, 1, false},
+		{"ammo", 1, 15, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w, gmap, player := newAIWorld(2, 2)
+			turret := addTurret(w, player, 3, 2, tc.ammo, tc.turns)
+			if tc.withEnemy {
+				addEnemy(w, 6, 2, component.BehaviorStationary, 5)
+			}
+			ProcessTurrets(w, gmap, rand.New(rand.NewSource(42)))
+			if w.Alive(turret) {
+				t.Error("turret should be destroyed once spent")
+			}
+		})
+	}
+}
+
+func TestAIIgnoresAllyAndAllyDoesNotMove(t *testing.T) {
+	w, gmap, player := newAIWorld(5, 5)
+	turret := addTurret(w, player, 6, 5, 8, 15)
+
+	hits := ProcessAI(w, gmap, []ecs.EntityID{player}, rand.New(rand.NewSource(42)))
+	if len(hits) != 0 {
+		t.Errorf("ally turret attacked the player: %d hits", len(hits))
+	}
+	if pos := w.Get(turret, component.CPosition).(component.Position); pos.X != 6 || pos.Y != 5 {
+		t.Errorf("turret moved to (%d,%d); want (6,5)", pos.X, pos.Y)
+	}
+}
+
+func TestClearTurretsOnlyRemovesOwners(t *testing.T) {
+	w, _, player := newAIWorld(2, 2)
+	other := w.CreateEntity()
+	mine := addTurret(w, player, 3, 2, 8, 15)
+	theirs := addTurret(w, other, 4, 2, 8, 15)
+
+	ClearTurrets(w, player)
+
+	if w.Alive(mine) {
+		t.Error("owner's turret should be cleared")
+	}
+	if !w.Alive(theirs) {
+		t.Error("another player's turret should survive")
+	}
+}
+
+func TestFindDeploySpotSkipsOccupied(t *testing.T) {
+	w, gmap, player := newAIWorld(5, 5)
+	addEnemy(w, 6, 5, component.BehaviorStationary, 5) // first candidate (east) is taken
+	x, y, ok := FindDeploySpot(w, gmap, w.Get(player, component.CPosition).(component.Position))
+	if !ok {
+		t.Fatal("expected a free deploy spot on an open map")
+	}
+	if x == 6 && y == 5 {
+		t.Error("deploy spot must not overlap a blocking entity")
+	}
+}
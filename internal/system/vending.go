@@ -0,0 +1,32 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+)
+
+// TakeVendingSlot removes slot idx from vending machine id and returns it.
+// When the last slot sells, the machine's furniture is marked used and its
+// description says so. Returns false if the slot does not exist.
+func TakeVendingSlot(w *ecs.World, id ecs.EntityID, idx int) (component.VendingSlot, bool) {
+	vc := w.Get(id, component.CVending)
+	if vc == nil {
+		return component.VendingSlot{}, false
+	}
+	vm := vc.(component.VendingMachine)
+	if idx < 0 || idx >= len(vm.Stock) {
+		return component.VendingSlot{}, false
+	}
+	slot := vm.Stock[idx]
+	vm.Stock = append(vm.Stock[:idx:idx], vm.Stock[idx+1:]...)
+	w.Add(id, vm)
+	if len(vm.Stock) == 0 {
+		if fc := w.Get(id, component.CFurniture); fc != nil {
+			f := fc.(component.Furniture)
+			f.Used = true
+			f.Description = "Its shelves are empty."
+			w.Add(id, f)
+		}
+	}
+	return slot, true
+}
@@ -0,0 +1,35 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"testing"
+)
+
+func TestTakeVendingSlotEmptiesMachine(t *testing.T) {
+	w := ecs.NewWorld()
+	id := w.CreateEntity()
+	w.Add(id, component.Furniture{Name: "Vending Machine"})
+	w.Add(id, component.VendingMachine{Stock: []component.VendingSlot{
+		{Glyph: "🧪", Price: 25},
+		{Glyph: "💎", Price: 18},
+	}})
+
+	slot, ok := TakeVendingSlot(w, id, 1)
+	if !ok || slot.Glyph != "💎" {
+		t.Fatalf("TakeVendingSlot(1) = %v, %v; want 💎", slot, ok)
+	}
+	if w.Get(id, component.CFurniture).(component.Furniture).Used {
+		t.Error("machine with stock left must not be marked used")
+	}
+	if _, ok := TakeVendingSlot(w, id, 1); ok {
+		t.Error("taking a slot past the end of stock should fail")
+	}
+
+	if _, ok := TakeVendingSlot(w, id, 0); !ok {
+		t.Fatal("last slot should sell")
+	}
+	if !w.Get(id, component.CFurniture).(component.Furniture).Used {
+		t.Error("machine should be marked used once its stock runs out")
+	}
+}
@@ -0,0 +1,43 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/ecs"
+	"emoji-roguelike/internal/gamemap"
+)
+
+// WandRange is how far, in tiles, a wand reaches.
+const WandRange = 6
+
+// WandTarget returns the nearest hostile enemy that userID can see within
+// WandRange, the target every wand fires at.
+func WandTarget(w *ecs.World, gmap *gamemap.GameMap, userID ecs.EntityID) (ecs.EntityID, bool) {
+	pc := w.Get(userID, component.CPosition)
+	if pc == nil {
+		return ecs.NilEntity, false
+	}
+	return nearestHostile(w, gmap, pc.(component.Position), WandRange)
+}
+
+// Bolt deals dmg to defenderID as a ranged hit that ignores DEF and dodging.
+// Cover still reduces it, to a minimum of 1. The defender is destroyed if its
+// HP drops to 0 or below.
+func Bolt(w *ecs.World, gmap *gamemap.GameMap, defenderID ecs.EntityID, dmg int) AttackResult {
+	hc := w.Get(defenderID, component.CHealth)
+	if hc == nil {
+		return AttackResult{}
+	}
+	if pc := w.Get(defenderID, component.CPosition); pc != nil {
+		pos := pc.(component.Position)
+		dmg = max(dmg*(100-CoverBonus(gmap, pos.X, pos.Y))/100, 1)
+	}
+	hp := hc.(component.Health)
+	hp.Current -= dmg
+	w.Add(defenderID, hp)
+	result := AttackResult{Damage: dmg}
+	if hp.Current <= 0 {
+		result.Killed = true
+		w.DestroyEntity(defenderID)
+	}
+	return result
+}
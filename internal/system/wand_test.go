@@ -0,0 +1,50 @@
+package system
+
+import (
+	"emoji-roguelike/internal/component"
+	"emoji-roguelike/internal/gamemap"
+	"testing"
+)
+
+func TestWandTargetPicksNearestEnemyInRange(t *testing.T) {
+	w, gmap, player := newAIWorld(5, 5)
+	far := addEnemy(w, 5+WandRange, 5, component.BehaviorStationary, 5)
+	near := addEnemy(w, 8, 5, component.BehaviorStationary, 5)
+	if got, ok := WandTarget(w, gmap, player); !ok || got != near {
+		t.Errorf("WandTarget = %v, %v; want nearest enemy %v (not %v)", got, ok, near, far)
+	}
+}
+
+func TestWandTargetNoneInRange(t *testing.T) {
+	w, gmap, player := newAIWorld(2, 2)
+	addEnemy(w, 2+WandRange+1, 2, component.BehaviorStationary, 5)
+	if _, ok := WandTarget(w, gmap, player); ok {
+		t.Error("an enemy beyond WandRange should not be targeted")
+	}
+}
+
+func TestBoltIgnoresDefense(t *testing.T) {
+	w, gmap, _ := newAIWorld(2, 2)
+	enemy := addEnemy(w, 10, 10, component.BehaviorStationary, 5)
+	w.Add(enemy, component.Combat{Attack: 4, Defense: 50})
+	res := Bolt(w, gmap, enemy, 8)
+	if res.Damage != 8 || res.Killed {
+		t.Fatalf("Bolt = %+v; want 8 damage, no kill", res)
+	}
+	if hp := w.Get(enemy, component.CHealth).(component.Health); hp.Current != 12 {
+		t.Errorf("HP = %d; want 12", hp.Current)
+	}
+}
+
+func TestBoltReducedByCoverAndKills(t *testing.T) {
+	w, gmap, _ := newAIWorld(2, 2)
+	enemy := addEnemy(w, 10, 10, component.BehaviorStationary, 5)
+	gmap.Set(11, 10, gamemap.MakeWall())
+	want := max(8*(100-CoverBonus(gmap, 10, 10))/100, 1)
+	if res := Bolt(w, gmap, enemy, 8); res.Damage != want {
+		t.Errorf("damage in cover = %d; want %d", res.Damage, want)
+	}
+	if res := Bolt(w, gmap, enemy, 100); !res.Killed || w.Alive(enemy) {
+		t.Error("a lethal bolt should kill and destroy the enemy")
+	}
+}
@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"log/slog"
 	"net"
-	"os"
-	"sync"
 
 	"emoji-roguelike/internal/mud"
 
@@ -15,16 +13,19 @@ import (
 // HandleConnection manages a single telnet client connection through class
 // selection, session creation, and the game loop. It mirrors the SSH
 // handleSession flow in cmd/server/main.go.
-func HandleConnection(conn net.Conn, srv *mud.Server, logger *slog.Logger, termMu *sync.Mutex) {
+func HandleConnection(conn net.Conn, srv *mud.Server, logger *slog.Logger) {
 	remoteAddr := conn.RemoteAddr().String()
 	logger.Info("telnet connection", "remote", remoteAddr)
 
 	tty := NewTelnetTty(conn)
 
-	termMu.Lock()
-	_ = os.Setenv("TERM", "xterm-256color")
-	screen, err := tcell.NewTerminfoScreenFromTty(tty)
-	termMu.Unlock()
+	// Telnet has no TERM negotiation here; assume a modern xterm. Looking the
+	// entry up by name keeps screen setup independent of the process's $TERM.
+	ti, err := tcell.LookupTerminfo("xterm-256color")
+	var screen tcell.Screen
+	if err == nil {
+		screen, err = tcell.NewTerminfoScreenFromTtyTerminfo(tty, ti)
+	}
 	if err != nil {
 		logger.Error("telnet terminal setup failed", "remote", remoteAddr, "error", err)
 		fmt.Fprintf(conn, "Terminal setup failed: %v\r\n", err)
@@ -43,6 +44,11 @@ func HandleConnection(conn net.Conn, srv *mud.Server, logger *slog.Logger, termM
 	// Derive a display name from the remote address.
 	name := sanitizeTelnetName(remoteAddr)
 
+	if !mud.Title(screen) {
+		logger.Info("telnet disconnected at the title screen", "remote", remoteAddr)
+		return
+	}
+
 	cls, ok := mud.ClassSelect(screen)
 	if !ok {
 		logger.Info("telnet disconnected during class select", "remote", remoteAddr)
@@ -55,6 +61,7 @@ func HandleConnection(conn net.Conn, srv *mud.Server, logger *slog.Logger, termM
 	sess.FovRadius = cls.FOVRadius
 	sess.BaseMaxHP = cls.MaxHP
 	sess.RunLog.Class = cls.Name
+	sess.Disconnect = func() { conn.Close() }
 
 	if !srv.AddSession(sess) {
 		fmt.Fprintf(conn, "Server is full. Please try again later.\r\n")
@@ -1,16 +1,40 @@
 package main
 
 import (
+	"emoji-roguelike/assets"
 	"emoji-roguelike/internal/game"
+	"flag"
 	"fmt"
 	"os"
 )
 
 func main() {
+	density := flag.Float64("density", 1, "enemy density multiplier for new floors (must be above 0; see -sandbox)")
+	sandbox := flag.Bool("sandbox", false, "explore with no enemies")
+	ascii := flag.Bool("ascii", false, "draw the map with ASCII characters instead of emoji")
+	messages := flag.Int("messages", 50, "number of recent messages kept for the message log")
+	saveLog := flag.Bool("log", false, "save each run's full message log next to the run history")
+	flag.Parse()
+	if *density <= 0 {
+		fmt.Fprintln(os.Stderr, "error: -density must be above 0; use -sandbox for no enemies")
+		os.Exit(2)
+	}
+
+	if err := assets.ValidateFloorConfigs(); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
 	g, err := game.New()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
 	}
+	g.SetEnemyDensity(*density)
+	g.SetSandbox(*sandbox)
+	g.SetMessageLimit(*messages)
+	g.SetMessageLog(*saveLog)
+	if *ascii {
+		g.SetASCII(true)
+	}
 	g.Run()
 }